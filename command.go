@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/stefanowiczd/ddd-case-01/orchestrator"
+)
+
+// ErrUnknownCommand is returned when the command line doesn't name a known command
+var ErrUnknownCommand = errors.New("unknown command")
+
+const usage = `Usage: ddd-bank [command]
+
+Without a command the service is run.
+
+Commands:
+  snapshots rebuild --aggregate=<type>    replay the aggregates of the type from their events and store their snapshots
+  projections rebuild --projection=<name> [--dry-run] [--resume]
+                                          replay the events into the shadow table of the read model and swap it in,
+                                          the dry run prints the rows differing from the live read model instead
+`
+
+// SnapshotRebuilder rebuilds the snapshots of the aggregates of one type, i.e. accounts
+type SnapshotRebuilder interface {
+	// RebuildSnapshots replays every aggregate from its events and stores its snapshot, it returns the number of the aggregates snapshotted
+	RebuildSnapshots(ctx context.Context) (int, error)
+}
+
+// ProjectionRebuilder rebuilds a read model (projection) from the events, i.e. accounts
+type ProjectionRebuilder interface {
+	// Rebuild replays the events into the shadow table of the read model and swaps it in, or diffs it against the live one for the dry run
+	Rebuild(ctx context.Context, options orchestrator.RebuildOptions) (orchestrator.RebuildResult, error)
+}
+
+// Commands runs the maintenance commands given on the command line instead of the service
+type Commands struct {
+	snapshots   map[string]SnapshotRebuilder   // Snapshot rebuilders by the aggregate type
+	projections map[string]ProjectionRebuilder // Read model rebuilders by the read model name
+	output      io.Writer
+}
+
+// NewCommands creates the commands, snapshots holds the snapshot rebuilders by the aggregate type, i.e. account,
+// and projections holds the read model rebuilders by the read model name, i.e. accounts
+func NewCommands(snapshots map[string]SnapshotRebuilder, projections map[string]ProjectionRebuilder, output io.Writer) *Commands {
+	return &Commands{
+		snapshots:   snapshots,
+		projections: projections,
+		output:      output,
+	}
+}
+
+// Run runs the command named by the arguments, i.e. snapshots rebuild --aggregate=account
+func (c *Commands) Run(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		_, _ = fmt.Fprint(c.output, usage)
+		return fmt.Errorf("running %q: %w", strings.Join(args, " "), ErrUnknownCommand)
+	}
+
+	switch args[0] + " " + args[1] {
+	case "snapshots rebuild":
+		return c.rebuildSnapshots(ctx, args[2:])
+	case "projections rebuild":
+		return c.rebuildProjection(ctx, args[2:])
+	default:
+		_, _ = fmt.Fprint(c.output, usage)
+		return fmt.Errorf("running %q: %w", strings.Join(args[:2], " "), ErrUnknownCommand)
+	}
+}
+
+// rebuildSnapshots replays the aggregates of the type given by the aggregate flag and stores their snapshots
+func (c *Commands) rebuildSnapshots(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("snapshots rebuild", flag.ContinueOnError)
+	flags.SetOutput(c.output)
+	aggregate := flags.String("aggregate", "", "type of the aggregates to snapshot: "+strings.Join(sortedKeys(c.snapshots), ", "))
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parsing snapshots rebuild flags: %w", err)
+	}
+
+	rebuilder, ok := c.snapshots[*aggregate]
+	if !ok {
+		return fmt.Errorf("rebuilding snapshots of %q aggregate: %w", *aggregate, ErrUnknownCommand)
+	}
+
+	snapshotted, err := rebuilder.RebuildSnapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("rebuilding snapshots of %s aggregate: %w", *aggregate, err)
+	}
+
+	log.Printf("rebuilt snapshots of %d %s aggregates", snapshotted, *aggregate)
+
+	return nil
+}
+
+// rebuildProjection rebuilds the read model given by the projection flag, the dry run prints the rows of the rebuilt read model
+// which differ from the live one, the live row prefixed with - and the rebuilt one with +
+func (c *Commands) rebuildProjection(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("projections rebuild", flag.ContinueOnError)
+	flags.SetOutput(c.output)
+	projection := flags.String("projection", "", "read model to rebuild: "+strings.Join(sortedKeys(c.projections), ", "))
+	dryRun := flags.Bool("dry-run", false, "diff the rebuilt read model against the live one instead of swapping it in")
+	resume := flags.Bool("resume", false, "continue the interrupted rebuild from its checkpoint")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parsing projections rebuild flags: %w", err)
+	}
+
+	rebuilder, ok := c.projections[*projection]
+	if !ok {
+		return fmt.Errorf("rebuilding %q projection: %w", *projection, ErrUnknownCommand)
+	}
+
+	result, err := rebuilder.Rebuild(ctx, orchestrator.RebuildOptions{DryRun: *dryRun, Resume: *resume})
+	if err != nil {
+		return fmt.Errorf("rebuilding %s projection: %w", *projection, err)
+	}
+
+	if !*dryRun {
+		log.Printf("rebuilt %s projection from %d events", *projection, result.Replayed)
+		return nil
+	}
+
+	for _, diff := range result.Diff {
+		if diff.Live != "" {
+			_, _ = fmt.Fprintf(c.output, "- %s %s\n", diff.ID, diff.Live)
+		}
+
+		if diff.Rebuilt != "" {
+			_, _ = fmt.Fprintf(c.output, "+ %s %s\n", diff.ID, diff.Rebuilt)
+		}
+	}
+
+	log.Printf("rebuilt %s projection from %d events in its shadow table, %d rows differ from the live one", *projection, result.Replayed, len(result.Diff))
+
+	return nil
+}
+
+// sortedKeys returns the sorted names the commands are run for, i.e. the types of the aggregates with snapshots
+func sortedKeys[T any](commands map[string]T) []string {
+	keys := make([]string, 0, len(commands))
+	for key := range commands {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
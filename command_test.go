@@ -0,0 +1,233 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator"
+)
+
+// testSnapshotRebuilder counts the rebuilds of the snapshots
+type testSnapshotRebuilder struct {
+	rebuilds int
+	err      error
+}
+
+func (r *testSnapshotRebuilder) RebuildSnapshots(_ context.Context) (int, error) {
+	r.rebuilds++
+	return 1, r.err
+}
+
+// testProjectionRebuilder records the options of the read model rebuilds
+type testProjectionRebuilder struct {
+	options []orchestrator.RebuildOptions
+	diff    []eventdomain.ProjectionDiff
+	err     error
+}
+
+func (r *testProjectionRebuilder) Rebuild(_ context.Context, options orchestrator.RebuildOptions) (orchestrator.RebuildResult, error) {
+	r.options = append(r.options, options)
+	return orchestrator.RebuildResult{Replayed: 3, Diff: r.diff}, r.err
+}
+
+func TestCommands_Run(t *testing.T) {
+	type testCaseParams struct {
+		args []string
+		err  error
+	}
+
+	type testCaseExpected struct {
+		rebuilds int
+		err      error
+		wantErr  bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should rebuild snapshots of the aggregate",
+			params: testCaseParams{
+				args: []string{"snapshots", "rebuild", "--aggregate=account"},
+			},
+			expected: testCaseExpected{
+				rebuilds: 1,
+			},
+		},
+		{
+			name: "shouldn't rebuild snapshots - rebuild failed",
+			params: testCaseParams{
+				args: []string{"snapshots", "rebuild", "--aggregate=account"},
+				err:  errors.New("db error"),
+			},
+			expected: testCaseExpected{
+				rebuilds: 1,
+				wantErr:  true,
+			},
+		},
+		{
+			name: "shouldn't rebuild snapshots - unknown aggregate",
+			params: testCaseParams{
+				args: []string{"snapshots", "rebuild", "--aggregate=product"},
+			},
+			expected: testCaseExpected{
+				err: ErrUnknownCommand,
+			},
+		},
+		{
+			name: "shouldn't run unknown command",
+			params: testCaseParams{
+				args: []string{"snapshots", "drop"},
+			},
+			expected: testCaseExpected{
+				err: ErrUnknownCommand,
+			},
+		},
+		{
+			name: "shouldn't run incomplete command",
+			params: testCaseParams{
+				args: []string{"snapshots"},
+			},
+			expected: testCaseExpected{
+				err: ErrUnknownCommand,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rebuilder := &testSnapshotRebuilder{err: tt.params.err}
+			commands := NewCommands(map[string]SnapshotRebuilder{"account": rebuilder}, map[string]ProjectionRebuilder{}, &bytes.Buffer{})
+
+			err := commands.Run(context.Background(), tt.params.args)
+			switch {
+			case tt.expected.err != nil:
+				require.ErrorIs(t, err, tt.expected.err)
+			case tt.expected.wantErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expected.rebuilds, rebuilder.rebuilds)
+		})
+	}
+}
+
+func TestCommands_Run_RebuildProjection(t *testing.T) {
+	type testCaseParams struct {
+		args []string
+		diff []eventdomain.ProjectionDiff
+		err  error
+	}
+
+	type testCaseExpected struct {
+		options []orchestrator.RebuildOptions
+		output  string
+		err     error
+		wantErr bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should rebuild projection",
+			params: testCaseParams{
+				args: []string{"projections", "rebuild", "--projection=accounts"},
+			},
+			expected: testCaseExpected{
+				options: []orchestrator.RebuildOptions{{}},
+			},
+		},
+		{
+			name: "should resume projection rebuild",
+			params: testCaseParams{
+				args: []string{"projections", "rebuild", "--projection=accounts", "--resume"},
+			},
+			expected: testCaseExpected{
+				options: []orchestrator.RebuildOptions{{Resume: true}},
+			},
+		},
+		{
+			name: "should print rows differing from live projection - dry run",
+			params: testCaseParams{
+				args: []string{"projections", "rebuild", "--projection=accounts", "--dry-run"},
+				diff: []eventdomain.ProjectionDiff{
+					{ID: "1", Live: `{"balance": 100}`, Rebuilt: `{"balance": 200}`},
+					{ID: "2", Rebuilt: `{"balance": 300}`},
+					{ID: "3", Live: `{"balance": 400}`},
+				},
+			},
+			expected: testCaseExpected{
+				options: []orchestrator.RebuildOptions{{DryRun: true}},
+				output: `- 1 {"balance": 100}
++ 1 {"balance": 200}
++ 2 {"balance": 300}
+- 3 {"balance": 400}
+`,
+			},
+		},
+		{
+			name: "shouldn't rebuild projection - rebuild failed",
+			params: testCaseParams{
+				args: []string{"projections", "rebuild", "--projection=accounts"},
+				err:  eventdomain.ErrProjectionBusy,
+			},
+			expected: testCaseExpected{
+				options: []orchestrator.RebuildOptions{{}},
+				err:     eventdomain.ErrProjectionBusy,
+			},
+		},
+		{
+			name: "shouldn't rebuild projection - unknown projection",
+			params: testCaseParams{
+				args: []string{"projections", "rebuild", "--projection=products"},
+			},
+			expected: testCaseExpected{
+				err: ErrUnknownCommand,
+			},
+		},
+		{
+			name: "shouldn't rebuild projection - unknown flag",
+			params: testCaseParams{
+				args: []string{"projections", "rebuild", "--projection=accounts", "--force"},
+			},
+			expected: testCaseExpected{
+				wantErr: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rebuilder := &testProjectionRebuilder{diff: tt.params.diff, err: tt.params.err}
+			output := &bytes.Buffer{}
+			commands := NewCommands(map[string]SnapshotRebuilder{}, map[string]ProjectionRebuilder{"accounts": rebuilder}, output)
+
+			err := commands.Run(context.Background(), tt.params.args)
+			switch {
+			case tt.expected.err != nil:
+				require.ErrorIs(t, err, tt.expected.err)
+			case tt.expected.wantErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.expected.output, output.String())
+			}
+
+			require.Equal(t, tt.expected.options, rebuilder.options)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package access
+
+import (
+	"context"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+//go:generate mockgen -destination=./mock/access_service_mock.go -package=mock -source=./access_interface.go
+
+// AccessAuditRepository defines the interface for the persistence of the audits of the staff accessing the resources of the customers
+type AccessAuditRepository interface {
+	// CreateAccessAudit persists the audit of the access overriding the ownership of the resource
+	CreateAccessAudit(ctx context.Context, audit identitydomain.AccessAudit) error
+}
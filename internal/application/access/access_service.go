@@ -0,0 +1,60 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+// AccessService authorizes the access of the principal to the resources owned by the customers. The customer accesses
+// only the resources they own, the staff overrides the ownership and every such access is audited.
+type AccessService struct {
+	auditRepo AccessAuditRepository
+}
+
+// NewAccessService creates a new access service
+func NewAccessService(auditRepo AccessAuditRepository) *AccessService {
+	return &AccessService{
+		auditRepo: auditRepo,
+	}
+}
+
+// AuthorizeOwner authorizes the access of the principal of the context to the resource. The principal owning the resource
+// is allowed, the one acting in the staff role is allowed once the access is audited, the access isn't allowed when it can't be audited.
+// It returns identitydomain.ErrNotOwner for any other principal. The use case called without the principal, i.e. by the orchestrator
+// or the command line, isn't restricted, the REST API authenticates every request reaching the use cases of the customers.
+func (s *AccessService) AuthorizeOwner(ctx context.Context, resource identitydomain.Resource) error {
+	principal, ok := identitydomain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if principal.Owns(resource) {
+		return nil
+	}
+
+	if !principal.OverridesOwnership() {
+		return fmt.Errorf("authorizing access to %s %s: %w", resource.Type, resource.ID, identitydomain.ErrNotOwner)
+	}
+
+	audit := identitydomain.AccessAudit{
+		ID:            uuid.New(),
+		PrincipalID:   principal.ID,
+		PrincipalType: principal.Type,
+		Roles:         principal.Roles,
+		Resource:      resource,
+		CorrelationID: eventdomain.MetadataFromContext(ctx).CorrelationID,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	if err := s.auditRepo.CreateAccessAudit(ctx, audit); err != nil {
+		return fmt.Errorf("auditing access to %s %s: %w", resource.Type, resource.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+//go:build unit
+
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/application/access/mock"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+func TestAccessService_AuthorizeOwner(t *testing.T) {
+	customerID := uuid.New()
+	correlationID := uuid.New()
+
+	resource := identitydomain.Resource{Type: identitydomain.ResourceTypeAccount, ID: uuid.New(), CustomerID: customerID}
+
+	owner := identitydomain.Principal{ID: "customer-1", Type: identitydomain.PrincipalTypeUser, Roles: []identitydomain.Role{identitydomain.RoleCustomer}, CustomerID: customerID}
+	another := identitydomain.Principal{ID: "customer-2", Type: identitydomain.PrincipalTypeUser, Roles: []identitydomain.Role{identitydomain.RoleCustomer}, CustomerID: uuid.New()}
+	teller := identitydomain.Principal{ID: "teller-1", Type: identitydomain.PrincipalTypeUser, Roles: []identitydomain.Role{identitydomain.RoleTeller}}
+
+	type testCaseParams struct {
+		principal     *identitydomain.Principal
+		mockAuditRepo func(*gomock.Controller) *mock.MockAccessAuditRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+		err       error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should allow access without principal",
+			params: testCaseParams{
+				mockAuditRepo: func(m *gomock.Controller) *mock.MockAccessAuditRepository {
+					return mock.NewMockAccessAuditRepository(m)
+				},
+			},
+		},
+		{
+			name: "should allow customer owning resource",
+			params: testCaseParams{
+				principal: &owner,
+				mockAuditRepo: func(m *gomock.Controller) *mock.MockAccessAuditRepository {
+					// The access of the owner isn't audited
+					return mock.NewMockAccessAuditRepository(m)
+				},
+			},
+		},
+		{
+			name: "shouldn't allow customer not owning resource",
+			params: testCaseParams{
+				principal: &another,
+				mockAuditRepo: func(m *gomock.Controller) *mock.MockAccessAuditRepository {
+					return mock.NewMockAccessAuditRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrNotOwner,
+			},
+		},
+		{
+			name: "should allow staff overriding ownership and audit access",
+			params: testCaseParams{
+				principal: &teller,
+				mockAuditRepo: func(m *gomock.Controller) *mock.MockAccessAuditRepository {
+					mock := mock.NewMockAccessAuditRepository(m)
+					mock.EXPECT().CreateAccessAudit(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, audit identitydomain.AccessAudit) error {
+						require.NotEqual(t, uuid.Nil, audit.ID)
+						require.Equal(t, "teller-1", audit.PrincipalID)
+						require.Equal(t, identitydomain.PrincipalTypeUser, audit.PrincipalType)
+						require.Equal(t, []identitydomain.Role{identitydomain.RoleTeller}, audit.Roles)
+						require.Equal(t, resource, audit.Resource)
+						require.Equal(t, correlationID, audit.CorrelationID)
+						require.False(t, audit.CreatedAt.IsZero())
+						return nil
+					})
+					return mock
+				},
+			},
+		},
+		{
+			name: "shouldn't allow staff when access can't be audited",
+			params: testCaseParams{
+				principal: &teller,
+				mockAuditRepo: func(m *gomock.Controller) *mock.MockAccessAuditRepository {
+					mock := mock.NewMockAccessAuditRepository(m)
+					mock.EXPECT().CreateAccessAudit(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewAccessService(tt.params.mockAuditRepo(ctrl))
+
+			ctx := eventdomain.ContextWithMetadata(context.Background(), eventdomain.Metadata{CorrelationID: correlationID})
+			if tt.params.principal != nil {
+				ctx = identitydomain.ContextWithPrincipal(ctx, *tt.params.principal)
+			}
+
+			err := service.AuthorizeOwner(ctx, resource)
+			if tt.expected.wantError {
+				require.Error(t, err)
+				if tt.expected.err != nil {
+					require.ErrorIs(t, err, tt.expected.err)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./access_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/access_service_mock.go -package=mock -source=./access_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	identity "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccessAuditRepository is a mock of AccessAuditRepository interface.
+type MockAccessAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccessAuditRepositoryMockRecorder is the mock recorder for MockAccessAuditRepository.
+type MockAccessAuditRepositoryMockRecorder struct {
+	mock *MockAccessAuditRepository
+}
+
+// NewMockAccessAuditRepository creates a new mock instance.
+func NewMockAccessAuditRepository(ctrl *gomock.Controller) *MockAccessAuditRepository {
+	mock := &MockAccessAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAccessAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessAuditRepository) EXPECT() *MockAccessAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAccessAudit mocks base method.
+func (m *MockAccessAuditRepository) CreateAccessAudit(ctx context.Context, audit identity.AccessAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessAudit", ctx, audit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccessAudit indicates an expected call of CreateAccessAudit.
+func (mr *MockAccessAuditRepositoryMockRecorder) CreateAccessAudit(ctx, audit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessAudit", reflect.TypeOf((*MockAccessAuditRepository)(nil).CreateAccessAudit), ctx, audit)
+}
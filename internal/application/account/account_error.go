@@ -16,4 +16,14 @@ var (
 	ErrInvalidDepositAmount = errors.New("invalid deposit money amount")
 	// ErrInvalidInitialBalanceAmount is returned when the initial account balance amount is invalid.
 	ErrInvalidInitialBalanceAmount = errors.New("invalid initial account balance amount")
+	// ErrInvalidCurrency is returned when the account currency is not supported.
+	ErrInvalidCurrency = errors.New("invalid account currency")
+	// ErrAccountStatusConflict is returned when the account status doesn't allow the operation, i.e. withdrawal from a blocked account.
+	ErrAccountStatusConflict = errors.New("account status does not allow the operation")
+	// ErrInsufficientFunds is returned when the account balance doesn't cover the withdrawal.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrInvalidBlockedUntil is returned when the time the account is blocked until is invalid.
+	ErrInvalidBlockedUntil = errors.New("invalid account blocked until time")
+	// ErrConcurrencyConflict is returned when the account kept being changed concurrently and the command couldn't be recorded.
+	ErrConcurrencyConflict = errors.New("account was changed concurrently")
 )
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
 )
 
 //go:generate mockgen -destination=./mock/account_service_mock.go -package=mock -source=./account_interface.go
@@ -26,8 +27,28 @@ type AccountQueryRepository interface {
 
 // AccountEventRepository defines the interface for account event persistence
 type AccountEventRepository interface {
-	// CreateEvents persists an account event
-	CreateEvents(ctx context.Context, events []accountdomain.Event) error
+	// CreateEvents persists the account events after the expected version of the account,
+	// it returns event.ErrConcurrencyConflict when the account was changed in the meantime
+	CreateEvents(ctx context.Context, expectedVersion int, events []accountdomain.Event) error
+
+	// FindEventsByContextID retrieves the events of an account in the order they were recorded
+	FindEventsByContextID(ctx context.Context, id uuid.UUID) ([]accountdomain.Event, error)
+
+	// FindEventsAfterVersion retrieves the events of an account recorded after the version in the order they were recorded
+	FindEventsAfterVersion(ctx context.Context, id uuid.UUID, version int) ([]accountdomain.Event, error)
+
+	// FindAccountIDs retrieves the IDs of all the accounts
+	FindAccountIDs(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// AccountSnapshotRepository defines the interface for account snapshot persistence
+type AccountSnapshotRepository interface {
+	// CreateSnapshot persists the snapshot of an account at its version
+	CreateSnapshot(ctx context.Context, snapshot accountdomain.AccountSnapshot) error
+
+	// FindLatestSnapshot retrieves the latest snapshot of an account,
+	// it returns accountdomain.ErrAccountSnapshotNotFound when the account has no snapshot
+	FindLatestSnapshot(ctx context.Context, id uuid.UUID) (accountdomain.AccountSnapshot, error)
 }
 
 //             Customer
@@ -37,3 +58,12 @@ type CustomerQueryRepository interface {
 	// FindByID retrieves a customer by its ID
 	FindByID(ctx context.Context, id uuid.UUID) (*customerdomain.Customer, error)
 }
+
+//             Access
+
+// AccessGuard defines the interface for authorizing the access to the resources owned by the customers
+type AccessGuard interface {
+	// AuthorizeOwner authorizes the access of the principal of the context to the resource,
+	// it returns identitydomain.ErrNotOwner when the principal isn't allowed to access it
+	AuthorizeOwner(ctx context.Context, resource identitydomain.Resource) error
+}
@@ -4,51 +4,73 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 
 	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
 )
 
 type Account = accountdomain.Account
 
+// maxCommandAttempts is the number of times an account command is run when the account is changed concurrently
+const maxCommandAttempts = 3
+
 // AccountService handles account-related use cases
 type AccountService struct {
 	accountQueryRepo  AccountQueryRepository
 	customerQueryRepo CustomerQueryRepository
 
-	accountEventRepo AccountEventRepository
+	accountEventRepo    AccountEventRepository
+	accountSnapshotRepo AccountSnapshotRepository
+
+	accessGuard AccessGuard
+
+	snapshotPolicy eventdomain.SnapshotPolicy
 }
 
-// NewService creates a new account service
+// NewService creates a new account service, the accounts are snapshotted by the snapshot policy
+// and the customers access only the accounts they own by the access guard
 func NewService(
 	accountQueryRepo AccountQueryRepository,
 	customerQueryRepo CustomerQueryRepository,
-	accountEventRepo AccountEventRepository) *AccountService {
+	accountEventRepo AccountEventRepository,
+	accountSnapshotRepo AccountSnapshotRepository,
+	accessGuard AccessGuard,
+	snapshotPolicy eventdomain.SnapshotPolicy) *AccountService {
 	return &AccountService{
-		accountQueryRepo:  accountQueryRepo,
-		accountEventRepo:  accountEventRepo,
-		customerQueryRepo: customerQueryRepo,
+		accountQueryRepo:    accountQueryRepo,
+		accountEventRepo:    accountEventRepo,
+		accountSnapshotRepo: accountSnapshotRepo,
+		customerQueryRepo:   customerQueryRepo,
+		accessGuard:         accessGuard,
+		snapshotPolicy:      snapshotPolicy,
 	}
 }
 
-// CreateAccountDTO represents the data needed to create a new account
+// CreateAccountDTO represents the data needed to create a new account.
+// The initial balance is a decimal amount in the account currency, i.e. "10.50".
 type CreateAccountDTO struct {
-	CustomerID     string  `json:"customerId"`
-	InitialBalance float64 `json:"initialBalance"`
-	Currency       string  `json:"currency"`
+	CustomerID     string `json:"customerId"`
+	InitialBalance string `json:"initialBalance"`
+	Currency       string `json:"currency"`
 }
 
-// AccountResponseDTO represents the account data returned to clients
+// AccountResponseDTO represents the account data returned to clients.
+// The balance is a decimal amount with all the currency minor units, i.e. "10.50".
 type AccountResponseDTO struct {
-	ID            string  `json:"id"`
-	AccountNumber string  `json:"accountNumber"`
-	CustomerID    string  `json:"customerId"`
-	Balance       float64 `json:"balance"`
-	Currency      string  `json:"currency"`
-	Status        string  `json:"status"`
-	CreatedAt     string  `json:"createdAt"`
-	UpdatedAt     string  `json:"updatedAt"`
+	ID            string `json:"id"`
+	AccountNumber string `json:"accountNumber"`
+	CustomerID    string `json:"customerId"`
+	Balance       string `json:"balance"`
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
 }
 
 type CreateAccountResponseDTO struct {
@@ -57,7 +79,20 @@ type CreateAccountResponseDTO struct {
 
 // CreateAccount creates a new account
 func (s *AccountService) CreateAccount(ctx context.Context, dto CreateAccountDTO) (CreateAccountResponseDTO, error) {
-	if dto.InitialBalance < 0 {
+	currency, err := money.ParseCurrency(dto.Currency)
+	if err != nil {
+		return CreateAccountResponseDTO{}, fmt.Errorf("parsing currency %q: %w", dto.Currency, ErrInvalidCurrency)
+	}
+
+	initialBalance := money.Zero(currency)
+	if dto.InitialBalance != "" {
+		initialBalance, err = money.Parse(dto.InitialBalance, currency)
+		if err != nil {
+			return CreateAccountResponseDTO{}, fmt.Errorf("parsing initial balance: %w: %w", ErrInvalidInitialBalanceAmount, err)
+		}
+	}
+
+	if initialBalance.IsNegative() {
 		return CreateAccountResponseDTO{}, ErrInvalidInitialBalanceAmount
 	}
 
@@ -70,9 +105,9 @@ func (s *AccountService) CreateAccount(ctx context.Context, dto CreateAccountDTO
 		}
 	}
 
-	account := accountdomain.NewAccount(id, uuid.MustParse(dto.CustomerID), accountNumber, dto.InitialBalance, dto.Currency)
+	account := accountdomain.NewAccount(id, uuid.MustParse(dto.CustomerID), accountNumber, initialBalance)
 
-	if err := s.accountEventRepo.CreateEvents(ctx, account.GetEvents()); err != nil {
+	if err := s.accountEventRepo.CreateEvents(ctx, account.Version, account.GetEvents()); err != nil {
 		return CreateAccountResponseDTO{}, err
 	}
 
@@ -85,7 +120,8 @@ type GetAccountDTO struct {
 	AccountID uuid.UUID `json:"accountId"`
 }
 
-// GetAccount retrieves an account by its ID
+// GetAccount retrieves an account by its ID. The account of another customer isn't found,
+// so the customer can't tell which accounts exist.
 func (s *AccountService) GetAccount(ctx context.Context, dto GetAccountDTO) (AccountResponseDTO, error) {
 	account, err := s.accountQueryRepo.FindByID(ctx, dto.AccountID)
 	if err != nil {
@@ -95,75 +131,103 @@ func (s *AccountService) GetAccount(ctx context.Context, dto GetAccountDTO) (Acc
 		return AccountResponseDTO{}, fmt.Errorf("finding account by id: %w", err)
 	}
 
+	err = s.accessGuard.AuthorizeOwner(ctx, identitydomain.Resource{
+		Type:       identitydomain.ResourceTypeAccount,
+		ID:         account.ID,
+		CustomerID: account.CustomerID,
+	})
+	if err != nil {
+		if errors.Is(err, identitydomain.ErrNotOwner) {
+			return AccountResponseDTO{}, fmt.Errorf("authorizing account access: %w", ErrAccountNotFound)
+		}
+		return AccountResponseDTO{}, fmt.Errorf("authorizing account access: %w", err)
+	}
+
 	return ToDTO(account), nil
 }
 
-// DepositDTO represents the data needed to deposit money
+// CommandResponseDTO represents the account command accepted for processing, EventID is the ID of the event recorded by the command.
+// The command changes the account once its event is processed, its progress is tracked by the event.
+type CommandResponseDTO struct {
+	EventID string `json:"eventId"`
+}
+
+// DepositDTO represents the data needed to deposit money.
+// The amount is a decimal amount in the account currency, i.e. "10.50".
 type DepositDTO struct {
 	AccountID uuid.UUID `json:"accountId"`
-	Amount    float64   `json:"amount"`
+	Amount    string    `json:"amount"`
 }
 
 // Deposit adds money to an account
-func (s *AccountService) Deposit(ctx context.Context, dto DepositDTO) error {
-	if dto.Amount <= 0 {
-		return ErrInvalidDepositAmount
-	}
+func (s *AccountService) Deposit(ctx context.Context, dto DepositDTO) (CommandResponseDTO, error) {
+	return s.execute(ctx, dto.AccountID, func(account *Account) error {
+		amount, err := money.Parse(dto.Amount, account.Balance.Currency())
+		if err != nil {
+			return fmt.Errorf("parsing deposit amount: %w: %w", ErrInvalidDepositAmount, err)
+		}
 
-	account, err := s.accountQueryRepo.FindByID(ctx, dto.AccountID)
-	if err != nil {
-		if errors.Is(err, accountdomain.ErrAccountNotFound) {
-			return fmt.Errorf("finding account by id: %w", ErrAccountNotFound)
+		if !amount.IsPositive() {
+			return ErrInvalidDepositAmount
 		}
-		return fmt.Errorf("finding account by id: %w", err)
-	}
 
-	account.Deposit(dto.Amount)
+		if err := account.Deposit(amount); err != nil {
+			return operationError("depositing money", err)
+		}
 
-	return s.accountEventRepo.CreateEvents(ctx, account.GetEvents())
+		return nil
+	})
 }
 
-// WithdrawDTO represents the data needed to withdraw money
+// WithdrawDTO represents the data needed to withdraw money.
+// The amount is a decimal amount in the account currency, i.e. "10.50".
 type WithdrawDTO struct {
 	AccountID uuid.UUID `json:"accountId"`
-	Amount    float64   `json:"amount"`
+	Amount    string    `json:"amount"`
 }
 
 // Withdraw removes money from an account
-func (s *AccountService) Withdraw(ctx context.Context, dto WithdrawDTO) error {
-	if dto.Amount <= 0 {
-		return ErrInvalidWithdrawAmount
-	}
+func (s *AccountService) Withdraw(ctx context.Context, dto WithdrawDTO) (CommandResponseDTO, error) {
+	return s.execute(ctx, dto.AccountID, func(account *Account) error {
+		amount, err := money.Parse(dto.Amount, account.Balance.Currency())
+		if err != nil {
+			return fmt.Errorf("parsing withdraw amount: %w: %w", ErrInvalidWithdrawAmount, err)
+		}
 
-	account, err := s.accountQueryRepo.FindByID(ctx, dto.AccountID)
-	if err != nil {
-		if errors.Is(err, accountdomain.ErrAccountNotFound) {
-			return fmt.Errorf("finding account by id: %w", ErrAccountNotFound)
+		if !amount.IsPositive() {
+			return ErrInvalidWithdrawAmount
+		}
+
+		if err := account.Withdraw(amount); err != nil {
+			return operationError("withdrawing money", err)
 		}
-		return fmt.Errorf("finding account by id: %w", err)
-	}
 
-	return s.accountEventRepo.CreateEvents(ctx, account.GetEvents())
+		return nil
+	})
 }
 
-// BlockAccountDTO represents the data needed to block an account
+// BlockAccountDTO represents the data needed to block an account.
+// The account is blocked until it is unblocked when BlockedUntil is zero, otherwise it is unblocked at BlockedUntil.
 type BlockAccountDTO struct {
-	AccountID uuid.UUID `json:"accountId"`
+	AccountID    uuid.UUID `json:"accountId"`
+	BlockedUntil time.Time `json:"blockedUntil"`
 }
 
 // BlockAccount blocks an account
-func (s *AccountService) BlockAccount(ctx context.Context, dto BlockAccountDTO) error {
-	account, err := s.accountQueryRepo.FindByID(ctx, dto.AccountID)
-	if err != nil {
-		if errors.Is(err, accountdomain.ErrAccountNotFound) {
-			return fmt.Errorf("finding account by id: %w", ErrAccountNotFound)
+func (s *AccountService) BlockAccount(ctx context.Context, dto BlockAccountDTO) (CommandResponseDTO, error) {
+	return s.execute(ctx, dto.AccountID, func(account *Account) error {
+		if err := account.Block(); err != nil {
+			return operationError("blocking account", err)
 		}
-		return fmt.Errorf("finding account by id: %w", err)
-	}
 
-	account.Block()
+		if !dto.BlockedUntil.IsZero() {
+			if err := account.ScheduleUnblock(dto.BlockedUntil); err != nil {
+				return fmt.Errorf("scheduling account unblock: %w: %w", ErrInvalidBlockedUntil, err)
+			}
+		}
 
-	return s.accountEventRepo.CreateEvents(ctx, account.GetEvents())
+		return nil
+	})
 }
 
 // UnblockAccountDTO represents the data needed to unblock an account
@@ -172,18 +236,196 @@ type UnblockAccountDTO struct {
 }
 
 // UnblockAccount unblocks an account
-func (s *AccountService) UnblockAccount(ctx context.Context, dto UnblockAccountDTO) error {
-	account, err := s.accountQueryRepo.FindByID(ctx, dto.AccountID)
+func (s *AccountService) UnblockAccount(ctx context.Context, dto UnblockAccountDTO) (CommandResponseDTO, error) {
+	return s.changeStatus(ctx, dto.AccountID, "unblocking account", (*Account).Unblock)
+}
+
+// ActivateAccountDTO represents the data needed to activate an account
+type ActivateAccountDTO struct {
+	AccountID uuid.UUID `json:"accountId"`
+}
+
+// ActivateAccount activates a pending account
+func (s *AccountService) ActivateAccount(ctx context.Context, dto ActivateAccountDTO) (CommandResponseDTO, error) {
+	return s.changeStatus(ctx, dto.AccountID, "activating account", (*Account).Activate)
+}
+
+// FreezeAccountDTO represents the data needed to freeze an account
+type FreezeAccountDTO struct {
+	AccountID uuid.UUID `json:"accountId"`
+}
+
+// FreezeAccount freezes an account, the account accepts deposits, but the funds can't be withdrawn
+func (s *AccountService) FreezeAccount(ctx context.Context, dto FreezeAccountDTO) (CommandResponseDTO, error) {
+	return s.changeStatus(ctx, dto.AccountID, "freezing account", (*Account).Freeze)
+}
+
+// UnfreezeAccountDTO represents the data needed to unfreeze an account
+type UnfreezeAccountDTO struct {
+	AccountID uuid.UUID `json:"accountId"`
+}
+
+// UnfreezeAccount unfreezes an account
+func (s *AccountService) UnfreezeAccount(ctx context.Context, dto UnfreezeAccountDTO) (CommandResponseDTO, error) {
+	return s.changeStatus(ctx, dto.AccountID, "unfreezing account", (*Account).Unfreeze)
+}
+
+// CloseAccountDTO represents the data needed to close an account
+type CloseAccountDTO struct {
+	AccountID uuid.UUID `json:"accountId"`
+}
+
+// CloseAccount closes an account for good, the account balance has to be zero
+func (s *AccountService) CloseAccount(ctx context.Context, dto CloseAccountDTO) (CommandResponseDTO, error) {
+	return s.changeStatus(ctx, dto.AccountID, "closing account", (*Account).Close)
+}
+
+// changeStatus applies the lifecycle operation to the account and records the events of the change
+func (s *AccountService) changeStatus(ctx context.Context, id uuid.UUID, operation string, apply func(*Account) error) (CommandResponseDTO, error) {
+	return s.execute(ctx, id, func(account *Account) error {
+		if err := apply(account); err != nil {
+			return operationError(operation, err)
+		}
+
+		return nil
+	})
+}
+
+// execute runs the command on the account rebuilt from its events and records the events of the command
+// expecting the account in the version the command decided on. When the account was changed concurrently,
+// the command is run again on the account rebuilt once more, up to maxCommandAttempts times.
+// The command is accepted with the first of its events, i.e. the deposit, the events are processed later by the orchestrator.
+func (s *AccountService) execute(ctx context.Context, id uuid.UUID, command func(*Account) error) (CommandResponseDTO, error) {
+	for attempt := 1; ; attempt++ {
+		account, err := s.findAccount(ctx, id)
+		if err != nil {
+			return CommandResponseDTO{}, err
+		}
+
+		if err := command(account); err != nil {
+			return CommandResponseDTO{}, err
+		}
+
+		events := account.GetEvents()
+
+		err = s.accountEventRepo.CreateEvents(ctx, account.Version, events)
+		if err == nil {
+			if s.snapshotPolicy.IsDue(account.Version, account.Version+len(events)) {
+				s.snapshot(ctx, id)
+			}
+
+			return CommandResponseDTO{EventID: events[0].GetID().String()}, nil
+		}
+
+		if !errors.Is(err, eventdomain.ErrConcurrencyConflict) {
+			return CommandResponseDTO{}, fmt.Errorf("creating account events: %w", err)
+		}
+
+		if attempt == maxCommandAttempts {
+			return CommandResponseDTO{}, fmt.Errorf("creating account events after %d attempts: %w: %w", attempt, ErrConcurrencyConflict, err)
+		}
+	}
+}
+
+// findAccount rebuilds the account from its events, so the command decides on the current state of the account
+// even when the accounts read model lags behind. The account is restored from its latest snapshot and the events
+// recorded after it, or from all its events when it has no snapshot. A missing account is reported with ErrAccountNotFound.
+func (s *AccountService) findAccount(ctx context.Context, id uuid.UUID) (*Account, error) {
+	snapshot, err := s.accountSnapshotRepo.FindLatestSnapshot(ctx, id)
+	if err != nil {
+		if !errors.Is(err, accountdomain.ErrAccountSnapshotNotFound) {
+			return nil, fmt.Errorf("finding account snapshot: %w", err)
+		}
+
+		return s.replayAccount(ctx, id)
+	}
+
+	events, err := s.accountEventRepo.FindEventsAfterVersion(ctx, id, snapshot.Version)
+	if err != nil {
+		return nil, fmt.Errorf("finding account events after snapshot: %w", err)
+	}
+
+	account, err := accountdomain.NewAccountFromSnapshot(snapshot, events)
+	if err != nil {
+		return nil, fmt.Errorf("restoring account from snapshot: %w", err)
+	}
+
+	return account, nil
+}
+
+// replayAccount rebuilds the account from all its events, a missing account is reported with ErrAccountNotFound
+func (s *AccountService) replayAccount(ctx context.Context, id uuid.UUID) (*Account, error) {
+	events, err := s.accountEventRepo.FindEventsByContextID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding account events: %w", err)
+	}
+
+	account, err := accountdomain.NewAccountFromEvents(events)
 	if err != nil {
 		if errors.Is(err, accountdomain.ErrAccountNotFound) {
-			return fmt.Errorf("finding account by id: %w", ErrAccountNotFound)
+			return nil, fmt.Errorf("rebuilding account: %w", ErrAccountNotFound)
 		}
-		return fmt.Errorf("finding account by id: %w", err)
+		return nil, fmt.Errorf("rebuilding account: %w", err)
 	}
 
-	account.Unblock()
+	return account, nil
+}
 
-	return s.accountEventRepo.CreateEvents(ctx, account.GetEvents())
+// snapshot stores the snapshot of the account at the version its last command led to.
+// The events of the command are already recorded, so a failed snapshot only leaves the account to be rebuilt from more events.
+func (s *AccountService) snapshot(ctx context.Context, id uuid.UUID) {
+	account, err := s.findAccount(ctx, id)
+	if err != nil {
+		log.Printf("snapshotting account %s: %v", id, err)
+		return
+	}
+
+	if err := s.accountSnapshotRepo.CreateSnapshot(ctx, account.Snapshot()); err != nil {
+		log.Printf("snapshotting account %s: %v", id, err)
+	}
+}
+
+// RebuildSnapshots replays every account from all its events and stores its snapshot at its current version,
+// i.e. when the snapshot format changed. It returns the number of the accounts snapshotted.
+func (s *AccountService) RebuildSnapshots(ctx context.Context) (int, error) {
+	ids, err := s.accountEventRepo.FindAccountIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("finding account ids: %w", err)
+	}
+
+	snapshotted := 0
+	for _, id := range ids {
+		account, err := s.replayAccount(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrAccountNotFound) {
+				continue
+			}
+			return snapshotted, fmt.Errorf("rebuilding account %s: %w", id, err)
+		}
+
+		if err := s.accountSnapshotRepo.CreateSnapshot(ctx, account.Snapshot()); err != nil {
+			return snapshotted, fmt.Errorf("creating account %s snapshot: %w", id, err)
+		}
+
+		snapshotted++
+	}
+
+	return snapshotted, nil
+}
+
+// operationError wraps the error of the account operation rejected by the account lifecycle or balance into the application error
+func operationError(operation string, err error) error {
+	switch {
+	case errors.Is(err, accountdomain.ErrAccountInsufficientFunds):
+		return fmt.Errorf("%s: %w: %w", operation, ErrInsufficientFunds, err)
+	case errors.Is(err, accountdomain.ErrAccountInvalidTransition),
+		errors.Is(err, accountdomain.ErrAccountDepositNotAllowed),
+		errors.Is(err, accountdomain.ErrAccountWithdrawalNotAllowed),
+		errors.Is(err, accountdomain.ErrAccountBalanceNotZero):
+		return fmt.Errorf("%s: %w: %w", operation, ErrAccountStatusConflict, err)
+	default:
+		return fmt.Errorf("%s: %w", operation, err)
+	}
 }
 
 // ToDTO converts an Account domain model to AccountResponseDTO
@@ -192,8 +434,8 @@ func ToDTO(account *Account) AccountResponseDTO {
 		ID:            account.ID.String(),
 		AccountNumber: account.AccountNumber,
 		CustomerID:    account.CustomerID.String(),
-		Balance:       account.Balance,
-		Currency:      account.Currency,
+		Balance:       account.Balance.Decimal(),
+		Currency:      account.Balance.Currency().String(),
 		Status:        account.Status.String(),
 		CreatedAt:     account.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:     account.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
@@ -218,9 +460,22 @@ type GetCustomerAccountsResponseDTO struct {
 }
 
 // TODO: move to customer service where responsibility of customer is
-// GetCustomerAccounts retrieves all accounts for a customer
+// GetCustomerAccounts retrieves all accounts for a customer. The accounts of another customer aren't found,
+// the access is authorized before the customer is looked up, so the customer can't tell which customers exist.
 func (s *AccountService) GetCustomerAccounts(ctx context.Context, dto GetCustomerAccountsDTO) (GetCustomerAccountsResponseDTO, error) {
-	_, err := s.customerQueryRepo.FindByID(ctx, dto.CustomerID)
+	err := s.accessGuard.AuthorizeOwner(ctx, identitydomain.Resource{
+		Type:       identitydomain.ResourceTypeCustomer,
+		ID:         dto.CustomerID,
+		CustomerID: dto.CustomerID,
+	})
+	if err != nil {
+		if errors.Is(err, identitydomain.ErrNotOwner) {
+			return GetCustomerAccountsResponseDTO{}, fmt.Errorf("authorizing customer access: %w", ErrCustomerNotFound)
+		}
+		return GetCustomerAccountsResponseDTO{}, fmt.Errorf("authorizing customer access: %w", err)
+	}
+
+	_, err = s.customerQueryRepo.FindByID(ctx, dto.CustomerID)
 	if err != nil {
 		return GetCustomerAccountsResponseDTO{}, ErrCustomerNotFound
 	}
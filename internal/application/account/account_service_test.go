@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -14,8 +15,79 @@ import (
 	"github.com/stefanowiczd/ddd-case-01/internal/application/account/mock"
 	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
 )
 
+func testAccount(t *testing.T) *Account {
+	balance, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	return &Account{
+		ID:      uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+		Balance: balance,
+		Status:  accountdomain.AccountStatusActive,
+	}
+}
+
+// testAccountEvents returns the events of the active test account followed by the events of the operations
+func testAccountEvents(t *testing.T, operations ...func(*Account) error) []accountdomain.Event {
+	account := accountdomain.NewAccount(uuid.MustParse("00000000-0000-0000-0000-000000000000"), uuid.New(), "1234567890", testAccount(t).Balance)
+	require.NoError(t, account.Activate())
+
+	for _, operation := range operations {
+		require.NoError(t, operation(account))
+	}
+
+	return testRecordedEvents(t, account.GetEvents())
+}
+
+// testNoSnapshots returns the snapshot repository of the accounts without snapshots, they are rebuilt from all their events
+func testNoSnapshots(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+	m := mock.NewMockAccountSnapshotRepository(ctrl)
+	m.EXPECT().FindLatestSnapshot(gomock.Any(), gomock.Any()).Return(accountdomain.AccountSnapshot{}, accountdomain.ErrAccountSnapshotNotFound).AnyTimes()
+	return m
+}
+
+// testAllowedAccess returns the access guard allowing the access to any account and customer
+func testAllowedAccess(ctrl *gomock.Controller) *mock.MockAccessGuard {
+	m := mock.NewMockAccessGuard(ctrl)
+	m.EXPECT().AuthorizeOwner(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return m
+}
+
+// testRecordedEvents returns the events as they are loaded from the events table, in the aggregate versions 1, 2, ...
+func testRecordedEvents(t *testing.T, events []accountdomain.Event) []accountdomain.Event {
+	registry := eventdomain.NewRegistry()
+	accountdomain.RegisterEvents(registry)
+
+	recorded := make([]accountdomain.Event, len(events))
+	for i, e := range events {
+		data, err := eventdomain.Encode(e)
+		require.NoError(t, err)
+
+		decoded, err := registry.Decode(&eventdomain.BaseEvent{
+			ID:               e.GetID(),
+			ContextID:        e.GetContextID(),
+			Origin:           e.GetOrigin(),
+			Type:             e.GetType(),
+			TypeVersion:      e.GetTypeVersion(),
+			State:            e.GetState(),
+			CreatedAt:        e.GetCreatedAt(),
+			ScheduledAt:      e.GetScheduledAt(),
+			MaxRetry:         e.GetMaxRetry(),
+			AggregateVersion: i + 1,
+			Data:             data,
+		})
+		require.NoError(t, err)
+
+		recorded[i] = decoded.(accountdomain.Event)
+	}
+
+	return recorded
+}
+
 func TestAccountService_CreateAccount(t *testing.T) {
 	type testCaseParams struct {
 		dto                  CreateAccountDTO
@@ -39,7 +111,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 			params: testCaseParams{
 				dto: CreateAccountDTO{
 					CustomerID:     "00000000-0000-0000-0000-000000000000",
-					InitialBalance: -100,
+					InitialBalance: "-100",
 					Currency:       "USD",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
@@ -55,12 +127,31 @@ func TestAccountService_CreateAccount(t *testing.T) {
 				err:              ErrInvalidInitialBalanceAmount,
 			},
 		},
+		{
+			name: "should return error for unsupported currency",
+			params: testCaseParams{
+				dto: CreateAccountDTO{
+					CustomerID:     "00000000-0000-0000-0000-000000000000",
+					InitialBalance: "100",
+					Currency:       "XYZ",
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					return mock.NewMockAccountEventRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
 		{
 			name: "should return error - error",
 			params: testCaseParams{
 				dto: CreateAccountDTO{
 					CustomerID:     "00000000-0000-0000-0000-000000000000",
-					InitialBalance: 0,
+					InitialBalance: "0",
 					Currency:       "USD",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
@@ -81,7 +172,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 			params: testCaseParams{
 				dto: CreateAccountDTO{
 					CustomerID:     "00000000-0000-0000-0000-000000000000",
-					InitialBalance: 0,
+					InitialBalance: "0",
 					Currency:       "USD",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
@@ -102,7 +193,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 			params: testCaseParams{
 				dto: CreateAccountDTO{
 					CustomerID:     "00000000-0000-0000-0000-000000000000",
-					InitialBalance: 0,
+					InitialBalance: "0",
 					Currency:       "USD",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
@@ -112,7 +203,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
 					mock := mock.NewMockAccountEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				},
 			},
@@ -132,6 +223,9 @@ func TestAccountService_CreateAccount(t *testing.T) {
 				tt.params.mockAccountQueryRepo(ctrl),
 				mock.NewMockCustomerQueryRepository(ctrl),
 				tt.params.mockAccountEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 			account, err := service.CreateAccount(context.Background(), tt.params.dto)
 
@@ -153,6 +247,7 @@ func TestAccountService_GetAccount(t *testing.T) {
 	type testCaseParams struct {
 		dto                  GetAccountDTO
 		mockAccountQueryRepo func(*gomock.Controller) *mock.MockAccountQueryRepository
+		mockAccessGuard      func(*gomock.Controller) *mock.MockAccessGuard
 	}
 
 	type testCaseExpected struct {
@@ -177,6 +272,7 @@ func TestAccountService_GetAccount(t *testing.T) {
 					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
 					return mock
 				},
+				mockAccessGuard: testAllowedAccess,
 			},
 			expected: testCaseExpected{
 				wantError: true,
@@ -193,6 +289,37 @@ func TestAccountService_GetAccount(t *testing.T) {
 					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, accountdomain.ErrAccountNotFound)
 					return mock
 				},
+				mockAccessGuard: testAllowedAccess,
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrAccountNotFound,
+			},
+		},
+		{
+			name: "shouldn't get account - account of another customer",
+			params: testCaseParams{
+				dto: GetAccountDTO{
+					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&Account{
+						ID:         uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+						CustomerID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					}, nil)
+					return mock
+				},
+				mockAccessGuard: func(m *gomock.Controller) *mock.MockAccessGuard {
+					mock := mock.NewMockAccessGuard(m)
+					mock.EXPECT().AuthorizeOwner(gomock.Any(), identitydomain.Resource{
+						Type:       identitydomain.ResourceTypeAccount,
+						ID:         uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+						CustomerID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					}).Return(identitydomain.ErrNotOwner)
+					return mock
+				},
 			},
 			expected: testCaseExpected{
 				wantError:        true,
@@ -200,6 +327,27 @@ func TestAccountService_GetAccount(t *testing.T) {
 				err:              ErrAccountNotFound,
 			},
 		},
+		{
+			name: "shouldn't get account - internal error when auditing access",
+			params: testCaseParams{
+				dto: GetAccountDTO{
+					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&Account{}, nil)
+					return mock
+				},
+				mockAccessGuard: func(m *gomock.Controller) *mock.MockAccessGuard {
+					mock := mock.NewMockAccessGuard(m)
+					mock.EXPECT().AuthorizeOwner(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
 		{
 			name: "should get account successfully",
 			params: testCaseParams{
@@ -211,6 +359,7 @@ func TestAccountService_GetAccount(t *testing.T) {
 					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&Account{}, nil)
 					return mock
 				},
+				mockAccessGuard: testAllowedAccess,
 			},
 			expected: testCaseExpected{
 				wantError: false,
@@ -228,6 +377,9 @@ func TestAccountService_GetAccount(t *testing.T) {
 				tt.params.mockAccountQueryRepo(ctrl),
 				mock.NewMockCustomerQueryRepository(ctrl),
 				mock.NewMockAccountEventRepository(ctrl),
+				testNoSnapshots(ctrl),
+				tt.params.mockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 			account, err := service.GetAccount(context.Background(), tt.params.dto)
 
@@ -268,13 +420,15 @@ func TestAccountService_Deposit(t *testing.T) {
 			params: testCaseParams{
 				dto: DepositDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    -100,
+					Amount:    "-100",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
 					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -284,19 +438,41 @@ func TestAccountService_Deposit(t *testing.T) {
 			},
 		},
 		{
-			name: "shouldn't deposit - internal error while finding account",
+			name: "shouldn't deposit - amount more precise than the account currency",
 			params: testCaseParams{
 				dto: DepositDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    100,
+					Amount:    "100.005",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
 					return mock
 				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrInvalidDepositAmount,
+			},
+		},
+		{
+			name: "shouldn't deposit - internal error while finding account",
+			params: testCaseParams{
+				dto: DepositDTO{
+					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					Amount:    "100.00",
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -308,15 +484,15 @@ func TestAccountService_Deposit(t *testing.T) {
 			params: testCaseParams{
 				dto: DepositDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    100,
+					Amount:    "100.00",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, accountdomain.ErrAccountNotFound)
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -330,16 +506,15 @@ func TestAccountService_Deposit(t *testing.T) {
 			params: testCaseParams{
 				dto: DepositDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    100,
+					Amount:    "100.00",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&Account{}, nil)
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
 					mock := mock.NewMockAccountEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				},
 			},
@@ -359,8 +534,11 @@ func TestAccountService_Deposit(t *testing.T) {
 				tt.params.mockAccountQueryRepo(ctrl),
 				mock.NewMockCustomerQueryRepository(ctrl),
 				tt.params.mockAccountEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
-			err := service.Deposit(context.Background(), tt.params.dto)
+			command, err := service.Deposit(context.Background(), tt.params.dto)
 
 			if tt.expected.wantError {
 				require.Error(t, err)
@@ -369,6 +547,8 @@ func TestAccountService_Deposit(t *testing.T) {
 				}
 			} else {
 				require.NoError(t, err)
+				_, err = uuid.Parse(command.EventID)
+				require.NoError(t, err)
 			}
 		})
 	}
@@ -397,13 +577,15 @@ func TestAccountService_Withdraw(t *testing.T) {
 			params: testCaseParams{
 				dto: WithdrawDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    -100,
+					Amount:    "-100",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
 					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -417,15 +599,15 @@ func TestAccountService_Withdraw(t *testing.T) {
 			params: testCaseParams{
 				dto: WithdrawDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    100,
+					Amount:    "100.00",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -437,15 +619,15 @@ func TestAccountService_Withdraw(t *testing.T) {
 			params: testCaseParams{
 				dto: WithdrawDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    100,
+					Amount:    "100.00",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, accountdomain.ErrAccountNotFound)
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -455,20 +637,63 @@ func TestAccountService_Withdraw(t *testing.T) {
 			},
 		},
 		{
-			name: "successful withdraw",
+			name: "shouldn't withdraw - insufficient funds",
 			params: testCaseParams{
 				dto: WithdrawDTO{
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-					Amount:    100,
+					Amount:    "100000.00",
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&Account{}, nil)
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrInsufficientFunds,
+			},
+		},
+		{
+			name: "shouldn't withdraw - account blocked",
+			params: testCaseParams{
+				dto: WithdrawDTO{
+					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					Amount:    "100.00",
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t, (*Account).Block), nil)
 					return mock
 				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrAccountStatusConflict,
+			},
+		},
+		{
+			name: "successful withdraw",
+			params: testCaseParams{
+				dto: WithdrawDTO{
+					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					Amount:    "100.00",
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
 					mock := mock.NewMockAccountEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				},
 			},
@@ -488,8 +713,11 @@ func TestAccountService_Withdraw(t *testing.T) {
 				tt.params.mockAccountQueryRepo(ctrl),
 				mock.NewMockCustomerQueryRepository(ctrl),
 				tt.params.mockAccountEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
-			err := service.Withdraw(context.Background(), tt.params.dto)
+			command, err := service.Withdraw(context.Background(), tt.params.dto)
 
 			if tt.expected.wantError {
 				require.Error(t, err)
@@ -498,6 +726,8 @@ func TestAccountService_Withdraw(t *testing.T) {
 				}
 			} else {
 				require.NoError(t, err)
+				_, err = uuid.Parse(command.EventID)
+				require.NoError(t, err)
 			}
 		})
 	}
@@ -528,12 +758,12 @@ func TestAccountService_BlockAccount(t *testing.T) {
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -547,12 +777,12 @@ func TestAccountService_BlockAccount(t *testing.T) {
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, accountdomain.ErrAccountNotFound)
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -568,13 +798,55 @@ func TestAccountService_BlockAccount(t *testing.T) {
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&Account{}, nil)
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: false,
+				err:       nil,
+			},
+		},
+		{
+			name: "shouldn't block account - account already blocked",
+			params: testCaseParams{
+				dto: BlockAccountDTO{
+					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t, (*Account).Block), nil)
 					return mock
 				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrAccountStatusConflict,
+			},
+		},
+		{
+			name: "should block account until the given time",
+			params: testCaseParams{
+				dto: BlockAccountDTO{
+					AccountID:    uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					BlockedUntil: time.Now().UTC().Add(24 * time.Hour),
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
 					mock := mock.NewMockAccountEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), 2, gomock.Len(2)).Return(nil)
 					return mock
 				},
 			},
@@ -583,6 +855,28 @@ func TestAccountService_BlockAccount(t *testing.T) {
 				err:       nil,
 			},
 		},
+		{
+			name: "shouldn't block account - blocked until time in the past",
+			params: testCaseParams{
+				dto: BlockAccountDTO{
+					AccountID:    uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					BlockedUntil: time.Now().UTC().Add(-time.Hour),
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrInvalidBlockedUntil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -594,8 +888,11 @@ func TestAccountService_BlockAccount(t *testing.T) {
 				tt.params.mockAccountQueryRepo(ctrl),
 				mock.NewMockCustomerQueryRepository(ctrl),
 				tt.params.mockAccountEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
-			err := service.BlockAccount(context.Background(), tt.params.dto)
+			command, err := service.BlockAccount(context.Background(), tt.params.dto)
 
 			if tt.expected.wantError {
 				require.Error(t, err)
@@ -604,6 +901,8 @@ func TestAccountService_BlockAccount(t *testing.T) {
 				}
 			} else {
 				require.NoError(t, err)
+				_, err = uuid.Parse(command.EventID)
+				require.NoError(t, err)
 			}
 		})
 	}
@@ -634,12 +933,12 @@ func TestAccountService_UnblockAccount(t *testing.T) {
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -653,12 +952,12 @@ func TestAccountService_UnblockAccount(t *testing.T) {
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, accountdomain.ErrAccountNotFound)
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
-					return mock.NewMockAccountEventRepository(m)
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -674,13 +973,12 @@ func TestAccountService_UnblockAccount(t *testing.T) {
 					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 				},
 				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
-					mock := mock.NewMockAccountQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&Account{}, nil)
-					return mock
+					return mock.NewMockAccountQueryRepository(m)
 				},
 				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
 					mock := mock.NewMockAccountEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t, (*Account).Block), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 					return mock
 				},
 			},
@@ -689,6 +987,27 @@ func TestAccountService_UnblockAccount(t *testing.T) {
 				err:       nil,
 			},
 		},
+		{
+			name: "shouldn't unblock account - account not blocked",
+			params: testCaseParams{
+				dto: UnblockAccountDTO{
+					AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockAccountEventRepo: func(m *gomock.Controller) *mock.MockAccountEventRepository {
+					mock := mock.NewMockAccountEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testAccountEvents(t), nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrAccountStatusConflict,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -700,8 +1019,11 @@ func TestAccountService_UnblockAccount(t *testing.T) {
 				tt.params.mockAccountQueryRepo(ctrl),
 				mock.NewMockCustomerQueryRepository(ctrl),
 				tt.params.mockAccountEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
-			err := service.UnblockAccount(context.Background(), tt.params.dto)
+			command, err := service.UnblockAccount(context.Background(), tt.params.dto)
 
 			if tt.expected.wantError {
 				require.Error(t, err)
@@ -710,7 +1032,202 @@ func TestAccountService_UnblockAccount(t *testing.T) {
 				}
 			} else {
 				require.NoError(t, err)
+				_, err = uuid.Parse(command.EventID)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountService_ChangeStatus(t *testing.T) {
+	accountID := uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+	type testCaseParams struct {
+		events []accountdomain.Event
+		change func(s *AccountService) (CommandResponseDTO, error)
+	}
+
+	type testCaseExpected struct {
+		err error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should activate pending account",
+			params: testCaseParams{
+				events: testRecordedEvents(t, accountdomain.NewAccount(accountID, uuid.New(), "1234567890", testAccount(t).Balance).GetEvents()),
+				change: func(s *AccountService) (CommandResponseDTO, error) {
+					return s.ActivateAccount(context.Background(), ActivateAccountDTO{AccountID: accountID})
+				},
+			},
+		},
+		{
+			name: "should freeze active account",
+			params: testCaseParams{
+				events: testAccountEvents(t),
+				change: func(s *AccountService) (CommandResponseDTO, error) {
+					return s.FreezeAccount(context.Background(), FreezeAccountDTO{AccountID: accountID})
+				},
+			},
+		},
+		{
+			name: "should unfreeze frozen account",
+			params: testCaseParams{
+				events: testAccountEvents(t, (*Account).Freeze),
+				change: func(s *AccountService) (CommandResponseDTO, error) {
+					return s.UnfreezeAccount(context.Background(), UnfreezeAccountDTO{AccountID: accountID})
+				},
+			},
+		},
+		{
+			name: "should close account without funds",
+			params: testCaseParams{
+				events: testAccountEvents(t, func(a *Account) error { return a.Withdraw(a.Balance) }),
+				change: func(s *AccountService) (CommandResponseDTO, error) {
+					return s.CloseAccount(context.Background(), CloseAccountDTO{AccountID: accountID})
+				},
+			},
+		},
+		{
+			name: "shouldn't close account - account holds funds",
+			params: testCaseParams{
+				events: testAccountEvents(t),
+				change: func(s *AccountService) (CommandResponseDTO, error) {
+					return s.CloseAccount(context.Background(), CloseAccountDTO{AccountID: accountID})
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrAccountStatusConflict,
+			},
+		},
+		{
+			name: "shouldn't activate active account",
+			params: testCaseParams{
+				events: testAccountEvents(t),
+				change: func(s *AccountService) (CommandResponseDTO, error) {
+					return s.ActivateAccount(context.Background(), ActivateAccountDTO{AccountID: accountID})
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrAccountStatusConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var recorded []accountdomain.Event
+
+			accountEventRepo := mock.NewMockAccountEventRepository(ctrl)
+			accountEventRepo.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(tt.params.events, nil)
+			if tt.expected.err == nil {
+				accountEventRepo.EXPECT().CreateEvents(gomock.Any(), len(tt.params.events), gomock.Len(1)).
+					DoAndReturn(func(_ context.Context, _ int, events []accountdomain.Event) error {
+						recorded = events
+						return nil
+					})
+			}
+
+			service := NewService(mock.NewMockAccountQueryRepository(ctrl), mock.NewMockCustomerQueryRepository(ctrl), accountEventRepo, testNoSnapshots(ctrl), mock.NewMockAccessGuard(ctrl), eventdomain.SnapshotPolicy{})
+
+			command, err := tt.params.change(service)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, recorded[0].GetID().String(), command.EventID)
+		})
+	}
+}
+
+func TestAccountService_Withdraw_ConcurrencyConflict(t *testing.T) {
+	accountID := uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+	type testCaseParams struct {
+		mockAccountEventRepo func(*gomock.Controller) *mock.MockAccountEventRepository
+	}
+
+	type testCaseExpected struct {
+		err error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should withdraw money from the account rebuilt after a concurrent change",
+			params: testCaseParams{
+				mockAccountEventRepo: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := mock.NewMockAccountEventRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(testAccountEvents(t), nil),
+						m.EXPECT().CreateEvents(gomock.Any(), 2, gomock.Len(1)).Return(eventdomain.ErrConcurrencyConflict),
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(testAccountEvents(t, (*Account).Freeze, (*Account).Unfreeze), nil),
+						m.EXPECT().CreateEvents(gomock.Any(), 4, gomock.Len(1)).Return(nil),
+					)
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't withdraw money - funds withdrawn concurrently",
+			params: testCaseParams{
+				mockAccountEventRepo: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := mock.NewMockAccountEventRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(testAccountEvents(t), nil),
+						m.EXPECT().CreateEvents(gomock.Any(), 2, gomock.Len(1)).Return(eventdomain.ErrConcurrencyConflict),
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).
+							Return(testAccountEvents(t, func(a *Account) error { return a.Withdraw(a.Balance) }), nil),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrInsufficientFunds,
+			},
+		},
+		{
+			name: "shouldn't withdraw money - account kept changing concurrently",
+			params: testCaseParams{
+				mockAccountEventRepo: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := mock.NewMockAccountEventRepository(ctrl)
+					m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(testAccountEvents(t), nil).Times(maxCommandAttempts)
+					m.EXPECT().CreateEvents(gomock.Any(), 2, gomock.Len(1)).Return(eventdomain.ErrConcurrencyConflict).Times(maxCommandAttempts)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrConcurrencyConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewService(mock.NewMockAccountQueryRepository(ctrl), mock.NewMockCustomerQueryRepository(ctrl), tt.params.mockAccountEventRepo(ctrl), testNoSnapshots(ctrl), mock.NewMockAccessGuard(ctrl), eventdomain.SnapshotPolicy{})
+
+			_, err := service.Withdraw(context.Background(), WithdrawDTO{AccountID: accountID, Amount: "10.00"})
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
 			}
+
+			require.NoError(t, err)
 		})
 	}
 }
@@ -720,6 +1237,7 @@ func TestAccountService_GetCustomerAccounts(t *testing.T) {
 		dto                   GetCustomerAccountsDTO
 		mockAccountQueryRepo  func(*gomock.Controller) *mock.MockAccountQueryRepository
 		mockCustomerQueryRepo func(*gomock.Controller) *mock.MockCustomerQueryRepository
+		mockAccessGuard       func(*gomock.Controller) *mock.MockAccessGuard
 	}
 
 	type testCaseExpected struct {
@@ -746,6 +1264,35 @@ func TestAccountService_GetCustomerAccounts(t *testing.T) {
 					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, ErrCustomerNotFound)
 					return mock
 				},
+				mockAccessGuard: testAllowedAccess,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       ErrCustomerNotFound,
+			},
+		},
+		{
+			name: "shouldn't get customer accounts - accounts of another customer",
+			params: testCaseParams{
+				dto: GetCustomerAccountsDTO{
+					CustomerID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+				},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
+					// The access is denied before the customer is looked up
+					return mock.NewMockCustomerQueryRepository(m)
+				},
+				mockAccessGuard: func(m *gomock.Controller) *mock.MockAccessGuard {
+					mock := mock.NewMockAccessGuard(m)
+					mock.EXPECT().AuthorizeOwner(gomock.Any(), identitydomain.Resource{
+						Type:       identitydomain.ResourceTypeCustomer,
+						ID:         uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+						CustomerID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					}).Return(identitydomain.ErrNotOwner)
+					return mock
+				},
 			},
 			expected: testCaseExpected{
 				wantError: true,
@@ -768,6 +1315,7 @@ func TestAccountService_GetCustomerAccounts(t *testing.T) {
 					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
 					return mock
 				},
+				mockAccessGuard: testAllowedAccess,
 			},
 			expected: testCaseExpected{
 				wantError: false,
@@ -785,6 +1333,9 @@ func TestAccountService_GetCustomerAccounts(t *testing.T) {
 				tt.params.mockAccountQueryRepo(ctrl),
 				tt.params.mockCustomerQueryRepo(ctrl),
 				mock.NewMockAccountEventRepository(ctrl),
+				testNoSnapshots(ctrl),
+				tt.params.mockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 			accounts, err := service.GetCustomerAccounts(context.Background(), tt.params.dto)
 
@@ -799,3 +1350,175 @@ func TestAccountService_GetCustomerAccounts(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountService_Deposit_Snapshots(t *testing.T) {
+	accountID := uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+	amount, err := money.New(500, "USD")
+	require.NoError(t, err)
+
+	// The events of the active test account with the deposit the command records, the account reaches the version 3
+	events := testAccountEvents(t, func(a *Account) error { return a.Deposit(amount) })
+
+	snapshotAt := func(t *testing.T, version int) accountdomain.AccountSnapshot {
+		account, err := accountdomain.NewAccountFromEvents(events[:version])
+		require.NoError(t, err)
+
+		snapshot := account.Snapshot()
+
+		return snapshot
+	}
+
+	type testCaseParams struct {
+		policy                  eventdomain.SnapshotPolicy
+		mockAccountEventRepo    func(*gomock.Controller) *mock.MockAccountEventRepository
+		mockAccountSnapshotRepo func(*gomock.Controller) *mock.MockAccountSnapshotRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should deposit into account restored from its snapshot and the events after it",
+			params: testCaseParams{
+				mockAccountEventRepo: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := mock.NewMockAccountEventRepository(ctrl)
+					m.EXPECT().FindEventsAfterVersion(gomock.Any(), accountID, 1).Return(events[1:2], nil)
+					m.EXPECT().CreateEvents(gomock.Any(), 2, gomock.Len(1)).Return(nil)
+					return m
+				},
+				mockAccountSnapshotRepo: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := mock.NewMockAccountSnapshotRepository(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), accountID).Return(snapshotAt(t, 1), nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should snapshot account reaching the snapshot frequency",
+			params: testCaseParams{
+				policy: eventdomain.SnapshotPolicy{Frequency: 3},
+				mockAccountEventRepo: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := mock.NewMockAccountEventRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(events[:2], nil),
+						m.EXPECT().CreateEvents(gomock.Any(), 2, gomock.Len(1)).Return(nil),
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(events, nil),
+					)
+					return m
+				},
+				mockAccountSnapshotRepo: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := testNoSnapshots(ctrl)
+					m.EXPECT().CreateSnapshot(gomock.Any(), snapshotAt(t, 3)).Return(nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should deposit when the snapshot can't be stored",
+			params: testCaseParams{
+				policy: eventdomain.SnapshotPolicy{Frequency: 3},
+				mockAccountEventRepo: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := mock.NewMockAccountEventRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(events[:2], nil),
+						m.EXPECT().CreateEvents(gomock.Any(), 2, gomock.Len(1)).Return(nil),
+						m.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(events, nil),
+					)
+					return m
+				},
+				mockAccountSnapshotRepo: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := testNoSnapshots(ctrl)
+					m.EXPECT().CreateSnapshot(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't deposit - snapshot can't be loaded",
+			params: testCaseParams{
+				mockAccountEventRepo: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					return mock.NewMockAccountEventRepository(ctrl)
+				},
+				mockAccountSnapshotRepo: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := mock.NewMockAccountSnapshotRepository(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), accountID).Return(accountdomain.AccountSnapshot{}, errors.New("db error"))
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewService(
+				mock.NewMockAccountQueryRepository(ctrl),
+				mock.NewMockCustomerQueryRepository(ctrl),
+				tt.params.mockAccountEventRepo(ctrl),
+				tt.params.mockAccountSnapshotRepo(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				tt.params.policy,
+			)
+
+			_, err := service.Deposit(context.Background(), DepositDTO{AccountID: accountID, Amount: "5.00"})
+			if tt.expected.wantError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAccountService_RebuildSnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountID := uuid.MustParse("00000000-0000-0000-0000-000000000000")
+	abortedID := uuid.New()
+
+	amount, err := money.New(500, "USD")
+	require.NoError(t, err)
+
+	events := testAccountEvents(t, func(a *Account) error { return a.Deposit(amount) })
+
+	replayed, err := accountdomain.NewAccountFromEvents(events)
+	require.NoError(t, err)
+
+	snapshot := replayed.Snapshot()
+
+	accountEventRepo := mock.NewMockAccountEventRepository(ctrl)
+	accountEventRepo.EXPECT().FindAccountIDs(gomock.Any()).Return([]uuid.UUID{accountID, abortedID}, nil)
+	accountEventRepo.EXPECT().FindEventsByContextID(gomock.Any(), accountID).Return(events, nil)
+	// The account whose creation was aborted doesn't exist, so it isn't snapshotted
+	accountEventRepo.EXPECT().FindEventsByContextID(gomock.Any(), abortedID).Return(nil, nil)
+
+	accountSnapshotRepo := mock.NewMockAccountSnapshotRepository(ctrl)
+	accountSnapshotRepo.EXPECT().CreateSnapshot(gomock.Any(), snapshot).Return(nil)
+
+	service := NewService(
+		mock.NewMockAccountQueryRepository(ctrl),
+		mock.NewMockCustomerQueryRepository(ctrl),
+		accountEventRepo,
+		accountSnapshotRepo,
+		mock.NewMockAccessGuard(ctrl),
+		eventdomain.SnapshotPolicy{},
+	)
+
+	snapshotted, err := service.RebuildSnapshots(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, snapshotted)
+}
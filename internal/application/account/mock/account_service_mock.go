@@ -16,6 +16,7 @@ import (
 	uuid "github.com/google/uuid"
 	account "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
 	customer "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	identity "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -113,17 +114,115 @@ func (m *MockAccountEventRepository) EXPECT() *MockAccountEventRepositoryMockRec
 }
 
 // CreateEvents mocks base method.
-func (m *MockAccountEventRepository) CreateEvents(ctx context.Context, events []account.Event) error {
+func (m *MockAccountEventRepository) CreateEvents(ctx context.Context, expectedVersion int, events []account.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEvents", ctx, events)
+	ret := m.ctrl.Call(m, "CreateEvents", ctx, expectedVersion, events)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateEvents indicates an expected call of CreateEvents.
-func (mr *MockAccountEventRepositoryMockRecorder) CreateEvents(ctx, events any) *gomock.Call {
+func (mr *MockAccountEventRepositoryMockRecorder) CreateEvents(ctx, expectedVersion, events any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockAccountEventRepository)(nil).CreateEvents), ctx, events)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockAccountEventRepository)(nil).CreateEvents), ctx, expectedVersion, events)
+}
+
+// FindAccountIDs mocks base method.
+func (m *MockAccountEventRepository) FindAccountIDs(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAccountIDs", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAccountIDs indicates an expected call of FindAccountIDs.
+func (mr *MockAccountEventRepositoryMockRecorder) FindAccountIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAccountIDs", reflect.TypeOf((*MockAccountEventRepository)(nil).FindAccountIDs), ctx)
+}
+
+// FindEventsAfterVersion mocks base method.
+func (m *MockAccountEventRepository) FindEventsAfterVersion(ctx context.Context, id uuid.UUID, version int) ([]account.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventsAfterVersion", ctx, id, version)
+	ret0, _ := ret[0].([]account.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventsAfterVersion indicates an expected call of FindEventsAfterVersion.
+func (mr *MockAccountEventRepositoryMockRecorder) FindEventsAfterVersion(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsAfterVersion", reflect.TypeOf((*MockAccountEventRepository)(nil).FindEventsAfterVersion), ctx, id, version)
+}
+
+// FindEventsByContextID mocks base method.
+func (m *MockAccountEventRepository) FindEventsByContextID(ctx context.Context, id uuid.UUID) ([]account.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventsByContextID", ctx, id)
+	ret0, _ := ret[0].([]account.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventsByContextID indicates an expected call of FindEventsByContextID.
+func (mr *MockAccountEventRepositoryMockRecorder) FindEventsByContextID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsByContextID", reflect.TypeOf((*MockAccountEventRepository)(nil).FindEventsByContextID), ctx, id)
+}
+
+// MockAccountSnapshotRepository is a mock of AccountSnapshotRepository interface.
+type MockAccountSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountSnapshotRepositoryMockRecorder is the mock recorder for MockAccountSnapshotRepository.
+type MockAccountSnapshotRepositoryMockRecorder struct {
+	mock *MockAccountSnapshotRepository
+}
+
+// NewMockAccountSnapshotRepository creates a new mock instance.
+func NewMockAccountSnapshotRepository(ctrl *gomock.Controller) *MockAccountSnapshotRepository {
+	mock := &MockAccountSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountSnapshotRepository) EXPECT() *MockAccountSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSnapshot mocks base method.
+func (m *MockAccountSnapshotRepository) CreateSnapshot(ctx context.Context, snapshot account.AccountSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSnapshot indicates an expected call of CreateSnapshot.
+func (mr *MockAccountSnapshotRepositoryMockRecorder) CreateSnapshot(ctx, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshot", reflect.TypeOf((*MockAccountSnapshotRepository)(nil).CreateSnapshot), ctx, snapshot)
+}
+
+// FindLatestSnapshot mocks base method.
+func (m *MockAccountSnapshotRepository) FindLatestSnapshot(ctx context.Context, id uuid.UUID) (account.AccountSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestSnapshot", ctx, id)
+	ret0, _ := ret[0].(account.AccountSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestSnapshot indicates an expected call of FindLatestSnapshot.
+func (mr *MockAccountSnapshotRepositoryMockRecorder) FindLatestSnapshot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestSnapshot", reflect.TypeOf((*MockAccountSnapshotRepository)(nil).FindLatestSnapshot), ctx, id)
 }
 
 // MockCustomerQueryRepository is a mock of CustomerQueryRepository interface.
@@ -164,3 +263,41 @@ func (mr *MockCustomerQueryRepositoryMockRecorder) FindByID(ctx, id any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCustomerQueryRepository)(nil).FindByID), ctx, id)
 }
+
+// MockAccessGuard is a mock of AccessGuard interface.
+type MockAccessGuard struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessGuardMockRecorder
+	isgomock struct{}
+}
+
+// MockAccessGuardMockRecorder is the mock recorder for MockAccessGuard.
+type MockAccessGuardMockRecorder struct {
+	mock *MockAccessGuard
+}
+
+// NewMockAccessGuard creates a new mock instance.
+func NewMockAccessGuard(ctrl *gomock.Controller) *MockAccessGuard {
+	mock := &MockAccessGuard{ctrl: ctrl}
+	mock.recorder = &MockAccessGuardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessGuard) EXPECT() *MockAccessGuardMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeOwner mocks base method.
+func (m *MockAccessGuard) AuthorizeOwner(ctx context.Context, resource identity.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeOwner", ctx, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeOwner indicates an expected call of AuthorizeOwner.
+func (mr *MockAccessGuardMockRecorder) AuthorizeOwner(ctx, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeOwner", reflect.TypeOf((*MockAccessGuard)(nil).AuthorizeOwner), ctx, resource)
+}
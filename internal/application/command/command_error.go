@@ -0,0 +1,11 @@
+package command
+
+import (
+	"errors"
+)
+
+// Command errors
+var (
+	// ErrCommandNotFound is returned when the event of a command is not found.
+	ErrCommandNotFound = errors.New("command not found")
+)
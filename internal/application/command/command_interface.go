@@ -0,0 +1,17 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+//go:generate mockgen -destination=./mock/command_service_mock.go -package=mock -source=./command_interface.go
+
+// CommandQueryRepository defines the interface for the queries of the events recorded by the commands
+type CommandQueryRepository interface {
+	// FindByID retrieves an event by its ID
+	FindByID(ctx context.Context, id uuid.UUID) (*eventdomain.BaseEvent, error)
+}
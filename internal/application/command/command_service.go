@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+const (
+	// MaxWait is the longest time a client can wait for a command to finish, it's shorter than the server write timeout
+	MaxWait = 10 * time.Second
+	// defaultPollInterval is how often the event of the command is checked while waiting for it to finish
+	defaultPollInterval = 100 * time.Millisecond
+)
+
+// CommandService handles the use cases of clients tracking the commands accepted for processing.
+// A command, i.e. a deposit, only records an event, which is processed later by the orchestrator,
+// so the command is tracked by the state of its event.
+type CommandService struct {
+	eventQueryRepo CommandQueryRepository
+	pollInterval   time.Duration
+}
+
+// NewCommandService creates a new command service
+func NewCommandService(eventQueryRepo CommandQueryRepository) *CommandService {
+	return &CommandService{
+		eventQueryRepo: eventQueryRepo,
+		pollInterval:   defaultPollInterval,
+	}
+}
+
+// GetCommandDTO represents the data needed to track a command.
+// Wait is how long to wait for the command to finish, up to MaxWait, the command isn't waited for when it's zero.
+type GetCommandDTO struct {
+	EventID uuid.UUID     `json:"eventId"`
+	Wait    time.Duration `json:"wait"`
+}
+
+// CommandResponseDTO represents the command returned to clients, ID is the ID of the event recorded by the command.
+// The command is finished when its event was processed (completed) or ended without being processed (failed),
+// the failure reason is the error of the last failed attempt to process the event.
+type CommandResponseDTO struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	State         string `json:"state"`
+	Finished      bool   `json:"finished"`
+	Retry         int    `json:"retry"`
+	MaxRetry      int    `json:"maxRetry"`
+	FailureReason string `json:"failureReason,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+	CompletedAt   string `json:"completedAt,omitempty"`
+}
+
+// GetCommand returns the command with the state of its event. When the wait is given, the command is returned
+// once it's finished or the wait is over, whichever comes first, so the client reads what the command wrote.
+func (s *CommandService) GetCommand(ctx context.Context, dto GetCommandDTO) (CommandResponseDTO, error) {
+	deadline := time.Now().Add(min(dto.Wait, MaxWait))
+
+	for {
+		ev, err := s.eventQueryRepo.FindByID(ctx, dto.EventID)
+		if err != nil {
+			if errors.Is(err, eventdomain.ErrEventNotFound) {
+				return CommandResponseDTO{}, fmt.Errorf("finding command event by id: %w", ErrCommandNotFound)
+			}
+			return CommandResponseDTO{}, fmt.Errorf("finding command event by id: %w", err)
+		}
+
+		remaining := time.Until(deadline)
+		if eventdomain.EventState(ev.GetState()).IsFinished() || remaining <= 0 {
+			return mapCommandToResponse(ev), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return mapCommandToResponse(ev), nil
+		case <-time.After(min(s.pollInterval, remaining)):
+		}
+	}
+}
+
+// mapCommandToResponse maps the event of the command to the response DTO
+func mapCommandToResponse(ev *eventdomain.BaseEvent) CommandResponseDTO {
+	response := CommandResponseDTO{
+		ID:            ev.GetID().String(),
+		Type:          ev.GetType(),
+		State:         ev.GetState(),
+		Finished:      eventdomain.EventState(ev.GetState()).IsFinished(),
+		Retry:         ev.GetRetry(),
+		MaxRetry:      ev.GetMaxRetry(),
+		FailureReason: ev.FailureReason,
+		CreatedAt:     ev.GetCreatedAt().Format(time.RFC3339),
+	}
+
+	if response.Finished && !ev.GetCompletedAt().IsZero() {
+		response.CompletedAt = ev.GetCompletedAt().Format(time.RFC3339)
+	}
+
+	return response
+}
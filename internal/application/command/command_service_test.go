@@ -0,0 +1,185 @@
+//go:build unit
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/application/command/mock"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+func testEvent(id uuid.UUID, state eventdomain.EventState) *eventdomain.BaseEvent {
+	now := time.Now().UTC()
+
+	ev := &eventdomain.BaseEvent{
+		ID:          id,
+		ContextID:   uuid.New(),
+		Origin:      "account",
+		Type:        "account.funds.deposited",
+		TypeVersion: "0.0.0",
+		State:       state.String(),
+		CreatedAt:   now,
+		ScheduledAt: now,
+		MaxRetry:    3,
+	}
+
+	if state.IsFinished() {
+		ev.CompletedAt = now
+	}
+
+	return ev
+}
+
+func TestCommandService_GetCommand(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		dto                  GetCommandDTO
+		mockCommandQueryRepo func(*gomock.Controller) *mock.MockCommandQueryRepository
+	}
+
+	type testCaseExpected struct {
+		state         string
+		finished      bool
+		failureReason string
+		err           error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should return command without waiting",
+			params: testCaseParams{
+				dto: GetCommandDTO{EventID: eventID},
+				mockCommandQueryRepo: func(m *gomock.Controller) *mock.MockCommandQueryRepository {
+					mock := mock.NewMockCommandQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), eventID).Return(testEvent(eventID, eventdomain.EventStateReady), nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				state: eventdomain.EventStateReady.String(),
+			},
+		},
+		{
+			name: "should wait until command is completed",
+			params: testCaseParams{
+				dto: GetCommandDTO{EventID: eventID, Wait: time.Second},
+				mockCommandQueryRepo: func(m *gomock.Controller) *mock.MockCommandQueryRepository {
+					mock := mock.NewMockCommandQueryRepository(m)
+					gomock.InOrder(
+						mock.EXPECT().FindByID(gomock.Any(), eventID).Return(testEvent(eventID, eventdomain.EventStateReady), nil),
+						mock.EXPECT().FindByID(gomock.Any(), eventID).Return(testEvent(eventID, eventdomain.EventStateProcessing), nil),
+						mock.EXPECT().FindByID(gomock.Any(), eventID).Return(testEvent(eventID, eventdomain.EventStateCompleted), nil),
+					)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				state:    eventdomain.EventStateCompleted.String(),
+				finished: true,
+			},
+		},
+		{
+			name: "should wait until command is failed",
+			params: testCaseParams{
+				dto: GetCommandDTO{EventID: eventID, Wait: time.Second},
+				mockCommandQueryRepo: func(m *gomock.Controller) *mock.MockCommandQueryRepository {
+					failed := testEvent(eventID, eventdomain.EventStateFailed)
+					failed.Retry = 3
+					failed.FailureReason = "account not found"
+
+					mock := mock.NewMockCommandQueryRepository(m)
+					gomock.InOrder(
+						mock.EXPECT().FindByID(gomock.Any(), eventID).Return(testEvent(eventID, eventdomain.EventStateReady), nil),
+						mock.EXPECT().FindByID(gomock.Any(), eventID).Return(failed, nil),
+					)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				state:         eventdomain.EventStateFailed.String(),
+				finished:      true,
+				failureReason: "account not found",
+			},
+		},
+		{
+			name: "should return unfinished command once wait is over",
+			params: testCaseParams{
+				dto: GetCommandDTO{EventID: eventID, Wait: 30 * time.Millisecond},
+				mockCommandQueryRepo: func(m *gomock.Controller) *mock.MockCommandQueryRepository {
+					mock := mock.NewMockCommandQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), eventID).Return(testEvent(eventID, eventdomain.EventStateReady), nil).MinTimes(2)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				state: eventdomain.EventStateReady.String(),
+			},
+		},
+		{
+			name: "should fail when command is not found",
+			params: testCaseParams{
+				dto: GetCommandDTO{EventID: eventID, Wait: time.Second},
+				mockCommandQueryRepo: func(m *gomock.Controller) *mock.MockCommandQueryRepository {
+					mock := mock.NewMockCommandQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), eventID).Return(nil, fmt.Errorf("finding event by id: %w", eventdomain.ErrEventNotFound))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrCommandNotFound,
+			},
+		},
+		{
+			name: "should fail when command can't be found",
+			params: testCaseParams{
+				dto: GetCommandDTO{EventID: eventID},
+				mockCommandQueryRepo: func(m *gomock.Controller) *mock.MockCommandQueryRepository {
+					mock := mock.NewMockCommandQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), eventID).Return(nil, errors.New("connection refused"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				err: errors.New("connection refused"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewCommandService(tt.params.mockCommandQueryRepo(ctrl))
+			service.pollInterval = 5 * time.Millisecond
+
+			response, err := service.GetCommand(context.Background(), tt.params.dto)
+			if tt.expected.err != nil {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tt.expected.err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, eventID.String(), response.ID)
+			require.Equal(t, tt.expected.state, response.State)
+			require.Equal(t, tt.expected.finished, response.Finished)
+			require.Equal(t, tt.expected.failureReason, response.FailureReason)
+			require.Equal(t, tt.expected.finished, response.CompletedAt != "")
+		})
+	}
+}
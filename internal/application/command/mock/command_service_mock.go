@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./command_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/command_service_mock.go -package=mock -source=./command_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	event "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommandQueryRepository is a mock of CommandQueryRepository interface.
+type MockCommandQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandQueryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCommandQueryRepositoryMockRecorder is the mock recorder for MockCommandQueryRepository.
+type MockCommandQueryRepositoryMockRecorder struct {
+	mock *MockCommandQueryRepository
+}
+
+// NewMockCommandQueryRepository creates a new mock instance.
+func NewMockCommandQueryRepository(ctrl *gomock.Controller) *MockCommandQueryRepository {
+	mock := &MockCommandQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockCommandQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandQueryRepository) EXPECT() *MockCommandQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockCommandQueryRepository) FindByID(ctx context.Context, id uuid.UUID) (*event.BaseEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*event.BaseEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCommandQueryRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCommandQueryRepository)(nil).FindByID), ctx, id)
+}
@@ -0,0 +1,15 @@
+package credentials
+
+import (
+	"errors"
+)
+
+// Credentials errors
+var (
+	// ErrCustomerNotFound is returned when the customer the credentials are set for is not found.
+	ErrCustomerNotFound = errors.New("customer not found")
+	// ErrCredentialsNotFound is returned when the customer has no credentials, i.e. the TOTP is enrolled before the password is set.
+	ErrCredentialsNotFound = errors.New("credentials not found")
+	// ErrConcurrencyConflict is returned when the credentials were changed concurrently, i.e. by another login of the customer.
+	ErrConcurrencyConflict = errors.New("credentials were changed concurrently")
+)
@@ -0,0 +1,520 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+type Credentials = credentialsdomain.Credentials
+type Session = credentialsdomain.Session
+
+// TOTPIssuer is the issuer the authenticator app of the customer shows the TOTP codes under
+const TOTPIssuer = "ddd-bank"
+
+// tokenType is the type of the access token issued, it's sent in the Authorization header as the bearer token
+const tokenType = "Bearer"
+
+// dummyPasswordHash is checked against the password of the login of the unknown customer, so the login of the unknown customer
+// takes as long as the login of the known one and the response time doesn't tell which customers exist
+var dummyPasswordHash = mustHashPassword("dummy password of the unknown customer")
+
+func mustHashPassword(password string) string {
+	hash, err := credentialsdomain.HashPassword(password)
+	if err != nil {
+		panic(err)
+	}
+
+	return hash
+}
+
+// CredentialsService handles the online banking credentials of the customers: setting the password, the TOTP second factor
+// and the sessions of the customers logged in
+type CredentialsService struct {
+	credentialsRepo   CredentialsRepository
+	sessionRepo       SessionRepository
+	customerQueryRepo CustomerQueryRepository
+
+	accessGuard AccessGuard
+
+	lockoutPolicy credentialsdomain.LockoutPolicy
+	sessionPolicy credentialsdomain.SessionPolicy
+}
+
+// NewCredentialsService creates a new credentials service, the credentials are locked after the failed logins by the lockout policy
+// and the tokens of the sessions expire by the session policy
+func NewCredentialsService(
+	credentialsRepo CredentialsRepository,
+	sessionRepo SessionRepository,
+	customerQueryRepo CustomerQueryRepository,
+	accessGuard AccessGuard,
+	lockoutPolicy credentialsdomain.LockoutPolicy,
+	sessionPolicy credentialsdomain.SessionPolicy) *CredentialsService {
+	return &CredentialsService{
+		credentialsRepo:   credentialsRepo,
+		sessionRepo:       sessionRepo,
+		customerQueryRepo: customerQueryRepo,
+		accessGuard:       accessGuard,
+		lockoutPolicy:     lockoutPolicy,
+		sessionPolicy:     sessionPolicy,
+	}
+}
+
+type SetPasswordDTO struct {
+	CustomerID string
+	Password   string
+}
+
+// SetPassword sets the password of the customer, creating the credentials of the customer logging in for the first time.
+// Setting the password unlocks the credentials and ends all the sessions of the customer.
+func (s *CredentialsService) SetPassword(ctx context.Context, dto SetPasswordDTO) error {
+	customerID := uuid.MustParse(dto.CustomerID)
+
+	if err := s.authorize(ctx, customerID); err != nil {
+		return err
+	}
+
+	_, err := s.customerQueryRepo.FindByID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, customerdomain.ErrCustomerNotFound) {
+			return fmt.Errorf("finding customer by id: %w", ErrCustomerNotFound)
+		}
+
+		return fmt.Errorf("finding customer by id: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	credentials, err := s.credentialsRepo.FindCredentialsByCustomerID(ctx, customerID)
+	if err != nil {
+		if !errors.Is(err, credentialsdomain.ErrCredentialsNotFound) {
+			return fmt.Errorf("finding credentials: %w", err)
+		}
+
+		credentials = credentialsdomain.NewCredentials(customerID, now)
+	}
+
+	expectedVersion := credentials.Version
+
+	if err := credentials.SetPassword(dto.Password, now); err != nil {
+		return fmt.Errorf("setting password: %w", err)
+	}
+
+	if err := s.save(ctx, expectedVersion, credentials); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeCustomerSessions(ctx, customerID, now); err != nil {
+		return fmt.Errorf("revoking customer sessions: %w", err)
+	}
+
+	return nil
+}
+
+type EnrollTOTPDTO struct {
+	CustomerID string
+}
+
+type EnrollTOTPResponseDTO struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// EnrollTOTP starts the enrollment of the TOTP second factor of the customer, it returns the secret the authenticator app
+// is enrolled with. The TOTP is enabled once the enrollment is confirmed with the code of the app.
+func (s *CredentialsService) EnrollTOTP(ctx context.Context, dto EnrollTOTPDTO) (EnrollTOTPResponseDTO, error) {
+	customerID := uuid.MustParse(dto.CustomerID)
+
+	credentials, err := s.findCredentials(ctx, customerID)
+	if err != nil {
+		return EnrollTOTPResponseDTO{}, err
+	}
+
+	expectedVersion := credentials.Version
+
+	secret, err := credentials.EnrollTOTP(time.Now().UTC())
+	if err != nil {
+		return EnrollTOTPResponseDTO{}, fmt.Errorf("enrolling totp: %w", err)
+	}
+
+	if err := s.save(ctx, expectedVersion, credentials); err != nil {
+		return EnrollTOTPResponseDTO{}, err
+	}
+
+	return EnrollTOTPResponseDTO{
+		Secret: secret,
+		URI:    credentialsdomain.TOTPURI(TOTPIssuer, customerID.String(), secret),
+	}, nil
+}
+
+type ConfirmTOTPDTO struct {
+	CustomerID string
+	Code       string
+}
+
+// ConfirmTOTP enables the TOTP second factor enrolled, the login requires the TOTP code after the password from then on
+func (s *CredentialsService) ConfirmTOTP(ctx context.Context, dto ConfirmTOTPDTO) error {
+	customerID := uuid.MustParse(dto.CustomerID)
+
+	credentials, err := s.findCredentials(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	expectedVersion := credentials.Version
+
+	if err := credentials.ConfirmTOTP(dto.Code, time.Now().UTC()); err != nil {
+		return fmt.Errorf("confirming totp: %w", err)
+	}
+
+	return s.save(ctx, expectedVersion, credentials)
+}
+
+type LoginDTO struct {
+	Email    string
+	Password string
+}
+
+type SessionResponseDTO struct {
+	AccessToken      string    `json:"accessToken"`
+	RefreshToken     string    `json:"refreshToken"`
+	TokenType        string    `json:"tokenType"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
+}
+
+type LoginResponseDTO struct {
+	MFARequired  bool                `json:"mfaRequired"`
+	MFAToken     string              `json:"mfaToken,omitempty"`
+	MFAExpiresAt *time.Time          `json:"mfaExpiresAt,omitempty"`
+	Session      *SessionResponseDTO `json:"session,omitempty"`
+}
+
+func toSessionDTO(tokens credentialsdomain.SessionTokens) *SessionResponseDTO {
+	return &SessionResponseDTO{
+		AccessToken:      tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		TokenType:        tokenType,
+		ExpiresAt:        tokens.AccessExpiresAt,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+	}
+}
+
+// Login logs the customer in with the email and the password. The customer with the TOTP enabled gets the MFA token,
+// the session is issued once the TOTP code is verified with it, the other customer gets the session right away.
+// It returns identitydomain.ErrInvalidCredentials for the unknown or inactive customer and the wrong password alike,
+// and credentialsdomain.ErrCredentialsLocked once the customer failed to log in too many times.
+func (s *CredentialsService) Login(ctx context.Context, dto LoginDTO) (LoginResponseDTO, error) {
+	now := time.Now().UTC()
+
+	credentials, err := s.findLoginCredentials(ctx, dto.Email)
+	if err != nil {
+		return LoginResponseDTO{}, err
+	}
+
+	if credentials == nil {
+		_, _ = credentialsdomain.CheckPassword(dummyPasswordHash, dto.Password)
+		return LoginResponseDTO{}, fmt.Errorf("logging in: %w", identitydomain.ErrInvalidCredentials)
+	}
+
+	expectedVersion := credentials.Version
+
+	err = credentials.VerifyPassword(dto.Password, s.lockoutPolicy, now)
+	if err != nil {
+		if errors.Is(err, credentialsdomain.ErrWrongPassword) {
+			return LoginResponseDTO{}, s.reject(ctx, credentials)
+		}
+
+		return LoginResponseDTO{}, fmt.Errorf("verifying password: %w", err)
+	}
+
+	if credentials.MFARequired() {
+		session, tokens, err := credentialsdomain.NewSession(credentials.CustomerID, true, s.sessionPolicy, now)
+		if err != nil {
+			return LoginResponseDTO{}, fmt.Errorf("creating session: %w", err)
+		}
+
+		if err := s.sessionRepo.CreateSession(ctx, *session); err != nil {
+			return LoginResponseDTO{}, fmt.Errorf("creating session: %w", err)
+		}
+
+		return LoginResponseDTO{
+			MFARequired:  true,
+			MFAToken:     tokens.AccessToken,
+			MFAExpiresAt: &tokens.AccessExpiresAt,
+		}, nil
+	}
+
+	credentials.Succeed(now)
+
+	if err := s.save(ctx, expectedVersion, credentials); err != nil {
+		return LoginResponseDTO{}, err
+	}
+
+	session, tokens, err := credentialsdomain.NewSession(credentials.CustomerID, false, s.sessionPolicy, now)
+	if err != nil {
+		return LoginResponseDTO{}, fmt.Errorf("creating session: %w", err)
+	}
+
+	if err := s.sessionRepo.CreateSession(ctx, *session); err != nil {
+		return LoginResponseDTO{}, fmt.Errorf("creating session: %w", err)
+	}
+
+	return LoginResponseDTO{Session: toSessionDTO(tokens)}, nil
+}
+
+type VerifyMFADTO struct {
+	MFAToken string
+	Code     string
+}
+
+// VerifyMFA completes the login of the customer with the TOTP enabled, the TOTP code is verified with the MFA token of the login.
+// It returns identitydomain.ErrInvalidCredentials when the MFA token isn't valid or the code doesn't match,
+// and credentialsdomain.ErrCredentialsLocked once the customer failed to log in too many times.
+func (s *CredentialsService) VerifyMFA(ctx context.Context, dto VerifyMFADTO) (SessionResponseDTO, error) {
+	now := time.Now().UTC()
+
+	session, err := s.findSession(ctx, s.sessionRepo.FindSessionByAccessToken, dto.MFAToken)
+	if err != nil {
+		return SessionResponseDTO{}, err
+	}
+
+	if !session.IsAwaitingMFA(now) {
+		return SessionResponseDTO{}, fmt.Errorf("verifying mfa token: %w", identitydomain.ErrInvalidCredentials)
+	}
+
+	credentials, err := s.credentialsRepo.FindCredentialsByCustomerID(ctx, session.CustomerID)
+	if err != nil {
+		if errors.Is(err, credentialsdomain.ErrCredentialsNotFound) {
+			return SessionResponseDTO{}, fmt.Errorf("finding credentials: %w", identitydomain.ErrInvalidCredentials)
+		}
+
+		return SessionResponseDTO{}, fmt.Errorf("finding credentials: %w", err)
+	}
+
+	expectedVersion := credentials.Version
+
+	err = credentials.VerifyTOTP(dto.Code, s.lockoutPolicy, now)
+	if err != nil {
+		if errors.Is(err, credentialsdomain.ErrWrongTOTPCode) {
+			return SessionResponseDTO{}, s.reject(ctx, credentials)
+		}
+
+		if errors.Is(err, credentialsdomain.ErrTOTPNotEnrolled) {
+			return SessionResponseDTO{}, fmt.Errorf("verifying totp code: %w", identitydomain.ErrInvalidCredentials)
+		}
+
+		return SessionResponseDTO{}, fmt.Errorf("verifying totp code: %w", err)
+	}
+
+	credentials.Succeed(now)
+
+	// The credentials are stored with the step of the code first, so the code can't complete another login concurrently
+	if err := s.save(ctx, expectedVersion, credentials); err != nil {
+		return SessionResponseDTO{}, err
+	}
+
+	return s.rotate(ctx, session, now)
+}
+
+type RefreshSessionDTO struct {
+	RefreshToken string
+}
+
+// RefreshSession issues the new tokens of the session with its refresh token, the former tokens aren't valid anymore.
+// It returns identitydomain.ErrInvalidCredentials when the refresh token isn't valid, i.e. it's expired or it was already used.
+func (s *CredentialsService) RefreshSession(ctx context.Context, dto RefreshSessionDTO) (SessionResponseDTO, error) {
+	now := time.Now().UTC()
+
+	session, err := s.findSession(ctx, s.sessionRepo.FindSessionByRefreshToken, dto.RefreshToken)
+	if err != nil {
+		return SessionResponseDTO{}, err
+	}
+
+	if !session.CanRefresh(now) {
+		return SessionResponseDTO{}, fmt.Errorf("verifying refresh token: %w", identitydomain.ErrInvalidCredentials)
+	}
+
+	return s.rotate(ctx, session, now)
+}
+
+type LogoutDTO struct {
+	RefreshToken string
+}
+
+// Logout ends the session of the refresh token, the tokens of the session aren't valid anymore.
+// The session which doesn't exist or already ended is ignored, so the logout can be repeated.
+func (s *CredentialsService) Logout(ctx context.Context, dto LogoutDTO) error {
+	session, err := s.findSession(ctx, s.sessionRepo.FindSessionByRefreshToken, dto.RefreshToken)
+	if err != nil {
+		if errors.Is(err, identitydomain.ErrInvalidCredentials) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeSession(ctx, session.ID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("revoking session: %w", err)
+	}
+
+	return nil
+}
+
+// AuthenticateSession returns the principal of the customer the access token of the session was issued to.
+// It returns identitydomain.ErrInvalidCredentials when the access token isn't valid, i.e. it's expired or the session ended.
+func (s *CredentialsService) AuthenticateSession(ctx context.Context, accessToken string) (identitydomain.Principal, error) {
+	session, err := s.findSession(ctx, s.sessionRepo.FindSessionByAccessToken, accessToken)
+	if err != nil {
+		return identitydomain.Principal{}, err
+	}
+
+	if !session.IsActive(time.Now().UTC()) {
+		return identitydomain.Principal{}, fmt.Errorf("verifying access token: %w", identitydomain.ErrInvalidCredentials)
+	}
+
+	return identitydomain.Principal{
+		ID:         session.CustomerID.String(),
+		Type:       identitydomain.PrincipalTypeUser,
+		Roles:      []identitydomain.Role{identitydomain.RoleCustomer},
+		CustomerID: session.CustomerID,
+	}, nil
+}
+
+// authorize authorizes the access to the credentials of the customer, the credentials of another customer aren't found
+func (s *CredentialsService) authorize(ctx context.Context, customerID uuid.UUID) error {
+	err := s.accessGuard.AuthorizeOwner(ctx, identitydomain.Resource{
+		Type:       identitydomain.ResourceTypeCustomer,
+		ID:         customerID,
+		CustomerID: customerID,
+	})
+	if err != nil {
+		if errors.Is(err, identitydomain.ErrNotOwner) {
+			return fmt.Errorf("authorizing credentials access: %w", ErrCustomerNotFound)
+		}
+
+		return fmt.Errorf("authorizing credentials access: %w", err)
+	}
+
+	return nil
+}
+
+// findCredentials retrieves the credentials of the customer once the access to them is authorized,
+// the credentials of the customer who hasn't set the password yet are reported with ErrCredentialsNotFound
+func (s *CredentialsService) findCredentials(ctx context.Context, customerID uuid.UUID) (*Credentials, error) {
+	if err := s.authorize(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.credentialsRepo.FindCredentialsByCustomerID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, credentialsdomain.ErrCredentialsNotFound) {
+			return nil, fmt.Errorf("finding credentials: %w", ErrCredentialsNotFound)
+		}
+
+		return nil, fmt.Errorf("finding credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// findLoginCredentials retrieves the credentials of the active customer with the email,
+// it returns nil credentials when the customer is unknown, isn't active or hasn't set the password yet
+func (s *CredentialsService) findLoginCredentials(ctx context.Context, email string) (*Credentials, error) {
+	customer, err := s.customerQueryRepo.FindByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, customerdomain.ErrCustomerNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("finding customer by email: %w", err)
+	}
+
+	if customer.Status != customerdomain.CustomerStatusActive {
+		return nil, nil
+	}
+
+	credentials, err := s.credentialsRepo.FindCredentialsByCustomerID(ctx, customer.ID)
+	if err != nil {
+		if errors.Is(err, credentialsdomain.ErrCredentialsNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("finding credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// findSession retrieves the session by the hash of the token, the token of no session is reported with identitydomain.ErrInvalidCredentials
+func (s *CredentialsService) findSession(ctx context.Context, find func(context.Context, string) (Session, error), token string) (Session, error) {
+	session, err := find(ctx, credentialsdomain.HashToken(token))
+	if err != nil {
+		if errors.Is(err, credentialsdomain.ErrSessionNotFound) {
+			return Session{}, fmt.Errorf("finding session: %w", identitydomain.ErrInvalidCredentials)
+		}
+
+		return Session{}, fmt.Errorf("finding session: %w", err)
+	}
+
+	return session, nil
+}
+
+// rotate issues the new tokens of the session. The session rotated concurrently is reported with identitydomain.ErrInvalidCredentials,
+// the token was used by the other request, so its tokens are issued once.
+func (s *CredentialsService) rotate(ctx context.Context, session Session, now time.Time) (SessionResponseDTO, error) {
+	expectedVersion := session.Version
+
+	tokens, err := session.Rotate(s.sessionPolicy, now)
+	if err != nil {
+		return SessionResponseDTO{}, fmt.Errorf("rotating session tokens: %w", err)
+	}
+
+	if err := s.sessionRepo.UpdateSession(ctx, expectedVersion, session); err != nil {
+		if errors.Is(err, eventdomain.ErrConcurrencyConflict) {
+			return SessionResponseDTO{}, fmt.Errorf("updating session: %w", identitydomain.ErrInvalidCredentials)
+		}
+
+		return SessionResponseDTO{}, fmt.Errorf("updating session: %w", err)
+	}
+
+	return *toSessionDTO(tokens), nil
+}
+
+// reject stores the failed login counted towards the lockout and returns identitydomain.ErrInvalidCredentials. The failed login
+// is counted whatever the version the credentials were found in, so the concurrent failed logins are all counted and rejected alike.
+func (s *CredentialsService) reject(ctx context.Context, credentials *Credentials) error {
+	if err := s.credentialsRepo.SaveFailedLogin(ctx, credentials, s.lockoutPolicy); err != nil {
+		if errors.Is(err, credentialsdomain.ErrCredentialsNotFound) {
+			return fmt.Errorf("saving failed login: %w", identitydomain.ErrInvalidCredentials)
+		}
+
+		return fmt.Errorf("saving failed login: %w", err)
+	}
+
+	return fmt.Errorf("logging in: %w", identitydomain.ErrInvalidCredentials)
+}
+
+// save stores the credentials expecting them in the version they were found in, the credentials changed concurrently
+// are reported with ErrConcurrencyConflict
+func (s *CredentialsService) save(ctx context.Context, expectedVersion int, credentials *Credentials) error {
+	err := s.credentialsRepo.SaveCredentials(ctx, expectedVersion, credentials)
+	if err != nil {
+		if errors.Is(err, eventdomain.ErrConcurrencyConflict) {
+			return fmt.Errorf("saving credentials: %w: %w", ErrConcurrencyConflict, err)
+		}
+
+		return fmt.Errorf("saving credentials: %w", err)
+	}
+
+	return nil
+}
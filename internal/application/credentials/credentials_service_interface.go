@@ -0,0 +1,76 @@
+package credentials
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+//go:generate mockgen -destination=./mock/credentials_service_mock.go -package=mock -source=./credentials_service_interface.go
+
+//             Credentials
+
+// CredentialsRepository defines the interface for customer credentials persistence
+type CredentialsRepository interface {
+	// FindCredentialsByCustomerID retrieves the credentials of a customer,
+	// it returns credentialsdomain.ErrCredentialsNotFound when the customer has no credentials
+	FindCredentialsByCustomerID(ctx context.Context, customerID uuid.UUID) (*credentialsdomain.Credentials, error)
+
+	// SaveCredentials persists the credentials together with their events, expecting the credentials in the version they were found in
+	// (zero for the new credentials), it returns event.ErrConcurrencyConflict when the credentials were changed in the meantime
+	SaveCredentials(ctx context.Context, expectedVersion int, credentials *credentialsdomain.Credentials) error
+
+	// SaveFailedLogin counts the failed login recorded by the credentials towards the lockout by the policy and persists its event.
+	// The failed login is counted atomically whatever the version the credentials were found in, so the failed logins of the concurrent
+	// logins are all counted. The credentials are updated to the stored count and version.
+	SaveFailedLogin(ctx context.Context, credentials *credentialsdomain.Credentials, policy credentialsdomain.LockoutPolicy) error
+}
+
+// SessionRepository defines the interface for customer session persistence
+type SessionRepository interface {
+	// CreateSession persists the new session
+	CreateSession(ctx context.Context, session credentialsdomain.Session) error
+
+	// FindSessionByAccessToken retrieves the session by the hash of its access token,
+	// it returns credentialsdomain.ErrSessionNotFound when no session has the token
+	FindSessionByAccessToken(ctx context.Context, accessTokenHash string) (credentialsdomain.Session, error)
+
+	// FindSessionByRefreshToken retrieves the session by the hash of its refresh token,
+	// it returns credentialsdomain.ErrSessionNotFound when no session has the token
+	FindSessionByRefreshToken(ctx context.Context, refreshTokenHash string) (credentialsdomain.Session, error)
+
+	// UpdateSession persists the tokens of the session expecting the session in the version it was found in,
+	// it returns event.ErrConcurrencyConflict when the session was changed in the meantime, i.e. its tokens were already rotated
+	UpdateSession(ctx context.Context, expectedVersion int, session credentialsdomain.Session) error
+
+	// RevokeSession revokes the session, the session already revoked stays revoked at the former time
+	RevokeSession(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+
+	// RevokeCustomerSessions revokes all the sessions of the customer, i.e. when the password is changed
+	RevokeCustomerSessions(ctx context.Context, customerID uuid.UUID, revokedAt time.Time) error
+}
+
+//             Customer
+
+// CustomerQueryRepository defines the interface for customer queries
+type CustomerQueryRepository interface {
+	// FindByID retrieves a customer by its ID
+	FindByID(ctx context.Context, id uuid.UUID) (*customerdomain.Customer, error)
+
+	// FindByEmail retrieves a customer by its email
+	FindByEmail(ctx context.Context, email string) (*customerdomain.Customer, error)
+}
+
+//             Access
+
+// AccessGuard defines the interface for authorizing the access to the resources owned by the customers
+type AccessGuard interface {
+	// AuthorizeOwner authorizes the access of the principal of the context to the resource,
+	// it returns identitydomain.ErrNotOwner when the principal isn't allowed to access it
+	AuthorizeOwner(ctx context.Context, resource identitydomain.Resource) error
+}
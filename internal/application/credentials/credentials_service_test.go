@@ -0,0 +1,921 @@
+//go:build unit
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/application/credentials/mock"
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+const (
+	testCustomerID = "00000000-0000-0000-0000-000000000001"
+	testEmail      = "john.doe@example.com"
+	testPassword   = "correct horse battery"
+)
+
+// testCustomer returns the test customer in the status
+func testCustomer(status customerdomain.CustomerStatus) *customerdomain.Customer {
+	return &customerdomain.Customer{ID: uuid.MustParse(testCustomerID), Email: testEmail, Status: status}
+}
+
+// testCredentials returns the credentials of the test customer with the test password set, with the TOTP enabled when asked
+func testCredentials(t *testing.T, totp bool) *credentialsdomain.Credentials {
+	t.Helper()
+
+	now := time.Now().UTC()
+
+	c := credentialsdomain.NewCredentials(uuid.MustParse(testCustomerID), now.Add(-time.Hour))
+	require.NoError(t, c.SetPassword(testPassword, now.Add(-time.Hour)))
+
+	if totp {
+		secret, err := c.EnrollTOTP(now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		code, err := credentialsdomain.TOTPCode(secret, credentialsdomain.TOTPStep(now.Add(-time.Hour)))
+		require.NoError(t, err)
+		require.NoError(t, c.ConfirmTOTP(code, now.Add(-time.Hour)))
+	}
+
+	c.ClearEvents()
+	c.Version = 3
+
+	return c
+}
+
+// testSession returns the session of the test customer, awaiting the TOTP code when asked, and its tokens
+func testSession(t *testing.T, mfaPending bool) (credentialsdomain.Session, credentialsdomain.SessionTokens) {
+	t.Helper()
+
+	s, tokens, err := credentialsdomain.NewSession(uuid.MustParse(testCustomerID), mfaPending, credentialsdomain.DefaultSessionPolicy(), time.Now().UTC())
+	require.NoError(t, err)
+	s.Version = 1
+
+	return *s, tokens
+}
+
+// testAllowedAccess returns the access guard allowing the access to any customer
+func testAllowedAccess(ctrl *gomock.Controller) *mock.MockAccessGuard {
+	m := mock.NewMockAccessGuard(ctrl)
+	m.EXPECT().AuthorizeOwner(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return m
+}
+
+func TestCredentialsService_SetPassword(t *testing.T) {
+	type testCaseParams struct {
+		dto SetPasswordDTO
+
+		mockAccessGuard       func(*gomock.Controller) *mock.MockAccessGuard
+		mockCustomerQueryRepo func(*gomock.Controller) *mock.MockCustomerQueryRepository
+		mockCredentialsRepo   func(*gomock.Controller) *mock.MockCredentialsRepository
+		mockSessionRepo       func(*gomock.Controller) *mock.MockSessionRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+		err       error
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should create credentials of customer logging in for the first time",
+			params: testCaseParams{
+				dto:             SetPasswordDTO{CustomerID: testCustomerID, Password: testPassword},
+				mockAccessGuard: testAllowedAccess,
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), uuid.MustParse(testCustomerID)).Return(testCustomer(customerdomain.CustomerStatusActive), nil)
+					return m
+				},
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), uuid.MustParse(testCustomerID)).Return(nil, credentialsdomain.ErrCredentialsNotFound)
+					m.EXPECT().SaveCredentials(gomock.Any(), 0, gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ int, c *credentialsdomain.Credentials) error {
+							require.Len(t, c.Events, 1)
+							require.Equal(t, credentialsdomain.CredentialsSetEventType.String(), c.Events[0].GetType())
+							return nil
+						},
+					)
+					return m
+				},
+				mockSessionRepo: func(ctrl *gomock.Controller) *mock.MockSessionRepository {
+					m := mock.NewMockSessionRepository(ctrl)
+					m.EXPECT().RevokeCustomerSessions(gomock.Any(), uuid.MustParse(testCustomerID), gomock.Any()).Return(nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should change password and end sessions",
+			params: testCaseParams{
+				dto:             SetPasswordDTO{CustomerID: testCustomerID, Password: "another horse battery"},
+				mockAccessGuard: testAllowedAccess,
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(testCustomer(customerdomain.CustomerStatusActive), nil)
+					return m
+				},
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(testCredentials(t, false), nil)
+					m.EXPECT().SaveCredentials(gomock.Any(), 3, gomock.Any()).Return(nil)
+					return m
+				},
+				mockSessionRepo: func(ctrl *gomock.Controller) *mock.MockSessionRepository {
+					m := mock.NewMockSessionRepository(ctrl)
+					m.EXPECT().RevokeCustomerSessions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't set password - credentials of another customer",
+			params: testCaseParams{
+				dto: SetPasswordDTO{CustomerID: testCustomerID, Password: testPassword},
+				mockAccessGuard: func(ctrl *gomock.Controller) *mock.MockAccessGuard {
+					m := mock.NewMockAccessGuard(ctrl)
+					m.EXPECT().AuthorizeOwner(gomock.Any(), gomock.Any()).Return(identitydomain.ErrNotOwner)
+					return m
+				},
+				mockCustomerQueryRepo: mock.NewMockCustomerQueryRepository,
+				mockCredentialsRepo:   mock.NewMockCredentialsRepository,
+				mockSessionRepo:       mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       ErrCustomerNotFound,
+			},
+		},
+		{
+			name: "shouldn't set password - customer not found",
+			params: testCaseParams{
+				dto:             SetPasswordDTO{CustomerID: testCustomerID, Password: testPassword},
+				mockAccessGuard: testAllowedAccess,
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, customerdomain.ErrCustomerNotFound)
+					return m
+				},
+				mockCredentialsRepo: mock.NewMockCredentialsRepository,
+				mockSessionRepo:     mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       ErrCustomerNotFound,
+			},
+		},
+		{
+			name: "shouldn't set password - weak password",
+			params: testCaseParams{
+				dto:             SetPasswordDTO{CustomerID: testCustomerID, Password: "short"},
+				mockAccessGuard: testAllowedAccess,
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(testCustomer(customerdomain.CustomerStatusActive), nil)
+					return m
+				},
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(testCredentials(t, false), nil)
+					return m
+				},
+				mockSessionRepo: mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       credentialsdomain.ErrWeakPassword,
+			},
+		},
+		{
+			name: "shouldn't set password - credentials changed concurrently",
+			params: testCaseParams{
+				dto:             SetPasswordDTO{CustomerID: testCustomerID, Password: testPassword},
+				mockAccessGuard: testAllowedAccess,
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(testCustomer(customerdomain.CustomerStatusActive), nil)
+					return m
+				},
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(testCredentials(t, false), nil)
+					m.EXPECT().SaveCredentials(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrConcurrencyConflict)
+					return m
+				},
+				mockSessionRepo: mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       ErrConcurrencyConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewCredentialsService(
+				tt.params.mockCredentialsRepo(ctrl),
+				tt.params.mockSessionRepo(ctrl),
+				tt.params.mockCustomerQueryRepo(ctrl),
+				tt.params.mockAccessGuard(ctrl),
+				credentialsdomain.DefaultLockoutPolicy(),
+				credentialsdomain.DefaultSessionPolicy(),
+			)
+
+			err := service.SetPassword(context.Background(), tt.params.dto)
+
+			if tt.expected.wantError {
+				require.Error(t, err)
+				require.ErrorIs(t, err, tt.expected.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCredentialsService_EnrollTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	credentialsRepo := mock.NewMockCredentialsRepository(ctrl)
+	credentialsRepo.EXPECT().FindCredentialsByCustomerID(gomock.Any(), uuid.MustParse(testCustomerID)).Return(testCredentials(t, false), nil)
+	credentialsRepo.EXPECT().SaveCredentials(gomock.Any(), 3, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, c *credentialsdomain.Credentials) error {
+			require.NotEmpty(t, c.TOTPSecret)
+			require.False(t, c.TOTPEnabled)
+			return nil
+		},
+	)
+
+	service := NewCredentialsService(
+		credentialsRepo,
+		mock.NewMockSessionRepository(ctrl),
+		mock.NewMockCustomerQueryRepository(ctrl),
+		testAllowedAccess(ctrl),
+		credentialsdomain.DefaultLockoutPolicy(),
+		credentialsdomain.DefaultSessionPolicy(),
+	)
+
+	resp, err := service.EnrollTOTP(context.Background(), EnrollTOTPDTO{CustomerID: testCustomerID})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Secret)
+	require.Contains(t, resp.URI, "otpauth://totp/"+TOTPIssuer)
+	require.Contains(t, resp.URI, "secret="+resp.Secret)
+}
+
+func TestCredentialsService_Login(t *testing.T) {
+	type testCaseParams struct {
+		dto LoginDTO
+
+		mockCustomerQueryRepo func(*gomock.Controller) *mock.MockCustomerQueryRepository
+		mockCredentialsRepo   func(*gomock.Controller) *mock.MockCredentialsRepository
+		mockSessionRepo       func(*gomock.Controller) *mock.MockSessionRepository
+	}
+
+	type testCaseExpected struct {
+		wantError   bool
+		err         error
+		mfaRequired bool
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	activeCustomer := func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+		m := mock.NewMockCustomerQueryRepository(ctrl)
+		m.EXPECT().FindByEmail(gomock.Any(), testEmail).Return(testCustomer(customerdomain.CustomerStatusActive), nil)
+		return m
+	}
+
+	createdSession := func(ctrl *gomock.Controller) *mock.MockSessionRepository {
+		m := mock.NewMockSessionRepository(ctrl)
+		m.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Return(nil)
+		return m
+	}
+
+	tests := []testCase{
+		{
+			name: "should issue session",
+			params: testCaseParams{
+				dto:                   LoginDTO{Email: testEmail, Password: testPassword},
+				mockCustomerQueryRepo: activeCustomer,
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), uuid.MustParse(testCustomerID)).Return(testCredentials(t, false), nil)
+					m.EXPECT().SaveCredentials(gomock.Any(), 3, gomock.Any()).Return(nil)
+					return m
+				},
+				mockSessionRepo: createdSession,
+			},
+		},
+		{
+			name: "should issue mfa token when totp enabled",
+			params: testCaseParams{
+				dto:                   LoginDTO{Email: testEmail, Password: testPassword},
+				mockCustomerQueryRepo: activeCustomer,
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(testCredentials(t, true), nil)
+					return m
+				},
+				mockSessionRepo: func(ctrl *gomock.Controller) *mock.MockSessionRepository {
+					m := mock.NewMockSessionRepository(ctrl)
+					m.EXPECT().CreateSession(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, s credentialsdomain.Session) error {
+							require.True(t, s.MFAPending)
+							return nil
+						},
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				mfaRequired: true,
+			},
+		},
+		{
+			name: "shouldn't log in - wrong password counted",
+			params: testCaseParams{
+				dto:                   LoginDTO{Email: testEmail, Password: "wrong horse battery"},
+				mockCustomerQueryRepo: activeCustomer,
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(testCredentials(t, false), nil)
+					m.EXPECT().SaveFailedLogin(gomock.Any(), gomock.Any(), credentialsdomain.DefaultLockoutPolicy()).DoAndReturn(
+						func(_ context.Context, c *credentialsdomain.Credentials, _ credentialsdomain.LockoutPolicy) error {
+							require.Equal(t, 1, c.FailedAttempts)
+							require.Len(t, c.Events, 1)
+							require.Equal(t, credentialsdomain.LoginFailedEventType.String(), c.Events[0].GetType())
+							return nil
+						},
+					)
+					return m
+				},
+				mockSessionRepo: mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't log in - wrong password counted whatever version credentials were found in",
+			params: testCaseParams{
+				dto:                   LoginDTO{Email: testEmail, Password: "wrong horse battery"},
+				mockCustomerQueryRepo: activeCustomer,
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(testCredentials(t, false), nil)
+					m.EXPECT().SaveFailedLogin(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, c *credentialsdomain.Credentials, policy credentialsdomain.LockoutPolicy) error {
+							// The concurrent failed logins were counted first, the failed login locks the credentials
+							return c.CountFailedLogin(0, time.Now().UTC().Add(policy.Duration), 8, policy)
+						},
+					)
+					return m
+				},
+				mockSessionRepo: mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't log in - internal error when saving failed login",
+			params: testCaseParams{
+				dto:                   LoginDTO{Email: testEmail, Password: "wrong horse battery"},
+				mockCustomerQueryRepo: activeCustomer,
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(testCredentials(t, false), nil)
+					m.EXPECT().SaveFailedLogin(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+					return m
+				},
+				mockSessionRepo: mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+		{
+			name: "shouldn't log in - credentials locked",
+			params: testCaseParams{
+				dto:                   LoginDTO{Email: testEmail, Password: testPassword},
+				mockCustomerQueryRepo: activeCustomer,
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					c := testCredentials(t, false)
+					c.LockedUntil = time.Now().UTC().Add(time.Minute)
+
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(c, nil)
+					return m
+				},
+				mockSessionRepo: mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       credentialsdomain.ErrCredentialsLocked,
+			},
+		},
+		{
+			name: "shouldn't log in - unknown customer",
+			params: testCaseParams{
+				dto: LoginDTO{Email: "jane.doe@example.com", Password: testPassword},
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(nil, customerdomain.ErrCustomerNotFound)
+					return m
+				},
+				mockCredentialsRepo: mock.NewMockCredentialsRepository,
+				mockSessionRepo:     mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't log in - blocked customer",
+			params: testCaseParams{
+				dto: LoginDTO{Email: testEmail, Password: testPassword},
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(testCustomer(customerdomain.CustomerStatusBlocked), nil)
+					return m
+				},
+				mockCredentialsRepo: mock.NewMockCredentialsRepository,
+				mockSessionRepo:     mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't log in - password not set",
+			params: testCaseParams{
+				dto:                   LoginDTO{Email: testEmail, Password: testPassword},
+				mockCustomerQueryRepo: activeCustomer,
+				mockCredentialsRepo: func(ctrl *gomock.Controller) *mock.MockCredentialsRepository {
+					m := mock.NewMockCredentialsRepository(ctrl)
+					m.EXPECT().FindCredentialsByCustomerID(gomock.Any(), gomock.Any()).Return(nil, credentialsdomain.ErrCredentialsNotFound)
+					return m
+				},
+				mockSessionRepo: mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't log in - internal error when finding customer",
+			params: testCaseParams{
+				dto: LoginDTO{Email: testEmail, Password: testPassword},
+				mockCustomerQueryRepo: func(ctrl *gomock.Controller) *mock.MockCustomerQueryRepository {
+					m := mock.NewMockCustomerQueryRepository(ctrl)
+					m.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+					return m
+				},
+				mockCredentialsRepo: mock.NewMockCredentialsRepository,
+				mockSessionRepo:     mock.NewMockSessionRepository,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewCredentialsService(
+				tt.params.mockCredentialsRepo(ctrl),
+				tt.params.mockSessionRepo(ctrl),
+				tt.params.mockCustomerQueryRepo(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				credentialsdomain.DefaultLockoutPolicy(),
+				credentialsdomain.DefaultSessionPolicy(),
+			)
+
+			resp, err := service.Login(context.Background(), tt.params.dto)
+
+			if tt.expected.wantError {
+				require.Error(t, err)
+				if tt.expected.err != nil {
+					require.ErrorIs(t, err, tt.expected.err)
+				}
+				require.NotErrorIs(t, err, credentialsdomain.ErrWrongPassword)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.mfaRequired, resp.MFARequired)
+
+			if tt.expected.mfaRequired {
+				require.NotEmpty(t, resp.MFAToken)
+				require.Nil(t, resp.Session)
+				return
+			}
+
+			require.Empty(t, resp.MFAToken)
+			require.NotNil(t, resp.Session)
+			require.NotEmpty(t, resp.Session.AccessToken)
+			require.NotEmpty(t, resp.Session.RefreshToken)
+		})
+	}
+}
+
+func TestCredentialsService_VerifyMFA(t *testing.T) {
+	type testCaseParams struct {
+		code func(c *credentialsdomain.Credentials) string
+
+		mockSessionRepo func(*gomock.Controller, credentialsdomain.Session) *mock.MockSessionRepository
+		saveErr         error
+		failedLogin     bool
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+		err       error
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	validCode := func(c *credentialsdomain.Credentials) string {
+		code, _ := credentialsdomain.TOTPCode(c.TOTPSecret, credentialsdomain.TOTPStep(time.Now().UTC()))
+		return code
+	}
+
+	tests := []testCase{
+		{
+			name: "should issue session once totp code verified",
+			params: testCaseParams{
+				code: validCode,
+				mockSessionRepo: func(ctrl *gomock.Controller, s credentialsdomain.Session) *mock.MockSessionRepository {
+					m := mock.NewMockSessionRepository(ctrl)
+					m.EXPECT().FindSessionByAccessToken(gomock.Any(), s.AccessTokenHash).Return(s, nil)
+					m.EXPECT().UpdateSession(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ int, updated credentialsdomain.Session) error {
+							require.False(t, updated.MFAPending)
+							require.NotEqual(t, s.AccessTokenHash, updated.AccessTokenHash)
+							return nil
+						},
+					)
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't issue session - wrong totp code",
+			params: testCaseParams{
+				code: func(*credentialsdomain.Credentials) string { return "abcdef" },
+				mockSessionRepo: func(ctrl *gomock.Controller, s credentialsdomain.Session) *mock.MockSessionRepository {
+					m := mock.NewMockSessionRepository(ctrl)
+					m.EXPECT().FindSessionByAccessToken(gomock.Any(), gomock.Any()).Return(s, nil)
+					return m
+				},
+				failedLogin: true,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't issue session - mfa token already used",
+			params: testCaseParams{
+				code: validCode,
+				mockSessionRepo: func(ctrl *gomock.Controller, s credentialsdomain.Session) *mock.MockSessionRepository {
+					m := mock.NewMockSessionRepository(ctrl)
+					m.EXPECT().FindSessionByAccessToken(gomock.Any(), gomock.Any()).Return(s, nil)
+					m.EXPECT().UpdateSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrConcurrencyConflict)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't issue session - totp code used concurrently",
+			params: testCaseParams{
+				code: validCode,
+				mockSessionRepo: func(ctrl *gomock.Controller, s credentialsdomain.Session) *mock.MockSessionRepository {
+					m := mock.NewMockSessionRepository(ctrl)
+					m.EXPECT().FindSessionByAccessToken(gomock.Any(), gomock.Any()).Return(s, nil)
+					return m
+				},
+				saveErr: eventdomain.ErrConcurrencyConflict,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       ErrConcurrencyConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			credentials := testCredentials(t, true)
+			session, tokens := testSession(t, true)
+			code := tt.params.code(credentials)
+
+			credentialsRepo := mock.NewMockCredentialsRepository(ctrl)
+			credentialsRepo.EXPECT().FindCredentialsByCustomerID(gomock.Any(), uuid.MustParse(testCustomerID)).Return(credentials, nil)
+			if tt.params.failedLogin {
+				credentialsRepo.EXPECT().SaveFailedLogin(gomock.Any(), credentials, gomock.Any()).Return(nil)
+			} else {
+				credentialsRepo.EXPECT().SaveCredentials(gomock.Any(), 3, gomock.Any()).Return(tt.params.saveErr)
+			}
+
+			service := NewCredentialsService(
+				credentialsRepo,
+				tt.params.mockSessionRepo(ctrl, session),
+				mock.NewMockCustomerQueryRepository(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				credentialsdomain.DefaultLockoutPolicy(),
+				credentialsdomain.DefaultSessionPolicy(),
+			)
+
+			resp, err := service.VerifyMFA(context.Background(), VerifyMFADTO{MFAToken: tokens.AccessToken, Code: code})
+
+			if tt.expected.wantError {
+				require.Error(t, err)
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, resp.AccessToken)
+			require.NotEmpty(t, resp.RefreshToken)
+		})
+	}
+}
+
+func TestCredentialsService_RefreshSession(t *testing.T) {
+	type testCaseParams struct {
+		session func() credentialsdomain.Session
+		findErr error
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+		err       error
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should rotate session tokens",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session {
+					s, _ := testSession(t, false)
+					return s
+				},
+			},
+		},
+		{
+			name: "shouldn't rotate session tokens - session revoked",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session {
+					s, _ := testSession(t, false)
+					s.Revoke(time.Now().UTC())
+					return s
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't rotate session tokens - session awaiting totp code",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session {
+					s, _ := testSession(t, true)
+					return s
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't rotate session tokens - unknown refresh token",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session { return credentialsdomain.Session{} },
+				findErr: credentialsdomain.ErrSessionNotFound,
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			session := tt.params.session()
+
+			sessionRepo := mock.NewMockSessionRepository(ctrl)
+			sessionRepo.EXPECT().FindSessionByRefreshToken(gomock.Any(), credentialsdomain.HashToken("ref_token")).Return(session, tt.params.findErr)
+			if !tt.expected.wantError {
+				sessionRepo.EXPECT().UpdateSession(gomock.Any(), session.Version, gomock.Any()).Return(nil)
+			}
+
+			service := NewCredentialsService(
+				mock.NewMockCredentialsRepository(ctrl),
+				sessionRepo,
+				mock.NewMockCustomerQueryRepository(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				credentialsdomain.DefaultLockoutPolicy(),
+				credentialsdomain.DefaultSessionPolicy(),
+			)
+
+			resp, err := service.RefreshSession(context.Background(), RefreshSessionDTO{RefreshToken: "ref_token"})
+
+			if tt.expected.wantError {
+				require.Error(t, err)
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, resp.AccessToken)
+			require.NotEqual(t, session.RefreshTokenHash, credentialsdomain.HashToken(resp.RefreshToken))
+		})
+	}
+}
+
+func TestCredentialsService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session, tokens := testSession(t, false)
+
+	sessionRepo := mock.NewMockSessionRepository(ctrl)
+	sessionRepo.EXPECT().FindSessionByRefreshToken(gomock.Any(), session.RefreshTokenHash).Return(session, nil)
+	sessionRepo.EXPECT().RevokeSession(gomock.Any(), session.ID, gomock.Any()).Return(nil)
+	sessionRepo.EXPECT().FindSessionByRefreshToken(gomock.Any(), credentialsdomain.HashToken("ref_unknown")).Return(credentialsdomain.Session{}, credentialsdomain.ErrSessionNotFound)
+
+	service := NewCredentialsService(
+		mock.NewMockCredentialsRepository(ctrl),
+		sessionRepo,
+		mock.NewMockCustomerQueryRepository(ctrl),
+		mock.NewMockAccessGuard(ctrl),
+		credentialsdomain.DefaultLockoutPolicy(),
+		credentialsdomain.DefaultSessionPolicy(),
+	)
+
+	require.NoError(t, service.Logout(context.Background(), LogoutDTO{RefreshToken: tokens.RefreshToken}))
+
+	// The unknown session is ignored, so the logout can be repeated
+	require.NoError(t, service.Logout(context.Background(), LogoutDTO{RefreshToken: "ref_unknown"}))
+}
+
+func TestCredentialsService_AuthenticateSession(t *testing.T) {
+	type testCaseParams struct {
+		session func() credentialsdomain.Session
+		findErr error
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+		err       error
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should authenticate customer",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session {
+					s, _ := testSession(t, false)
+					return s
+				},
+			},
+		},
+		{
+			name: "shouldn't authenticate - access token expired",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session {
+					s, _ := testSession(t, false)
+					s.AccessExpiresAt = time.Now().UTC().Add(-time.Second)
+					return s
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't authenticate - mfa token",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session {
+					s, _ := testSession(t, true)
+					return s
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       identitydomain.ErrInvalidCredentials,
+			},
+		},
+		{
+			name: "shouldn't authenticate - internal error when finding session",
+			params: testCaseParams{
+				session: func() credentialsdomain.Session { return credentialsdomain.Session{} },
+				findErr: errors.New("internal error"),
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessionRepo := mock.NewMockSessionRepository(ctrl)
+			sessionRepo.EXPECT().FindSessionByAccessToken(gomock.Any(), credentialsdomain.HashToken("ses_token")).Return(tt.params.session(), tt.params.findErr)
+
+			service := NewCredentialsService(
+				mock.NewMockCredentialsRepository(ctrl),
+				sessionRepo,
+				mock.NewMockCustomerQueryRepository(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				credentialsdomain.DefaultLockoutPolicy(),
+				credentialsdomain.DefaultSessionPolicy(),
+			)
+
+			principal, err := service.AuthenticateSession(context.Background(), "ses_token")
+
+			if tt.expected.wantError {
+				require.Error(t, err)
+				if tt.expected.err != nil {
+					require.ErrorIs(t, err, tt.expected.err)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, uuid.MustParse(testCustomerID), principal.CustomerID)
+			require.Equal(t, identitydomain.PrincipalTypeUser, principal.Type)
+			require.Equal(t, []identitydomain.Role{identitydomain.RoleCustomer}, principal.Roles)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./credentials_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/credentials_service_mock.go -package=mock -source=./credentials_service_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	credentials "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	customer "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	identity "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCredentialsRepository is a mock of CredentialsRepository interface.
+type MockCredentialsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCredentialsRepositoryMockRecorder is the mock recorder for MockCredentialsRepository.
+type MockCredentialsRepositoryMockRecorder struct {
+	mock *MockCredentialsRepository
+}
+
+// NewMockCredentialsRepository creates a new mock instance.
+func NewMockCredentialsRepository(ctrl *gomock.Controller) *MockCredentialsRepository {
+	mock := &MockCredentialsRepository{ctrl: ctrl}
+	mock.recorder = &MockCredentialsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialsRepository) EXPECT() *MockCredentialsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindCredentialsByCustomerID mocks base method.
+func (m *MockCredentialsRepository) FindCredentialsByCustomerID(ctx context.Context, customerID uuid.UUID) (*credentials.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCredentialsByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(*credentials.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCredentialsByCustomerID indicates an expected call of FindCredentialsByCustomerID.
+func (mr *MockCredentialsRepositoryMockRecorder) FindCredentialsByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCredentialsByCustomerID", reflect.TypeOf((*MockCredentialsRepository)(nil).FindCredentialsByCustomerID), ctx, customerID)
+}
+
+// SaveCredentials mocks base method.
+func (m *MockCredentialsRepository) SaveCredentials(ctx context.Context, expectedVersion int, arg2 *credentials.Credentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCredentials", ctx, expectedVersion, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCredentials indicates an expected call of SaveCredentials.
+func (mr *MockCredentialsRepositoryMockRecorder) SaveCredentials(ctx, expectedVersion, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCredentials", reflect.TypeOf((*MockCredentialsRepository)(nil).SaveCredentials), ctx, expectedVersion, arg2)
+}
+
+// SaveFailedLogin mocks base method.
+func (m *MockCredentialsRepository) SaveFailedLogin(ctx context.Context, arg1 *credentials.Credentials, policy credentials.LockoutPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFailedLogin", ctx, arg1, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFailedLogin indicates an expected call of SaveFailedLogin.
+func (mr *MockCredentialsRepositoryMockRecorder) SaveFailedLogin(ctx, arg1, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFailedLogin", reflect.TypeOf((*MockCredentialsRepository)(nil).SaveFailedLogin), ctx, arg1, policy)
+}
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessionRepository) CreateSession(ctx context.Context, session credentials.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionRepositoryMockRecorder) CreateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionRepository)(nil).CreateSession), ctx, session)
+}
+
+// FindSessionByAccessToken mocks base method.
+func (m *MockSessionRepository) FindSessionByAccessToken(ctx context.Context, accessTokenHash string) (credentials.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSessionByAccessToken", ctx, accessTokenHash)
+	ret0, _ := ret[0].(credentials.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSessionByAccessToken indicates an expected call of FindSessionByAccessToken.
+func (mr *MockSessionRepositoryMockRecorder) FindSessionByAccessToken(ctx, accessTokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSessionByAccessToken", reflect.TypeOf((*MockSessionRepository)(nil).FindSessionByAccessToken), ctx, accessTokenHash)
+}
+
+// FindSessionByRefreshToken mocks base method.
+func (m *MockSessionRepository) FindSessionByRefreshToken(ctx context.Context, refreshTokenHash string) (credentials.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSessionByRefreshToken", ctx, refreshTokenHash)
+	ret0, _ := ret[0].(credentials.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSessionByRefreshToken indicates an expected call of FindSessionByRefreshToken.
+func (mr *MockSessionRepositoryMockRecorder) FindSessionByRefreshToken(ctx, refreshTokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSessionByRefreshToken", reflect.TypeOf((*MockSessionRepository)(nil).FindSessionByRefreshToken), ctx, refreshTokenHash)
+}
+
+// RevokeCustomerSessions mocks base method.
+func (m *MockSessionRepository) RevokeCustomerSessions(ctx context.Context, customerID uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCustomerSessions", ctx, customerID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCustomerSessions indicates an expected call of RevokeCustomerSessions.
+func (mr *MockSessionRepositoryMockRecorder) RevokeCustomerSessions(ctx, customerID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCustomerSessions", reflect.TypeOf((*MockSessionRepository)(nil).RevokeCustomerSessions), ctx, customerID, revokedAt)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionRepository) RevokeSession(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionRepositoryMockRecorder) RevokeSession(ctx, id, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionRepository)(nil).RevokeSession), ctx, id, revokedAt)
+}
+
+// UpdateSession mocks base method.
+func (m *MockSessionRepository) UpdateSession(ctx context.Context, expectedVersion int, session credentials.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSession", ctx, expectedVersion, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSession indicates an expected call of UpdateSession.
+func (mr *MockSessionRepositoryMockRecorder) UpdateSession(ctx, expectedVersion, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSession", reflect.TypeOf((*MockSessionRepository)(nil).UpdateSession), ctx, expectedVersion, session)
+}
+
+// MockCustomerQueryRepository is a mock of CustomerQueryRepository interface.
+type MockCustomerQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerQueryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerQueryRepositoryMockRecorder is the mock recorder for MockCustomerQueryRepository.
+type MockCustomerQueryRepositoryMockRecorder struct {
+	mock *MockCustomerQueryRepository
+}
+
+// NewMockCustomerQueryRepository creates a new mock instance.
+func NewMockCustomerQueryRepository(ctrl *gomock.Controller) *MockCustomerQueryRepository {
+	mock := &MockCustomerQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerQueryRepository) EXPECT() *MockCustomerQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByEmail mocks base method.
+func (m *MockCustomerQueryRepository) FindByEmail(ctx context.Context, email string) (*customer.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*customer.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockCustomerQueryRepositoryMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockCustomerQueryRepository)(nil).FindByEmail), ctx, email)
+}
+
+// FindByID mocks base method.
+func (m *MockCustomerQueryRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*customer.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCustomerQueryRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCustomerQueryRepository)(nil).FindByID), ctx, id)
+}
+
+// MockAccessGuard is a mock of AccessGuard interface.
+type MockAccessGuard struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessGuardMockRecorder
+	isgomock struct{}
+}
+
+// MockAccessGuardMockRecorder is the mock recorder for MockAccessGuard.
+type MockAccessGuardMockRecorder struct {
+	mock *MockAccessGuard
+}
+
+// NewMockAccessGuard creates a new mock instance.
+func NewMockAccessGuard(ctrl *gomock.Controller) *MockAccessGuard {
+	mock := &MockAccessGuard{ctrl: ctrl}
+	mock.recorder = &MockAccessGuardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessGuard) EXPECT() *MockAccessGuardMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeOwner mocks base method.
+func (m *MockAccessGuard) AuthorizeOwner(ctx context.Context, resource identity.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeOwner", ctx, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeOwner indicates an expected call of AuthorizeOwner.
+func (mr *MockAccessGuardMockRecorder) AuthorizeOwner(ctx, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeOwner", reflect.TypeOf((*MockAccessGuard)(nil).AuthorizeOwner), ctx, resource)
+}
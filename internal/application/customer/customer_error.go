@@ -10,4 +10,6 @@ var (
 	ErrCustomerNotFound = errors.New("customer not found")
 	// ErrCustomerAlreadyExists is returned when a customer already exists.
 	ErrCustomerAlreadyExists = errors.New("customer already exists")
+	// ErrConcurrencyConflict is returned when the customer kept being changed concurrently and the command couldn't be recorded.
+	ErrConcurrencyConflict = errors.New("customer was changed concurrently")
 )
@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
 
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
 )
 
 type Customer = customerdomain.Customer
 type Address = customerdomain.Address
 
+// maxCommandAttempts is the number of times a customer command is run when the customer is changed concurrently
+const maxCommandAttempts = 3
+
 func ToCustomerDTO(customer *Customer) CustomerResponseDTO {
 	return CustomerResponseDTO{
 		ID:        customer.ID.String(),
@@ -32,13 +38,28 @@ func ToCustomerDTO(customer *Customer) CustomerResponseDTO {
 type CustomerService struct {
 	customerQueryRepo CustomerQueryRepository
 
-	customerEventRepo CustomerEventRepository
+	customerEventRepo    CustomerEventRepository
+	customerSnapshotRepo CustomerSnapshotRepository
+
+	accessGuard AccessGuard
+
+	snapshotPolicy eventdomain.SnapshotPolicy
 }
 
-func NewCustomerService(customerQueryRepo CustomerQueryRepository, customerEventRepo CustomerEventRepository) *CustomerService {
+// NewCustomerService creates a new customer service, the customers are snapshotted by the snapshot policy
+// and the customer accesses only their own data by the access guard
+func NewCustomerService(
+	customerQueryRepo CustomerQueryRepository,
+	customerEventRepo CustomerEventRepository,
+	customerSnapshotRepo CustomerSnapshotRepository,
+	accessGuard AccessGuard,
+	snapshotPolicy eventdomain.SnapshotPolicy) *CustomerService {
 	return &CustomerService{
-		customerQueryRepo: customerQueryRepo,
-		customerEventRepo: customerEventRepo,
+		customerQueryRepo:    customerQueryRepo,
+		customerEventRepo:    customerEventRepo,
+		customerSnapshotRepo: customerSnapshotRepo,
+		accessGuard:          accessGuard,
+		snapshotPolicy:       snapshotPolicy,
 	}
 }
 
@@ -52,7 +73,7 @@ type CreateCustomerDTO struct {
 }
 
 type CreateCustomerResponseDTO struct {
-	Customer CustomerResponseDTO
+	Customer CustomerResponseDTO `json:"customer"`
 }
 
 // CreateCustomer creates a new customer
@@ -66,7 +87,7 @@ func (c *CustomerService) CreateCustomer(ctx context.Context, dto CreateCustomer
 
 	customer := customerdomain.NewCustomer(customerID, dto.FirstName, dto.LastName, dto.Phone, dto.Email, dto.DateOfBirth, dto.Address)
 
-	err = c.customerEventRepo.CreateEvents(ctx, customer.Events)
+	err = c.customerEventRepo.CreateEvents(ctx, customer.Version, customer.Events)
 	if err != nil {
 		return CreateCustomerResponseDTO{}, fmt.Errorf("creating customer events: %w", err)
 	}
@@ -75,15 +96,15 @@ func (c *CustomerService) CreateCustomer(ctx context.Context, dto CreateCustomer
 }
 
 type CustomerResponseDTO struct {
-	ID        string
-	FirstName string
-	LastName  string
-	Email     string
-	Phone     string
-	Address   Address
-	Status    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID        string    `json:"id"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Address   Address   `json:"address"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 type GetCustomerDTO struct {
@@ -94,9 +115,25 @@ type GetCustomerResponseDTO struct {
 	Customer CustomerResponseDTO `json:"customer"`
 }
 
-// GetCustomer retrieves a customer by its ID
+// GetCustomer retrieves a customer by its ID. Another customer isn't found, the access is authorized
+// before the customer is looked up, so the customer can't tell which customers exist.
 func (c *CustomerService) GetCustomer(ctx context.Context, dto GetCustomerDTO) (GetCustomerResponseDTO, error) {
-	customer, err := c.customerQueryRepo.FindByID(ctx, uuid.MustParse(dto.CustomerID))
+	customerID := uuid.MustParse(dto.CustomerID)
+
+	err := c.accessGuard.AuthorizeOwner(ctx, identitydomain.Resource{
+		Type:       identitydomain.ResourceTypeCustomer,
+		ID:         customerID,
+		CustomerID: customerID,
+	})
+	if err != nil {
+		if errors.Is(err, identitydomain.ErrNotOwner) {
+			return GetCustomerResponseDTO{}, fmt.Errorf("authorizing customer access: %w", ErrCustomerNotFound)
+		}
+
+		return GetCustomerResponseDTO{}, fmt.Errorf("authorizing customer access: %w", err)
+	}
+
+	customer, err := c.customerQueryRepo.FindByID(ctx, customerID)
 	if err != nil {
 		if errors.Is(err, customerdomain.ErrCustomerNotFound) {
 			return GetCustomerResponseDTO{}, fmt.Errorf("finding customer by id: %w", ErrCustomerNotFound)
@@ -143,25 +180,11 @@ func (c *CustomerService) detectChanges(dto UpdateCustomerDTO) customerdomain.Cu
 }
 
 func (c *CustomerService) UpdateCustomer(ctx context.Context, dto UpdateCustomerDTO) error {
-	customer, err := c.customerQueryRepo.FindByID(ctx, uuid.MustParse(dto.CustomerID))
-	if err != nil {
-		if errors.Is(err, customerdomain.ErrCustomerNotFound) {
-			return fmt.Errorf("finding customer by id: %w", ErrCustomerNotFound)
-		}
-
-		return fmt.Errorf("finding customer by id: %w", err)
-	}
-
 	updateEventType := c.detectChanges(dto)
 
-	customer.Update(updateEventType, dto.FirstName, dto.LastName, dto.Phone, dto.Email, dto.DateOfBirth, dto.Address)
-
-	err = c.customerEventRepo.CreateEvents(ctx, customer.Events)
-	if err != nil {
-		return fmt.Errorf("creating customer events: %w", err)
-	}
-
-	return nil
+	return c.execute(ctx, uuid.MustParse(dto.CustomerID), func(customer *Customer) {
+		customer.Update(updateEventType, dto.FirstName, dto.LastName, dto.Phone, dto.Email, dto.DateOfBirth, dto.Address)
+	})
 }
 
 type BlockCustomerDTO struct {
@@ -170,69 +193,147 @@ type BlockCustomerDTO struct {
 }
 
 func (c *CustomerService) BlockCustomer(ctx context.Context, dto BlockCustomerDTO) error {
-	customer, err := c.customerQueryRepo.FindByID(ctx, uuid.MustParse(dto.CustomerID))
-	if err != nil {
-		if errors.Is(err, customerdomain.ErrCustomerNotFound) {
-			return fmt.Errorf("finding customer by id: %w", ErrCustomerNotFound)
-		}
+	return c.execute(ctx, uuid.MustParse(dto.CustomerID), func(customer *Customer) {
+		customer.Block(dto.Reason)
+	})
+}
 
-		return fmt.Errorf("finding customer by id: %w", err)
-	}
+type UnblockCustomerDTO struct {
+	CustomerID string
+}
 
-	customer.Block(dto.Reason)
+func (c *CustomerService) UnblockCustomer(ctx context.Context, dto UnblockCustomerDTO) error {
+	return c.execute(ctx, uuid.MustParse(dto.CustomerID), (*Customer).Unblock)
+}
 
-	err = c.customerEventRepo.CreateEvents(ctx, customer.Events)
-	if err != nil {
-		return fmt.Errorf("creating customer events: %w", err)
+type DeleteCustomerDTO struct {
+	CustomerID string
+}
+
+func (c *CustomerService) DeleteCustomer(ctx context.Context, dto DeleteCustomerDTO) error {
+	err := c.execute(ctx, uuid.MustParse(dto.CustomerID), (*Customer).Delete)
+	if errors.Is(err, ErrCustomerNotFound) {
+		return nil
 	}
 
-	return nil
+	return err
 }
 
-type UnblockCustomerDTO struct {
-	CustomerID string
+// execute runs the command on the customer rebuilt from its events and records the events of the command
+// expecting the customer in the version the command decided on. When the customer was changed concurrently,
+// the command is run again on the customer rebuilt once more, up to maxCommandAttempts times.
+func (c *CustomerService) execute(ctx context.Context, id uuid.UUID, command func(*Customer)) error {
+	for attempt := 1; ; attempt++ {
+		customer, err := c.findCustomer(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		command(customer)
+
+		err = c.customerEventRepo.CreateEvents(ctx, customer.Version, customer.Events)
+		if err == nil {
+			if c.snapshotPolicy.IsDue(customer.Version, customer.Version+len(customer.Events)) {
+				c.snapshot(ctx, id)
+			}
+
+			return nil
+		}
+
+		if !errors.Is(err, eventdomain.ErrConcurrencyConflict) {
+			return fmt.Errorf("creating customer events: %w", err)
+		}
+
+		if attempt == maxCommandAttempts {
+			return fmt.Errorf("creating customer events after %d attempts: %w: %w", attempt, ErrConcurrencyConflict, err)
+		}
+	}
 }
 
-func (c *CustomerService) UnblockCustomer(ctx context.Context, dto UnblockCustomerDTO) error {
-	customer, err := c.customerQueryRepo.FindByID(ctx, uuid.MustParse(dto.CustomerID))
+// findCustomer rebuilds the customer from its events, so the command decides on the current state of the customer
+// even when the customers read model lags behind. The customer is restored from its latest snapshot and the events
+// recorded after it, or from all its events when it has no snapshot. A missing customer is reported with ErrCustomerNotFound.
+func (c *CustomerService) findCustomer(ctx context.Context, id uuid.UUID) (*Customer, error) {
+	snapshot, err := c.customerSnapshotRepo.FindLatestSnapshot(ctx, id)
 	if err != nil {
-		if errors.Is(err, customerdomain.ErrCustomerNotFound) {
-			return fmt.Errorf("finding customer by id: %w", ErrCustomerNotFound)
+		if !errors.Is(err, customerdomain.ErrCustomerSnapshotNotFound) {
+			return nil, fmt.Errorf("finding customer snapshot: %w", err)
 		}
 
-		return fmt.Errorf("finding customer by id: %w", err)
+		return c.replayCustomer(ctx, id)
 	}
 
-	customer.Unblock()
+	events, err := c.customerEventRepo.FindEventsAfterVersion(ctx, id, snapshot.Version)
+	if err != nil {
+		return nil, fmt.Errorf("finding customer events after snapshot: %w", err)
+	}
 
-	err = c.customerEventRepo.CreateEvents(ctx, customer.Events)
+	customer, err := customerdomain.NewCustomerFromSnapshot(snapshot, events)
 	if err != nil {
-		return fmt.Errorf("creating customer events: %w", err)
+		return nil, fmt.Errorf("restoring customer from snapshot: %w", err)
 	}
 
-	return nil
+	return customer, nil
 }
 
-type DeleteCustomerDTO struct {
-	CustomerID string
-}
+// replayCustomer rebuilds the customer from all its events, a missing customer is reported with ErrCustomerNotFound
+func (c *CustomerService) replayCustomer(ctx context.Context, id uuid.UUID) (*Customer, error) {
+	events, err := c.customerEventRepo.FindEventsByContextID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding customer events: %w", err)
+	}
 
-func (c *CustomerService) DeleteCustomer(ctx context.Context, dto DeleteCustomerDTO) error {
-	customer, err := c.customerQueryRepo.FindByID(ctx, uuid.MustParse(dto.CustomerID))
+	customer, err := customerdomain.NewCustomerFromEvents(events)
 	if err != nil {
 		if errors.Is(err, customerdomain.ErrCustomerNotFound) {
-			return nil
+			return nil, fmt.Errorf("rebuilding customer: %w", ErrCustomerNotFound)
 		}
 
-		return fmt.Errorf("finding customer by id: %w", err)
+		return nil, fmt.Errorf("rebuilding customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+// snapshot stores the snapshot of the customer at the version its last command led to.
+// The events of the command are already recorded, so a failed snapshot only leaves the customer to be rebuilt from more events.
+func (c *CustomerService) snapshot(ctx context.Context, id uuid.UUID) {
+	customer, err := c.findCustomer(ctx, id)
+	if err != nil {
+		log.Printf("snapshotting customer %s: %v", id, err)
+		return
 	}
 
-	customer.Delete()
+	if err := c.customerSnapshotRepo.CreateSnapshot(ctx, customer.Snapshot()); err != nil {
+		log.Printf("snapshotting customer %s: %v", id, err)
+	}
+}
 
-	err = c.customerEventRepo.CreateEvents(ctx, customer.Events)
+// RebuildSnapshots replays every customer from all its events and stores its snapshot at its current version,
+// i.e. when the snapshot format changed. It returns the number of the customers snapshotted.
+func (c *CustomerService) RebuildSnapshots(ctx context.Context) (int, error) {
+	ids, err := c.customerEventRepo.FindCustomerIDs(ctx)
 	if err != nil {
-		return fmt.Errorf("creating customer events: %w", err)
+		return 0, fmt.Errorf("finding customer ids: %w", err)
+	}
+
+	snapshotted := 0
+	for _, id := range ids {
+		customer, err := c.replayCustomer(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrCustomerNotFound) {
+				continue
+			}
+
+			return snapshotted, fmt.Errorf("rebuilding customer %s: %w", id, err)
+		}
+
+		if err := c.customerSnapshotRepo.CreateSnapshot(ctx, customer.Snapshot()); err != nil {
+			return snapshotted, fmt.Errorf("creating customer %s snapshot: %w", id, err)
+		}
+
+		snapshotted++
 	}
 
-	return nil
+	return snapshotted, nil
 }
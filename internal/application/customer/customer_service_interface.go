@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
 )
 
 //go:generate mockgen -destination=./mock/customer_service_mock.go -package=mock -source=./customer_service_interface.go
@@ -23,6 +24,35 @@ type CustomerQueryRepository interface {
 
 // CustomerEventRepository defines the interface for customer event persistence
 type CustomerEventRepository interface {
-	// CreateEvents persists a customer event
-	CreateEvents(ctx context.Context, events []customerdomain.Event) error
+	// CreateEvents persists the customer events after the expected version of the customer,
+	// it returns event.ErrConcurrencyConflict when the customer was changed in the meantime
+	CreateEvents(ctx context.Context, expectedVersion int, events []customerdomain.Event) error
+
+	// FindEventsByContextID retrieves the events of a customer in the order they were recorded
+	FindEventsByContextID(ctx context.Context, id uuid.UUID) ([]customerdomain.Event, error)
+
+	// FindEventsAfterVersion retrieves the events of a customer recorded after the version in the order they were recorded
+	FindEventsAfterVersion(ctx context.Context, id uuid.UUID, version int) ([]customerdomain.Event, error)
+
+	// FindCustomerIDs retrieves the IDs of all the customers
+	FindCustomerIDs(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// CustomerSnapshotRepository defines the interface for customer snapshot persistence
+type CustomerSnapshotRepository interface {
+	// CreateSnapshot persists the snapshot of a customer at its version
+	CreateSnapshot(ctx context.Context, snapshot customerdomain.CustomerSnapshot) error
+
+	// FindLatestSnapshot retrieves the latest snapshot of a customer,
+	// it returns customerdomain.ErrCustomerSnapshotNotFound when the customer has no snapshot
+	FindLatestSnapshot(ctx context.Context, id uuid.UUID) (customerdomain.CustomerSnapshot, error)
+}
+
+//             Access
+
+// AccessGuard defines the interface for authorizing the access to the resources owned by the customers
+type AccessGuard interface {
+	// AuthorizeOwner authorizes the access of the principal of the context to the resource,
+	// it returns identitydomain.ErrNotOwner when the principal isn't allowed to access it
+	AuthorizeOwner(ctx context.Context, resource identitydomain.Resource) error
 }
@@ -7,13 +7,41 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stefanowiczd/ddd-case-01/internal/application/customer/mock"
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
 )
 
+// testCustomerEvents returns the events of the test customer
+func testCustomerEvents() []customerdomain.Event {
+	customer := customerdomain.NewCustomer(
+		uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+		"John", "Doe", "john.doe@example.com", "+48123456789", "1990-01-01",
+		customerdomain.Address{Street: "Main St 1", City: "Warsaw", PostalCode: "00-001", Country: "Poland"},
+	)
+
+	return customer.GetEvents()
+}
+
+// testNoSnapshots returns the snapshot repository of the customers without snapshots, they are rebuilt from all their events
+func testNoSnapshots(ctrl *gomock.Controller) *mock.MockCustomerSnapshotRepository {
+	m := mock.NewMockCustomerSnapshotRepository(ctrl)
+	m.EXPECT().FindLatestSnapshot(gomock.Any(), gomock.Any()).Return(customerdomain.CustomerSnapshot{}, customerdomain.ErrCustomerSnapshotNotFound).AnyTimes()
+	return m
+}
+
+// testAllowedAccess returns the access guard allowing the access to any customer
+func testAllowedAccess(ctrl *gomock.Controller) *mock.MockAccessGuard {
+	m := mock.NewMockAccessGuard(ctrl)
+	m.EXPECT().AuthorizeOwner(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return m
+}
+
 func TestCustomerService_CreateCustomer(t *testing.T) {
 	type testCaseParams struct {
 		dto CreateCustomerDTO
@@ -106,7 +134,7 @@ func TestCustomerService_CreateCustomer(t *testing.T) {
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 
 					return mock
 				},
@@ -134,7 +162,7 @@ func TestCustomerService_CreateCustomer(t *testing.T) {
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 					return mock
 				},
@@ -153,6 +181,9 @@ func TestCustomerService_CreateCustomer(t *testing.T) {
 			service := NewCustomerService(
 				tt.params.mockCustomerQueryRepo(ctrl),
 				tt.params.mockCustomerEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 
 			customer, err := service.CreateCustomer(context.Background(), tt.params.dto)
@@ -176,6 +207,7 @@ func Test_CustomerService_GetCustomer(t *testing.T) {
 	type testCaseParams struct {
 		dto                   GetCustomerDTO
 		mockCustomerQueryRepo func(*gomock.Controller) *mock.MockCustomerQueryRepository
+		mockAccessGuard       func(*gomock.Controller) *mock.MockAccessGuard
 	}
 
 	type testCaseExpected struct {
@@ -203,6 +235,7 @@ func Test_CustomerService_GetCustomer(t *testing.T) {
 
 					return mock
 				},
+				mockAccessGuard: testAllowedAccess,
 			},
 			expected: testCaseExpected{
 				wantError: true,
@@ -218,6 +251,34 @@ func Test_CustomerService_GetCustomer(t *testing.T) {
 					mock := mock.NewMockCustomerQueryRepository(m)
 					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, customerdomain.ErrCustomerNotFound)
 
+					return mock
+				},
+				mockAccessGuard: testAllowedAccess,
+			},
+			expected: testCaseExpected{
+				wantError:      true,
+				errWantCompare: true,
+				err:            ErrCustomerNotFound,
+			},
+		},
+		{
+			name: "shouldn't get customer - customer of another customer",
+			params: testCaseParams{
+				dto: GetCustomerDTO{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+				},
+				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
+					// The access is denied before the customer is looked up
+					return mock.NewMockCustomerQueryRepository(m)
+				},
+				mockAccessGuard: func(m *gomock.Controller) *mock.MockAccessGuard {
+					mock := mock.NewMockAccessGuard(m)
+					mock.EXPECT().AuthorizeOwner(gomock.Any(), identitydomain.Resource{
+						Type:       identitydomain.ResourceTypeCustomer,
+						ID:         uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+						CustomerID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+					}).Return(identitydomain.ErrNotOwner)
+
 					return mock
 				},
 			},
@@ -227,6 +288,26 @@ func Test_CustomerService_GetCustomer(t *testing.T) {
 				err:            ErrCustomerNotFound,
 			},
 		},
+		{
+			name: "shouldn't get customer - internal error when auditing access",
+			params: testCaseParams{
+				dto: GetCustomerDTO{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+				},
+				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
+					return mock.NewMockCustomerQueryRepository(m)
+				},
+				mockAccessGuard: func(m *gomock.Controller) *mock.MockAccessGuard {
+					mock := mock.NewMockAccessGuard(m)
+					mock.EXPECT().AuthorizeOwner(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
 		{
 			name: "should get customer",
 			params: testCaseParams{
@@ -239,6 +320,7 @@ func Test_CustomerService_GetCustomer(t *testing.T) {
 
 					return mock
 				},
+				mockAccessGuard: testAllowedAccess,
 			},
 			expected: testCaseExpected{
 				wantError: false,
@@ -254,6 +336,9 @@ func Test_CustomerService_GetCustomer(t *testing.T) {
 			service := NewCustomerService(
 				tt.params.mockCustomerQueryRepo(ctrl),
 				mock.NewMockCustomerEventRepository(ctrl),
+				testNoSnapshots(ctrl),
+				tt.params.mockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 
 			customer, err := service.GetCustomer(context.Background(), tt.params.dto)
@@ -301,13 +386,13 @@ func Test_CustomerService_UpdateCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -321,13 +406,13 @@ func Test_CustomerService_UpdateCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, customerdomain.ErrCustomerNotFound)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -343,14 +428,12 @@ func Test_CustomerService_UpdateCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
 
 					return mock
 				},
@@ -366,14 +449,12 @@ func Test_CustomerService_UpdateCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 					return mock
 				},
@@ -392,6 +473,9 @@ func Test_CustomerService_UpdateCustomer(t *testing.T) {
 			service := NewCustomerService(
 				tt.params.mockCustomerQueryRepo(ctrl),
 				tt.params.mockCustomerEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 
 			err := service.UpdateCustomer(context.Background(), tt.params.dto)
@@ -439,13 +523,13 @@ func Test_CustomerService_BlockCustomer(t *testing.T) {
 					Reason:     "some reason",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -460,13 +544,13 @@ func Test_CustomerService_BlockCustomer(t *testing.T) {
 					Reason:     "some reason",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, customerdomain.ErrCustomerNotFound)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -483,14 +567,12 @@ func Test_CustomerService_BlockCustomer(t *testing.T) {
 					Reason:     "some reason",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 
 					return mock
 				},
@@ -501,21 +583,68 @@ func Test_CustomerService_BlockCustomer(t *testing.T) {
 			},
 		},
 		{
-			name: "should block customer",
+			name: "should block customer rebuilt after a concurrent change",
 			params: testCaseParams{
 				dto: BlockCustomerDTO{
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 					Reason:     "some reason",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
+					return mock.NewMockCustomerQueryRepository(m)
+				},
+				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil).Times(2)
+					gomock.InOrder(
+						mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrConcurrencyConflict),
+						mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "shouldn't block customer - customer kept changing concurrently",
+			params: testCaseParams{
+				dto: BlockCustomerDTO{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Reason:     "some reason",
+				},
+				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
+					return mock.NewMockCustomerQueryRepository(m)
+				},
+				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil).Times(maxCommandAttempts)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrConcurrencyConflict).Times(maxCommandAttempts)
 
 					return mock
 				},
+			},
+			expected: testCaseExpected{
+				wantError:      true,
+				errWantCompare: true,
+				err:            ErrConcurrencyConflict,
+			},
+		},
+		{
+			name: "should block customer",
+			params: testCaseParams{
+				dto: BlockCustomerDTO{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Reason:     "some reason",
+				},
+				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
+					return mock.NewMockCustomerQueryRepository(m)
+				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 					return mock
 				},
@@ -534,6 +663,9 @@ func Test_CustomerService_BlockCustomer(t *testing.T) {
 			service := NewCustomerService(
 				tt.params.mockCustomerQueryRepo(ctrl),
 				tt.params.mockCustomerEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 
 			err := service.BlockCustomer(context.Background(), tt.params.dto)
@@ -551,6 +683,119 @@ func Test_CustomerService_BlockCustomer(t *testing.T) {
 	}
 }
 
+func Test_CustomerService_BlockCustomer_Snapshots(t *testing.T) {
+	customerID := uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+	// The events of the test customer with the blocking the command records, the customer reaches the version 2
+	events := testCustomerEvents()
+	events[0].(*customerdomain.CustomerCreatedEvent).AggregateVersion = 1
+
+	blocked, err := customerdomain.NewCustomerFromEvents(events)
+	require.NoError(t, err)
+	blocked.Block("fraud suspicion")
+	blocked.Events[0].(*customerdomain.CustomerBlockedEvent).AggregateVersion = 2
+	events = append(events, blocked.Events...)
+
+	snapshotAt := func(t *testing.T, version int) customerdomain.CustomerSnapshot {
+		customer, err := customerdomain.NewCustomerFromEvents(events[:version])
+		require.NoError(t, err)
+
+		return customer.Snapshot()
+	}
+
+	type testCaseParams struct {
+		policy                   eventdomain.SnapshotPolicy
+		mockCustomerEventRepo    func(*gomock.Controller) *mock.MockCustomerEventRepository
+		mockCustomerSnapshotRepo func(*gomock.Controller) *mock.MockCustomerSnapshotRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should block customer restored from its snapshot",
+			params: testCaseParams{
+				mockCustomerEventRepo: func(ctrl *gomock.Controller) *mock.MockCustomerEventRepository {
+					m := mock.NewMockCustomerEventRepository(ctrl)
+					m.EXPECT().FindEventsAfterVersion(gomock.Any(), customerID, 1).Return(nil, nil)
+					m.EXPECT().CreateEvents(gomock.Any(), 1, gomock.Len(1)).Return(nil)
+					return m
+				},
+				mockCustomerSnapshotRepo: func(ctrl *gomock.Controller) *mock.MockCustomerSnapshotRepository {
+					m := mock.NewMockCustomerSnapshotRepository(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), customerID).Return(snapshotAt(t, 1), nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should snapshot customer reaching the snapshot frequency",
+			params: testCaseParams{
+				policy: eventdomain.SnapshotPolicy{Frequency: 2},
+				mockCustomerEventRepo: func(ctrl *gomock.Controller) *mock.MockCustomerEventRepository {
+					m := mock.NewMockCustomerEventRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindEventsByContextID(gomock.Any(), customerID).Return(events[:1], nil),
+						m.EXPECT().CreateEvents(gomock.Any(), 1, gomock.Len(1)).Return(nil),
+						m.EXPECT().FindEventsByContextID(gomock.Any(), customerID).Return(events, nil),
+					)
+					return m
+				},
+				mockCustomerSnapshotRepo: func(ctrl *gomock.Controller) *mock.MockCustomerSnapshotRepository {
+					m := testNoSnapshots(ctrl)
+					m.EXPECT().CreateSnapshot(gomock.Any(), snapshotAt(t, 2)).Return(nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't block customer - snapshot can't be loaded",
+			params: testCaseParams{
+				mockCustomerEventRepo: func(ctrl *gomock.Controller) *mock.MockCustomerEventRepository {
+					return mock.NewMockCustomerEventRepository(ctrl)
+				},
+				mockCustomerSnapshotRepo: func(ctrl *gomock.Controller) *mock.MockCustomerSnapshotRepository {
+					m := mock.NewMockCustomerSnapshotRepository(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), customerID).Return(customerdomain.CustomerSnapshot{}, errors.New("db error"))
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewCustomerService(
+				mock.NewMockCustomerQueryRepository(ctrl),
+				tt.params.mockCustomerEventRepo(ctrl),
+				tt.params.mockCustomerSnapshotRepo(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				tt.params.policy,
+			)
+
+			err := service.BlockCustomer(context.Background(), BlockCustomerDTO{CustomerID: customerID.String(), Reason: "fraud suspicion"})
+			if tt.expected.wantError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
 func Test_CustomerService_UnblockCustomer(t *testing.T) {
 
 	type testCaseParams struct {
@@ -580,13 +825,13 @@ func Test_CustomerService_UnblockCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -600,13 +845,13 @@ func Test_CustomerService_UnblockCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, customerdomain.ErrCustomerNotFound)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -622,14 +867,12 @@ func Test_CustomerService_UnblockCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some error"))
 
 					return mock
 				},
@@ -646,14 +889,12 @@ func Test_CustomerService_UnblockCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 					return mock
 				},
@@ -672,6 +913,9 @@ func Test_CustomerService_UnblockCustomer(t *testing.T) {
 			service := NewCustomerService(
 				tt.params.mockCustomerQueryRepo(ctrl),
 				tt.params.mockCustomerEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 
 			err := service.UnblockCustomer(context.Background(), tt.params.dto)
@@ -718,13 +962,13 @@ func Test_CustomerService_DeleteCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, errors.New("internal error"))
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -738,13 +982,13 @@ func Test_CustomerService_DeleteCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, customerdomain.ErrCustomerNotFound)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
-					return mock.NewMockCustomerEventRepository(m)
+					mock := mock.NewMockCustomerEventRepository(m)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+					return mock
 				},
 			},
 			expected: testCaseExpected{
@@ -758,14 +1002,12 @@ func Test_CustomerService_DeleteCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
 
 					return mock
 				},
@@ -781,14 +1023,12 @@ func Test_CustomerService_DeleteCustomer(t *testing.T) {
 					CustomerID: "00000000-0000-0000-0000-000000000000",
 				},
 				mockCustomerQueryRepo: func(m *gomock.Controller) *mock.MockCustomerQueryRepository {
-					mock := mock.NewMockCustomerQueryRepository(m)
-					mock.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(&customerdomain.Customer{}, nil)
-
-					return mock
+					return mock.NewMockCustomerQueryRepository(m)
 				},
 				mockCustomerEventRepo: func(m *gomock.Controller) *mock.MockCustomerEventRepository {
 					mock := mock.NewMockCustomerEventRepository(m)
-					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+					mock.EXPECT().FindEventsByContextID(gomock.Any(), gomock.Any()).Return(testCustomerEvents(), nil)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 					return mock
 				},
@@ -807,6 +1047,9 @@ func Test_CustomerService_DeleteCustomer(t *testing.T) {
 			service := NewCustomerService(
 				tt.params.mockCustomerQueryRepo(ctrl),
 				tt.params.mockCustomerEventRepo(ctrl),
+				testNoSnapshots(ctrl),
+				mock.NewMockAccessGuard(ctrl),
+				eventdomain.SnapshotPolicy{},
 			)
 
 			err := service.DeleteCustomer(context.Background(), tt.params.dto)
@@ -15,6 +15,7 @@ import (
 
 	uuid "github.com/google/uuid"
 	customer "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	identity "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -97,15 +98,151 @@ func (m *MockCustomerEventRepository) EXPECT() *MockCustomerEventRepositoryMockR
 }
 
 // CreateEvents mocks base method.
-func (m *MockCustomerEventRepository) CreateEvents(ctx context.Context, events []customer.Event) error {
+func (m *MockCustomerEventRepository) CreateEvents(ctx context.Context, expectedVersion int, events []customer.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEvents", ctx, events)
+	ret := m.ctrl.Call(m, "CreateEvents", ctx, expectedVersion, events)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateEvents indicates an expected call of CreateEvents.
-func (mr *MockCustomerEventRepositoryMockRecorder) CreateEvents(ctx, events any) *gomock.Call {
+func (mr *MockCustomerEventRepositoryMockRecorder) CreateEvents(ctx, expectedVersion, events any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockCustomerEventRepository)(nil).CreateEvents), ctx, events)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockCustomerEventRepository)(nil).CreateEvents), ctx, expectedVersion, events)
+}
+
+// FindCustomerIDs mocks base method.
+func (m *MockCustomerEventRepository) FindCustomerIDs(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCustomerIDs", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCustomerIDs indicates an expected call of FindCustomerIDs.
+func (mr *MockCustomerEventRepositoryMockRecorder) FindCustomerIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCustomerIDs", reflect.TypeOf((*MockCustomerEventRepository)(nil).FindCustomerIDs), ctx)
+}
+
+// FindEventsAfterVersion mocks base method.
+func (m *MockCustomerEventRepository) FindEventsAfterVersion(ctx context.Context, id uuid.UUID, version int) ([]customer.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventsAfterVersion", ctx, id, version)
+	ret0, _ := ret[0].([]customer.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventsAfterVersion indicates an expected call of FindEventsAfterVersion.
+func (mr *MockCustomerEventRepositoryMockRecorder) FindEventsAfterVersion(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsAfterVersion", reflect.TypeOf((*MockCustomerEventRepository)(nil).FindEventsAfterVersion), ctx, id, version)
+}
+
+// FindEventsByContextID mocks base method.
+func (m *MockCustomerEventRepository) FindEventsByContextID(ctx context.Context, id uuid.UUID) ([]customer.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventsByContextID", ctx, id)
+	ret0, _ := ret[0].([]customer.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventsByContextID indicates an expected call of FindEventsByContextID.
+func (mr *MockCustomerEventRepositoryMockRecorder) FindEventsByContextID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsByContextID", reflect.TypeOf((*MockCustomerEventRepository)(nil).FindEventsByContextID), ctx, id)
+}
+
+// MockCustomerSnapshotRepository is a mock of CustomerSnapshotRepository interface.
+type MockCustomerSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerSnapshotRepositoryMockRecorder is the mock recorder for MockCustomerSnapshotRepository.
+type MockCustomerSnapshotRepositoryMockRecorder struct {
+	mock *MockCustomerSnapshotRepository
+}
+
+// NewMockCustomerSnapshotRepository creates a new mock instance.
+func NewMockCustomerSnapshotRepository(ctrl *gomock.Controller) *MockCustomerSnapshotRepository {
+	mock := &MockCustomerSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerSnapshotRepository) EXPECT() *MockCustomerSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSnapshot mocks base method.
+func (m *MockCustomerSnapshotRepository) CreateSnapshot(ctx context.Context, snapshot customer.CustomerSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSnapshot indicates an expected call of CreateSnapshot.
+func (mr *MockCustomerSnapshotRepositoryMockRecorder) CreateSnapshot(ctx, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshot", reflect.TypeOf((*MockCustomerSnapshotRepository)(nil).CreateSnapshot), ctx, snapshot)
+}
+
+// FindLatestSnapshot mocks base method.
+func (m *MockCustomerSnapshotRepository) FindLatestSnapshot(ctx context.Context, id uuid.UUID) (customer.CustomerSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestSnapshot", ctx, id)
+	ret0, _ := ret[0].(customer.CustomerSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestSnapshot indicates an expected call of FindLatestSnapshot.
+func (mr *MockCustomerSnapshotRepositoryMockRecorder) FindLatestSnapshot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestSnapshot", reflect.TypeOf((*MockCustomerSnapshotRepository)(nil).FindLatestSnapshot), ctx, id)
+}
+
+// MockAccessGuard is a mock of AccessGuard interface.
+type MockAccessGuard struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessGuardMockRecorder
+	isgomock struct{}
+}
+
+// MockAccessGuardMockRecorder is the mock recorder for MockAccessGuard.
+type MockAccessGuardMockRecorder struct {
+	mock *MockAccessGuard
+}
+
+// NewMockAccessGuard creates a new mock instance.
+func NewMockAccessGuard(ctrl *gomock.Controller) *MockAccessGuard {
+	mock := &MockAccessGuard{ctrl: ctrl}
+	mock.recorder = &MockAccessGuardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessGuard) EXPECT() *MockAccessGuardMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeOwner mocks base method.
+func (m *MockAccessGuard) AuthorizeOwner(ctx context.Context, resource identity.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeOwner", ctx, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeOwner indicates an expected call of AuthorizeOwner.
+func (mr *MockAccessGuardMockRecorder) AuthorizeOwner(ctx, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeOwner", reflect.TypeOf((*MockAccessGuard)(nil).AuthorizeOwner), ctx, resource)
 }
@@ -0,0 +1,19 @@
+package event
+
+import (
+	"errors"
+)
+
+// Event errors
+var (
+	// ErrEventNotFound is returned when an event is not found.
+	ErrEventNotFound = errors.New("event not found")
+	// ErrEventStateConflict is returned when the state of the event doesn't allow the action, i.e. requeue of a completed event.
+	ErrEventStateConflict = errors.New("event state doesn't allow the action")
+	// ErrInvalidEventFilter is returned when the events can't be filtered by the given state, origin or pagination.
+	ErrInvalidEventFilter = errors.New("invalid event filter")
+	// ErrMissingActor is returned when the operator taking the action isn't given.
+	ErrMissingActor = errors.New("missing actor")
+	// ErrMissingReason is returned when the reason of the action isn't given.
+	ErrMissingReason = errors.New("missing reason")
+)
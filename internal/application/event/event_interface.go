@@ -0,0 +1,32 @@
+package event
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+//go:generate mockgen -destination=./mock/event_service_mock.go -package=mock -source=./event_interface.go
+
+// EventQueryRepository defines the interface for event queries
+type EventQueryRepository interface {
+	// FindByID retrieves an event by its ID
+	FindByID(ctx context.Context, id uuid.UUID) (*eventdomain.BaseEvent, error)
+	// FindEvents retrieves the events in the state and of the origin paginated with the limit and offset, an empty state or origin matches any
+	FindEvents(ctx context.Context, state, origin string, limit, offset int) ([]*eventdomain.BaseEvent, error)
+	// FindEventAudits retrieves the actions taken by operators on the event
+	FindEventAudits(ctx context.Context, eventID uuid.UUID) ([]eventdomain.Audit, error)
+}
+
+// EventAdminRepository defines the interface for the actions taken by operators on the events
+type EventAdminRepository interface {
+	// RequeueEvent requeues the dead-lettered event with its retry counter reset and records it in the event audit
+	RequeueEvent(ctx context.Context, id uuid.UUID, actor, reason string) error
+	// RequeueEvents requeues the dead-lettered events in the state and of the origin and records them in the event audit
+	RequeueEvents(ctx context.Context, state, origin, actor, reason string) (int, error)
+	// AbortEvent aborts the event and records it in the event audit,
+	// it returns eventdomain.ErrEventHasSuccessors when later events of its aggregate were recorded
+	AbortEvent(ctx context.Context, id uuid.UUID, actor, reason string) error
+}
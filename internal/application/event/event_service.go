@@ -0,0 +1,293 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+const (
+	// defaultEventsLimit is the number of events listed when the limit isn't given
+	defaultEventsLimit = 50
+	// maxEventsLimit is the maximum number of events listed at once
+	maxEventsLimit = 500
+)
+
+// EventService handles the use cases of operators inspecting and repairing the events,
+// i.e. requeue of the events which failed or couldn't be processed
+type EventService struct {
+	eventQueryRepo EventQueryRepository
+	eventAdminRepo EventAdminRepository
+}
+
+// NewEventService creates a new event service
+func NewEventService(eventQueryRepo EventQueryRepository, eventAdminRepo EventAdminRepository) *EventService {
+	return &EventService{
+		eventQueryRepo: eventQueryRepo,
+		eventAdminRepo: eventAdminRepo,
+	}
+}
+
+// EventResponseDTO represents the event data returned to operators, Data holds the JSON encoded payload of the event.
+// The metadata tells who and what caused the event, the events recorded before the metadata was introduced have none.
+type EventResponseDTO struct {
+	ID             string          `json:"id"`
+	ContextID      string          `json:"contextId"`
+	Origin         string          `json:"origin"`
+	Type           string          `json:"type"`
+	TypeVersion    string          `json:"typeVersion"`
+	State          string          `json:"state"`
+	Retry          int             `json:"retry"`
+	MaxRetry       int             `json:"maxRetry"`
+	CreatedAt      string          `json:"createdAt"`
+	ScheduledAt    string          `json:"scheduledAt"`
+	StartedAt      string          `json:"startedAt,omitempty"`
+	CompletedAt    string          `json:"completedAt,omitempty"`
+	FailureReason  string          `json:"failureReason,omitempty"`
+	CorrelationID  string          `json:"correlationId,omitempty"`
+	CausationID    string          `json:"causationId,omitempty"`
+	ActorID        string          `json:"actorId,omitempty"`
+	ActorType      string          `json:"actorType,omitempty"`
+	SourceIP       string          `json:"sourceIp,omitempty"`
+	IdempotencyKey string          `json:"idempotencyKey,omitempty"`
+	Data           json.RawMessage `json:"data,omitempty"`
+}
+
+// AuditResponseDTO represents an action taken by an operator on an event
+type AuditResponseDTO struct {
+	Action        string `json:"action"`
+	Actor         string `json:"actor"`
+	Reason        string `json:"reason"`
+	PreviousState string `json:"previousState"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// ListEventsDTO represents the filter of the listed events, an empty state or origin matches any
+type ListEventsDTO struct {
+	State  string `json:"state"`
+	Origin string `json:"origin"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// ListEventsResponseDTO represents a page of the listed events
+type ListEventsResponseDTO struct {
+	Events []EventResponseDTO `json:"events"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// ListEvents lists the events in the state and of the origin, in the order they were recorded
+func (s *EventService) ListEvents(ctx context.Context, dto ListEventsDTO) (ListEventsResponseDTO, error) {
+	if dto.State != "" && !eventdomain.EventState(dto.State).IsValid() {
+		return ListEventsResponseDTO{}, fmt.Errorf("unknown event state %q: %w", dto.State, ErrInvalidEventFilter)
+	}
+
+	if dto.Limit == 0 {
+		dto.Limit = defaultEventsLimit
+	}
+
+	if dto.Limit < 0 || dto.Limit > maxEventsLimit || dto.Offset < 0 {
+		return ListEventsResponseDTO{}, fmt.Errorf("limit %d and offset %d out of range: %w", dto.Limit, dto.Offset, ErrInvalidEventFilter)
+	}
+
+	events, err := s.eventQueryRepo.FindEvents(ctx, dto.State, dto.Origin, dto.Limit, dto.Offset)
+	if err != nil {
+		return ListEventsResponseDTO{}, fmt.Errorf("finding events: %w", err)
+	}
+
+	response := ListEventsResponseDTO{
+		Events: make([]EventResponseDTO, len(events)),
+		Limit:  dto.Limit,
+		Offset: dto.Offset,
+	}
+	for i, ev := range events {
+		response.Events[i] = s.mapEventToResponse(ev)
+	}
+
+	return response, nil
+}
+
+// GetEventDTO represents the data needed to inspect an event
+type GetEventDTO struct {
+	EventID uuid.UUID `json:"eventId"`
+}
+
+// EventDetailsResponseDTO represents the event together with the actions taken by operators on it
+type EventDetailsResponseDTO struct {
+	EventResponseDTO
+	Audits []AuditResponseDTO `json:"audits"`
+}
+
+// GetEvent returns the event with its payload and the actions taken by operators on it
+func (s *EventService) GetEvent(ctx context.Context, dto GetEventDTO) (EventDetailsResponseDTO, error) {
+	ev, err := s.eventQueryRepo.FindByID(ctx, dto.EventID)
+	if err != nil {
+		if errors.Is(err, eventdomain.ErrEventNotFound) {
+			return EventDetailsResponseDTO{}, fmt.Errorf("finding event by id: %w", ErrEventNotFound)
+		}
+		return EventDetailsResponseDTO{}, fmt.Errorf("finding event by id: %w", err)
+	}
+
+	audits, err := s.eventQueryRepo.FindEventAudits(ctx, dto.EventID)
+	if err != nil {
+		return EventDetailsResponseDTO{}, fmt.Errorf("finding event audits: %w", err)
+	}
+
+	response := EventDetailsResponseDTO{
+		EventResponseDTO: s.mapEventToResponse(ev),
+		Audits:           make([]AuditResponseDTO, len(audits)),
+	}
+	for i, audit := range audits {
+		response.Audits[i] = AuditResponseDTO{
+			Action:        audit.Action.String(),
+			Actor:         audit.Actor,
+			Reason:        audit.Reason,
+			PreviousState: audit.PreviousState,
+			CreatedAt:     formatTime(audit.CreatedAt),
+		}
+	}
+
+	return response, nil
+}
+
+// RequeueEventDTO represents the data needed to requeue an event
+type RequeueEventDTO struct {
+	EventID uuid.UUID `json:"eventId"`
+	Actor   string    `json:"actor"`
+	Reason  string    `json:"reason"`
+}
+
+// RequeueEvent requeues the event which failed, couldn't be processed or was aborted, with its retry counter reset
+func (s *EventService) RequeueEvent(ctx context.Context, dto RequeueEventDTO) error {
+	if strings.TrimSpace(dto.Actor) == "" {
+		return ErrMissingActor
+	}
+
+	if err := s.eventAdminRepo.RequeueEvent(ctx, dto.EventID, dto.Actor, dto.Reason); err != nil {
+		return s.mapAdminError("requeuing event", err)
+	}
+
+	return nil
+}
+
+// RequeueEventsDTO represents the filter of the requeued events, an empty origin matches any
+type RequeueEventsDTO struct {
+	State  string `json:"state"`
+	Origin string `json:"origin"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+// RequeueEventsResponseDTO represents the result of a bulk requeue
+type RequeueEventsResponseDTO struct {
+	Requeued int `json:"requeued"`
+}
+
+// RequeueEvents requeues all the events in the dead-letter state and of the origin
+func (s *EventService) RequeueEvents(ctx context.Context, dto RequeueEventsDTO) (RequeueEventsResponseDTO, error) {
+	if strings.TrimSpace(dto.Actor) == "" {
+		return RequeueEventsResponseDTO{}, ErrMissingActor
+	}
+
+	if !eventdomain.EventState(dto.State).CanRequeue() {
+		return RequeueEventsResponseDTO{}, fmt.Errorf("events in %q state can't be requeued: %w", dto.State, ErrInvalidEventFilter)
+	}
+
+	requeued, err := s.eventAdminRepo.RequeueEvents(ctx, dto.State, dto.Origin, dto.Actor, dto.Reason)
+	if err != nil {
+		return RequeueEventsResponseDTO{}, s.mapAdminError("requeuing events", err)
+	}
+
+	return RequeueEventsResponseDTO{Requeued: requeued}, nil
+}
+
+// AbortEventDTO represents the data needed to abort an event
+type AbortEventDTO struct {
+	EventID uuid.UUID `json:"eventId"`
+	Actor   string    `json:"actor"`
+	Reason  string    `json:"reason"`
+}
+
+// AbortEvent aborts the event which is waiting to be processed or ended without being processed, so it is never processed.
+// Only the last event of its aggregate can be aborted.
+func (s *EventService) AbortEvent(ctx context.Context, dto AbortEventDTO) error {
+	if strings.TrimSpace(dto.Actor) == "" {
+		return ErrMissingActor
+	}
+
+	if strings.TrimSpace(dto.Reason) == "" {
+		return ErrMissingReason
+	}
+
+	if err := s.eventAdminRepo.AbortEvent(ctx, dto.EventID, dto.Actor, dto.Reason); err != nil {
+		return s.mapAdminError("aborting event", err)
+	}
+
+	return nil
+}
+
+// mapAdminError maps the repository errors of the actions taken on the events to the service errors
+func (s *EventService) mapAdminError(action string, err error) error {
+	switch {
+	case errors.Is(err, eventdomain.ErrEventNotFound):
+		return fmt.Errorf("%s: %w", action, ErrEventNotFound)
+	case errors.Is(err, eventdomain.ErrEventStateInvalid), errors.Is(err, eventdomain.ErrEventHasSuccessors):
+		return fmt.Errorf("%s: %w: %w", action, ErrEventStateConflict, err)
+	default:
+		return fmt.Errorf("%s: %w", action, err)
+	}
+}
+
+// mapEventToResponse maps the event to the response DTO, the times the event wasn't started or completed yet are omitted
+func (s *EventService) mapEventToResponse(ev *eventdomain.BaseEvent) EventResponseDTO {
+	response := EventResponseDTO{
+		ID:             ev.GetID().String(),
+		ContextID:      ev.GetContextID().String(),
+		Origin:         ev.GetOrigin(),
+		Type:           ev.GetType(),
+		TypeVersion:    ev.GetTypeVersion(),
+		State:          ev.GetState(),
+		Retry:          ev.GetRetry(),
+		MaxRetry:       ev.GetMaxRetry(),
+		CreatedAt:      formatTime(ev.GetCreatedAt()),
+		ScheduledAt:    formatTime(ev.GetScheduledAt()),
+		StartedAt:      formatTime(ev.GetStartedAt()),
+		CompletedAt:    formatTime(ev.GetCompletedAt()),
+		FailureReason:  ev.FailureReason,
+		ActorID:        ev.Metadata.ActorID,
+		ActorType:      ev.Metadata.ActorType,
+		SourceIP:       ev.Metadata.SourceIP,
+		IdempotencyKey: ev.Metadata.IdempotencyKey,
+	}
+
+	if ev.Metadata.CorrelationID != uuid.Nil {
+		response.CorrelationID = ev.Metadata.CorrelationID.String()
+	}
+
+	if ev.Metadata.CausationID != uuid.Nil {
+		response.CausationID = ev.Metadata.CausationID.String()
+	}
+
+	if data := ev.GetEventData(); json.Valid(data) {
+		response.Data = data
+	}
+
+	return response
+}
+
+// formatTime formats the time as RFC 3339, a zero time is formatted as an empty string
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format("2006-01-02T15:04:05Z07:00")
+}
@@ -0,0 +1,500 @@
+//go:build unit
+
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/application/event/mock"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+func testEvent(state eventdomain.EventState) *eventdomain.BaseEvent {
+	now := time.Now().UTC()
+
+	return &eventdomain.BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   uuid.New(),
+		Origin:      "account",
+		Type:        "account.funds.withdrawn",
+		TypeVersion: "0.0.0",
+		State:       state.String(),
+		CreatedAt:   now,
+		ScheduledAt: now,
+		CompletedAt: now,
+		Retry:       3,
+		MaxRetry:    3,
+		Data:        []byte(`{"amount":1050}`),
+	}
+}
+
+func TestEventService_ListEvents(t *testing.T) {
+	type testCaseParams struct {
+		dto                ListEventsDTO
+		mockEventQueryRepo func(*gomock.Controller) *mock.MockEventQueryRepository
+	}
+
+	type testCaseExpected struct {
+		events int
+		limit  int
+		err    error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should list events with the default limit",
+			params: testCaseParams{
+				dto: ListEventsDTO{State: "failed", Origin: "account"},
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					mock := mock.NewMockEventQueryRepository(m)
+					mock.EXPECT().FindEvents(gomock.Any(), "failed", "account", defaultEventsLimit, 0).
+						Return([]*eventdomain.BaseEvent{testEvent(eventdomain.EventStateFailed), testEvent(eventdomain.EventStateFailed)}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				events: 2,
+				limit:  defaultEventsLimit,
+			},
+		},
+		{
+			name: "should list events of any state and origin",
+			params: testCaseParams{
+				dto: ListEventsDTO{Limit: 10, Offset: 20},
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					mock := mock.NewMockEventQueryRepository(m)
+					mock.EXPECT().FindEvents(gomock.Any(), "", "", 10, 20).Return([]*eventdomain.BaseEvent{}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				limit: 10,
+			},
+		},
+		{
+			name: "shouldn't list events - unknown state",
+			params: testCaseParams{
+				dto: ListEventsDTO{State: "stuck"},
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					return mock.NewMockEventQueryRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrInvalidEventFilter,
+			},
+		},
+		{
+			name: "shouldn't list events - limit out of range",
+			params: testCaseParams{
+				dto: ListEventsDTO{Limit: maxEventsLimit + 1},
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					return mock.NewMockEventQueryRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrInvalidEventFilter,
+			},
+		},
+		{
+			name: "shouldn't list events - negative offset",
+			params: testCaseParams{
+				dto: ListEventsDTO{Offset: -1},
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					return mock.NewMockEventQueryRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrInvalidEventFilter,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewEventService(tt.params.mockEventQueryRepo(ctrl), mock.NewMockEventAdminRepository(ctrl))
+
+			response, err := service.ListEvents(context.Background(), tt.params.dto)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, response.Events, tt.expected.events)
+			require.Equal(t, tt.expected.limit, response.Limit)
+		})
+	}
+}
+
+func TestEventService_GetEvent(t *testing.T) {
+	ev := testEvent(eventdomain.EventStateReady)
+	ev.Metadata = eventdomain.Metadata{
+		CorrelationID: uuid.New(),
+		CausationID:   uuid.New(),
+		ActorType:     eventdomain.ActorTypeAnonymous,
+		SourceIP:      "192.0.2.1",
+	}
+
+	type testCaseParams struct {
+		mockEventQueryRepo func(*gomock.Controller) *mock.MockEventQueryRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+		err       error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should return event with its payload and audits",
+			params: testCaseParams{
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					mock := mock.NewMockEventQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), ev.ID).Return(ev, nil)
+					mock.EXPECT().FindEventAudits(gomock.Any(), ev.ID).Return([]eventdomain.Audit{
+						{
+							ID:            uuid.New(),
+							EventID:       ev.ID,
+							Action:        eventdomain.AuditActionRequeued,
+							Actor:         "jane.doe",
+							Reason:        "account projection fixed",
+							PreviousState: eventdomain.EventStateFailed.String(),
+							CreatedAt:     time.Now().UTC(),
+						},
+					}, nil)
+					return mock
+				},
+			},
+		},
+		{
+			name: "shouldn't return event - event not found",
+			params: testCaseParams{
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					mock := mock.NewMockEventQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), ev.ID).Return(nil, fmt.Errorf("finding event by id: %w", eventdomain.ErrEventNotFound))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       ErrEventNotFound,
+			},
+		},
+		{
+			name: "shouldn't return event - internal error while finding audits",
+			params: testCaseParams{
+				mockEventQueryRepo: func(m *gomock.Controller) *mock.MockEventQueryRepository {
+					mock := mock.NewMockEventQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), ev.ID).Return(ev, nil)
+					mock.EXPECT().FindEventAudits(gomock.Any(), ev.ID).Return(nil, errors.New("internal error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewEventService(tt.params.mockEventQueryRepo(ctrl), mock.NewMockEventAdminRepository(ctrl))
+
+			response, err := service.GetEvent(context.Background(), GetEventDTO{EventID: ev.ID})
+			if tt.expected.wantError {
+				require.Error(t, err)
+				if tt.expected.err != nil {
+					require.ErrorIs(t, err, tt.expected.err)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, ev.ID.String(), response.ID)
+			require.JSONEq(t, `{"amount":1050}`, string(response.Data))
+			require.Empty(t, response.StartedAt)
+			require.Equal(t, ev.Metadata.CorrelationID.String(), response.CorrelationID)
+			require.Equal(t, ev.Metadata.CausationID.String(), response.CausationID)
+			require.Equal(t, eventdomain.ActorTypeAnonymous, response.ActorType)
+			require.Empty(t, response.ActorID)
+			require.Equal(t, "192.0.2.1", response.SourceIP)
+			require.Len(t, response.Audits, 1)
+			require.Equal(t, "requeued", response.Audits[0].Action)
+		})
+	}
+}
+
+func TestEventService_RequeueEvent(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		dto                RequeueEventDTO
+		mockEventAdminRepo func(*gomock.Controller) *mock.MockEventAdminRepository
+	}
+
+	type testCaseExpected struct {
+		err error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should requeue event",
+			params: testCaseParams{
+				dto: RequeueEventDTO{EventID: eventID, Actor: "jane.doe", Reason: "account projection fixed"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					mock := mock.NewMockEventAdminRepository(m)
+					mock.EXPECT().RequeueEvent(gomock.Any(), eventID, "jane.doe", "account projection fixed").Return(nil)
+					return mock
+				},
+			},
+		},
+		{
+			name: "shouldn't requeue event - missing actor",
+			params: testCaseParams{
+				dto: RequeueEventDTO{EventID: eventID, Actor: " "},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					return mock.NewMockEventAdminRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrMissingActor,
+			},
+		},
+		{
+			name: "shouldn't requeue event - event not found",
+			params: testCaseParams{
+				dto: RequeueEventDTO{EventID: eventID, Actor: "jane.doe"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					mock := mock.NewMockEventAdminRepository(m)
+					mock.EXPECT().RequeueEvent(gomock.Any(), eventID, "jane.doe", "").Return(eventdomain.ErrEventNotFound)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrEventNotFound,
+			},
+		},
+		{
+			name: "shouldn't requeue event - event completed",
+			params: testCaseParams{
+				dto: RequeueEventDTO{EventID: eventID, Actor: "jane.doe"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					mock := mock.NewMockEventAdminRepository(m)
+					mock.EXPECT().RequeueEvent(gomock.Any(), eventID, "jane.doe", "").Return(eventdomain.ErrEventStateInvalid)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrEventStateConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewEventService(mock.NewMockEventQueryRepository(ctrl), tt.params.mockEventAdminRepo(ctrl))
+
+			err := service.RequeueEvent(context.Background(), tt.params.dto)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEventService_RequeueEvents(t *testing.T) {
+	type testCaseParams struct {
+		dto                RequeueEventsDTO
+		mockEventAdminRepo func(*gomock.Controller) *mock.MockEventAdminRepository
+	}
+
+	type testCaseExpected struct {
+		requeued int
+		err      error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should requeue failed account events",
+			params: testCaseParams{
+				dto: RequeueEventsDTO{State: "failed", Origin: "account", Actor: "jane.doe", Reason: "database outage"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					mock := mock.NewMockEventAdminRepository(m)
+					mock.EXPECT().RequeueEvents(gomock.Any(), "failed", "account", "jane.doe", "database outage").Return(7, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				requeued: 7,
+			},
+		},
+		{
+			name: "shouldn't requeue events - state not dead-lettered",
+			params: testCaseParams{
+				dto: RequeueEventsDTO{State: "completed", Actor: "jane.doe"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					return mock.NewMockEventAdminRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrInvalidEventFilter,
+			},
+		},
+		{
+			name: "shouldn't requeue events - missing actor",
+			params: testCaseParams{
+				dto: RequeueEventsDTO{State: "failed"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					return mock.NewMockEventAdminRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrMissingActor,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewEventService(mock.NewMockEventQueryRepository(ctrl), tt.params.mockEventAdminRepo(ctrl))
+
+			response, err := service.RequeueEvents(context.Background(), tt.params.dto)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.requeued, response.Requeued)
+		})
+	}
+}
+
+func TestEventService_AbortEvent(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		dto                AbortEventDTO
+		mockEventAdminRepo func(*gomock.Controller) *mock.MockEventAdminRepository
+	}
+
+	type testCaseExpected struct {
+		err error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should abort event",
+			params: testCaseParams{
+				dto: AbortEventDTO{EventID: eventID, Actor: "jane.doe", Reason: "duplicated withdrawal"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					mock := mock.NewMockEventAdminRepository(m)
+					mock.EXPECT().AbortEvent(gomock.Any(), eventID, "jane.doe", "duplicated withdrawal").Return(nil)
+					return mock
+				},
+			},
+		},
+		{
+			name: "shouldn't abort event - missing reason",
+			params: testCaseParams{
+				dto: AbortEventDTO{EventID: eventID, Actor: "jane.doe"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					return mock.NewMockEventAdminRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrMissingReason,
+			},
+		},
+		{
+			name: "shouldn't abort event - event being processed",
+			params: testCaseParams{
+				dto: AbortEventDTO{EventID: eventID, Actor: "jane.doe", Reason: "duplicated withdrawal"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					mock := mock.NewMockEventAdminRepository(m)
+					mock.EXPECT().AbortEvent(gomock.Any(), eventID, "jane.doe", "duplicated withdrawal").
+						Return(fmt.Errorf("aborting event in processing state: %w", eventdomain.ErrEventStateInvalid))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrEventStateConflict,
+			},
+		},
+		{
+			name: "shouldn't abort event - later events of aggregate recorded",
+			params: testCaseParams{
+				dto: AbortEventDTO{EventID: eventID, Actor: "jane.doe", Reason: "duplicated withdrawal"},
+				mockEventAdminRepo: func(m *gomock.Controller) *mock.MockEventAdminRepository {
+					mock := mock.NewMockEventAdminRepository(m)
+					mock.EXPECT().AbortEvent(gomock.Any(), eventID, "jane.doe", "duplicated withdrawal").
+						Return(fmt.Errorf("aborting event in version 2: %w", eventdomain.ErrEventHasSuccessors))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrEventStateConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewEventService(mock.NewMockEventQueryRepository(ctrl), tt.params.mockEventAdminRepo(ctrl))
+
+			err := service.AbortEvent(context.Background(), tt.params.dto)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
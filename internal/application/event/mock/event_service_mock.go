@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./event_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/event_service_mock.go -package=mock -source=./event_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	event "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventQueryRepository is a mock of EventQueryRepository interface.
+type MockEventQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventQueryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventQueryRepositoryMockRecorder is the mock recorder for MockEventQueryRepository.
+type MockEventQueryRepositoryMockRecorder struct {
+	mock *MockEventQueryRepository
+}
+
+// NewMockEventQueryRepository creates a new mock instance.
+func NewMockEventQueryRepository(ctrl *gomock.Controller) *MockEventQueryRepository {
+	mock := &MockEventQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockEventQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventQueryRepository) EXPECT() *MockEventQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockEventQueryRepository) FindByID(ctx context.Context, id uuid.UUID) (*event.BaseEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*event.BaseEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockEventQueryRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockEventQueryRepository)(nil).FindByID), ctx, id)
+}
+
+// FindEventAudits mocks base method.
+func (m *MockEventQueryRepository) FindEventAudits(ctx context.Context, eventID uuid.UUID) ([]event.Audit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventAudits", ctx, eventID)
+	ret0, _ := ret[0].([]event.Audit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventAudits indicates an expected call of FindEventAudits.
+func (mr *MockEventQueryRepositoryMockRecorder) FindEventAudits(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventAudits", reflect.TypeOf((*MockEventQueryRepository)(nil).FindEventAudits), ctx, eventID)
+}
+
+// FindEvents mocks base method.
+func (m *MockEventQueryRepository) FindEvents(ctx context.Context, state, origin string, limit, offset int) ([]*event.BaseEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEvents", ctx, state, origin, limit, offset)
+	ret0, _ := ret[0].([]*event.BaseEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEvents indicates an expected call of FindEvents.
+func (mr *MockEventQueryRepositoryMockRecorder) FindEvents(ctx, state, origin, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEvents", reflect.TypeOf((*MockEventQueryRepository)(nil).FindEvents), ctx, state, origin, limit, offset)
+}
+
+// MockEventAdminRepository is a mock of EventAdminRepository interface.
+type MockEventAdminRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventAdminRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventAdminRepositoryMockRecorder is the mock recorder for MockEventAdminRepository.
+type MockEventAdminRepositoryMockRecorder struct {
+	mock *MockEventAdminRepository
+}
+
+// NewMockEventAdminRepository creates a new mock instance.
+func NewMockEventAdminRepository(ctrl *gomock.Controller) *MockEventAdminRepository {
+	mock := &MockEventAdminRepository{ctrl: ctrl}
+	mock.recorder = &MockEventAdminRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventAdminRepository) EXPECT() *MockEventAdminRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AbortEvent mocks base method.
+func (m *MockEventAdminRepository) AbortEvent(ctx context.Context, id uuid.UUID, actor, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortEvent", ctx, id, actor, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortEvent indicates an expected call of AbortEvent.
+func (mr *MockEventAdminRepositoryMockRecorder) AbortEvent(ctx, id, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortEvent", reflect.TypeOf((*MockEventAdminRepository)(nil).AbortEvent), ctx, id, actor, reason)
+}
+
+// RequeueEvent mocks base method.
+func (m *MockEventAdminRepository) RequeueEvent(ctx context.Context, id uuid.UUID, actor, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEvent", ctx, id, actor, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueEvent indicates an expected call of RequeueEvent.
+func (mr *MockEventAdminRepositoryMockRecorder) RequeueEvent(ctx, id, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEvent", reflect.TypeOf((*MockEventAdminRepository)(nil).RequeueEvent), ctx, id, actor, reason)
+}
+
+// RequeueEvents mocks base method.
+func (m *MockEventAdminRepository) RequeueEvents(ctx context.Context, state, origin, actor, reason string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEvents", ctx, state, origin, actor, reason)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueEvents indicates an expected call of RequeueEvents.
+func (mr *MockEventAdminRepositoryMockRecorder) RequeueEvents(ctx, state, origin, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEvents", reflect.TypeOf((*MockEventAdminRepository)(nil).RequeueEvents), ctx, state, origin, actor, reason)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./transaction_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/transaction_service_mock.go -package=mock -source=./transaction_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	account "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	transaction "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountQueryRepository is a mock of AccountQueryRepository interface.
+type MockAccountQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountQueryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountQueryRepositoryMockRecorder is the mock recorder for MockAccountQueryRepository.
+type MockAccountQueryRepositoryMockRecorder struct {
+	mock *MockAccountQueryRepository
+}
+
+// NewMockAccountQueryRepository creates a new mock instance.
+func NewMockAccountQueryRepository(ctrl *gomock.Controller) *MockAccountQueryRepository {
+	mock := &MockAccountQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountQueryRepository) EXPECT() *MockAccountQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockAccountQueryRepository) FindByID(ctx context.Context, id uuid.UUID) (*account.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*account.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAccountQueryRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAccountQueryRepository)(nil).FindByID), ctx, id)
+}
+
+// MockTransactionQueryRepository is a mock of TransactionQueryRepository interface.
+type MockTransactionQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionQueryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionQueryRepositoryMockRecorder is the mock recorder for MockTransactionQueryRepository.
+type MockTransactionQueryRepositoryMockRecorder struct {
+	mock *MockTransactionQueryRepository
+}
+
+// NewMockTransactionQueryRepository creates a new mock instance.
+func NewMockTransactionQueryRepository(ctrl *gomock.Controller) *MockTransactionQueryRepository {
+	mock := &MockTransactionQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionQueryRepository) EXPECT() *MockTransactionQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockTransactionQueryRepository) FindByID(ctx context.Context, id uuid.UUID) (*transaction.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*transaction.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTransactionQueryRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTransactionQueryRepository)(nil).FindByID), ctx, id)
+}
+
+// MockTransactionEventRepository is a mock of TransactionEventRepository interface.
+type MockTransactionEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionEventRepositoryMockRecorder is the mock recorder for MockTransactionEventRepository.
+type MockTransactionEventRepositoryMockRecorder struct {
+	mock *MockTransactionEventRepository
+}
+
+// NewMockTransactionEventRepository creates a new mock instance.
+func NewMockTransactionEventRepository(ctrl *gomock.Controller) *MockTransactionEventRepository {
+	mock := &MockTransactionEventRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionEventRepository) EXPECT() *MockTransactionEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEvents mocks base method.
+func (m *MockTransactionEventRepository) CreateEvents(ctx context.Context, expectedVersion int, events []transaction.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvents", ctx, expectedVersion, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvents indicates an expected call of CreateEvents.
+func (mr *MockTransactionEventRepositoryMockRecorder) CreateEvents(ctx, expectedVersion, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockTransactionEventRepository)(nil).CreateEvents), ctx, expectedVersion, events)
+}
@@ -0,0 +1,21 @@
+package transaction
+
+import (
+	"errors"
+)
+
+// Transaction errors
+var (
+	// ErrTransactionNotFound is returned when a transaction is not found.
+	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrSourceAccountNotFound is returned when the account the money is transferred from is not found.
+	ErrSourceAccountNotFound = errors.New("source account not found")
+	// ErrTargetAccountNotFound is returned when the account the money is transferred to is not found.
+	ErrTargetAccountNotFound = errors.New("target account not found")
+	// ErrInvalidTransferAmount is returned when the transferred money amount is invalid.
+	ErrInvalidTransferAmount = errors.New("invalid transfer money amount")
+	// ErrSameAccountTransfer is returned when the money is transferred to the same account.
+	ErrSameAccountTransfer = errors.New("source and target accounts are the same")
+	// ErrCurrencyMismatch is returned when the source and target accounts have different currencies.
+	ErrCurrencyMismatch = errors.New("source and target account currencies differ")
+)
@@ -1 +1,34 @@
 package transaction
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+//go:generate mockgen -destination=./mock/transaction_service_mock.go -package=mock -source=./transaction_interface.go
+
+//           Account
+
+// AccountQueryRepository defines the interface for account queries
+type AccountQueryRepository interface {
+	// FindByID retrieves an account by its ID
+	FindByID(ctx context.Context, id uuid.UUID) (*accountdomain.Account, error)
+}
+
+//           Transaction
+
+// TransactionQueryRepository defines the interface for transaction queries
+type TransactionQueryRepository interface {
+	// FindByID retrieves a transaction by its ID
+	FindByID(ctx context.Context, id uuid.UUID) (*transactiondomain.Transaction, error)
+}
+
+// TransactionEventRepository defines the interface for transaction event persistence
+type TransactionEventRepository interface {
+	// CreateEvents persists the transaction events after the expected version of the transaction,
+	// it returns event.ErrConcurrencyConflict when the transaction was changed in the meantime
+	CreateEvents(ctx context.Context, expectedVersion int, events []transactiondomain.Event) error
+}
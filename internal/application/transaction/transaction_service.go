@@ -1 +1,144 @@
 package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+type Transaction = transactiondomain.Transaction
+
+// TransferService handles money transfer use cases
+type TransferService struct {
+	accountQueryRepo     AccountQueryRepository
+	transactionQueryRepo TransactionQueryRepository
+
+	transactionEventRepo TransactionEventRepository
+}
+
+// NewTransferService creates a new transfer service
+func NewTransferService(
+	accountQueryRepo AccountQueryRepository,
+	transactionQueryRepo TransactionQueryRepository,
+	transactionEventRepo TransactionEventRepository) *TransferService {
+	return &TransferService{
+		accountQueryRepo:     accountQueryRepo,
+		transactionQueryRepo: transactionQueryRepo,
+		transactionEventRepo: transactionEventRepo,
+	}
+}
+
+// TransferDTO represents the data needed to transfer money between two accounts.
+// The amount is a decimal amount in the currency of both accounts, i.e. "10.50".
+type TransferDTO struct {
+	SourceAccountID uuid.UUID `json:"sourceAccountId"`
+	TargetAccountID uuid.UUID `json:"targetAccountId"`
+	Amount          string    `json:"amount"`
+}
+
+// TransactionResponseDTO represents the transaction data returned to clients.
+// The amount is a decimal amount with all the currency minor units, i.e. "10.50".
+type TransactionResponseDTO struct {
+	ID              string `json:"id"`
+	SourceAccountID string `json:"sourceAccountId"`
+	TargetAccountID string `json:"targetAccountId"`
+	Amount          string `json:"amount"`
+	Currency        string `json:"currency"`
+	Status          string `json:"status"`
+	FailureReason   string `json:"failureReason,omitempty"`
+	CreatedAt       string `json:"createdAt"`
+	UpdatedAt       string `json:"updatedAt"`
+}
+
+type TransferResponseDTO struct {
+	TransactionResponseDTO
+}
+
+// Transfer initiates a money transfer between two accounts.
+// The transfer is completed asynchronously by the orchestrator, which runs the saga started by the initiation event.
+func (s *TransferService) Transfer(ctx context.Context, dto TransferDTO) (TransferResponseDTO, error) {
+	if dto.SourceAccountID == dto.TargetAccountID {
+		return TransferResponseDTO{}, ErrSameAccountTransfer
+	}
+
+	source, err := s.accountQueryRepo.FindByID(ctx, dto.SourceAccountID)
+	if err != nil {
+		if errors.Is(err, accountdomain.ErrAccountNotFound) {
+			return TransferResponseDTO{}, fmt.Errorf("finding source account by id: %w", ErrSourceAccountNotFound)
+		}
+		return TransferResponseDTO{}, fmt.Errorf("finding source account by id: %w", err)
+	}
+
+	target, err := s.accountQueryRepo.FindByID(ctx, dto.TargetAccountID)
+	if err != nil {
+		if errors.Is(err, accountdomain.ErrAccountNotFound) {
+			return TransferResponseDTO{}, fmt.Errorf("finding target account by id: %w", ErrTargetAccountNotFound)
+		}
+		return TransferResponseDTO{}, fmt.Errorf("finding target account by id: %w", err)
+	}
+
+	if source.Balance.Currency() != target.Balance.Currency() {
+		return TransferResponseDTO{}, ErrCurrencyMismatch
+	}
+
+	amount, err := money.Parse(dto.Amount, source.Balance.Currency())
+	if err != nil {
+		return TransferResponseDTO{}, fmt.Errorf("parsing transfer amount: %w: %w", ErrInvalidTransferAmount, err)
+	}
+
+	if !amount.IsPositive() {
+		return TransferResponseDTO{}, ErrInvalidTransferAmount
+	}
+
+	transaction, err := transactiondomain.NewTransfer(uuid.New(), source.ID, target.ID, amount)
+	if err != nil {
+		return TransferResponseDTO{}, fmt.Errorf("creating transfer: %w", err)
+	}
+
+	// The new transfer has no events recorded yet, its events start the transaction in version 1
+	if err := s.transactionEventRepo.CreateEvents(ctx, 0, transaction.GetEvents()); err != nil {
+		return TransferResponseDTO{}, err
+	}
+
+	return TransferResponseDTO{
+		TransactionResponseDTO: ToDTO(transaction),
+	}, nil
+}
+
+type GetTransferDTO struct {
+	TransactionID uuid.UUID `json:"transactionId"`
+}
+
+// GetTransfer retrieves a transaction by its ID
+func (s *TransferService) GetTransfer(ctx context.Context, dto GetTransferDTO) (TransactionResponseDTO, error) {
+	transaction, err := s.transactionQueryRepo.FindByID(ctx, dto.TransactionID)
+	if err != nil {
+		if errors.Is(err, transactiondomain.ErrTransactionNotFound) {
+			return TransactionResponseDTO{}, fmt.Errorf("finding transaction by id: %w", ErrTransactionNotFound)
+		}
+		return TransactionResponseDTO{}, fmt.Errorf("finding transaction by id: %w", err)
+	}
+
+	return ToDTO(transaction), nil
+}
+
+// ToDTO converts a Transaction domain model to TransactionResponseDTO
+func ToDTO(transaction *Transaction) TransactionResponseDTO {
+	return TransactionResponseDTO{
+		ID:              transaction.ID.String(),
+		SourceAccountID: transaction.SourceAccountID.String(),
+		TargetAccountID: transaction.TargetAccountID.String(),
+		Amount:          transaction.Amount.Decimal(),
+		Currency:        transaction.Amount.Currency().String(),
+		Status:          transaction.Status.String(),
+		FailureReason:   transaction.FailureReason,
+		CreatedAt:       transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:       transaction.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -1,3 +1,308 @@
 //go:build unit
 
 package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/application/transaction/mock"
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+func testMoney(t *testing.T, amount int64, currency money.Currency) money.Money {
+	m, err := money.New(amount, currency)
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestTransferService_Transfer(t *testing.T) {
+	sourceAccountID := uuid.New()
+	targetAccountID := uuid.New()
+
+	sourceAccount := &accountdomain.Account{ID: sourceAccountID, Balance: testMoney(t, 10000, "USD"), Status: accountdomain.AccountStatusActive}
+	targetAccount := &accountdomain.Account{ID: targetAccountID, Balance: testMoney(t, 0, "USD"), Status: accountdomain.AccountStatusActive}
+
+	type testCaseParams struct {
+		dto                      TransferDTO
+		mockAccountQueryRepo     func(*gomock.Controller) *mock.MockAccountQueryRepository
+		mockTransactionEventRepo func(*gomock.Controller) *mock.MockTransactionEventRepository
+	}
+
+	type testCaseExpected struct {
+		wantError        bool
+		wantErrorCompare bool
+		err              error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should return error for non positive amount",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: targetAccountID, Amount: "0"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), sourceAccountID).Return(sourceAccount, nil)
+					mock.EXPECT().FindByID(gomock.Any(), targetAccountID).Return(targetAccount, nil)
+					return mock
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					return mock.NewMockTransactionEventRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrInvalidTransferAmount,
+			},
+		},
+		{
+			name: "should return error for amount more precise than the account currency",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: targetAccountID, Amount: "10.001"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), sourceAccountID).Return(sourceAccount, nil)
+					mock.EXPECT().FindByID(gomock.Any(), targetAccountID).Return(targetAccount, nil)
+					return mock
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					return mock.NewMockTransactionEventRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrInvalidTransferAmount,
+			},
+		},
+		{
+			name: "should return error for transfer to the same account",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: sourceAccountID, Amount: "10.50"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					return mock.NewMockAccountQueryRepository(m)
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					return mock.NewMockTransactionEventRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrSameAccountTransfer,
+			},
+		},
+		{
+			name: "should return error - source account not found",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: targetAccountID, Amount: "10.50"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), sourceAccountID).Return(nil, accountdomain.ErrAccountNotFound)
+					return mock
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					return mock.NewMockTransactionEventRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrSourceAccountNotFound,
+			},
+		},
+		{
+			name: "should return error - target account not found",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: targetAccountID, Amount: "10.50"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), sourceAccountID).Return(sourceAccount, nil)
+					mock.EXPECT().FindByID(gomock.Any(), targetAccountID).Return(nil, accountdomain.ErrAccountNotFound)
+					return mock
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					return mock.NewMockTransactionEventRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrTargetAccountNotFound,
+			},
+		},
+		{
+			name: "should return error - account currencies differ",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: targetAccountID, Amount: "10.50"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), sourceAccountID).Return(sourceAccount, nil)
+					mock.EXPECT().FindByID(gomock.Any(), targetAccountID).Return(&accountdomain.Account{ID: targetAccountID, Balance: testMoney(t, 0, "EUR")}, nil)
+					return mock
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					return mock.NewMockTransactionEventRepository(m)
+				},
+			},
+			expected: testCaseExpected{
+				wantError:        true,
+				wantErrorCompare: true,
+				err:              ErrCurrencyMismatch,
+			},
+		},
+		{
+			name: "should return error - events couldn't be persisted",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: targetAccountID, Amount: "10.50"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), sourceAccountID).Return(sourceAccount, nil)
+					mock.EXPECT().FindByID(gomock.Any(), targetAccountID).Return(targetAccount, nil)
+					return mock
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					mock := mock.NewMockTransactionEventRepository(m)
+					mock.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+		{
+			name: "should initiate transfer successfully",
+			params: testCaseParams{
+				dto: TransferDTO{SourceAccountID: sourceAccountID, TargetAccountID: targetAccountID, Amount: "10.50"},
+				mockAccountQueryRepo: func(m *gomock.Controller) *mock.MockAccountQueryRepository {
+					mock := mock.NewMockAccountQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), sourceAccountID).Return(sourceAccount, nil)
+					mock.EXPECT().FindByID(gomock.Any(), targetAccountID).Return(targetAccount, nil)
+					return mock
+				},
+				mockTransactionEventRepo: func(m *gomock.Controller) *mock.MockTransactionEventRepository {
+					mock := mock.NewMockTransactionEventRepository(m)
+					mock.EXPECT().CreateEvents(gomock.Any(), 0, gomock.Len(1)).Return(nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewTransferService(
+				tt.params.mockAccountQueryRepo(ctrl),
+				mock.NewMockTransactionQueryRepository(ctrl),
+				tt.params.mockTransactionEventRepo(ctrl),
+			)
+			transfer, err := service.Transfer(context.Background(), tt.params.dto)
+
+			if tt.expected.wantError {
+				require.Error(t, err)
+				if tt.expected.wantErrorCompare {
+					require.ErrorIs(t, err, tt.expected.err)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, transactiondomain.TransactionStatusInitiated.String(), transfer.Status)
+			require.Equal(t, "USD", transfer.Currency)
+			require.Equal(t, tt.params.dto.Amount, transfer.Amount)
+		})
+	}
+}
+
+func TestTransferService_GetTransfer(t *testing.T) {
+	transactionID := uuid.New()
+
+	type testCaseParams struct {
+		mockTransactionQueryRepo func(*gomock.Controller) *mock.MockTransactionQueryRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+		err       error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should return error - transaction not found",
+			params: testCaseParams{
+				mockTransactionQueryRepo: func(m *gomock.Controller) *mock.MockTransactionQueryRepository {
+					mock := mock.NewMockTransactionQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), transactionID).Return(nil, transactiondomain.ErrTransactionNotFound)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+				err:       ErrTransactionNotFound,
+			},
+		},
+		{
+			name: "should return transaction",
+			params: testCaseParams{
+				mockTransactionQueryRepo: func(m *gomock.Controller) *mock.MockTransactionQueryRepository {
+					mock := mock.NewMockTransactionQueryRepository(m)
+					mock.EXPECT().FindByID(gomock.Any(), transactionID).Return(&transactiondomain.Transaction{
+						ID:       transactionID,
+						Transfer: transactiondomain.Transfer{Amount: testMoney(t, 1050, "USD")},
+						Status:   transactiondomain.TransactionStatusCompleted,
+					}, nil)
+					return mock
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewTransferService(
+				mock.NewMockAccountQueryRepository(ctrl),
+				tt.params.mockTransactionQueryRepo(ctrl),
+				mock.NewMockTransactionEventRepository(ctrl),
+			)
+			transfer, err := service.GetTransfer(context.Background(), GetTransferDTO{TransactionID: transactionID})
+
+			if tt.expected.wantError {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, transactionID.String(), transfer.ID)
+			require.Equal(t, transactiondomain.TransactionStatusCompleted.String(), transfer.Status)
+			require.Equal(t, "10.50", transfer.Amount)
+			require.Equal(t, "USD", transfer.Currency)
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package account
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
 )
 
 type EventOrigin = event.EventOrigin
@@ -30,162 +32,374 @@ type Account struct {
 	ID            uuid.UUID     // Unique identifier of the account, must be in UUID format
 	CustomerID    uuid.UUID     // Unique identifier of the customer, must be in UUID format
 	AccountNumber string        // Account number (e.g., 1234567890)
-	Balance       float64       // Current balance of the account
-	Status        AccountStatus // Current status of the account (active/blocked)
-	Currency      string        // Currency code (e.g., USD, EUR)
+	Balance       money.Money   // Current balance of the account in the account currency
+	Status        AccountStatus // Current status of the account (pending/active/blocked/frozen/closed)
 	CreatedAt     time.Time     // When the account was created
 	UpdatedAt     time.Time     // When the account was last updated
+	Version       int           // Version of the account, the aggregate version of the last event applied to the account
 	events        []Event       // List of domain events that occurred on this account
+
+	unblockAt time.Time // When the unblocking scheduled for the blocked account takes effect, zero when no unblocking is pending
 }
 
 // NewAccount creates a new account with the given ID and initial balance.
-// It automatically sets the account status to active and records the creation event.
-func NewAccount(id uuid.UUID, customerID uuid.UUID, number string, initialBalance float64, currency string) *Account {
+// The currency of the initial balance becomes the account currency.
+// It sets the account status to pending, the account performs transactions once it's activated, and records the creation event.
+func NewAccount(id uuid.UUID, customerID uuid.UUID, number string, initialBalance money.Money) *Account {
 	now := time.Now().UTC()
 	account := &Account{
 		ID:            id,
 		CustomerID:    customerID,
 		AccountNumber: number,
 		Balance:       initialBalance,
-		Currency:      currency,
-		Status:        AccountStatusActive,
+		Status:        AccountStatusPending,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 		events:        make([]Event, 0),
 	}
 
-	origin := EventOrigin("account")
-
 	account.addEvent(&AccountCreatedEvent{
-		BaseEvent: event.BaseEvent{
-			ID:          uuid.New(),
-			ContextID:   id,
-			Origin:      origin.String(),
-			Type:        AccountCreatedEventType.String(),
-			TypeVersion: "0.0.0",
-			State:       event.EventStateReady.String(),
-			CreatedAt:   now,
-			ScheduledAt: now,
-			Retry:       0,
-			MaxRetry:    3,
-			Data:        nil,
-		},
+		BaseEvent:      account.newBaseEvent(AccountCreatedEventType, now),
 		InitialBalance: initialBalance,
 		CustomerID:     customerID,
+		AccountNumber:  number,
 	})
 
 	return account
 }
 
-// Block marks the account as blocked, preventing any transactions.
-// It updates the account status and records a blocking event.
-func (a *Account) Block() {
-	now := time.Now().UTC()
-	a.UpdatedAt = now
-	a.Status = AccountStatusBlocked
+// NewAccountFromEvents rebuilds the account by applying its events in the order they were recorded.
+// It returns ErrAccountNotFound if there are no events or the creation of the account was aborted, the account starts with its creation event.
+func NewAccountFromEvents(events []Event) (*Account, error) {
+	account := &Account{
+		events: make([]Event, 0),
+	}
 
-	origin := EventOrigin("account")
+	for _, e := range events {
+		if err := account.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+
+	if account.Status == "" {
+		return nil, ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+// Activate marks the pending account as active, allowing transactions.
+// It updates the account status and records an activation event.
+func (a *Account) Activate() error {
+	now, err := a.transition(AccountOperationActivate)
+	if err != nil {
+		return err
+	}
+
+	a.addEvent(&AccountActivatedEvent{
+		BaseEvent: a.newBaseEvent(AccountActivatedEventType, now),
+	})
+
+	return nil
+}
+
+// Block marks the active account as blocked, preventing any transactions.
+// It updates the account status and records a blocking event.
+func (a *Account) Block() error {
+	now, err := a.transition(AccountOperationBlock)
+	if err != nil {
+		return err
+	}
 
 	a.addEvent(&AccountBlockedEvent{
-		BaseEvent: event.BaseEvent{
-			ID:          uuid.New(),
-			ContextID:   a.ID,
-			Origin:      origin.String(),
-			Type:        AccountBlockedEventType.String(),
-			TypeVersion: "0.0.0",
-			State:       event.EventStateReady.String(),
-			CreatedAt:   now,
-			ScheduledAt: now,
-			Retry:       0,
-			MaxRetry:    3,
-			Data:        nil,
-		},
+		BaseEvent: a.newBaseEvent(AccountBlockedEventType, now),
 	})
+
+	return nil
 }
 
-// Unblock marks the account as active, allowing transactions again.
+// Unblock marks the blocked account as active, allowing transactions again.
 // It updates the account status and records an unblocking event.
-func (a *Account) Unblock() {
+func (a *Account) Unblock() error {
+	now, err := a.transition(AccountOperationUnblock)
+	if err != nil {
+		return err
+	}
+
+	a.addEvent(&AccountUnblockedEvent{
+		BaseEvent: a.newBaseEvent(AccountUnblockedEventType, now),
+	})
+
+	return nil
+}
+
+// ScheduleUnblock records an unblocking event which is processed at the given time, i.e. when a temporary block ends.
+// The account stays blocked until the event is due, so the account has to be blocked and the time has to be in the future.
+// It replaces the unblocking scheduled before, and any later change of the account status cancels it.
+func (a *Account) ScheduleUnblock(at time.Time) error {
 	now := time.Now().UTC()
-	a.UpdatedAt = now
-	a.Status = AccountStatusActive
+	a.settleUnblock(now)
 
-	origin := EventOrigin("account")
+	if a.Status != AccountStatusBlocked {
+		return fmt.Errorf("scheduling unblock of %s account: %w", a.Status, ErrAccountNotBlocked)
+	}
 
-	a.addEvent(&AccountUnblockedEvent{
-		BaseEvent: event.BaseEvent{
-			ID:          uuid.New(),
-			ContextID:   a.ID,
-			Origin:      origin.String(),
-			Type:        AccountUnblockedEventType.String(),
-			TypeVersion: "0.0.0",
-			State:       event.EventStateReady.String(),
-			CreatedAt:   now,
-			ScheduledAt: now,
-			Retry:       0,
-			MaxRetry:    3,
-			Data:        nil,
-		},
+	if !at.After(now) {
+		return fmt.Errorf("scheduling unblock at %s: %w", at.UTC().Format(time.RFC3339), ErrAccountUnblockNotInFuture)
+	}
+
+	unblockedEvent := &AccountUnblockedEvent{
+		BaseEvent: a.newBaseEvent(AccountUnblockedEventType, now),
+	}
+	unblockedEvent.Schedule(at.UTC())
+
+	a.unblockAt = at.UTC()
+	a.addEvent(unblockedEvent)
+
+	return nil
+}
+
+// Freeze marks the active account as frozen, the account accepts deposits, but the funds can't be withdrawn.
+// It updates the account status and records a freezing event.
+func (a *Account) Freeze() error {
+	now, err := a.transition(AccountOperationFreeze)
+	if err != nil {
+		return err
+	}
+
+	a.addEvent(&AccountFrozenEvent{
+		BaseEvent: a.newBaseEvent(AccountFrozenEventType, now),
+	})
+
+	return nil
+}
+
+// Unfreeze marks the frozen account as active, allowing withdrawals again.
+// It updates the account status and records an unfreezing event.
+func (a *Account) Unfreeze() error {
+	now, err := a.transition(AccountOperationUnfreeze)
+	if err != nil {
+		return err
+	}
+
+	a.addEvent(&AccountUnfrozenEvent{
+		BaseEvent: a.newBaseEvent(AccountUnfrozenEventType, now),
+	})
+
+	return nil
+}
+
+// Close marks the account as closed for good, the account can't perform transactions anymore.
+// It returns an error if the account still holds funds, otherwise it updates the account status and records a closing event.
+func (a *Account) Close() error {
+	if !a.Balance.IsZero() {
+		return fmt.Errorf("closing account with balance %s: %w", a.Balance, ErrAccountBalanceNotZero)
+	}
+
+	now, err := a.transition(AccountOperationClose)
+	if err != nil {
+		return err
+	}
+
+	a.addEvent(&AccountClosedEvent{
+		BaseEvent: a.newBaseEvent(AccountClosedEventType, now),
 	})
+
+	return nil
 }
 
 // Deposit adds the specified amount to the account balance.
-// It updates the account's balance and records a deposit event.
-func (a *Account) Deposit(amount float64) {
+// It returns an error if the account doesn't accept deposits or the amount is in a different currency than the account.
+// On success, it updates the account's balance and records a deposit event.
+func (a *Account) Deposit(amount money.Money) error {
+	now := time.Now().UTC()
+	a.settleUnblock(now)
+
+	if !a.Status.CanDeposit() {
+		return fmt.Errorf("depositing funds into %s account: %w", a.Status, ErrAccountDepositNotAllowed)
+	}
+
+	return a.deposit(amount, now)
+}
+
+// Refund returns the funds reserved by a transfer which couldn't be completed.
+// The funds belong to the account holder, so they are returned whatever the account status is.
+func (a *Account) Refund(amount money.Money) error {
+	return a.deposit(amount, time.Now().UTC())
+}
+
+// Withdraw subtracts the specified amount from the account balance.
+// It returns an error if the account isn't active, the amount is in a different currency than the account
+// or the balance doesn't cover the amount.
+// On success, it updates the balance and records a withdrawal event.
+func (a *Account) Withdraw(amount money.Money) error {
 	now := time.Now().UTC()
-	a.Balance += amount
+	a.settleUnblock(now)
+
+	if !a.Status.CanWithdraw() {
+		return fmt.Errorf("withdrawing funds from %s account: %w", a.Status, ErrAccountWithdrawalNotAllowed)
+	}
+
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return fmt.Errorf("withdrawing funds: %w", err)
+	}
+
+	if balance.IsNegative() {
+		return fmt.Errorf("withdrawing %s from balance %s: %w", amount, a.Balance, ErrAccountInsufficientFunds)
+	}
+
+	a.Balance = balance
 	a.UpdatedAt = now
 
-	origin := EventOrigin("account")
+	a.addEvent(&AccountFundsWithdrawnEvent{
+		BaseEvent: a.newBaseEvent(AccountFundsWithdrawnEventType, now),
+		Amount:    amount,
+		Balance:   a.Balance,
+	})
+
+	return nil
+}
+
+// deposit adds the amount to the balance and records a deposit event at the given time
+func (a *Account) deposit(amount money.Money, now time.Time) error {
+	balance, err := a.Balance.Add(amount)
+	if err != nil {
+		return fmt.Errorf("depositing funds: %w", err)
+	}
+
+	a.Balance = balance
+	a.UpdatedAt = now
 
 	a.addEvent(&AccountFundsDepositedEvent{
-		BaseEvent: event.BaseEvent{
-			ID:          uuid.New(),
-			ContextID:   a.ID,
-			Origin:      origin.String(),
-			Type:        AccountFundsDepositedEventType.String(),
-			TypeVersion: "0.0.0",
-			State:       event.EventStateReady.String(),
-			CreatedAt:   now,
-			ScheduledAt: now,
-			Retry:       0,
-			MaxRetry:    3,
-			Data:        nil,
-		},
-		Amount:   amount,
-		Balance:  a.Balance,
-		Currency: a.Currency,
+		BaseEvent: a.newBaseEvent(AccountFundsDepositedEventType, now),
+		Amount:    amount,
+		Balance:   a.Balance,
 	})
+
+	return nil
 }
 
-// Withdraw subtracts the specified amount from the account balance.
-// It returns an error if there are insufficient funds.
-// On success, it updates the balance and records a withdrawal event.
-func (a *Account) Withdraw(amount float64) error {
+// transition moves the account to the status the operation leads to by the account lifecycle.
+// It returns the time of the change, or an error if the operation isn't allowed in the current status.
+func (a *Account) transition(op AccountOperation) (time.Time, error) {
 	now := time.Now().UTC()
-	a.Balance -= amount
+	a.settleUnblock(now)
+
+	status, err := a.Status.Transition(op)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	a.setStatus(status)
 	a.UpdatedAt = now
 
+	return now, nil
+}
+
+// setStatus changes the account status, the change cancels the pending scheduled unblocking
+func (a *Account) setStatus(status AccountStatus) {
+	a.Status = status
+	a.unblockAt = time.Time{}
+}
+
+// settleUnblock unblocks the account when its pending scheduled unblocking is due at the given time,
+// the account was unblocked at the time the unblocking was due.
+func (a *Account) settleUnblock(at time.Time) {
+	if a.unblockAt.IsZero() || a.unblockAt.After(at) {
+		return
+	}
+
+	a.UpdatedAt = a.unblockAt
+	a.setStatus(AccountStatusActive)
+}
+
+// StatusAt returns the status of the account at the given time, the blocked account is active once its pending scheduled unblocking is due
+func (a *Account) StatusAt(at time.Time) AccountStatus {
+	if !a.unblockAt.IsZero() && !a.unblockAt.After(at) {
+		return AccountStatusActive
+	}
+
+	return a.Status
+}
+
+// newBaseEvent creates the base of an account event, due at once
+func (a *Account) newBaseEvent(eventType AccountEventType, now time.Time) event.BaseEvent {
 	origin := EventOrigin("account")
 
-	a.addEvent(&AccountFundsWithdrawnEvent{
-		BaseEvent: event.BaseEvent{
-			ID:          uuid.New(),
-			ContextID:   a.ID,
-			Origin:      origin.String(),
-			Type:        AccountFundsWithdrawnEventType.String(),
-			TypeVersion: "0.0.0",
-			State:       event.EventStateReady.String(),
-			CreatedAt:   now,
-			ScheduledAt: now,
-			Retry:       0,
-			MaxRetry:    3,
-			Data:        nil,
-		},
-		Amount:   amount,
-		Balance:  a.Balance,
-		Currency: a.Currency,
-	})
+	return event.BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   a.ID,
+		Origin:      origin.String(),
+		Type:        eventType.String(),
+		TypeVersion: eventTypeVersion,
+		State:       event.EventStateReady.String(),
+		CreatedAt:   now,
+		ScheduledAt: now,
+		Retry:       0,
+		MaxRetry:    event.DefaultMaxRetry,
+		Data:        nil,
+	}
+}
+
+// Apply changes the account state by the recorded event, the event isn't recorded again.
+// The unblocking scheduled for later is pending until the event due after it, or the command run after it, settles it,
+// any change of the account status recorded before it's due cancels it. So the state depends on the events only.
+// The account takes the aggregate version of the event, so the next command expects the account in this version.
+func (a *Account) Apply(e Event) error {
+	// An event aborted by an operator doesn't change the account, only its version
+	if e.GetState() == event.EventStateAborted.String() {
+		a.Version = e.GetAggregateVersion()
+		return nil
+	}
+
+	if _, ok := e.(*AccountCreatedEvent); !ok && a.Status == "" {
+		return fmt.Errorf("applying %s event before account creation: %w", e.GetType(), ErrAccountEventNotApplicable)
+	}
+
+	a.settleUnblock(e.GetCreatedAt())
+
+	switch ev := e.(type) {
+	case *AccountCreatedEvent:
+		a.ID = ev.ContextID
+		a.CustomerID = ev.CustomerID
+		a.AccountNumber = ev.AccountNumber
+		a.Balance = ev.InitialBalance
+		a.Status = AccountStatusPending
+		a.CreatedAt = ev.CreatedAt
+	case *AccountFundsDepositedEvent:
+		a.Balance = ev.Balance
+	case *AccountFundsWithdrawnEvent:
+		a.Balance = ev.Balance
+	case *AccountActivatedEvent:
+		a.setStatus(AccountStatusActive)
+	case *AccountBlockedEvent:
+		a.setStatus(AccountStatusBlocked)
+	case *AccountUnblockedEvent:
+		if a.Status != AccountStatusBlocked {
+			a.Version = ev.AggregateVersion
+			return nil
+		}
+
+		if ev.ScheduledAt.After(ev.CreatedAt) {
+			a.unblockAt = ev.ScheduledAt
+			a.Version = ev.AggregateVersion
+			return nil
+		}
+
+		a.setStatus(AccountStatusActive)
+	case *AccountFrozenEvent:
+		a.setStatus(AccountStatusFrozen)
+	case *AccountUnfrozenEvent:
+		a.setStatus(AccountStatusActive)
+	case *AccountClosedEvent:
+		a.setStatus(AccountStatusClosed)
+	default:
+		return fmt.Errorf("applying %s event: %w", e.GetType(), ErrAccountEventNotApplicable)
+	}
+
+	a.UpdatedAt = e.GetCreatedAt()
+	a.Version = e.GetAggregateVersion()
 
 	return nil
 }
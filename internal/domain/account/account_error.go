@@ -12,10 +12,30 @@ var (
 	ErrAccountNotFound = errors.New("account not found")
 	// ErrAccountAlreadyExists is returned when an account already exists
 	ErrAccountAlreadyExists = errors.New("account already exists")
+	// ErrAccountNotBlocked is returned when an unblock is scheduled for an account which isn't blocked
+	ErrAccountNotBlocked = errors.New("account not blocked")
+	// ErrAccountInvalidTransition is returned when the operation isn't allowed in the account status, i.e. closing a closed account
+	ErrAccountInvalidTransition = errors.New("account status transition not allowed")
+	// ErrAccountDepositNotAllowed is returned when funds are deposited into an account which doesn't accept deposits, i.e. a blocked account
+	ErrAccountDepositNotAllowed = errors.New("account does not accept deposits")
+	// ErrAccountWithdrawalNotAllowed is returned when funds are withdrawn from an account which isn't active, i.e. a frozen account
+	ErrAccountWithdrawalNotAllowed = errors.New("account does not allow withdrawals")
+	// ErrAccountBalanceNotZero is returned when an account holding funds is closed
+	ErrAccountBalanceNotZero = errors.New("account balance not zero")
+	// ErrAccountUnblockNotInFuture is returned when an unblock is scheduled at a time which isn't in the future
+	ErrAccountUnblockNotInFuture = errors.New("account unblock time not in the future")
 )
 
 // Account Event errors
 var (
 	// ErrAccountEventNotFound is returned when an account event is not found
 	ErrAccountEventNotFound = errors.New("account event not found")
+	// ErrAccountEventNotApplicable is returned when an event can't be applied to the account, i.e. an event of another aggregate
+	ErrAccountEventNotApplicable = errors.New("account event not applicable")
+)
+
+// Account Snapshot errors
+var (
+	// ErrAccountSnapshotNotFound is returned when an account has no snapshot, the account is rebuilt from all its events
+	ErrAccountSnapshotNotFound = errors.New("account snapshot not found")
 )
@@ -4,37 +4,79 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
 )
 
 // AccountCreatedEvent is emitted when a new account is created
 type AccountCreatedEvent struct {
-	event.BaseEvent
-	CustomerID     uuid.UUID `json:"customer_id"`
-	InitialBalance float64   `json:"initial_balance"` // The initial balance of the account
+	event.BaseEvent `json:"-"`
+	CustomerID      uuid.UUID   `json:"customer_id"`
+	AccountNumber   string      `json:"account_number"`  // The account number assigned to the account
+	InitialBalance  money.Money `json:"initial_balance"` // The initial balance of the account in the account currency
 }
 
 // AccountFundsDepositedEvent is emitted when funds are deposited into an account
 type AccountFundsDepositedEvent struct {
-	event.BaseEvent
-	Amount   float64 `json:"amount"`   // The amount that was deposited
-	Balance  float64 `json:"balance" ` // The new balance after the deposit
-	Currency string  `json:"currency"` // The currency of the account
+	event.BaseEvent `json:"-"`
+	Amount          money.Money `json:"amount"`  // The amount that was deposited
+	Balance         money.Money `json:"balance"` // The new balance after the deposit
 }
 
 // AccountFundsWithdrawnEvent is emitted when funds are withdrawn from an account
 type AccountFundsWithdrawnEvent struct {
-	event.BaseEvent
-	Amount   float64 `json:"amount"`   // The amount that was withdrawn
-	Balance  float64 `json:"balance"`  // The new balance after the withdrawal
-	Currency string  `json:"currency"` // The currency of the account
+	event.BaseEvent `json:"-"`
+	Amount          money.Money `json:"amount"`  // The amount that was withdrawn
+	Balance         money.Money `json:"balance"` // The new balance after the withdrawal
+}
+
+// AccountActivatedEvent is emitted when a pending account is activated
+type AccountActivatedEvent struct {
+	event.BaseEvent `json:"-"`
 }
 
 // AccountBlockedEvent is emitted when an account is blocked
 type AccountBlockedEvent struct {
-	event.BaseEvent
+	event.BaseEvent `json:"-"`
 }
 
 // AccountUnblockedEvent is emitted when an account is unblocked
 type AccountUnblockedEvent struct {
-	event.BaseEvent
+	event.BaseEvent `json:"-"`
+}
+
+// AccountFrozenEvent is emitted when an account is frozen
+type AccountFrozenEvent struct {
+	event.BaseEvent `json:"-"`
+}
+
+// AccountUnfrozenEvent is emitted when an account is unfrozen
+type AccountUnfrozenEvent struct {
+	event.BaseEvent `json:"-"`
+}
+
+// AccountClosedEvent is emitted when an account is closed
+type AccountClosedEvent struct {
+	event.BaseEvent `json:"-"`
+}
+
+// RegisterEvents registers the account events, so they can be decoded from the events table
+func RegisterEvents(r *event.Registry) {
+	r.Register(AccountCreatedEventType.String(), eventTypeVersion, func() event.Event { return &AccountCreatedEvent{} })
+	r.Register(AccountFundsDepositedEventType.String(), eventTypeVersion, func() event.Event { return &AccountFundsDepositedEvent{} })
+	r.Register(AccountFundsWithdrawnEventType.String(), eventTypeVersion, func() event.Event { return &AccountFundsWithdrawnEvent{} })
+	r.Register(AccountBlockedEventType.String(), eventTypeVersion, func() event.Event { return &AccountBlockedEvent{} })
+	r.Register(AccountUnblockedEventType.String(), eventTypeVersion, func() event.Event { return &AccountUnblockedEvent{} })
+	r.Register(AccountActivatedEventType.String(), eventTypeVersion, func() event.Event { return &AccountActivatedEvent{} })
+	r.Register(AccountFrozenEventType.String(), eventTypeVersion, func() event.Event { return &AccountFrozenEvent{} })
+	r.Register(AccountUnfrozenEventType.String(), eventTypeVersion, func() event.Event { return &AccountUnfrozenEvent{} })
+	r.Register(AccountClosedEventType.String(), eventTypeVersion, func() event.Event { return &AccountClosedEvent{} })
+}
+
+// RegisterRetryPolicies registers the retry policy of the account events.
+// Retrying can't bring back a missing account nor cover a withdrawal exceeding the balance, so these errors fail the event at once.
+func RegisterRetryPolicies(r *event.RetryPolicies) {
+	policy := event.DefaultRetryPolicy()
+	policy.Terminal = append(policy.Terminal, ErrAccountNotFound, ErrAccountInsufficientFunds)
+
+	r.RegisterOrigin(EventOrigin("account").String(), policy)
 }
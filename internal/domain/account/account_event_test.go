@@ -3,13 +3,14 @@
 package account
 
 import (
-	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event/eventtest"
 )
 
 func compareCustomerBaseEvents(t *testing.T, event, restoredEvent Event) {
@@ -26,6 +27,25 @@ func compareCustomerBaseEvents(t *testing.T, event, restoredEvent Event) {
 	require.Equal(t, event.GetMaxRetry(), restoredEvent.GetMaxRetry())
 }
 
+// encodeAccountEvent encodes the event payload the way it's stored in the events table
+func encodeAccountEvent(t *testing.T, e Event) []byte {
+	data, err := event.Encode(e)
+	require.NoError(t, err)
+
+	return data
+}
+
+// decodeAccountEvent restores the domain event from the event read from the events table
+func decodeAccountEvent(t *testing.T, e Event) Event {
+	registry := event.NewRegistry()
+	RegisterEvents(registry)
+
+	restored, err := registry.Decode(e)
+	require.NoError(t, err)
+
+	return restored
+}
+
 func Test_AccountCreatedEvent(t *testing.T) {
 	eventID := uuid.New()
 	accountID := uuid.New()
@@ -48,20 +68,18 @@ func Test_AccountCreatedEvent(t *testing.T) {
 			Data:        nil,
 		},
 		CustomerID:     customerID,
-		InitialBalance: 10,
+		AccountNumber:  "1234567890",
+		InitialBalance: testMoney(t, 1000),
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeAccountEvent(t, event)
 
-	restoredEvent := &AccountCreatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, event).(*AccountCreatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
 	require.Equal(t, event.InitialBalance, restoredEvent.InitialBalance)
+	require.Equal(t, event.AccountNumber, restoredEvent.AccountNumber)
 	require.Equal(t, event.CustomerID, restoredEvent.CustomerID)
 }
 
@@ -87,13 +105,9 @@ func Test_AccountBlockedEvent(t *testing.T) {
 		},
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeAccountEvent(t, event)
 
-	restoredEvent := &AccountBlockedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, event).(*AccountBlockedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 }
@@ -120,13 +134,9 @@ func Test_AccountUnblockedEvent(t *testing.T) {
 		},
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeAccountEvent(t, event)
 
-	restoredEvent := &AccountUnblockedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, event).(*AccountUnblockedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 }
@@ -151,24 +161,18 @@ func Test_FundsWithdrawnEvent(t *testing.T) {
 			MaxRetry:    3,
 			Data:        nil,
 		},
-		Amount:   10,
-		Balance:  10,
-		Currency: "USD",
+		Amount:  testMoney(t, 1000),
+		Balance: testMoney(t, 1000),
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeAccountEvent(t, event)
 
-	restoredEvent := &AccountFundsWithdrawnEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, event).(*AccountFundsWithdrawnEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
 	require.Equal(t, event.Amount, restoredEvent.Amount)
 	require.Equal(t, event.Balance, restoredEvent.Balance)
-	require.Equal(t, event.Currency, restoredEvent.Currency)
 }
 
 func Test_FundsDepositedEvent(t *testing.T) {
@@ -191,22 +195,34 @@ func Test_FundsDepositedEvent(t *testing.T) {
 			MaxRetry:    3,
 			Data:        nil,
 		},
-		Amount:   10,
-		Balance:  10,
-		Currency: "USD",
+		Amount:  testMoney(t, 1000),
+		Balance: testMoney(t, 1000),
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeAccountEvent(t, event)
 
-	restoredEvent := &AccountFundsDepositedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, event).(*AccountFundsDepositedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
 	require.Equal(t, event.Amount, restoredEvent.Amount)
 	require.Equal(t, event.Balance, restoredEvent.Balance)
-	require.Equal(t, event.Currency, restoredEvent.Currency)
+}
+
+// Test_AccountEvents_Golden decodes the golden payloads of every account event in every type version it was recorded in
+func Test_AccountEvents_Golden(t *testing.T) {
+	registry := event.NewRegistry()
+	RegisterEvents(registry)
+
+	eventtest.RequireGoldenPayloads(t, registry, "account", map[string]string{
+		AccountCreatedEventType.String():        eventTypeVersion,
+		AccountActivatedEventType.String():      eventTypeVersion,
+		AccountBlockedEventType.String():        eventTypeVersion,
+		AccountUnblockedEventType.String():      eventTypeVersion,
+		AccountFrozenEventType.String():         eventTypeVersion,
+		AccountUnfrozenEventType.String():       eventTypeVersion,
+		AccountClosedEventType.String():         eventTypeVersion,
+		AccountFundsDepositedEventType.String(): eventTypeVersion,
+		AccountFundsWithdrawnEventType.String(): eventTypeVersion,
+	})
 }
@@ -15,6 +15,25 @@ const (
 	AccountBlockedEventType   AccountEventType = "account.blocked"
 	AccountUnblockedEventType AccountEventType = "account.unblocked"
 
+	AccountFrozenEventType   AccountEventType = "account.frozen"
+	AccountUnfrozenEventType AccountEventType = "account.unfrozen"
+
+	AccountClosedEventType AccountEventType = "account.closed"
+
 	AccountFundsDepositedEventType AccountEventType = "account.funds.deposited"
 	AccountFundsWithdrawnEventType AccountEventType = "account.funds.withdrawn"
 )
+
+// AccountStatusEventTypes are the types of the events changing the account status,
+// any of them recorded before the unblocking scheduled for later is due cancels it
+var AccountStatusEventTypes = []AccountEventType{
+	AccountActivatedEventType,
+	AccountBlockedEventType,
+	AccountUnblockedEventType,
+	AccountFrozenEventType,
+	AccountUnfrozenEventType,
+	AccountClosedEventType,
+}
+
+// eventTypeVersion is the version of the account events payload schema
+const eventTypeVersion = "0.0.0"
@@ -0,0 +1,75 @@
+package account
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+)
+
+// AccountAggregateType is the type of the account aggregate the snapshots are stored by
+const AccountAggregateType = "account"
+
+// AccountSnapshotVersion is the version of the account snapshot format.
+// It has to be changed together with AccountSnapshot, so the snapshots in the previous format are ignored.
+const AccountSnapshotVersion = "2"
+
+// AccountSnapshot represents the state of the account at its version.
+// The account is restored from its latest snapshot and the events recorded after it, instead of all its events.
+type AccountSnapshot struct {
+	ID            uuid.UUID     `json:"id"`
+	CustomerID    uuid.UUID     `json:"customerId"`
+	AccountNumber string        `json:"accountNumber"`
+	Balance       money.Money   `json:"balance"`
+	Status        AccountStatus `json:"status"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+	Version       int           `json:"version"`
+	UnblockAt     time.Time     `json:"unblockAt"` // When the pending scheduled unblocking takes effect, zero when none is pending
+}
+
+// DefaultSnapshotPolicy returns the policy snapshotting the accounts, i.e. a checking account with many deposits and withdrawals
+func DefaultSnapshotPolicy() event.SnapshotPolicy {
+	return event.SnapshotPolicy{Frequency: 100}
+}
+
+// Snapshot returns the snapshot of the account state at its version, together with the pending scheduled unblocking.
+func (a *Account) Snapshot() AccountSnapshot {
+	return AccountSnapshot{
+		ID:            a.ID,
+		CustomerID:    a.CustomerID,
+		AccountNumber: a.AccountNumber,
+		Balance:       a.Balance,
+		Status:        a.Status,
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
+		Version:       a.Version,
+		UnblockAt:     a.unblockAt,
+	}
+}
+
+// NewAccountFromSnapshot restores the account from its snapshot and applies the events recorded after the snapshot.
+func NewAccountFromSnapshot(snapshot AccountSnapshot, events []Event) (*Account, error) {
+	account := &Account{
+		ID:            snapshot.ID,
+		CustomerID:    snapshot.CustomerID,
+		AccountNumber: snapshot.AccountNumber,
+		Balance:       snapshot.Balance,
+		Status:        snapshot.Status,
+		CreatedAt:     snapshot.CreatedAt,
+		UpdatedAt:     snapshot.UpdatedAt,
+		Version:       snapshot.Version,
+		unblockAt:     snapshot.UnblockAt,
+		events:        make([]Event, 0),
+	}
+
+	for _, e := range events {
+		if err := account.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+
+	return account, nil
+}
@@ -0,0 +1,194 @@
+//go:build unit
+
+package account
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+// testRecordedEvents returns the events as they are read from the events table, with their aggregate versions
+func testRecordedEvents(t *testing.T, events []Event) []Event {
+	registry := event.NewRegistry()
+	RegisterEvents(registry)
+
+	recorded := make([]Event, len(events))
+	for i, e := range events {
+		data, err := event.Encode(e)
+		require.NoError(t, err)
+
+		decoded, err := registry.Decode(&event.BaseEvent{
+			ID:               e.GetID(),
+			ContextID:        e.GetContextID(),
+			Origin:           e.GetOrigin(),
+			Type:             e.GetType(),
+			TypeVersion:      e.GetTypeVersion(),
+			State:            e.GetState(),
+			CreatedAt:        e.GetCreatedAt(),
+			ScheduledAt:      e.GetScheduledAt(),
+			MaxRetry:         e.GetMaxRetry(),
+			AggregateVersion: i + 1,
+			Data:             data,
+		})
+		require.NoError(t, err)
+
+		recorded[i] = decoded.(Event)
+	}
+
+	return recorded
+}
+
+// testSnapshotRoundTrip stores and loads the snapshot the way the snapshot repository does
+func testSnapshotRoundTrip(t *testing.T, snapshot AccountSnapshot) AccountSnapshot {
+	data, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+
+	var loaded AccountSnapshot
+	require.NoError(t, json.Unmarshal(data, &loaded))
+
+	return loaded
+}
+
+func Test_NewAccountFromSnapshot(t *testing.T) {
+	type testCaseParams struct {
+		events func(t *testing.T, account *Account) []Event
+	}
+
+	tests := []struct {
+		name   string
+		params testCaseParams
+	}{
+		{
+			name: "should restore account with its balance changes",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Deposit(testMoney(t, 500)))
+					require.NoError(t, account.Withdraw(testMoney(t, 300)))
+					require.NoError(t, account.Block())
+					require.NoError(t, account.Unblock())
+					require.NoError(t, account.Freeze())
+					require.NoError(t, account.Deposit(testMoney(t, 100)))
+					require.NoError(t, account.Unfreeze())
+					require.NoError(t, account.Withdraw(testMoney(t, 1300)))
+					require.NoError(t, account.Close())
+
+					return testRecordedEvents(t, account.GetEvents())
+				},
+			},
+		},
+		{
+			name: "should restore account with aborted event",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Deposit(testMoney(t, 500)))
+					require.NoError(t, account.Withdraw(testMoney(t, 300)))
+
+					events := testRecordedEvents(t, account.GetEvents())
+					events[2].(*AccountFundsDepositedEvent).State = event.EventStateAborted.String()
+
+					return events
+				},
+			},
+		},
+		{
+			name: "should restore account with pending scheduled unblock",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+
+					return testRecordedEvents(t, account.GetEvents())
+				},
+			},
+		},
+		{
+			name: "should restore account unblocked by the scheduled unblock due before the next event",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+					require.NoError(t, account.Refund(testMoney(t, 500)))
+
+					events := testRecordedEvents(t, account.GetEvents())
+					events[4].(*AccountFundsDepositedEvent).CreatedAt = time.Now().UTC().Add(2 * time.Hour)
+
+					return events
+				},
+			},
+		},
+		{
+			name: "should restore account with cancelled scheduled unblock",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+					require.NoError(t, account.Unblock())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.Refund(testMoney(t, 500)))
+
+					events := testRecordedEvents(t, account.GetEvents())
+					events[6].(*AccountFundsDepositedEvent).CreatedAt = time.Now().UTC().Add(2 * time.Hour)
+
+					return events
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), testMoney(t, 1000))
+			events := tt.params.events(t, account)
+
+			replayed, err := NewAccountFromEvents(events)
+			require.NoError(t, err)
+
+			// The account restored from the snapshot taken at any version and the events after it
+			// has to be the account replayed from all its events
+			for version := 1; version <= len(events); version++ {
+				snapshotted, err := NewAccountFromEvents(events[:version])
+				require.NoError(t, err)
+
+				snapshot := snapshotted.Snapshot()
+				require.Equal(t, version, snapshot.Version)
+
+				restored, err := NewAccountFromSnapshot(testSnapshotRoundTrip(t, snapshot), events[version:])
+				require.NoError(t, err)
+				require.Equal(t, replayed, restored, "snapshot at version %d", version)
+			}
+		})
+	}
+}
+
+func Test_Account_Snapshot_UnblockPending(t *testing.T) {
+	account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), testMoney(t, 1000))
+	require.NoError(t, account.Activate())
+	require.NoError(t, account.Block())
+	require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+
+	pending, err := NewAccountFromEvents(testRecordedEvents(t, account.GetEvents()))
+	require.NoError(t, err)
+
+	// The snapshot keeps the scheduled unblock which isn't due yet
+	snapshot := testSnapshotRoundTrip(t, pending.Snapshot())
+	require.Equal(t, AccountStatusBlocked, snapshot.Status)
+	require.Equal(t, account.GetEvents()[3].GetScheduledAt(), snapshot.UnblockAt)
+
+	// Once it's due, the account restored from the snapshot is unblocked by the next command
+	snapshot.UnblockAt = time.Now().UTC().Add(-time.Minute)
+
+	restored, err := NewAccountFromSnapshot(snapshot, nil)
+	require.NoError(t, err)
+	require.NoError(t, restored.Withdraw(testMoney(t, 100)))
+	require.Equal(t, AccountStatusActive, restored.Status)
+}
@@ -8,6 +8,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
 )
 
 func testAccountID() uuid.UUID {
@@ -22,6 +25,13 @@ func testAccountNumber() string {
 	return "0123456789"
 }
 
+func testMoney(t *testing.T, amount int64) money.Money {
+	m, err := money.New(amount, "USD")
+	require.NoError(t, err)
+
+	return m
+}
+
 func Test_NewAccount(t *testing.T) {
 	accountID := testCustomerID()
 	customerID := testAccountID()
@@ -36,8 +46,8 @@ func Test_NewAccount(t *testing.T) {
 		contextID       uuid.UUID
 		customerID      uuid.UUID
 		accountStatus   string
-		accountBalance  float64
-		accountCurrency string
+		accountBalance  int64
+		accountCurrency money.Currency
 		eventsNumber    int
 		eventType       string
 	}
@@ -48,7 +58,7 @@ func Test_NewAccount(t *testing.T) {
 		expected testCaseExpected
 	}{
 		{
-			name: "should create new account with active status",
+			name: "should create new account with pending status",
 			params: testCaseParams{
 				accountID:     accountID,
 				customerID:    customerID,
@@ -57,9 +67,9 @@ func Test_NewAccount(t *testing.T) {
 			expected: testCaseExpected{
 				contextID:       accountID,
 				customerID:      customerID,
-				accountBalance:  0.0,
+				accountBalance:  0,
 				accountCurrency: "USD",
-				accountStatus:   AccountStatusActive.String(),
+				accountStatus:   AccountStatusPending.String(),
 				eventsNumber:    1,
 				eventType:       AccountCreatedEventType.String(),
 			},
@@ -68,14 +78,14 @@ func Test_NewAccount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			account := NewAccount(tt.params.accountID, tt.params.customerID, tt.params.accountNumber, 0, "USD")
+			account := NewAccount(tt.params.accountID, tt.params.customerID, tt.params.accountNumber, testMoney(t, 0))
 
 			// Account checks
 			require.Equal(t, tt.expected.contextID, account.ID)
 			require.Equal(t, tt.expected.customerID, account.CustomerID)
 			require.Equal(t, tt.expected.accountStatus, account.Status.String())
-			require.Equal(t, tt.expected.accountBalance, account.Balance)
-			require.Equal(t, tt.expected.accountCurrency, account.Currency)
+			require.Equal(t, tt.expected.accountBalance, account.Balance.Amount())
+			require.Equal(t, tt.expected.accountCurrency, account.Balance.Currency())
 
 			// Event checks
 			require.Len(t, account.events, tt.expected.eventsNumber)
@@ -87,13 +97,38 @@ func Test_NewAccount(t *testing.T) {
 	}
 }
 
-func Test_Account_Block(t *testing.T) {
+// testAccountInStatus returns the account moved through the lifecycle to the status, with no events recorded yet
+func testAccountInStatus(t *testing.T, status AccountStatus, balance int64) *Account {
+	account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), testMoney(t, balance))
+	if status != AccountStatusPending {
+		require.NoError(t, account.Activate())
+	}
+
+	switch status {
+	case AccountStatusBlocked:
+		require.NoError(t, account.Block())
+	case AccountStatusFrozen:
+		require.NoError(t, account.Freeze())
+	case AccountStatusClosed:
+		account.Balance = testMoney(t, 0)
+		require.NoError(t, account.Close())
+	}
+
+	account.ClearEvents()
 
-	type testCaseParams struct{}
+	return account
+}
+
+func Test_Account_Lifecycle(t *testing.T) {
+	type testCaseParams struct {
+		status    AccountStatus
+		operation func(a *Account) error
+	}
 
 	type testCaseExpected struct {
-		eventsNumber int
-		eventType    string
+		status    AccountStatus
+		eventType string
+		err       error
 	}
 
 	tests := []struct {
@@ -102,68 +137,180 @@ func Test_Account_Block(t *testing.T) {
 		expected testCaseExpected
 	}{
 		{
-			name:   "should create a new event when blocking an account",
-			params: testCaseParams{},
-			expected: testCaseExpected{
-				eventsNumber: 2,
-				eventType:    AccountBlockedEventType.String(),
-			},
+			name:     "should activate pending account",
+			params:   testCaseParams{status: AccountStatusPending, operation: (*Account).Activate},
+			expected: testCaseExpected{status: AccountStatusActive, eventType: AccountActivatedEventType.String()},
+		},
+		{
+			name:     "should block active account",
+			params:   testCaseParams{status: AccountStatusActive, operation: (*Account).Block},
+			expected: testCaseExpected{status: AccountStatusBlocked, eventType: AccountBlockedEventType.String()},
+		},
+		{
+			name:     "should unblock blocked account",
+			params:   testCaseParams{status: AccountStatusBlocked, operation: (*Account).Unblock},
+			expected: testCaseExpected{status: AccountStatusActive, eventType: AccountUnblockedEventType.String()},
+		},
+		{
+			name:     "should freeze active account",
+			params:   testCaseParams{status: AccountStatusActive, operation: (*Account).Freeze},
+			expected: testCaseExpected{status: AccountStatusFrozen, eventType: AccountFrozenEventType.String()},
+		},
+		{
+			name:     "should unfreeze frozen account",
+			params:   testCaseParams{status: AccountStatusFrozen, operation: (*Account).Unfreeze},
+			expected: testCaseExpected{status: AccountStatusActive, eventType: AccountUnfrozenEventType.String()},
+		},
+		{
+			name:     "should close blocked account",
+			params:   testCaseParams{status: AccountStatusBlocked, operation: (*Account).Close},
+			expected: testCaseExpected{status: AccountStatusClosed, eventType: AccountClosedEventType.String()},
+		},
+		{
+			name:     "shouldn't block blocked account",
+			params:   testCaseParams{status: AccountStatusBlocked, operation: (*Account).Block},
+			expected: testCaseExpected{status: AccountStatusBlocked, err: ErrAccountInvalidTransition},
+		},
+		{
+			name:     "shouldn't unblock active account",
+			params:   testCaseParams{status: AccountStatusActive, operation: (*Account).Unblock},
+			expected: testCaseExpected{status: AccountStatusActive, err: ErrAccountInvalidTransition},
+		},
+		{
+			name:     "shouldn't block pending account",
+			params:   testCaseParams{status: AccountStatusPending, operation: (*Account).Block},
+			expected: testCaseExpected{status: AccountStatusPending, err: ErrAccountInvalidTransition},
+		},
+		{
+			name:     "shouldn't freeze blocked account",
+			params:   testCaseParams{status: AccountStatusBlocked, operation: (*Account).Freeze},
+			expected: testCaseExpected{status: AccountStatusBlocked, err: ErrAccountInvalidTransition},
+		},
+		{
+			name:     "shouldn't activate closed account",
+			params:   testCaseParams{status: AccountStatusClosed, operation: (*Account).Activate},
+			expected: testCaseExpected{status: AccountStatusClosed, err: ErrAccountInvalidTransition},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), 0, "USD")
+			account := testAccountInStatus(t, tt.params.status, 0)
 
-			account.Block()
-			require.Len(t, account.events, tt.expected.eventsNumber)
-			require.Equal(t, tt.expected.eventType, account.events[1].GetType())
+			err := tt.params.operation(account)
+			require.Equal(t, tt.expected.status, account.Status)
+
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Empty(t, account.events)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, account.events, 1)
+			require.Equal(t, tt.expected.eventType, account.events[0].GetType())
+			require.Equal(t, account.ID, account.events[0].GetContextID())
 		})
 	}
 }
 
-func Test_Account_Unblock(t *testing.T) {
+func Test_Account_Close_BalanceNotZero(t *testing.T) {
+	account := testAccountInStatus(t, AccountStatusActive, 100)
+
+	require.ErrorIs(t, account.Close(), ErrAccountBalanceNotZero)
+	require.Equal(t, AccountStatusActive, account.Status)
+	require.Empty(t, account.events)
+}
+
+func Test_Account_ScheduleUnblock(t *testing.T) {
 
-	type testCaseParams struct{}
+	type testCaseParams struct {
+		block bool
+		at    time.Time
+	}
 
 	type testCaseExpected struct {
 		eventsNumber int
-		eventType    string
+		err          error
 	}
 
+	unblockAt := time.Now().UTC().Add(24 * time.Hour)
+
 	tests := []struct {
 		name     string
 		params   testCaseParams
 		expected testCaseExpected
 	}{
 		{
-			name:   "should create a new event when unblocking an account",
-			params: testCaseParams{},
+			name: "should schedule unblocking of a blocked account",
+			params: testCaseParams{
+				block: true,
+				at:    unblockAt,
+			},
+			expected: testCaseExpected{
+				eventsNumber: 1,
+			},
+		},
+		{
+			name: "shouldn't schedule unblocking - account not blocked",
+			params: testCaseParams{
+				at: unblockAt,
+			},
+			expected: testCaseExpected{
+				eventsNumber: 0,
+				err:          ErrAccountNotBlocked,
+			},
+		},
+		{
+			name: "shouldn't schedule unblocking - time in the past",
+			params: testCaseParams{
+				block: true,
+				at:    time.Now().UTC().Add(-time.Minute),
+			},
 			expected: testCaseExpected{
-				eventsNumber: 2,
-				eventType:    AccountUnblockedEventType.String(),
+				eventsNumber: 0,
+				err:          ErrAccountUnblockNotInFuture,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), 0, "USD")
+			status := AccountStatusActive
+			if tt.params.block {
+				status = AccountStatusBlocked
+			}
+
+			account := testAccountInStatus(t, status, 0)
 
-			account.Unblock()
+			err := account.ScheduleUnblock(tt.params.at)
 			require.Len(t, account.events, tt.expected.eventsNumber)
-			require.Equal(t, tt.expected.eventType, account.events[1].GetType())
+
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, AccountStatusBlocked, account.Status)
+
+			unblockedEvent := account.events[0]
+			require.Equal(t, AccountUnblockedEventType.String(), unblockedEvent.GetType())
+			require.Equal(t, tt.params.at, unblockedEvent.GetScheduledAt())
+			require.True(t, unblockedEvent.GetScheduledAt().After(unblockedEvent.GetCreatedAt()))
 		})
 	}
 }
 
 func Test_Account_Deposit(t *testing.T) {
 
-	type testCaseParams struct{}
+	type testCaseParams struct {
+		status AccountStatus
+	}
 
 	type testCaseExpected struct {
-		eventsNumber int
-		eventType    string
+		balance int64
+		err     error
 	}
 
 	tests := []struct {
@@ -172,33 +319,62 @@ func Test_Account_Deposit(t *testing.T) {
 		expected testCaseExpected
 	}{
 		{
-			name:   "should create a new event when depositing funds",
-			params: testCaseParams{},
-			expected: testCaseExpected{
-				eventsNumber: 2,
-				eventType:    AccountFundsDepositedEventType.String(),
-			},
+			name:     "should deposit funds into active account",
+			params:   testCaseParams{status: AccountStatusActive},
+			expected: testCaseExpected{balance: 40400},
+		},
+		{
+			name:     "should deposit funds into frozen account",
+			params:   testCaseParams{status: AccountStatusFrozen},
+			expected: testCaseExpected{balance: 40400},
+		},
+		{
+			name:     "shouldn't deposit funds into pending account",
+			params:   testCaseParams{status: AccountStatusPending},
+			expected: testCaseExpected{err: ErrAccountDepositNotAllowed},
+		},
+		{
+			name:     "shouldn't deposit funds into blocked account",
+			params:   testCaseParams{status: AccountStatusBlocked},
+			expected: testCaseExpected{err: ErrAccountDepositNotAllowed},
+		},
+		{
+			name:     "shouldn't deposit funds into closed account",
+			params:   testCaseParams{status: AccountStatusClosed},
+			expected: testCaseExpected{err: ErrAccountDepositNotAllowed},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), 0, "USD")
+			account := testAccountInStatus(t, tt.params.status, 0)
 
-			account.Deposit(404)
-			require.Len(t, account.events, tt.expected.eventsNumber)
-			require.Equal(t, tt.expected.eventType, account.events[1].GetType())
+			err := account.Deposit(testMoney(t, 40400))
+			require.Equal(t, tt.expected.balance, account.Balance.Amount())
+
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Empty(t, account.events)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, account.events, 1)
+			require.Equal(t, AccountFundsDepositedEventType.String(), account.events[0].GetType())
 		})
 	}
 }
 
 func Test_Account_Withdraw(t *testing.T) {
 
-	type testCaseParams struct{}
+	type testCaseParams struct {
+		status AccountStatus
+		amount int64
+	}
 
 	type testCaseExpected struct {
-		eventsNumber int
-		eventType    string
+		balance int64
+		err     error
 	}
 
 	tests := []struct {
@@ -207,22 +383,367 @@ func Test_Account_Withdraw(t *testing.T) {
 		expected testCaseExpected
 	}{
 		{
-			name:   "should create a new event when withdrawing funds",
-			params: testCaseParams{},
+			name:     "should withdraw funds from active account",
+			params:   testCaseParams{status: AccountStatusActive, amount: 40400},
+			expected: testCaseExpected{balance: 59600},
+		},
+		{
+			name:     "should withdraw the whole balance",
+			params:   testCaseParams{status: AccountStatusActive, amount: 100000},
+			expected: testCaseExpected{balance: 0},
+		},
+		{
+			name:     "shouldn't withdraw funds - insufficient funds",
+			params:   testCaseParams{status: AccountStatusActive, amount: 100001},
+			expected: testCaseExpected{balance: 100000, err: ErrAccountInsufficientFunds},
+		},
+		{
+			name:     "shouldn't withdraw funds from blocked account",
+			params:   testCaseParams{status: AccountStatusBlocked, amount: 40400},
+			expected: testCaseExpected{balance: 100000, err: ErrAccountWithdrawalNotAllowed},
+		},
+		{
+			name:     "shouldn't withdraw funds from frozen account",
+			params:   testCaseParams{status: AccountStatusFrozen, amount: 40400},
+			expected: testCaseExpected{balance: 100000, err: ErrAccountWithdrawalNotAllowed},
+		},
+		{
+			name:     "shouldn't withdraw funds from pending account",
+			params:   testCaseParams{status: AccountStatusPending, amount: 40400},
+			expected: testCaseExpected{balance: 100000, err: ErrAccountWithdrawalNotAllowed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := testAccountInStatus(t, tt.params.status, 100000)
+
+			err := account.Withdraw(testMoney(t, tt.params.amount))
+			require.Equal(t, tt.expected.balance, account.Balance.Amount())
+
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Empty(t, account.events)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, account.events, 1)
+			require.Equal(t, AccountFundsWithdrawnEventType.String(), account.events[0].GetType())
+		})
+	}
+}
+
+func Test_Account_Refund(t *testing.T) {
+	account := testAccountInStatus(t, AccountStatusBlocked, 0)
+
+	require.NoError(t, account.Refund(testMoney(t, 40400)))
+	require.Equal(t, int64(40400), account.Balance.Amount())
+	require.Len(t, account.events, 1)
+	require.Equal(t, AccountFundsDepositedEventType.String(), account.events[0].GetType())
+}
+
+func Test_Account_CurrencyMismatch(t *testing.T) {
+	account := testAccountInStatus(t, AccountStatusActive, 100000)
+
+	amount, err := money.New(40400, "EUR")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, account.Deposit(amount), money.ErrCurrencyMismatch)
+	require.ErrorIs(t, account.Withdraw(amount), money.ErrCurrencyMismatch)
+	require.Equal(t, int64(100000), account.Balance.Amount())
+	require.Empty(t, account.events)
+}
+
+func Test_NewAccountFromEvents(t *testing.T) {
+	type testCaseParams struct {
+		events func(t *testing.T, account *Account) []Event
+	}
+
+	type testCaseExpected struct {
+		status  AccountStatus
+		balance int64
+		version int
+		err     error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should rebuild created account",
+			params: testCaseParams{
+				events: func(_ *testing.T, account *Account) []Event {
+					return account.GetEvents()
+				},
+			},
+			expected: testCaseExpected{
+				status:  AccountStatusPending,
+				balance: 1000,
+			},
+		},
+		{
+			name: "should rebuild account with its balance changes",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Deposit(testMoney(t, 500)))
+					require.NoError(t, account.Withdraw(testMoney(t, 300)))
+					require.NoError(t, account.Freeze())
+
+					return account.GetEvents()
+				},
+			},
+			expected: testCaseExpected{
+				status:  AccountStatusFrozen,
+				balance: 1200,
+			},
+		},
+		{
+			name: "should keep account blocked until the scheduled unblock is due",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+
+					return account.GetEvents()
+				},
+			},
+			expected: testCaseExpected{
+				status:  AccountStatusBlocked,
+				balance: 1000,
+			},
+		},
+		{
+			name: "should unblock account when the scheduled unblock is due before the next event",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+					require.NoError(t, account.Refund(testMoney(t, 500)))
+
+					events := account.GetEvents()
+					events[len(events)-1].(*AccountFundsDepositedEvent).CreatedAt = time.Now().UTC().Add(2 * time.Hour)
+
+					return events
+				},
+			},
+			expected: testCaseExpected{
+				status:  AccountStatusActive,
+				balance: 1500,
+			},
+		},
+		{
+			name: "should keep account blocked when the scheduled unblock was cancelled",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+					require.NoError(t, account.Unblock())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.Refund(testMoney(t, 500)))
+
+					events := account.GetEvents()
+					events[len(events)-1].(*AccountFundsDepositedEvent).CreatedAt = time.Now().UTC().Add(2 * time.Hour)
+
+					return events
+				},
+			},
+			expected: testCaseExpected{
+				status:  AccountStatusBlocked,
+				balance: 1500,
+			},
+		},
+		{
+			name: "should skip aborted event but take its version",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Deposit(testMoney(t, 500)))
+
+					events := account.GetEvents()
+					events[0].(*AccountCreatedEvent).AggregateVersion = 1
+					events[1].(*AccountActivatedEvent).AggregateVersion = 2
+					events[2].(*AccountFundsDepositedEvent).AggregateVersion = 3
+					events[2].(*AccountFundsDepositedEvent).State = event.EventStateAborted.String()
+
+					return events
+				},
+			},
+			expected: testCaseExpected{
+				status:  AccountStatusActive,
+				balance: 1000,
+				version: 3,
+			},
+		},
+		{
+			name: "shouldn't rebuild account - creation aborted",
+			params: testCaseParams{
+				events: func(_ *testing.T, account *Account) []Event {
+					events := account.GetEvents()
+					events[0].(*AccountCreatedEvent).State = event.EventStateAborted.String()
+
+					return events
+				},
+			},
 			expected: testCaseExpected{
-				eventsNumber: 2,
-				eventType:    AccountFundsWithdrawnEventType.String(),
+				err: ErrAccountNotFound,
+			},
+		},
+		{
+			name: "shouldn't rebuild account - no events",
+			params: testCaseParams{
+				events: func(_ *testing.T, _ *Account) []Event {
+					return nil
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrAccountNotFound,
+			},
+		},
+		{
+			name: "shouldn't rebuild account - first event isn't the creation",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+
+					return account.GetEvents()[1:]
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrAccountEventNotApplicable,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), 1000, "USD")
+			account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), testMoney(t, 1000))
+
+			rebuilt, err := NewAccountFromEvents(tt.params.events(t, account))
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, account.ID, rebuilt.ID)
+			require.Equal(t, account.CustomerID, rebuilt.CustomerID)
+			require.Equal(t, account.AccountNumber, rebuilt.AccountNumber)
+			require.Equal(t, tt.expected.status, rebuilt.Status)
+			require.Equal(t, tt.expected.balance, rebuilt.Balance.Amount())
+			require.Equal(t, tt.expected.version, rebuilt.Version)
+			require.Empty(t, rebuilt.GetEvents())
+		})
+	}
+}
 
-			account.Withdraw(404)
-			require.Len(t, account.events, tt.expected.eventsNumber)
-			require.Equal(t, tt.expected.eventType, account.events[1].GetType())
+func Test_Account_SettleScheduledUnblock(t *testing.T) {
+	type testCaseParams struct {
+		events func(t *testing.T, account *Account) []Event
+	}
+
+	type testCaseExpected struct {
+		status AccountStatus
+		err    error
+	}
+
+	// The unblock was scheduled three hours ago and was due an hour ago
+	scheduledAt := time.Now().UTC().Add(-3 * time.Hour)
+	dueAt := scheduledAt.Add(2 * time.Hour)
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should withdraw from account unblocked by the due scheduled unblock",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+
+					events := account.GetEvents()
+					events[3].(*AccountUnblockedEvent).CreatedAt = scheduledAt
+					events[3].(*AccountUnblockedEvent).ScheduledAt = dueAt
+
+					return events
+				},
+			},
+			expected: testCaseExpected{
+				status: AccountStatusActive,
+			},
+		},
+		{
+			name: "shouldn't withdraw from account - scheduled unblock not due yet",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+
+					return account.GetEvents()
+				},
+			},
+			expected: testCaseExpected{
+				status: AccountStatusBlocked,
+				err:    ErrAccountWithdrawalNotAllowed,
+			},
+		},
+		{
+			name: "shouldn't withdraw from account - scheduled unblock cancelled by unblocking and blocking again",
+			params: testCaseParams{
+				events: func(t *testing.T, account *Account) []Event {
+					require.NoError(t, account.Activate())
+					require.NoError(t, account.Block())
+					require.NoError(t, account.ScheduleUnblock(time.Now().Add(time.Hour)))
+					require.NoError(t, account.Unblock())
+					require.NoError(t, account.Block())
+
+					events := account.GetEvents()
+					events[3].(*AccountUnblockedEvent).CreatedAt = scheduledAt
+					events[3].(*AccountUnblockedEvent).ScheduledAt = dueAt
+					events[4].(*AccountUnblockedEvent).CreatedAt = scheduledAt.Add(time.Hour)
+					events[4].(*AccountUnblockedEvent).ScheduledAt = scheduledAt.Add(time.Hour)
+					events[5].(*AccountBlockedEvent).CreatedAt = scheduledAt.Add(90 * time.Minute)
+					events[5].(*AccountBlockedEvent).ScheduledAt = scheduledAt.Add(90 * time.Minute)
+
+					return events
+				},
+			},
+			expected: testCaseExpected{
+				status: AccountStatusBlocked,
+				err:    ErrAccountWithdrawalNotAllowed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := NewAccount(testAccountID(), testCustomerID(), testAccountNumber(), testMoney(t, 1000))
+
+			rebuilt, err := NewAccountFromEvents(tt.params.events(t, account))
+			require.NoError(t, err)
+			require.Equal(t, AccountStatusBlocked, rebuilt.Status)
+			require.Equal(t, tt.expected.status, rebuilt.StatusAt(time.Now().UTC()))
+
+			err = rebuilt.Withdraw(testMoney(t, 100))
+			require.Equal(t, tt.expected.status, rebuilt.Status)
+
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(900), rebuilt.Balance.Amount())
 		})
 	}
 }
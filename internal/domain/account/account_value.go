@@ -1,12 +1,18 @@
 package account
 
+import (
+	"fmt"
+)
+
 // AccountStatus represents the possible states of an account
 type AccountStatus string
 
 const (
-	AccountStatusInactive AccountStatus = "inactive" // Account is active and can perform transactions
-	AccountStatusActive   AccountStatus = "active"   // Account is active and can perform transactions
-	AccountStatusBlocked  AccountStatus = "blocked"  // Account is blocked and cannot perform transactions
+	AccountStatusPending AccountStatus = "pending" // Account is opened, but it waits for the activation before it can perform transactions
+	AccountStatusActive  AccountStatus = "active"  // Account is active and can perform transactions
+	AccountStatusBlocked AccountStatus = "blocked" // Account is blocked and cannot perform transactions
+	AccountStatusFrozen  AccountStatus = "frozen"  // Account is frozen, it accepts deposits, but the funds cannot be withdrawn
+	AccountStatusClosed  AccountStatus = "closed"  // Account is closed for good and cannot perform transactions
 )
 
 func (s AccountStatus) String() string {
@@ -15,5 +21,72 @@ func (s AccountStatus) String() string {
 
 // IsValid checks if the account status is valid
 func (s AccountStatus) IsValid() bool {
-	return s == AccountStatusInactive || s == AccountStatusActive || s == AccountStatusBlocked
+	switch s {
+	case AccountStatusPending, AccountStatusActive, AccountStatusBlocked, AccountStatusFrozen, AccountStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanDeposit reports whether the account in the status accepts deposits
+func (s AccountStatus) CanDeposit() bool {
+	return s == AccountStatusActive || s == AccountStatusFrozen
+}
+
+// CanWithdraw reports whether the funds can be withdrawn from the account in the status
+func (s AccountStatus) CanWithdraw() bool {
+	return s == AccountStatusActive
+}
+
+// AccountOperation represents an operation changing the account status
+type AccountOperation string
+
+const (
+	AccountOperationActivate AccountOperation = "activate"
+	AccountOperationBlock    AccountOperation = "block"
+	AccountOperationUnblock  AccountOperation = "unblock"
+	AccountOperationFreeze   AccountOperation = "freeze"
+	AccountOperationUnfreeze AccountOperation = "unfreeze"
+	AccountOperationClose    AccountOperation = "close"
+)
+
+func (o AccountOperation) String() string {
+	return string(o)
+}
+
+// accountTransitions is the account lifecycle, the status the account gets to by the operation allowed in the current status:
+//
+//	pending ──► active ──► blocked ──► active
+//	   │          │   └──► frozen  ──► active
+//	   └──────────┴──────────┴────┴──► closed
+var accountTransitions = map[AccountStatus]map[AccountOperation]AccountStatus{
+	AccountStatusPending: {
+		AccountOperationActivate: AccountStatusActive,
+		AccountOperationClose:    AccountStatusClosed,
+	},
+	AccountStatusActive: {
+		AccountOperationBlock:  AccountStatusBlocked,
+		AccountOperationFreeze: AccountStatusFrozen,
+		AccountOperationClose:  AccountStatusClosed,
+	},
+	AccountStatusBlocked: {
+		AccountOperationUnblock: AccountStatusActive,
+		AccountOperationClose:   AccountStatusClosed,
+	},
+	AccountStatusFrozen: {
+		AccountOperationUnfreeze: AccountStatusActive,
+		AccountOperationClose:    AccountStatusClosed,
+	},
+}
+
+// Transition returns the status the account gets to by the operation.
+// It returns ErrAccountInvalidTransition when the operation isn't allowed in the status, i.e. blocking a blocked account.
+func (s AccountStatus) Transition(op AccountOperation) (AccountStatus, error) {
+	next, ok := accountTransitions[s][op]
+	if !ok {
+		return s, fmt.Errorf("operation %s on %s account: %w", op, s, ErrAccountInvalidTransition)
+	}
+
+	return next, nil
 }
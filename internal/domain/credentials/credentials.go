@@ -0,0 +1,280 @@
+package credentials
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+// EventOrigin it is used to identify the source of the event
+type EventOrigin = event.EventOrigin
+
+// origin is the origin of the credentials events, the credentials events aren't the events of the customer aggregate
+const origin EventOrigin = "credentials"
+
+// LockoutPolicy defines when the credentials are locked after the failed logins
+type LockoutPolicy struct {
+	MaxFailedAttempts int           // Number of consecutive failed logins locking the credentials, the credentials aren't locked when zero
+	Duration          time.Duration // How long the credentials stay locked
+}
+
+// DefaultLockoutPolicy returns the default lockout policy, the credentials are locked for 15 minutes after 5 failed logins
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		MaxFailedAttempts: 5,
+		Duration:          15 * time.Minute,
+	}
+}
+
+// Credentials are the online banking credentials of the customer: the password, the optional TOTP second factor
+// and the failed logins counted towards the lockout. The credentials are stored as they are, their events record
+// the changes of the factors and the failed logins, without the password hash nor the TOTP secret.
+type Credentials struct {
+	ID             uuid.UUID `json:"id"`             // Unique identifier of the credentials, the context of their events
+	CustomerID     uuid.UUID `json:"customerId"`     // Customer the credentials belong to
+	PasswordHash   string    `json:"-"`              // Argon2id hash of the password in the PHC string format
+	TOTPSecret     string    `json:"-"`              // Base32 encoded TOTP secret, set by the enrollment, used once the TOTP is enabled
+	TOTPEnabled    bool      `json:"totpEnabled"`    // Whether the login requires the TOTP code, set once the enrollment is confirmed
+	TOTPLastStep   int64     `json:"-"`              // Time step of the last accepted TOTP code, the code can't be used twice
+	FailedAttempts int       `json:"failedAttempts"` // Consecutive failed logins since the last successful one or the lockout
+	LockedUntil    time.Time `json:"lockedUntil"`    // Until when the login is rejected, zero when the credentials aren't locked
+	CreatedAt      time.Time `json:"createdAt"`      // When the credentials were created
+	UpdatedAt      time.Time `json:"updatedAt"`      // When the credentials were last updated
+	Version        int       `json:"version"`        // Version of the credentials, incremented by every change stored
+	Events         []Event   // List of events associated with the credentials
+}
+
+// NewCredentials creates the credentials of the customer without any factor, the password is set next
+func NewCredentials(customerID uuid.UUID, now time.Time) *Credentials {
+	return &Credentials{
+		ID:         uuid.New(),
+		CustomerID: customerID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Events:     []Event{},
+	}
+}
+
+// SetPassword sets the password of the credentials, the password is checked against the password policy and stored hashed.
+// Setting the password unlocks the credentials, i.e. the back office resets the password of the locked out customer.
+func (c *Credentials) SetPassword(password string, now time.Time) error {
+	if err := ValidatePassword(password); err != nil {
+		return err
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("setting password: %w", err)
+	}
+
+	c.PasswordHash = hash
+	c.FailedAttempts = 0
+	c.LockedUntil = time.Time{}
+	c.UpdatedAt = now
+
+	c.addEvent(&CredentialsSetEvent{
+		BaseEvent:  c.newBaseEvent(CredentialsSetEventType, now),
+		CustomerID: c.CustomerID,
+		Factor:     FactorPassword,
+	})
+
+	return nil
+}
+
+// EnrollTOTP generates the TOTP secret the authenticator app of the customer is enrolled with, the TOTP is enabled
+// once the enrollment is confirmed with the code of the app. The enrollment started again replaces the secret.
+func (c *Credentials) EnrollTOTP(now time.Time) (string, error) {
+	if c.TOTPEnabled {
+		return "", ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return "", fmt.Errorf("enrolling totp: %w", err)
+	}
+
+	c.TOTPSecret = secret
+	c.TOTPLastStep = 0
+	c.UpdatedAt = now
+
+	return secret, nil
+}
+
+// ConfirmTOTP enables the TOTP enrolled, the code proves the authenticator app of the customer was enrolled with the secret
+func (c *Credentials) ConfirmTOTP(code string, now time.Time) error {
+	if c.TOTPEnabled {
+		return ErrTOTPAlreadyEnabled
+	}
+
+	if c.TOTPSecret == "" {
+		return ErrTOTPNotEnrolled
+	}
+
+	step, ok := MatchTOTP(c.TOTPSecret, code, c.TOTPLastStep, now)
+	if !ok {
+		return ErrWrongTOTPCode
+	}
+
+	c.TOTPEnabled = true
+	c.TOTPLastStep = step
+	c.UpdatedAt = now
+
+	c.addEvent(&CredentialsSetEvent{
+		BaseEvent:  c.newBaseEvent(CredentialsSetEventType, now),
+		CustomerID: c.CustomerID,
+		Factor:     FactorTOTP,
+	})
+
+	return nil
+}
+
+// MFARequired reports whether the login requires the TOTP code after the password
+func (c *Credentials) MFARequired() bool {
+	return c.TOTPEnabled
+}
+
+// IsLocked reports whether the login is rejected at the time after the repeated failed logins
+func (c *Credentials) IsLocked(now time.Time) bool {
+	return now.Before(c.LockedUntil)
+}
+
+// VerifyPassword verifies the password of the login. It returns ErrCredentialsLocked when the credentials are locked,
+// the password isn't checked then. The password which doesn't match is counted towards the lockout and ErrWrongPassword is returned.
+func (c *Credentials) VerifyPassword(password string, policy LockoutPolicy, now time.Time) error {
+	if c.IsLocked(now) {
+		return ErrCredentialsLocked
+	}
+
+	ok, err := CheckPassword(c.PasswordHash, password)
+	if err != nil {
+		return fmt.Errorf("verifying password: %w", err)
+	}
+
+	if !ok {
+		c.fail(FactorPassword, policy, now)
+		return ErrWrongPassword
+	}
+
+	return nil
+}
+
+// VerifyTOTP verifies the TOTP code of the login, the second step of the login once the password matched.
+// It returns ErrCredentialsLocked when the credentials are locked. The code which doesn't match is counted towards the lockout
+// and ErrWrongTOTPCode is returned.
+func (c *Credentials) VerifyTOTP(code string, policy LockoutPolicy, now time.Time) error {
+	if c.IsLocked(now) {
+		return ErrCredentialsLocked
+	}
+
+	if !c.TOTPEnabled {
+		return ErrTOTPNotEnrolled
+	}
+
+	step, ok := MatchTOTP(c.TOTPSecret, code, c.TOTPLastStep, now)
+	if !ok {
+		c.fail(FactorTOTP, policy, now)
+		return ErrWrongTOTPCode
+	}
+
+	c.TOTPLastStep = step
+	c.UpdatedAt = now
+
+	return nil
+}
+
+// Succeed records the successful login, the failed logins aren't counted towards the lockout anymore
+func (c *Credentials) Succeed(now time.Time) {
+	c.FailedAttempts = 0
+	c.UpdatedAt = now
+}
+
+// fail counts the failed login, the credentials are locked for the lockout duration once the failed logins reach
+// the maximum, the failed logins are counted from zero after the lockout
+func (c *Credentials) fail(factor Factor, policy LockoutPolicy, now time.Time) {
+	c.FailedAttempts++
+	c.UpdatedAt = now
+
+	failed := &LoginFailedEvent{
+		BaseEvent:      c.newBaseEvent(LoginFailedEventType, now),
+		CustomerID:     c.CustomerID,
+		Factor:         factor,
+		FailedAttempts: c.FailedAttempts,
+	}
+
+	if policy.MaxFailedAttempts > 0 && c.FailedAttempts >= policy.MaxFailedAttempts {
+		c.LockedUntil = now.Add(policy.Duration)
+		c.FailedAttempts = 0
+
+		lockedUntil := c.LockedUntil
+		failed.LockedUntil = &lockedUntil
+	}
+
+	c.addEvent(failed)
+}
+
+// CountFailedLogin sets the failed login counted by the store to the credentials and to their login failed event. The store counts
+// the failed login atomically with the failed logins of the concurrent logins, so the count the credentials were found with may be stale.
+// The failed logins are counted from zero once the failed login locked the credentials, as fail does. It returns ErrLoginNotFailed
+// when the credentials didn't record the failed login.
+func (c *Credentials) CountFailedLogin(failedAttempts int, lockedUntil time.Time, version int, policy LockoutPolicy) error {
+	var failed *LoginFailedEvent
+	if len(c.Events) > 0 {
+		failed, _ = c.Events[len(c.Events)-1].(*LoginFailedEvent)
+	}
+
+	if failed == nil {
+		return ErrLoginNotFailed
+	}
+
+	c.FailedAttempts = failedAttempts
+	c.LockedUntil = lockedUntil
+	c.Version = version
+
+	failed.FailedAttempts = failedAttempts
+	failed.LockedUntil = nil
+
+	// The failed login locking the credentials is the last one counted towards the lockout
+	if failedAttempts == 0 && !lockedUntil.IsZero() {
+		failed.FailedAttempts = policy.MaxFailedAttempts
+		failed.LockedUntil = &lockedUntil
+	}
+
+	return nil
+}
+
+// newBaseEvent returns the base of the credentials event. The credentials events are recorded completed,
+// the credentials are stored together with their events, so the orchestrator has nothing to process.
+func (c *Credentials) newBaseEvent(eventType CredentialsEventType, now time.Time) event.BaseEvent {
+	return event.BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   c.ID,
+		Origin:      origin.String(),
+		Type:        eventType.String(),
+		TypeVersion: eventTypeVersion,
+		State:       event.EventStateCompleted.String(),
+		CreatedAt:   now,
+		ScheduledAt: now,
+		CompletedAt: now,
+		Retry:       0,
+		MaxRetry:    0,
+	}
+}
+
+// GetEvents returns all domain events that have occurred on the credentials.
+func (c *Credentials) GetEvents() []Event {
+	return c.Events
+}
+
+// ClearEvents removes all recorded events from the credentials.
+// This is typically called after the credentials are stored.
+func (c *Credentials) ClearEvents() {
+	c.Events = make([]Event, 0)
+}
+
+// addEvent is an internal method to record a new domain event.
+func (c *Credentials) addEvent(event Event) {
+	c.Events = append(c.Events, event)
+}
@@ -0,0 +1,33 @@
+package credentials
+
+import (
+	"errors"
+)
+
+// Credentials errors
+var (
+	// ErrCredentialsNotFound is returned when the customer has no credentials, i.e. their password was never set
+	ErrCredentialsNotFound = errors.New("credentials not found")
+	// ErrWeakPassword is returned when the password doesn't meet the password policy
+	ErrWeakPassword = errors.New("password must be 12 to 128 characters long")
+	// ErrInvalidPasswordHash is returned when the stored password hash can't be parsed
+	ErrInvalidPasswordHash = errors.New("invalid password hash")
+	// ErrWrongPassword is returned when the password doesn't match the password hash
+	ErrWrongPassword = errors.New("password doesn't match")
+	// ErrWrongTOTPCode is returned when the TOTP code doesn't match the TOTP secret or it was already used
+	ErrWrongTOTPCode = errors.New("totp code doesn't match")
+	// ErrCredentialsLocked is returned when the credentials are locked after repeated failed logins
+	ErrCredentialsLocked = errors.New("credentials locked after repeated failed logins")
+	// ErrTOTPNotEnrolled is returned when the TOTP is confirmed before it's enrolled
+	ErrTOTPNotEnrolled = errors.New("totp not enrolled")
+	// ErrTOTPAlreadyEnabled is returned when the TOTP is enrolled once it's already enabled
+	ErrTOTPAlreadyEnabled = errors.New("totp already enabled")
+	// ErrLoginNotFailed is returned when the failed login is counted for the credentials which didn't record the failed login
+	ErrLoginNotFailed = errors.New("login didn't fail")
+)
+
+// Session errors
+var (
+	// ErrSessionNotFound is returned when no session is issued the token
+	ErrSessionNotFound = errors.New("session not found")
+)
@@ -0,0 +1,32 @@
+package credentials
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+// CredentialsSetEvent is emitted when the factor of the credentials is set, i.e. the password is set or the TOTP enrollment is confirmed.
+// The event doesn't carry the password hash nor the TOTP secret, they are kept by the credentials only.
+type CredentialsSetEvent struct {
+	event.BaseEvent `json:"-"`
+	CustomerID      uuid.UUID `json:"customerId"`
+	Factor          Factor    `json:"factor"`
+}
+
+// LoginFailedEvent is emitted when the password or the TOTP code of the login doesn't match
+type LoginFailedEvent struct {
+	event.BaseEvent `json:"-"`
+	CustomerID      uuid.UUID  `json:"customerId"`
+	Factor          Factor     `json:"factor"`                // Factor which didn't match
+	FailedAttempts  int        `json:"failedAttempts"`        // Consecutive failed attempts, this one included
+	LockedUntil     *time.Time `json:"lockedUntil,omitempty"` // Set when the failure locked the credentials
+}
+
+// RegisterEvents registers the credentials events, so they can be decoded from the events table
+func RegisterEvents(r *event.Registry) {
+	r.Register(CredentialsSetEventType.String(), eventTypeVersion, func() event.Event { return &CredentialsSetEvent{} })
+	r.Register(LoginFailedEventType.String(), eventTypeVersion, func() event.Event { return &LoginFailedEvent{} })
+}
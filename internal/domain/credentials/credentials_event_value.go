@@ -0,0 +1,32 @@
+package credentials
+
+// CredentialsEventType represents the type of credentials event
+type CredentialsEventType string
+
+// String returns the string representation of the credentials event type
+func (e CredentialsEventType) String() string {
+	return string(e)
+}
+
+const (
+	CredentialsSetEventType CredentialsEventType = "customer.credentials.set"
+	LoginFailedEventType    CredentialsEventType = "customer.login.failed"
+)
+
+// eventTypeVersion is the version of the credentials events payload schema
+const eventTypeVersion = "0.0.0"
+
+// Factor represents the authentication factor of the credentials
+type Factor string
+
+// String returns the string representation of the factor
+func (f Factor) String() string {
+	return string(f)
+}
+
+const (
+	// FactorPassword is the password of the customer, the first factor
+	FactorPassword Factor = "password"
+	// FactorTOTP is the time-based one-time password (RFC 6238) of the authenticator app of the customer, the second factor
+	FactorTOTP Factor = "totp"
+)
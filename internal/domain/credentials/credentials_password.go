@@ -0,0 +1,91 @@
+package credentials
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// MinPasswordLength is the minimum number of characters of the password
+	MinPasswordLength = 12
+	// MaxPasswordLength is the maximum number of characters of the password, it bounds the cost of hashing it
+	MaxPasswordLength = 128
+)
+
+// The argon2id parameters of the new password hashes (OWASP recommendation), the parameters of the stored hash are kept in the hash,
+// so the hashes made with the former parameters are still verified once the parameters are raised
+const (
+	argon2Memory  = 19 * 1024 // KiB
+	argon2Time    = 2
+	argon2Threads = 1
+	argon2KeySize = 32
+	argon2Salt    = 16
+)
+
+// ValidatePassword checks the password against the password policy, it returns ErrWeakPassword when the password is too short or too long
+func ValidatePassword(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < MinPasswordLength || n > MaxPasswordLength {
+		return ErrWeakPassword
+	}
+
+	return nil
+}
+
+// HashPassword returns the argon2id hash of the password with a random salt in the PHC string format,
+// i.e. $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2Salt)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generating password salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeySize)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// CheckPassword reports whether the password matches the argon2id hash, the hash is computed with the parameters kept in it.
+// It returns ErrInvalidPasswordHash when the hash can't be parsed.
+func CheckPassword(hash, password string) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, ErrInvalidPasswordHash
+	}
+
+	var memory, iterations uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false, ErrInvalidPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, ErrInvalidPasswordHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false, ErrInvalidPasswordHash
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, iterations, memory, threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, computed) == 1, nil
+}
@@ -0,0 +1,79 @@
+//go:build unit
+
+package credentials
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+)
+
+func Test_ValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		expected error
+	}{
+		{name: "should accept password of minimum length", password: strings.Repeat("a", MinPasswordLength)},
+		{name: "should count characters rather than bytes", password: strings.Repeat("ż", MaxPasswordLength)},
+		{name: "should reject short password", password: strings.Repeat("a", MinPasswordLength-1), expected: ErrWeakPassword},
+		{name: "should reject long password", password: strings.Repeat("a", MaxPasswordLength+1), expected: ErrWeakPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, ValidatePassword(tt.password), tt.expected)
+		})
+	}
+}
+
+func Test_HashPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse battery")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"))
+
+	// The salt is random, the same password has another hash
+	another, err := HashPassword("correct horse battery")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, another)
+
+	ok, err := CheckPassword(hash, "correct horse battery")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = CheckPassword(hash, "correct horse battery staple")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func Test_CheckPassword(t *testing.T) {
+	// The hash made with the former parameters is verified with the parameters kept in it
+	salt := []byte("somesaltsomesalt")
+	key := argon2.IDKey([]byte("correct horse battery"), salt, 1, 8*1024, 2, 16)
+	former := "$argon2id$v=19$m=8192,t=1,p=2$" + base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(key)
+
+	ok, err := CheckPassword(former, "correct horse battery")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "should reject hash of another algorithm", hash: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"},
+		{name: "should reject hash of another version", hash: "$argon2id$v=16$m=19456,t=2,p=1$c29tZXNhbHQ$aGFzaA"},
+		{name: "should reject hash without parameters", hash: "$argon2id$v=19$$c29tZXNhbHQ$aGFzaA"},
+		{name: "should reject hash which isn't base64 encoded", hash: "$argon2id$v=19$m=19456,t=2,p=1$c29tZXNhbHQ$!"},
+		{name: "should reject empty hash", hash: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CheckPassword(tt.hash, "correct horse battery")
+			require.ErrorIs(t, err, ErrInvalidPasswordHash)
+		})
+	}
+}
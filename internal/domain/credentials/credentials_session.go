@@ -0,0 +1,156 @@
+package credentials
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AccessTokenPrefix prefixes the access tokens of the sessions, so they are told apart from the JWTs sent in the same header
+	AccessTokenPrefix = "ses_"
+	// RefreshTokenPrefix prefixes the refresh tokens of the sessions
+	RefreshTokenPrefix = "ref_"
+	// tokenSize is the number of random bytes of the token
+	tokenSize = 32
+)
+
+// SessionPolicy defines how long the tokens of the sessions are valid
+type SessionPolicy struct {
+	AccessTTL  time.Duration // How long the access token authenticates the requests
+	RefreshTTL time.Duration // How long the refresh token issues the new tokens, the session ends once it expires
+	MFATTL     time.Duration // How long the session waits for the TOTP code after the password matched
+}
+
+// DefaultSessionPolicy returns the default session policy
+func DefaultSessionPolicy() SessionPolicy {
+	return SessionPolicy{
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 12 * time.Hour,
+		MFATTL:     5 * time.Minute,
+	}
+}
+
+// Session is the server-side session of the customer logged in. Only the SHA-256 hashes of its tokens are stored,
+// the tokens are handed out to the customer once, when they are issued.
+type Session struct {
+	ID               uuid.UUID // Unique identifier of the session
+	CustomerID       uuid.UUID // Customer logged in
+	AccessTokenHash  string    // Hex encoded SHA-256 hash of the access token
+	RefreshTokenHash string    // Hex encoded SHA-256 hash of the refresh token, empty while the TOTP code is awaited
+	MFAPending       bool      // Whether the session awaits the TOTP code, the access token is the MFA token of the login then
+	AccessExpiresAt  time.Time // When the access token expires
+	RefreshExpiresAt time.Time // When the refresh token expires
+	CreatedAt        time.Time // When the session was created
+	RevokedAt        time.Time // When the session was revoked, i.e. by the logout, zero when it wasn't
+	Version          int       // Version of the session, incremented by every change stored, so the tokens are rotated once
+}
+
+// SessionTokens are the tokens issued to the customer for the session
+type SessionTokens struct {
+	AccessToken      string
+	RefreshToken     string // Empty while the TOTP code is awaited
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
+
+// NewSession creates the session of the customer whose password matched. When the TOTP code is required too,
+// the session awaits it and its access token is the MFA token of the login, valid for the MFA TTL of the policy.
+func NewSession(customerID uuid.UUID, mfaPending bool, policy SessionPolicy, now time.Time) (*Session, SessionTokens, error) {
+	s := &Session{
+		ID:         uuid.New(),
+		CustomerID: customerID,
+		CreatedAt:  now,
+	}
+
+	if !mfaPending {
+		tokens, err := s.Rotate(policy, now)
+		if err != nil {
+			return nil, SessionTokens{}, err
+		}
+
+		return s, tokens, nil
+	}
+
+	accessToken, err := newToken(AccessTokenPrefix)
+	if err != nil {
+		return nil, SessionTokens{}, err
+	}
+
+	s.MFAPending = true
+	s.AccessTokenHash = HashToken(accessToken)
+	s.AccessExpiresAt = now.Add(policy.MFATTL)
+	s.RefreshExpiresAt = s.AccessExpiresAt
+
+	return s, SessionTokens{AccessToken: accessToken, AccessExpiresAt: s.AccessExpiresAt, RefreshExpiresAt: s.RefreshExpiresAt}, nil
+}
+
+// Rotate issues the new access and refresh tokens of the session, the former tokens aren't valid anymore.
+// It completes the login of the session awaiting the TOTP code.
+func (s *Session) Rotate(policy SessionPolicy, now time.Time) (SessionTokens, error) {
+	accessToken, err := newToken(AccessTokenPrefix)
+	if err != nil {
+		return SessionTokens{}, err
+	}
+
+	refreshToken, err := newToken(RefreshTokenPrefix)
+	if err != nil {
+		return SessionTokens{}, err
+	}
+
+	s.MFAPending = false
+	s.AccessTokenHash = HashToken(accessToken)
+	s.RefreshTokenHash = HashToken(refreshToken)
+	s.AccessExpiresAt = now.Add(policy.AccessTTL)
+	s.RefreshExpiresAt = now.Add(policy.RefreshTTL)
+
+	return SessionTokens{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresAt:  s.AccessExpiresAt,
+		RefreshExpiresAt: s.RefreshExpiresAt,
+	}, nil
+}
+
+// IsActive reports whether the access token of the session authenticates the requests at the time
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt.IsZero() && !s.MFAPending && now.Before(s.AccessExpiresAt)
+}
+
+// IsAwaitingMFA reports whether the session awaits the TOTP code at the time
+func (s *Session) IsAwaitingMFA(now time.Time) bool {
+	return s.RevokedAt.IsZero() && s.MFAPending && now.Before(s.AccessExpiresAt)
+}
+
+// CanRefresh reports whether the refresh token of the session issues the new tokens at the time
+func (s *Session) CanRefresh(now time.Time) bool {
+	return s.RevokedAt.IsZero() && !s.MFAPending && now.Before(s.RefreshExpiresAt)
+}
+
+// Revoke ends the session, its tokens aren't valid anymore
+func (s *Session) Revoke(now time.Time) {
+	if s.RevokedAt.IsZero() {
+		s.RevokedAt = now
+	}
+}
+
+// HashToken returns the hex encoded SHA-256 hash of the token the session is stored with
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newToken returns a new random token with the prefix
+func newToken(prefix string) (string, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating session token: %w", err)
+	}
+
+	return prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,171 @@
+//go:build unit
+
+package credentials
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewSession(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultSessionPolicy()
+
+	type testCaseParams struct {
+		mfaPending bool
+	}
+
+	type testCaseExpected struct {
+		refreshToken     bool
+		accessExpiresAt  time.Time
+		refreshExpiresAt time.Time
+		active           bool
+		awaitingMFA      bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should create active session",
+			expected: testCaseExpected{
+				refreshToken:     true,
+				accessExpiresAt:  now.Add(policy.AccessTTL),
+				refreshExpiresAt: now.Add(policy.RefreshTTL),
+				active:           true,
+			},
+		},
+		{
+			name: "should create session awaiting totp code",
+			params: testCaseParams{
+				mfaPending: true,
+			},
+			expected: testCaseExpected{
+				accessExpiresAt:  now.Add(policy.MFATTL),
+				refreshExpiresAt: now.Add(policy.MFATTL),
+				awaitingMFA:      true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			customerID := uuid.New()
+
+			s, tokens, err := NewSession(customerID, tt.params.mfaPending, policy, now)
+			require.NoError(t, err)
+
+			require.Equal(t, customerID, s.CustomerID)
+			require.True(t, strings.HasPrefix(tokens.AccessToken, AccessTokenPrefix))
+			require.Equal(t, HashToken(tokens.AccessToken), s.AccessTokenHash)
+			require.Equal(t, tt.expected.accessExpiresAt, tokens.AccessExpiresAt)
+			require.Equal(t, tt.expected.refreshExpiresAt, tokens.RefreshExpiresAt)
+			require.Equal(t, tt.expected.active, s.IsActive(now))
+			require.Equal(t, tt.expected.awaitingMFA, s.IsAwaitingMFA(now))
+			require.Equal(t, tt.expected.active, s.CanRefresh(now))
+
+			if !tt.expected.refreshToken {
+				require.Empty(t, tokens.RefreshToken)
+				require.Empty(t, s.RefreshTokenHash)
+				return
+			}
+
+			require.True(t, strings.HasPrefix(tokens.RefreshToken, RefreshTokenPrefix))
+			require.Equal(t, HashToken(tokens.RefreshToken), s.RefreshTokenHash)
+		})
+	}
+}
+
+func Test_Session_Rotate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultSessionPolicy()
+
+	s, former, err := NewSession(uuid.New(), true, policy, now)
+	require.NoError(t, err)
+
+	later := now.Add(time.Minute)
+	tokens, err := s.Rotate(policy, later)
+	require.NoError(t, err)
+
+	require.False(t, s.MFAPending)
+	require.NotEqual(t, former.AccessToken, tokens.AccessToken)
+	require.Equal(t, HashToken(tokens.AccessToken), s.AccessTokenHash)
+	require.Equal(t, HashToken(tokens.RefreshToken), s.RefreshTokenHash)
+	require.Equal(t, later.Add(policy.AccessTTL), s.AccessExpiresAt)
+	require.Equal(t, later.Add(policy.RefreshTTL), s.RefreshExpiresAt)
+	require.True(t, s.IsActive(later))
+}
+
+func Test_Session_Expiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultSessionPolicy()
+
+	type testCaseParams struct {
+		at     time.Time
+		revoke bool
+	}
+
+	type testCaseExpected struct {
+		active     bool
+		canRefresh bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should be active before access token expires",
+			params: testCaseParams{
+				at: now.Add(policy.AccessTTL - time.Second),
+			},
+			expected: testCaseExpected{
+				active:     true,
+				canRefresh: true,
+			},
+		},
+		{
+			name: "should refresh once access token expired",
+			params: testCaseParams{
+				at: now.Add(policy.AccessTTL),
+			},
+			expected: testCaseExpected{
+				canRefresh: true,
+			},
+		},
+		{
+			name: "should end once refresh token expired",
+			params: testCaseParams{
+				at: now.Add(policy.RefreshTTL),
+			},
+		},
+		{
+			name: "should end once revoked",
+			params: testCaseParams{
+				at:     now,
+				revoke: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, err := NewSession(uuid.New(), false, policy, now)
+			require.NoError(t, err)
+
+			if tt.params.revoke {
+				s.Revoke(now)
+			}
+
+			require.Equal(t, tt.expected.active, s.IsActive(tt.params.at))
+			require.Equal(t, tt.expected.canRefresh, s.CanRefresh(tt.params.at))
+		})
+	}
+}
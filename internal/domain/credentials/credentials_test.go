@@ -0,0 +1,521 @@
+//go:build unit
+
+package credentials
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+const testPassword = "correct horse battery"
+
+// testCredentials returns the credentials with the password set and their events cleared
+func testCredentials(t *testing.T, now time.Time) *Credentials {
+	t.Helper()
+
+	c := NewCredentials(uuid.New(), now)
+	require.NoError(t, c.SetPassword(testPassword, now))
+	c.ClearEvents()
+
+	return c
+}
+
+// testEnabledTOTP enables the TOTP of the credentials and clears their events
+func testEnabledTOTP(t *testing.T, c *Credentials, now time.Time) {
+	t.Helper()
+
+	secret, err := c.EnrollTOTP(now)
+	require.NoError(t, err)
+
+	code, err := TOTPCode(secret, TOTPStep(now))
+	require.NoError(t, err)
+
+	require.NoError(t, c.ConfirmTOTP(code, now))
+	c.ClearEvents()
+}
+
+func Test_Credentials_SetPassword(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type testCaseParams struct {
+		password string
+		locked   bool
+	}
+
+	type testCaseExpected struct {
+		err          error
+		eventsNumber int
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should set password",
+			params: testCaseParams{
+				password: testPassword,
+			},
+			expected: testCaseExpected{
+				eventsNumber: 1,
+			},
+		},
+		{
+			name: "should set password and unlock credentials",
+			params: testCaseParams{
+				password: testPassword,
+				locked:   true,
+			},
+			expected: testCaseExpected{
+				eventsNumber: 1,
+			},
+		},
+		{
+			name: "should reject weak password",
+			params: testCaseParams{
+				password: "short",
+			},
+			expected: testCaseExpected{
+				err: ErrWeakPassword,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCredentials(uuid.New(), now)
+			if tt.params.locked {
+				c.FailedAttempts = 3
+				c.LockedUntil = now.Add(time.Hour)
+			}
+
+			err := c.SetPassword(tt.params.password, now)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Empty(t, c.PasswordHash)
+				require.Empty(t, c.Events)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, c.IsLocked(now))
+			require.Zero(t, c.FailedAttempts)
+
+			ok, err := CheckPassword(c.PasswordHash, tt.params.password)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			// Event checks
+			require.Len(t, c.Events, tt.expected.eventsNumber)
+			require.Equal(t, CredentialsSetEventType.String(), c.Events[0].GetType())
+			require.Equal(t, c.ID, c.Events[0].GetContextID())
+			require.Equal(t, origin.String(), c.Events[0].GetOrigin())
+			require.Equal(t, event.EventStateCompleted.String(), c.Events[0].GetState())
+
+			set, ok := c.Events[0].(*CredentialsSetEvent)
+			require.True(t, ok)
+			require.Equal(t, FactorPassword, set.Factor)
+			require.Equal(t, c.CustomerID, set.CustomerID)
+		})
+	}
+}
+
+func Test_Credentials_TOTPEnrollment(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type testCaseParams struct {
+		enroll  bool
+		enabled bool
+		code    func(secret string) string
+	}
+
+	type testCaseExpected struct {
+		err     error
+		enabled bool
+	}
+
+	validCode := func(secret string) string {
+		code, _ := TOTPCode(secret, TOTPStep(now))
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should enable totp confirmed with valid code",
+			params: testCaseParams{
+				enroll: true,
+				code:   validCode,
+			},
+			expected: testCaseExpected{
+				enabled: true,
+			},
+		},
+		{
+			name: "should reject wrong code",
+			params: testCaseParams{
+				enroll: true,
+				code:   func(string) string { return "000000" },
+			},
+			expected: testCaseExpected{
+				err: ErrWrongTOTPCode,
+			},
+		},
+		{
+			name: "should reject confirmation without enrollment",
+			params: testCaseParams{
+				code: func(string) string { return "000000" },
+			},
+			expected: testCaseExpected{
+				err: ErrTOTPNotEnrolled,
+			},
+		},
+		{
+			name: "should reject enrollment when totp enabled",
+			params: testCaseParams{
+				enabled: true,
+			},
+			expected: testCaseExpected{
+				err:     ErrTOTPAlreadyEnabled,
+				enabled: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCredentials(t, now)
+
+			if tt.params.enabled {
+				testEnabledTOTP(t, c, now.Add(-time.Hour))
+
+				_, err := c.EnrollTOTP(now)
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Equal(t, tt.expected.enabled, c.TOTPEnabled)
+				return
+			}
+
+			var secret string
+			if tt.params.enroll {
+				var err error
+				secret, err = c.EnrollTOTP(now)
+				require.NoError(t, err)
+				require.Equal(t, secret, c.TOTPSecret)
+				require.Empty(t, c.Events)
+			}
+
+			err := c.ConfirmTOTP(tt.params.code(secret), now)
+			require.ErrorIs(t, err, tt.expected.err)
+			require.Equal(t, tt.expected.enabled, c.TOTPEnabled)
+			require.Equal(t, tt.expected.enabled, c.MFARequired())
+
+			if tt.expected.err != nil {
+				require.Empty(t, c.Events)
+				return
+			}
+
+			// Event checks
+			require.Len(t, c.Events, 1)
+			set, ok := c.Events[0].(*CredentialsSetEvent)
+			require.True(t, ok)
+			require.Equal(t, FactorTOTP, set.Factor)
+		})
+	}
+}
+
+func Test_Credentials_VerifyPassword(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultLockoutPolicy()
+
+	type testCaseParams struct {
+		password       string
+		failedAttempts int
+		lockedUntil    time.Time
+	}
+
+	type testCaseExpected struct {
+		err            error
+		failedAttempts int
+		locked         bool
+		eventsNumber   int
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should accept matching password",
+			params: testCaseParams{
+				password:       testPassword,
+				failedAttempts: 2,
+			},
+			expected: testCaseExpected{
+				failedAttempts: 2,
+			},
+		},
+		{
+			name: "should count wrong password",
+			params: testCaseParams{
+				password:       "wrong horse battery",
+				failedAttempts: 2,
+			},
+			expected: testCaseExpected{
+				err:            ErrWrongPassword,
+				failedAttempts: 3,
+				eventsNumber:   1,
+			},
+		},
+		{
+			name: "should lock credentials after max failed attempts",
+			params: testCaseParams{
+				password:       "wrong horse battery",
+				failedAttempts: policy.MaxFailedAttempts - 1,
+			},
+			expected: testCaseExpected{
+				err:          ErrWrongPassword,
+				locked:       true,
+				eventsNumber: 1,
+			},
+		},
+		{
+			name: "should reject matching password when locked",
+			params: testCaseParams{
+				password:    testPassword,
+				lockedUntil: now.Add(time.Minute),
+			},
+			expected: testCaseExpected{
+				err:    ErrCredentialsLocked,
+				locked: true,
+			},
+		},
+		{
+			name: "should accept matching password once lockout expired",
+			params: testCaseParams{
+				password:    testPassword,
+				lockedUntil: now.Add(-time.Minute),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCredentials(t, now)
+			c.FailedAttempts = tt.params.failedAttempts
+			c.LockedUntil = tt.params.lockedUntil
+
+			err := c.VerifyPassword(tt.params.password, policy, now)
+			require.ErrorIs(t, err, tt.expected.err)
+			require.Equal(t, tt.expected.failedAttempts, c.FailedAttempts)
+			require.Equal(t, tt.expected.locked, c.IsLocked(now))
+
+			// Event checks
+			require.Len(t, c.Events, tt.expected.eventsNumber)
+			if tt.expected.eventsNumber == 0 {
+				return
+			}
+
+			failed, ok := c.Events[0].(*LoginFailedEvent)
+			require.True(t, ok)
+			require.Equal(t, LoginFailedEventType.String(), failed.GetType())
+			require.Equal(t, FactorPassword, failed.Factor)
+
+			if tt.expected.locked {
+				require.Equal(t, policy.MaxFailedAttempts, failed.FailedAttempts)
+				require.NotNil(t, failed.LockedUntil)
+				require.Equal(t, now.Add(policy.Duration), *failed.LockedUntil)
+				return
+			}
+
+			require.Equal(t, tt.expected.failedAttempts, failed.FailedAttempts)
+			require.Nil(t, failed.LockedUntil)
+		})
+	}
+}
+
+func Test_Credentials_VerifyTOTP(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultLockoutPolicy()
+
+	type testCaseParams struct {
+		enabled bool
+		code    func(c *Credentials) string
+	}
+
+	type testCaseExpected struct {
+		err          error
+		eventsNumber int
+	}
+
+	validCode := func(c *Credentials) string {
+		code, _ := TOTPCode(c.TOTPSecret, TOTPStep(now))
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should accept valid code",
+			params: testCaseParams{
+				enabled: true,
+				code:    validCode,
+			},
+		},
+		{
+			name: "should count wrong code",
+			params: testCaseParams{
+				enabled: true,
+				code:    func(*Credentials) string { return "abcdef" },
+			},
+			expected: testCaseExpected{
+				err:          ErrWrongTOTPCode,
+				eventsNumber: 1,
+			},
+		},
+		{
+			name: "should reject code when totp not enabled",
+			params: testCaseParams{
+				code: func(*Credentials) string { return "000000" },
+			},
+			expected: testCaseExpected{
+				err: ErrTOTPNotEnrolled,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCredentials(t, now)
+			if tt.params.enabled {
+				testEnabledTOTP(t, c, now.Add(-time.Hour))
+			}
+
+			err := c.VerifyTOTP(tt.params.code(c), policy, now)
+			require.ErrorIs(t, err, tt.expected.err)
+			require.Len(t, c.Events, tt.expected.eventsNumber)
+
+			if tt.expected.err != nil {
+				return
+			}
+
+			require.Equal(t, TOTPStep(now), c.TOTPLastStep)
+
+			// The code used can't be used again
+			err = c.VerifyTOTP(tt.params.code(c), policy, now)
+			require.ErrorIs(t, err, ErrWrongTOTPCode)
+		})
+	}
+}
+
+func Test_Credentials_CountFailedLogin(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultLockoutPolicy()
+
+	type testCaseParams struct {
+		password       string
+		failedAttempts int
+		lockedUntil    time.Time
+	}
+
+	type testCaseExpected struct {
+		err            error
+		failedAttempts int
+		eventAttempts  int
+		locked         bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should count failed login after concurrent ones",
+			params: testCaseParams{
+				password:       "wrong horse battery",
+				failedAttempts: 3,
+			},
+			expected: testCaseExpected{
+				failedAttempts: 3,
+				eventAttempts:  3,
+			},
+		},
+		{
+			name: "should lock credentials by failed login counted after concurrent ones",
+			params: testCaseParams{
+				password:    "wrong horse battery",
+				lockedUntil: now.Add(policy.Duration),
+			},
+			expected: testCaseExpected{
+				eventAttempts: policy.MaxFailedAttempts,
+				locked:        true,
+			},
+		},
+		{
+			name: "shouldn't count failed login - login didn't fail",
+			params: testCaseParams{
+				password:       testPassword,
+				failedAttempts: 1,
+			},
+			expected: testCaseExpected{
+				err: ErrLoginNotFailed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCredentials(t, now)
+			_ = c.VerifyPassword(tt.params.password, policy, now)
+
+			err := c.CountFailedLogin(tt.params.failedAttempts, tt.params.lockedUntil, 7, policy)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.failedAttempts, c.FailedAttempts)
+			require.Equal(t, tt.expected.locked, c.IsLocked(now))
+			require.Equal(t, 7, c.Version)
+
+			failed, ok := c.Events[0].(*LoginFailedEvent)
+			require.True(t, ok)
+			require.Equal(t, tt.expected.eventAttempts, failed.FailedAttempts)
+
+			if tt.expected.locked {
+				require.NotNil(t, failed.LockedUntil)
+				require.Equal(t, tt.params.lockedUntil, *failed.LockedUntil)
+				return
+			}
+
+			require.Nil(t, failed.LockedUntil)
+		})
+	}
+}
+
+func Test_Credentials_Succeed(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	c := testCredentials(t, now)
+	c.FailedAttempts = 3
+
+	c.Succeed(now.Add(time.Minute))
+
+	require.Zero(t, c.FailedAttempts)
+	require.Equal(t, now.Add(time.Minute), c.UpdatedAt)
+	require.Empty(t, c.Events)
+}
@@ -0,0 +1,111 @@
+//go:build unit
+
+package credentials
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the secret of the RFC 6238 test vectors of HMAC-SHA1, "12345678901234567890" base32 encoded
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func Test_TOTPCode(t *testing.T) {
+	// The RFC 6238 (appendix B) test vectors of HMAC-SHA1, truncated to 6 digits
+	tests := []struct {
+		name     string
+		time     int64
+		expected string
+	}{
+		{name: "should generate code at 59", time: 59, expected: "287082"},
+		{name: "should generate code at 1111111109", time: 1111111109, expected: "081804"},
+		{name: "should generate code at 1234567890", time: 1234567890, expected: "005924"},
+		{name: "should generate code at 20000000000", time: 20000000000, expected: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := TOTPCode(rfcSecret, TOTPStep(time.Unix(tt.time, 0)))
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func Test_MatchTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := TOTPStep(now)
+
+	code := func(step int64) string {
+		c, err := TOTPCode(rfcSecret, step)
+		require.NoError(t, err)
+		return c
+	}
+
+	type testCaseParams struct {
+		code     string
+		lastStep int64
+	}
+
+	type testCaseExpected struct {
+		step  int64
+		match bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name:     "should match code of current step",
+			params:   testCaseParams{code: code(step)},
+			expected: testCaseExpected{step: step, match: true},
+		},
+		{
+			name:     "should match code of previous step within skew",
+			params:   testCaseParams{code: code(step - 1)},
+			expected: testCaseExpected{step: step - 1, match: true},
+		},
+		{
+			name:   "shouldn't match code out of skew",
+			params: testCaseParams{code: code(step - 2)},
+		},
+		{
+			name:   "shouldn't match code already used",
+			params: testCaseParams{code: code(step), lastStep: step},
+		},
+		{
+			name:   "shouldn't match code of wrong length",
+			params: testCaseParams{code: "12345"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := MatchTOTP(rfcSecret, tt.params.code, tt.params.lastStep, now)
+			require.Equal(t, tt.expected.match, ok)
+			require.Equal(t, tt.expected.step, step)
+		})
+	}
+}
+
+func Test_TOTPURI(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	uri, err := url.Parse(TOTPURI("ddd-bank", "customer-1", secret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/ddd-bank:customer-1", uri.Path)
+	require.Equal(t, secret, uri.Query().Get("secret"))
+	require.Equal(t, "ddd-bank", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+	require.Equal(t, "30", uri.Query().Get("period"))
+}
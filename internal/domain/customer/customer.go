@@ -1,6 +1,7 @@
 package customer
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +24,7 @@ type Customer struct {
 	Accounts    []string       `json:"accounts"`    // List of account IDs associated with the customer
 	CreatedAt   time.Time      `json:"createdAt"`   // When the customer was created
 	UpdatedAt   time.Time      `json:"updatedAt"`   // When the customer was last updated
+	Version     int            `json:"version"`     // Version of the customer, the aggregate version of the last event applied to the customer
 	Events      []Event        // List of events associated with the customer
 }
 
@@ -54,12 +56,12 @@ func NewCustomer(id uuid.UUID, firstName string, lastName string, email string,
 				ContextID:   id,
 				Origin:      origin.String(),
 				Type:        CustomerCreatedEventType.String(),
-				TypeVersion: "0.0.0",
+				TypeVersion: eventTypeVersion,
 				State:       event.EventStateReady.String(),
 				CreatedAt:   now,
 				ScheduledAt: now,
 				Retry:       0,
-				MaxRetry:    3,
+				MaxRetry:    event.DefaultMaxRetry,
 				Data:        nil,
 			},
 			FirstName:   firstName,
@@ -73,6 +75,27 @@ func NewCustomer(id uuid.UUID, firstName string, lastName string, email string,
 	return customer
 }
 
+// NewCustomerFromEvents rebuilds the customer by applying its events in the order they were recorded.
+// It returns ErrCustomerNotFound if there are no events or the creation of the customer was aborted, the customer starts with its creation event.
+func NewCustomerFromEvents(events []Event) (*Customer, error) {
+	customer := &Customer{
+		Accounts: []string{},
+		Events:   []Event{},
+	}
+
+	for _, e := range events {
+		if err := customer.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+
+	if customer.Status == "" {
+		return nil, ErrCustomerNotFound
+	}
+
+	return customer, nil
+}
+
 // Activate activates a customer
 func (c *Customer) Activate() {
 	now := time.Now().UTC()
@@ -89,12 +112,12 @@ func (c *Customer) Activate() {
 				ContextID:   c.ID,
 				Origin:      origin.String(),
 				Type:        CustomerActivatedEventType.String(),
-				TypeVersion: "0.0.0",
+				TypeVersion: eventTypeVersion,
 				State:       event.EventStateReady.String(),
 				CreatedAt:   now,
 				ScheduledAt: now,
 				Retry:       0,
-				MaxRetry:    3,
+				MaxRetry:    event.DefaultMaxRetry,
 			},
 		})
 }
@@ -115,12 +138,12 @@ func (c *Customer) Deactivate() {
 				ContextID:   c.ID,
 				Origin:      origin.String(),
 				Type:        CustomerDeactivatedEventType.String(),
-				TypeVersion: "0.0.0",
+				TypeVersion: eventTypeVersion,
 				State:       event.EventStateReady.String(),
 				CreatedAt:   now,
 				ScheduledAt: now,
 				Retry:       0,
-				MaxRetry:    3,
+				MaxRetry:    event.DefaultMaxRetry,
 			},
 		})
 }
@@ -141,12 +164,12 @@ func (c *Customer) Block(reason string) {
 				ContextID:   c.ID,
 				Origin:      origin.String(),
 				Type:        CustomerBlockedEventType.String(),
-				TypeVersion: "0.0.0",
+				TypeVersion: eventTypeVersion,
 				State:       event.EventStateReady.String(),
 				CreatedAt:   now,
 				ScheduledAt: now,
 				Retry:       0,
-				MaxRetry:    3,
+				MaxRetry:    event.DefaultMaxRetry,
 			},
 			Reason: reason,
 		})
@@ -158,19 +181,22 @@ func (c *Customer) Unblock() {
 	c.Status = CustomerStatusActive
 	c.UpdatedAt = now
 
+	origin := EventOrigin("customer")
+
 	c.Events = append(
 		c.Events,
 		&CustomerUnblockedEvent{
 			BaseEvent: event.BaseEvent{
 				ID:          uuid.New(),
 				ContextID:   c.ID,
+				Origin:      origin.String(),
 				Type:        CustomerUnblockedEventType.String(),
-				TypeVersion: "0.0.0",
+				TypeVersion: eventTypeVersion,
 				State:       event.EventStateReady.String(),
 				CreatedAt:   now,
 				ScheduledAt: now,
 				Retry:       0,
-				MaxRetry:    3,
+				MaxRetry:    event.DefaultMaxRetry,
 			},
 		})
 }
@@ -202,19 +228,20 @@ func (c *Customer) Update(
 				ContextID:   c.ID,
 				Origin:      origin.String(),
 				Type:        updateType.String(),
-				TypeVersion: "0.0.0",
+				TypeVersion: updatedEventTypeVersion,
 				State:       event.EventStateReady.String(),
 				CreatedAt:   now,
 				ScheduledAt: now,
 				Retry:       0,
-				MaxRetry:    3,
+				MaxRetry:    event.DefaultMaxRetry,
 			},
-			FirstName:   firstName,
-			LastName:    lastName,
-			Phone:       phone,
-			Email:       email,
-			DateOfBirth: dob,
-			Address:     address,
+			FirstName:     firstName,
+			LastName:      lastName,
+			Phone:         phone,
+			Email:         email,
+			DateOfBirth:   dob,
+			Address:       address,
+			UpdatedFields: updateType.updatedFields(),
 		})
 }
 
@@ -231,16 +258,66 @@ func (c *Customer) Delete() {
 			ContextID:   c.ID,
 			Origin:      origin.String(),
 			Type:        CustomerDeletedEventType.String(),
-			TypeVersion: "0.0.0",
+			TypeVersion: eventTypeVersion,
 			State:       event.EventStateReady.String(),
 			CreatedAt:   now,
 			ScheduledAt: now,
 			Retry:       0,
-			MaxRetry:    3,
+			MaxRetry:    event.DefaultMaxRetry,
 		},
 	})
 }
 
+// Apply changes the customer state by the recorded event, the event isn't recorded again.
+func (c *Customer) Apply(e Event) error {
+	// An event aborted by an operator doesn't change the customer, only its version
+	if e.GetState() == event.EventStateAborted.String() {
+		c.Version = e.GetAggregateVersion()
+		return nil
+	}
+
+	if _, ok := e.(*CustomerCreatedEvent); !ok && c.Status == "" {
+		return fmt.Errorf("applying %s event before customer creation: %w", e.GetType(), ErrCustomerEventNotApplicable)
+	}
+
+	switch ev := e.(type) {
+	case *CustomerCreatedEvent:
+		c.ID = ev.ContextID
+		c.FirstName = ev.FirstName
+		c.LastName = ev.LastName
+		c.Email = ev.Email
+		c.Phone = ev.Phone
+		c.DateOfBirth = ev.DateOfBirth
+		c.Address = ev.Address
+		c.Status = CustomerStatusActive
+		c.CreatedAt = ev.CreatedAt
+	case *CustomerActivatedEvent:
+		c.Status = CustomerStatusActive
+	case *CustomerDeactivatedEvent:
+		c.Status = CustomerStatusInactive
+	case *CustomerBlockedEvent:
+		c.Status = CustomerStatusBlocked
+	case *CustomerUnblockedEvent:
+		c.Status = CustomerStatusActive
+	case *CustomerUpdatedEvent:
+		c.FirstName = ev.FirstName
+		c.LastName = ev.LastName
+		c.Email = ev.Email
+		c.Phone = ev.Phone
+		c.DateOfBirth = ev.DateOfBirth
+		c.Address = ev.Address
+	case *CustomerDeletedEvent:
+		c.Status = CustomerStatusInactive
+	default:
+		return fmt.Errorf("applying %s event: %w", e.GetType(), ErrCustomerEventNotApplicable)
+	}
+
+	c.UpdatedAt = e.GetCreatedAt()
+	c.Version = e.GetAggregateVersion()
+
+	return nil
+}
+
 // GetEvents returns all domain events that have occurred on this customer.
 func (c *Customer) GetEvents() []Event {
 	return c.Events
@@ -16,4 +16,12 @@ var (
 var (
 	// ErrCustomerEventNotFound is returned when a customer event is not found
 	ErrCustomerEventNotFound = errors.New("customer event not found")
+	// ErrCustomerEventNotApplicable is returned when an event can't be applied to the customer, i.e. an event of another aggregate
+	ErrCustomerEventNotApplicable = errors.New("customer event not applicable")
+)
+
+// Customer Snapshot errors
+var (
+	// ErrCustomerSnapshotNotFound is returned when a customer has no snapshot, the customer is rebuilt from all its events
+	ErrCustomerSnapshotNotFound = errors.New("customer snapshot not found")
 )
@@ -1,53 +1,104 @@
 package customer
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
 )
 
 // AccountCreatedEvent is emitted when a new account is created
 type CustomerCreatedEvent struct {
-	event.BaseEvent
-	FirstName   string  `json:"firstName"`
-	LastName    string  `json:"lastName"`
-	Phone       string  `json:"phone"`
-	Email       string  `json:"email"`
-	DateOfBirth string  `json:"dateOfBirth"`
-	Address     Address `json:"address"`
+	event.BaseEvent `json:"-"`
+	FirstName       string  `json:"firstName"`
+	LastName        string  `json:"lastName"`
+	Phone           string  `json:"phone"`
+	Email           string  `json:"email"`
+	DateOfBirth     string  `json:"dateOfBirth"`
+	Address         Address `json:"address"`
 }
 
 // CustomerActivatedEvent is emitted when a customer is activated
 type CustomerActivatedEvent struct {
-	event.BaseEvent
+	event.BaseEvent `json:"-"`
 }
 
 // CustomerDeactivatedEvent is emitted when a customer is deactivated
 type CustomerDeactivatedEvent struct {
-	event.BaseEvent
+	event.BaseEvent `json:"-"`
 }
 
 // CustomerBlockedEvent is emitted when a customer is blocked
 type CustomerBlockedEvent struct {
-	event.BaseEvent
-	Reason string `json:"reason"` // The reason the customer was blocked
+	event.BaseEvent `json:"-"`
+	Reason          string `json:"reason"` // The reason the customer was blocked
 }
 
 // CustomerUnblockedEvent is emitted when a customer is unblocked
 type CustomerUnblockedEvent struct {
-	event.BaseEvent
+	event.BaseEvent `json:"-"`
 }
 
 // CustomerUpdatedEvent is emitted when a customer is updated
 type CustomerUpdatedEvent struct {
-	event.BaseEvent
-	FirstName   string  `json:"firstName"`
-	LastName    string  `json:"lastName"`
-	Phone       string  `json:"phone"`
-	Email       string  `json:"email"`
-	DateOfBirth string  `json:"dateOfBirth"`
-	Address     Address `json:"address"`
+	event.BaseEvent `json:"-"`
+	FirstName       string   `json:"firstName"`
+	LastName        string   `json:"lastName"`
+	Phone           string   `json:"phone"`
+	Email           string   `json:"email"`
+	DateOfBirth     string   `json:"dateOfBirth"`
+	Address         Address  `json:"address"`
+	UpdatedFields   []string `json:"updatedFields"` // The customer attributes changed by the update, i.e. firstName and lastName
 }
 
 // CustomerDeletedEvent is emitted when a customer is deleted
 type CustomerDeletedEvent struct {
-	event.BaseEvent
+	event.BaseEvent `json:"-"`
+}
+
+// RegisterEvents registers the customer events, so they can be decoded from the events table
+func RegisterEvents(r *event.Registry) {
+	r.Register(CustomerCreatedEventType.String(), eventTypeVersion, func() event.Event { return &CustomerCreatedEvent{} })
+	r.Register(CustomerActivatedEventType.String(), eventTypeVersion, func() event.Event { return &CustomerActivatedEvent{} })
+	r.Register(CustomerDeactivatedEventType.String(), eventTypeVersion, func() event.Event { return &CustomerDeactivatedEvent{} })
+	r.Register(CustomerBlockedEventType.String(), eventTypeVersion, func() event.Event { return &CustomerBlockedEvent{} })
+	r.Register(CustomerUnblockedEventType.String(), eventTypeVersion, func() event.Event { return &CustomerUnblockedEvent{} })
+	r.Register(CustomerDeletedEventType.String(), eventTypeVersion, func() event.Event { return &CustomerDeletedEvent{} })
+
+	for _, updateType := range []CustomerEventType{
+		CustomerUpdatedNameEventType,
+		CustomerUpdatedContactEventType,
+		CustomerUpdatedAddressEventType,
+		CustomerUpdatedAllEventType,
+	} {
+		r.Register(updateType.String(), updatedEventTypeVersion, func() event.Event { return &CustomerUpdatedEvent{} })
+		r.RegisterUpcaster(updateType.String(), eventTypeVersion, updatedEventTypeVersion, upcastUpdatedFields(updateType))
+	}
+}
+
+// upcastUpdatedFields returns the upcaster of the customer updated event payload from 0.0.0 to 0.1.0,
+// it adds the customer attributes changed by the update, which the update type tells
+func upcastUpdatedFields(updateType CustomerEventType) event.Upcaster {
+	return event.UpcastFields(func(fields map[string]json.RawMessage) error {
+		if _, ok := fields["updatedFields"]; ok {
+			return nil
+		}
+
+		updatedFields, err := json.Marshal(updateType.updatedFields())
+		if err != nil {
+			return fmt.Errorf("encoding updated fields: %w", err)
+		}
+
+		fields["updatedFields"] = updatedFields
+		return nil
+	})
+}
+
+// RegisterRetryPolicies registers the retry policy of the customer events.
+// Retrying can't bring back a missing customer, so the error fails the event at once.
+func RegisterRetryPolicies(r *event.RetryPolicies) {
+	policy := event.DefaultRetryPolicy()
+	policy.Terminal = append(policy.Terminal, ErrCustomerNotFound)
+
+	r.RegisterOrigin(EventOrigin("customer").String(), policy)
 }
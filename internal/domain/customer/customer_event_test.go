@@ -3,7 +3,6 @@
 package customer
 
 import (
-	"encoding/json"
 	"testing"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event/eventtest"
 )
 
 func compareCustomerBaseEvents(t *testing.T, event, restoredEvent Event) {
@@ -27,6 +27,25 @@ func compareCustomerBaseEvents(t *testing.T, event, restoredEvent Event) {
 	require.Equal(t, event.GetMaxRetry(), restoredEvent.GetMaxRetry())
 }
 
+// encodeCustomerEvent encodes the event payload the way it's stored in the events table
+func encodeCustomerEvent(t *testing.T, e Event) []byte {
+	data, err := event.Encode(e)
+	require.NoError(t, err)
+
+	return data
+}
+
+// decodeCustomerEvent restores the domain event from the event read from the events table
+func decodeCustomerEvent(t *testing.T, e Event) Event {
+	registry := event.NewRegistry()
+	RegisterEvents(registry)
+
+	restored, err := registry.Decode(e)
+	require.NoError(t, err)
+
+	return restored
+}
+
 func Test_CustomerCreatedEvent(t *testing.T) {
 	now := time.Now().UTC()
 	eventID := uuid.New()
@@ -61,13 +80,9 @@ func Test_CustomerCreatedEvent(t *testing.T) {
 		},
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerCreatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerCreatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
@@ -100,13 +115,9 @@ func Test_CustomerActivatedEvent(t *testing.T) {
 		},
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerActivatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerActivatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
@@ -133,13 +144,9 @@ func Test_CustomerDeactivatedEvent(t *testing.T) {
 		},
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerDeactivatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerDeactivatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 }
@@ -167,13 +174,9 @@ func Test_CustomerBlockedEvent(t *testing.T) {
 		Reason: reason,
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerBlockedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerBlockedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
@@ -201,13 +204,9 @@ func Test_CustomerUnblockedEvent(t *testing.T) {
 		},
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerUnblockedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerUnblockedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 }
@@ -224,7 +223,7 @@ func Test_CustomerUpdatedEvent_Name(t *testing.T) {
 			ContextID:   customerID,
 			Origin:      origin.String(),
 			Type:        CustomerUpdatedNameEventType.String(),
-			TypeVersion: "0.0.0",
+			TypeVersion: updatedEventTypeVersion,
 			State:       event.EventStateReady.String(),
 			CreatedAt:   now,
 			ScheduledAt: now,
@@ -243,15 +242,12 @@ func Test_CustomerUpdatedEvent_Name(t *testing.T) {
 			PostalCode: "00-000",
 			Country:    "Poland",
 		},
+		UpdatedFields: CustomerUpdatedNameEventType.updatedFields(),
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerUpdatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerUpdatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
@@ -261,6 +257,7 @@ func Test_CustomerUpdatedEvent_Name(t *testing.T) {
 	require.Equal(t, event.Email, restoredEvent.Email)
 	require.Equal(t, event.DateOfBirth, restoredEvent.DateOfBirth)
 	require.Equal(t, event.Address, restoredEvent.Address)
+	require.Equal(t, event.UpdatedFields, restoredEvent.UpdatedFields)
 }
 
 func Test_CustomerUpdatedEvent_Contact(t *testing.T) {
@@ -275,7 +272,7 @@ func Test_CustomerUpdatedEvent_Contact(t *testing.T) {
 			ContextID:   customerID,
 			Origin:      origin.String(),
 			Type:        CustomerUpdatedContactEventType.String(),
-			TypeVersion: "0.0.0",
+			TypeVersion: updatedEventTypeVersion,
 			State:       event.EventStateReady.String(),
 			CreatedAt:   now,
 			ScheduledAt: now,
@@ -294,15 +291,12 @@ func Test_CustomerUpdatedEvent_Contact(t *testing.T) {
 			PostalCode: "00-000",
 			Country:    "Poland",
 		},
+		UpdatedFields: CustomerUpdatedContactEventType.updatedFields(),
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
+	event.Data = encodeCustomerEvent(t, event)
 
-	event.Data = data
-
-	restoredEvent := &CustomerUpdatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerUpdatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
@@ -312,6 +306,7 @@ func Test_CustomerUpdatedEvent_Contact(t *testing.T) {
 	require.Equal(t, event.Email, restoredEvent.Email)
 	require.Equal(t, event.DateOfBirth, restoredEvent.DateOfBirth)
 	require.Equal(t, event.Address, restoredEvent.Address)
+	require.Equal(t, event.UpdatedFields, restoredEvent.UpdatedFields)
 }
 
 func Test_CustomerUpdatedEvent_Address(t *testing.T) {
@@ -326,7 +321,7 @@ func Test_CustomerUpdatedEvent_Address(t *testing.T) {
 			ContextID:   customerID,
 			Origin:      origin.String(),
 			Type:        CustomerUpdatedAddressEventType.String(),
-			TypeVersion: "0.0.0",
+			TypeVersion: updatedEventTypeVersion,
 			State:       event.EventStateReady.String(),
 			CreatedAt:   now,
 			ScheduledAt: now,
@@ -345,15 +340,12 @@ func Test_CustomerUpdatedEvent_Address(t *testing.T) {
 			PostalCode: "00-000",
 			Country:    "Poland",
 		},
+		UpdatedFields: CustomerUpdatedAddressEventType.updatedFields(),
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerUpdatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerUpdatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
@@ -362,6 +354,7 @@ func Test_CustomerUpdatedEvent_Address(t *testing.T) {
 	require.Equal(t, event.Phone, restoredEvent.Phone)
 	require.Equal(t, event.Email, restoredEvent.Email)
 	require.Equal(t, event.Address, restoredEvent.Address)
+	require.Equal(t, event.UpdatedFields, restoredEvent.UpdatedFields)
 }
 
 func Test_CustomerUpdatedEvent_All(t *testing.T) {
@@ -376,7 +369,7 @@ func Test_CustomerUpdatedEvent_All(t *testing.T) {
 			ContextID:   customerID,
 			Origin:      origin.String(),
 			Type:        CustomerUpdatedAllEventType.String(),
-			TypeVersion: "0.0.0",
+			TypeVersion: updatedEventTypeVersion,
 			State:       event.EventStateReady.String(),
 			CreatedAt:   now,
 			ScheduledAt: now,
@@ -395,15 +388,12 @@ func Test_CustomerUpdatedEvent_All(t *testing.T) {
 			PostalCode: "00-000",
 			Country:    "Poland",
 		},
+		UpdatedFields: CustomerUpdatedAllEventType.updatedFields(),
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerUpdatedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerUpdatedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 
@@ -413,6 +403,7 @@ func Test_CustomerUpdatedEvent_All(t *testing.T) {
 	require.Equal(t, event.Email, restoredEvent.Email)
 	require.Equal(t, event.DateOfBirth, restoredEvent.DateOfBirth)
 	require.Equal(t, event.Address, restoredEvent.Address)
+	require.Equal(t, event.UpdatedFields, restoredEvent.UpdatedFields)
 }
 
 func Test_CustomerDeletedEvent(t *testing.T) {
@@ -436,13 +427,105 @@ func Test_CustomerDeletedEvent(t *testing.T) {
 		},
 	}
 
-	data, err := json.Marshal(event)
-	require.NoError(t, err)
-
-	event.Data = data
+	event.Data = encodeCustomerEvent(t, event)
 
-	restoredEvent := &CustomerDeletedEvent{}
-	require.NoError(t, json.Unmarshal(event.GetEventData(), restoredEvent))
+	restoredEvent := decodeCustomerEvent(t, event).(*CustomerDeletedEvent)
 
 	compareCustomerBaseEvents(t, event, restoredEvent)
 }
+
+// Test_CustomerEvents_Golden decodes the golden payloads of every customer event in every type version it was recorded in
+func Test_CustomerEvents_Golden(t *testing.T) {
+	registry := event.NewRegistry()
+	RegisterEvents(registry)
+
+	eventtest.RequireGoldenPayloads(t, registry, "customer", map[string]string{
+		CustomerCreatedEventType.String():        eventTypeVersion,
+		CustomerDeletedEventType.String():        eventTypeVersion,
+		CustomerActivatedEventType.String():      eventTypeVersion,
+		CustomerDeactivatedEventType.String():    eventTypeVersion,
+		CustomerBlockedEventType.String():        eventTypeVersion,
+		CustomerUnblockedEventType.String():      eventTypeVersion,
+		CustomerUpdatedNameEventType.String():    updatedEventTypeVersion,
+		CustomerUpdatedContactEventType.String(): updatedEventTypeVersion,
+		CustomerUpdatedAddressEventType.String(): updatedEventTypeVersion,
+		CustomerUpdatedAllEventType.String():     updatedEventTypeVersion,
+	})
+}
+
+func Test_CustomerUpdatedEvent_Upcast(t *testing.T) {
+	type testCaseParams struct {
+		eventType   CustomerEventType
+		typeVersion string
+		data        string
+	}
+
+	type testCaseExpected struct {
+		typeVersion   string
+		firstName     string
+		updatedFields []string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should add updated fields of name update recorded in version 0.0.0",
+			params: testCaseParams{
+				eventType:   CustomerUpdatedNameEventType,
+				typeVersion: eventTypeVersion,
+				data:        `{"firstName":"John","lastName":"Doe"}`,
+			},
+			expected: testCaseExpected{
+				typeVersion:   updatedEventTypeVersion,
+				firstName:     "John",
+				updatedFields: []string{"firstName", "lastName"},
+			},
+		},
+		{
+			name: "should add updated fields of contact update recorded in version 0.0.0",
+			params: testCaseParams{
+				eventType:   CustomerUpdatedContactEventType,
+				typeVersion: eventTypeVersion,
+				data:        `{"firstName":"John","phone":"+48123456789","email":"john.doe@example.com"}`,
+			},
+			expected: testCaseExpected{
+				typeVersion:   updatedEventTypeVersion,
+				firstName:     "John",
+				updatedFields: []string{"phone", "email"},
+			},
+		},
+		{
+			name: "should keep updated fields of update recorded in version 0.1.0",
+			params: testCaseParams{
+				eventType:   CustomerUpdatedAllEventType,
+				typeVersion: updatedEventTypeVersion,
+				data:        `{"firstName":"John","updatedFields":["firstName"]}`,
+			},
+			expected: testCaseExpected{
+				typeVersion:   updatedEventTypeVersion,
+				firstName:     "John",
+				updatedFields: []string{"firstName"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restoredEvent := decodeCustomerEvent(t, &event.BaseEvent{
+				ID:          uuid.New(),
+				ContextID:   uuid.New(),
+				Origin:      EventOrigin("customer").String(),
+				Type:        tt.params.eventType.String(),
+				TypeVersion: tt.params.typeVersion,
+				Data:        []byte(tt.params.data),
+			}).(*CustomerUpdatedEvent)
+
+			require.Equal(t, tt.expected.typeVersion, restoredEvent.GetTypeVersion())
+			require.Equal(t, tt.expected.firstName, restoredEvent.FirstName)
+			require.Equal(t, tt.expected.updatedFields, restoredEvent.UpdatedFields)
+		})
+	}
+}
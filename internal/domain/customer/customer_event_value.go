@@ -23,3 +23,26 @@ const (
 	CustomerUpdatedAddressEventType CustomerEventType = "customer.updated.address"
 	CustomerUpdatedAllEventType     CustomerEventType = "customer.updated.all"
 )
+
+// eventTypeVersion is the version of the customer events payload schema
+const eventTypeVersion = "0.0.0"
+
+// updatedEventTypeVersion is the version of the customer updated events payload schema,
+// 0.0.0 -> 0.1.0 added the customer attributes changed by the update
+const updatedEventTypeVersion = "0.1.0"
+
+// updatedFields returns the customer attributes changed by the update of the type, named as in the event payload
+func (e CustomerEventType) updatedFields() []string {
+	switch e {
+	case CustomerUpdatedNameEventType:
+		return []string{"firstName", "lastName"}
+	case CustomerUpdatedContactEventType:
+		return []string{"phone", "email"}
+	case CustomerUpdatedAddressEventType:
+		return []string{"address"}
+	case CustomerUpdatedAllEventType:
+		return []string{"firstName", "lastName", "phone", "email", "dateOfBirth", "address"}
+	default:
+		return nil
+	}
+}
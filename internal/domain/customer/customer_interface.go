@@ -46,6 +46,9 @@ type Event interface {
 	// GetMaxRetry returns the maximum number of times the event can be retried
 	GetMaxRetry() int
 
+	// GetAggregateVersion returns the version of the aggregate (context) the event leads to
+	GetAggregateVersion() int
+
 	// GetEventData returns the event data
 	GetEventData() []byte
 }
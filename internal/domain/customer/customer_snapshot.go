@@ -0,0 +1,83 @@
+package customer
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+// CustomerAggregateType is the type of the customer aggregate the snapshots are stored by
+const CustomerAggregateType = "customer"
+
+// CustomerSnapshotVersion is the version of the customer snapshot format.
+// It has to be changed together with CustomerSnapshot, so the snapshots in the previous format are ignored.
+const CustomerSnapshotVersion = "1"
+
+// CustomerSnapshot represents the state of the customer at its version.
+// The customer is restored from its latest snapshot and the events recorded after it, instead of all its events.
+type CustomerSnapshot struct {
+	ID          uuid.UUID      `json:"id"`
+	FirstName   string         `json:"firstName"`
+	LastName    string         `json:"lastName"`
+	Email       string         `json:"email"`
+	Phone       string         `json:"phone"`
+	DateOfBirth string         `json:"dateOfBirth"`
+	Address     Address        `json:"address"`
+	Status      CustomerStatus `json:"status"`
+	Accounts    []string       `json:"accounts"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Version     int            `json:"version"`
+}
+
+// DefaultSnapshotPolicy returns the policy snapshotting the customers, which change far less often than the accounts
+func DefaultSnapshotPolicy() event.SnapshotPolicy {
+	return event.SnapshotPolicy{Frequency: 50}
+}
+
+// Snapshot returns the snapshot of the customer state at its version
+func (c *Customer) Snapshot() CustomerSnapshot {
+	return CustomerSnapshot{
+		ID:          c.ID,
+		FirstName:   c.FirstName,
+		LastName:    c.LastName,
+		Email:       c.Email,
+		Phone:       c.Phone,
+		DateOfBirth: c.DateOfBirth,
+		Address:     c.Address,
+		Status:      c.Status,
+		Accounts:    append([]string{}, c.Accounts...),
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+		Version:     c.Version,
+	}
+}
+
+// NewCustomerFromSnapshot restores the customer from its snapshot and applies the events recorded after the snapshot.
+func NewCustomerFromSnapshot(snapshot CustomerSnapshot, events []Event) (*Customer, error) {
+	customer := &Customer{
+		ID:          snapshot.ID,
+		FirstName:   snapshot.FirstName,
+		LastName:    snapshot.LastName,
+		Email:       snapshot.Email,
+		Phone:       snapshot.Phone,
+		DateOfBirth: snapshot.DateOfBirth,
+		Address:     snapshot.Address,
+		Status:      snapshot.Status,
+		Accounts:    append([]string{}, snapshot.Accounts...),
+		CreatedAt:   snapshot.CreatedAt,
+		UpdatedAt:   snapshot.UpdatedAt,
+		Version:     snapshot.Version,
+		Events:      []Event{},
+	}
+
+	for _, e := range events {
+		if err := customer.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+
+	return customer, nil
+}
@@ -0,0 +1,80 @@
+//go:build unit
+
+package customer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+// testRecordedEvents returns the events as they are read from the events table, with their aggregate versions
+func testRecordedEvents(t *testing.T, events []Event) []Event {
+	registry := event.NewRegistry()
+	RegisterEvents(registry)
+
+	recorded := make([]Event, len(events))
+	for i, e := range events {
+		data, err := event.Encode(e)
+		require.NoError(t, err)
+
+		decoded, err := registry.Decode(&event.BaseEvent{
+			ID:               e.GetID(),
+			ContextID:        e.GetContextID(),
+			Origin:           e.GetOrigin(),
+			Type:             e.GetType(),
+			TypeVersion:      e.GetTypeVersion(),
+			State:            e.GetState(),
+			CreatedAt:        e.GetCreatedAt(),
+			ScheduledAt:      e.GetScheduledAt(),
+			MaxRetry:         e.GetMaxRetry(),
+			AggregateVersion: i + 1,
+			Data:             data,
+		})
+		require.NoError(t, err)
+
+		recorded[i] = decoded.(Event)
+	}
+
+	return recorded
+}
+
+func Test_NewCustomerFromSnapshot(t *testing.T) {
+	address := Address{Street: "Main St 1", City: "Warsaw", PostalCode: "00-001", Country: "Poland"}
+
+	customer := NewCustomer(uuid.New(), "John", "Doe", "john.doe@example.com", "+48123456789", "1990-01-01", address)
+	customer.Update(CustomerUpdatedNameEventType, "Jane", "Doe", "+48123456789", "jane.doe@example.com", "1990-01-01", address)
+	customer.Block("fraud suspicion")
+	customer.Unblock()
+	customer.Deactivate()
+	customer.Activate()
+	customer.Delete()
+
+	events := testRecordedEvents(t, customer.GetEvents())
+	events[3].(*CustomerUnblockedEvent).State = event.EventStateAborted.String()
+
+	replayed, err := NewCustomerFromEvents(events)
+	require.NoError(t, err)
+
+	// The customer restored from the snapshot taken at any version and the events after it
+	// has to be the customer replayed from all its events
+	for version := 1; version <= len(events); version++ {
+		snapshotted, err := NewCustomerFromEvents(events[:version])
+		require.NoError(t, err)
+
+		data, err := json.Marshal(snapshotted.Snapshot())
+		require.NoError(t, err)
+
+		var snapshot CustomerSnapshot
+		require.NoError(t, json.Unmarshal(data, &snapshot))
+		require.Equal(t, version, snapshot.Version)
+
+		restored, err := NewCustomerFromSnapshot(snapshot, events[version:])
+		require.NoError(t, err)
+		require.Equal(t, replayed, restored, "snapshot at version %d", version)
+	}
+}
@@ -23,8 +23,9 @@ func Test_NewCustomer(t *testing.T) {
 	}
 
 	type testCaseExpected struct {
-		eventsNumber int
-		eventType    string
+		eventsNumber  int
+		eventType     string
+		updatedFields []string
 	}
 
 	tests := []struct {
@@ -113,8 +114,9 @@ func Test_Customer_Update_Name(t *testing.T) {
 				dateOfBirth: "1990-01-01",
 				address:     Address{Street: "Street 1", City: "Warsaw", State: "Masovian", PostalCode: "00-000", Country: "Poland"},
 
-				eventsNumber: 2, // 1 for creation and 1 for update
-				eventType:    CustomerUpdatedAllEventType.String(),
+				eventsNumber:  2, // 1 for creation and 1 for update
+				eventType:     CustomerUpdatedAllEventType.String(),
+				updatedFields: []string{"firstName", "lastName", "phone", "email", "dateOfBirth", "address"},
 			},
 		},
 	}
@@ -142,6 +144,7 @@ func Test_Customer_Update_Name(t *testing.T) {
 
 			require.Equal(t, tt.expected.eventsNumber, len(customer.Events))
 			require.Equal(t, tt.expected.eventType, customer.Events[1].GetType())
+			require.Equal(t, tt.expected.updatedFields, customer.Events[1].(*CustomerUpdatedEvent).UpdatedFields)
 		})
 	}
 }
@@ -264,3 +267,26 @@ func Test_Customer_Activate(t *testing.T) {
 func Test_Customer_Deactivate(t *testing.T) {
 	// TODO: Implement
 }
+
+func Test_NewCustomerFromEvents(t *testing.T) {
+	address := Address{Street: "Main St 1", City: "Warsaw", PostalCode: "00-001", Country: "Poland"}
+
+	customer := NewCustomer(uuid.New(), "John", "Doe", "john.doe@example.com", "+48123456789", "1990-01-01", address)
+	customer.Update(CustomerUpdatedNameEventType, "Jane", "Doe", "+48123456789", "jane.doe@example.com", "1990-01-01", address)
+	customer.Block("fraud suspicion")
+
+	rebuilt, err := NewCustomerFromEvents(customer.GetEvents())
+	require.NoError(t, err)
+	require.Equal(t, customer.ID, rebuilt.ID)
+	require.Equal(t, "Jane", rebuilt.FirstName)
+	require.Equal(t, "jane.doe@example.com", rebuilt.Email)
+	require.Equal(t, address, rebuilt.Address)
+	require.Equal(t, CustomerStatusBlocked, rebuilt.Status)
+	require.Empty(t, rebuilt.GetEvents())
+
+	_, err = NewCustomerFromEvents(nil)
+	require.ErrorIs(t, err, ErrCustomerNotFound)
+
+	_, err = NewCustomerFromEvents(customer.GetEvents()[1:])
+	require.ErrorIs(t, err, ErrCustomerEventNotApplicable)
+}
@@ -41,6 +41,20 @@ func (m *MockEvent) EXPECT() *MockEventMockRecorder {
 	return m.recorder
 }
 
+// GetAggregateVersion mocks base method.
+func (m *MockEvent) GetAggregateVersion() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateVersion")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetAggregateVersion indicates an expected call of GetAggregateVersion.
+func (mr *MockEventMockRecorder) GetAggregateVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateVersion", reflect.TypeOf((*MockEvent)(nil).GetAggregateVersion))
+}
+
 // GetCompletedAt mocks base method.
 func (m *MockEvent) GetCompletedAt() time.Time {
 	m.ctrl.T.Helper()
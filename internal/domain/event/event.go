@@ -32,6 +32,12 @@ type BaseEvent struct {
 	Retry int `json:"retry"`
 	// MaxRetry is the maximum number of times the event an be retried
 	MaxRetry int `json:"max_retry"`
+	// AggregateVersion is the version of the aggregate (context) the event leads to, set when the event is recorded
+	AggregateVersion int `json:"aggregate_version"`
+	// Metadata describes who and what caused the event, i.e. the request recording it, its actor and idempotency key
+	Metadata Metadata `json:"metadata"`
+	// FailureReason is the error of the last failed attempt to process the event, empty when no attempt failed
+	FailureReason string `json:"failure_reason"`
 	// Data is the data associated with the event
 	Data []byte `json:"data"`
 }
@@ -102,10 +108,16 @@ func (e *BaseEvent) GetMaxRetry() int {
 	return e.MaxRetry
 }
 
+func (e *BaseEvent) GetAggregateVersion() int {
+	return e.AggregateVersion
+}
+
 func (e *BaseEvent) GetEventData() []byte {
 	return e.Data
 }
 
+// Schedule defers processing of the event until the given time.
+// The orchestrator picks up the event only when it is due, events are processed in the order they are scheduled.
 func (e *BaseEvent) Schedule(t time.Time) {
 	e.ScheduledAt = t
 }
@@ -0,0 +1,40 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction represents an action taken by an operator on an event
+type AuditAction string
+
+// String returns the string representation of the audit action
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+const (
+	// AuditActionRequeued is recorded when the event is requeued to be processed again with its retry counter reset
+	AuditActionRequeued AuditAction = "requeued"
+	// AuditActionAborted is recorded when the event is aborted, so it is never processed
+	AuditActionAborted AuditAction = "aborted"
+)
+
+// Audit records an action taken by an operator on an event
+type Audit struct {
+	// ID is the unique identifier of the audit record
+	ID uuid.UUID
+	// EventID is the ID of the event the action was taken on
+	EventID uuid.UUID
+	// Action is the action taken on the event
+	Action AuditAction
+	// Actor is the operator who took the action
+	Actor string
+	// Reason is the reason given by the operator
+	Reason string
+	// PreviousState is the state of the event before the action
+	PreviousState string
+	// CreatedAt is the time the action was taken
+	CreatedAt time.Time
+}
@@ -0,0 +1,106 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Encode returns the event data, which is the JSON encoded payload of the event, i.e. the initial balance of a created account.
+// The common event fields are not part of the payload, they're stored next to it.
+// Events which already carry their data, i.e. the events read from the events table, are returned as they are.
+func Encode(e Event) ([]byte, error) {
+	if data := e.GetEventData(); len(data) > 0 {
+		return data, nil
+	}
+
+	if _, ok := e.(*BaseEvent); ok {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s event payload: %w", e.GetType(), err)
+	}
+
+	return data, nil
+}
+
+// typedEvent is a domain event embedding the base event, i.e. the account created event
+type typedEvent interface {
+	Event
+	base() *BaseEvent
+}
+
+// base gives access to the base event embedded into a domain event
+func (e *BaseEvent) base() *BaseEvent {
+	return e
+}
+
+// registryKey identifies the payload schema of an event
+type registryKey struct {
+	eventType   string
+	typeVersion string
+}
+
+// Registry decodes the events into the domain events based on their type and type version.
+// The payloads of the older type versions are upcast to the version the domain event is registered with.
+type Registry struct {
+	events    map[registryKey]func() Event
+	upcasters map[registryKey]upcast
+}
+
+// NewRegistry creates an empty events registry
+func NewRegistry() *Registry {
+	return &Registry{
+		events:    make(map[registryKey]func() Event),
+		upcasters: make(map[registryKey]upcast),
+	}
+}
+
+// Register registers the constructor of the domain event with the type and type version.
+// The constructor must return a pointer to a struct embedding the base event, i.e. &AccountCreatedEvent{}.
+func (r *Registry) Register(eventType, typeVersion string, newEvent func() Event) {
+	r.events[registryKey{eventType: eventType, typeVersion: typeVersion}] = newEvent
+}
+
+// Decode creates the domain event registered for the event type and type version.
+// The event in an older type version is upcast first, so the domain event gets the payload in its own type version.
+// The common fields are copied from the event and the payload is decoded from the event data.
+func (r *Registry) Decode(e Event) (Event, error) {
+	typeVersion, data, err := r.Upcast(e.GetType(), e.GetTypeVersion(), e.GetEventData())
+	if err != nil {
+		return nil, err
+	}
+
+	newEvent := r.events[registryKey{eventType: e.GetType(), typeVersion: typeVersion}]
+
+	decoded, ok := newEvent().(typedEvent)
+	if !ok {
+		return nil, fmt.Errorf("decoding %s event in version %s: %w", e.GetType(), typeVersion, ErrEventNotRegistered)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, decoded); err != nil {
+			return nil, fmt.Errorf("decoding %s event payload: %w: %w", e.GetType(), ErrEventDataInvalid, err)
+		}
+	}
+
+	*decoded.base() = BaseEvent{
+		ID:               e.GetID(),
+		ContextID:        e.GetContextID(),
+		Origin:           e.GetOrigin(),
+		Type:             e.GetType(),
+		TypeVersion:      typeVersion,
+		State:            e.GetState(),
+		CreatedAt:        e.GetCreatedAt(),
+		ScheduledAt:      e.GetScheduledAt(),
+		StartedAt:        e.GetStartedAt(),
+		CompletedAt:      e.GetCompletedAt(),
+		Retry:            e.GetRetry(),
+		MaxRetry:         e.GetMaxRetry(),
+		AggregateVersion: e.GetAggregateVersion(),
+		Data:             data,
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,180 @@
+//go:build unit
+
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testPayloadEvent is a domain event carrying a payload next to the base event
+type testPayloadEvent struct {
+	BaseEvent `json:"-"`
+	Reason    string `json:"reason"`
+}
+
+func testBaseEvent(typeVersion string) BaseEvent {
+	now := time.Now().UTC()
+
+	return BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   uuid.New(),
+		Origin:      "test",
+		Type:        "test.happened",
+		TypeVersion: typeVersion,
+		State:       EventStateReady.String(),
+		CreatedAt:   now,
+		ScheduledAt: now,
+		MaxRetry:    3,
+	}
+}
+
+func Test_Encode(t *testing.T) {
+	type testCaseParams struct {
+		event Event
+	}
+
+	type testCaseExpected struct {
+		data string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should encode the payload only",
+			params: testCaseParams{
+				event: &testPayloadEvent{
+					BaseEvent: testBaseEvent("0.0.0"),
+					Reason:    "fraud",
+				},
+			},
+			expected: testCaseExpected{
+				data: `{"reason":"fraud"}`,
+			},
+		},
+		{
+			name: "should keep the data of an already encoded event",
+			params: testCaseParams{
+				event: func() Event {
+					e := testBaseEvent("0.0.0")
+					e.Data = []byte(`{"reason":"stored"}`)
+
+					return &e
+				}(),
+			},
+			expected: testCaseExpected{
+				data: `{"reason":"stored"}`,
+			},
+		},
+		{
+			name: "should encode no data for an event without payload",
+			params: testCaseParams{
+				event: func() Event {
+					e := testBaseEvent("0.0.0")
+
+					return &e
+				}(),
+			},
+			expected: testCaseExpected{
+				data: "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encode(tt.params.event)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.data, string(data))
+		})
+	}
+}
+
+func Test_Registry_Decode(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.happened", "0.0.0", func() Event { return &testPayloadEvent{} })
+
+	type testCaseParams struct {
+		event func() BaseEvent
+	}
+
+	type testCaseExpected struct {
+		reason string
+		err    error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should decode registered event",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					e := testBaseEvent("0.0.0")
+					e.Data = []byte(`{"reason":"fraud"}`)
+
+					return e
+				},
+			},
+			expected: testCaseExpected{
+				reason: "fraud",
+			},
+		},
+		{
+			name: "shouldn't decode event - type version not registered",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					e := testBaseEvent("1.0.0")
+					e.Data = []byte(`{"reason":"fraud"}`)
+
+					return e
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrEventNotRegistered,
+			},
+		},
+		{
+			name: "shouldn't decode event - invalid data",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					e := testBaseEvent("0.0.0")
+					e.Data = []byte(`{ ... invalid data ... }`)
+
+					return e
+				},
+			},
+			expected: testCaseExpected{
+				err: ErrEventDataInvalid,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.params.event()
+
+			decoded, err := registry.Decode(&e)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Nil(t, decoded)
+				return
+			}
+
+			require.NoError(t, err)
+
+			payloadEvent, ok := decoded.(*testPayloadEvent)
+			require.True(t, ok)
+			require.Equal(t, e, payloadEvent.BaseEvent)
+			require.Equal(t, tt.expected.reason, payloadEvent.Reason)
+		})
+	}
+}
@@ -8,4 +8,26 @@ import (
 var (
 	// ErrEventNotFound is returned when an event is not found
 	ErrEventNotFound = errors.New("event not found") // TODO: decide if we should use this error for all domains?
+	// ErrEventAlreadyExists is returned when an event with the same ID was already recorded
+	ErrEventAlreadyExists = errors.New("event already exists")
+	// ErrEventNotRegistered is returned when no domain event is registered for the event type and type version
+	ErrEventNotRegistered = errors.New("event type not registered")
+	// ErrEventDataInvalid is returned when the event data can't be decoded into the domain event payload
+	ErrEventDataInvalid = errors.New("invalid event data")
+	// ErrEventStateInvalid is returned when the state of the event doesn't allow the operation, i.e. requeue of a completed event
+	ErrEventStateInvalid = errors.New("invalid event state")
+	// ErrEventHasSuccessors is returned when the event can't be aborted, later events of its aggregate were decided on top of it
+	ErrEventHasSuccessors = errors.New("event has successors")
+	// ErrConcurrencyConflict is returned when the aggregate was changed since it was loaded, the version expected by the command was already recorded
+	ErrConcurrencyConflict = errors.New("concurrency conflict")
+)
+
+// Event projection errors
+var (
+	// ErrProjectionNotFound is returned when the read model (projection) can't be rebuilt from the events
+	ErrProjectionNotFound = errors.New("projection not found")
+	// ErrCheckpointNotFound is returned when the rebuild of the read model can't be resumed, it hasn't started yet
+	ErrCheckpointNotFound = errors.New("projection checkpoint not found")
+	// ErrProjectionBusy is returned when the rebuilt read model can't be swapped in, its events are being processed
+	ErrProjectionBusy = errors.New("projection busy")
 )
@@ -0,0 +1,98 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ActorTypeAnonymous is the type of the actor of the request which wasn't authenticated
+	ActorTypeAnonymous = "anonymous"
+	// ActorTypeUser is the type of the authenticated user, the actor ID is the user ID
+	ActorTypeUser = "user"
+	// ActorTypeService is the type of the service calling the API with its API key, the actor ID is the name of the service
+	ActorTypeService = "service"
+	// ActorTypeSystem is the type of the service itself, i.e. the orchestrator processing the events recorded without an actor
+	ActorTypeSystem = "system"
+)
+
+// Metadata describes who and what caused the event, it's stored next to the event data and published with the event.
+// The request recording the events is the cause of them, the events recorded while processing an event are caused by that event.
+type Metadata struct {
+	// CorrelationID is shared by the events recorded by a request and all the events following from them, i.e. the steps of a transfer saga
+	CorrelationID uuid.UUID `json:"correlation_id,omitzero"`
+	// CausationID is the ID of the request or the event which caused the event
+	CausationID uuid.UUID `json:"causation_id,omitzero"`
+	// ActorID identifies who triggered the event, i.e. the user ID, empty for an anonymous actor
+	ActorID string `json:"actor_id,omitempty"`
+	// ActorType is the kind of the actor, i.e. user, system, anonymous
+	ActorType string `json:"actor_type,omitempty"`
+	// SourceIP is the IP address the request recording the event came from
+	SourceIP string `json:"source_ip,omitempty"`
+	// IdempotencyKey is the key of the request which recorded the event, empty when the request didn't carry any
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// CausedBy returns the metadata of the events caused by the event with the ID, which carries the metadata.
+// The caused events keep the correlation ID and the actor of the event, the event recorded without them starts the correlation
+// and the service is its actor.
+func (m Metadata) CausedBy(id uuid.UUID) Metadata {
+	caused := Metadata{
+		CorrelationID: m.CorrelationID,
+		CausationID:   id,
+		ActorID:       m.ActorID,
+		ActorType:     m.ActorType,
+	}
+
+	if caused.CorrelationID == uuid.Nil {
+		caused.CorrelationID = id
+	}
+
+	if caused.ActorType == "" {
+		caused.ActorType = ActorTypeSystem
+	}
+
+	return caused
+}
+
+// metadataContextKey is the key of the event metadata in the request context
+type metadataContextKey struct{}
+
+// ContextWithMetadata returns the context carrying the metadata, the events recorded within the context are stored with it
+func ContextWithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataContextKey{}, md)
+}
+
+// MetadataFromContext returns the metadata carried by the context, the empty metadata when the context carries none
+func MetadataFromContext(ctx context.Context) Metadata {
+	md, _ := ctx.Value(metadataContextKey{}).(Metadata)
+	return md
+}
+
+// EncodeMetadata returns the JSON encoded metadata as stored in the event_metadata column of the events table.
+// The correlation, causation, actor and source IP have their own columns, so they're left out.
+func EncodeMetadata(md Metadata) ([]byte, error) {
+	data, err := json.Marshal(Metadata{IdempotencyKey: md.IdempotencyKey})
+	if err != nil {
+		return nil, fmt.Errorf("encoding event metadata: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodeMetadata decodes the metadata stored in the event_metadata column, the events recorded before the metadata was introduced have none
+func DecodeMetadata(data []byte) (Metadata, error) {
+	var md Metadata
+	if len(data) == 0 {
+		return md, nil
+	}
+
+	if err := json.Unmarshal(data, &md); err != nil {
+		return Metadata{}, fmt.Errorf("decoding event metadata: %w", err)
+	}
+
+	return md, nil
+}
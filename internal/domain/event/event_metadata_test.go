@@ -0,0 +1,97 @@
+//go:build unit
+
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Metadata_CausedBy(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		metadata Metadata
+	}
+
+	type testCaseExpected struct {
+		metadata Metadata
+	}
+
+	correlationID := uuid.New()
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should keep correlation and actor of event",
+			params: testCaseParams{metadata: Metadata{
+				CorrelationID:  correlationID,
+				CausationID:    uuid.New(),
+				ActorID:        "user-1",
+				ActorType:      ActorTypeUser,
+				SourceIP:       "192.0.2.1",
+				IdempotencyKey: "transfer-1",
+			}},
+			expected: testCaseExpected{metadata: Metadata{
+				CorrelationID: correlationID,
+				CausationID:   eventID,
+				ActorID:       "user-1",
+				ActorType:     ActorTypeUser,
+			}},
+		},
+		{
+			name:   "should start correlation of event recorded without metadata",
+			params: testCaseParams{metadata: Metadata{}},
+			expected: testCaseExpected{metadata: Metadata{
+				CorrelationID: eventID,
+				CausationID:   eventID,
+				ActorType:     ActorTypeSystem,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected.metadata, tt.params.metadata.CausedBy(eventID))
+		})
+	}
+}
+
+func Test_Metadata_Context(t *testing.T) {
+	require.Equal(t, Metadata{}, MetadataFromContext(context.Background()))
+
+	md := Metadata{CorrelationID: uuid.New(), ActorType: ActorTypeAnonymous}
+	require.Equal(t, md, MetadataFromContext(ContextWithMetadata(context.Background(), md)))
+}
+
+func Test_EncodeMetadata(t *testing.T) {
+	md := Metadata{
+		CorrelationID:  uuid.New(),
+		CausationID:    uuid.New(),
+		ActorType:      ActorTypeAnonymous,
+		SourceIP:       "192.0.2.1",
+		IdempotencyKey: "deposit-1",
+	}
+
+	// The metadata having their own columns are left out
+	data, err := EncodeMetadata(md)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"idempotency_key":"deposit-1"}`, string(data))
+
+	decoded, err := DecodeMetadata(data)
+	require.NoError(t, err)
+	require.Equal(t, Metadata{IdempotencyKey: "deposit-1"}, decoded)
+
+	decoded, err = DecodeMetadata(nil)
+	require.NoError(t, err)
+	require.Equal(t, Metadata{}, decoded)
+
+	_, err = DecodeMetadata([]byte(`{"idempotency_key":1}`))
+	require.Error(t, err)
+}
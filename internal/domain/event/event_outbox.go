@@ -0,0 +1,14 @@
+package event
+
+// OutboxCheckpoint marks the last event published from the outbox.
+// The events are published in the order of the database transactions which recorded them, then in the order they were recorded.
+type OutboxCheckpoint struct {
+	TransactionID  int64 // Database transaction which recorded the last published event
+	SequenceNumber int64 // Outbox sequence number of the last published event, it orders the events of the same transaction
+}
+
+// OutboxMessage is a committed event waiting in the outbox to be published
+type OutboxMessage struct {
+	Checkpoint OutboxCheckpoint // Position of the event in the outbox
+	Event      *BaseEvent       // The recorded event, its data holds the JSON encoded payload
+}
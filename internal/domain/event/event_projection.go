@@ -0,0 +1,19 @@
+package event
+
+import (
+	"time"
+)
+
+// Checkpoint marks the last event replayed into the rebuilt read model (projection).
+// The events are replayed in the order they were completed, which is the order they were applied to the live read model.
+type Checkpoint struct {
+	CompletedAt    time.Time // When the last replayed event was completed
+	SequenceNumber int64     // Sequence number of the last replayed event, it orders the events completed at the same time
+}
+
+// ProjectionDiff is a row which differs between the live and the rebuilt read model
+type ProjectionDiff struct {
+	ID      string // Identifier of the row
+	Live    string // JSON encoded row of the live read model, empty when only the rebuilt read model has the row
+	Rebuilt string // JSON encoded row of the rebuilt read model, empty when only the live read model has the row
+}
@@ -0,0 +1,140 @@
+package event
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultMaxRetry is the number of attempts to process an event when no retry policy overrides it
+const DefaultMaxRetry = 3
+
+// Backoff represents how the delay between the attempts to process an event grows
+type Backoff string
+
+// String returns the string representation of the backoff
+func (b Backoff) String() string {
+	return string(b)
+}
+
+const (
+	// BackoffLinear grows the delay by the base delay with every attempt, i.e. 10s, 20s, 30s
+	BackoffLinear Backoff = "linear"
+	// BackoffExponential doubles the delay with every attempt, i.e. 10s, 20s, 40s
+	BackoffExponential Backoff = "exponential"
+)
+
+// RetryPolicy defines how the processing of an event is retried when it fails
+type RetryPolicy struct {
+	MaxAttempts int           // Maximum number of attempts to process the event, the event fails when they're exhausted
+	Backoff     Backoff       // How the delay grows with the attempts
+	BaseDelay   time.Duration // Delay before the first retry
+	MaxDelay    time.Duration // Upper bound of the delay, no bound when zero
+	Jitter      float64       // Fraction of the delay randomized, i.e. 0.2 spreads the delay by ±20%, so the retries don't come in bursts
+	Terminal    []error       // Errors retrying can't recover from, i.e. account not found, they fail the event at once
+}
+
+// DefaultRetryPolicy returns the policy applied to the events without a policy of their type or origin
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultMaxRetry,
+		Backoff:     BackoffExponential,
+		BaseDelay:   30 * time.Second,
+		MaxDelay:    10 * time.Minute,
+		Jitter:      0.2,
+		Terminal:    []error{ErrEventNotRegistered, ErrEventDataInvalid},
+	}
+}
+
+// IsRetryable reports whether the processing failure is transient, so the event may be processed again
+func (p RetryPolicy) IsRetryable(err error) bool {
+	for _, terminal := range p.Terminal {
+		if errors.Is(err, terminal) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Next returns the delay before the next attempt to process the event which failed with the error,
+// retry is the number of the failed attempts so far including this one.
+// It reports false when the event shouldn't be processed again, the error is terminal or the attempts are exhausted.
+func (p RetryPolicy) Next(retry int, err error) (time.Duration, bool) {
+	if !p.IsRetryable(err) || retry >= p.MaxAttempts {
+		return 0, false
+	}
+
+	return p.Delay(retry), true
+}
+
+// Delay returns the delay before the retry, the first retry is 1
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+
+	delay := p.BaseDelay
+	switch p.Backoff {
+	case BackoffLinear:
+		delay = p.BaseDelay * time.Duration(retry)
+	case BackoffExponential:
+		for i := 1; i < retry && (p.MaxDelay == 0 || delay < p.MaxDelay); i++ {
+			delay *= 2
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		delay += time.Duration(float64(delay) * p.Jitter * (2*rand.Float64() - 1))
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return max(delay, 0)
+}
+
+// RetryPolicies holds the retry policies of the events.
+// The policy of the event type takes precedence over the policy of the event origin, which takes precedence over the default policy.
+type RetryPolicies struct {
+	defaultPolicy RetryPolicy
+	origins       map[string]RetryPolicy
+	types         map[string]RetryPolicy
+}
+
+// NewRetryPolicies creates the retry policies falling back to the default policy
+func NewRetryPolicies(defaultPolicy RetryPolicy) *RetryPolicies {
+	return &RetryPolicies{
+		defaultPolicy: defaultPolicy,
+		origins:       make(map[string]RetryPolicy),
+		types:         make(map[string]RetryPolicy),
+	}
+}
+
+// RegisterOrigin registers the retry policy of the events of the origin, i.e. account
+func (r *RetryPolicies) RegisterOrigin(origin string, policy RetryPolicy) {
+	r.origins[origin] = policy
+}
+
+// RegisterType registers the retry policy of the events of the type, i.e. account.funds.withdrawn
+func (r *RetryPolicies) RegisterType(eventType string, policy RetryPolicy) {
+	r.types[eventType] = policy
+}
+
+// Policy returns the retry policy of the event of the origin and type
+func (r *RetryPolicies) Policy(origin, eventType string) RetryPolicy {
+	if policy, ok := r.types[eventType]; ok {
+		return policy
+	}
+
+	if policy, ok := r.origins[origin]; ok {
+		return policy
+	}
+
+	return r.defaultPolicy
+}
@@ -0,0 +1,161 @@
+//go:build unit
+
+package event
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetryPolicy_Delay(t *testing.T) {
+	type testCaseParams struct {
+		policy RetryPolicy
+		retry  int
+	}
+
+	type testCaseExpected struct {
+		delay time.Duration
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should delay the first retry by the base delay",
+			params: testCaseParams{
+				policy: RetryPolicy{Backoff: BackoffExponential, BaseDelay: 10 * time.Second},
+				retry:  1,
+			},
+			expected: testCaseExpected{
+				delay: 10 * time.Second,
+			},
+		},
+		{
+			name: "should double the delay with exponential backoff",
+			params: testCaseParams{
+				policy: RetryPolicy{Backoff: BackoffExponential, BaseDelay: 10 * time.Second},
+				retry:  3,
+			},
+			expected: testCaseExpected{
+				delay: 40 * time.Second,
+			},
+		},
+		{
+			name: "should grow the delay by the base delay with linear backoff",
+			params: testCaseParams{
+				policy: RetryPolicy{Backoff: BackoffLinear, BaseDelay: 10 * time.Second},
+				retry:  3,
+			},
+			expected: testCaseExpected{
+				delay: 30 * time.Second,
+			},
+		},
+		{
+			name: "should cap the delay by the max delay",
+			params: testCaseParams{
+				policy: RetryPolicy{Backoff: BackoffExponential, BaseDelay: 10 * time.Second, MaxDelay: time.Minute},
+				retry:  100,
+			},
+			expected: testCaseExpected{
+				delay: time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected.delay, tt.params.policy.Delay(tt.params.retry))
+		})
+	}
+}
+
+func Test_RetryPolicy_Delay_Jitter(t *testing.T) {
+	policy := RetryPolicy{Backoff: BackoffLinear, BaseDelay: 10 * time.Second, MaxDelay: 11 * time.Second, Jitter: 0.5}
+
+	for range 100 {
+		delay := policy.Delay(1)
+		require.GreaterOrEqual(t, delay, 5*time.Second)
+		require.LessOrEqual(t, delay, 11*time.Second)
+	}
+}
+
+func Test_RetryPolicy_Next(t *testing.T) {
+	errTerminal := errors.New("terminal")
+
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     BackoffExponential,
+		BaseDelay:   time.Second,
+		Terminal:    []error{errTerminal},
+	}
+
+	type testCaseParams struct {
+		retry int
+		err   error
+	}
+
+	type testCaseExpected struct {
+		delay     time.Duration
+		retryable bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should retry transient error",
+			params: testCaseParams{
+				retry: 2,
+				err:   errors.New("db error"),
+			},
+			expected: testCaseExpected{
+				delay:     2 * time.Second,
+				retryable: true,
+			},
+		},
+		{
+			name: "shouldn't retry - attempts exhausted",
+			params: testCaseParams{
+				retry: 3,
+				err:   errors.New("db error"),
+			},
+		},
+		{
+			name: "shouldn't retry - wrapped terminal error",
+			params: testCaseParams{
+				retry: 1,
+				err:   fmt.Errorf("withdrawing funds: %w", errTerminal),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retryable := policy.Next(tt.params.retry, tt.params.err)
+			require.Equal(t, tt.expected.retryable, retryable)
+			require.Equal(t, tt.expected.delay, delay)
+		})
+	}
+}
+
+func Test_RetryPolicies_Policy(t *testing.T) {
+	defaultPolicy := RetryPolicy{MaxAttempts: 3}
+	originPolicy := RetryPolicy{MaxAttempts: 5}
+	typePolicy := RetryPolicy{MaxAttempts: 10}
+
+	policies := NewRetryPolicies(defaultPolicy)
+	policies.RegisterOrigin("account", originPolicy)
+	policies.RegisterType("account.funds.withdrawn", typePolicy)
+
+	require.Equal(t, typePolicy.MaxAttempts, policies.Policy("account", "account.funds.withdrawn").MaxAttempts)
+	require.Equal(t, originPolicy.MaxAttempts, policies.Policy("account", "account.created").MaxAttempts)
+	require.Equal(t, defaultPolicy.MaxAttempts, policies.Policy("customer", "customer.created").MaxAttempts)
+}
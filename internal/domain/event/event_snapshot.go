@@ -0,0 +1,16 @@
+package event
+
+// SnapshotPolicy defines how often the state of an aggregate is snapshotted
+type SnapshotPolicy struct {
+	Frequency int // Number of events between the snapshots of an aggregate, the aggregate isn't snapshotted when zero
+}
+
+// IsDue reports whether the aggregate moved from one version to another should be snapshotted,
+// i.e. with the frequency of 100 the aggregate is snapshotted when it reaches the version 100, 200, 300, etc.
+func (p SnapshotPolicy) IsDue(from, to int) bool {
+	if p.Frequency <= 0 {
+		return false
+	}
+
+	return to/p.Frequency > from/p.Frequency
+}
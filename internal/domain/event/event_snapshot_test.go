@@ -0,0 +1,78 @@
+//go:build unit
+
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SnapshotPolicy_IsDue(t *testing.T) {
+	type testCaseParams struct {
+		policy SnapshotPolicy
+		from   int
+		to     int
+	}
+
+	type testCaseExpected struct {
+		due bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should snapshot aggregate reaching the frequency",
+			params: testCaseParams{
+				policy: SnapshotPolicy{Frequency: 10},
+				from:   9,
+				to:     10,
+			},
+			expected: testCaseExpected{
+				due: true,
+			},
+		},
+		{
+			name: "should snapshot aggregate passing the frequency with several events",
+			params: testCaseParams{
+				policy: SnapshotPolicy{Frequency: 10},
+				from:   18,
+				to:     21,
+			},
+			expected: testCaseExpected{
+				due: true,
+			},
+		},
+		{
+			name: "shouldn't snapshot aggregate between the snapshots",
+			params: testCaseParams{
+				policy: SnapshotPolicy{Frequency: 10},
+				from:   10,
+				to:     12,
+			},
+			expected: testCaseExpected{
+				due: false,
+			},
+		},
+		{
+			name: "shouldn't snapshot aggregate - snapshots disabled",
+			params: testCaseParams{
+				policy: SnapshotPolicy{},
+				from:   9,
+				to:     10,
+			},
+			expected: testCaseExpected{
+				due: false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected.due, tt.params.policy.IsDue(tt.params.from, tt.params.to))
+		})
+	}
+}
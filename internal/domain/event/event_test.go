@@ -0,0 +1,53 @@
+//go:build unit
+
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BaseEvent_Schedule(t *testing.T) {
+	e := testBaseEvent("0.0.0")
+	scheduledAt := e.CreatedAt.Add(time.Hour)
+
+	e.Schedule(scheduledAt)
+
+	require.Equal(t, scheduledAt, e.GetScheduledAt())
+	require.Equal(t, EventStateReady.String(), e.GetState())
+}
+
+func Test_EventState(t *testing.T) {
+	type testCaseExpected struct {
+		valid      bool
+		deadLetter bool
+		finished   bool
+		canRequeue bool
+		canAbort   bool
+	}
+
+	tests := []struct {
+		state    EventState
+		expected testCaseExpected
+	}{
+		{state: EventStateReady, expected: testCaseExpected{valid: true, canAbort: true}},
+		{state: EventStateProcessing, expected: testCaseExpected{valid: true}},
+		{state: EventStateCompleted, expected: testCaseExpected{valid: true, finished: true}},
+		{state: EventStateFailed, expected: testCaseExpected{valid: true, deadLetter: true, finished: true, canRequeue: true, canAbort: true}},
+		{state: EventStateUnprocessable, expected: testCaseExpected{valid: true, deadLetter: true, finished: true, canRequeue: true, canAbort: true}},
+		{state: EventStateAborted, expected: testCaseExpected{valid: true, deadLetter: true, finished: true, canRequeue: true}},
+		{state: EventState("stuck"), expected: testCaseExpected{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state.String(), func(t *testing.T) {
+			require.Equal(t, tt.expected.valid, tt.state.IsValid())
+			require.Equal(t, tt.expected.deadLetter, tt.state.IsDeadLetter())
+			require.Equal(t, tt.expected.finished, tt.state.IsFinished())
+			require.Equal(t, tt.expected.canRequeue, tt.state.CanRequeue())
+			require.Equal(t, tt.expected.canAbort, tt.state.CanAbort())
+		})
+	}
+}
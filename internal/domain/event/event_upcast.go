@@ -0,0 +1,74 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Upcaster turns the JSON encoded payload of an event in one type version into the payload of the next type version,
+// i.e. it fills a field added to the event with its default value or renames a field
+type Upcaster func(data []byte) ([]byte, error)
+
+// upcast leads the payload of an event type from one type version to the next one
+type upcast struct {
+	toVersion string
+	upcaster  Upcaster
+}
+
+// UpcastFields returns the upcaster changing the fields of the payload, which is a JSON object.
+// The fields keep their JSON encoded values, so the values which aren't changed are left as they are.
+// The payload of an event without data is an empty object.
+func UpcastFields(change func(fields map[string]json.RawMessage) error) Upcaster {
+	return func(data []byte) ([]byte, error) {
+		fields := make(map[string]json.RawMessage)
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return nil, fmt.Errorf("decoding payload fields: %w", err)
+			}
+		}
+
+		if err := change(fields); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(fields)
+	}
+}
+
+// RegisterUpcaster registers the upcaster of the payload of the event type from one type version to the next one.
+// The upcasters of an event type form a chain, i.e. 0.0.0 -> 0.1.0 -> 1.0.0, leading the payloads of the old type versions
+// to the type version the domain event is registered with, so the events recorded long ago are still decoded.
+func (r *Registry) RegisterUpcaster(eventType, fromVersion, toVersion string, upcaster Upcaster) {
+	r.upcasters[registryKey{eventType: eventType, typeVersion: fromVersion}] = upcast{
+		toVersion: toVersion,
+		upcaster:  upcaster,
+	}
+}
+
+// Upcast runs the chain of the upcasters of the event type on the payload until it reaches a type version
+// a domain event is registered with. It returns the reached type version and the payload in this version.
+// The payload in a registered type version is returned as it is.
+func (r *Registry) Upcast(eventType, typeVersion string, data []byte) (string, []byte, error) {
+	// Each upcaster is run at most once, so a chain leading back to one of its versions can't run forever
+	for steps := 0; steps <= len(r.upcasters); steps++ {
+		key := registryKey{eventType: eventType, typeVersion: typeVersion}
+
+		if _, ok := r.events[key]; ok {
+			return typeVersion, data, nil
+		}
+
+		next, ok := r.upcasters[key]
+		if !ok {
+			break
+		}
+
+		upcasted, err := next.upcaster(data)
+		if err != nil {
+			return "", nil, fmt.Errorf("upcasting %s event from version %s to %s: %w: %w", eventType, typeVersion, next.toVersion, ErrEventDataInvalid, err)
+		}
+
+		typeVersion, data = next.toVersion, upcasted
+	}
+
+	return "", nil, fmt.Errorf("decoding %s event in version %s: %w", eventType, typeVersion, ErrEventNotRegistered)
+}
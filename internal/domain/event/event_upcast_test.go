@@ -0,0 +1,161 @@
+//go:build unit
+
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testUpcastEvent is a domain event whose payload schema changed twice:
+// 0.0.0 -> 0.1.0 renamed the cause to the reason, 0.1.0 -> 1.0.0 added the severity
+type testUpcastEvent struct {
+	BaseEvent `json:"-"`
+	Reason    string `json:"reason"`
+	Severity  string `json:"severity"`
+}
+
+func testUpcastRegistry() *Registry {
+	registry := NewRegistry()
+	registry.Register("test.happened", "1.0.0", func() Event { return &testUpcastEvent{} })
+
+	registry.RegisterUpcaster("test.happened", "0.0.0", "0.1.0", UpcastFields(func(fields map[string]json.RawMessage) error {
+		fields["reason"] = fields["cause"]
+		delete(fields, "cause")
+		return nil
+	}))
+	registry.RegisterUpcaster("test.happened", "0.1.0", "1.0.0", UpcastFields(func(fields map[string]json.RawMessage) error {
+		if _, ok := fields["severity"]; !ok {
+			fields["severity"] = json.RawMessage(`"low"`)
+		}
+		return nil
+	}))
+
+	return registry
+}
+
+func Test_Registry_Upcast(t *testing.T) {
+	type testCaseParams struct {
+		typeVersion string
+		data        string
+	}
+
+	type testCaseExpected struct {
+		data     string
+		reason   string
+		severity string
+		err      error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should upcast payload through the whole chain",
+			params: testCaseParams{
+				typeVersion: "0.0.0",
+				data:        `{"cause":"fraud"}`,
+			},
+			expected: testCaseExpected{
+				data:     `{"reason":"fraud","severity":"low"}`,
+				reason:   "fraud",
+				severity: "low",
+			},
+		},
+		{
+			name: "should upcast payload from the middle of the chain",
+			params: testCaseParams{
+				typeVersion: "0.1.0",
+				data:        `{"reason":"fraud"}`,
+			},
+			expected: testCaseExpected{
+				data:     `{"reason":"fraud","severity":"low"}`,
+				reason:   "fraud",
+				severity: "low",
+			},
+		},
+		{
+			name: "should keep payload in the registered version",
+			params: testCaseParams{
+				typeVersion: "1.0.0",
+				data:        `{"reason":"fraud","severity":"high"}`,
+			},
+			expected: testCaseExpected{
+				data:     `{"reason":"fraud","severity":"high"}`,
+				reason:   "fraud",
+				severity: "high",
+			},
+		},
+		{
+			name: "should upcast event without data",
+			params: testCaseParams{
+				typeVersion: "0.1.0",
+			},
+			expected: testCaseExpected{
+				data:     `{"severity":"low"}`,
+				severity: "low",
+			},
+		},
+		{
+			name: "shouldn't upcast payload - no upcaster from the version",
+			params: testCaseParams{
+				typeVersion: "2.0.0",
+				data:        `{"reason":"fraud"}`,
+			},
+			expected: testCaseExpected{
+				err: ErrEventNotRegistered,
+			},
+		},
+		{
+			name: "shouldn't upcast payload - invalid data",
+			params: testCaseParams{
+				typeVersion: "0.0.0",
+				data:        `{ ... invalid data ... }`,
+			},
+			expected: testCaseExpected{
+				err: ErrEventDataInvalid,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testBaseEvent(tt.params.typeVersion)
+			if tt.params.data != "" {
+				e.Data = []byte(tt.params.data)
+			}
+
+			decoded, err := testUpcastRegistry().Decode(&e)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Nil(t, decoded)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "1.0.0", decoded.GetTypeVersion())
+			require.JSONEq(t, tt.expected.data, string(decoded.GetEventData()))
+
+			upcastEvent, ok := decoded.(*testUpcastEvent)
+			require.True(t, ok)
+			require.Equal(t, tt.expected.reason, upcastEvent.Reason)
+			require.Equal(t, tt.expected.severity, upcastEvent.Severity)
+		})
+	}
+}
+
+func Test_Registry_Upcast_Cycle(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.happened", "1.0.0", func() Event { return &testUpcastEvent{} })
+
+	noChange := func(data []byte) ([]byte, error) { return data, nil }
+	registry.RegisterUpcaster("test.happened", "0.0.0", "0.1.0", noChange)
+	registry.RegisterUpcaster("test.happened", "0.1.0", "0.0.0", noChange)
+
+	_, _, err := registry.Upcast("test.happened", "0.0.0", []byte(`{}`))
+	require.ErrorIs(t, err, ErrEventNotRegistered)
+}
@@ -29,3 +29,44 @@ const (
 	// EventStateUnprocessable is the state of the event when it is unprocessable, i.e. the event origin or type is unknown
 	EventStateUnprocessable EventState = "unprocessable"
 )
+
+// IsValid reports whether the event state is one of the known states
+func (e EventState) IsValid() bool {
+	switch e {
+	case EventStateReady, EventStateProcessing, EventStateCompleted, EventStateFailed, EventStateAborted, EventStateUnprocessable:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDeadLetter reports whether the event ended without being processed, so only an operator can bring it back
+func (e EventState) IsDeadLetter() bool {
+	switch e {
+	case EventStateFailed, EventStateUnprocessable, EventStateAborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFinished reports whether the event was processed or ended without being processed,
+// its state doesn't change anymore unless an operator requeues it
+func (e EventState) IsFinished() bool {
+	return e == EventStateCompleted || e.IsDeadLetter()
+}
+
+// CanRequeue reports whether the event can be requeued to be processed again
+func (e EventState) CanRequeue() bool {
+	return e.IsDeadLetter()
+}
+
+// CanAbort reports whether the event can be aborted, an event being processed or already finished can't be aborted
+func (e EventState) CanAbort() bool {
+	switch e {
+	case EventStateReady, EventStateFailed, EventStateUnprocessable:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,67 @@
+// Package eventtest provides the helpers testing the domain events of the bounded contexts
+package eventtest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stretchr/testify/require"
+)
+
+// GoldenPayload is a payload stored in an old or the current type version, with the payload of the event it's decoded into
+type GoldenPayload struct {
+	Data  json.RawMessage `json:"data"`  // Payload as it's stored in the events table in the type version
+	Event json.RawMessage `json:"event"` // Payload of the domain event decoded from the data, in the current type version
+}
+
+// RequireGoldenPayloads decodes the golden payloads of every event of the origin in every type version it was recorded in,
+// testdata/golden/<event type>/<type version>.json, with the registry of the domain events. The current type versions
+// are keyed by the event type, each of them needs its golden payload and the payloads of the old ones are upcast to it.
+// A change of the payload schema breaks it until the change gets a new type version with the upcaster from the previous one,
+// and the golden payload of the new type version is added.
+func RequireGoldenPayloads(t *testing.T, registry *event.Registry, origin string, currentVersions map[string]string) {
+	t.Helper()
+
+	for eventType, typeVersion := range currentVersions {
+		require.FileExists(t, filepath.Join("testdata", "golden", eventType, typeVersion+".json"))
+	}
+
+	files, err := filepath.Glob(filepath.Join("testdata", "golden", "*", "*.json"))
+	require.NoError(t, err)
+
+	for _, file := range files {
+		eventType := filepath.Base(filepath.Dir(file))
+		typeVersion := strings.TrimSuffix(filepath.Base(file), ".json")
+
+		t.Run(eventType+"@"+typeVersion, func(t *testing.T) {
+			currentVersion, ok := currentVersions[eventType]
+			require.True(t, ok, "golden payload of unknown %s event type", eventType)
+
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			var golden GoldenPayload
+			require.NoError(t, json.Unmarshal(content, &golden))
+
+			decoded, err := registry.Decode(&event.BaseEvent{
+				ID:          uuid.New(),
+				ContextID:   uuid.New(),
+				Origin:      origin,
+				Type:        eventType,
+				TypeVersion: typeVersion,
+				Data:        golden.Data,
+			})
+			require.NoError(t, err)
+			require.Equal(t, currentVersion, decoded.GetTypeVersion())
+
+			encoded, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			require.JSONEq(t, string(golden.Event), string(encoded))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package idempotency
+
+import (
+	"time"
+)
+
+// Record is the idempotency key claimed by a mutating request, it keeps the response to the request once it's completed
+type Record struct {
+	Key         string    // Key sent by the client in the Idempotency-Key header
+	Fingerprint string    // Fingerprint of the request, the key reused for another request is rejected
+	Response    *Response // Response to the request, nil while the request is in progress
+	CreatedAt   time.Time // When the key was claimed
+	LockedUntil time.Time // The request still in progress after that time is considered abandoned, its key can be claimed again
+	ExpiresAt   time.Time // The key can be reused for another request after that time
+}
+
+// InProgress reports whether the request which claimed the key hasn't completed yet
+func (r Record) InProgress() bool {
+	return r.Response == nil
+}
+
+// Response is the response to the request, replayed to the request retried with the same key
+type Response struct {
+	StatusCode int                 // Status code of the response
+	Header     map[string][]string // Headers of the response
+	Body       []byte              // Body of the response
+}
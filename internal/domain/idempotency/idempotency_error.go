@@ -0,0 +1,13 @@
+package idempotency
+
+import (
+	"errors"
+)
+
+// Idempotency errors
+var (
+	// ErrKeyNotFound is returned when the idempotency key wasn't claimed or has already been purged
+	ErrKeyNotFound = errors.New("idempotency key not found")
+	// ErrKeyAlreadyClaimed is returned when the idempotency key is held by another request, in progress or completed
+	ErrKeyAlreadyClaimed = errors.New("idempotency key already claimed")
+)
@@ -0,0 +1,72 @@
+package identity
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Role is the role the principal acts in, the routes of the API are allowed to the roles by the policy of the router
+type Role string
+
+const (
+	// RoleTeller is the role of the branch employee serving the customers, i.e. opening accounts, deposits and withdrawals
+	RoleTeller Role = "teller"
+	// RoleBackOffice is the role of the operations staff, i.e. closing accounts, deleting customers and repairing the events
+	RoleBackOffice Role = "back-office"
+	// RoleAuditor is the role of the staff reviewing the accounts, customers and events, it's read only
+	RoleAuditor Role = "auditor"
+	// RoleCustomer is the role of the customer calling the API on their own behalf
+	RoleCustomer Role = "customer"
+)
+
+// Roles returns all the known roles
+func Roles() []Role {
+	return []Role{RoleTeller, RoleBackOffice, RoleAuditor, RoleCustomer}
+}
+
+// Valid reports whether the role is known
+func (r Role) Valid() bool {
+	return slices.Contains(Roles(), r)
+}
+
+const (
+	// PrincipalTypeUser is the type of the person authenticated with the token, i.e. the teller or the customer
+	PrincipalTypeUser = "user"
+	// PrincipalTypeService is the type of the service authenticated with the API key, i.e. the service to service call
+	PrincipalTypeService = "service"
+)
+
+// Principal is the authenticated caller of the API
+type Principal struct {
+	ID         string    // ID of the user (the subject of the token) or the name of the service (the API key)
+	Type       string    // Type of the principal, i.e. user, service
+	Roles      []Role    // Roles the principal acts in
+	CustomerID uuid.UUID // ID of the customer the principal with the customer role acts for, uuid.Nil for the staff and the services
+}
+
+// HasAnyRole reports whether the principal acts in any of the roles
+func (p Principal) HasAnyRole(roles ...Role) bool {
+	for _, role := range roles {
+		if slices.Contains(p.Roles, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// principalContextKey is the key of the principal in the request context
+type principalContextKey struct{}
+
+// ContextWithPrincipal returns the context carrying the authenticated principal
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal carried by the context, it reports false when the caller wasn't authenticated
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,43 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ResourceTypeAccount is the type of the account owned by the customer
+	ResourceTypeAccount = "account"
+	// ResourceTypeCustomer is the type of the customer, the customer owns themselves and all their accounts
+	ResourceTypeCustomer = "customer"
+)
+
+// Resource is the resource owned by the customer, i.e. the account
+type Resource struct {
+	Type       string    // Type of the resource, i.e. account
+	ID         uuid.UUID // ID of the resource
+	CustomerID uuid.UUID // ID of the customer owning the resource
+}
+
+// Owns reports whether the principal is the customer owning the resource
+func (p Principal) Owns(resource Resource) bool {
+	return p.HasAnyRole(RoleCustomer) && p.CustomerID != uuid.Nil && p.CustomerID == resource.CustomerID
+}
+
+// OverridesOwnership reports whether the principal acts in the staff role, which may access the resources of any customer.
+// Every access overriding the ownership is audited.
+func (p Principal) OverridesOwnership() bool {
+	return p.HasAnyRole(RoleTeller, RoleBackOffice, RoleAuditor)
+}
+
+// AccessAudit records the access of the staff to the resource of the customer, the ownership of which the staff role overrode
+type AccessAudit struct {
+	ID            uuid.UUID // ID of the audit record
+	PrincipalID   string    // ID of the principal who accessed the resource
+	PrincipalType string    // Type of the principal, i.e. user, service
+	Roles         []Role    // Roles the principal acted in
+	Resource      Resource  // Resource accessed
+	CorrelationID uuid.UUID // Correlation ID of the request which accessed the resource, uuid.Nil when it's unknown
+	CreatedAt     time.Time // When the resource was accessed
+}
@@ -0,0 +1,53 @@
+//go:build unit
+
+package identity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Principal_Owns(t *testing.T) {
+	customerID := uuid.New()
+	resource := Resource{Type: ResourceTypeAccount, ID: uuid.New(), CustomerID: customerID}
+
+	tests := []struct {
+		name      string
+		principal Principal
+		expected  bool
+	}{
+		{
+			name:      "should own resource of the customer",
+			principal: Principal{ID: "customer-1", Roles: []Role{RoleCustomer}, CustomerID: customerID},
+			expected:  true,
+		},
+		{
+			name:      "shouldn't own resource of another customer",
+			principal: Principal{ID: "customer-2", Roles: []Role{RoleCustomer}, CustomerID: uuid.New()},
+		},
+		{
+			name:      "shouldn't own resource without customer role",
+			principal: Principal{ID: "teller-1", Roles: []Role{RoleTeller}, CustomerID: customerID},
+		},
+		{
+			name:      "shouldn't own resource without customer id",
+			principal: Principal{ID: "customer-1", Roles: []Role{RoleCustomer}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.principal.Owns(resource))
+		})
+	}
+}
+
+func Test_Principal_OverridesOwnership(t *testing.T) {
+	require.True(t, Principal{Roles: []Role{RoleTeller}}.OverridesOwnership())
+	require.True(t, Principal{Roles: []Role{RoleBackOffice}}.OverridesOwnership())
+	require.True(t, Principal{Roles: []Role{RoleAuditor}}.OverridesOwnership())
+	require.False(t, Principal{Roles: []Role{RoleCustomer}}.OverridesOwnership())
+	require.False(t, Principal{}.OverridesOwnership())
+}
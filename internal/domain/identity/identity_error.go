@@ -0,0 +1,17 @@
+package identity
+
+import (
+	"errors"
+)
+
+// Identity errors
+var (
+	// ErrUnauthenticated is returned when the request doesn't carry the credentials the route requires
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrInvalidCredentials is returned when the token or the API key isn't valid, i.e. it's expired or its signature doesn't match
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrForbidden is returned when the principal doesn't act in any of the roles the route is allowed to
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotOwner is returned when the principal neither owns the resource nor acts in the staff role overriding the ownership
+	ErrNotOwner = errors.New("resource not owned by the principal")
+)
@@ -0,0 +1,62 @@
+//go:build unit
+
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Principal_HasAnyRole(t *testing.T) {
+	type testCaseParams struct {
+		principal Principal
+		roles     []Role
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected bool
+	}{
+		{
+			name: "should have role the principal acts in",
+			params: testCaseParams{
+				principal: Principal{Roles: []Role{RoleTeller, RoleAuditor}},
+				roles:     []Role{RoleBackOffice, RoleAuditor},
+			},
+			expected: true,
+		},
+		{
+			name: "shouldn't have role the principal doesn't act in",
+			params: testCaseParams{
+				principal: Principal{Roles: []Role{RoleCustomer}},
+				roles:     []Role{RoleTeller, RoleBackOffice},
+			},
+		},
+		{
+			name: "shouldn't have any role without roles",
+			params: testCaseParams{
+				principal: Principal{Roles: []Role{RoleTeller}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.params.principal.HasAnyRole(tt.params.roles...))
+		})
+	}
+}
+
+func Test_PrincipalFromContext(t *testing.T) {
+	_, ok := PrincipalFromContext(context.Background())
+	require.False(t, ok)
+
+	principal := Principal{ID: "teller-1", Type: PrincipalTypeUser, Roles: []Role{RoleTeller}}
+
+	got, ok := PrincipalFromContext(ContextWithPrincipal(context.Background(), principal))
+	require.True(t, ok)
+	require.Equal(t, principal, got)
+}
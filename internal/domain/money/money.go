@@ -0,0 +1,229 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money represents an exact amount of money in a currency.
+// The amount is kept as an integer number of the currency minor units, i.e. cents for USD,
+// so it never suffers from the floating point rounding.
+type Money struct {
+	amount   int64    // Amount in the currency minor units
+	currency Currency // Currency of the amount
+}
+
+// New creates money from the amount expressed in the currency minor units, i.e. New(1050, "USD") is 10.50 USD
+func New(amount int64, currency Currency) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, fmt.Errorf("creating money in %q: %w", currency, ErrUnknownCurrency)
+	}
+
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// Zero creates zero money in the currency
+func Zero(currency Currency) Money {
+	return Money{amount: 0, currency: currency}
+}
+
+// Parse creates money from the decimal amount, i.e. "10.50".
+// The amount must not have more fractional digits than the currency minor units.
+func Parse(value string, currency Currency) (Money, error) {
+	return parse(value, currency, nil)
+}
+
+// ParseRounded creates money from the decimal amount, rounding it to the currency minor units with the mode
+func ParseRounded(value string, currency Currency, mode RoundingMode) (Money, error) {
+	return parse(value, currency, &mode)
+}
+
+// parse creates money from the decimal amount, digits beyond the currency minor units are rounded
+// when the rounding mode is given, rejected otherwise
+func parse(value string, currency Currency, mode *RoundingMode) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, fmt.Errorf("parsing money in %q: %w", currency, ErrUnknownCurrency)
+	}
+
+	digits := strings.TrimSpace(value)
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(strings.TrimPrefix(digits, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if (whole == "" && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("parsing money %q: %w", value, ErrInvalidAmount)
+	}
+
+	units := currency.MinorUnits()
+
+	var remainder string
+	if len(fraction) > units {
+		fraction, remainder = fraction[:units], fraction[units:]
+	}
+
+	if strings.Trim(remainder, "0") != "" && mode == nil {
+		return Money{}, fmt.Errorf("parsing money %q in %s: %w", value, currency, ErrInvalidPrecision)
+	}
+
+	fraction += strings.Repeat("0", units-len(fraction))
+
+	amount, err := strconv.ParseInt("0"+whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("parsing money %q: %w", value, ErrOverflow)
+	}
+
+	if mode != nil && roundsUp(amount, remainder, *mode) {
+		if amount == math.MaxInt64 {
+			return Money{}, fmt.Errorf("parsing money %q: %w", value, ErrOverflow)
+		}
+		amount++
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// roundsUp checks if the absolute amount is rounded away from zero by the discarded digits
+func roundsUp(amount int64, remainder string, mode RoundingMode) bool {
+	if strings.Trim(remainder, "0") == "" {
+		return false
+	}
+
+	switch mode {
+	case RoundHalfUp:
+		return remainder[0] >= '5'
+	case RoundHalfEven:
+		if remainder[0] != '5' {
+			return remainder[0] > '5'
+		}
+
+		if strings.Trim(remainder[1:], "0") != "" {
+			return true
+		}
+
+		return amount%2 != 0
+	default:
+		return false
+	}
+}
+
+// isDigits checks if the value consists of decimal digits only
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Amount returns the amount in the currency minor units
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the currency of the amount
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero checks if the amount is zero
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive checks if the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative checks if the amount is lower than zero
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Add returns the sum of both amounts, which must be in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, fmt.Errorf("adding %s to %s: %w", other.currency, m.currency, ErrCurrencyMismatch)
+	}
+
+	if (other.amount > 0 && m.amount > math.MaxInt64-other.amount) ||
+		(other.amount < 0 && m.amount < math.MinInt64-other.amount) {
+		return Money{}, fmt.Errorf("adding %s to %s: %w", other, m, ErrOverflow)
+	}
+
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+// Sub returns the difference of both amounts, which must be in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, fmt.Errorf("subtracting %s from %s: %w", other.currency, m.currency, ErrCurrencyMismatch)
+	}
+
+	if (other.amount < 0 && m.amount > math.MaxInt64+other.amount) ||
+		(other.amount > 0 && m.amount < math.MinInt64+other.amount) {
+		return Money{}, fmt.Errorf("subtracting %s from %s: %w", other, m, ErrOverflow)
+	}
+
+	return Money{amount: m.amount - other.amount, currency: m.currency}, nil
+}
+
+// Cmp compares both amounts, which must be in the same currency.
+// It returns -1 when the amount is lower than the other, 0 when they're equal and +1 otherwise.
+func (m Money) Cmp(other Money) (int, error) {
+	if m.currency != other.currency {
+		return 0, fmt.Errorf("comparing %s with %s: %w", m.currency, other.currency, ErrCurrencyMismatch)
+	}
+
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Decimal returns the amount as a decimal number with all the currency minor units, i.e. "10.50"
+func (m Money) Decimal() string {
+	units := m.currency.MinorUnits()
+
+	abs := strconv.FormatUint(absUint(m.amount), 10)
+	if len(abs) <= units {
+		abs = strings.Repeat("0", units-len(abs)+1) + abs
+	}
+
+	sign := ""
+	if m.amount < 0 {
+		sign = "-"
+	}
+
+	if units == 0 {
+		return sign + abs
+	}
+
+	return sign + abs[:len(abs)-units] + "." + abs[len(abs)-units:]
+}
+
+// String returns the amount with the currency, i.e. "10.50 USD"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currency.String()
+}
+
+// absUint returns the absolute value of the amount, math.MinInt64 included
+func absUint(amount int64) uint64 {
+	if amount < 0 {
+		return uint64(-(amount + 1)) + 1
+	}
+
+	return uint64(amount)
+}
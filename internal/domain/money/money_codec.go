@@ -0,0 +1,60 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// moneyJSON is the JSON representation of money.
+// The amount is a decimal string, so it's never read as a floating point number by clients.
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes money as {"amount":"10.50","currency":"USD"}, money without currency is encoded as null
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.currency == "" {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(moneyJSON{
+		Amount:   m.Decimal(),
+		Currency: m.currency.String(),
+	})
+}
+
+// UnmarshalJSON decodes money from {"amount":"10.50","currency":"USD"}
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("decoding money: %w", err)
+	}
+
+	currency, err := ParseCurrency(raw.Currency)
+	if err != nil {
+		return fmt.Errorf("decoding money currency %q: %w", raw.Currency, err)
+	}
+
+	money, err := Parse(raw.Amount, currency)
+	if err != nil {
+		return fmt.Errorf("decoding money: %w", err)
+	}
+
+	*m = money
+
+	return nil
+}
+
+// Value encodes money for the database as the amount in the currency minor units.
+// The currency is stored in its own column next to the amount.
+func (m Money) Value() (driver.Value, error) {
+	return m.amount, nil
+}
@@ -0,0 +1,44 @@
+//go:build unit
+
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Money_JSON(t *testing.T) {
+	money, err := New(1050, "USD")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(money)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"10.50","currency":"USD"}`, string(data))
+
+	var restored Money
+	require.NoError(t, json.Unmarshal(data, &restored))
+	require.Equal(t, money, restored)
+
+	data, err = json.Marshal(Money{})
+	require.NoError(t, err)
+	require.Equal(t, "null", string(data))
+
+	restored = money
+	require.NoError(t, json.Unmarshal([]byte("null"), &restored))
+	require.Equal(t, Money{}, restored)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"amount":"10.505","currency":"USD"}`), &restored), ErrInvalidPrecision)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"amount":"10.50","currency":"XXX"}`), &restored), ErrUnknownCurrency)
+	require.Error(t, json.Unmarshal([]byte(`{"amount":10.50,"currency":"USD"}`), &restored))
+}
+
+func Test_Money_Value(t *testing.T) {
+	money, err := New(-1050, "EUR")
+	require.NoError(t, err)
+
+	value, err := money.Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1050), value)
+}
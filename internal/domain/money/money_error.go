@@ -0,0 +1,19 @@
+package money
+
+import (
+	"errors"
+)
+
+// Money errors
+var (
+	// ErrUnknownCurrency is returned when the currency is not an ISO 4217 code known to the registry
+	ErrUnknownCurrency = errors.New("unknown currency")
+	// ErrCurrencyMismatch is returned when an operation mixes amounts in different currencies
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrInvalidAmount is returned when the amount isn't a valid decimal number
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrInvalidPrecision is returned when the amount has more fractional digits than the currency minor units
+	ErrInvalidPrecision = errors.New("amount precision exceeds currency minor units")
+	// ErrOverflow is returned when the amount doesn't fit into the minor units range
+	ErrOverflow = errors.New("amount overflow")
+)
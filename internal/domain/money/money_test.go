@@ -0,0 +1,204 @@
+//go:build unit
+
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse(t *testing.T) {
+	type testCaseParams struct {
+		value    string
+		currency Currency
+	}
+
+	type testCaseExpected struct {
+		amount  int64
+		decimal string
+		err     error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name:     "should parse amount with cents",
+			params:   testCaseParams{value: "10.50", currency: "USD"},
+			expected: testCaseExpected{amount: 1050, decimal: "10.50"},
+		},
+		{
+			name:     "should parse amount without fraction",
+			params:   testCaseParams{value: "10", currency: "USD"},
+			expected: testCaseExpected{amount: 1000, decimal: "10.00"},
+		},
+		{
+			name:     "should parse amount with partial fraction",
+			params:   testCaseParams{value: "0.1", currency: "EUR"},
+			expected: testCaseExpected{amount: 10, decimal: "0.10"},
+		},
+		{
+			name:     "should parse negative amount",
+			params:   testCaseParams{value: "-0.05", currency: "USD"},
+			expected: testCaseExpected{amount: -5, decimal: "-0.05"},
+		},
+		{
+			name:     "should parse amount in currency without minor units",
+			params:   testCaseParams{value: "1500", currency: "JPY"},
+			expected: testCaseExpected{amount: 1500, decimal: "1500"},
+		},
+		{
+			name:     "should parse amount in currency with three minor units",
+			params:   testCaseParams{value: "1.005", currency: "KWD"},
+			expected: testCaseExpected{amount: 1005, decimal: "1.005"},
+		},
+		{
+			name:     "should accept trailing zeros beyond minor units",
+			params:   testCaseParams{value: "1.2500", currency: "USD"},
+			expected: testCaseExpected{amount: 125, decimal: "1.25"},
+		},
+		{
+			name:     "shouldn't parse amount - precision exceeds minor units",
+			params:   testCaseParams{value: "1.005", currency: "USD"},
+			expected: testCaseExpected{err: ErrInvalidPrecision},
+		},
+		{
+			name:     "shouldn't parse amount - not a number",
+			params:   testCaseParams{value: "1e3", currency: "USD"},
+			expected: testCaseExpected{err: ErrInvalidAmount},
+		},
+		{
+			name:     "shouldn't parse amount - empty",
+			params:   testCaseParams{value: "", currency: "USD"},
+			expected: testCaseExpected{err: ErrInvalidAmount},
+		},
+		{
+			name:     "shouldn't parse amount - unknown currency",
+			params:   testCaseParams{value: "1", currency: "XXX"},
+			expected: testCaseExpected{err: ErrUnknownCurrency},
+		},
+		{
+			name:     "shouldn't parse amount - overflow",
+			params:   testCaseParams{value: "92233720368547758.08", currency: "USD"},
+			expected: testCaseExpected{err: ErrOverflow},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			money, err := Parse(tt.params.value, tt.params.currency)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.amount, money.Amount())
+			require.Equal(t, tt.params.currency, money.Currency())
+			require.Equal(t, tt.expected.decimal, money.Decimal())
+		})
+	}
+}
+
+func Test_ParseRounded(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		mode   RoundingMode
+		amount int64
+	}{
+		{name: "half even - tie rounds to even down", value: "0.125", mode: RoundHalfEven, amount: 12},
+		{name: "half even - tie rounds to even up", value: "0.135", mode: RoundHalfEven, amount: 14},
+		{name: "half even - above tie rounds up", value: "0.1251", mode: RoundHalfEven, amount: 13},
+		{name: "half even - below tie rounds down", value: "0.1249", mode: RoundHalfEven, amount: 12},
+		{name: "half even - negative tie rounds to even", value: "-0.135", mode: RoundHalfEven, amount: -14},
+		{name: "half up - tie rounds away from zero", value: "0.125", mode: RoundHalfUp, amount: 13},
+		{name: "half up - negative tie rounds away from zero", value: "-0.125", mode: RoundHalfUp, amount: -13},
+		{name: "half up - below tie rounds down", value: "0.1249", mode: RoundHalfUp, amount: 12},
+		{name: "down - truncates", value: "0.129", mode: RoundDown, amount: 12},
+		{name: "down - negative truncates towards zero", value: "-0.129", mode: RoundDown, amount: -12},
+		{name: "exact amount isn't rounded", value: "0.12", mode: RoundHalfUp, amount: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			money, err := ParseRounded(tt.value, "USD", tt.mode)
+			require.NoError(t, err)
+			require.Equal(t, tt.amount, money.Amount())
+		})
+	}
+}
+
+func Test_Money_Arithmetic(t *testing.T) {
+	usd := func(amount int64) Money {
+		money, err := New(amount, "USD")
+		require.NoError(t, err)
+		return money
+	}
+
+	eur, err := New(100, "EUR")
+	require.NoError(t, err)
+
+	sum, err := usd(1050).Add(usd(25))
+	require.NoError(t, err)
+	require.Equal(t, usd(1075), sum)
+
+	difference, err := usd(1050).Sub(usd(1100))
+	require.NoError(t, err)
+	require.Equal(t, usd(-50), difference)
+	require.True(t, difference.IsNegative())
+
+	cmp, err := usd(10).Cmp(usd(20))
+	require.NoError(t, err)
+	require.Equal(t, -1, cmp)
+
+	cmp, err = usd(20).Cmp(usd(20))
+	require.NoError(t, err)
+	require.Equal(t, 0, cmp)
+
+	_, err = usd(100).Add(eur)
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = usd(100).Sub(eur)
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = usd(100).Cmp(eur)
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = usd(9223372036854775807).Add(usd(1))
+	require.ErrorIs(t, err, ErrOverflow)
+
+	_, err = usd(-9223372036854775808).Sub(usd(1))
+	require.ErrorIs(t, err, ErrOverflow)
+
+	_, err = New(100, "XXX")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func Test_Money_Decimal(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency Currency
+		expected string
+	}{
+		{amount: 0, currency: "USD", expected: "0.00"},
+		{amount: 5, currency: "USD", expected: "0.05"},
+		{amount: -5, currency: "USD", expected: "-0.05"},
+		{amount: 123456, currency: "USD", expected: "1234.56"},
+		{amount: 7, currency: "KWD", expected: "0.007"},
+		{amount: 7, currency: "JPY", expected: "7"},
+		{amount: -9223372036854775808, currency: "USD", expected: "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			money, err := New(tt.amount, tt.currency)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, money.Decimal())
+			require.Equal(t, tt.expected+" "+tt.currency.String(), money.String())
+		})
+	}
+}
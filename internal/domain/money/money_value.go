@@ -0,0 +1,61 @@
+package money
+
+// Currency represents an ISO 4217 currency code, e.g. USD, EUR
+type Currency string
+
+// currencyMinorUnits is the registry of supported currencies with the number of their minor units (ISO 4217 exponent)
+var currencyMinorUnits = map[Currency]int{
+	"AUD": 2,
+	"BHD": 3,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"CZK": 2,
+	"DKK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HUF": 2,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"NOK": 2,
+	"PLN": 2,
+	"SEK": 2,
+	"USD": 2,
+}
+
+// ParseCurrency returns the currency for the ISO 4217 code
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(code)
+	if !currency.IsValid() {
+		return "", ErrUnknownCurrency
+	}
+
+	return currency, nil
+}
+
+// String returns the ISO 4217 code of the currency
+func (c Currency) String() string {
+	return string(c)
+}
+
+// IsValid checks if the currency is known to the registry
+func (c Currency) IsValid() bool {
+	_, ok := currencyMinorUnits[c]
+	return ok
+}
+
+// MinorUnits returns the number of decimal places of the currency minor unit, i.e. 2 for USD cents
+func (c Currency) MinorUnits() int {
+	return currencyMinorUnits[c]
+}
+
+// RoundingMode defines how an amount with more fractional digits than the currency minor units is rounded
+type RoundingMode int
+
+const (
+	RoundHalfEven RoundingMode = iota // Round to the nearest minor unit, ties to the even one (banker's rounding)
+	RoundHalfUp                       // Round to the nearest minor unit, ties away from zero
+	RoundDown                         // Truncate the digits beyond the minor unit, towards zero
+)
@@ -1 +1,174 @@
 package transaction
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+)
+
+type EventOrigin = event.EventOrigin
+
+// Transaction represents a money transfer between two accounts.
+// It is an aggregate root driven step by step by the transfer saga run in the orchestrator.
+type Transaction struct {
+	ID uuid.UUID // Unique identifier of the transaction, must be in UUID format
+	Transfer
+	Status        TransactionStatus // Current status of the transaction
+	FailureReason string            // Reason of the failure or compensation (if applicable)
+	CreatedAt     time.Time         // When the transaction was created
+	UpdatedAt     time.Time         // When the transaction was last updated
+	events        []Event           // List of domain events that occurred on this transaction
+}
+
+// NewTransfer creates a new transaction moving the amount from the source to the target account.
+// It sets the transaction status to initiated and records the initiation event which starts the saga.
+func NewTransfer(id, sourceAccountID, targetAccountID uuid.UUID, amount money.Money) (*Transaction, error) {
+	if !amount.IsPositive() {
+		return nil, ErrTransactionInvalidAmount
+	}
+
+	if sourceAccountID == targetAccountID {
+		return nil, ErrTransactionSameAccount
+	}
+
+	now := time.Now().UTC()
+	transaction := &Transaction{
+		ID: id,
+		Transfer: Transfer{
+			SourceAccountID: sourceAccountID,
+			TargetAccountID: targetAccountID,
+			Amount:          amount,
+		},
+		Status:    TransactionStatusInitiated,
+		CreatedAt: now,
+		UpdatedAt: now,
+		events:    make([]Event, 0),
+	}
+
+	transaction.addEvent(&TransactionInitiatedEvent{
+		BaseEvent: transaction.newBaseEvent(TransactionInitiatedEventType, now),
+		Transfer:  transaction.Transfer,
+	})
+
+	return transaction, nil
+}
+
+// ReserveFunds marks the funds as withdrawn from the source account.
+// It records the reservation event which triggers crediting of the target account.
+func (t *Transaction) ReserveFunds() error {
+	if t.Status != TransactionStatusInitiated {
+		return fmt.Errorf("reserving funds in %s state: %w", t.Status, ErrTransactionInvalidState)
+	}
+
+	now := time.Now().UTC()
+	t.Status = TransactionStatusReserved
+	t.UpdatedAt = now
+
+	t.addEvent(&TransactionFundsReservedEvent{
+		BaseEvent: t.newBaseEvent(TransactionFundsReservedEventType, now),
+		Transfer:  t.Transfer,
+	})
+
+	return nil
+}
+
+// Complete marks the funds as deposited into the target account, which finishes the saga.
+func (t *Transaction) Complete() error {
+	if t.Status != TransactionStatusReserved {
+		return fmt.Errorf("completing transaction in %s state: %w", t.Status, ErrTransactionInvalidState)
+	}
+
+	now := time.Now().UTC()
+	t.Status = TransactionStatusCompleted
+	t.UpdatedAt = now
+
+	t.addEvent(&TransactionCompletedEvent{
+		BaseEvent: t.newBaseEvent(TransactionCompletedEventType, now),
+		Transfer:  t.Transfer,
+	})
+
+	return nil
+}
+
+// Fail marks the transaction as failed when the funds couldn't be reserved.
+// No money was moved, so there is nothing to compensate.
+func (t *Transaction) Fail(reason string) error {
+	if t.Status != TransactionStatusInitiated {
+		return fmt.Errorf("failing transaction in %s state: %w", t.Status, ErrTransactionInvalidState)
+	}
+
+	now := time.Now().UTC()
+	t.Status = TransactionStatusFailed
+	t.FailureReason = reason
+	t.UpdatedAt = now
+
+	t.addEvent(&TransactionFailedEvent{
+		BaseEvent: t.newBaseEvent(TransactionFailedEventType, now),
+		Transfer:  t.Transfer,
+		Reason:    reason,
+	})
+
+	return nil
+}
+
+// Compensate marks the reserved funds as returned to the source account
+// when they couldn't be credited to the target account.
+func (t *Transaction) Compensate(reason string) error {
+	if t.Status != TransactionStatusReserved {
+		return fmt.Errorf("compensating transaction in %s state: %w", t.Status, ErrTransactionInvalidState)
+	}
+
+	now := time.Now().UTC()
+	t.Status = TransactionStatusCompensated
+	t.FailureReason = reason
+	t.UpdatedAt = now
+
+	t.addEvent(&TransactionCompensatedEvent{
+		BaseEvent: t.newBaseEvent(TransactionCompensatedEventType, now),
+		Transfer:  t.Transfer,
+		Reason:    reason,
+	})
+
+	return nil
+}
+
+// GetEvents returns all domain events that have occurred on this transaction.
+func (t *Transaction) GetEvents() []Event {
+	return t.events
+}
+
+// ClearEvents removes all recorded events from the transaction.
+// This is typically called after events have been processed.
+func (t *Transaction) ClearEvents() {
+	t.events = make([]Event, 0)
+}
+
+// addEvent is an internal method to record a new domain event.
+func (t *Transaction) addEvent(event Event) {
+	t.events = append(t.events, event)
+}
+
+// newBaseEvent creates the base of a transaction event.
+// The event ID is derived from the transaction ID and the saga step, so each step
+// can be recorded only once even when the orchestrator retries it.
+func (t *Transaction) newBaseEvent(eventType TransactionEventType, now time.Time) event.BaseEvent {
+	origin := EventOrigin("transaction")
+
+	return event.BaseEvent{
+		ID:          uuid.NewSHA1(t.ID, []byte(eventType.sagaStep())),
+		ContextID:   t.ID,
+		Origin:      origin.String(),
+		Type:        eventType.String(),
+		TypeVersion: eventTypeVersion,
+		State:       event.EventStateReady.String(),
+		CreatedAt:   now,
+		ScheduledAt: now,
+		Retry:       0,
+		MaxRetry:    event.DefaultMaxRetry,
+		Data:        nil,
+	}
+}
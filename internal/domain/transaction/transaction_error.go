@@ -0,0 +1,19 @@
+package transaction
+
+import (
+	"errors"
+)
+
+// Transaction errors
+var (
+	// ErrTransactionNotFound is returned when a transaction is not found
+	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrTransactionAlreadyExists is returned when a transaction already exists
+	ErrTransactionAlreadyExists = errors.New("transaction already exists")
+	// ErrTransactionInvalidAmount is returned when the transferred amount is not positive
+	ErrTransactionInvalidAmount = errors.New("invalid transaction amount")
+	// ErrTransactionSameAccount is returned when the source and target accounts are the same
+	ErrTransactionSameAccount = errors.New("source and target accounts are the same")
+	// ErrTransactionInvalidState is returned when a saga step is applied in a wrong transaction state
+	ErrTransactionInvalidState = errors.New("invalid transaction state")
+)
@@ -0,0 +1,55 @@
+package transaction
+
+import (
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+// TransactionInitiatedEvent is emitted when a transfer between two accounts is requested
+type TransactionInitiatedEvent struct {
+	event.BaseEvent `json:"-"`
+	Transfer
+}
+
+// TransactionFundsReservedEvent is emitted when the funds were withdrawn from the source account
+type TransactionFundsReservedEvent struct {
+	event.BaseEvent `json:"-"`
+	Transfer
+}
+
+// TransactionCompletedEvent is emitted when the funds were deposited into the target account
+type TransactionCompletedEvent struct {
+	event.BaseEvent `json:"-"`
+	Transfer
+}
+
+// TransactionFailedEvent is emitted when the funds couldn't be reserved on the source account
+type TransactionFailedEvent struct {
+	event.BaseEvent `json:"-"`
+	Transfer
+	Reason string `json:"reason"` // The reason the transaction failed
+}
+
+// TransactionCompensatedEvent is emitted when the reserved funds were returned to the source account
+type TransactionCompensatedEvent struct {
+	event.BaseEvent `json:"-"`
+	Transfer
+	Reason string `json:"reason"` // The reason the transaction was compensated
+}
+
+// RegisterEvents registers the transaction events, so they can be decoded from the events table
+func RegisterEvents(r *event.Registry) {
+	r.Register(TransactionInitiatedEventType.String(), eventTypeVersion, func() event.Event { return &TransactionInitiatedEvent{} })
+	r.Register(TransactionFundsReservedEventType.String(), eventTypeVersion, func() event.Event { return &TransactionFundsReservedEvent{} })
+	r.Register(TransactionCompletedEventType.String(), eventTypeVersion, func() event.Event { return &TransactionCompletedEvent{} })
+	r.Register(TransactionFailedEventType.String(), eventTypeVersion, func() event.Event { return &TransactionFailedEvent{} })
+	r.Register(TransactionCompensatedEventType.String(), eventTypeVersion, func() event.Event { return &TransactionCompensatedEvent{} })
+}
+
+// RegisterRetryPolicies registers the retry policy of the transaction events.
+// A saga step which doesn't fit the transaction status can't be applied by retrying it, so the error fails the event at once.
+func RegisterRetryPolicies(r *event.RetryPolicies) {
+	policy := event.DefaultRetryPolicy()
+	policy.Terminal = append(policy.Terminal, ErrTransactionInvalidState)
+
+	r.RegisterOrigin(EventOrigin("transaction").String(), policy)
+}
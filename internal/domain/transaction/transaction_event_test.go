@@ -0,0 +1,24 @@
+//go:build unit
+
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event/eventtest"
+)
+
+// Test_TransactionEvents_Golden decodes the golden payloads of every transaction event in every type version it was recorded in
+func Test_TransactionEvents_Golden(t *testing.T) {
+	registry := event.NewRegistry()
+	RegisterEvents(registry)
+
+	eventtest.RequireGoldenPayloads(t, registry, "transaction", map[string]string{
+		TransactionInitiatedEventType.String():     eventTypeVersion,
+		TransactionFundsReservedEventType.String(): eventTypeVersion,
+		TransactionCompletedEventType.String():     eventTypeVersion,
+		TransactionFailedEventType.String():        eventTypeVersion,
+		TransactionCompensatedEventType.String():   eventTypeVersion,
+	})
+}
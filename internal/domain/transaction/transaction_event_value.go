@@ -0,0 +1,34 @@
+package transaction
+
+// TransactionEventType represents the type of transaction event
+type TransactionEventType string
+
+// String returns the string representation of the transaction event type
+func (e TransactionEventType) String() string {
+	return string(e)
+}
+
+const (
+	TransactionInitiatedEventType     TransactionEventType = "transaction.initiated"
+	TransactionFundsReservedEventType TransactionEventType = "transaction.funds.reserved"
+
+	TransactionCompletedEventType   TransactionEventType = "transaction.completed"
+	TransactionFailedEventType      TransactionEventType = "transaction.failed"
+	TransactionCompensatedEventType TransactionEventType = "transaction.compensated"
+)
+
+// eventTypeVersion is the version of the transaction events payload schema
+const eventTypeVersion = "0.0.0"
+
+// sagaStep returns the saga step concluded by the event type.
+// Events concluding the same step share their ID, so only one outcome of a step can be recorded.
+func (e TransactionEventType) sagaStep() string {
+	switch e {
+	case TransactionFundsReservedEventType, TransactionFailedEventType:
+		return "reserve"
+	case TransactionCompletedEventType, TransactionCompensatedEventType:
+		return "credit"
+	default:
+		return "initiate"
+	}
+}
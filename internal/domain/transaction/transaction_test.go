@@ -1,3 +1,254 @@
 //go:build unit
 
 package transaction
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+)
+
+func testMoney(t *testing.T, amount int64) money.Money {
+	m, err := money.New(amount, "USD")
+	require.NoError(t, err)
+
+	return m
+}
+
+func testTransfer(t *testing.T) *Transaction {
+	transaction, err := NewTransfer(uuid.New(), uuid.New(), uuid.New(), testMoney(t, 10000))
+	require.NoError(t, err)
+
+	return transaction
+}
+
+func Test_NewTransfer(t *testing.T) {
+	accountID := uuid.New()
+
+	type testCaseParams struct {
+		sourceAccountID uuid.UUID
+		targetAccountID uuid.UUID
+		amount          int64
+	}
+
+	type testCaseExpected struct {
+		err          error
+		eventsNumber int
+		eventType    string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should initiate new transfer",
+			params: testCaseParams{
+				sourceAccountID: uuid.New(),
+				targetAccountID: uuid.New(),
+				amount:          10000,
+			},
+			expected: testCaseExpected{
+				eventsNumber: 1,
+				eventType:    TransactionInitiatedEventType.String(),
+			},
+		},
+		{
+			name: "shouldn't initiate transfer - amount is not positive",
+			params: testCaseParams{
+				sourceAccountID: uuid.New(),
+				targetAccountID: uuid.New(),
+				amount:          0,
+			},
+			expected: testCaseExpected{
+				err: ErrTransactionInvalidAmount,
+			},
+		},
+		{
+			name: "shouldn't initiate transfer - amount is negative",
+			params: testCaseParams{
+				sourceAccountID: uuid.New(),
+				targetAccountID: uuid.New(),
+				amount:          -10000,
+			},
+			expected: testCaseExpected{
+				err: ErrTransactionInvalidAmount,
+			},
+		},
+		{
+			name: "shouldn't initiate transfer - source and target accounts are the same",
+			params: testCaseParams{
+				sourceAccountID: accountID,
+				targetAccountID: accountID,
+				amount:          10000,
+			},
+			expected: testCaseExpected{
+				err: ErrTransactionSameAccount,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+
+			transaction, err := NewTransfer(id, tt.params.sourceAccountID, tt.params.targetAccountID, testMoney(t, tt.params.amount))
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				require.Nil(t, transaction)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, TransactionStatusInitiated, transaction.Status)
+			require.Len(t, transaction.events, tt.expected.eventsNumber)
+			require.Equal(t, tt.expected.eventType, transaction.events[0].GetType())
+			require.Equal(t, id, transaction.events[0].GetContextID())
+		})
+	}
+}
+
+func Test_Transaction_SagaSteps(t *testing.T) {
+	type testCaseExpected struct {
+		status       TransactionStatus
+		eventsNumber int
+		eventType    string
+		err          error
+	}
+
+	tests := []struct {
+		name     string
+		steps    func(tr *Transaction) error
+		expected testCaseExpected
+	}{
+		{
+			name: "should reserve funds",
+			steps: func(tr *Transaction) error {
+				return tr.ReserveFunds()
+			},
+			expected: testCaseExpected{
+				status:       TransactionStatusReserved,
+				eventsNumber: 2,
+				eventType:    TransactionFundsReservedEventType.String(),
+			},
+		},
+		{
+			name: "should complete transaction after reservation",
+			steps: func(tr *Transaction) error {
+				require.NoError(t, tr.ReserveFunds())
+				return tr.Complete()
+			},
+			expected: testCaseExpected{
+				status:       TransactionStatusCompleted,
+				eventsNumber: 3,
+				eventType:    TransactionCompletedEventType.String(),
+			},
+		},
+		{
+			name: "should fail transaction before reservation",
+			steps: func(tr *Transaction) error {
+				return tr.Fail("insufficient funds")
+			},
+			expected: testCaseExpected{
+				status:       TransactionStatusFailed,
+				eventsNumber: 2,
+				eventType:    TransactionFailedEventType.String(),
+			},
+		},
+		{
+			name: "should compensate transaction after reservation",
+			steps: func(tr *Transaction) error {
+				require.NoError(t, tr.ReserveFunds())
+				return tr.Compensate("target account not found")
+			},
+			expected: testCaseExpected{
+				status:       TransactionStatusCompensated,
+				eventsNumber: 3,
+				eventType:    TransactionCompensatedEventType.String(),
+			},
+		},
+		{
+			name: "shouldn't complete transaction - funds weren't reserved",
+			steps: func(tr *Transaction) error {
+				return tr.Complete()
+			},
+			expected: testCaseExpected{
+				status:       TransactionStatusInitiated,
+				eventsNumber: 1,
+				eventType:    TransactionInitiatedEventType.String(),
+				err:          ErrTransactionInvalidState,
+			},
+		},
+		{
+			name: "shouldn't compensate transaction - funds weren't reserved",
+			steps: func(tr *Transaction) error {
+				return tr.Compensate("target account not found")
+			},
+			expected: testCaseExpected{
+				status:       TransactionStatusInitiated,
+				eventsNumber: 1,
+				eventType:    TransactionInitiatedEventType.String(),
+				err:          ErrTransactionInvalidState,
+			},
+		},
+		{
+			name: "shouldn't fail transaction - funds were already reserved",
+			steps: func(tr *Transaction) error {
+				require.NoError(t, tr.ReserveFunds())
+				return tr.Fail("insufficient funds")
+			},
+			expected: testCaseExpected{
+				status:       TransactionStatusReserved,
+				eventsNumber: 2,
+				eventType:    TransactionFundsReservedEventType.String(),
+				err:          ErrTransactionInvalidState,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := testTransfer(t)
+
+			err := tt.steps(transaction)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expected.status, transaction.Status)
+			require.Len(t, transaction.events, tt.expected.eventsNumber)
+			require.Equal(t, tt.expected.eventType, transaction.events[len(transaction.events)-1].GetType())
+		})
+	}
+}
+
+func Test_Transaction_StepEventIDsAreDeterministic(t *testing.T) {
+	id := uuid.New()
+	sourceAccountID := uuid.New()
+	targetAccountID := uuid.New()
+
+	first, err := NewTransfer(id, sourceAccountID, targetAccountID, testMoney(t, 10000))
+	require.NoError(t, err)
+	require.NoError(t, first.ReserveFunds())
+
+	second, err := NewTransfer(id, sourceAccountID, targetAccountID, testMoney(t, 10000))
+	require.NoError(t, err)
+	require.NoError(t, second.ReserveFunds())
+
+	require.Equal(t, first.events[0].GetID(), second.events[0].GetID())
+	require.Equal(t, first.events[1].GetID(), second.events[1].GetID())
+	require.NotEqual(t, first.events[0].GetID(), first.events[1].GetID())
+
+	// A retried step deciding a different outcome can't record it next to the first one
+	third, err := NewTransfer(id, sourceAccountID, targetAccountID, testMoney(t, 10000))
+	require.NoError(t, err)
+	require.NoError(t, third.Fail("insufficient funds"))
+
+	require.Equal(t, first.events[1].GetID(), third.events[1].GetID())
+}
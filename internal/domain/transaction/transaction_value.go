@@ -0,0 +1,48 @@
+package transaction
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+)
+
+// TransactionStatus represents the state of a transaction within the transfer saga
+type TransactionStatus string
+
+const (
+	TransactionStatusInitiated   TransactionStatus = "initiated"   // Transfer was requested, no money was moved yet
+	TransactionStatusReserved    TransactionStatus = "reserved"    // Funds were withdrawn from the source account
+	TransactionStatusCompleted   TransactionStatus = "completed"   // Funds were deposited into the target account
+	TransactionStatusFailed      TransactionStatus = "failed"      // Funds couldn't be reserved, no money was moved
+	TransactionStatusCompensated TransactionStatus = "compensated" // Funds couldn't be credited and were returned to the source account
+)
+
+func (s TransactionStatus) String() string {
+	return string(s)
+}
+
+// IsValid checks if the transaction status is valid
+func (s TransactionStatus) IsValid() bool {
+	switch s {
+	case TransactionStatusInitiated,
+		TransactionStatusReserved,
+		TransactionStatusCompleted,
+		TransactionStatusFailed,
+		TransactionStatusCompensated:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFinal checks if the transaction reached one of its final states
+func (s TransactionStatus) IsFinal() bool {
+	return s == TransactionStatusCompleted || s == TransactionStatusFailed || s == TransactionStatusCompensated
+}
+
+// Transfer describes the money movement between two accounts
+type Transfer struct {
+	SourceAccountID uuid.UUID   `json:"source_account_id"` // Account the money is taken from
+	TargetAccountID uuid.UUID   `json:"target_account_id"` // Account the money is credited to
+	Amount          money.Money `json:"amount"`            // Amount of money to transfer in the currency of both accounts
+}
@@ -0,0 +1,8 @@
+-- name: CreateAccessAudit :exec
+INSERT INTO access_audits (id, principal_id, principal_type, roles, resource_type, resource_id, customer_id, correlation_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: FindAccessAuditsByCustomerID :many
+SELECT * FROM access_audits
+WHERE customer_id = $1
+ORDER BY created_at, id;
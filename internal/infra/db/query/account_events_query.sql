@@ -1,8 +1,21 @@
--- name: CreateAccountEvent :one
-INSERT INTO events (id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, retry, max_retry, event_data)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-RETURNING *;
+-- name: CreateAccountEvents :copyfrom
+INSERT INTO events (id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, retry, max_retry, event_data, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18);
 
 -- name: FindAccountEventByID :one
 SELECT * FROM events
-WHERE id = $1 LIMIT 1;
\ No newline at end of file
+WHERE id = $1 LIMIT 1;
+-- name: FindAccountEventsByContextID :many
+SELECT * FROM events
+WHERE context_id = $1
+ORDER BY aggregate_version;
+
+-- name: FindAccountEventsByContextIDAfterVersion :many
+SELECT * FROM events
+WHERE context_id = $1 AND aggregate_version > $2
+ORDER BY aggregate_version;
+
+-- name: FindAccountIDs :many
+SELECT context_id FROM events
+WHERE event_type = 'account.created'
+ORDER BY sequence_number;
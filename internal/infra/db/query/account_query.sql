@@ -2,6 +2,10 @@
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: FindAccountByAccountNumber :one
+SELECT * FROM accounts
+WHERE account_number = $1 LIMIT 1;
+
 -- name: FindAccountsByCustomerID :many
 SELECT * FROM accounts
 WHERE customer_id = $1;
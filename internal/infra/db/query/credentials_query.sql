@@ -0,0 +1,24 @@
+-- name: FindCredentialsByCustomerID :one
+SELECT * FROM customer_credentials
+WHERE customer_id = $1 LIMIT 1;
+
+-- name: CreateCredentials :exec
+INSERT INTO customer_credentials (id, customer_id, password_hash, totp_secret, totp_enabled, totp_last_step, failed_attempts, locked_until, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: UpdateCredentials :execrows
+UPDATE customer_credentials
+SET password_hash = $2, totp_secret = $3, totp_enabled = $4, totp_last_step = $5, failed_attempts = $6, locked_until = $7, updated_at = $8, version = $9
+WHERE id = $1 AND version = sqlc.arg(expected_version);
+
+-- name: CountFailedLogin :one
+UPDATE customer_credentials
+SET failed_attempts = CASE WHEN sqlc.arg(max_failed_attempts)::INT > 0 AND failed_attempts + 1 >= sqlc.arg(max_failed_attempts)::INT THEN 0 ELSE failed_attempts + 1 END,
+    locked_until = CASE WHEN sqlc.arg(max_failed_attempts)::INT > 0 AND failed_attempts + 1 >= sqlc.arg(max_failed_attempts)::INT THEN sqlc.arg(locked_until)::TIMESTAMP ELSE locked_until END,
+    updated_at = sqlc.arg(updated_at), version = version + 1
+WHERE id = sqlc.arg(id)
+RETURNING failed_attempts, locked_until, version;
+
+-- name: CreateCredentialsEvents :copyfrom
+INSERT INTO events (id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, completed_at, retry, max_retry, event_data, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19);
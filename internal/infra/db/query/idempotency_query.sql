@@ -0,0 +1,35 @@
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (idempotency_key, fingerprint, created_at, locked_until, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (idempotency_key) DO UPDATE
+SET fingerprint = EXCLUDED.fingerprint,
+    status_code = NULL,
+    response_headers = '{}',
+    response_body = NULL,
+    created_at = EXCLUDED.created_at,
+    locked_until = EXCLUDED.locked_until,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+    OR (idempotency_keys.status_code IS NULL
+        AND idempotency_keys.locked_until <= EXCLUDED.created_at
+        AND idempotency_keys.fingerprint = EXCLUDED.fingerprint)
+RETURNING idempotency_key;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE idempotency_key = $1 AND status_code IS NULL;
+
+-- name: FindIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE idempotency_key = $1 LIMIT 1;
+
+-- name: SaveIdempotencyResponse :exec
+UPDATE idempotency_keys
+SET status_code = $2,
+    response_headers = $3,
+    response_body = $4
+WHERE idempotency_key = $1;
@@ -0,0 +1,28 @@
+-- name: CreateOutboxMessages :exec
+INSERT INTO outbox (event_id)
+SELECT id FROM events
+WHERE id = ANY(sqlc.arg(event_ids)::UUID[])
+ORDER BY sequence_number;
+
+-- name: FindOutboxMessagesAfter :many
+SELECT outbox.transaction_id, outbox.sequence_number, events.id, events.context_id, events.event_origin, events.event_type,
+    events.event_type_version, events.event_state, events.created_at, events.scheduled_at, events.aggregate_version, events.event_data,
+    events.event_metadata, events.correlation_id, events.causation_id, events.actor_id, events.actor_type, events.source_ip
+FROM outbox
+JOIN events ON events.id = outbox.event_id
+WHERE (outbox.transaction_id, outbox.sequence_number) > (sqlc.arg(transaction_id)::BIGINT, sqlc.arg(sequence_number)::BIGINT)
+    AND outbox.transaction_id < pg_snapshot_xmin(pg_current_snapshot())::TEXT::BIGINT
+ORDER BY outbox.transaction_id, outbox.sequence_number
+LIMIT (sqlc.arg('limit'));
+
+-- name: FindPublishCheckpoint :one
+SELECT * FROM publish_checkpoints
+WHERE publisher = $1 LIMIT 1;
+
+-- name: SavePublishCheckpoint :exec
+INSERT INTO publish_checkpoints (publisher, transaction_id, sequence_number, updated_at)
+VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+ON CONFLICT (publisher) DO UPDATE
+SET transaction_id = EXCLUDED.transaction_id,
+    sequence_number = EXCLUDED.sequence_number,
+    updated_at = EXCLUDED.updated_at;
@@ -0,0 +1,26 @@
+-- name: CreateSession :exec
+INSERT INTO customer_sessions (id, customer_id, access_token_hash, refresh_token_hash, mfa_pending, access_expires_at, refresh_expires_at, created_at, version)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: FindSessionByAccessTokenHash :one
+SELECT * FROM customer_sessions
+WHERE access_token_hash = $1 LIMIT 1;
+
+-- name: FindSessionByRefreshTokenHash :one
+SELECT * FROM customer_sessions
+WHERE refresh_token_hash = $1 AND refresh_token_hash <> '' LIMIT 1;
+
+-- name: UpdateSession :execrows
+UPDATE customer_sessions
+SET access_token_hash = $2, refresh_token_hash = $3, mfa_pending = $4, access_expires_at = $5, refresh_expires_at = $6, version = version + 1
+WHERE id = $1 AND version = $7 AND revoked_at IS NULL;
+
+-- name: RevokeSession :exec
+UPDATE customer_sessions
+SET revoked_at = $2, version = version + 1
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeCustomerSessions :exec
+UPDATE customer_sessions
+SET revoked_at = $2, version = version + 1
+WHERE customer_id = $1 AND revoked_at IS NULL;
@@ -0,0 +1,11 @@
+-- name: CreateSnapshot :exec
+INSERT INTO snapshots (context_id, aggregate_type, aggregate_version, snapshot_version, snapshot_data)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (context_id, aggregate_version) DO UPDATE
+SET snapshot_version = EXCLUDED.snapshot_version, snapshot_data = EXCLUDED.snapshot_data, created_at = CURRENT_TIMESTAMP;
+
+-- name: FindLatestSnapshot :one
+SELECT * FROM snapshots
+WHERE context_id = $1 AND aggregate_type = $2 AND snapshot_version = $3
+ORDER BY aggregate_version DESC
+LIMIT 1;
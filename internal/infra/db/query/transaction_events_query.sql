@@ -0,0 +1,8 @@
+-- name: CreateTransactionEvent :one
+INSERT INTO events (id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, retry, max_retry, event_data, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+RETURNING *;
+
+-- name: FindTransactionEventByID :one
+SELECT * FROM events
+WHERE id = $1 LIMIT 1;
@@ -0,0 +1,3 @@
+-- name: FindTransactionByID :one
+SELECT * FROM transactions
+WHERE id = $1 LIMIT 1;
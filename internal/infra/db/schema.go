@@ -0,0 +1,9 @@
+// Package db holds the database schema and the queries of the bank
+package db
+
+import "embed"
+
+// Schema holds the scripts creating the database schema, applied in the order of their names
+//
+//go:embed schema/*.sql
+var Schema embed.FS
@@ -3,7 +3,7 @@ CREATE TABLE IF NOT EXISTS events (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     context_id UUID NOT NULL,
     event_origin VARCHAR(25) NOT NULL,
-    event_type VARCHAR(25) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
     event_type_version VARCHAR(10) NOT NULL,
     event_state VARCHAR(25) NOT NULL,
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -12,7 +12,18 @@ CREATE TABLE IF NOT EXISTS events (
     completed_at TIMESTAMP,
     retry INT NOT NULL,
     max_retry INT NOT NULL,
-    event_data JSONB NOT NULL
+    event_data JSONB NOT NULL,
+    lease_expires_at TIMESTAMP, -- set when the event is claimed for processing, after it passes the event can be claimed again
+    sequence_number BIGSERIAL NOT NULL, -- order in which the events were recorded, the events of the same context are processed in this order
+    aggregate_version BIGINT NOT NULL, -- version of the aggregate (context) the event leads to, the first event of an aggregate has version 1
+    event_metadata JSONB NOT NULL DEFAULT '{}', -- metadata of the request which recorded the event, i.e. its idempotency key
+    correlation_id UUID, -- shared by the events recorded by a request and all the events following from them, i.e. the steps of a transfer saga
+    causation_id UUID, -- ID of the request or the event which caused the event
+    actor_id VARCHAR(255) NOT NULL DEFAULT '', -- who triggered the event, i.e. the user ID, empty for an anonymous actor
+    actor_type VARCHAR(25) NOT NULL DEFAULT '', -- kind of the actor, i.e. user, system, anonymous
+    source_ip VARCHAR(45) NOT NULL DEFAULT '', -- IP address the request recording the event came from
+    failure_reason TEXT NOT NULL DEFAULT '', -- error of the last failed attempt to process the event, cleared when the event is requeued
+    CONSTRAINT events_context_id_aggregate_version_key UNIQUE (context_id, aggregate_version) -- two commands can't append the same version of an aggregate
 );
 
 -- Create indexes for events table (draft)
@@ -20,3 +31,6 @@ CREATE INDEX IF NOT EXISTS idx_events_context_id ON events(context_id);
 CREATE INDEX IF NOT EXISTS idx_events_event_origin ON events(event_origin);
 CREATE INDEX IF NOT EXISTS idx_events_event_type ON events(event_type);
 CREATE INDEX IF NOT EXISTS idx_events_scheduled_at ON events(scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_events_event_state_scheduled_at ON events(event_state, scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_events_context_id_sequence_number ON events(context_id, sequence_number);
+CREATE INDEX IF NOT EXISTS idx_events_correlation_id ON events(correlation_id);
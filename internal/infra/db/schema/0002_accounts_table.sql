@@ -3,7 +3,7 @@ CREATE TABLE IF NOT EXISTS accounts (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     account_number VARCHAR(20) NOT NULL UNIQUE,
     customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
-    balance DOUBLE PRECISION NOT NULL DEFAULT 0,
+    balance BIGINT NOT NULL DEFAULT 0, -- in the currency minor units, i.e. cents
     currency VARCHAR(3) NOT NULL DEFAULT 'USD',
     status VARCHAR(20) NOT NULL DEFAULT 'active',
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
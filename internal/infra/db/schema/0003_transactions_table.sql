@@ -0,0 +1,16 @@
+-- Create transactions table which is the read model of the transfer saga
+CREATE TABLE IF NOT EXISTS transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_account_id UUID NOT NULL,
+    target_account_id UUID NOT NULL,
+    amount BIGINT NOT NULL, -- in the currency minor units, i.e. cents
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    failure_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for transactions table
+CREATE INDEX IF NOT EXISTS idx_transactions_source_account_id ON transactions(source_account_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_target_account_id ON transactions(target_account_id);
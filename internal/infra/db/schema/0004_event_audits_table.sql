@@ -0,0 +1,13 @@
+-- Create event_audits table which records the actions taken by operators on the events, i.e. requeue of a failed event
+CREATE TABLE IF NOT EXISTS event_audits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL,
+    action VARCHAR(25) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    reason TEXT NOT NULL,
+    previous_state VARCHAR(25) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for event_audits table
+CREATE INDEX IF NOT EXISTS idx_event_audits_event_id ON event_audits(event_id);
@@ -0,0 +1,11 @@
+-- Create snapshots table which keeps the state of an aggregate at its version, so the aggregate is restored
+-- from its latest snapshot and the events recorded after it instead of all its events
+CREATE TABLE IF NOT EXISTS snapshots (
+    context_id UUID NOT NULL,
+    aggregate_type VARCHAR(25) NOT NULL,
+    aggregate_version BIGINT NOT NULL,
+    snapshot_version VARCHAR(25) NOT NULL, -- version of the snapshot data format, the snapshots in another format are ignored
+    snapshot_data JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (context_id, aggregate_version)
+);
@@ -0,0 +1,11 @@
+-- Create projection_checkpoints table which keeps the progress of the read model (projection) rebuilds, the events
+-- are replayed into the shadow table of the read model in the order they were completed
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    projection VARCHAR(25) PRIMARY KEY, -- name of the rebuilt read model, i.e. accounts
+    completed_at TIMESTAMP NOT NULL, -- completion time of the last replayed event
+    sequence_number BIGINT NOT NULL, -- sequence number of the last replayed event, orders the events completed at the same time
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for the replay of the completed events
+CREATE INDEX IF NOT EXISTS idx_events_event_state_completed_at ON events(event_state, completed_at, sequence_number);
@@ -0,0 +1,19 @@
+-- Create outbox table which queues the recorded events to be published outside of the service. An event is added
+-- to the outbox in the database transaction recording it, so only the committed events are published.
+CREATE TABLE IF NOT EXISTS outbox (
+    sequence_number BIGSERIAL PRIMARY KEY, -- order in which the events were added within the transaction
+    event_id UUID NOT NULL UNIQUE,
+    transaction_id BIGINT NOT NULL DEFAULT pg_current_xact_id()::TEXT::BIGINT, -- database transaction which added the event
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for outbox table, the events are published in the order of the transactions which added them
+CREATE INDEX IF NOT EXISTS idx_outbox_transaction_id_sequence_number ON outbox(transaction_id, sequence_number);
+
+-- Create publish_checkpoints table which keeps the last event published by each publisher
+CREATE TABLE IF NOT EXISTS publish_checkpoints (
+    publisher VARCHAR(25) PRIMARY KEY, -- name of the publisher, i.e. nats
+    transaction_id BIGINT NOT NULL, -- transaction which added the last published event
+    sequence_number BIGINT NOT NULL, -- sequence number of the last published event
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
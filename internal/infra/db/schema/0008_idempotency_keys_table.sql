@@ -0,0 +1,15 @@
+-- Create idempotency_keys table which keeps the responses to the mutating requests sent with the Idempotency-Key header,
+-- the request retried with the same key gets the stored response instead of being executed again
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY, -- key sent by the client in the Idempotency-Key header
+    fingerprint VARCHAR(64) NOT NULL, -- hex encoded SHA-256 of the request method, path and body
+    status_code INTEGER, -- status code of the response, NULL while the request is in progress
+    response_headers JSONB NOT NULL DEFAULT '{}', -- headers of the response
+    response_body BYTEA, -- body of the response
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP NOT NULL, -- the request in progress after that time is considered abandoned, i.e. the server crashed
+    expires_at TIMESTAMP NOT NULL -- the key can be reused after that time
+);
+
+-- Create index for the purge of the expired keys
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
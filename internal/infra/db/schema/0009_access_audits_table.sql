@@ -0,0 +1,16 @@
+-- Create access_audits table which records the staff accessing the resources owned by the customers,
+-- the ownership of the resource is overridden by the role of the staff, i.e. the teller reading the account of a customer
+CREATE TABLE IF NOT EXISTS access_audits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    principal_id VARCHAR(255) NOT NULL, -- ID of the user or name of the service
+    principal_type VARCHAR(25) NOT NULL, -- user or service
+    roles TEXT[] NOT NULL, -- roles the principal acted in
+    resource_type VARCHAR(25) NOT NULL, -- type of the resource, i.e. account
+    resource_id UUID NOT NULL,
+    customer_id UUID NOT NULL, -- customer owning the resource
+    correlation_id UUID, -- correlation ID of the request, NULL when it's unknown
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for the review of the accesses to the resources of a customer
+CREATE INDEX IF NOT EXISTS idx_access_audits_customer_id ON access_audits(customer_id, created_at);
@@ -0,0 +1,16 @@
+-- Create customer_credentials table which keeps the online banking credentials of the customers: the password hash,
+-- the TOTP second factor and the failed logins counted towards the lockout. The changes of the credentials are recorded
+-- in the events table with the credentials origin, the events don't carry the password hash nor the TOTP secret.
+CREATE TABLE IF NOT EXISTS customer_credentials (
+    id UUID PRIMARY KEY, -- context of the credentials events
+    customer_id UUID NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL, -- argon2id hash of the password in the PHC string format
+    totp_secret VARCHAR(64) NOT NULL DEFAULT '', -- base32 encoded TOTP secret, empty until the TOTP is enrolled
+    totp_enabled BOOLEAN NOT NULL DEFAULT FALSE, -- the login requires the TOTP code once the enrollment is confirmed
+    totp_last_step BIGINT NOT NULL DEFAULT 0, -- time step of the last accepted TOTP code, the code can't be used twice
+    failed_attempts INT NOT NULL DEFAULT 0, -- consecutive failed logins since the last successful one or the lockout
+    locked_until TIMESTAMP, -- the login is rejected until that time, NULL when the credentials aren't locked
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    version INT NOT NULL -- incremented by every change, the credentials are updated expecting the version they were read in
+);
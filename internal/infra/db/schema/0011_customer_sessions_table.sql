@@ -0,0 +1,19 @@
+-- Create customer_sessions table which keeps the sessions of the customers logged in to the online banking,
+-- only the SHA-256 hashes of the tokens are stored, the tokens are handed out to the customer once
+CREATE TABLE IF NOT EXISTS customer_sessions (
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL,
+    access_token_hash VARCHAR(64) NOT NULL, -- hex encoded SHA-256 of the access token, the MFA token while the TOTP code is awaited
+    refresh_token_hash VARCHAR(64) NOT NULL DEFAULT '', -- hex encoded SHA-256 of the refresh token, empty while the TOTP code is awaited
+    mfa_pending BOOLEAN NOT NULL DEFAULT FALSE, -- the session awaits the TOTP code
+    access_expires_at TIMESTAMP NOT NULL,
+    refresh_expires_at TIMESTAMP NOT NULL, -- the session ends at that time
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP, -- set when the session is ended, i.e. by the logout or the password change
+    version INT NOT NULL -- incremented by every change, the tokens of the session are rotated once
+);
+
+-- Create indexes for the lookup of the sessions by their tokens and of the sessions of a customer
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customer_sessions_access_token_hash ON customer_sessions(access_token_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customer_sessions_refresh_token_hash ON customer_sessions(refresh_token_hash) WHERE refresh_token_hash <> '';
+CREATE INDEX IF NOT EXISTS idx_customer_sessions_customer_id ON customer_sessions(customer_id);
@@ -26,6 +26,6 @@ INSERT INTO accounts (
     '00000000-0000-0000-0000-000000000000',
     '00000000-0000-0000-0000-000000000000',
     '1234567890',
-    1000.0,
+    100000,
     'USD'
 );
\ No newline at end of file
@@ -5,5 +5,5 @@
 -- ) VALUES (
 --     '00000000-0000-0000-0000-000000000000',
 --     'account_created',
---     '{"account_number": "1234567890", "balance": 1000.0, "currency": "USD"}'
+--     '{"account_number": "1234567890", "balance": {"amount": "1000.00", "currency": "USD"}}'
 -- );
\ No newline at end of file
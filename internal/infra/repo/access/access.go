@@ -0,0 +1,84 @@
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// AccessAuditRepository is a repository for the audits of the staff accessing the resources owned by the customers
+type AccessAuditRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewAccessAuditRepository creates a new access audit repository
+func NewAccessAuditRepository(c *pgxpool.Pool) *AccessAuditRepository {
+	return &AccessAuditRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// CreateAccessAudit persists the audit of the access overriding the ownership of the resource
+func (r *AccessAuditRepository) CreateAccessAudit(ctx context.Context, audit identitydomain.AccessAudit) error {
+	roles := make([]string, 0, len(audit.Roles))
+	for _, role := range audit.Roles {
+		roles = append(roles, string(role))
+	}
+
+	err := r.Q.CreateAccessAudit(ctx, query.CreateAccessAuditParams{
+		ID:            pgtype.UUID{Bytes: audit.ID, Valid: true},
+		PrincipalID:   audit.PrincipalID,
+		PrincipalType: audit.PrincipalType,
+		Roles:         roles,
+		ResourceType:  audit.Resource.Type,
+		ResourceID:    pgtype.UUID{Bytes: audit.Resource.ID, Valid: true},
+		CustomerID:    pgtype.UUID{Bytes: audit.Resource.CustomerID, Valid: true},
+		CorrelationID: pgtype.UUID{Bytes: audit.CorrelationID, Valid: audit.CorrelationID != uuid.Nil},
+		CreatedAt:     pgtype.Timestamp{Time: audit.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("creating access audit: %w", err)
+	}
+
+	return nil
+}
+
+// FindAccessAuditsByCustomerID retrieves the audits of the accesses to the resources of the customer in the order they were recorded
+func (r *AccessAuditRepository) FindAccessAuditsByCustomerID(ctx context.Context, customerID uuid.UUID) ([]identitydomain.AccessAudit, error) {
+	rows, err := r.Q.FindAccessAuditsByCustomerID(ctx, pgtype.UUID{Bytes: customerID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("finding access audits by customer id: %w", err)
+	}
+
+	audits := make([]identitydomain.AccessAudit, 0, len(rows))
+	for _, row := range rows {
+		roles := make([]identitydomain.Role, 0, len(row.Roles))
+		for _, role := range row.Roles {
+			roles = append(roles, identitydomain.Role(role))
+		}
+
+		audits = append(audits, identitydomain.AccessAudit{
+			ID:            row.ID.Bytes,
+			PrincipalID:   row.PrincipalID,
+			PrincipalType: row.PrincipalType,
+			Roles:         roles,
+			Resource: identitydomain.Resource{
+				Type:       row.ResourceType,
+				ID:         row.ResourceID.Bytes,
+				CustomerID: row.CustomerID.Bytes,
+			},
+			CorrelationID: row.CorrelationID.Bytes,
+			CreatedAt:     row.CreatedAt.Time,
+		})
+	}
+
+	return audits, nil
+}
@@ -0,0 +1,73 @@
+//go:build integration
+
+package access
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+func TestAccessAuditRepository_CreateAccessAudit(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewAccessAuditRepository(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	customerID := uuid.New()
+
+	accountAudit := identitydomain.AccessAudit{
+		ID:            uuid.New(),
+		PrincipalID:   "teller-1",
+		PrincipalType: identitydomain.PrincipalTypeUser,
+		Roles:         []identitydomain.Role{identitydomain.RoleTeller, identitydomain.RoleAuditor},
+		Resource: identitydomain.Resource{
+			Type:       identitydomain.ResourceTypeAccount,
+			ID:         uuid.New(),
+			CustomerID: customerID,
+		},
+		CorrelationID: uuid.New(),
+		CreatedAt:     now,
+	}
+
+	// The audit of the access without the correlation ID, i.e. called outside of a request
+	customerAudit := identitydomain.AccessAudit{
+		ID:            uuid.New(),
+		PrincipalID:   "reporting",
+		PrincipalType: identitydomain.PrincipalTypeService,
+		Roles:         []identitydomain.Role{identitydomain.RoleAuditor},
+		Resource: identitydomain.Resource{
+			Type:       identitydomain.ResourceTypeCustomer,
+			ID:         customerID,
+			CustomerID: customerID,
+		},
+		CreatedAt: now.Add(time.Second),
+	}
+
+	otherCustomerAudit := accountAudit
+	otherCustomerAudit.ID = uuid.New()
+	otherCustomerAudit.Resource.CustomerID = uuid.New()
+
+	require.NoError(t, repo.CreateAccessAudit(ctx, accountAudit))
+	require.NoError(t, repo.CreateAccessAudit(ctx, customerAudit))
+	require.NoError(t, repo.CreateAccessAudit(ctx, otherCustomerAudit))
+
+	audits, err := repo.FindAccessAuditsByCustomerID(ctx, customerID)
+	require.NoError(t, err)
+	require.Equal(t, []identitydomain.AccessAudit{accountAudit, customerAudit}, audits)
+
+	audits, err = repo.FindAccessAuditsByCustomerID(ctx, uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, audits)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
 	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
 )
 
@@ -43,8 +44,8 @@ func (r *AccountRepository) CreateAccount(ctx context.Context, acc *accountdomai
 			ID:            pgtype.UUID{Bytes: acc.ID, Valid: true},
 			CustomerID:    pgtype.UUID{Bytes: acc.CustomerID, Valid: true},
 			AccountNumber: acc.AccountNumber,
-			Balance:       acc.Balance,
-			Currency:      acc.Currency,
+			Balance:       acc.Balance.Amount(),
+			Currency:      acc.Balance.Currency().String(),
 			Status:        acc.Status.String(),
 			CreatedAt:     pgtype.Timestamp{Time: acc.CreatedAt, Valid: true},
 			UpdatedAt:     pgtype.Timestamp{Time: acc.UpdatedAt, Valid: true},
@@ -67,11 +68,16 @@ func (r *AccountRepository) CreateAccount(ctx context.Context, acc *accountdomai
 		return nil, fmt.Errorf("committing transaction: create account: %w", err)
 	}
 
+	balance, err := money.New(account.Balance, money.Currency(account.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("mapping account balance: create account: %w", err)
+	}
+
 	return &accountdomain.Account{
 		ID:            account.ID.Bytes,
+		CustomerID:    account.CustomerID.Bytes,
 		AccountNumber: account.AccountNumber,
-		Balance:       account.Balance,
-		Currency:      account.Currency,
+		Balance:       balance,
 		Status:        accountdomain.AccountStatus(account.Status),
 	}, nil
 }
@@ -91,11 +97,43 @@ func (r *AccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*accoun
 
 	}
 
+	balance, err := money.New(account.Balance, money.Currency(account.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("mapping account balance: finding account by id: %w", err)
+	}
+
+	return &accountdomain.Account{
+		ID:            account.ID.Bytes,
+		CustomerID:    account.CustomerID.Bytes,
+		AccountNumber: account.AccountNumber,
+		Balance:       balance,
+		Status:        accountdomain.AccountStatus(account.Status),
+		CreatedAt:     account.CreatedAt.Time,
+		UpdatedAt:     account.UpdatedAt.Time,
+	}, nil
+}
+
+// FindByAccountNumber retrieves an account by its account number
+func (r *AccountRepository) FindByAccountNumber(ctx context.Context, accountNumber string) (*accountdomain.Account, error) {
+	account, err := r.Q.FindAccountByAccountNumber(ctx, accountNumber)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("finding account by account number: %w", accountdomain.ErrAccountNotFound)
+		}
+
+		return nil, fmt.Errorf("finding account by account number: %w", err)
+	}
+
+	balance, err := money.New(account.Balance, money.Currency(account.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("mapping account balance: finding account by account number: %w", err)
+	}
+
 	return &accountdomain.Account{
 		ID:            account.ID.Bytes,
+		CustomerID:    account.CustomerID.Bytes,
 		AccountNumber: account.AccountNumber,
-		Balance:       account.Balance,
-		Currency:      account.Currency,
+		Balance:       balance,
 		Status:        accountdomain.AccountStatus(account.Status),
 		CreatedAt:     account.CreatedAt.Time,
 		UpdatedAt:     account.UpdatedAt.Time,
@@ -118,11 +156,16 @@ func (r *AccountRepository) FindByCustomerID(ctx context.Context, customerID uui
 
 	accountsDomain := make([]*accountdomain.Account, 0)
 	for _, account := range accounts {
+		balance, err := money.New(account.Balance, money.Currency(account.Currency))
+		if err != nil {
+			return nil, fmt.Errorf("mapping account balance: finding accounts by customer id: %w", err)
+		}
+
 		accountsDomain = append(accountsDomain, &accountdomain.Account{
 			ID:            account.ID.Bytes,
+			CustomerID:    account.CustomerID.Bytes,
 			AccountNumber: account.AccountNumber,
-			Balance:       account.Balance,
-			Currency:      account.Currency,
+			Balance:       balance,
 			Status:        accountdomain.AccountStatus(account.Status),
 			CreatedAt:     account.CreatedAt.Time,
 			UpdatedAt:     account.UpdatedAt.Time,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -17,62 +18,105 @@ import (
 
 // AccountEventRepository is a repository for account event operations
 type AccountEventRepository struct {
-	Conn *pgxpool.Pool
-	Q    *query.Queries
+	Conn     *pgxpool.Pool
+	Q        *query.Queries
+	Registry *event.Registry // decodes the account events loaded from the events table
 }
 
 // NewAccountEventRepository creates a new account event repository
 func NewAccountEventRepository(c *pgxpool.Pool) *AccountEventRepository {
+	registry := event.NewRegistry()
+	accountdomain.RegisterEvents(registry)
+
 	return &AccountEventRepository{
-		Conn: c,
-		Q:    query.New(c),
+		Conn:     c,
+		Q:        query.New(c),
+		Registry: registry,
 	}
 }
 
-// CreateAccountEvent creates an account event
-func (r *AccountEventRepository) CreateAccountEvent(ctx context.Context, eventObject BaseEvent) (uuid.UUID, error) {
-	switch eventObject.(type) {
-	case *accountdomain.AccountCreatedEvent,
-		*accountdomain.AccountBlockedEvent,
-		*accountdomain.AccountUnblockedEvent,
-		*accountdomain.AccountFundsWithdrawnEvent,
-		*accountdomain.AccountFundsDepositedEvent:
+// CreateEvents creates all account events produced by one account command in a single database transaction.
+// The events are written with a single COPY, so either all of them are persisted or none.
+// The events are added to the outbox in the same transaction, so they are published once committed.
+// The events are recorded after the expected version of the account, the version the command decided on. When another command
+// recorded the next version in the meantime, none of the events is persisted and event.ErrConcurrencyConflict is returned.
+func (r *AccountEventRepository) CreateEvents(ctx context.Context, expectedVersion int, events []accountdomain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
 
-		tx, err := r.Conn.Begin(ctx)
+	md := event.MetadataFromContext(ctx)
+	metadata, err := event.EncodeMetadata(md)
+	if err != nil {
+		return fmt.Errorf("creating account events: %w", err)
+	}
+
+	params := make([]query.CreateAccountEventsParams, len(events))
+	ids := make([]pgtype.UUID, len(events))
+	for i, eventObject := range events {
+		data, err := event.Encode(eventObject)
 		if err != nil {
-			return uuid.UUID{}, fmt.Errorf("starting transaction: creating account event: %w", err)
+			return fmt.Errorf("creating account events: %w", err)
 		}
-		defer func() { _ = tx.Rollback(ctx) }()
-
-		qtx := r.Q.WithTx(tx)
-
-		accountEvent, err := qtx.CreateAccountEvent(
-			ctx,
-			query.CreateAccountEventParams{
-				ID:               pgtype.UUID{Bytes: eventObject.GetID(), Valid: true},
-				ContextID:        pgtype.UUID{Bytes: eventObject.GetContextID(), Valid: true},
-				EventOrigin:      eventObject.GetOrigin(),
-				EventType:        eventObject.GetType(),
-				EventTypeVersion: eventObject.GetTypeVersion(),
-				EventState:       eventObject.GetState(),
-				CreatedAt:        pgtype.Timestamp{Time: eventObject.GetCreatedAt(), Valid: true},
-				ScheduledAt:      pgtype.Timestamp{Time: eventObject.GetScheduledAt(), Valid: true},
-				Retry:            int32(eventObject.GetRetry()),
-				MaxRetry:         int32(eventObject.GetMaxRetry()),
-				EventData:        eventObject.GetEventData(),
-			},
-		)
-		if err != nil {
-			return uuid.Nil, fmt.Errorf("creating account event: %w", err)
+
+		ids[i] = pgtype.UUID{Bytes: eventObject.GetID(), Valid: true}
+		params[i] = query.CreateAccountEventsParams{
+			ID:               pgtype.UUID{Bytes: eventObject.GetID(), Valid: true},
+			ContextID:        pgtype.UUID{Bytes: eventObject.GetContextID(), Valid: true},
+			EventOrigin:      eventObject.GetOrigin(),
+			EventType:        eventObject.GetType(),
+			EventTypeVersion: eventObject.GetTypeVersion(),
+			EventState:       eventObject.GetState(),
+			CreatedAt:        pgtype.Timestamp{Time: eventObject.GetCreatedAt(), Valid: true},
+			ScheduledAt:      pgtype.Timestamp{Time: eventObject.GetScheduledAt(), Valid: true},
+			Retry:            int32(eventObject.GetRetry()),
+			MaxRetry:         int32(eventObject.GetMaxRetry()),
+			EventData:        data,
+			AggregateVersion: int64(expectedVersion + 1 + i),
+			EventMetadata:    metadata,
+			CorrelationID:    pgtype.UUID{Bytes: md.CorrelationID, Valid: md.CorrelationID != uuid.Nil},
+			CausationID:      pgtype.UUID{Bytes: md.CausationID, Valid: md.CausationID != uuid.Nil},
+			ActorID:          md.ActorID,
+			ActorType:        md.ActorType,
+			SourceIp:         md.SourceIP,
 		}
+	}
+
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: creating account events: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	created, err := qtx.CreateAccountEvents(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+			if pgErr.ConstraintName == query.EVENTS_AGGREGATE_VERSION_CONSTRAINT {
+				return fmt.Errorf("creating account events in version %d: %w", expectedVersion+1, event.ErrConcurrencyConflict)
+			}
 
-		if err = tx.Commit(ctx); err != nil {
-			return uuid.UUID{}, fmt.Errorf("committing transaction: creating account event: %w", err)
+			return fmt.Errorf("creating account events: %w", event.ErrEventAlreadyExists)
 		}
-		return accountEvent.ID.Bytes, nil
-	default:
-		return uuid.Nil, fmt.Errorf("casting account event: event type not recognized")
+
+		return fmt.Errorf("creating account events: %w", err)
+	}
+
+	if created != int64(len(events)) {
+		return fmt.Errorf("creating account events: created %d of %d events", created, len(events))
 	}
+
+	if err = qtx.CreateOutboxMessages(ctx, ids); err != nil {
+		return fmt.Errorf("creating account events: adding events to outbox: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: creating account events: %w", err)
+	}
+
+	return nil
 }
 
 // FindAccountEventByID finds an account event by id
@@ -87,18 +131,102 @@ func (r *AccountEventRepository) FindAccountEventByID(ctx context.Context, id uu
 	}
 
 	return &event.BaseEvent{
-		ID:          ev.ID.Bytes,
-		ContextID:   ev.ContextID.Bytes,
-		Origin:      ev.EventOrigin,
-		Type:        ev.EventType,
-		TypeVersion: ev.EventTypeVersion,
-		State:       ev.EventState,
-		CreatedAt:   ev.CreatedAt.Time,
-		ScheduledAt: ev.ScheduledAt.Time,
-		StartedAt:   ev.StartedAt.Time,
-		CompletedAt: ev.CompletedAt.Time,
-		Retry:       int(ev.Retry),    // TODO check this one more time...
-		MaxRetry:    int(ev.MaxRetry), // TODO check this one more time...
-		Data:        ev.EventData,
+		ID:               ev.ID.Bytes,
+		ContextID:        ev.ContextID.Bytes,
+		Origin:           ev.EventOrigin,
+		Type:             ev.EventType,
+		TypeVersion:      ev.EventTypeVersion,
+		State:            ev.EventState,
+		CreatedAt:        ev.CreatedAt.Time,
+		ScheduledAt:      ev.ScheduledAt.Time,
+		StartedAt:        ev.StartedAt.Time,
+		CompletedAt:      ev.CompletedAt.Time,
+		Retry:            int(ev.Retry),    // TODO check this one more time...
+		MaxRetry:         int(ev.MaxRetry), // TODO check this one more time...
+		AggregateVersion: int(ev.AggregateVersion),
+		Data:             ev.EventData,
 	}, nil
 }
+
+// FindEventsByContextID returns the events of the account in the order they were recorded, decoded into the account events.
+// The aborted events are loaded too, they don't change the account, but they hold their aggregate versions.
+func (r *AccountEventRepository) FindEventsByContextID(ctx context.Context, id uuid.UUID) ([]accountdomain.Event, error) {
+	rows, err := r.Q.FindAccountEventsByContextID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("finding account events by context id: %w", err)
+	}
+
+	events, err := r.decodeEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("finding account events by context id: %w", err)
+	}
+
+	return events, nil
+}
+
+// FindEventsAfterVersion returns the events of the account recorded after the version, i.e. the version of the account snapshot,
+// in the order they were recorded, decoded into the account events.
+func (r *AccountEventRepository) FindEventsAfterVersion(ctx context.Context, id uuid.UUID, version int) ([]accountdomain.Event, error) {
+	rows, err := r.Q.FindAccountEventsByContextIDAfterVersion(
+		ctx,
+		query.FindAccountEventsByContextIDAfterVersionParams{
+			ContextID:        pgtype.UUID{Bytes: id, Valid: true},
+			AggregateVersion: int64(version),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding account events after version %d: %w", version, err)
+	}
+
+	events, err := r.decodeEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("finding account events after version %d: %w", version, err)
+	}
+
+	return events, nil
+}
+
+// FindAccountIDs returns the IDs of all the accounts in the order they were created
+func (r *AccountEventRepository) FindAccountIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.Q.FindAccountIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("finding account ids: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.Bytes)
+	}
+
+	return ids, nil
+}
+
+// decodeEvents decodes the rows of the events table into the account events
+func (r *AccountEventRepository) decodeEvents(rows []query.Event) ([]accountdomain.Event, error) {
+	events := make([]accountdomain.Event, 0, len(rows))
+	for _, row := range rows {
+		decoded, err := r.Registry.Decode(&event.BaseEvent{
+			ID:               row.ID.Bytes,
+			ContextID:        row.ContextID.Bytes,
+			Origin:           row.EventOrigin,
+			Type:             row.EventType,
+			TypeVersion:      row.EventTypeVersion,
+			State:            row.EventState,
+			CreatedAt:        row.CreatedAt.Time,
+			ScheduledAt:      row.ScheduledAt.Time,
+			StartedAt:        row.StartedAt.Time,
+			CompletedAt:      row.CompletedAt.Time,
+			Retry:            int(row.Retry),
+			MaxRetry:         int(row.MaxRetry),
+			AggregateVersion: int(row.AggregateVersion),
+			Data:             row.EventData,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, decoded)
+	}
+
+	return events, nil
+}
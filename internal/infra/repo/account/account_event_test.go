@@ -4,7 +4,6 @@ package account
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"testing"
 	"time"
@@ -14,9 +13,21 @@ import (
 
 	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
 	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
 )
 
-func TestAccountEventRepository_CreateAccountEvent(t *testing.T) {
+// decodeAccountEvent restores the domain event from the event read from the events table
+func decodeAccountEvent(t *testing.T, e accountdomain.Event) accountdomain.Event {
+	registry := event.NewRegistry()
+	accountdomain.RegisterEvents(registry)
+
+	restored, err := registry.Decode(e)
+	require.NoError(t, err)
+
+	return restored
+}
+
+func TestAccountEventRepository_AccountCreatedEvent(t *testing.T) {
 	// Set keepContainer to true to keep the container running after the test
 	ctx := context.Background()
 	keepContainer := false
@@ -33,7 +44,7 @@ func TestAccountEventRepository_CreateAccountEvent(t *testing.T) {
 			ContextID:   id,
 			Origin:      accountdomain.EventOrigin("account").String(),
 			Type:        accountdomain.AccountCreatedEventType.String(),
-			TypeVersion: "0.0.1",
+			TypeVersion: "0.0.0",
 			State:       event.EventStateReady.String(),
 			CreatedAt:   time.Now().UTC(),
 			ScheduledAt: time.Now().UTC(),
@@ -41,25 +52,21 @@ func TestAccountEventRepository_CreateAccountEvent(t *testing.T) {
 			MaxRetry:    3,
 			Data:        nil,
 		},
-		InitialBalance: 10,
+		InitialBalance: testMoney(t, 1000),
 	}
 
-	data, _ := json.Marshal(event)
-	event.Data = data
-
 	ev, err := repo.FindAccountEventByID(ctx, id)
 	require.ErrorIs(t, err, accountdomain.ErrAccountEventNotFound)
 
-	ID, err := repo.CreateAccountEvent(ctx, &event)
-	require.NoError(t, err)
+	require.NoError(t, repo.CreateEvents(ctx, 0, []accountdomain.Event{&event}))
 
-	ev, err = repo.FindAccountEventByID(ctx, ID)
+	ev, err = repo.FindAccountEventByID(ctx, event.GetID())
 	require.NoError(t, err)
 	require.NotNil(t, ev)
 	require.Equal(t, event.GetType(), ev.GetType())
+	require.Equal(t, 1, ev.GetAggregateVersion())
 
-	restoredEvent := accountdomain.AccountCreatedEvent{}
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, ev).(*accountdomain.AccountCreatedEvent)
 
 	require.Equal(t, event.GetID(), restoredEvent.GetID())
 	require.Equal(t, event.GetContextID(), restoredEvent.GetContextID())
@@ -91,7 +98,7 @@ func TestAccountEventRepository_AccountBlockEvent(t *testing.T) {
 			ID:          uuid.MustParse("00000000-1111-2222-0000-000000000000"),
 			ContextID:   id,
 			Type:        accountdomain.AccountBlockedEventType.String(),
-			TypeVersion: "0.0.1",
+			TypeVersion: "0.0.0",
 			State:       event.EventStateReady.String(),
 			CreatedAt:   time.Now().UTC(),
 			ScheduledAt: time.Now().UTC(),
@@ -104,18 +111,13 @@ func TestAccountEventRepository_AccountBlockEvent(t *testing.T) {
 	ev, err := repo.FindAccountEventByID(ctx, id)
 	require.ErrorIs(t, err, accountdomain.ErrAccountEventNotFound)
 
-	data, _ := json.Marshal(event)
-	event.Data = data
-
-	ID, err := repo.CreateAccountEvent(ctx, &event)
-	require.NoError(t, err)
+	require.NoError(t, repo.CreateEvents(ctx, 0, []accountdomain.Event{&event}))
 
-	ev, err = repo.FindAccountEventByID(ctx, ID)
+	ev, err = repo.FindAccountEventByID(ctx, event.GetID())
 	require.NoError(t, err)
 	require.NotNil(t, ev)
 
-	restoredEvent := accountdomain.AccountBlockedEvent{}
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, ev).(*accountdomain.AccountBlockedEvent)
 
 	require.Equal(t, event.GetID(), restoredEvent.GetID())
 	require.Equal(t, event.GetContextID(), restoredEvent.GetContextID())
@@ -145,7 +147,7 @@ func TestAccountEventRepository_AccountUnblockEvent(t *testing.T) {
 			ID:          uuid.MustParse("00000000-1111-2222-0000-000000000000"),
 			ContextID:   id,
 			Type:        accountdomain.AccountUnblockedEventType.String(),
-			TypeVersion: "0.0.1",
+			TypeVersion: "0.0.0",
 			State:       event.EventStateReady.String(),
 			CreatedAt:   time.Now().UTC(),
 			ScheduledAt: time.Now().UTC(),
@@ -158,20 +160,13 @@ func TestAccountEventRepository_AccountUnblockEvent(t *testing.T) {
 	ev, err := repo.FindAccountEventByID(ctx, id)
 	require.ErrorIs(t, err, accountdomain.ErrAccountEventNotFound)
 
-	data, _ := json.Marshal(event)
-	event.Data = data
+	require.NoError(t, repo.CreateEvents(ctx, 0, []accountdomain.Event{&event}))
 
-	ID, err := repo.CreateAccountEvent(ctx, &event)
-	require.NoError(t, err)
-
-	ev, err = repo.FindAccountEventByID(ctx, ID)
+	ev, err = repo.FindAccountEventByID(ctx, event.GetID())
 	require.NoError(t, err)
 	require.NotNil(t, ev)
 
-	restoredEvent := accountdomain.AccountUnblockedEvent{}
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
-
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, ev).(*accountdomain.AccountUnblockedEvent)
 	require.Equal(t, event.GetID(), restoredEvent.GetID())
 	require.Equal(t, event.GetContextID(), restoredEvent.GetContextID())
 	require.Equal(t, event.GetType(), restoredEvent.GetType())
@@ -200,7 +195,7 @@ func TestAccountEventRepository_FundsWithdrawnEvent(t *testing.T) {
 			ID:          uuid.MustParse("00000000-1111-2222-0000-000000000000"),
 			ContextID:   id,
 			Type:        accountdomain.AccountFundsWithdrawnEventType.String(),
-			TypeVersion: "0.0.1",
+			TypeVersion: "0.0.0",
 			State:       event.EventStateReady.String(),
 			CreatedAt:   time.Now().UTC(),
 			ScheduledAt: time.Now().UTC(),
@@ -208,28 +203,20 @@ func TestAccountEventRepository_FundsWithdrawnEvent(t *testing.T) {
 			MaxRetry:    3,
 			Data:        nil,
 		},
-		Amount:   10,
-		Balance:  10,
-		Currency: "USD",
+		Amount:  testMoney(t, 1000),
+		Balance: testMoney(t, 1000),
 	}
 
 	ev, err := repo.FindAccountEventByID(ctx, id)
 	require.ErrorIs(t, err, accountdomain.ErrAccountEventNotFound)
 
-	data, _ := json.Marshal(event)
-	event.Data = data
+	require.NoError(t, repo.CreateEvents(ctx, 0, []accountdomain.Event{&event}))
 
-	ID, err := repo.CreateAccountEvent(ctx, &event)
-	require.NoError(t, err)
-
-	ev, err = repo.FindAccountEventByID(ctx, ID)
+	ev, err = repo.FindAccountEventByID(ctx, event.GetID())
 	require.NoError(t, err)
 	require.NotNil(t, ev)
 
-	restoredEvent := accountdomain.AccountFundsWithdrawnEvent{}
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
-
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, ev).(*accountdomain.AccountFundsWithdrawnEvent)
 	require.Equal(t, event.GetID(), restoredEvent.GetID())
 	require.Equal(t, event.GetContextID(), restoredEvent.GetContextID())
 	require.Equal(t, event.GetType(), restoredEvent.GetType())
@@ -242,7 +229,6 @@ func TestAccountEventRepository_FundsWithdrawnEvent(t *testing.T) {
 	require.Equal(t, event.GetMaxRetry(), restoredEvent.GetMaxRetry())
 	require.Equal(t, event.Amount, restoredEvent.Amount)
 	require.Equal(t, event.Balance, restoredEvent.Balance)
-	require.Equal(t, event.Currency, restoredEvent.Currency)
 }
 
 func TestAccountEventRepository_FundsDepositedEvent(t *testing.T) {
@@ -261,7 +247,7 @@ func TestAccountEventRepository_FundsDepositedEvent(t *testing.T) {
 			ID:          uuid.MustParse("00000000-1111-2222-0000-000000000000"),
 			ContextID:   id,
 			Type:        accountdomain.AccountFundsDepositedEventType.String(),
-			TypeVersion: "0.0.1",
+			TypeVersion: "0.0.0",
 			State:       event.EventStateReady.String(),
 			CreatedAt:   time.Now().UTC(),
 			CompletedAt: time.Time{},
@@ -270,26 +256,20 @@ func TestAccountEventRepository_FundsDepositedEvent(t *testing.T) {
 			MaxRetry:    3,
 			Data:        nil,
 		},
-		Amount:   10,
-		Balance:  10,
-		Currency: "USD",
+		Amount:  testMoney(t, 1000),
+		Balance: testMoney(t, 1000),
 	}
 
 	ev, err := repo.FindAccountEventByID(ctx, id)
 	require.ErrorIs(t, err, accountdomain.ErrAccountEventNotFound)
 
-	data, _ := json.Marshal(event)
-	event.Data = data
+	require.NoError(t, repo.CreateEvents(ctx, 0, []accountdomain.Event{&event}))
 
-	ID, err := repo.CreateAccountEvent(ctx, &event)
-	require.NoError(t, err)
-
-	ev, err = repo.FindAccountEventByID(ctx, ID)
+	ev, err = repo.FindAccountEventByID(ctx, event.GetID())
 	require.NoError(t, err)
 	require.NotNil(t, ev)
 
-	restoredEvent := accountdomain.AccountFundsDepositedEvent{}
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
+	restoredEvent := decodeAccountEvent(t, ev).(*accountdomain.AccountFundsDepositedEvent)
 
 	require.Equal(t, event.GetID(), restoredEvent.GetID())
 	require.Equal(t, event.GetContextID(), restoredEvent.GetContextID())
@@ -304,5 +284,67 @@ func TestAccountEventRepository_FundsDepositedEvent(t *testing.T) {
 
 	require.Equal(t, event.Amount, restoredEvent.Amount)
 	require.Equal(t, event.Balance, restoredEvent.Balance)
-	require.Equal(t, event.Currency, restoredEvent.Currency)
+}
+
+func testMoney(t *testing.T, amount int64) money.Money {
+	m, err := money.New(amount, "USD")
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestAccountEventRepository_CreateEvents(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewAccountEventRepository(pool)
+
+	account := accountdomain.NewAccount(uuid.New(), uuid.MustParse("00000000-0000-0000-0000-000000000000"), "2222222222", testMoney(t, 1000))
+	require.NoError(t, account.Activate())
+	require.NoError(t, account.Deposit(testMoney(t, 500)))
+	require.NoError(t, account.Block())
+
+	require.NoError(t, repo.CreateEvents(ctx, 0, account.GetEvents()))
+
+	for _, accountEvent := range account.GetEvents() {
+		ev, err := repo.FindAccountEventByID(ctx, accountEvent.GetID())
+		require.NoError(t, err)
+		require.Equal(t, accountEvent.GetType(), ev.GetType())
+	}
+
+	// The account is rebuilt from its events in the order they were recorded
+	events, err := repo.FindEventsByContextID(ctx, account.ID)
+	require.NoError(t, err)
+	require.Len(t, events, len(account.GetEvents()))
+
+	rebuilt, err := accountdomain.NewAccountFromEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, accountdomain.AccountStatusBlocked, rebuilt.Status)
+	require.Equal(t, account.Balance, rebuilt.Balance)
+	require.Equal(t, len(account.GetEvents()), rebuilt.Version)
+
+	// A command re-recording an already persisted event persists none of its events
+	account.ClearEvents()
+	require.NoError(t, account.Unblock())
+	unblockedEvent := account.GetEvents()[0]
+
+	duplicatedEvents := append(account.GetEvents(), account.GetEvents()...)
+	require.ErrorIs(t, repo.CreateEvents(ctx, rebuilt.Version, duplicatedEvents), event.ErrEventAlreadyExists)
+
+	_, err = repo.FindAccountEventByID(ctx, unblockedEvent.GetID())
+	require.ErrorIs(t, err, accountdomain.ErrAccountEventNotFound)
+
+	// A command which decided on an outdated version of the account persists none of its events
+	require.ErrorIs(t, repo.CreateEvents(ctx, rebuilt.Version-1, account.GetEvents()), event.ErrConcurrencyConflict)
+
+	_, err = repo.FindAccountEventByID(ctx, unblockedEvent.GetID())
+	require.ErrorIs(t, err, accountdomain.ErrAccountEventNotFound)
+
+	require.NoError(t, repo.CreateEvents(ctx, rebuilt.Version, account.GetEvents()))
+
+	require.NoError(t, repo.CreateEvents(ctx, 0, nil))
 }
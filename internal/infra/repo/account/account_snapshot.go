@@ -0,0 +1,81 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// AccountSnapshotRepository is a repository for account snapshot operations
+type AccountSnapshotRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewAccountSnapshotRepository creates a new account snapshot repository
+func NewAccountSnapshotRepository(c *pgxpool.Pool) *AccountSnapshotRepository {
+	return &AccountSnapshotRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// CreateSnapshot stores the JSON encoded snapshot of the account at its version, the snapshot taken again at the same version replaces it
+func (r *AccountSnapshotRepository) CreateSnapshot(ctx context.Context, snapshot accountdomain.AccountSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("encoding account snapshot: %w", err)
+	}
+
+	err = r.Q.CreateSnapshot(
+		ctx,
+		query.CreateSnapshotParams{
+			ContextID:        pgtype.UUID{Bytes: snapshot.ID, Valid: true},
+			AggregateType:    accountdomain.AccountAggregateType,
+			AggregateVersion: int64(snapshot.Version),
+			SnapshotVersion:  accountdomain.AccountSnapshotVersion,
+			SnapshotData:     data,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("creating account snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatestSnapshot returns the snapshot of the account at its highest version.
+// The snapshots in another format than accountdomain.AccountSnapshotVersion are ignored.
+func (r *AccountSnapshotRepository) FindLatestSnapshot(ctx context.Context, id uuid.UUID) (accountdomain.AccountSnapshot, error) {
+	row, err := r.Q.FindLatestSnapshot(
+		ctx,
+		query.FindLatestSnapshotParams{
+			ContextID:       pgtype.UUID{Bytes: id, Valid: true},
+			AggregateType:   accountdomain.AccountAggregateType,
+			SnapshotVersion: accountdomain.AccountSnapshotVersion,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return accountdomain.AccountSnapshot{}, fmt.Errorf("finding account snapshot: %w", accountdomain.ErrAccountSnapshotNotFound)
+		}
+
+		return accountdomain.AccountSnapshot{}, fmt.Errorf("finding account snapshot: %w", err)
+	}
+
+	var snapshot accountdomain.AccountSnapshot
+	if err := json.Unmarshal(row.SnapshotData, &snapshot); err != nil {
+		return accountdomain.AccountSnapshot{}, fmt.Errorf("decoding account snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
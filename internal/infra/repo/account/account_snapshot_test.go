@@ -0,0 +1,77 @@
+//go:build integration
+
+package account
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+)
+
+func TestAccountSnapshotRepository_FindLatestSnapshot(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewAccountEventRepository(pool)
+	snapshotRepo := NewAccountSnapshotRepository(pool)
+
+	account := accountdomain.NewAccount(uuid.New(), uuid.MustParse("00000000-0000-0000-0000-000000000000"), "3333333333", testMoney(t, 1000))
+	require.NoError(t, account.Activate())
+	require.NoError(t, account.Deposit(testMoney(t, 500)))
+	require.NoError(t, eventRepo.CreateEvents(ctx, 0, account.GetEvents()))
+
+	_, err := snapshotRepo.FindLatestSnapshot(ctx, account.ID)
+	require.ErrorIs(t, err, accountdomain.ErrAccountSnapshotNotFound)
+
+	events, err := eventRepo.FindEventsByContextID(ctx, account.ID)
+	require.NoError(t, err)
+
+	snapshotted, err := accountdomain.NewAccountFromEvents(events)
+	require.NoError(t, err)
+
+	snapshot := snapshotted.Snapshot()
+	require.NoError(t, snapshotRepo.CreateSnapshot(ctx, snapshot))
+
+	// Taking the snapshot at the same version again replaces it
+	require.NoError(t, snapshotRepo.CreateSnapshot(ctx, snapshot))
+
+	account.ClearEvents()
+	require.NoError(t, account.Withdraw(testMoney(t, 200)))
+	require.NoError(t, account.Freeze())
+	require.NoError(t, eventRepo.CreateEvents(ctx, snapshot.Version, account.GetEvents()))
+
+	// The account is restored from its latest snapshot and the events recorded after it
+	latest, err := snapshotRepo.FindLatestSnapshot(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, snapshot.Version, latest.Version)
+	require.Equal(t, snapshot.Balance, latest.Balance)
+
+	tail, err := eventRepo.FindEventsAfterVersion(ctx, account.ID, latest.Version)
+	require.NoError(t, err)
+	require.Len(t, tail, len(account.GetEvents()))
+
+	restored, err := accountdomain.NewAccountFromSnapshot(latest, tail)
+	require.NoError(t, err)
+
+	events, err = eventRepo.FindEventsByContextID(ctx, account.ID)
+	require.NoError(t, err)
+
+	replayed, err := accountdomain.NewAccountFromEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, replayed.Status, restored.Status)
+	require.Equal(t, replayed.Balance, restored.Balance)
+	require.Equal(t, replayed.Version, restored.Version)
+
+	ids, err := eventRepo.FindAccountIDs(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, account.ID)
+}
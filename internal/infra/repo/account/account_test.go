@@ -66,8 +66,7 @@ func TestAccountRepository_CreateAccount(t *testing.T) {
 		ID:            uuid.MustParse("00000000-0000-0000-0000-111111111111"),
 		CustomerID:    uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 		AccountNumber: "1111111111",
-		Balance:       1000,
-		Currency:      "USD",
+		Balance:       testMoney(t, 100000),
 		Status:        accountdomain.AccountStatusActive,
 		CreatedAt:     time.Now().UTC(),
 		UpdatedAt:     time.Now().UTC(),
@@ -81,6 +80,15 @@ func TestAccountRepository_CreateAccount(t *testing.T) {
 	acc, err = repo.FindByID(ctx, uuid.MustParse("00000000-0000-0000-0000-111111111111"))
 	require.NoError(t, err)
 	require.NotNil(t, acc)
+	require.Equal(t, a.Balance, acc.Balance)
+	require.Equal(t, a.CustomerID, acc.CustomerID)
+
+	acc, err = repo.FindByAccountNumber(ctx, a.AccountNumber)
+	require.NoError(t, err)
+	require.Equal(t, a.ID, acc.ID)
+
+	_, err = repo.FindByAccountNumber(ctx, "0000000000")
+	require.ErrorIs(t, err, accountdomain.ErrAccountNotFound)
 
 	_, err = repo.CreateAccount(ctx, a)
 	require.ErrorIs(t, err, accountdomain.ErrAccountAlreadyExists)
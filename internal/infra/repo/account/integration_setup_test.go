@@ -61,6 +61,16 @@ func setupTestDB(t *testing.T, keepContainer bool) (*pgxpool.Pool, string) {
 				ContainerFilePath: "/docker-entrypoint-initdb.d/0002_accounts.sql",
 				FileMode:          0644,
 			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0005_snapshots_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0005_snapshots.sql",
+				FileMode:          0644,
+			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0007_outbox_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0007_outbox.sql",
+				FileMode:          0644,
+			},
 		},
 	}
 
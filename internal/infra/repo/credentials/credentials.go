@@ -0,0 +1,258 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// CredentialsRepository is a repository for the online banking credentials of the customers
+type CredentialsRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewCredentialsRepository creates a new credentials repository
+func NewCredentialsRepository(c *pgxpool.Pool) *CredentialsRepository {
+	return &CredentialsRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// FindCredentialsByCustomerID finds the credentials of the customer
+func (r *CredentialsRepository) FindCredentialsByCustomerID(ctx context.Context, customerID uuid.UUID) (*credentialsdomain.Credentials, error) {
+	row, err := r.Q.FindCredentialsByCustomerID(ctx, pgtype.UUID{Bytes: customerID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("finding credentials by customer id: %w", credentialsdomain.ErrCredentialsNotFound)
+		}
+
+		return nil, fmt.Errorf("finding credentials by customer id: %w", err)
+	}
+
+	return &credentialsdomain.Credentials{
+		ID:             row.ID.Bytes,
+		CustomerID:     row.CustomerID.Bytes,
+		PasswordHash:   row.PasswordHash,
+		TOTPSecret:     row.TotpSecret,
+		TOTPEnabled:    row.TotpEnabled,
+		TOTPLastStep:   row.TotpLastStep,
+		FailedAttempts: int(row.FailedAttempts),
+		LockedUntil:    row.LockedUntil.Time,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+		Version:        int(row.Version),
+		Events:         []credentialsdomain.Event{},
+	}, nil
+}
+
+// SaveCredentials persists the credentials together with their events in a single database transaction. The credentials found
+// in the expected version are updated, the new credentials are created when the expected version is zero. When the credentials
+// were changed in the meantime, neither the credentials nor the events are persisted and event.ErrConcurrencyConflict is returned.
+// The events are recorded completed after the expected version and added to the outbox, so they are published once committed.
+func (r *CredentialsRepository) SaveCredentials(ctx context.Context, expectedVersion int, credentials *credentialsdomain.Credentials) error {
+	// Every change stored increments the version, the version of the change recording the events is the version of its last event
+	version := expectedVersion + max(1, len(credentials.Events))
+
+	params, ids, err := newCredentialsEventsParams(ctx, credentials.Events, expectedVersion+1)
+	if err != nil {
+		return fmt.Errorf("saving credentials: %w", err)
+	}
+
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: saving credentials: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	if expectedVersion == 0 {
+		err = qtx.CreateCredentials(ctx, query.CreateCredentialsParams{
+			ID:             pgtype.UUID{Bytes: credentials.ID, Valid: true},
+			CustomerID:     pgtype.UUID{Bytes: credentials.CustomerID, Valid: true},
+			PasswordHash:   credentials.PasswordHash,
+			TotpSecret:     credentials.TOTPSecret,
+			TotpEnabled:    credentials.TOTPEnabled,
+			TotpLastStep:   credentials.TOTPLastStep,
+			FailedAttempts: int32(credentials.FailedAttempts),
+			LockedUntil:    pgtype.Timestamp{Time: credentials.LockedUntil, Valid: !credentials.LockedUntil.IsZero()},
+			CreatedAt:      pgtype.Timestamp{Time: credentials.CreatedAt, Valid: true},
+			UpdatedAt:      pgtype.Timestamp{Time: credentials.UpdatedAt, Valid: true},
+			Version:        int32(version),
+		})
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+				return fmt.Errorf("creating credentials: %w", event.ErrConcurrencyConflict)
+			}
+
+			return fmt.Errorf("creating credentials: %w", err)
+		}
+	} else {
+		updated, err := qtx.UpdateCredentials(ctx, query.UpdateCredentialsParams{
+			ID:              pgtype.UUID{Bytes: credentials.ID, Valid: true},
+			PasswordHash:    credentials.PasswordHash,
+			TotpSecret:      credentials.TOTPSecret,
+			TotpEnabled:     credentials.TOTPEnabled,
+			TotpLastStep:    credentials.TOTPLastStep,
+			FailedAttempts:  int32(credentials.FailedAttempts),
+			LockedUntil:     pgtype.Timestamp{Time: credentials.LockedUntil, Valid: !credentials.LockedUntil.IsZero()},
+			UpdatedAt:       pgtype.Timestamp{Time: credentials.UpdatedAt, Valid: true},
+			Version:         int32(version),
+			ExpectedVersion: int32(expectedVersion),
+		})
+		if err != nil {
+			return fmt.Errorf("updating credentials: %w", err)
+		}
+
+		if updated == 0 {
+			return fmt.Errorf("updating credentials in version %d: %w", expectedVersion, event.ErrConcurrencyConflict)
+		}
+	}
+
+	if err := createCredentialsEvents(ctx, qtx, params, ids); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: saving credentials: %w", err)
+	}
+
+	return nil
+}
+
+// SaveFailedLogin counts the failed login of the credentials towards the lockout by the policy and records their login failed event
+// in a single database transaction. The failed login is counted by a single update of the stored credentials, so the failed logins
+// of the concurrent logins are all counted and the credentials are locked once they reach the maximum whatever the version
+// the credentials were found in. The credentials and their event are updated to the stored count and version.
+func (r *CredentialsRepository) SaveFailedLogin(ctx context.Context, credentials *credentialsdomain.Credentials, policy credentialsdomain.LockoutPolicy) error {
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: saving failed login: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	row, err := qtx.CountFailedLogin(ctx, query.CountFailedLoginParams{
+		MaxFailedAttempts: int32(policy.MaxFailedAttempts),
+		LockedUntil:       pgtype.Timestamp{Time: credentials.UpdatedAt.Add(policy.Duration), Valid: true},
+		UpdatedAt:         pgtype.Timestamp{Time: credentials.UpdatedAt, Valid: true},
+		ID:                pgtype.UUID{Bytes: credentials.ID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("counting failed login: %w", credentialsdomain.ErrCredentialsNotFound)
+		}
+
+		return fmt.Errorf("counting failed login: %w", err)
+	}
+
+	version := int(row.Version)
+	if err := credentials.CountFailedLogin(int(row.FailedAttempts), row.LockedUntil.Time, version, policy); err != nil {
+		return fmt.Errorf("counting failed login: %w", err)
+	}
+
+	// The failed login is the only change stored by the update, so its event is recorded in the version of the credentials
+	params, ids, err := newCredentialsEventsParams(ctx, credentials.Events[len(credentials.Events)-1:], version)
+	if err != nil {
+		return fmt.Errorf("saving failed login: %w", err)
+	}
+
+	if err := createCredentialsEvents(ctx, qtx, params, ids); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: saving failed login: %w", err)
+	}
+
+	return nil
+}
+
+// newCredentialsEventsParams returns the parameters of the credentials events recorded in the versions following each other
+// from the version, together with their IDs added to the outbox
+func newCredentialsEventsParams(ctx context.Context, events []credentialsdomain.Event, version int) ([]query.CreateCredentialsEventsParams, []pgtype.UUID, error) {
+	md := event.MetadataFromContext(ctx)
+	metadata, err := event.EncodeMetadata(md)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := make([]query.CreateCredentialsEventsParams, len(events))
+	ids := make([]pgtype.UUID, len(events))
+	for i, eventObject := range events {
+		data, err := event.Encode(eventObject)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids[i] = pgtype.UUID{Bytes: eventObject.GetID(), Valid: true}
+		params[i] = query.CreateCredentialsEventsParams{
+			ID:               pgtype.UUID{Bytes: eventObject.GetID(), Valid: true},
+			ContextID:        pgtype.UUID{Bytes: eventObject.GetContextID(), Valid: true},
+			EventOrigin:      eventObject.GetOrigin(),
+			EventType:        eventObject.GetType(),
+			EventTypeVersion: eventObject.GetTypeVersion(),
+			EventState:       eventObject.GetState(),
+			CreatedAt:        pgtype.Timestamp{Time: eventObject.GetCreatedAt(), Valid: true},
+			ScheduledAt:      pgtype.Timestamp{Time: eventObject.GetScheduledAt(), Valid: true},
+			CompletedAt:      pgtype.Timestamp{Time: eventObject.GetCompletedAt(), Valid: !eventObject.GetCompletedAt().IsZero()},
+			Retry:            int32(eventObject.GetRetry()),
+			MaxRetry:         int32(eventObject.GetMaxRetry()),
+			EventData:        data,
+			AggregateVersion: int64(version + i),
+			EventMetadata:    metadata,
+			CorrelationID:    pgtype.UUID{Bytes: md.CorrelationID, Valid: md.CorrelationID != uuid.Nil},
+			CausationID:      pgtype.UUID{Bytes: md.CausationID, Valid: md.CausationID != uuid.Nil},
+			ActorID:          md.ActorID,
+			ActorType:        md.ActorType,
+			SourceIp:         md.SourceIP,
+		}
+	}
+
+	return params, ids, nil
+}
+
+// createCredentialsEvents records the credentials events and adds them to the outbox within the database transaction
+func createCredentialsEvents(ctx context.Context, qtx *query.Queries, params []query.CreateCredentialsEventsParams, ids []pgtype.UUID) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	created, err := qtx.CreateCredentialsEvents(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+			if pgErr.ConstraintName == query.EVENTS_AGGREGATE_VERSION_CONSTRAINT {
+				return fmt.Errorf("creating credentials events in version %d: %w", params[0].AggregateVersion, event.ErrConcurrencyConflict)
+			}
+
+			return fmt.Errorf("creating credentials events: %w", event.ErrEventAlreadyExists)
+		}
+
+		return fmt.Errorf("creating credentials events: %w", err)
+	}
+
+	if created != int64(len(params)) {
+		return fmt.Errorf("creating credentials events: created %d of %d events", created, len(params))
+	}
+
+	if err = qtx.CreateOutboxMessages(ctx, ids); err != nil {
+		return fmt.Errorf("creating credentials events: adding events to outbox: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+//go:build integration
+
+package credentials
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+func TestCredentialsRepository_SaveCredentials(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewCredentialsRepository(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	customerID := uuid.New()
+
+	_, err := repo.FindCredentialsByCustomerID(ctx, customerID)
+	require.ErrorIs(t, err, credentialsdomain.ErrCredentialsNotFound)
+
+	// The new credentials are created with the password set event
+	credentials := credentialsdomain.NewCredentials(customerID, now)
+	require.NoError(t, credentials.SetPassword("correct horse battery", now))
+	require.NoError(t, repo.SaveCredentials(ctx, 0, credentials))
+
+	found, err := repo.FindCredentialsByCustomerID(ctx, customerID)
+	require.NoError(t, err)
+	require.Equal(t, credentials.ID, found.ID)
+	require.Equal(t, credentials.PasswordHash, found.PasswordHash)
+	require.Equal(t, 1, found.Version)
+	require.Empty(t, found.Events)
+
+	// The credentials created again are in conflict
+	require.ErrorIs(t, repo.SaveCredentials(ctx, 0, credentialsdomain.NewCredentials(customerID, now)), event.ErrConcurrencyConflict)
+
+	// The failed login is recorded with the lockout state
+	policy := credentialsdomain.DefaultLockoutPolicy()
+	require.ErrorIs(t, found.VerifyPassword("wrong horse battery", policy, now), credentialsdomain.ErrWrongPassword)
+	require.NoError(t, repo.SaveCredentials(ctx, 1, found))
+
+	found, err = repo.FindCredentialsByCustomerID(ctx, customerID)
+	require.NoError(t, err)
+	require.Equal(t, 1, found.FailedAttempts)
+	require.Equal(t, 2, found.Version)
+
+	// The change without the events increments the version
+	found.Succeed(now)
+	require.NoError(t, repo.SaveCredentials(ctx, 2, found))
+
+	// The credentials changed in the meantime aren't updated
+	require.ErrorIs(t, repo.SaveCredentials(ctx, 2, found), event.ErrConcurrencyConflict)
+
+	found, err = repo.FindCredentialsByCustomerID(ctx, customerID)
+	require.NoError(t, err)
+	require.Zero(t, found.FailedAttempts)
+	require.Equal(t, 3, found.Version)
+
+	// Event checks
+	var events, completed, outbox int
+	err = pool.QueryRow(ctx,
+		"SELECT COUNT(*), COUNT(*) FILTER (WHERE event_state = $2), (SELECT COUNT(*) FROM outbox) FROM events WHERE context_id = $1",
+		credentials.ID, event.EventStateCompleted.String(),
+	).Scan(&events, &completed, &outbox)
+	require.NoError(t, err)
+	require.Equal(t, 2, events)
+	require.Equal(t, 2, completed)
+	require.Equal(t, 2, outbox)
+}
+
+func TestCredentialsRepository_SaveFailedLogin(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewCredentialsRepository(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	policy := credentialsdomain.LockoutPolicy{MaxFailedAttempts: 3, Duration: time.Minute}
+
+	customerID := uuid.New()
+	credentials := credentialsdomain.NewCredentials(customerID, now)
+	require.NoError(t, credentials.SetPassword("correct horse battery", now))
+	require.NoError(t, repo.SaveCredentials(ctx, 0, credentials))
+
+	// The concurrent logins found the credentials in the same version, their failed logins are all counted
+	logins := make([]*credentialsdomain.Credentials, policy.MaxFailedAttempts)
+	for i := range logins {
+		found, err := repo.FindCredentialsByCustomerID(ctx, customerID)
+		require.NoError(t, err)
+		require.ErrorIs(t, found.VerifyPassword("wrong horse battery", policy, now), credentialsdomain.ErrWrongPassword)
+
+		logins[i] = found
+	}
+
+	for i, login := range logins[:len(logins)-1] {
+		require.NoError(t, repo.SaveFailedLogin(ctx, login, policy))
+		require.Equal(t, i+1, login.FailedAttempts)
+		require.Equal(t, i+2, login.Version)
+	}
+
+	// The last failed login reaches the maximum and locks the credentials
+	last := logins[len(logins)-1]
+	require.NoError(t, repo.SaveFailedLogin(ctx, last, policy))
+
+	found, err := repo.FindCredentialsByCustomerID(ctx, customerID)
+	require.NoError(t, err)
+	require.Zero(t, found.FailedAttempts)
+	require.True(t, found.IsLocked(now))
+	require.Equal(t, 1+policy.MaxFailedAttempts, found.Version)
+
+	failed, ok := last.Events[0].(*credentialsdomain.LoginFailedEvent)
+	require.True(t, ok)
+	require.Equal(t, policy.MaxFailedAttempts, failed.FailedAttempts)
+	require.NotNil(t, failed.LockedUntil)
+
+	// Event checks
+	var events, maxVersion int
+	err = pool.QueryRow(ctx,
+		"SELECT COUNT(*), MAX(aggregate_version) FROM events WHERE context_id = $1 AND event_type = $2",
+		credentials.ID, credentialsdomain.LoginFailedEventType.String(),
+	).Scan(&events, &maxVersion)
+	require.NoError(t, err)
+	require.Equal(t, policy.MaxFailedAttempts, events)
+	require.Equal(t, found.Version, maxVersion)
+}
@@ -0,0 +1,144 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// SessionRepository is a repository for the sessions of the customers logged in to the online banking
+type SessionRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(c *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// CreateSession persists the new session in its first version
+func (r *SessionRepository) CreateSession(ctx context.Context, session credentialsdomain.Session) error {
+	err := r.Q.CreateSession(ctx, query.CreateSessionParams{
+		ID:               pgtype.UUID{Bytes: session.ID, Valid: true},
+		CustomerID:       pgtype.UUID{Bytes: session.CustomerID, Valid: true},
+		AccessTokenHash:  session.AccessTokenHash,
+		RefreshTokenHash: session.RefreshTokenHash,
+		MfaPending:       session.MFAPending,
+		AccessExpiresAt:  pgtype.Timestamp{Time: session.AccessExpiresAt, Valid: true},
+		RefreshExpiresAt: pgtype.Timestamp{Time: session.RefreshExpiresAt, Valid: true},
+		CreatedAt:        pgtype.Timestamp{Time: session.CreatedAt, Valid: true},
+		Version:          int32(session.Version + 1),
+	})
+	if err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+
+	return nil
+}
+
+// FindSessionByAccessToken finds the session by the hash of its access token
+func (r *SessionRepository) FindSessionByAccessToken(ctx context.Context, accessTokenHash string) (credentialsdomain.Session, error) {
+	row, err := r.Q.FindSessionByAccessTokenHash(ctx, accessTokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return credentialsdomain.Session{}, fmt.Errorf("finding session by access token: %w", credentialsdomain.ErrSessionNotFound)
+		}
+
+		return credentialsdomain.Session{}, fmt.Errorf("finding session by access token: %w", err)
+	}
+
+	return toSession(row), nil
+}
+
+// FindSessionByRefreshToken finds the session by the hash of its refresh token
+func (r *SessionRepository) FindSessionByRefreshToken(ctx context.Context, refreshTokenHash string) (credentialsdomain.Session, error) {
+	row, err := r.Q.FindSessionByRefreshTokenHash(ctx, refreshTokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return credentialsdomain.Session{}, fmt.Errorf("finding session by refresh token: %w", credentialsdomain.ErrSessionNotFound)
+		}
+
+		return credentialsdomain.Session{}, fmt.Errorf("finding session by refresh token: %w", err)
+	}
+
+	return toSession(row), nil
+}
+
+// UpdateSession persists the tokens of the session found in the expected version. When the session was changed in the meantime,
+// i.e. its tokens were rotated or it was revoked, the session isn't updated and event.ErrConcurrencyConflict is returned.
+func (r *SessionRepository) UpdateSession(ctx context.Context, expectedVersion int, session credentialsdomain.Session) error {
+	updated, err := r.Q.UpdateSession(ctx, query.UpdateSessionParams{
+		ID:               pgtype.UUID{Bytes: session.ID, Valid: true},
+		AccessTokenHash:  session.AccessTokenHash,
+		RefreshTokenHash: session.RefreshTokenHash,
+		MfaPending:       session.MFAPending,
+		AccessExpiresAt:  pgtype.Timestamp{Time: session.AccessExpiresAt, Valid: true},
+		RefreshExpiresAt: pgtype.Timestamp{Time: session.RefreshExpiresAt, Valid: true},
+		Version:          int32(expectedVersion),
+	})
+	if err != nil {
+		return fmt.Errorf("updating session: %w", err)
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("updating session in version %d: %w", expectedVersion, event.ErrConcurrencyConflict)
+	}
+
+	return nil
+}
+
+// RevokeSession revokes the session, the session already revoked stays revoked at the former time
+func (r *SessionRepository) RevokeSession(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	err := r.Q.RevokeSession(ctx, query.RevokeSessionParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		RevokedAt: pgtype.Timestamp{Time: revokedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("revoking session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeCustomerSessions revokes all the sessions of the customer which weren't revoked yet
+func (r *SessionRepository) RevokeCustomerSessions(ctx context.Context, customerID uuid.UUID, revokedAt time.Time) error {
+	err := r.Q.RevokeCustomerSessions(ctx, query.RevokeCustomerSessionsParams{
+		CustomerID: pgtype.UUID{Bytes: customerID, Valid: true},
+		RevokedAt:  pgtype.Timestamp{Time: revokedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("revoking customer sessions: %w", err)
+	}
+
+	return nil
+}
+
+// toSession maps the row of the session to the session
+func toSession(row query.CustomerSession) credentialsdomain.Session {
+	return credentialsdomain.Session{
+		ID:               row.ID.Bytes,
+		CustomerID:       row.CustomerID.Bytes,
+		AccessTokenHash:  row.AccessTokenHash,
+		RefreshTokenHash: row.RefreshTokenHash,
+		MFAPending:       row.MfaPending,
+		AccessExpiresAt:  row.AccessExpiresAt.Time,
+		RefreshExpiresAt: row.RefreshExpiresAt.Time,
+		CreatedAt:        row.CreatedAt.Time,
+		RevokedAt:        row.RevokedAt.Time,
+		Version:          int(row.Version),
+	}
+}
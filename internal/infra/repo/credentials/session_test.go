@@ -0,0 +1,83 @@
+//go:build integration
+
+package credentials
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+func TestSessionRepository(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewSessionRepository(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	policy := credentialsdomain.DefaultSessionPolicy()
+
+	customerID := uuid.New()
+
+	// The session awaiting the TOTP code has no refresh token yet
+	session, tokens, err := credentialsdomain.NewSession(customerID, true, policy, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateSession(ctx, *session))
+
+	other, _, err := credentialsdomain.NewSession(customerID, true, policy, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateSession(ctx, *other))
+
+	found, err := repo.FindSessionByAccessToken(ctx, credentialsdomain.HashToken(tokens.AccessToken))
+	require.NoError(t, err)
+	require.Equal(t, session.ID, found.ID)
+	require.True(t, found.IsAwaitingMFA(now))
+	require.Equal(t, 1, found.Version)
+
+	// The tokens are rotated once
+	rotated := found
+	tokens, err = rotated.Rotate(policy, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateSession(ctx, found.Version, rotated))
+	require.ErrorIs(t, repo.UpdateSession(ctx, found.Version, rotated), event.ErrConcurrencyConflict)
+
+	found, err = repo.FindSessionByRefreshToken(ctx, credentialsdomain.HashToken(tokens.RefreshToken))
+	require.NoError(t, err)
+	require.Equal(t, session.ID, found.ID)
+	require.True(t, found.IsActive(now))
+	require.Equal(t, 2, found.Version)
+
+	// The former access token doesn't find the session anymore
+	_, err = repo.FindSessionByAccessToken(ctx, session.AccessTokenHash)
+	require.ErrorIs(t, err, credentialsdomain.ErrSessionNotFound)
+
+	// The revoked session stays revoked at the former time and isn't updated anymore
+	require.NoError(t, repo.RevokeSession(ctx, session.ID, now))
+	require.NoError(t, repo.RevokeSession(ctx, session.ID, now.Add(time.Minute)))
+
+	found, err = repo.FindSessionByAccessToken(ctx, credentialsdomain.HashToken(tokens.AccessToken))
+	require.NoError(t, err)
+	require.Equal(t, now, found.RevokedAt)
+	require.False(t, found.IsActive(now))
+	require.ErrorIs(t, repo.UpdateSession(ctx, found.Version, found), event.ErrConcurrencyConflict)
+
+	// All the sessions of the customer are revoked
+	require.NoError(t, repo.RevokeCustomerSessions(ctx, customerID, now.Add(time.Minute)))
+
+	found, err = repo.FindSessionByAccessToken(ctx, other.AccessTokenHash)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Minute), found.RevokedAt)
+
+	_, err = repo.FindSessionByRefreshToken(ctx, credentialsdomain.HashToken("ref_unknown"))
+	require.ErrorIs(t, err, credentialsdomain.ErrSessionNotFound)
+}
@@ -4,10 +4,8 @@ package customer
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"testing"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -16,8 +14,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestCustomerEventRepository_CreateCustomerEvent(t *testing.T) {
+func TestCustomerEventRepository_FindCustomerEventByID(t *testing.T) {
+}
 
+func TestCustomerEventRepository_CreateEvents(t *testing.T) {
 	ctx := context.Background()
 	keepContainer := false
 	pool, address := setupTestDB(t, keepContainer)
@@ -26,65 +26,49 @@ func TestCustomerEventRepository_CreateCustomerEvent(t *testing.T) {
 
 	repo := NewCustomerEventRepository(pool)
 
-	id := uuid.MustParse("00000000-0000-0000-0000-111111111111")
-
-	event := &customerdomain.CustomerCreatedEvent{
-		BaseEvent: event.BaseEvent{
-			ID:          id,
-			ContextID:   uuid.MustParse("00000000-0000-0000-0000-111111111111"),
-			Origin:      customerdomain.EventOrigin("customer").String(),
-			Type:        customerdomain.CustomerCreatedEventType.String(),
-			TypeVersion: "1.0.0",
-			State:       event.EventStateReady.String(),
-			CreatedAt:   time.Now().UTC(),
-			ScheduledAt: time.Now().UTC(),
-			Retry:       0,
-			MaxRetry:    3,
-		},
-		FirstName:   "John",
-		LastName:    "Doe",
-		Phone:       "1234567890",
-		Email:       "john.doe.3@example.com",
-		DateOfBirth: "1990-01-01",
-		Address: customerdomain.Address{
-			Street:     "Street 1",
-			City:       "Warsaw",
-			State:      "Masovian",
-			PostalCode: "00-000",
-			Country:    "Poland",
-		},
+	customer := customerdomain.NewCustomer(
+		uuid.New(),
+		"John",
+		"Doe",
+		"john.doe.events@example.com",
+		"+48123456789",
+		"1990-01-01",
+		customerdomain.Address{Street: "Main", City: "Warsaw", PostalCode: "00-001", Country: "PL"},
+	)
+	customer.Activate()
+
+	require.NoError(t, repo.CreateEvents(ctx, 0, customer.GetEvents()))
+
+	for _, customerEvent := range customer.GetEvents() {
+		ev, err := repo.FindCustomerEventByID(ctx, customerEvent.GetID())
+		require.NoError(t, err)
+		require.Equal(t, customerEvent.GetType(), ev.GetType())
 	}
 
-	data, _ := json.Marshal(event)
-	event.Data = data
+	// The customer is rebuilt from its events in the order they were recorded
+	events, err := repo.FindEventsByContextID(ctx, customer.ID)
+	require.NoError(t, err)
+	require.Len(t, events, len(customer.GetEvents()))
+
+	rebuilt, err := customerdomain.NewCustomerFromEvents(events)
+	require.NoError(t, err)
+	require.Equal(t, customer.Email, rebuilt.Email)
+	require.Equal(t, customerdomain.CustomerStatusActive, rebuilt.Status)
+	require.Equal(t, len(customer.GetEvents()), rebuilt.Version)
+
+	// A command re-recording an already persisted event persists none of its events
+	customer.ClearEvents()
+	customer.Block("fraud suspicion")
+	blockedEvent := customer.GetEvents()[0]
 
-	ev, err := repo.FindCustomerEventByID(ctx, id)
+	duplicatedEvents := append(customer.GetEvents(), customer.GetEvents()...)
+	require.ErrorIs(t, repo.CreateEvents(ctx, rebuilt.Version, duplicatedEvents), event.ErrEventAlreadyExists)
+
+	_, err = repo.FindCustomerEventByID(ctx, blockedEvent.GetID())
 	require.ErrorIs(t, err, customerdomain.ErrCustomerEventNotFound)
 
-	idOut, err := repo.CreateCustomerEvent(
-		ctx,
-		event,
-	)
-	require.NoError(t, err)
-	require.Equal(t, id, idOut)
-
-	ev, err = repo.FindCustomerEventByID(ctx, id)
-	require.NotNil(t, ev)
-
-	restoredEvent := &customerdomain.CustomerCreatedEvent{}
-	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
-
-	require.Equal(t, event.GetID(), restoredEvent.GetID())
-	require.Equal(t, event.GetContextID(), restoredEvent.GetContextID())
-	require.Equal(t, event.GetType(), restoredEvent.GetType())
-	require.Equal(t, event.GetTypeVersion(), restoredEvent.GetTypeVersion())
-	require.Equal(t, event.GetState(), restoredEvent.GetState())
-	require.Equal(t, event.GetCreatedAt(), restoredEvent.GetCreatedAt())
-	require.Equal(t, event.GetCompletedAt(), restoredEvent.GetCompletedAt())
-	require.Equal(t, event.GetScheduledAt(), restoredEvent.GetScheduledAt())
-	require.Equal(t, event.GetRetry(), restoredEvent.GetRetry())
-	require.Equal(t, event.GetMaxRetry(), restoredEvent.GetMaxRetry())
-}
+	// A command which decided on an outdated version of the customer persists none of its events
+	require.ErrorIs(t, repo.CreateEvents(ctx, rebuilt.Version-1, customer.GetEvents()), event.ErrConcurrencyConflict)
 
-func TestCustomerEventRepository_FindCustomerEventByID(t *testing.T) {
+	require.NoError(t, repo.CreateEvents(ctx, rebuilt.Version, customer.GetEvents()))
 }
@@ -0,0 +1,81 @@
+package customer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// CustomerSnapshotRepository is a repository for customer snapshot operations
+type CustomerSnapshotRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewCustomerSnapshotRepository creates a new customer snapshot repository
+func NewCustomerSnapshotRepository(c *pgxpool.Pool) *CustomerSnapshotRepository {
+	return &CustomerSnapshotRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// CreateSnapshot stores the JSON encoded snapshot of the customer at its version, the snapshot taken again at the same version replaces it
+func (r *CustomerSnapshotRepository) CreateSnapshot(ctx context.Context, snapshot customerdomain.CustomerSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("encoding customer snapshot: %w", err)
+	}
+
+	err = r.Q.CreateSnapshot(
+		ctx,
+		query.CreateSnapshotParams{
+			ContextID:        pgtype.UUID{Bytes: snapshot.ID, Valid: true},
+			AggregateType:    customerdomain.CustomerAggregateType,
+			AggregateVersion: int64(snapshot.Version),
+			SnapshotVersion:  customerdomain.CustomerSnapshotVersion,
+			SnapshotData:     data,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("creating customer snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatestSnapshot returns the snapshot of the customer at its highest version.
+// The snapshots in another format than customerdomain.CustomerSnapshotVersion are ignored.
+func (r *CustomerSnapshotRepository) FindLatestSnapshot(ctx context.Context, id uuid.UUID) (customerdomain.CustomerSnapshot, error) {
+	row, err := r.Q.FindLatestSnapshot(
+		ctx,
+		query.FindLatestSnapshotParams{
+			ContextID:       pgtype.UUID{Bytes: id, Valid: true},
+			AggregateType:   customerdomain.CustomerAggregateType,
+			SnapshotVersion: customerdomain.CustomerSnapshotVersion,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return customerdomain.CustomerSnapshot{}, fmt.Errorf("finding customer snapshot: %w", customerdomain.ErrCustomerSnapshotNotFound)
+		}
+
+		return customerdomain.CustomerSnapshot{}, fmt.Errorf("finding customer snapshot: %w", err)
+	}
+
+	var snapshot customerdomain.CustomerSnapshot
+	if err := json.Unmarshal(row.SnapshotData, &snapshot); err != nil {
+		return customerdomain.CustomerSnapshot{}, fmt.Errorf("decoding customer snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
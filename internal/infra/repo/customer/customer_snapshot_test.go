@@ -0,0 +1,70 @@
+//go:build integration
+
+package customer
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+)
+
+func TestCustomerSnapshotRepository_FindLatestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewCustomerEventRepository(pool)
+	snapshotRepo := NewCustomerSnapshotRepository(pool)
+
+	customer := customerdomain.NewCustomer(
+		uuid.New(),
+		"John",
+		"Doe",
+		"john.doe.snapshots@example.com",
+		"+48123456789",
+		"1990-01-01",
+		customerdomain.Address{Street: "Main", City: "Warsaw", PostalCode: "00-001", Country: "PL"},
+	)
+	customer.Block("fraud suspicion")
+	require.NoError(t, eventRepo.CreateEvents(ctx, 0, customer.GetEvents()))
+
+	_, err := snapshotRepo.FindLatestSnapshot(ctx, customer.ID)
+	require.ErrorIs(t, err, customerdomain.ErrCustomerSnapshotNotFound)
+
+	events, err := eventRepo.FindEventsByContextID(ctx, customer.ID)
+	require.NoError(t, err)
+
+	snapshotted, err := customerdomain.NewCustomerFromEvents(events)
+	require.NoError(t, err)
+	require.NoError(t, snapshotRepo.CreateSnapshot(ctx, snapshotted.Snapshot()))
+
+	customer.ClearEvents()
+	customer.Unblock()
+	require.NoError(t, eventRepo.CreateEvents(ctx, snapshotted.Version, customer.GetEvents()))
+
+	// The customer is restored from its latest snapshot and the events recorded after it
+	latest, err := snapshotRepo.FindLatestSnapshot(ctx, customer.ID)
+	require.NoError(t, err)
+	require.Equal(t, snapshotted.Version, latest.Version)
+	require.Equal(t, customerdomain.CustomerStatusBlocked, latest.Status)
+
+	tail, err := eventRepo.FindEventsAfterVersion(ctx, customer.ID, latest.Version)
+	require.NoError(t, err)
+	require.Len(t, tail, len(customer.GetEvents()))
+
+	restored, err := customerdomain.NewCustomerFromSnapshot(latest, tail)
+	require.NoError(t, err)
+	require.Equal(t, customerdomain.CustomerStatusActive, restored.Status)
+	require.Equal(t, snapshotted.Version+1, restored.Version)
+
+	ids, err := eventRepo.FindCustomerIDs(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ids, customer.ID)
+}
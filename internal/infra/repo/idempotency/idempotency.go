@@ -0,0 +1,133 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	idempotencydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/idempotency"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// IdempotencyRepository is a repository for the idempotency keys of the mutating requests and their responses
+type IdempotencyRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(c *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// Claim claims the idempotency key of the record for the request in progress. The key which expired, or was abandoned by
+// the same request still in progress after its lock, is claimed again. When the key is held by another request, the record
+// of that request is returned together with idempotencydomain.ErrKeyAlreadyClaimed.
+func (r *IdempotencyRepository) Claim(ctx context.Context, record idempotencydomain.Record) (idempotencydomain.Record, error) {
+	_, err := r.Q.ClaimIdempotencyKey(ctx, query.ClaimIdempotencyKeyParams{
+		IdempotencyKey: record.Key,
+		Fingerprint:    record.Fingerprint,
+		CreatedAt:      pgtype.Timestamp{Time: record.CreatedAt, Valid: true},
+		LockedUntil:    pgtype.Timestamp{Time: record.LockedUntil, Valid: true},
+		ExpiresAt:      pgtype.Timestamp{Time: record.ExpiresAt, Valid: true},
+	})
+	if err == nil {
+		record.Response = nil
+		return record, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return idempotencydomain.Record{}, fmt.Errorf("claiming idempotency key: %w", err)
+	}
+
+	claimed, err := r.Find(ctx, record.Key)
+	if err != nil {
+		return idempotencydomain.Record{}, fmt.Errorf("claiming idempotency key: %w", err)
+	}
+
+	return claimed, fmt.Errorf("claiming idempotency key: %w", idempotencydomain.ErrKeyAlreadyClaimed)
+}
+
+// Find finds the record of the idempotency key
+func (r *IdempotencyRepository) Find(ctx context.Context, key string) (idempotencydomain.Record, error) {
+	row, err := r.Q.FindIdempotencyKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return idempotencydomain.Record{}, fmt.Errorf("finding idempotency key: %w", idempotencydomain.ErrKeyNotFound)
+		}
+
+		return idempotencydomain.Record{}, fmt.Errorf("finding idempotency key: %w", err)
+	}
+
+	record := idempotencydomain.Record{
+		Key:         row.IdempotencyKey,
+		Fingerprint: row.Fingerprint,
+		CreatedAt:   row.CreatedAt.Time,
+		LockedUntil: row.LockedUntil.Time,
+		ExpiresAt:   row.ExpiresAt.Time,
+	}
+
+	if row.StatusCode.Valid {
+		response := &idempotencydomain.Response{
+			StatusCode: int(row.StatusCode.Int32),
+			Body:       row.ResponseBody,
+		}
+
+		if err = json.Unmarshal(row.ResponseHeaders, &response.Header); err != nil {
+			return idempotencydomain.Record{}, fmt.Errorf("decoding idempotency key response headers: %w", err)
+		}
+
+		record.Response = response
+	}
+
+	return record, nil
+}
+
+// SaveResponse saves the response to the request which claimed the idempotency key, the key is completed
+func (r *IdempotencyRepository) SaveResponse(ctx context.Context, key string, response idempotencydomain.Response) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return fmt.Errorf("encoding idempotency key response headers: %w", err)
+	}
+
+	err = r.Q.SaveIdempotencyResponse(ctx, query.SaveIdempotencyResponseParams{
+		IdempotencyKey:  key,
+		StatusCode:      pgtype.Int4{Int32: int32(response.StatusCode), Valid: true},
+		ResponseHeaders: header,
+		ResponseBody:    response.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("saving idempotency key response: %w", err)
+	}
+
+	return nil
+}
+
+// Release releases the idempotency key claimed by the request which didn't complete, so the request can be retried with it.
+// The completed key is kept.
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	if err := r.Q.DeleteIdempotencyKey(ctx, key); err != nil {
+		return fmt.Errorf("releasing idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes the idempotency keys expired at the time, it returns the number of deleted keys
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, at time.Time) (int, error) {
+	deleted, err := r.Q.DeleteExpiredIdempotencyKeys(ctx, pgtype.Timestamp{Time: at, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired idempotency keys: %w", err)
+	}
+
+	return int(deleted), nil
+}
@@ -0,0 +1,117 @@
+//go:build integration
+
+package idempotency
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	idempotencydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/idempotency"
+)
+
+func testRecord(key, fingerprint string, now time.Time) idempotencydomain.Record {
+	return idempotencydomain.Record{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		LockedUntil: now.Add(time.Minute),
+		ExpiresAt:   now.Add(24 * time.Hour),
+	}
+}
+
+func TestIdempotencyRepository_Claim(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewIdempotencyRepository(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	claimed, err := repo.Claim(ctx, testRecord("deposit-1", "fingerprint-1", now))
+	require.NoError(t, err)
+	require.True(t, claimed.InProgress())
+
+	// The key in progress is held by the request which claimed it
+	held, err := repo.Claim(ctx, testRecord("deposit-1", "fingerprint-1", now))
+	require.ErrorIs(t, err, idempotencydomain.ErrKeyAlreadyClaimed)
+	require.True(t, held.InProgress())
+	require.Equal(t, "fingerprint-1", held.Fingerprint)
+
+	response := idempotencydomain.Response{
+		StatusCode: 200,
+		Header:     map[string][]string{"Content-Type": {"application/json"}},
+		Body:       []byte(`{"id":"1"}`),
+	}
+	require.NoError(t, repo.SaveResponse(ctx, "deposit-1", response))
+
+	// The completed key keeps the response, it isn't released
+	require.NoError(t, repo.Release(ctx, "deposit-1"))
+
+	held, err = repo.Claim(ctx, testRecord("deposit-1", "fingerprint-2", now))
+	require.ErrorIs(t, err, idempotencydomain.ErrKeyAlreadyClaimed)
+	require.False(t, held.InProgress())
+	require.Equal(t, "fingerprint-1", held.Fingerprint)
+	require.Equal(t, response, *held.Response)
+
+	// The key abandoned by the request in progress is claimed again by the same request only
+	_, err = repo.Claim(ctx, testRecord("deposit-2", "fingerprint-1", now.Add(-2*time.Minute)))
+	require.NoError(t, err)
+
+	_, err = repo.Claim(ctx, testRecord("deposit-2", "fingerprint-2", now))
+	require.ErrorIs(t, err, idempotencydomain.ErrKeyAlreadyClaimed)
+
+	_, err = repo.Claim(ctx, testRecord("deposit-2", "fingerprint-1", now))
+	require.NoError(t, err)
+
+	// The released key is claimed again
+	require.NoError(t, repo.Release(ctx, "deposit-2"))
+
+	_, err = repo.Find(ctx, "deposit-2")
+	require.ErrorIs(t, err, idempotencydomain.ErrKeyNotFound)
+
+	_, err = repo.Claim(ctx, testRecord("deposit-2", "fingerprint-2", now))
+	require.NoError(t, err)
+}
+
+func TestIdempotencyRepository_DeleteExpired(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewIdempotencyRepository(pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	_, err := repo.Claim(ctx, testRecord("expired", "fingerprint-1", now.Add(-48*time.Hour)))
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveResponse(ctx, "expired", idempotencydomain.Response{StatusCode: 201}))
+
+	_, err = repo.Claim(ctx, testRecord("valid", "fingerprint-1", now))
+	require.NoError(t, err)
+
+	// The expired key is claimed again by another request
+	_, err = repo.Claim(ctx, testRecord("expired", "fingerprint-2", now))
+	require.NoError(t, err)
+
+	_, err = repo.Claim(ctx, testRecord("purged", "fingerprint-1", now.Add(-48*time.Hour)))
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteExpired(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+
+	_, err = repo.Find(ctx, "purged")
+	require.ErrorIs(t, err, idempotencydomain.ErrKeyNotFound)
+
+	_, err = repo.Find(ctx, "valid")
+	require.NoError(t, err)
+}
@@ -0,0 +1,108 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// OutboxRepository is a repository for the outbox of the recorded events and the checkpoints of their publishers
+type OutboxRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(c *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// FindMessages finds up to limit events added to the outbox after the checkpoint, in the order they are published.
+// The events added by the transactions still in progress are left out together with the events following them,
+// so an event committed later never lands before the checkpoint of a publisher.
+func (r *OutboxRepository) FindMessages(ctx context.Context, after eventdomain.OutboxCheckpoint, limit int) ([]eventdomain.OutboxMessage, error) {
+	rows, err := r.Q.FindOutboxMessagesAfter(ctx, query.FindOutboxMessagesAfterParams{
+		TransactionID:  after.TransactionID,
+		SequenceNumber: after.SequenceNumber,
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding outbox messages: %w", err)
+	}
+
+	messages := make([]eventdomain.OutboxMessage, len(rows))
+	for i, row := range rows {
+		metadata, err := eventdomain.DecodeMetadata(row.EventMetadata)
+		if err != nil {
+			return nil, fmt.Errorf("finding outbox messages: %w", err)
+		}
+		metadata.CorrelationID = row.CorrelationID.Bytes
+		metadata.CausationID = row.CausationID.Bytes
+		metadata.ActorID = row.ActorID
+		metadata.ActorType = row.ActorType
+		metadata.SourceIP = row.SourceIp
+
+		messages[i] = eventdomain.OutboxMessage{
+			Checkpoint: eventdomain.OutboxCheckpoint{
+				TransactionID:  row.TransactionID,
+				SequenceNumber: row.SequenceNumber,
+			},
+			Event: &eventdomain.BaseEvent{
+				ID:               row.ID.Bytes,
+				ContextID:        row.ContextID.Bytes,
+				Origin:           row.EventOrigin,
+				Type:             row.EventType,
+				TypeVersion:      row.EventTypeVersion,
+				State:            row.EventState,
+				CreatedAt:        row.CreatedAt.Time,
+				ScheduledAt:      row.ScheduledAt.Time,
+				AggregateVersion: int(row.AggregateVersion),
+				Metadata:         metadata,
+				Data:             row.EventData,
+			},
+		}
+	}
+
+	return messages, nil
+}
+
+// FindCheckpoint finds the checkpoint of the last event published by the publisher,
+// the publisher which hasn't published any event yet starts from the beginning of the outbox
+func (r *OutboxRepository) FindCheckpoint(ctx context.Context, publisher string) (eventdomain.OutboxCheckpoint, error) {
+	checkpoint, err := r.Q.FindPublishCheckpoint(ctx, publisher)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return eventdomain.OutboxCheckpoint{}, nil
+		}
+
+		return eventdomain.OutboxCheckpoint{}, fmt.Errorf("finding publish checkpoint of %s: %w", publisher, err)
+	}
+
+	return eventdomain.OutboxCheckpoint{
+		TransactionID:  checkpoint.TransactionID,
+		SequenceNumber: checkpoint.SequenceNumber,
+	}, nil
+}
+
+// SaveCheckpoint saves the checkpoint of the last event published by the publisher
+func (r *OutboxRepository) SaveCheckpoint(ctx context.Context, publisher string, checkpoint eventdomain.OutboxCheckpoint) error {
+	err := r.Q.SavePublishCheckpoint(ctx, query.SavePublishCheckpointParams{
+		Publisher:      publisher,
+		TransactionID:  checkpoint.TransactionID,
+		SequenceNumber: checkpoint.SequenceNumber,
+	})
+	if err != nil {
+		return fmt.Errorf("saving publish checkpoint of %s: %w", publisher, err)
+	}
+
+	return nil
+}
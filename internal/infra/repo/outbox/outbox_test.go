@@ -0,0 +1,95 @@
+//go:build integration
+
+package outbox
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+	transactionrepo "github.com/stefanowiczd/ddd-case-01/internal/infra/repo/transaction"
+)
+
+func TestOutboxRepository_FindMessages(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewOutboxRepository(pool)
+	eventRepo := transactionrepo.NewTransactionEventRepository(pool)
+
+	amount, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	first, err := transactiondomain.NewTransfer(uuid.New(), uuid.New(), uuid.New(), amount)
+	require.NoError(t, err)
+
+	second, err := transactiondomain.NewTransfer(uuid.New(), uuid.New(), uuid.New(), amount)
+	require.NoError(t, err)
+
+	require.NoError(t, eventRepo.CreateEvents(ctx, 0, first.GetEvents()))
+	// The metadata of the request recording the events is published with them
+	metadataCtx := eventdomain.ContextWithMetadata(ctx, eventdomain.Metadata{IdempotencyKey: "transfer-2"})
+	require.NoError(t, eventRepo.CreateEvents(metadataCtx, 0, second.GetEvents()))
+
+	// The events rejected by the transaction aren't added to the outbox
+	require.ErrorIs(t, eventRepo.CreateEvents(ctx, 0, first.GetEvents()), eventdomain.ErrEventAlreadyExists)
+
+	messages, err := repo.FindMessages(ctx, eventdomain.OutboxCheckpoint{}, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, first.GetEvents()[0].GetID(), messages[0].Event.ID)
+	require.Equal(t, second.GetEvents()[0].GetID(), messages[1].Event.ID)
+	require.Equal(t, transactiondomain.TransactionInitiatedEventType.String(), messages[0].Event.Type)
+	require.NotEmpty(t, messages[0].Event.Data)
+	require.Equal(t, eventdomain.Metadata{}, messages[0].Event.Metadata)
+	require.Equal(t, eventdomain.Metadata{IdempotencyKey: "transfer-2"}, messages[1].Event.Metadata)
+
+	// The events committed by the later transaction follow the checkpoint of the earlier one
+	require.Less(t, messages[0].Checkpoint.TransactionID, messages[1].Checkpoint.TransactionID)
+
+	messages, err = repo.FindMessages(ctx, messages[0].Checkpoint, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, second.GetEvents()[0].GetID(), messages[0].Event.ID)
+
+	messages, err = repo.FindMessages(ctx, messages[0].Checkpoint, 10)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
+
+func TestOutboxRepository_Checkpoint(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewOutboxRepository(pool)
+
+	// The publisher which hasn't published any event yet starts from the beginning of the outbox
+	checkpoint, err := repo.FindCheckpoint(ctx, "nats")
+	require.NoError(t, err)
+	require.Equal(t, eventdomain.OutboxCheckpoint{}, checkpoint)
+
+	require.NoError(t, repo.SaveCheckpoint(ctx, "nats", eventdomain.OutboxCheckpoint{TransactionID: 10, SequenceNumber: 2}))
+	require.NoError(t, repo.SaveCheckpoint(ctx, "nats", eventdomain.OutboxCheckpoint{TransactionID: 12, SequenceNumber: 5}))
+
+	checkpoint, err = repo.FindCheckpoint(ctx, "nats")
+	require.NoError(t, err)
+	require.Equal(t, eventdomain.OutboxCheckpoint{TransactionID: 12, SequenceNumber: 5}, checkpoint)
+
+	checkpoint, err = repo.FindCheckpoint(ctx, "file")
+	require.NoError(t, err)
+	require.Equal(t, eventdomain.OutboxCheckpoint{}, checkpoint)
+}
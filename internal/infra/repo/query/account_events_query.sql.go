@@ -11,13 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createAccountEvent = `-- name: CreateAccountEvent :one
-INSERT INTO events (id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, retry, max_retry, event_data)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-RETURNING id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data
-`
-
-type CreateAccountEventParams struct {
+type CreateAccountEventsParams struct {
 	ID               pgtype.UUID
 	ContextID        pgtype.UUID
 	EventOrigin      string
@@ -29,43 +23,17 @@ type CreateAccountEventParams struct {
 	Retry            int32
 	MaxRetry         int32
 	EventData        []byte
-}
-
-func (q *Queries) CreateAccountEvent(ctx context.Context, arg CreateAccountEventParams) (Event, error) {
-	row := q.db.QueryRow(ctx, createAccountEvent,
-		arg.ID,
-		arg.ContextID,
-		arg.EventOrigin,
-		arg.EventType,
-		arg.EventTypeVersion,
-		arg.EventState,
-		arg.CreatedAt,
-		arg.ScheduledAt,
-		arg.Retry,
-		arg.MaxRetry,
-		arg.EventData,
-	)
-	var i Event
-	err := row.Scan(
-		&i.ID,
-		&i.ContextID,
-		&i.EventOrigin,
-		&i.EventType,
-		&i.EventTypeVersion,
-		&i.EventState,
-		&i.CreatedAt,
-		&i.ScheduledAt,
-		&i.StartedAt,
-		&i.CompletedAt,
-		&i.Retry,
-		&i.MaxRetry,
-		&i.EventData,
-	)
-	return i, err
+	AggregateVersion int64
+	EventMetadata    []byte
+	CorrelationID    pgtype.UUID
+	CausationID      pgtype.UUID
+	ActorID          string
+	ActorType        string
+	SourceIp         string
 }
 
 const findAccountEventByID = `-- name: FindAccountEventByID :one
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 WHERE id = $1 LIMIT 1
 `
 
@@ -86,6 +54,147 @@ func (q *Queries) FindAccountEventByID(ctx context.Context, id pgtype.UUID) (Eve
 		&i.Retry,
 		&i.MaxRetry,
 		&i.EventData,
+		&i.LeaseExpiresAt,
+		&i.SequenceNumber,
+		&i.AggregateVersion,
+		&i.EventMetadata,
+		&i.CorrelationID,
+		&i.CausationID,
+		&i.ActorID,
+		&i.ActorType,
+		&i.SourceIp,
+		&i.FailureReason,
 	)
 	return i, err
 }
+
+const findAccountEventsByContextID = `-- name: FindAccountEventsByContextID :many
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
+WHERE context_id = $1
+ORDER BY aggregate_version
+`
+
+func (q *Queries) FindAccountEventsByContextID(ctx context.Context, contextID pgtype.UUID) ([]Event, error) {
+	rows, err := q.db.Query(ctx, findAccountEventsByContextID, contextID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.ContextID,
+			&i.EventOrigin,
+			&i.EventType,
+			&i.EventTypeVersion,
+			&i.EventState,
+			&i.CreatedAt,
+			&i.ScheduledAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.Retry,
+			&i.MaxRetry,
+			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAccountEventsByContextIDAfterVersion = `-- name: FindAccountEventsByContextIDAfterVersion :many
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
+WHERE context_id = $1 AND aggregate_version > $2
+ORDER BY aggregate_version
+`
+
+type FindAccountEventsByContextIDAfterVersionParams struct {
+	ContextID        pgtype.UUID
+	AggregateVersion int64
+}
+
+func (q *Queries) FindAccountEventsByContextIDAfterVersion(ctx context.Context, arg FindAccountEventsByContextIDAfterVersionParams) ([]Event, error) {
+	rows, err := q.db.Query(ctx, findAccountEventsByContextIDAfterVersion, arg.ContextID, arg.AggregateVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.ContextID,
+			&i.EventOrigin,
+			&i.EventType,
+			&i.EventTypeVersion,
+			&i.EventState,
+			&i.CreatedAt,
+			&i.ScheduledAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.Retry,
+			&i.MaxRetry,
+			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAccountIDs = `-- name: FindAccountIDs :many
+SELECT context_id FROM events
+WHERE event_type = 'account.created'
+ORDER BY sequence_number
+`
+
+func (q *Queries) FindAccountIDs(ctx context.Context) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, findAccountIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var context_id pgtype.UUID
+		if err := rows.Scan(&context_id); err != nil {
+			return nil, err
+		}
+		items = append(items, context_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -21,7 +21,7 @@ type CreateAccountParams struct {
 	ID            pgtype.UUID
 	CustomerID    pgtype.UUID
 	AccountNumber string
-	Balance       float64
+	Balance       int64
 	Currency      string
 	Status        string
 	CreatedAt     pgtype.Timestamp
@@ -61,7 +61,7 @@ WHERE id = $1
 
 type DepositAccountMoneyParams struct {
 	ID      pgtype.UUID
-	Balance float64
+	Balance int64
 }
 
 func (q *Queries) DepositAccountMoney(ctx context.Context, arg DepositAccountMoneyParams) error {
@@ -69,6 +69,27 @@ func (q *Queries) DepositAccountMoney(ctx context.Context, arg DepositAccountMon
 	return err
 }
 
+const findAccountByAccountNumber = `-- name: FindAccountByAccountNumber :one
+SELECT id, account_number, customer_id, balance, currency, status, created_at, updated_at FROM accounts
+WHERE account_number = $1 LIMIT 1
+`
+
+func (q *Queries) FindAccountByAccountNumber(ctx context.Context, accountNumber string) (Account, error) {
+	row := q.db.QueryRow(ctx, findAccountByAccountNumber, accountNumber)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.AccountNumber,
+		&i.CustomerID,
+		&i.Balance,
+		&i.Currency,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const findAccountByID = `-- name: FindAccountByID :one
 SELECT id, account_number, customer_id, balance, currency, status, created_at, updated_at FROM accounts
 WHERE id = $1 LIMIT 1
@@ -148,7 +169,7 @@ WHERE id = $1
 
 type WithdrawAccountMoneyParams struct {
 	ID      pgtype.UUID
-	Balance float64
+	Balance int64
 }
 
 func (q *Queries) WithdrawAccountMoney(ctx context.Context, arg WithdrawAccountMoneyParams) error {
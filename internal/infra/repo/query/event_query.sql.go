@@ -10,7 +10,7 @@ import (
 )
 
 const findEvents = `-- name: FindEvents :many
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 ORDER BY scheduled_at DESC
 `
 
@@ -37,6 +37,16 @@ func (q *Queries) FindEvents(ctx context.Context) ([]Event, error) {
 			&i.Retry,
 			&i.MaxRetry,
 			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
 		); err != nil {
 			return nil, err
 		}
@@ -49,7 +59,7 @@ func (q *Queries) FindEvents(ctx context.Context) ([]Event, error) {
 }
 
 const findEventsByOrigin = `-- name: FindEventsByOrigin :many
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 WHERE event_origin = $1
 ORDER BY scheduled_at DESC
 `
@@ -77,6 +87,16 @@ func (q *Queries) FindEventsByOrigin(ctx context.Context, eventOrigin string) ([
 			&i.Retry,
 			&i.MaxRetry,
 			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
 		); err != nil {
 			return nil, err
 		}
@@ -89,7 +109,7 @@ func (q *Queries) FindEventsByOrigin(ctx context.Context, eventOrigin string) ([
 }
 
 const findEventsByOriginAndType = `-- name: FindEventsByOriginAndType :many
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 WHERE event_origin = $1 AND event_type = $2
 ORDER BY scheduled_at DESC
 `
@@ -122,6 +142,16 @@ func (q *Queries) FindEventsByOriginAndType(ctx context.Context, arg FindEventsB
 			&i.Retry,
 			&i.MaxRetry,
 			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
 		); err != nil {
 			return nil, err
 		}
@@ -134,7 +164,7 @@ func (q *Queries) FindEventsByOriginAndType(ctx context.Context, arg FindEventsB
 }
 
 const findEventsByOriginAndTypeAndState = `-- name: FindEventsByOriginAndTypeAndState :many
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 WHERE event_origin = $1 AND event_type = $2 AND event_state = $3
 ORDER BY scheduled_at DESC
 `
@@ -168,6 +198,16 @@ func (q *Queries) FindEventsByOriginAndTypeAndState(ctx context.Context, arg Fin
 			&i.Retry,
 			&i.MaxRetry,
 			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
 		); err != nil {
 			return nil, err
 		}
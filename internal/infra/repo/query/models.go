@@ -8,11 +8,23 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccessAudit struct {
+	ID            pgtype.UUID
+	PrincipalID   string
+	PrincipalType string
+	Roles         []string
+	ResourceType  string
+	ResourceID    pgtype.UUID
+	CustomerID    pgtype.UUID
+	CorrelationID pgtype.UUID
+	CreatedAt     pgtype.Timestamp
+}
+
 type Account struct {
 	ID            pgtype.UUID
 	AccountNumber string
 	CustomerID    pgtype.UUID
-	Balance       float64
+	Balance       int64
 	Currency      string
 	Status        string
 	CreatedAt     pgtype.Timestamp
@@ -37,6 +49,33 @@ type Customer struct {
 	UpdatedAt      pgtype.Timestamp
 }
 
+type CustomerCredential struct {
+	ID             pgtype.UUID
+	CustomerID     pgtype.UUID
+	PasswordHash   string
+	TotpSecret     string
+	TotpEnabled    bool
+	TotpLastStep   int64
+	FailedAttempts int32
+	LockedUntil    pgtype.Timestamp
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+	Version        int32
+}
+
+type CustomerSession struct {
+	ID               pgtype.UUID
+	CustomerID       pgtype.UUID
+	AccessTokenHash  string
+	RefreshTokenHash string
+	MfaPending       bool
+	AccessExpiresAt  pgtype.Timestamp
+	RefreshExpiresAt pgtype.Timestamp
+	CreatedAt        pgtype.Timestamp
+	RevokedAt        pgtype.Timestamp
+	Version          int32
+}
+
 type Event struct {
 	ID               pgtype.UUID
 	ContextID        pgtype.UUID
@@ -51,4 +90,77 @@ type Event struct {
 	Retry            int32
 	MaxRetry         int32
 	EventData        []byte
+	LeaseExpiresAt   pgtype.Timestamp
+	SequenceNumber   int64
+	AggregateVersion int64
+	EventMetadata    []byte
+	CorrelationID    pgtype.UUID
+	CausationID      pgtype.UUID
+	ActorID          string
+	ActorType        string
+	SourceIp         string
+	FailureReason    string
+}
+
+type EventAudit struct {
+	ID            pgtype.UUID
+	EventID       pgtype.UUID
+	Action        string
+	Actor         string
+	Reason        string
+	PreviousState string
+	CreatedAt     pgtype.Timestamp
+}
+
+type IdempotencyKey struct {
+	IdempotencyKey  string
+	Fingerprint     string
+	StatusCode      pgtype.Int4
+	ResponseHeaders []byte
+	ResponseBody    []byte
+	CreatedAt       pgtype.Timestamp
+	LockedUntil     pgtype.Timestamp
+	ExpiresAt       pgtype.Timestamp
+}
+
+type Outbox struct {
+	SequenceNumber int64
+	EventID        pgtype.UUID
+	TransactionID  int64
+	CreatedAt      pgtype.Timestamp
+}
+
+type ProjectionCheckpoint struct {
+	Projection     string
+	CompletedAt    pgtype.Timestamp
+	SequenceNumber int64
+	UpdatedAt      pgtype.Timestamp
+}
+
+type PublishCheckpoint struct {
+	Publisher      string
+	TransactionID  int64
+	SequenceNumber int64
+	UpdatedAt      pgtype.Timestamp
+}
+
+type Snapshot struct {
+	ContextID        pgtype.UUID
+	AggregateType    string
+	AggregateVersion int64
+	SnapshotVersion  string
+	SnapshotData     []byte
+	CreatedAt        pgtype.Timestamp
+}
+
+type Transaction struct {
+	ID              pgtype.UUID
+	SourceAccountID pgtype.UUID
+	TargetAccountID pgtype.UUID
+	Amount          int64
+	Currency        string
+	Status          string
+	FailureReason   pgtype.Text
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
 }
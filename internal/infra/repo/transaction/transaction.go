@@ -0,0 +1,63 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// TransactionRepository is a repository for transaction operations
+type TransactionRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewTransactionRepository creates a new transaction repository
+func NewTransactionRepository(c *pgxpool.Pool) *TransactionRepository {
+	return &TransactionRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// FindByID retrieves a transaction by its ID
+func (r *TransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*transactiondomain.Transaction, error) {
+	transaction, err := r.Q.FindTransactionByID(
+		ctx,
+		pgtype.UUID{Bytes: id, Valid: true},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("finding transaction by id: %w", transactiondomain.ErrTransactionNotFound)
+		}
+
+		return nil, fmt.Errorf("finding transaction by id: %w", err)
+	}
+
+	amount, err := money.New(transaction.Amount, money.Currency(transaction.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("mapping transaction amount: finding transaction by id: %w", err)
+	}
+
+	return &transactiondomain.Transaction{
+		ID: transaction.ID.Bytes,
+		Transfer: transactiondomain.Transfer{
+			SourceAccountID: transaction.SourceAccountID.Bytes,
+			TargetAccountID: transaction.TargetAccountID.Bytes,
+			Amount:          amount,
+		},
+		Status:        transactiondomain.TransactionStatus(transaction.Status),
+		FailureReason: transaction.FailureReason.String,
+		CreatedAt:     transaction.CreatedAt.Time,
+		UpdatedAt:     transaction.UpdatedAt.Time,
+	}, nil
+}
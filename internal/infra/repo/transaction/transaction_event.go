@@ -0,0 +1,136 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/repo/query"
+)
+
+// TransactionEventRepository is a repository for transaction event operations
+type TransactionEventRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewTransactionEventRepository creates a new transaction event repository
+func NewTransactionEventRepository(c *pgxpool.Pool) *TransactionEventRepository {
+	return &TransactionEventRepository{
+		Conn: c,
+		Q:    query.New(c),
+	}
+}
+
+// CreateEvents creates transaction events in a single database transaction.
+// The events are recorded after the expected version of the transaction, event.ErrConcurrencyConflict is returned
+// when another command recorded the next version in the meantime. The events are added to the outbox in the same transaction,
+// so they are published once committed.
+func (r *TransactionEventRepository) CreateEvents(ctx context.Context, expectedVersion int, events []transactiondomain.Event) error {
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: creating transaction events: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	md := event.MetadataFromContext(ctx)
+	metadata, err := event.EncodeMetadata(md)
+	if err != nil {
+		return fmt.Errorf("creating transaction events: %w", err)
+	}
+
+	ids := make([]pgtype.UUID, len(events))
+	for i, eventObject := range events {
+		ids[i] = pgtype.UUID{Bytes: eventObject.GetID(), Valid: true}
+
+		data, err := event.Encode(eventObject)
+		if err != nil {
+			return fmt.Errorf("creating transaction event: %w", err)
+		}
+
+		_, err = qtx.CreateTransactionEvent(
+			ctx,
+			query.CreateTransactionEventParams{
+				ID:               pgtype.UUID{Bytes: eventObject.GetID(), Valid: true},
+				ContextID:        pgtype.UUID{Bytes: eventObject.GetContextID(), Valid: true},
+				EventOrigin:      eventObject.GetOrigin(),
+				EventType:        eventObject.GetType(),
+				EventTypeVersion: eventObject.GetTypeVersion(),
+				EventState:       eventObject.GetState(),
+				CreatedAt:        pgtype.Timestamp{Time: eventObject.GetCreatedAt(), Valid: true},
+				ScheduledAt:      pgtype.Timestamp{Time: eventObject.GetScheduledAt(), Valid: true},
+				Retry:            int32(eventObject.GetRetry()),
+				MaxRetry:         int32(eventObject.GetMaxRetry()),
+				EventData:        data,
+				AggregateVersion: int64(expectedVersion + 1 + i),
+				EventMetadata:    metadata,
+				CorrelationID:    pgtype.UUID{Bytes: md.CorrelationID, Valid: md.CorrelationID != uuid.Nil},
+				CausationID:      pgtype.UUID{Bytes: md.CausationID, Valid: md.CausationID != uuid.Nil},
+				ActorID:          md.ActorID,
+				ActorType:        md.ActorType,
+				SourceIp:         md.SourceIP,
+			},
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+				if pgErr.ConstraintName == query.EVENTS_AGGREGATE_VERSION_CONSTRAINT {
+					return fmt.Errorf("creating transaction event in version %d: %w", expectedVersion+1+i, event.ErrConcurrencyConflict)
+				}
+
+				return fmt.Errorf("creating transaction event: %w", event.ErrEventAlreadyExists)
+			}
+
+			return fmt.Errorf("creating transaction event: %w", err)
+		}
+	}
+
+	if err = qtx.CreateOutboxMessages(ctx, ids); err != nil {
+		return fmt.Errorf("creating transaction events: adding events to outbox: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: creating transaction events: %w", err)
+	}
+
+	return nil
+}
+
+// FindTransactionEventByID finds a transaction event by id
+func (r *TransactionEventRepository) FindTransactionEventByID(ctx context.Context, id uuid.UUID) (BaseEvent, error) {
+	ev, err := r.Q.FindTransactionEventByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &event.BaseEvent{}, fmt.Errorf("finding transaction event by id: %w", event.ErrEventNotFound)
+		}
+
+		return &event.BaseEvent{}, fmt.Errorf("finding transaction event by id: %w", err)
+	}
+
+	return &event.BaseEvent{
+		ID:               ev.ID.Bytes,
+		ContextID:        ev.ContextID.Bytes,
+		Origin:           ev.EventOrigin,
+		Type:             ev.EventType,
+		TypeVersion:      ev.EventTypeVersion,
+		State:            ev.EventState,
+		CreatedAt:        ev.CreatedAt.Time,
+		ScheduledAt:      ev.ScheduledAt.Time,
+		StartedAt:        ev.StartedAt.Time,
+		CompletedAt:      ev.CompletedAt.Time,
+		Retry:            int(ev.Retry),
+		MaxRetry:         int(ev.MaxRetry),
+		AggregateVersion: int(ev.AggregateVersion),
+		Data:             ev.EventData,
+	}, nil
+}
@@ -0,0 +1,53 @@
+//go:build integration
+
+package transaction
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+func testAmount(t *testing.T) money.Money {
+	amount, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	return amount
+}
+
+func TestTransactionEventRepository_CreateEvents(t *testing.T) {
+	// Set keepContainer to true to keep the container running after the test
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewTransactionEventRepository(pool)
+
+	transaction, err := transactiondomain.NewTransfer(uuid.New(), uuid.New(), uuid.New(), testAmount(t))
+	require.NoError(t, err)
+
+	initiatedEvent := transaction.GetEvents()[0]
+
+	_, err = repo.FindTransactionEventByID(ctx, initiatedEvent.GetID())
+	require.ErrorIs(t, err, event.ErrEventNotFound)
+
+	require.NoError(t, repo.CreateEvents(ctx, 0, transaction.GetEvents()))
+
+	ev, err := repo.FindTransactionEventByID(ctx, initiatedEvent.GetID())
+	require.NoError(t, err)
+	require.Equal(t, initiatedEvent.GetContextID(), ev.GetContextID())
+	require.Equal(t, transactiondomain.TransactionInitiatedEventType.String(), ev.GetType())
+
+	// Recording the same saga step again is rejected, so the step is applied only once
+	err = repo.CreateEvents(ctx, 0, transaction.GetEvents())
+	require.ErrorIs(t, err, event.ErrEventAlreadyExists)
+}
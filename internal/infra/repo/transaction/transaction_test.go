@@ -0,0 +1,29 @@
+//go:build integration
+
+package transaction
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+func TestTransactionRepository_FindByID_NoRows(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewTransactionRepository(pool)
+
+	transaction, err := repo.FindByID(ctx, uuid.New())
+
+	require.ErrorIs(t, err, transactiondomain.ErrTransactionNotFound)
+	require.Nil(t, transaction)
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+// APIKeyHeader is the header carrying the static API key of the service calling the API
+const APIKeyHeader = "X-API-Key"
+
+// APIKey is the static API key of the service calling the API, only the SHA-256 hash of the key is configured
+type APIKey struct {
+	Name  string                `json:"name"`  // Name of the service, it's the ID of the principal
+	Hash  string                `json:"hash"`  // Hex encoded SHA-256 hash of the key
+	Roles []identitydomain.Role `json:"roles"` // Roles the service acts in, the customer role isn't allowed
+}
+
+// APIKeys authenticates the service to service calls carrying the API key in the X-API-Key header
+type APIKeys struct {
+	keys   []APIKey
+	hashes [][]byte
+}
+
+// LoadAPIKeys loads the API keys from the JSON file holding the list of the keys
+func LoadAPIKeys(file string) (*APIKeys, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading api keys file: %w", err)
+	}
+
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("decoding api keys file: %w", err)
+	}
+
+	return NewAPIKeys(keys)
+}
+
+// NewAPIKeys creates a new authenticator of the API keys, the keys are checked to be unique and to have known roles
+func NewAPIKeys(keys []APIKey) (*APIKeys, error) {
+	a := &APIKeys{}
+
+	names := map[string]bool{}
+	for _, key := range keys {
+		if key.Name == "" {
+			return nil, errors.New("api key name is missing")
+		}
+
+		if names[key.Name] {
+			return nil, fmt.Errorf("api key %s is duplicated", key.Name)
+		}
+		names[key.Name] = true
+
+		hash, err := hex.DecodeString(key.Hash)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("api key %s hash must be a hex encoded sha-256 hash", key.Name)
+		}
+
+		for _, role := range key.Roles {
+			if !role.Valid() || role == identitydomain.RoleCustomer {
+				return nil, fmt.Errorf("api key %s role %q not allowed", key.Name, role)
+			}
+		}
+
+		a.keys = append(a.keys, key)
+		a.hashes = append(a.hashes, hash)
+	}
+
+	return a, nil
+}
+
+// Authenticate authenticates the request with the API key. It returns identitydomain.ErrUnauthenticated
+// when the request doesn't carry the API key, so the request can be authenticated otherwise.
+func (a *APIKeys) Authenticate(r *http.Request) (identitydomain.Principal, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return identitydomain.Principal{}, identitydomain.ErrUnauthenticated
+	}
+
+	hash := sha256.Sum256([]byte(key))
+
+	// All the keys are compared in constant time, so the time of the comparison doesn't tell which key is close to the sent one
+	found := -1
+	for i, h := range a.hashes {
+		if subtle.ConstantTimeCompare(hash[:], h) == 1 {
+			found = i
+		}
+	}
+
+	if found < 0 {
+		return identitydomain.Principal{}, fmt.Errorf("api key not recognized: %w", identitydomain.ErrInvalidCredentials)
+	}
+
+	return identitydomain.Principal{
+		ID:    a.keys[found].Name,
+		Type:  identitydomain.PrincipalTypeService,
+		Roles: a.keys[found].Roles,
+	}, nil
+}
@@ -0,0 +1,124 @@
+//go:build unit
+
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+// hash returns the hex encoded SHA-256 hash of the API key
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAPIKeys_Authenticate(t *testing.T) {
+	keys, err := NewAPIKeys([]APIKey{
+		{Name: "payments", Hash: hash("payments-key"), Roles: []identitydomain.Role{identitydomain.RoleTeller}},
+		{Name: "reporting", Hash: hash("reporting-key"), Roles: []identitydomain.Role{identitydomain.RoleAuditor}},
+	})
+	require.NoError(t, err)
+
+	type testCaseExpected struct {
+		principal identitydomain.Principal
+		err       error
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		expected testCaseExpected
+	}{
+		{
+			name: "should authenticate service with api key",
+			key:  "reporting-key",
+			expected: testCaseExpected{
+				principal: identitydomain.Principal{ID: "reporting", Type: identitydomain.PrincipalTypeService, Roles: []identitydomain.Role{identitydomain.RoleAuditor}},
+			},
+		},
+		{
+			name:     "shouldn't authenticate request without api key",
+			expected: testCaseExpected{err: identitydomain.ErrUnauthenticated},
+		},
+		{
+			name:     "should reject unknown api key",
+			key:      "payments-key-2",
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+
+			principal, err := keys.Authenticate(req)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.principal, principal)
+		})
+	}
+}
+
+func TestNewAPIKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []APIKey
+	}{
+		{
+			name: "should reject key without name",
+			keys: []APIKey{{Hash: hash("key")}},
+		},
+		{
+			name: "should reject duplicated key",
+			keys: []APIKey{{Name: "payments", Hash: hash("key-1")}, {Name: "payments", Hash: hash("key-2")}},
+		},
+		{
+			name: "should reject key which isn't sha-256 hash",
+			keys: []APIKey{{Name: "payments", Hash: "payments-key"}},
+		},
+		{
+			name: "should reject key with customer role",
+			keys: []APIKey{{Name: "payments", Hash: hash("key"), Roles: []identitydomain.Role{identitydomain.RoleCustomer}}},
+		},
+		{
+			name: "should reject key with unknown role",
+			keys: []APIKey{{Name: "payments", Hash: hash("key"), Roles: []identitydomain.Role{"admin"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPIKeys(tt.keys)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	file := writeKeyFile(t, []byte(`[{"name":"payments","hash":"`+hash("payments-key")+`","roles":["teller","back-office"]}]`))
+
+	keys, err := LoadAPIKeys(file)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+	req.Header.Set(APIKeyHeader, "payments-key")
+
+	principal, err := keys.Authenticate(req)
+	require.NoError(t, err)
+	require.Equal(t, []identitydomain.Role{identitydomain.RoleTeller, identitydomain.RoleBackOffice}, principal.Roles)
+}
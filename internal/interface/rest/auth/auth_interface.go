@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"context"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+//go:generate mockgen -destination=./mock/auth_mock.go -package=mock -source=./auth_interface.go
+
+// SessionService defines the interface for authenticating the customers with the access tokens of their sessions
+type SessionService interface {
+	// AuthenticateSession returns the principal of the customer the access token was issued to,
+	// it returns identitydomain.ErrInvalidCredentials when the access token isn't valid, i.e. it's expired or the session ended
+	AuthenticateSession(ctx context.Context, accessToken string) (identitydomain.Principal, error)
+}
@@ -0,0 +1,293 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+const (
+	// AlgorithmHS256 is the HMAC SHA-256 signature of the token, the key is the secret shared with the issuer
+	AlgorithmHS256 = "HS256"
+	// AlgorithmRS256 is the RSA PKCS #1 v1.5 SHA-256 signature of the token, the key is the public key of the issuer
+	AlgorithmRS256 = "RS256"
+)
+
+// JWTConfig holds the configuration of the verifier of the bearer tokens
+type JWTConfig struct {
+	Algorithm string        // Algorithm the tokens are signed with, the tokens signed with any other are rejected
+	KeyFile   string        // File holding the HS256 secret or the RS256 PEM encoded public key (or certificate)
+	Issuer    string        // Expected issuer (iss) of the tokens, not checked when empty
+	Audience  string        // Expected audience (aud) of the tokens, not checked when empty
+	Leeway    time.Duration // Clock skew allowed when the expiration and not before times are checked
+}
+
+// DefaultJWTConfig returns the default configuration of the verifier of the tokens signed with the key from the file
+func DefaultJWTConfig(algorithm, keyFile string) JWTConfig {
+	return JWTConfig{
+		Algorithm: algorithm,
+		KeyFile:   keyFile,
+		Leeway:    30 * time.Second,
+	}
+}
+
+// JWTVerifier authenticates the requests carrying the JSON Web Token in the Authorization: Bearer header.
+// The keys are configured locally, no key set is fetched from the issuer.
+type JWTVerifier struct {
+	config    JWTConfig
+	secret    []byte
+	publicKey *rsa.PublicKey
+}
+
+// claims are the claims of the token the principal is created from
+type claims struct {
+	Subject    string   `json:"sub"`
+	Issuer     string   `json:"iss"`
+	Audience   audience `json:"aud"`
+	ExpiresAt  *int64   `json:"exp"`
+	NotBefore  *int64   `json:"nbf"`
+	Roles      []string `json:"roles"`
+	CustomerID string   `json:"customer_id"`
+}
+
+// audience holds the audience of the token given as a single audience or as a list of audiences
+type audience []string
+
+// UnmarshalJSON decodes the audience given as a single audience or as a list of audiences
+func (a *audience) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		var single string
+		if err := json.Unmarshal(b, &single); err != nil {
+			return err
+		}
+
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+
+	*a = multiple
+	return nil
+}
+
+// NewJWTVerifier creates a new verifier of the tokens, the key is read from the key file of the configuration
+func NewJWTVerifier(config JWTConfig) (*JWTVerifier, error) {
+	key, err := os.ReadFile(config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading jwt key file: %w", err)
+	}
+
+	v := &JWTVerifier{config: config}
+
+	switch config.Algorithm {
+	case AlgorithmHS256:
+		v.secret = bytes.TrimSpace(key)
+		if len(v.secret) < sha256.Size {
+			return nil, fmt.Errorf("jwt secret must be at least %d bytes long", sha256.Size)
+		}
+	case AlgorithmRS256:
+		v.publicKey, err = parsePublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("parsing jwt public key: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("jwt algorithm %q not supported", config.Algorithm)
+	}
+
+	return v, nil
+}
+
+// parsePublicKey parses the PEM encoded RSA public key, given as the PKIX or PKCS #1 public key or as the certificate
+func parsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+
+	var key any
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = parsed
+	case "RSA PUBLIC KEY":
+		parsed, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = parsed
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = cert.PublicKey
+	default:
+		return nil, fmt.Errorf("pem block %q not supported", block.Type)
+	}
+
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key isn't an rsa key")
+	}
+
+	return publicKey, nil
+}
+
+// Authenticate authenticates the request with the bearer token. It returns identitydomain.ErrUnauthenticated
+// when the request doesn't carry the bearer token, so the request can be authenticated otherwise.
+func (v *JWTVerifier) Authenticate(r *http.Request) (identitydomain.Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return identitydomain.Principal{}, identitydomain.ErrUnauthenticated
+	}
+
+	return v.Verify(strings.TrimSpace(token), time.Now().UTC())
+}
+
+// Verify verifies the signature and the claims of the token at the time and returns the principal it was issued to
+func (v *JWTVerifier) Verify(token string, now time.Time) (identitydomain.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return identitydomain.Principal{}, fmt.Errorf("malformed token: %w", identitydomain.ErrInvalidCredentials)
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return identitydomain.Principal{}, fmt.Errorf("decoding token header: %w: %w", identitydomain.ErrInvalidCredentials, err)
+	}
+
+	// The algorithm is the configured one, so the token can't pick a weaker one, i.e. none, or use the public key as the HMAC secret
+	if header.Algorithm != v.config.Algorithm {
+		return identitydomain.Principal{}, fmt.Errorf("token algorithm %q not accepted: %w", header.Algorithm, identitydomain.ErrInvalidCredentials)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return identitydomain.Principal{}, fmt.Errorf("decoding token signature: %w: %w", identitydomain.ErrInvalidCredentials, err)
+	}
+
+	if err := v.verifySignature(parts[0]+"."+parts[1], signature); err != nil {
+		return identitydomain.Principal{}, fmt.Errorf("verifying token signature: %w: %w", identitydomain.ErrInvalidCredentials, err)
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return identitydomain.Principal{}, fmt.Errorf("decoding token claims: %w: %w", identitydomain.ErrInvalidCredentials, err)
+	}
+
+	if err := v.verifyClaims(c, now); err != nil {
+		return identitydomain.Principal{}, fmt.Errorf("verifying token claims: %w: %w", identitydomain.ErrInvalidCredentials, err)
+	}
+
+	return principal(c)
+}
+
+// verifySignature verifies the signature of the signed header and claims of the token
+func (v *JWTVerifier) verifySignature(signed string, signature []byte) error {
+	switch v.config.Algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("signature doesn't match")
+		}
+
+		return nil
+	case AlgorithmRS256:
+		digest := sha256.Sum256([]byte(signed))
+		return rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, digest[:], signature)
+	default:
+		return fmt.Errorf("algorithm %q not supported", v.config.Algorithm)
+	}
+}
+
+// verifyClaims verifies the token is issued for the API and is valid at the time, the token without the expiration time is rejected
+func (v *JWTVerifier) verifyClaims(c claims, now time.Time) error {
+	if c.Subject == "" {
+		return errors.New("subject is missing")
+	}
+
+	if c.ExpiresAt == nil {
+		return errors.New("expiration time is missing")
+	}
+
+	if now.After(time.Unix(*c.ExpiresAt, 0).Add(v.config.Leeway)) {
+		return errors.New("token expired")
+	}
+
+	if c.NotBefore != nil && now.Add(v.config.Leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return errors.New("token not valid yet")
+	}
+
+	if v.config.Issuer != "" && c.Issuer != v.config.Issuer {
+		return fmt.Errorf("issuer %q not accepted", c.Issuer)
+	}
+
+	if v.config.Audience != "" && !slices.Contains(c.Audience, v.config.Audience) {
+		return fmt.Errorf("audience %v not accepted", []string(c.Audience))
+	}
+
+	return nil
+}
+
+// principal creates the user principal from the claims of the token, the customer is required for the customer role
+func principal(c claims) (identitydomain.Principal, error) {
+	p := identitydomain.Principal{
+		ID:   c.Subject,
+		Type: identitydomain.PrincipalTypeUser,
+	}
+
+	for _, name := range c.Roles {
+		role := identitydomain.Role(name)
+		if !role.Valid() {
+			return identitydomain.Principal{}, fmt.Errorf("role %q not recognized: %w", name, identitydomain.ErrInvalidCredentials)
+		}
+		p.Roles = append(p.Roles, role)
+	}
+
+	if p.HasAnyRole(identitydomain.RoleCustomer) {
+		customerID, err := uuid.Parse(c.CustomerID)
+		if err != nil {
+			return identitydomain.Principal{}, fmt.Errorf("customer id of customer role: %w: %w", identitydomain.ErrInvalidCredentials, err)
+		}
+		p.CustomerID = customerID
+	}
+
+	return p, nil
+}
+
+// decodeSegment decodes the base64url encoded JSON segment of the token
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,351 @@
+//go:build unit
+
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// sign returns the token of the claims signed with the HS256 secret or the RS256 private key
+func sign(t *testing.T, algorithm string, key any, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": algorithm, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// writeKeyFile writes the key to the file in the test directory and returns its path
+func writeKeyFile(t *testing.T, data []byte) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(file, data, 0600))
+
+	return file
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	customerID := uuid.New()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	hsVerifier, err := NewJWTVerifier(JWTConfig{
+		Algorithm: AlgorithmHS256,
+		KeyFile:   writeKeyFile(t, []byte(testSecret+"\n")),
+		Issuer:    "https://auth.bank.test",
+		Audience:  "bank-api",
+		Leeway:    30 * time.Second,
+	})
+	require.NoError(t, err)
+
+	rsVerifier, err := NewJWTVerifier(DefaultJWTConfig(AlgorithmRS256, writeKeyFile(t, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))))
+	require.NoError(t, err)
+
+	validClaims := func(overrides map[string]any) map[string]any {
+		claims := map[string]any{
+			"sub":   "teller-1",
+			"iss":   "https://auth.bank.test",
+			"aud":   []string{"bank-api", "reports"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"roles": []string{"teller"},
+		}
+		for name, value := range overrides {
+			if value == nil {
+				delete(claims, name)
+				continue
+			}
+			claims[name] = value
+		}
+
+		return claims
+	}
+
+	type testCaseParams struct {
+		verifier *JWTVerifier
+		token    string
+	}
+
+	type testCaseExpected struct {
+		principal identitydomain.Principal
+		err       error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should verify HS256 token",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(nil)),
+			},
+			expected: testCaseExpected{
+				principal: identitydomain.Principal{ID: "teller-1", Type: identitydomain.PrincipalTypeUser, Roles: []identitydomain.Role{identitydomain.RoleTeller}},
+			},
+		},
+		{
+			name: "should verify RS256 token of customer",
+			params: testCaseParams{
+				verifier: rsVerifier,
+				token:    sign(t, AlgorithmRS256, privateKey, validClaims(map[string]any{"sub": "customer-1", "roles": []string{"customer"}, "customer_id": customerID.String()})),
+			},
+			expected: testCaseExpected{
+				principal: identitydomain.Principal{ID: "customer-1", Type: identitydomain.PrincipalTypeUser, Roles: []identitydomain.Role{identitydomain.RoleCustomer}, CustomerID: customerID},
+			},
+		},
+		{
+			name: "should verify token expired within leeway",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"exp": now.Add(-10 * time.Second).Unix(), "aud": "bank-api"})),
+			},
+			expected: testCaseExpected{
+				principal: identitydomain.Principal{ID: "teller-1", Type: identitydomain.PrincipalTypeUser, Roles: []identitydomain.Role{identitydomain.RoleTeller}},
+			},
+		},
+		{
+			name: "should reject token with wrong signature",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte("another-secret-another-secret-00"), validClaims(nil)),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject token signed with another algorithm",
+			params: testCaseParams{
+				verifier: rsVerifier,
+				token:    sign(t, AlgorithmHS256, publicKey, validClaims(nil)),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject unsigned token",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, "none", nil, validClaims(nil)),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject expired token",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"exp": now.Add(-time.Minute).Unix()})),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject token without expiration time",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"exp": nil})),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject token not valid yet",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"nbf": now.Add(time.Minute).Unix()})),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject token of another issuer",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"iss": "https://evil.test"})),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject token for another audience",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"aud": "reports"})),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject token with unknown role",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"roles": []string{"admin"}})),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject customer token without customer id",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    sign(t, AlgorithmHS256, []byte(testSecret), validClaims(map[string]any{"roles": []string{"customer"}})),
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "should reject malformed token",
+			params: testCaseParams{
+				verifier: hsVerifier,
+				token:    "not-a-token",
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := tt.params.verifier.Verify(tt.params.token, now)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.principal, principal)
+		})
+	}
+}
+
+func TestJWTVerifier_Authenticate(t *testing.T) {
+	verifier, err := NewJWTVerifier(DefaultJWTConfig(AlgorithmHS256, writeKeyFile(t, []byte(testSecret))))
+	require.NoError(t, err)
+
+	token := sign(t, AlgorithmHS256, []byte(testSecret), map[string]any{
+		"sub":   "auditor-1",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"auditor"},
+	})
+
+	type testCaseExpected struct {
+		principalID string
+		err         error
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		expected      testCaseExpected
+	}{
+		{
+			name:          "should authenticate request with bearer token",
+			authorization: "Bearer " + token,
+			expected:      testCaseExpected{principalID: "auditor-1"},
+		},
+		{
+			name:          "should authenticate request with lower case scheme",
+			authorization: "bearer " + token,
+			expected:      testCaseExpected{principalID: "auditor-1"},
+		},
+		{
+			name:     "shouldn't authenticate request without authorization header",
+			expected: testCaseExpected{err: identitydomain.ErrUnauthenticated},
+		},
+		{
+			name:          "shouldn't authenticate request with another scheme",
+			authorization: "Basic dXNlcjpwYXNz",
+			expected:      testCaseExpected{err: identitydomain.ErrUnauthenticated},
+		},
+		{
+			name:          "should reject request with invalid bearer token",
+			authorization: "Bearer " + token + "x",
+			expected:      testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			principal, err := verifier.Authenticate(req)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.principalID, principal.ID)
+		})
+	}
+}
+
+func TestNewJWTVerifier(t *testing.T) {
+	tests := []struct {
+		name   string
+		config JWTConfig
+	}{
+		{
+			name:   "should reject short secret",
+			config: DefaultJWTConfig(AlgorithmHS256, writeKeyFile(t, []byte("short"))),
+		},
+		{
+			name:   "should reject public key which isn't pem encoded",
+			config: DefaultJWTConfig(AlgorithmRS256, writeKeyFile(t, []byte(testSecret))),
+		},
+		{
+			name:   "should reject unsupported algorithm",
+			config: DefaultJWTConfig("ES256", writeKeyFile(t, []byte(testSecret))),
+		},
+		{
+			name:   "should reject missing key file",
+			config: DefaultJWTConfig(AlgorithmHS256, filepath.Join(t.TempDir(), "missing")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJWTVerifier(tt.config)
+			require.Error(t, err)
+		})
+	}
+}
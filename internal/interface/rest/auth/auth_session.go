@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+// Sessions authenticates the customers logged in to the online banking, the requests carrying the access token of the session
+// in the Authorization: Bearer header. The access tokens of the sessions are told apart from the JWTs by their prefix.
+type Sessions struct {
+	service SessionService
+}
+
+// NewSessions creates a new authenticator of the access tokens of the sessions
+func NewSessions(service SessionService) *Sessions {
+	return &Sessions{
+		service: service,
+	}
+}
+
+// Authenticate authenticates the request with the access token of the session. It returns identitydomain.ErrUnauthenticated
+// when the request doesn't carry the access token of the session, so the request can be authenticated otherwise, i.e. with the JWT.
+func (s *Sessions) Authenticate(r *http.Request) (identitydomain.Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return identitydomain.Principal{}, identitydomain.ErrUnauthenticated
+	}
+
+	token = strings.TrimSpace(token)
+	if !strings.HasPrefix(token, credentialsdomain.AccessTokenPrefix) {
+		return identitydomain.Principal{}, identitydomain.ErrUnauthenticated
+	}
+
+	return s.service.AuthenticateSession(r.Context(), token)
+}
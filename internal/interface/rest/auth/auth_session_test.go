@@ -0,0 +1,103 @@
+//go:build unit
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/auth/mock"
+)
+
+func TestSessions_Authenticate(t *testing.T) {
+	customer := identitydomain.Principal{
+		ID:         "00000000-0000-0000-0000-000000000001",
+		Type:       identitydomain.PrincipalTypeUser,
+		Roles:      []identitydomain.Role{identitydomain.RoleCustomer},
+		CustomerID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+	}
+
+	type testCaseParams struct {
+		authorization      string
+		mockSessionService func(*gomock.Controller) *mock.MockSessionService
+	}
+
+	type testCaseExpected struct {
+		principal identitydomain.Principal
+		err       error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should authenticate customer with session access token",
+			params: testCaseParams{
+				authorization: "Bearer ses_token",
+				mockSessionService: func(ctrl *gomock.Controller) *mock.MockSessionService {
+					m := mock.NewMockSessionService(ctrl)
+					m.EXPECT().AuthenticateSession(gomock.Any(), "ses_token").Return(customer, nil)
+					return m
+				},
+			},
+			expected: testCaseExpected{principal: customer},
+		},
+		{
+			name: "should reject expired session access token",
+			params: testCaseParams{
+				authorization: "Bearer ses_expired",
+				mockSessionService: func(ctrl *gomock.Controller) *mock.MockSessionService {
+					m := mock.NewMockSessionService(ctrl)
+					m.EXPECT().AuthenticateSession(gomock.Any(), "ses_expired").Return(identitydomain.Principal{}, identitydomain.ErrInvalidCredentials)
+					return m
+				},
+			},
+			expected: testCaseExpected{err: identitydomain.ErrInvalidCredentials},
+		},
+		{
+			name: "shouldn't authenticate request with jwt",
+			params: testCaseParams{
+				authorization:      "Bearer eyJhbGciOiJIUzI1NiJ9.e30.sig",
+				mockSessionService: mock.NewMockSessionService,
+			},
+			expected: testCaseExpected{err: identitydomain.ErrUnauthenticated},
+		},
+		{
+			name: "shouldn't authenticate request without bearer token",
+			params: testCaseParams{
+				mockSessionService: mock.NewMockSessionService,
+			},
+			expected: testCaseExpected{err: identitydomain.ErrUnauthenticated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := httptest.NewRequest(http.MethodGet, "/customers/1", nil)
+			if tt.params.authorization != "" {
+				req.Header.Set("Authorization", tt.params.authorization)
+			}
+
+			principal, err := NewSessions(tt.params.mockSessionService(ctrl)).Authenticate(req)
+
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.principal, principal)
+		})
+	}
+}
@@ -1,51 +1,63 @@
 package account
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	applicationaccount "github.com/stefanowiczd/ddd-case-01/internal/application/account"
+	applicationcommand "github.com/stefanowiczd/ddd-case-01/internal/application/command"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
 )
 
 // AccountHandler handles HTTP requests for account operations
 type AccountHandler struct {
 	accountService AccountService
+	commandService CommandService
 }
 
-// NewAccountHandler creates a new account handler
-func NewAccountHandler(accountService AccountService) *AccountHandler {
+// NewAccountHandler creates a new account handler, the accepted account commands are tracked by the command service
+func NewAccountHandler(accountService AccountService, commandService CommandService) *AccountHandler {
 	return &AccountHandler{
 		accountService: accountService,
+		commandService: commandService,
 	}
 }
 
-// CreateAccountRequest represents the request body for creating an account
+// CreateAccountRequest represents the request body for creating an account.
+// The initial balance is a decimal string in the account currency, i.e. "10.50".
 type CreateAccountRequest struct {
-	CustomerID     string  `json:"customerId"`
-	InitialBalance float64 `json:"initialBalance"`
-	Currency       string  `json:"currency"`
+	CustomerID     string `json:"customerId"`
+	InitialBalance string `json:"initialBalance"`
+	Currency       string `json:"currency"`
 }
 
 func (r CreateAccountRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.CustomerID); err != nil {
-		return fmt.Errorf("validate: customer id as uuid: %w", err)
+		validationErr.Add("customerId", problem.CodeInvalidUUID, "customer id must be a uuid")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 // CreateAccount handles the creation of a new account
 func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	var req CreateAccountRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidBody)
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -55,7 +67,7 @@ func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		Currency:       req.Currency,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -64,18 +76,21 @@ func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(account) // TODO decide about handling of this error.
 }
 
-// DepositRequest represents the request body for depositing money
+// DepositRequest represents the request body for depositing money.
+// The amount is a decimal string in the account currency, i.e. "10.50".
 type DepositRequest struct {
-	Amount    float64 `json:"amount"`
+	Amount    string `json:"amount"`
 	AccountID string
 }
 
 func (r *DepositRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.AccountID); err != nil {
-		return fmt.Errorf("validate: account id as uuid: %w", err)
+		validationErr.Add("id", problem.CodeInvalidUUID, "account id must be a uuid")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 // Deposit handles depositing money into an account
@@ -84,40 +99,44 @@ func (h *AccountHandler) Deposit(w http.ResponseWriter, r *http.Request) {
 
 	var req DepositRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidBody)
 		return
 	}
 
 	req.AccountID = accountID
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
-	if err := h.accountService.Deposit(r.Context(), applicationaccount.DepositDTO{
+	command, err := h.accountService.Deposit(r.Context(), applicationaccount.DepositDTO{
 		AccountID: uuid.MustParse(accountID),
 		Amount:    req.Amount,
-	}); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	})
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	h.writeAccepted(w, r, command)
 }
 
-// WithdrawRequest represents the request body for withdrawing money
+// WithdrawRequest represents the request body for withdrawing money.
+// The amount is a decimal string in the account currency, i.e. "10.50".
 type WithdrawRequest struct {
-	Amount    float64 `json:"amount"`
+	Amount    string `json:"amount"`
 	AccountID string
 }
 
 func (r *WithdrawRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.AccountID); err != nil {
-		return fmt.Errorf("validate: account id as uuid: %w", err)
+		validationErr.Add("id", problem.CodeInvalidUUID, "account id must be a uuid")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 // Withdraw handles withdrawing money from an account
@@ -126,60 +145,75 @@ func (h *AccountHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
 
 	var req WithdrawRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidBody)
 		return
 	}
 
 	req.AccountID = accountID
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
-	if err := h.accountService.Withdraw(r.Context(), applicationaccount.WithdrawDTO{
+	command, err := h.accountService.Withdraw(r.Context(), applicationaccount.WithdrawDTO{
 		AccountID: uuid.MustParse(accountID),
 		Amount:    req.Amount,
-	}); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	})
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	h.writeAccepted(w, r, command)
 }
 
-// BlockAccountRequest represents the request body for blocking an account
+// BlockAccountRequest represents the request body for blocking an account.
+// The body is optional, the account is unblocked automatically at blockedUntil (RFC 3339) when it is given.
 type BlockAccountRequest struct {
-	AccountID string
+	BlockedUntil *time.Time `json:"blockedUntil,omitempty"`
+	AccountID    string
 }
 
 func (r *BlockAccountRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.AccountID); err != nil {
-		return fmt.Errorf("validate: account id as uuid: %w", err)
+		validationErr.Add("id", problem.CodeInvalidUUID, "account id must be a uuid")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 // BlockAccount handles blocking an account
 func (h *AccountHandler) BlockAccount(w http.ResponseWriter, r *http.Request) {
-	req := BlockAccountRequest{
-		AccountID: r.PathValue("id"),
+	var req BlockAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
 	}
 
+	req.AccountID = r.PathValue("id")
+
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
-	if err := h.accountService.BlockAccount(r.Context(), applicationaccount.BlockAccountDTO{
+	dto := applicationaccount.BlockAccountDTO{
 		AccountID: uuid.MustParse(req.AccountID),
-	}); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if req.BlockedUntil != nil {
+		dto.BlockedUntil = req.BlockedUntil.UTC()
+	}
+
+	command, err := h.accountService.BlockAccount(r.Context(), dto)
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	h.writeAccepted(w, r, command)
 }
 
 // UnblockAccountRequest represents the request body for unblocking an account
@@ -188,11 +222,13 @@ type UnblockAccountRequest struct {
 }
 
 func (r *UnblockAccountRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.AccountID); err != nil {
-		return fmt.Errorf("validate: account id as uuid: %w", err)
+		validationErr.Add("id", problem.CodeInvalidUUID, "account id must be a uuid")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 // UnblockAccount handles unblocking an account
@@ -202,16 +238,113 @@ func (h *AccountHandler) UnblockAccount(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
-	if err := h.accountService.UnblockAccount(r.Context(), applicationaccount.UnblockAccountDTO{
+	command, err := h.accountService.UnblockAccount(r.Context(), applicationaccount.UnblockAccountDTO{
 		AccountID: uuid.MustParse(req.AccountID),
-	}); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	})
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	h.writeAccepted(w, r, command)
+}
+
+// AccountStatusRequest represents the request changing the account status, i.e. freezing an account
+type AccountStatusRequest struct {
+	AccountID string
+}
+
+func (r *AccountStatusRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.AccountID); err != nil {
+		validationErr.Add("id", problem.CodeInvalidUUID, "account id must be a uuid")
+	}
+
+	return validationErr.Err()
+}
+
+// ActivateAccount handles activating a pending account
+func (h *AccountHandler) ActivateAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, func(ctx context.Context, id uuid.UUID) (applicationaccount.CommandResponseDTO, error) {
+		return h.accountService.ActivateAccount(ctx, applicationaccount.ActivateAccountDTO{AccountID: id})
+	})
+}
+
+// FreezeAccount handles freezing an account
+func (h *AccountHandler) FreezeAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, func(ctx context.Context, id uuid.UUID) (applicationaccount.CommandResponseDTO, error) {
+		return h.accountService.FreezeAccount(ctx, applicationaccount.FreezeAccountDTO{AccountID: id})
+	})
+}
+
+// UnfreezeAccount handles unfreezing an account
+func (h *AccountHandler) UnfreezeAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, func(ctx context.Context, id uuid.UUID) (applicationaccount.CommandResponseDTO, error) {
+		return h.accountService.UnfreezeAccount(ctx, applicationaccount.UnfreezeAccountDTO{AccountID: id})
+	})
+}
+
+// CloseAccount handles closing an account
+func (h *AccountHandler) CloseAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, func(ctx context.Context, id uuid.UUID) (applicationaccount.CommandResponseDTO, error) {
+		return h.accountService.CloseAccount(ctx, applicationaccount.CloseAccountDTO{AccountID: id})
+	})
+}
+
+// changeStatus validates the account ID from the path and applies the status change to the account
+func (h *AccountHandler) changeStatus(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, id uuid.UUID) (applicationaccount.CommandResponseDTO, error)) {
+	req := AccountStatusRequest{
+		AccountID: r.PathValue("id"),
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	command, err := change(r.Context(), uuid.MustParse(req.AccountID))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	h.writeAccepted(w, r, command)
+}
+
+// writeAccepted writes the account command accepted for processing, 202 Accepted with the command status at the Location,
+// the account changes once the event of the command is processed. The client preferring to wait (Prefer: wait=10)
+// gets 200 OK when the command is finished within the wait, so it reads what the command wrote.
+func (h *AccountHandler) writeAccepted(w http.ResponseWriter, r *http.Request, accepted applicationaccount.CommandResponseDTO) {
+	location := "/commands/" + accepted.EventID
+	wait, preferred := middleware.PreferredWait(r)
+
+	command, err := h.commandService.GetCommand(r.Context(), applicationcommand.GetCommandDTO{
+		EventID: uuid.MustParse(accepted.EventID),
+		Wait:    wait,
+	})
+	if err != nil {
+		// The command is accepted even when its status can't be read, the client follows the Location
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if preferred {
+		w.Header().Set(middleware.PreferenceAppliedHeader, fmt.Sprintf("wait=%d", int(min(wait, applicationcommand.MaxWait).Seconds())))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if preferred && command.Finished {
+		w.Header().Set("Content-Location", location)
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusAccepted)
+	}
+	_ = json.NewEncoder(w).Encode(command) // TODO decide about handling of this error.
 }
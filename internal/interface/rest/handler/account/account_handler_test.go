@@ -10,16 +10,33 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stefanowiczd/ddd-case-01/internal/application/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/application/command"
 	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/account/mock"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
 )
 
+// testCommand is the command accepted by the account service
+var testCommand = account.CommandResponseDTO{EventID: "11111111-1111-1111-1111-111111111111"}
+
+// testCommandService returns the command service reporting the accepted command waiting to be processed
+func testCommandService(ctrl *gomock.Controller) *mock.MockCommandService {
+	m := mock.NewMockCommandService(ctrl)
+	m.EXPECT().
+		GetCommand(gomock.Any(), command.GetCommandDTO{EventID: uuid.MustParse(testCommand.EventID)}).
+		Return(command.CommandResponseDTO{ID: testCommand.EventID, State: "ready"}, nil).
+		AnyTimes()
+	return m
+}
+
 func TestAccountHandler_CreateAccount(t *testing.T) {
 	type testCaseParams struct {
 		req                CreateAccountRequest
@@ -44,7 +61,7 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 			params: testCaseParams{
 				req: CreateAccountRequest{
 					CustomerID:     "customer123",
-					InitialBalance: 100.0,
+					InitialBalance: "100.00",
 					Currency:       "USD",
 				},
 				reqBody: func(_ CreateAccountRequest) io.Reader {
@@ -64,7 +81,7 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 			params: testCaseParams{
 				req: CreateAccountRequest{
 					CustomerID:     "customer123",
-					InitialBalance: 100.0,
+					InitialBalance: "100.00",
 					Currency:       "USD",
 				},
 				reqBody: func(r CreateAccountRequest) io.Reader {
@@ -85,7 +102,7 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 			params: testCaseParams{
 				req: CreateAccountRequest{
 					CustomerID:     "00000000-0000-0000-0000-000000000000",
-					InitialBalance: 100.0,
+					InitialBalance: "100.00",
 					Currency:       "USD",
 				},
 				reqBody: func(r CreateAccountRequest) io.Reader {
@@ -113,7 +130,7 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 			params: testCaseParams{
 				req: CreateAccountRequest{
 					CustomerID:     "00000000-0000-0000-0000-000000000000",
-					InitialBalance: 100.0,
+					InitialBalance: "100.00",
 					Currency:       "USD",
 				},
 				reqBody: func(r CreateAccountRequest) io.Reader {
@@ -127,7 +144,7 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 							gomock.Any(),
 							account.CreateAccountDTO{
 								CustomerID:     "00000000-0000-0000-0000-000000000000",
-								InitialBalance: 100.0,
+								InitialBalance: "100.00",
 								Currency:       "USD",
 							}).
 						Return(
@@ -136,7 +153,7 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 									ID:            "00000000-0000-0000-0000-000000000000",
 									AccountNumber: "1234567890",
 									CustomerID:    "customer123",
-									Balance:       100.0,
+									Balance:       "100.00",
 									Currency:      "USD",
 									Status:        "active",
 								},
@@ -157,7 +174,7 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			handler := NewAccountHandler(tt.params.mockAccountService(ctrl))
+			handler := NewAccountHandler(tt.params.mockAccountService(ctrl), testCommandService(ctrl))
 
 			req := httptest.NewRequest(http.MethodPost, "/account", tt.params.reqBody(tt.params.req))
 			w := httptest.NewRecorder()
@@ -200,7 +217,7 @@ func TestAccountHandler_Deposit(t *testing.T) {
 			params: testCaseParams{
 				accountID: "acc123",
 				req: DepositRequest{
-					Amount: 100.0,
+					Amount: "100.00",
 				},
 				reqBody: func(_ DepositRequest) io.Reader {
 					return bytes.NewBuffer([]byte(`{ ... invalid json ... `))
@@ -219,7 +236,7 @@ func TestAccountHandler_Deposit(t *testing.T) {
 			params: testCaseParams{
 				accountID: "acc123",
 				req: DepositRequest{
-					Amount: 100.0,
+					Amount: "100.00",
 				},
 				reqBody: func(r DepositRequest) io.Reader {
 					body, _ := json.Marshal(r)
@@ -234,12 +251,37 @@ func TestAccountHandler_Deposit(t *testing.T) {
 				statusCode: http.StatusBadRequest,
 			},
 		},
+		{
+			name: "invalid deposit amount",
+			params: testCaseParams{
+				accountID: "00000000-0000-0000-0000-000000000000",
+				req: DepositRequest{
+					Amount: "100.005",
+				},
+				reqBody: func(r DepositRequest) io.Reader {
+					body, _ := json.Marshal(r)
+					return bytes.NewBuffer(body)
+				},
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().
+						Deposit(gomock.Any(), gomock.Any()).
+						Return(account.CommandResponseDTO{}, account.ErrInvalidDepositAmount)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				wantError:  true,
+			},
+		},
 		{
 			name: "unsuccessful deposit",
 			params: testCaseParams{
 				accountID: "00000000-0000-0000-0000-000000000000",
 				req: DepositRequest{
-					Amount: 100.0,
+					Amount: "100.00",
 				},
 				reqBody: func(r DepositRequest) io.Reader {
 					body, _ := json.Marshal(r)
@@ -249,7 +291,7 @@ func TestAccountHandler_Deposit(t *testing.T) {
 					mock := mock.NewMockAccountService(m)
 					mock.EXPECT().
 						Deposit(gomock.Any(), gomock.Any()).
-						Return(errors.New("error"))
+						Return(account.CommandResponseDTO{}, errors.New("error"))
 
 					return mock
 				},
@@ -264,7 +306,7 @@ func TestAccountHandler_Deposit(t *testing.T) {
 			params: testCaseParams{
 				accountID: "00000000-0000-0000-0000-000000000000",
 				req: DepositRequest{
-					Amount: 100.0,
+					Amount: "100.00",
 				},
 				reqBody: func(r DepositRequest) io.Reader {
 					body, _ := json.Marshal(r)
@@ -277,16 +319,16 @@ func TestAccountHandler_Deposit(t *testing.T) {
 							gomock.Any(),
 							account.DepositDTO{
 								AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-								Amount:    100.0,
+								Amount:    "100.00",
 							}).
-						Return(nil)
+						Return(testCommand, nil)
 
 					return mock
 				},
 			},
 			expected: testCaseExpected{
 				wantError:  false,
-				statusCode: http.StatusOK,
+				statusCode: http.StatusAccepted,
 			},
 		},
 	}
@@ -296,7 +338,7 @@ func TestAccountHandler_Deposit(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			handler := NewAccountHandler(tt.params.mockAccountService(ctrl))
+			handler := NewAccountHandler(tt.params.mockAccountService(ctrl), testCommandService(ctrl))
 
 			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/deposit", tt.params.accountID), tt.params.reqBody(tt.params.req))
 			req.SetPathValue("id", tt.params.accountID)
@@ -313,6 +355,129 @@ func TestAccountHandler_Deposit(t *testing.T) {
 	}
 }
 
+func TestAccountHandler_Deposit_PreferWait(t *testing.T) {
+	accountID := "00000000-0000-0000-0000-000000000000"
+	eventID := uuid.MustParse(testCommand.EventID)
+
+	type testCaseParams struct {
+		prefer             string
+		mockCommandService func(*gomock.Controller) *mock.MockCommandService
+	}
+
+	type testCaseExpected struct {
+		statusCode        int
+		location          string
+		contentLocation   string
+		preferenceApplied string
+		state             string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "accepted deposit without waiting",
+			params: testCaseParams{
+				mockCommandService: testCommandService,
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusAccepted,
+				location:   "/commands/" + testCommand.EventID,
+				state:      "ready",
+			},
+		},
+		{
+			name: "completed deposit within wait",
+			params: testCaseParams{
+				prefer: "wait=10",
+				mockCommandService: func(m *gomock.Controller) *mock.MockCommandService {
+					mock := mock.NewMockCommandService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), command.GetCommandDTO{EventID: eventID, Wait: 10 * time.Second}).
+						Return(command.CommandResponseDTO{ID: testCommand.EventID, State: "completed", Finished: true}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode:        http.StatusOK,
+				contentLocation:   "/commands/" + testCommand.EventID,
+				preferenceApplied: "wait=10",
+				state:             "completed",
+			},
+		},
+		{
+			name: "unfinished deposit once wait is over",
+			params: testCaseParams{
+				prefer: "wait=1",
+				mockCommandService: func(m *gomock.Controller) *mock.MockCommandService {
+					mock := mock.NewMockCommandService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), command.GetCommandDTO{EventID: eventID, Wait: time.Second}).
+						Return(command.CommandResponseDTO{ID: testCommand.EventID, State: "processing"}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode:        http.StatusAccepted,
+				location:          "/commands/" + testCommand.EventID,
+				preferenceApplied: "wait=1",
+				state:             "processing",
+			},
+		},
+		{
+			name: "accepted deposit when its status can't be read",
+			params: testCaseParams{
+				prefer: "wait=10",
+				mockCommandService: func(m *gomock.Controller) *mock.MockCommandService {
+					mock := mock.NewMockCommandService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), gomock.Any()).
+						Return(command.CommandResponseDTO{}, errors.New("error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusAccepted,
+				location:   "/commands/" + testCommand.EventID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountService := mock.NewMockAccountService(ctrl)
+			accountService.EXPECT().Deposit(gomock.Any(), gomock.Any()).Return(testCommand, nil)
+
+			handler := NewAccountHandler(accountService, tt.params.mockCommandService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/deposit", accountID), strings.NewReader(`{"amount":"100.00"}`))
+			req.SetPathValue("id", accountID)
+			if tt.params.prefer != "" {
+				req.Header.Set(middleware.PreferHeader, tt.params.prefer)
+			}
+			w := httptest.NewRecorder()
+
+			handler.Deposit(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+			require.Equal(t, tt.expected.location, w.Header().Get("Location"))
+			require.Equal(t, tt.expected.contentLocation, w.Header().Get("Content-Location"))
+			require.Equal(t, tt.expected.preferenceApplied, w.Header().Get(middleware.PreferenceAppliedHeader))
+
+			if tt.expected.state != "" {
+				var response command.CommandResponseDTO
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				require.Equal(t, tt.expected.state, response.State)
+			}
+		})
+	}
+}
+
 func TestAccountHandler_Withdraw(t *testing.T) {
 	type testCaseParams struct {
 		accountID          string
@@ -338,7 +503,7 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 			params: testCaseParams{
 				accountID: "acc123",
 				req: WithdrawRequest{
-					Amount: 50.0,
+					Amount: "50.00",
 				},
 				reqBody: func(_ WithdrawRequest) io.Reader {
 					return bytes.NewBuffer([]byte(`{ ... invalid json ... `))
@@ -357,7 +522,7 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 			params: testCaseParams{
 				accountID: "acc123",
 				req: WithdrawRequest{
-					Amount: 50.0,
+					Amount: "50.00",
 				},
 				reqBody: func(_ WithdrawRequest) io.Reader {
 					return bytes.NewBuffer([]byte(`{ ... invalid json ... `))
@@ -371,12 +536,62 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 				statusCode: http.StatusBadRequest,
 			},
 		},
+		{
+			name: "unsuccessful withdrawal - insufficient funds",
+			params: testCaseParams{
+				accountID: "00000000-0000-0000-0000-000000000000",
+				req: WithdrawRequest{
+					Amount: "50.00",
+				},
+				reqBody: func(req WithdrawRequest) io.Reader {
+					body, _ := json.Marshal(req)
+					return bytes.NewBuffer(body)
+				},
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().
+						Withdraw(gomock.Any(), gomock.Any()).
+						Return(account.CommandResponseDTO{}, account.ErrInsufficientFunds)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError:  true,
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "unsuccessful withdrawal - account changed concurrently",
+			params: testCaseParams{
+				accountID: "00000000-0000-0000-0000-000000000000",
+				req: WithdrawRequest{
+					Amount: "50.00",
+				},
+				reqBody: func(req WithdrawRequest) io.Reader {
+					body, _ := json.Marshal(req)
+					return bytes.NewBuffer(body)
+				},
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().
+						Withdraw(gomock.Any(), gomock.Any()).
+						Return(account.CommandResponseDTO{}, account.ErrConcurrencyConflict)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError:  true,
+				statusCode: http.StatusConflict,
+			},
+		},
 		{
 			name: "unsuccessful withdrawal",
 			params: testCaseParams{
 				accountID: "00000000-0000-0000-0000-000000000000",
 				req: WithdrawRequest{
-					Amount: 50.0,
+					Amount: "50.00",
 				},
 				reqBody: func(r WithdrawRequest) io.Reader {
 					body, _ := json.Marshal(r)
@@ -386,7 +601,7 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 					mock := mock.NewMockAccountService(m)
 					mock.EXPECT().
 						Withdraw(gomock.Any(), gomock.Any()).
-						Return(errors.New("error"))
+						Return(account.CommandResponseDTO{}, errors.New("error"))
 
 					return mock
 				},
@@ -401,7 +616,7 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 			params: testCaseParams{
 				accountID: "00000000-0000-0000-0000-000000000000",
 				req: WithdrawRequest{
-					Amount: 50.0,
+					Amount: "50.00",
 				},
 				reqBody: func(r WithdrawRequest) io.Reader {
 					body, _ := json.Marshal(r)
@@ -414,16 +629,16 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 							gomock.Any(),
 							account.WithdrawDTO{
 								AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-								Amount:    50.0,
+								Amount:    "50.00",
 							}).
-						Return(nil)
+						Return(testCommand, nil)
 
 					return mock
 				},
 			},
 			expected: testCaseExpected{
 				wantError:  false,
-				statusCode: http.StatusOK,
+				statusCode: http.StatusAccepted,
 			},
 		},
 	}
@@ -433,7 +648,7 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			handler := NewAccountHandler(tt.params.mockAccountService(ctrl))
+			handler := NewAccountHandler(tt.params.mockAccountService(ctrl), testCommandService(ctrl))
 
 			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/withdraw", tt.params.accountID), tt.params.reqBody(tt.params.req))
 			req.SetPathValue("id", tt.params.accountID)
@@ -453,6 +668,7 @@ func TestAccountHandler_Withdraw(t *testing.T) {
 func TestAccountHandler_BlockAccount(t *testing.T) {
 	type testCaseParams struct {
 		accountID          string
+		body               string
 		mockAccountService func(*gomock.Controller) *mock.MockAccountService
 	}
 
@@ -489,7 +705,7 @@ func TestAccountHandler_BlockAccount(t *testing.T) {
 					mock := mock.NewMockAccountService(m)
 					mock.EXPECT().
 						BlockAccount(gomock.Any(), gomock.Any()).
-						Return(errors.New("error"))
+						Return(account.CommandResponseDTO{}, errors.New("error"))
 
 					return mock
 				},
@@ -511,14 +727,71 @@ func TestAccountHandler_BlockAccount(t *testing.T) {
 							account.BlockAccountDTO{
 								AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 							}).
-						Return(nil)
+						Return(testCommand, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				wantError:  false,
+				statusCode: http.StatusAccepted,
+			},
+		},
+		{
+			name: "successful account blocking until the given time",
+			params: testCaseParams{
+				accountID: "00000000-0000-0000-0000-000000000000",
+				body:      `{"blockedUntil":"2030-01-02T15:04:05+02:00"}`,
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().
+						BlockAccount(
+							gomock.Any(),
+							account.BlockAccountDTO{
+								AccountID:    uuid.MustParse("00000000-0000-0000-0000-000000000000"),
+								BlockedUntil: time.Date(2030, 1, 2, 13, 4, 5, 0, time.UTC),
+							}).
+						Return(testCommand, nil)
 
 					return mock
 				},
 			},
 			expected: testCaseExpected{
 				wantError:  false,
-				statusCode: http.StatusOK,
+				statusCode: http.StatusAccepted,
+			},
+		},
+		{
+			name: "invalid blocked until time format in request body",
+			params: testCaseParams{
+				accountID: "00000000-0000-0000-0000-000000000000",
+				body:      `{"blockedUntil":"tomorrow"}`,
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					return mock.NewMockAccountService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				wantError:  true,
+			},
+		},
+		{
+			name: "unsuccessful account blocking - invalid blocked until time",
+			params: testCaseParams{
+				accountID: "00000000-0000-0000-0000-000000000000",
+				body:      `{"blockedUntil":"2020-01-02T15:04:05Z"}`,
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().
+						BlockAccount(gomock.Any(), gomock.Any()).
+						Return(account.CommandResponseDTO{}, account.ErrInvalidBlockedUntil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				wantError:  true,
 			},
 		},
 	}
@@ -528,9 +801,9 @@ func TestAccountHandler_BlockAccount(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			handler := NewAccountHandler(tt.params.mockAccountService(ctrl))
+			handler := NewAccountHandler(tt.params.mockAccountService(ctrl), testCommandService(ctrl))
 
-			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/block", tt.params.accountID), nil)
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/block", tt.params.accountID), strings.NewReader(tt.params.body))
 			req.SetPathValue("id", tt.params.accountID)
 			w := httptest.NewRecorder()
 
@@ -584,7 +857,7 @@ func TestAccountHandler_UnblockAccount(t *testing.T) {
 					mock := mock.NewMockAccountService(m)
 					mock.EXPECT().
 						UnblockAccount(gomock.Any(), gomock.Any()).
-						Return(errors.New("error"))
+						Return(account.CommandResponseDTO{}, errors.New("error"))
 
 					return mock
 				},
@@ -606,14 +879,14 @@ func TestAccountHandler_UnblockAccount(t *testing.T) {
 							account.UnblockAccountDTO{
 								AccountID: uuid.MustParse("00000000-0000-0000-0000-000000000000"),
 							}).
-						Return(nil)
+						Return(testCommand, nil)
 
 					return mock
 				},
 			},
 			expected: testCaseExpected{
 				wantError:  false,
-				statusCode: http.StatusOK,
+				statusCode: http.StatusAccepted,
 			},
 		},
 	}
@@ -623,7 +896,7 @@ func TestAccountHandler_UnblockAccount(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			handler := NewAccountHandler(tt.params.mockAccountService(ctrl))
+			handler := NewAccountHandler(tt.params.mockAccountService(ctrl), testCommandService(ctrl))
 
 			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/unblock", tt.params.accountID), nil)
 			req.SetPathValue("id", tt.params.accountID)
@@ -639,3 +912,166 @@ func TestAccountHandler_UnblockAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountHandler_ChangeStatus(t *testing.T) {
+	accountID := uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+	type testCaseParams struct {
+		accountID          string
+		handle             func(h *AccountHandler) http.HandlerFunc
+		mockAccountService func(*gomock.Controller) *mock.MockAccountService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "invalid account id format in request path",
+			params: testCaseParams{
+				accountID: "acc123",
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.ActivateAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					return mock.NewMockAccountService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "successful account activation",
+			params: testCaseParams{
+				accountID: accountID.String(),
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.ActivateAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().ActivateAccount(gomock.Any(), account.ActivateAccountDTO{AccountID: accountID}).Return(testCommand, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusAccepted,
+			},
+		},
+		{
+			name: "successful account freezing",
+			params: testCaseParams{
+				accountID: accountID.String(),
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.FreezeAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().FreezeAccount(gomock.Any(), account.FreezeAccountDTO{AccountID: accountID}).Return(testCommand, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusAccepted,
+			},
+		},
+		{
+			name: "successful account unfreezing",
+			params: testCaseParams{
+				accountID: accountID.String(),
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.UnfreezeAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().UnfreezeAccount(gomock.Any(), account.UnfreezeAccountDTO{AccountID: accountID}).Return(testCommand, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusAccepted,
+			},
+		},
+		{
+			name: "successful account closing",
+			params: testCaseParams{
+				accountID: accountID.String(),
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.CloseAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().CloseAccount(gomock.Any(), account.CloseAccountDTO{AccountID: accountID}).Return(testCommand, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusAccepted,
+			},
+		},
+		{
+			name: "unsuccessful account closing - account not found",
+			params: testCaseParams{
+				accountID: accountID.String(),
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.CloseAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(account.CommandResponseDTO{}, account.ErrAccountNotFound)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "unsuccessful account closing - status conflict",
+			params: testCaseParams{
+				accountID: accountID.String(),
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.CloseAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Return(account.CommandResponseDTO{}, account.ErrAccountStatusConflict)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusConflict,
+			},
+		},
+		{
+			name: "unsuccessful account freezing - internal error",
+			params: testCaseParams{
+				accountID: accountID.String(),
+				handle:    func(h *AccountHandler) http.HandlerFunc { return h.FreezeAccount },
+				mockAccountService: func(m *gomock.Controller) *mock.MockAccountService {
+					mock := mock.NewMockAccountService(m)
+					mock.EXPECT().FreezeAccount(gomock.Any(), gomock.Any()).Return(account.CommandResponseDTO{}, errors.New("error"))
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewAccountHandler(tt.params.mockAccountService(ctrl), testCommandService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/status", tt.params.accountID), nil)
+			req.SetPathValue("id", tt.params.accountID)
+			w := httptest.NewRecorder()
+
+			tt.params.handle(handler)(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	applicationaccount "github.com/stefanowiczd/ddd-case-01/internal/application/account"
+	applicationcommand "github.com/stefanowiczd/ddd-case-01/internal/application/command"
 )
 
 //go:generate mockgen -destination=./mock/account_handler_mock.go -package=mock -source=./account_interface.go
@@ -17,20 +18,39 @@ type AccountQueryService interface {
 	GetCustomerAccounts(ctx context.Context, dto applicationaccount.GetCustomerAccountsDTO) (applicationaccount.GetCustomerAccountsResponseDTO, error)
 }
 
-// AccountService defines the contract for account service that handles commands/mutable operations
+// AccountService defines the contract for account service that handles commands/mutable operations,
+// the commands are accepted with the event they recorded, which is processed later
 type AccountService interface {
 	// CreateAccount creates a new account
 	CreateAccount(ctx context.Context, dto applicationaccount.CreateAccountDTO) (applicationaccount.CreateAccountResponseDTO, error)
 
 	// Deposit adds money to an account
-	Deposit(ctx context.Context, dto applicationaccount.DepositDTO) error
+	Deposit(ctx context.Context, dto applicationaccount.DepositDTO) (applicationaccount.CommandResponseDTO, error)
 
 	// Withdraw removes money from an account
-	Withdraw(ctx context.Context, dto applicationaccount.WithdrawDTO) error
+	Withdraw(ctx context.Context, dto applicationaccount.WithdrawDTO) (applicationaccount.CommandResponseDTO, error)
 
 	// BlockAccount blocks an account
-	BlockAccount(ctx context.Context, dto applicationaccount.BlockAccountDTO) error
+	BlockAccount(ctx context.Context, dto applicationaccount.BlockAccountDTO) (applicationaccount.CommandResponseDTO, error)
 
 	// UnblockAccount unblocks an account
-	UnblockAccount(ctx context.Context, dto applicationaccount.UnblockAccountDTO) error
+	UnblockAccount(ctx context.Context, dto applicationaccount.UnblockAccountDTO) (applicationaccount.CommandResponseDTO, error)
+
+	// ActivateAccount activates a pending account
+	ActivateAccount(ctx context.Context, dto applicationaccount.ActivateAccountDTO) (applicationaccount.CommandResponseDTO, error)
+
+	// FreezeAccount freezes an account
+	FreezeAccount(ctx context.Context, dto applicationaccount.FreezeAccountDTO) (applicationaccount.CommandResponseDTO, error)
+
+	// UnfreezeAccount unfreezes an account
+	UnfreezeAccount(ctx context.Context, dto applicationaccount.UnfreezeAccountDTO) (applicationaccount.CommandResponseDTO, error)
+
+	// CloseAccount closes an account
+	CloseAccount(ctx context.Context, dto applicationaccount.CloseAccountDTO) (applicationaccount.CommandResponseDTO, error)
+}
+
+// CommandService defines the contract for command service that tracks the commands accepted for processing
+type CommandService interface {
+	// GetCommand returns the command with the state of its event, waiting for it to finish when the wait is given
+	GetCommand(ctx context.Context, dto applicationcommand.GetCommandDTO) (applicationcommand.CommandResponseDTO, error)
 }
@@ -2,11 +2,11 @@ package account
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
 	applicationaccount "github.com/stefanowiczd/ddd-case-01/internal/application/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
 )
 
 // AccountQueryHandler handles HTTP requests for account query operations
@@ -26,11 +26,13 @@ type GetAccountRequest struct {
 }
 
 func (r GetAccountRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.AccountID); err != nil {
-		return fmt.Errorf("validate: account id as uuid: %w", err)
+		validationErr.Add("id", problem.CodeInvalidUUID, "account id must be a uuid")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 // GetAccount handles retrieving an account by ID
@@ -40,7 +42,7 @@ func (h *AccountQueryHandler) GetAccount(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -49,7 +51,7 @@ func (h *AccountQueryHandler) GetAccount(w http.ResponseWriter, r *http.Request)
 	})
 	if err != nil {
 		// TODO add more detailed error validation
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -62,11 +64,13 @@ type GetCustomerAccountsRequest struct {
 }
 
 func (r GetCustomerAccountsRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.CustomerID); err != nil {
-		return fmt.Errorf("validate: customer id as uuid: %w", err)
+		validationErr.Add("customerId", problem.CodeInvalidUUID, "customer id must be a uuid")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 // GetCustomerAccounts handles retrieving all accounts for a customer
@@ -76,7 +80,7 @@ func (h *AccountQueryHandler) GetCustomerAccounts(w http.ResponseWriter, r *http
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -85,7 +89,7 @@ func (h *AccountQueryHandler) GetCustomerAccounts(w http.ResponseWriter, r *http
 	})
 	if err != nil {
 		// TODO add more detailed error validation
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	account "github.com/stefanowiczd/ddd-case-01/internal/application/account"
+	command "github.com/stefanowiczd/ddd-case-01/internal/application/command"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -95,12 +96,28 @@ func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
 	return m.recorder
 }
 
+// ActivateAccount mocks base method.
+func (m *MockAccountService) ActivateAccount(ctx context.Context, dto account.ActivateAccountDTO) (account.CommandResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateAccount", ctx, dto)
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateAccount indicates an expected call of ActivateAccount.
+func (mr *MockAccountServiceMockRecorder) ActivateAccount(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateAccount", reflect.TypeOf((*MockAccountService)(nil).ActivateAccount), ctx, dto)
+}
+
 // BlockAccount mocks base method.
-func (m *MockAccountService) BlockAccount(ctx context.Context, dto account.BlockAccountDTO) error {
+func (m *MockAccountService) BlockAccount(ctx context.Context, dto account.BlockAccountDTO) (account.CommandResponseDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BlockAccount", ctx, dto)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BlockAccount indicates an expected call of BlockAccount.
@@ -109,6 +126,21 @@ func (mr *MockAccountServiceMockRecorder) BlockAccount(ctx, dto any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAccount", reflect.TypeOf((*MockAccountService)(nil).BlockAccount), ctx, dto)
 }
 
+// CloseAccount mocks base method.
+func (m *MockAccountService) CloseAccount(ctx context.Context, dto account.CloseAccountDTO) (account.CommandResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAccount", ctx, dto)
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAccount indicates an expected call of CloseAccount.
+func (mr *MockAccountServiceMockRecorder) CloseAccount(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAccount", reflect.TypeOf((*MockAccountService)(nil).CloseAccount), ctx, dto)
+}
+
 // CreateAccount mocks base method.
 func (m *MockAccountService) CreateAccount(ctx context.Context, dto account.CreateAccountDTO) (account.CreateAccountResponseDTO, error) {
 	m.ctrl.T.Helper()
@@ -125,11 +157,12 @@ func (mr *MockAccountServiceMockRecorder) CreateAccount(ctx, dto any) *gomock.Ca
 }
 
 // Deposit mocks base method.
-func (m *MockAccountService) Deposit(ctx context.Context, dto account.DepositDTO) error {
+func (m *MockAccountService) Deposit(ctx context.Context, dto account.DepositDTO) (account.CommandResponseDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Deposit", ctx, dto)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Deposit indicates an expected call of Deposit.
@@ -138,12 +171,28 @@ func (mr *MockAccountServiceMockRecorder) Deposit(ctx, dto any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockAccountService)(nil).Deposit), ctx, dto)
 }
 
+// FreezeAccount mocks base method.
+func (m *MockAccountService) FreezeAccount(ctx context.Context, dto account.FreezeAccountDTO) (account.CommandResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeAccount", ctx, dto)
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeAccount indicates an expected call of FreezeAccount.
+func (mr *MockAccountServiceMockRecorder) FreezeAccount(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeAccount", reflect.TypeOf((*MockAccountService)(nil).FreezeAccount), ctx, dto)
+}
+
 // UnblockAccount mocks base method.
-func (m *MockAccountService) UnblockAccount(ctx context.Context, dto account.UnblockAccountDTO) error {
+func (m *MockAccountService) UnblockAccount(ctx context.Context, dto account.UnblockAccountDTO) (account.CommandResponseDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UnblockAccount", ctx, dto)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UnblockAccount indicates an expected call of UnblockAccount.
@@ -152,12 +201,28 @@ func (mr *MockAccountServiceMockRecorder) UnblockAccount(ctx, dto any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockAccount", reflect.TypeOf((*MockAccountService)(nil).UnblockAccount), ctx, dto)
 }
 
+// UnfreezeAccount mocks base method.
+func (m *MockAccountService) UnfreezeAccount(ctx context.Context, dto account.UnfreezeAccountDTO) (account.CommandResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfreezeAccount", ctx, dto)
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnfreezeAccount indicates an expected call of UnfreezeAccount.
+func (mr *MockAccountServiceMockRecorder) UnfreezeAccount(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfreezeAccount", reflect.TypeOf((*MockAccountService)(nil).UnfreezeAccount), ctx, dto)
+}
+
 // Withdraw mocks base method.
-func (m *MockAccountService) Withdraw(ctx context.Context, dto account.WithdrawDTO) error {
+func (m *MockAccountService) Withdraw(ctx context.Context, dto account.WithdrawDTO) (account.CommandResponseDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Withdraw", ctx, dto)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(account.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Withdraw indicates an expected call of Withdraw.
@@ -165,3 +230,42 @@ func (mr *MockAccountServiceMockRecorder) Withdraw(ctx, dto any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockAccountService)(nil).Withdraw), ctx, dto)
 }
+
+// MockCommandService is a mock of CommandService interface.
+type MockCommandService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCommandServiceMockRecorder is the mock recorder for MockCommandService.
+type MockCommandServiceMockRecorder struct {
+	mock *MockCommandService
+}
+
+// NewMockCommandService creates a new mock instance.
+func NewMockCommandService(ctrl *gomock.Controller) *MockCommandService {
+	mock := &MockCommandService{ctrl: ctrl}
+	mock.recorder = &MockCommandServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandService) EXPECT() *MockCommandServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCommand mocks base method.
+func (m *MockCommandService) GetCommand(ctx context.Context, dto command.GetCommandDTO) (command.CommandResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommand", ctx, dto)
+	ret0, _ := ret[0].(command.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommand indicates an expected call of GetCommand.
+func (mr *MockCommandServiceMockRecorder) GetCommand(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommand", reflect.TypeOf((*MockCommandService)(nil).GetCommand), ctx, dto)
+}
@@ -0,0 +1,15 @@
+package command
+
+import (
+	"context"
+
+	applicationcommand "github.com/stefanowiczd/ddd-case-01/internal/application/command"
+)
+
+//go:generate mockgen -destination=./mock/command_handler_mock.go -package=mock -source=./command_interface.go
+
+// CommandQueryService defines the contract for command query service that handles queries/read operations of the commands
+type CommandQueryService interface {
+	// GetCommand returns the command with the state of its event, waiting for it to finish when the wait is given
+	GetCommand(ctx context.Context, dto applicationcommand.GetCommandDTO) (applicationcommand.CommandResponseDTO, error)
+}
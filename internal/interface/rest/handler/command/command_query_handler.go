@@ -0,0 +1,70 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	applicationcommand "github.com/stefanowiczd/ddd-case-01/internal/application/command"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// CommandQueryHandler handles HTTP requests of clients tracking the commands accepted for processing
+type CommandQueryHandler struct {
+	commandQueryService CommandQueryService
+}
+
+// NewCommandQueryHandler creates a new command query handler
+func NewCommandQueryHandler(commandQueryService CommandQueryService) *CommandQueryHandler {
+	return &CommandQueryHandler{
+		commandQueryService: commandQueryService,
+	}
+}
+
+// GetCommandRequest represents the request of a command status
+type GetCommandRequest struct {
+	EventID string
+}
+
+func (r GetCommandRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.EventID); err != nil {
+		validationErr.Add("id", problem.CodeInvalidUUID, "event id must be a uuid")
+	}
+
+	return validationErr.Err()
+}
+
+// GetCommand handles retrieving the command status by the ID of its event, i.e. /commands/{eventId}.
+// The client preferring to wait (Prefer: wait=10) gets the status once the command is finished or the wait is over.
+func (h *CommandQueryHandler) GetCommand(w http.ResponseWriter, r *http.Request) {
+	req := GetCommandRequest{
+		EventID: r.PathValue("id"),
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	wait, preferred := middleware.PreferredWait(r)
+
+	command, err := h.commandQueryService.GetCommand(r.Context(), applicationcommand.GetCommandDTO{
+		EventID: uuid.MustParse(req.EventID),
+		Wait:    wait,
+	})
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if preferred {
+		w.Header().Set(middleware.PreferenceAppliedHeader, fmt.Sprintf("wait=%d", int(min(wait, applicationcommand.MaxWait).Seconds())))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(command) // TODO decide about handling of this error.
+}
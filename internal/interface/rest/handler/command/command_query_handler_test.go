@@ -0,0 +1,158 @@
+//go:build unit
+
+package command
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	applicationcommand "github.com/stefanowiczd/ddd-case-01/internal/application/command"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/command/mock"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+)
+
+func TestCommandQueryHandler_GetCommand(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		eventID                 string
+		prefer                  string
+		mockCommandQueryService func(*gomock.Controller) *mock.MockCommandQueryService
+	}
+
+	type testCaseExpected struct {
+		statusCode        int
+		preferenceApplied string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "successful command status retrieval",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				mockCommandQueryService: func(m *gomock.Controller) *mock.MockCommandQueryService {
+					mock := mock.NewMockCommandQueryService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), applicationcommand.GetCommandDTO{EventID: eventID}).
+						Return(applicationcommand.CommandResponseDTO{ID: eventID.String(), State: "ready"}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "successful command status retrieval waiting for command to finish",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				prefer:  "wait=5",
+				mockCommandQueryService: func(m *gomock.Controller) *mock.MockCommandQueryService {
+					mock := mock.NewMockCommandQueryService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), applicationcommand.GetCommandDTO{EventID: eventID, Wait: 5 * time.Second}).
+						Return(applicationcommand.CommandResponseDTO{ID: eventID.String(), State: "completed", Finished: true}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode:        http.StatusOK,
+				preferenceApplied: "wait=5",
+			},
+		},
+		{
+			name: "successful command status retrieval waiting no longer than max wait",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				prefer:  "wait=600",
+				mockCommandQueryService: func(m *gomock.Controller) *mock.MockCommandQueryService {
+					mock := mock.NewMockCommandQueryService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), gomock.Any()).
+						Return(applicationcommand.CommandResponseDTO{ID: eventID.String(), State: "ready"}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode:        http.StatusOK,
+				preferenceApplied: "wait=10",
+			},
+		},
+		{
+			name: "invalid event id format in request path",
+			params: testCaseParams{
+				eventID: "ev123",
+				mockCommandQueryService: func(m *gomock.Controller) *mock.MockCommandQueryService {
+					return mock.NewMockCommandQueryService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "command not found",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				mockCommandQueryService: func(m *gomock.Controller) *mock.MockCommandQueryService {
+					mock := mock.NewMockCommandQueryService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), gomock.Any()).
+						Return(applicationcommand.CommandResponseDTO{}, applicationcommand.ErrCommandNotFound)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "internal error while retrieving command status",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				mockCommandQueryService: func(m *gomock.Controller) *mock.MockCommandQueryService {
+					mock := mock.NewMockCommandQueryService(m)
+					mock.EXPECT().
+						GetCommand(gomock.Any(), gomock.Any()).
+						Return(applicationcommand.CommandResponseDTO{}, errors.New("error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewCommandQueryHandler(tt.params.mockCommandQueryService(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/commands/"+tt.params.eventID, nil)
+			req.SetPathValue("id", tt.params.eventID)
+			if tt.params.prefer != "" {
+				req.Header.Set(middleware.PreferHeader, tt.params.prefer)
+			}
+			w := httptest.NewRecorder()
+
+			handler.GetCommand(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+			require.Equal(t, tt.expected.preferenceApplied, w.Header().Get(middleware.PreferenceAppliedHeader))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./command_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/command_handler_mock.go -package=mock -source=./command_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	command "github.com/stefanowiczd/ddd-case-01/internal/application/command"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommandQueryService is a mock of CommandQueryService interface.
+type MockCommandQueryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandQueryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCommandQueryServiceMockRecorder is the mock recorder for MockCommandQueryService.
+type MockCommandQueryServiceMockRecorder struct {
+	mock *MockCommandQueryService
+}
+
+// NewMockCommandQueryService creates a new mock instance.
+func NewMockCommandQueryService(ctrl *gomock.Controller) *MockCommandQueryService {
+	mock := &MockCommandQueryService{ctrl: ctrl}
+	mock.recorder = &MockCommandQueryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommandQueryService) EXPECT() *MockCommandQueryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCommand mocks base method.
+func (m *MockCommandQueryService) GetCommand(ctx context.Context, dto command.GetCommandDTO) (command.CommandResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommand", ctx, dto)
+	ret0, _ := ret[0].(command.CommandResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommand indicates an expected call of GetCommand.
+func (mr *MockCommandQueryServiceMockRecorder) GetCommand(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommand", reflect.TypeOf((*MockCommandQueryService)(nil).GetCommand), ctx, dto)
+}
@@ -0,0 +1,146 @@
+package credentials
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	credentialsapplication "github.com/stefanowiczd/ddd-case-01/internal/application/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// CredentialsHandler handles HTTP requests for the online banking credentials of the customers
+type CredentialsHandler struct {
+	credentialsService CredentialsService
+}
+
+func NewCredentialsHandler(credentialsService CredentialsService) *CredentialsHandler {
+	return &CredentialsHandler{
+		credentialsService: credentialsService,
+	}
+}
+
+type SetPasswordRequest struct {
+	CustomerID string
+	Password   string `json:"password"`
+}
+
+func (r *SetPasswordRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.CustomerID); err != nil {
+		validationErr.Add("customerId", problem.CodeInvalidUUID, "customer id must be a uuid")
+	}
+
+	if r.Password == "" {
+		validationErr.Add("password", problem.CodeRequired, "password is required")
+	}
+
+	return validationErr.Err()
+}
+
+func (h *CredentialsHandler) SetPassword(w http.ResponseWriter, r *http.Request) {
+	var req SetPasswordRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	req.CustomerID = r.PathValue("customerId")
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	err := h.credentialsService.SetPassword(
+		r.Context(),
+		credentialsapplication.SetPasswordDTO{
+			CustomerID: req.CustomerID,
+			Password:   req.Password,
+		},
+	)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CredentialsHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	customerID := r.PathValue("customerId")
+
+	if _, err := uuid.Parse(customerID); err != nil {
+		problem.Write(w, r, problem.InvalidField("customerId", problem.CodeInvalidUUID, "customer id must be a uuid"))
+		return
+	}
+
+	enrollment, err := h.credentialsService.EnrollTOTP(
+		r.Context(),
+		credentialsapplication.EnrollTOTPDTO{
+			CustomerID: customerID,
+		},
+	)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// The secret is shown to the customer once, it's never cached
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(enrollment) // TODO decide about handling of this error.
+}
+
+type ConfirmTOTPRequest struct {
+	CustomerID string
+	Code       string `json:"code"`
+}
+
+func (r *ConfirmTOTPRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.CustomerID); err != nil {
+		validationErr.Add("customerId", problem.CodeInvalidUUID, "customer id must be a uuid")
+	}
+
+	if r.Code == "" {
+		validationErr.Add("code", problem.CodeRequired, "code is required")
+	}
+
+	return validationErr.Err()
+}
+
+func (h *CredentialsHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	var req ConfirmTOTPRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	req.CustomerID = r.PathValue("customerId")
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	err := h.credentialsService.ConfirmTOTP(
+		r.Context(),
+		credentialsapplication.ConfirmTOTPDTO{
+			CustomerID: req.CustomerID,
+			Code:       req.Code,
+		},
+	)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,375 @@
+//go:build unit
+
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	credentialsapplication "github.com/stefanowiczd/ddd-case-01/internal/application/credentials"
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/credentials/mock"
+)
+
+func TestCredentialsHandler_SetPassword(t *testing.T) {
+	type testCaseParams struct {
+		req                    SetPasswordRequest
+		reqBody                func(r SetPasswordRequest) io.Reader
+		mockCredentialsService func(*gomock.Controller) *mock.MockCredentialsService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should return 400 - invalid request body",
+			params: testCaseParams{
+				reqBody: func(SetPasswordRequest) io.Reader {
+					return bytes.NewBufferString("{")
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					return mock.NewMockCredentialsService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 400 - invalid customer id",
+			params: testCaseParams{
+				req: SetPasswordRequest{
+					CustomerID: "invalid-customer-id",
+					Password:   "correct horse battery",
+				},
+				reqBody: func(r SetPasswordRequest) io.Reader {
+					body, _ := json.Marshal(r)
+					return bytes.NewBuffer(body)
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					return mock.NewMockCredentialsService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 400 - weak password",
+			params: testCaseParams{
+				req: SetPasswordRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Password:   "short",
+				},
+				reqBody: func(r SetPasswordRequest) io.Reader {
+					body, _ := json.Marshal(r)
+					return bytes.NewBuffer(body)
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().SetPassword(gomock.Any(), gomock.Any()).
+						Return(credentialsdomain.ErrWeakPassword)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 404 - customer not found",
+			params: testCaseParams{
+				req: SetPasswordRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Password:   "correct horse battery",
+				},
+				reqBody: func(r SetPasswordRequest) io.Reader {
+					body, _ := json.Marshal(r)
+					return bytes.NewBuffer(body)
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().SetPassword(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.ErrCustomerNotFound)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "should return 204 - password set successfully",
+			params: testCaseParams{
+				req: SetPasswordRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Password:   "correct horse battery",
+				},
+				reqBody: func(r SetPasswordRequest) io.Reader {
+					body, _ := json.Marshal(r)
+					return bytes.NewBuffer(body)
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().SetPassword(gomock.Any(), credentialsapplication.SetPasswordDTO{
+						CustomerID: "00000000-0000-0000-0000-000000000000",
+						Password:   "correct horse battery",
+					}).Return(nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNoContent,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewCredentialsHandler(tt.params.mockCredentialsService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPut, "/customers/{customerId}/credentials", tt.params.reqBody(tt.params.req))
+			req.SetPathValue("customerId", tt.params.req.CustomerID)
+
+			w := httptest.NewRecorder()
+
+			handler.SetPassword(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
+
+func TestCredentialsHandler_EnrollTOTP(t *testing.T) {
+	type testCaseParams struct {
+		customerID             string
+		mockCredentialsService func(*gomock.Controller) *mock.MockCredentialsService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+		enrollment credentialsapplication.EnrollTOTPResponseDTO
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should return 400 - invalid customer id",
+			params: testCaseParams{
+				customerID: "invalid-customer-id",
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					return mock.NewMockCredentialsService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 409 - totp already enabled",
+			params: testCaseParams{
+				customerID: "00000000-0000-0000-0000-000000000000",
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().EnrollTOTP(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.EnrollTOTPResponseDTO{}, credentialsdomain.ErrTOTPAlreadyEnabled)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusConflict,
+			},
+		},
+		{
+			name: "should return 200 - totp enrolled successfully",
+			params: testCaseParams{
+				customerID: "00000000-0000-0000-0000-000000000000",
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().EnrollTOTP(gomock.Any(), credentialsapplication.EnrollTOTPDTO{
+						CustomerID: "00000000-0000-0000-0000-000000000000",
+					}).Return(credentialsapplication.EnrollTOTPResponseDTO{
+						Secret: "JBSWY3DPEHPK3PXP",
+						URI:    "otpauth://totp/ddd-bank:00000000-0000-0000-0000-000000000000?secret=JBSWY3DPEHPK3PXP",
+					}, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+				enrollment: credentialsapplication.EnrollTOTPResponseDTO{
+					Secret: "JBSWY3DPEHPK3PXP",
+					URI:    "otpauth://totp/ddd-bank:00000000-0000-0000-0000-000000000000?secret=JBSWY3DPEHPK3PXP",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewCredentialsHandler(tt.params.mockCredentialsService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPost, "/customers/{customerId}/credentials/totp", nil)
+			req.SetPathValue("customerId", tt.params.customerID)
+
+			w := httptest.NewRecorder()
+
+			handler.EnrollTOTP(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+			if tt.expected.statusCode != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+			var enrollment credentialsapplication.EnrollTOTPResponseDTO
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&enrollment))
+			require.Equal(t, tt.expected.enrollment, enrollment)
+		})
+	}
+}
+
+func TestCredentialsHandler_ConfirmTOTP(t *testing.T) {
+	type testCaseParams struct {
+		req                    ConfirmTOTPRequest
+		mockCredentialsService func(*gomock.Controller) *mock.MockCredentialsService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should return 400 - code missing",
+			params: testCaseParams{
+				req: ConfirmTOTPRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					return mock.NewMockCredentialsService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 400 - wrong totp code",
+			params: testCaseParams{
+				req: ConfirmTOTPRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Code:       "000000",
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().ConfirmTOTP(gomock.Any(), gomock.Any()).
+						Return(credentialsdomain.ErrWrongTOTPCode)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 500 - totp confirmation failed",
+			params: testCaseParams{
+				req: ConfirmTOTPRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Code:       "123456",
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().ConfirmTOTP(gomock.Any(), gomock.Any()).
+						Return(errors.New("totp confirmation failed"))
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "should return 204 - totp confirmed successfully",
+			params: testCaseParams{
+				req: ConfirmTOTPRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000000",
+					Code:       "123456",
+				},
+				mockCredentialsService: func(c *gomock.Controller) *mock.MockCredentialsService {
+					mock := mock.NewMockCredentialsService(c)
+					mock.EXPECT().ConfirmTOTP(gomock.Any(), credentialsapplication.ConfirmTOTPDTO{
+						CustomerID: "00000000-0000-0000-0000-000000000000",
+						Code:       "123456",
+					}).Return(nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNoContent,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewCredentialsHandler(tt.params.mockCredentialsService(ctrl))
+
+			body, _ := json.Marshal(tt.params.req)
+			req := httptest.NewRequest(http.MethodPost, "/customers/{customerId}/credentials/totp/confirm", bytes.NewBuffer(body))
+			req.SetPathValue("customerId", tt.params.req.CustomerID)
+
+			w := httptest.NewRecorder()
+
+			handler.ConfirmTOTP(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
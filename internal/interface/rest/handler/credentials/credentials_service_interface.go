@@ -0,0 +1,31 @@
+package credentials
+
+import (
+	"context"
+
+	credentialsapplication "github.com/stefanowiczd/ddd-case-01/internal/application/credentials"
+)
+
+//go:generate mockgen -destination=./mock/credentials_service_mock.go -package=mock -source=./credentials_service_interface.go
+
+// CredentialsService defines the contract for the credentials service that handles the password and the TOTP of the customers
+type CredentialsService interface {
+	// SetPassword sets the password of the customer
+	SetPassword(ctx context.Context, dto credentialsapplication.SetPasswordDTO) error
+	// EnrollTOTP starts the enrollment of the TOTP second factor of the customer
+	EnrollTOTP(ctx context.Context, dto credentialsapplication.EnrollTOTPDTO) (credentialsapplication.EnrollTOTPResponseDTO, error)
+	// ConfirmTOTP enables the TOTP second factor enrolled
+	ConfirmTOTP(ctx context.Context, dto credentialsapplication.ConfirmTOTPDTO) error
+}
+
+// SessionService defines the contract for the session service that handles the login and the logout of the customers
+type SessionService interface {
+	// Login logs the customer in with the email and the password
+	Login(ctx context.Context, dto credentialsapplication.LoginDTO) (credentialsapplication.LoginResponseDTO, error)
+	// VerifyMFA completes the login with the TOTP code
+	VerifyMFA(ctx context.Context, dto credentialsapplication.VerifyMFADTO) (credentialsapplication.SessionResponseDTO, error)
+	// RefreshSession issues the new tokens of the session
+	RefreshSession(ctx context.Context, dto credentialsapplication.RefreshSessionDTO) (credentialsapplication.SessionResponseDTO, error)
+	// Logout ends the session
+	Logout(ctx context.Context, dto credentialsapplication.LogoutDTO) error
+}
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./credentials_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/credentials_service_mock.go -package=mock -source=./credentials_service_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	credentials "github.com/stefanowiczd/ddd-case-01/internal/application/credentials"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCredentialsService is a mock of CredentialsService interface.
+type MockCredentialsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCredentialsServiceMockRecorder is the mock recorder for MockCredentialsService.
+type MockCredentialsServiceMockRecorder struct {
+	mock *MockCredentialsService
+}
+
+// NewMockCredentialsService creates a new mock instance.
+func NewMockCredentialsService(ctrl *gomock.Controller) *MockCredentialsService {
+	mock := &MockCredentialsService{ctrl: ctrl}
+	mock.recorder = &MockCredentialsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialsService) EXPECT() *MockCredentialsServiceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockCredentialsService) ConfirmTOTP(ctx context.Context, dto credentials.ConfirmTOTPDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockCredentialsServiceMockRecorder) ConfirmTOTP(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockCredentialsService)(nil).ConfirmTOTP), ctx, dto)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockCredentialsService) EnrollTOTP(ctx context.Context, dto credentials.EnrollTOTPDTO) (credentials.EnrollTOTPResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx, dto)
+	ret0, _ := ret[0].(credentials.EnrollTOTPResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockCredentialsServiceMockRecorder) EnrollTOTP(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockCredentialsService)(nil).EnrollTOTP), ctx, dto)
+}
+
+// SetPassword mocks base method.
+func (m *MockCredentialsService) SetPassword(ctx context.Context, dto credentials.SetPasswordDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockCredentialsServiceMockRecorder) SetPassword(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockCredentialsService)(nil).SetPassword), ctx, dto)
+}
+
+// MockSessionService is a mock of SessionService interface.
+type MockSessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionServiceMockRecorder is the mock recorder for MockSessionService.
+type MockSessionServiceMockRecorder struct {
+	mock *MockSessionService
+}
+
+// NewMockSessionService creates a new mock instance.
+func NewMockSessionService(ctrl *gomock.Controller) *MockSessionService {
+	mock := &MockSessionService{ctrl: ctrl}
+	mock.recorder = &MockSessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionService) EXPECT() *MockSessionServiceMockRecorder {
+	return m.recorder
+}
+
+// Login mocks base method.
+func (m *MockSessionService) Login(ctx context.Context, dto credentials.LoginDTO) (credentials.LoginResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, dto)
+	ret0, _ := ret[0].(credentials.LoginResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockSessionServiceMockRecorder) Login(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockSessionService)(nil).Login), ctx, dto)
+}
+
+// Logout mocks base method.
+func (m *MockSessionService) Logout(ctx context.Context, dto credentials.LogoutDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockSessionServiceMockRecorder) Logout(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockSessionService)(nil).Logout), ctx, dto)
+}
+
+// RefreshSession mocks base method.
+func (m *MockSessionService) RefreshSession(ctx context.Context, dto credentials.RefreshSessionDTO) (credentials.SessionResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, dto)
+	ret0, _ := ret[0].(credentials.SessionResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockSessionServiceMockRecorder) RefreshSession(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockSessionService)(nil).RefreshSession), ctx, dto)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockSessionService) VerifyMFA(ctx context.Context, dto credentials.VerifyMFADTO) (credentials.SessionResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, dto)
+	ret0, _ := ret[0].(credentials.SessionResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockSessionServiceMockRecorder) VerifyMFA(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockSessionService)(nil).VerifyMFA), ctx, dto)
+}
@@ -0,0 +1,190 @@
+package credentials
+
+import (
+	"encoding/json"
+	"net/http"
+
+	credentialsapplication "github.com/stefanowiczd/ddd-case-01/internal/application/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// SessionHandler handles HTTP requests for the login and the logout of the customers
+type SessionHandler struct {
+	sessionService SessionService
+}
+
+func NewSessionHandler(sessionService SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (r *LoginRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if r.Email == "" {
+		validationErr.Add("email", problem.CodeRequired, "email is required")
+	}
+
+	if r.Password == "" {
+		validationErr.Add("password", problem.CodeRequired, "password is required")
+	}
+
+	return validationErr.Err()
+}
+
+func (h *SessionHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	login, err := h.sessionService.Login(
+		r.Context(),
+		credentialsapplication.LoginDTO{
+			Email:    req.Email,
+			Password: req.Password,
+		},
+	)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	writeTokens(w, login)
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfaToken"`
+	Code     string `json:"code"`
+}
+
+func (r *VerifyMFARequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if r.MFAToken == "" {
+		validationErr.Add("mfaToken", problem.CodeRequired, "mfa token is required")
+	}
+
+	if r.Code == "" {
+		validationErr.Add("code", problem.CodeRequired, "code is required")
+	}
+
+	return validationErr.Err()
+}
+
+func (h *SessionHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req VerifyMFARequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	session, err := h.sessionService.VerifyMFA(
+		r.Context(),
+		credentialsapplication.VerifyMFADTO{
+			MFAToken: req.MFAToken,
+			Code:     req.Code,
+		},
+	)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	writeTokens(w, session)
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (r *RefreshTokenRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if r.RefreshToken == "" {
+		validationErr.Add("refreshToken", problem.CodeRequired, "refresh token is required")
+	}
+
+	return validationErr.Err()
+}
+
+func (h *SessionHandler) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	session, err := h.sessionService.RefreshSession(
+		r.Context(),
+		credentialsapplication.RefreshSessionDTO{
+			RefreshToken: req.RefreshToken,
+		},
+	)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	writeTokens(w, session)
+}
+
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	err := h.sessionService.Logout(
+		r.Context(),
+		credentialsapplication.LogoutDTO{
+			RefreshToken: req.RefreshToken,
+		},
+	)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTokens writes the response carrying the tokens, the tokens are never cached
+func writeTokens(w http.ResponseWriter, tokens any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(tokens) // TODO decide about handling of this error.
+}
@@ -0,0 +1,450 @@
+//go:build unit
+
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	credentialsapplication "github.com/stefanowiczd/ddd-case-01/internal/application/credentials"
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/credentials/mock"
+)
+
+func testSessionResponse() credentialsapplication.SessionResponseDTO {
+	return credentialsapplication.SessionResponseDTO{
+		AccessToken:      "ses_access",
+		RefreshToken:     "ref_refresh",
+		TokenType:        "Bearer",
+		ExpiresAt:        time.Date(2025, 1, 1, 12, 15, 0, 0, time.UTC),
+		RefreshExpiresAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSessionHandler_Login(t *testing.T) {
+	type testCaseParams struct {
+		req                LoginRequest
+		mockSessionService func(*gomock.Controller) *mock.MockSessionService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+		login      credentialsapplication.LoginResponseDTO
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	session := testSessionResponse()
+	mfaExpiresAt := time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+
+	tests := []testCase{
+		{
+			name: "should return 400 - required fields missing",
+			params: testCaseParams{
+				req: LoginRequest{
+					Email: "john.doe@example.com",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					return mock.NewMockSessionService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 401 - invalid credentials",
+			params: testCaseParams{
+				req: LoginRequest{
+					Email:    "john.doe@example.com",
+					Password: "wrong horse battery",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().Login(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.LoginResponseDTO{}, identitydomain.ErrInvalidCredentials)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "should return 423 - credentials locked",
+			params: testCaseParams{
+				req: LoginRequest{
+					Email:    "john.doe@example.com",
+					Password: "correct horse battery",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().Login(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.LoginResponseDTO{}, credentialsdomain.ErrCredentialsLocked)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusLocked,
+			},
+		},
+		{
+			name: "should return 200 - mfa required",
+			params: testCaseParams{
+				req: LoginRequest{
+					Email:    "john.doe@example.com",
+					Password: "correct horse battery",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().Login(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.LoginResponseDTO{
+							MFARequired:  true,
+							MFAToken:     "ses_mfa",
+							MFAExpiresAt: &mfaExpiresAt,
+						}, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+				login: credentialsapplication.LoginResponseDTO{
+					MFARequired:  true,
+					MFAToken:     "ses_mfa",
+					MFAExpiresAt: &mfaExpiresAt,
+				},
+			},
+		},
+		{
+			name: "should return 200 - logged in successfully",
+			params: testCaseParams{
+				req: LoginRequest{
+					Email:    "john.doe@example.com",
+					Password: "correct horse battery",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().Login(gomock.Any(), credentialsapplication.LoginDTO{
+						Email:    "john.doe@example.com",
+						Password: "correct horse battery",
+					}).Return(credentialsapplication.LoginResponseDTO{Session: &session}, nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+				login: credentialsapplication.LoginResponseDTO{
+					Session: &session,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewSessionHandler(tt.params.mockSessionService(ctrl))
+
+			body, _ := json.Marshal(tt.params.req)
+			req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+
+			w := httptest.NewRecorder()
+
+			handler.Login(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+			if tt.expected.statusCode != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+			var login credentialsapplication.LoginResponseDTO
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&login))
+			require.Equal(t, tt.expected.login, login)
+		})
+	}
+}
+
+func TestSessionHandler_VerifyMFA(t *testing.T) {
+	type testCaseParams struct {
+		req                VerifyMFARequest
+		mockSessionService func(*gomock.Controller) *mock.MockSessionService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should return 400 - code missing",
+			params: testCaseParams{
+				req: VerifyMFARequest{
+					MFAToken: "ses_mfa",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					return mock.NewMockSessionService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 400 - wrong totp code",
+			params: testCaseParams{
+				req: VerifyMFARequest{
+					MFAToken: "ses_mfa",
+					Code:     "000000",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().VerifyMFA(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.SessionResponseDTO{}, credentialsdomain.ErrWrongTOTPCode)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 401 - mfa token expired",
+			params: testCaseParams{
+				req: VerifyMFARequest{
+					MFAToken: "ses_mfa",
+					Code:     "123456",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().VerifyMFA(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.SessionResponseDTO{}, identitydomain.ErrInvalidCredentials)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "should return 200 - mfa verified successfully",
+			params: testCaseParams{
+				req: VerifyMFARequest{
+					MFAToken: "ses_mfa",
+					Code:     "123456",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().VerifyMFA(gomock.Any(), credentialsapplication.VerifyMFADTO{
+						MFAToken: "ses_mfa",
+						Code:     "123456",
+					}).Return(testSessionResponse(), nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewSessionHandler(tt.params.mockSessionService(ctrl))
+
+			body, _ := json.Marshal(tt.params.req)
+			req := httptest.NewRequest(http.MethodPost, "/auth/login/mfa", bytes.NewBuffer(body))
+
+			w := httptest.NewRecorder()
+
+			handler.VerifyMFA(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
+
+func TestSessionHandler_RefreshSession(t *testing.T) {
+	type testCaseParams struct {
+		req                RefreshTokenRequest
+		mockSessionService func(*gomock.Controller) *mock.MockSessionService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should return 400 - refresh token missing",
+			params: testCaseParams{
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					return mock.NewMockSessionService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 401 - refresh token expired",
+			params: testCaseParams{
+				req: RefreshTokenRequest{
+					RefreshToken: "ref_refresh",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).
+						Return(credentialsapplication.SessionResponseDTO{}, identitydomain.ErrInvalidCredentials)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "should return 200 - session refreshed successfully",
+			params: testCaseParams{
+				req: RefreshTokenRequest{
+					RefreshToken: "ref_refresh",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().RefreshSession(gomock.Any(), credentialsapplication.RefreshSessionDTO{
+						RefreshToken: "ref_refresh",
+					}).Return(testSessionResponse(), nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewSessionHandler(tt.params.mockSessionService(ctrl))
+
+			body, _ := json.Marshal(tt.params.req)
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBuffer(body))
+
+			w := httptest.NewRecorder()
+
+			handler.RefreshSession(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
+
+func TestSessionHandler_Logout(t *testing.T) {
+	type testCaseParams struct {
+		req                RefreshTokenRequest
+		mockSessionService func(*gomock.Controller) *mock.MockSessionService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "should return 400 - refresh token missing",
+			params: testCaseParams{
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					return mock.NewMockSessionService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should return 204 - logged out successfully",
+			params: testCaseParams{
+				req: RefreshTokenRequest{
+					RefreshToken: "ref_refresh",
+				},
+				mockSessionService: func(c *gomock.Controller) *mock.MockSessionService {
+					mock := mock.NewMockSessionService(c)
+					mock.EXPECT().Logout(gomock.Any(), credentialsapplication.LogoutDTO{
+						RefreshToken: "ref_refresh",
+					}).Return(nil)
+
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNoContent,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewSessionHandler(tt.params.mockSessionService(ctrl))
+
+			body, _ := json.Marshal(tt.params.req)
+			req := httptest.NewRequest(http.MethodPost, "/auth/logout", bytes.NewBuffer(body))
+
+			w := httptest.NewRecorder()
+
+			handler.Logout(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
@@ -2,12 +2,12 @@ package customer
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
 
 	customerapplication "github.com/stefanowiczd/ddd-case-01/internal/application/customer"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
 )
 
 // Handler handles HTTP requests for customer operations
@@ -51,20 +51,33 @@ type Customer struct {
 }
 
 func (r *CreateCustomerRequest) Validate() error {
-	// TODO: validate fields
-	return nil
+	var validationErr problem.ValidationError
+
+	if r.FirstName == "" {
+		validationErr.Add("firstName", problem.CodeRequired, "first name is required")
+	}
+
+	if r.LastName == "" {
+		validationErr.Add("lastName", problem.CodeRequired, "last name is required")
+	}
+
+	if r.Email == "" {
+		validationErr.Add("email", problem.CodeRequired, "email is required")
+	}
+
+	return validationErr.Err()
 }
 
 func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
 	var req CreateCustomerRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidBody)
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -85,7 +98,7 @@ func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request)
 		})
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -104,22 +117,39 @@ type UpdateCustomerRequest struct {
 }
 
 func (r *UpdateCustomerRequest) Validate() error {
-	// TODO: validate fields
-	return nil
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.CustomerID); err != nil {
+		validationErr.Add("customerId", problem.CodeInvalidUUID, "customer id must be a uuid")
+	}
+
+	if r.FirstName == "" {
+		validationErr.Add("firstName", problem.CodeRequired, "first name is required")
+	}
+
+	if r.LastName == "" {
+		validationErr.Add("lastName", problem.CodeRequired, "last name is required")
+	}
+
+	if r.Email == "" {
+		validationErr.Add("email", problem.CodeRequired, "email is required")
+	}
+
+	return validationErr.Err()
 }
 
 func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
 	var req UpdateCustomerRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidBody)
 		return
 	}
 
-	req.CustomerID = r.PathValue("id")
+	req.CustomerID = r.PathValue("customerId")
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -141,7 +171,7 @@ func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request)
 		},
 	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -154,29 +184,31 @@ type BlockCustomerRequest struct {
 }
 
 func (r *BlockCustomerRequest) Validate() error {
+	var validationErr problem.ValidationError
+
 	if _, err := uuid.Parse(r.CustomerID); err != nil {
-		return fmt.Errorf("validate: customer id as uuid: %w", err)
+		validationErr.Add("customerId", problem.CodeInvalidUUID, "customer id must be a uuid")
 	}
 
 	if r.Reason == "" {
-		return fmt.Errorf("validate: reason is required")
+		validationErr.Add("reason", problem.CodeRequired, "reason is required")
 	}
 
-	return nil
+	return validationErr.Err()
 }
 
 func (h *CustomerHandler) BlockCustomer(w http.ResponseWriter, r *http.Request) {
 	var req BlockCustomerRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		problem.Write(w, r, problem.ErrInvalidBody)
 		return
 	}
 
 	req.CustomerID = r.PathValue("customerId")
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -188,7 +220,7 @@ func (h *CustomerHandler) BlockCustomer(w http.ResponseWriter, r *http.Request)
 		},
 	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -199,7 +231,7 @@ func (h *CustomerHandler) UnblockCustomer(w http.ResponseWriter, r *http.Request
 	customerID := r.PathValue("customerId")
 
 	if _, err := uuid.Parse(customerID); err != nil {
-		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.InvalidField("customerId", problem.CodeInvalidUUID, "customer id must be a uuid"))
 		return
 	}
 	err := h.customerService.UnblockCustomer(
@@ -210,7 +242,7 @@ func (h *CustomerHandler) UnblockCustomer(w http.ResponseWriter, r *http.Request
 	)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -221,7 +253,7 @@ func (h *CustomerHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request)
 	customerID := r.PathValue("customerId")
 
 	if _, err := uuid.Parse(customerID); err != nil {
-		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.InvalidField("customerId", problem.CodeInvalidUUID, "customer id must be a uuid"))
 		return
 	}
 
@@ -232,7 +264,7 @@ func (h *CustomerHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request)
 		},
 	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
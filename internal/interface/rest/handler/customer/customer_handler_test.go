@@ -37,8 +37,26 @@ func TestCustomerHandler_CreateCustomer(t *testing.T) {
 		expected testCaseExpected
 	}
 
-	// TODO: add tests for payload validation
 	tests := []testCase{
+		{
+			name: "should return 400 - required fields missing",
+			params: testCaseParams{
+				req: CreateCustomerRequest{
+					FirstName: "John",
+				},
+				reqBody: func(r CreateCustomerRequest) io.Reader {
+					body, _ := json.Marshal(r)
+					return bytes.NewBuffer(body)
+				},
+				mockCustomerService: func(c *gomock.Controller) *mock.MockCustomerService {
+					return mock.NewMockCustomerService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				wantError:  true,
+			},
+		},
 		{
 			name: "should return 500 - customer creation failed",
 			params: testCaseParams{
@@ -169,16 +187,37 @@ func TestCustomerHandler_UpdateCustomer(t *testing.T) {
 		expected testCaseExpected
 	}
 
-	// TODO: add tests for payload validation
 	tests := []testCase{
 		{
-			name: "should return 500 - customer update failed",
+			name: "should return 400 - invalid customer id",
 			params: testCaseParams{
 				req: UpdateCustomerRequest{
 					CustomerID: "123",
 					FirstName:  "John",
 					LastName:   "Doe",
 					Email:      "john.doe@example.com",
+				},
+				reqBody: func(r UpdateCustomerRequest) io.Reader {
+					body, _ := json.Marshal(r)
+					return bytes.NewBuffer(body)
+				},
+				mockCustomerService: func(c *gomock.Controller) *mock.MockCustomerService {
+					return mock.NewMockCustomerService(c)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				wantError:  true,
+			},
+		},
+		{
+			name: "should return 500 - customer update failed",
+			params: testCaseParams{
+				req: UpdateCustomerRequest{
+					CustomerID: "00000000-0000-0000-0000-000000000001",
+					FirstName:  "John",
+					LastName:   "Doe",
+					Email:      "john.doe@example.com",
 					Phone:      "1234567890",
 					Address: Address{
 						Street:     "Street 1",
@@ -209,7 +248,7 @@ func TestCustomerHandler_UpdateCustomer(t *testing.T) {
 			name: "should return 204 - customer updated successfully",
 			params: testCaseParams{
 				req: UpdateCustomerRequest{
-					CustomerID: "123",
+					CustomerID: "00000000-0000-0000-0000-000000000001",
 					FirstName:  "John",
 					LastName:   "Doe",
 					Email:      "john.doe@example.com",
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	customerapplication "github.com/stefanowiczd/ddd-case-01/internal/application/customer"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
 )
 
 // CustomerQueryHandler handles HTTP requests for customer query operations
@@ -23,7 +24,7 @@ func (h *CustomerQueryHandler) GetCustomer(w http.ResponseWriter, r *http.Reques
 	customerID := r.PathValue("customerId")
 
 	if _, err := uuid.Parse(customerID); err != nil {
-		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		problem.Write(w, r, problem.InvalidField("customerId", problem.CodeInvalidUUID, "customer id must be a uuid"))
 		return
 	}
 
@@ -34,7 +35,7 @@ func (h *CustomerQueryHandler) GetCustomer(w http.ResponseWriter, r *http.Reques
 		},
 	)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -0,0 +1,141 @@
+package event
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	applicationevent "github.com/stefanowiczd/ddd-case-01/internal/application/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// EventHandler handles HTTP requests of operators repairing the events
+type EventHandler struct {
+	eventService EventService
+}
+
+// NewEventHandler creates a new event handler
+func NewEventHandler(eventService EventService) *EventHandler {
+	return &EventHandler{
+		eventService: eventService,
+	}
+}
+
+// RequeueEventRequest represents the request body for requeuing an event, the actor is the operator recorded in the event audit
+type RequeueEventRequest struct {
+	Actor   string `json:"actor"`
+	Reason  string `json:"reason"`
+	EventID string
+}
+
+func (r RequeueEventRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.EventID); err != nil {
+		validationErr.Add("id", problem.CodeInvalidUUID, "event id must be a uuid")
+	}
+
+	return validationErr.Err()
+}
+
+// RequeueEvent handles requeuing an event with its retry counter reset
+func (h *EventHandler) RequeueEvent(w http.ResponseWriter, r *http.Request) {
+	var req RequeueEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	req.EventID = r.PathValue("id")
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.eventService.RequeueEvent(r.Context(), applicationevent.RequeueEventDTO{
+		EventID: uuid.MustParse(req.EventID),
+		Actor:   req.Actor,
+		Reason:  req.Reason,
+	}); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// AbortEventRequest represents the request body for aborting an event, the actor is the operator recorded in the event audit
+type AbortEventRequest struct {
+	Actor   string `json:"actor"`
+	Reason  string `json:"reason"`
+	EventID string
+}
+
+func (r AbortEventRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.EventID); err != nil {
+		validationErr.Add("id", problem.CodeInvalidUUID, "event id must be a uuid")
+	}
+
+	return validationErr.Err()
+}
+
+// AbortEvent handles aborting an event with a reason
+func (h *EventHandler) AbortEvent(w http.ResponseWriter, r *http.Request) {
+	var req AbortEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	req.EventID = r.PathValue("id")
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if err := h.eventService.AbortEvent(r.Context(), applicationevent.AbortEventDTO{
+		EventID: uuid.MustParse(req.EventID),
+		Actor:   req.Actor,
+		Reason:  req.Reason,
+	}); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RequeueEventsRequest represents the request body for requeuing all the events in the state and of the origin
+type RequeueEventsRequest struct {
+	State  string `json:"state"`
+	Origin string `json:"origin"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+// RequeueEvents handles requeuing the events by filter
+func (h *EventHandler) RequeueEvents(w http.ResponseWriter, r *http.Request) {
+	var req RequeueEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	response, err := h.eventService.RequeueEvents(r.Context(), applicationevent.RequeueEventsDTO{
+		State:  req.State,
+		Origin: req.Origin,
+		Actor:  req.Actor,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response) // TODO decide about handling of this error.
+}
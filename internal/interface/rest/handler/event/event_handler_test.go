@@ -0,0 +1,313 @@
+//go:build unit
+
+package event
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	applicationevent "github.com/stefanowiczd/ddd-case-01/internal/application/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/event/mock"
+)
+
+func TestEventHandler_RequeueEvent(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		eventID          string
+		body             string
+		mockEventService func(*gomock.Controller) *mock.MockEventService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "successful event requeue",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{"actor":"jane.doe","reason":"account projection fixed"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().
+						RequeueEvent(gomock.Any(), applicationevent.RequeueEventDTO{
+							EventID: eventID,
+							Actor:   "jane.doe",
+							Reason:  "account projection fixed",
+						}).
+						Return(nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "invalid request body",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{ ... invalid body ... }`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					return mock.NewMockEventService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "missing actor",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().RequeueEvent(gomock.Any(), gomock.Any()).Return(applicationevent.ErrMissingActor)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "event not found",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{"actor":"jane.doe"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().RequeueEvent(gomock.Any(), gomock.Any()).Return(applicationevent.ErrEventNotFound)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "event state doesn't allow requeue",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{"actor":"jane.doe"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().RequeueEvent(gomock.Any(), gomock.Any()).Return(applicationevent.ErrEventStateConflict)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewEventHandler(tt.params.mockEventService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/events/"+tt.params.eventID+"/requeue", strings.NewReader(tt.params.body))
+			req.SetPathValue("id", tt.params.eventID)
+			w := httptest.NewRecorder()
+
+			handler.RequeueEvent(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
+
+func TestEventHandler_AbortEvent(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		eventID          string
+		body             string
+		mockEventService func(*gomock.Controller) *mock.MockEventService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "successful event abort",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{"actor":"jane.doe","reason":"duplicated withdrawal"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().
+						AbortEvent(gomock.Any(), applicationevent.AbortEventDTO{
+							EventID: eventID,
+							Actor:   "jane.doe",
+							Reason:  "duplicated withdrawal",
+						}).
+						Return(nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "invalid event id format in request path",
+			params: testCaseParams{
+				eventID: "ev123",
+				body:    `{"actor":"jane.doe","reason":"duplicated withdrawal"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					return mock.NewMockEventService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "missing reason",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{"actor":"jane.doe"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().AbortEvent(gomock.Any(), gomock.Any()).Return(applicationevent.ErrMissingReason)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "internal error while aborting event",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				body:    `{"actor":"jane.doe","reason":"duplicated withdrawal"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().AbortEvent(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewEventHandler(tt.params.mockEventService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/events/"+tt.params.eventID+"/abort", strings.NewReader(tt.params.body))
+			req.SetPathValue("id", tt.params.eventID)
+			w := httptest.NewRecorder()
+
+			handler.AbortEvent(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
+
+func TestEventHandler_RequeueEvents(t *testing.T) {
+	type testCaseParams struct {
+		body             string
+		mockEventService func(*gomock.Controller) *mock.MockEventService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "successful bulk requeue",
+			params: testCaseParams{
+				body: `{"state":"failed","origin":"account","actor":"jane.doe","reason":"database outage"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().
+						RequeueEvents(gomock.Any(), applicationevent.RequeueEventsDTO{
+							State:  "failed",
+							Origin: "account",
+							Actor:  "jane.doe",
+							Reason: "database outage",
+						}).
+						Return(applicationevent.RequeueEventsResponseDTO{Requeued: 7}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+				body:       `{"requeued":7}`,
+			},
+		},
+		{
+			name: "invalid event filter",
+			params: testCaseParams{
+				body: `{"state":"completed","actor":"jane.doe"}`,
+				mockEventService: func(m *gomock.Controller) *mock.MockEventService {
+					mock := mock.NewMockEventService(m)
+					mock.EXPECT().
+						RequeueEvents(gomock.Any(), gomock.Any()).
+						Return(applicationevent.RequeueEventsResponseDTO{}, applicationevent.ErrInvalidEventFilter)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewEventHandler(tt.params.mockEventService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/events/requeue", strings.NewReader(tt.params.body))
+			w := httptest.NewRecorder()
+
+			handler.RequeueEvents(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+			if tt.expected.body != "" {
+				require.JSONEq(t, tt.expected.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package event
+
+import (
+	"context"
+
+	applicationevent "github.com/stefanowiczd/ddd-case-01/internal/application/event"
+)
+
+//go:generate mockgen -destination=./mock/event_handler_mock.go -package=mock -source=./event_interface.go
+
+// EventQueryService defines the contract for event query service that handles queries/read operations of operators
+type EventQueryService interface {
+	// ListEvents lists the events in the state and of the origin
+	ListEvents(ctx context.Context, dto applicationevent.ListEventsDTO) (applicationevent.ListEventsResponseDTO, error)
+	// GetEvent returns the event with its payload and the actions taken by operators on it
+	GetEvent(ctx context.Context, dto applicationevent.GetEventDTO) (applicationevent.EventDetailsResponseDTO, error)
+}
+
+// EventService defines the contract for event service that handles commands/mutable operations of operators
+type EventService interface {
+	// RequeueEvent requeues the dead-lettered event with its retry counter reset
+	RequeueEvent(ctx context.Context, dto applicationevent.RequeueEventDTO) error
+	// RequeueEvents requeues the dead-lettered events in the state and of the origin
+	RequeueEvents(ctx context.Context, dto applicationevent.RequeueEventsDTO) (applicationevent.RequeueEventsResponseDTO, error)
+	// AbortEvent aborts the event, so it is never processed
+	AbortEvent(ctx context.Context, dto applicationevent.AbortEventDTO) error
+}
@@ -0,0 +1,121 @@
+package event
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	applicationevent "github.com/stefanowiczd/ddd-case-01/internal/application/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// EventQueryHandler handles HTTP requests of operators inspecting the events
+type EventQueryHandler struct {
+	eventQueryService EventQueryService
+}
+
+// NewEventQueryHandler creates a new event query handler
+func NewEventQueryHandler(eventQueryService EventQueryService) *EventQueryHandler {
+	return &EventQueryHandler{
+		eventQueryService: eventQueryService,
+	}
+}
+
+// ListEventsRequest represents the query parameters of the listed events
+type ListEventsRequest struct {
+	State  string
+	Origin string
+	Limit  string
+	Offset string
+}
+
+func (r ListEventsRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if r.Limit != "" {
+		if _, err := strconv.Atoi(r.Limit); err != nil {
+			validationErr.Add("limit", problem.CodeInvalidValue, "limit must be an integer")
+		}
+	}
+
+	if r.Offset != "" {
+		if _, err := strconv.Atoi(r.Offset); err != nil {
+			validationErr.Add("offset", problem.CodeInvalidValue, "offset must be an integer")
+		}
+	}
+
+	return validationErr.Err()
+}
+
+// ListEvents handles listing the events filtered by state and origin, i.e. /admin/events?state=failed&origin=account&limit=50
+func (h *EventQueryHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := ListEventsRequest{
+		State:  query.Get("state"),
+		Origin: query.Get("origin"),
+		Limit:  query.Get("limit"),
+		Offset: query.Get("offset"),
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// The limit and offset were validated, an empty one is zero
+	limit, _ := strconv.Atoi(req.Limit)
+	offset, _ := strconv.Atoi(req.Offset)
+
+	events, err := h.eventQueryService.ListEvents(r.Context(), applicationevent.ListEventsDTO{
+		State:  req.State,
+		Origin: req.Origin,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(events) // TODO decide about handling of this error.
+}
+
+// GetEventRequest represents the request of an event inspection
+type GetEventRequest struct {
+	EventID string
+}
+
+func (r GetEventRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.EventID); err != nil {
+		validationErr.Add("id", problem.CodeInvalidUUID, "event id must be a uuid")
+	}
+
+	return validationErr.Err()
+}
+
+// GetEvent handles retrieving an event with its payload and audit by ID
+func (h *EventQueryHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	req := GetEventRequest{
+		EventID: r.PathValue("id"),
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	event, err := h.eventQueryService.GetEvent(r.Context(), applicationevent.GetEventDTO{
+		EventID: uuid.MustParse(req.EventID),
+	})
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(event) // TODO decide about handling of this error.
+}
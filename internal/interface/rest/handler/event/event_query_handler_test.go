@@ -0,0 +1,192 @@
+//go:build unit
+
+package event
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	applicationevent "github.com/stefanowiczd/ddd-case-01/internal/application/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/event/mock"
+)
+
+func TestEventQueryHandler_ListEvents(t *testing.T) {
+	type testCaseParams struct {
+		query                 string
+		mockEventQueryService func(*gomock.Controller) *mock.MockEventQueryService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "successful listing of failed account events",
+			params: testCaseParams{
+				query: "?state=failed&origin=account&limit=10&offset=20",
+				mockEventQueryService: func(m *gomock.Controller) *mock.MockEventQueryService {
+					mock := mock.NewMockEventQueryService(m)
+					mock.EXPECT().
+						ListEvents(gomock.Any(), applicationevent.ListEventsDTO{State: "failed", Origin: "account", Limit: 10, Offset: 20}).
+						Return(applicationevent.ListEventsResponseDTO{Limit: 10, Offset: 20}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "invalid limit format in request query",
+			params: testCaseParams{
+				query: "?limit=ten",
+				mockEventQueryService: func(m *gomock.Controller) *mock.MockEventQueryService {
+					return mock.NewMockEventQueryService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "invalid event filter",
+			params: testCaseParams{
+				query: "?state=stuck",
+				mockEventQueryService: func(m *gomock.Controller) *mock.MockEventQueryService {
+					mock := mock.NewMockEventQueryService(m)
+					mock.EXPECT().
+						ListEvents(gomock.Any(), gomock.Any()).
+						Return(applicationevent.ListEventsResponseDTO{}, applicationevent.ErrInvalidEventFilter)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewEventQueryHandler(tt.params.mockEventQueryService(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/events"+tt.params.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListEvents(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
+
+func TestEventQueryHandler_GetEvent(t *testing.T) {
+	eventID := uuid.New()
+
+	type testCaseParams struct {
+		eventID               string
+		mockEventQueryService func(*gomock.Controller) *mock.MockEventQueryService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "successful event inspection",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				mockEventQueryService: func(m *gomock.Controller) *mock.MockEventQueryService {
+					mock := mock.NewMockEventQueryService(m)
+					mock.EXPECT().
+						GetEvent(gomock.Any(), applicationevent.GetEventDTO{EventID: eventID}).
+						Return(applicationevent.EventDetailsResponseDTO{}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "invalid event id format in request path",
+			params: testCaseParams{
+				eventID: "ev123",
+				mockEventQueryService: func(m *gomock.Controller) *mock.MockEventQueryService {
+					return mock.NewMockEventQueryService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "event not found",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				mockEventQueryService: func(m *gomock.Controller) *mock.MockEventQueryService {
+					mock := mock.NewMockEventQueryService(m)
+					mock.EXPECT().
+						GetEvent(gomock.Any(), gomock.Any()).
+						Return(applicationevent.EventDetailsResponseDTO{}, applicationevent.ErrEventNotFound)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "internal error while inspecting event",
+			params: testCaseParams{
+				eventID: eventID.String(),
+				mockEventQueryService: func(m *gomock.Controller) *mock.MockEventQueryService {
+					mock := mock.NewMockEventQueryService(m)
+					mock.EXPECT().
+						GetEvent(gomock.Any(), gomock.Any()).
+						Return(applicationevent.EventDetailsResponseDTO{}, errors.New("error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewEventQueryHandler(tt.params.mockEventQueryService(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/events/"+tt.params.eventID, nil)
+			req.SetPathValue("id", tt.params.eventID)
+			w := httptest.NewRecorder()
+
+			handler.GetEvent(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
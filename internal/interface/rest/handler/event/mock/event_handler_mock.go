@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./event_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/event_handler_mock.go -package=mock -source=./event_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	event "github.com/stefanowiczd/ddd-case-01/internal/application/event"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventQueryService is a mock of EventQueryService interface.
+type MockEventQueryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventQueryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEventQueryServiceMockRecorder is the mock recorder for MockEventQueryService.
+type MockEventQueryServiceMockRecorder struct {
+	mock *MockEventQueryService
+}
+
+// NewMockEventQueryService creates a new mock instance.
+func NewMockEventQueryService(ctrl *gomock.Controller) *MockEventQueryService {
+	mock := &MockEventQueryService{ctrl: ctrl}
+	mock.recorder = &MockEventQueryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventQueryService) EXPECT() *MockEventQueryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetEvent mocks base method.
+func (m *MockEventQueryService) GetEvent(ctx context.Context, dto event.GetEventDTO) (event.EventDetailsResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", ctx, dto)
+	ret0, _ := ret[0].(event.EventDetailsResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockEventQueryServiceMockRecorder) GetEvent(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockEventQueryService)(nil).GetEvent), ctx, dto)
+}
+
+// ListEvents mocks base method.
+func (m *MockEventQueryService) ListEvents(ctx context.Context, dto event.ListEventsDTO) (event.ListEventsResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, dto)
+	ret0, _ := ret[0].(event.ListEventsResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockEventQueryServiceMockRecorder) ListEvents(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockEventQueryService)(nil).ListEvents), ctx, dto)
+}
+
+// MockEventService is a mock of EventService interface.
+type MockEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEventServiceMockRecorder is the mock recorder for MockEventService.
+type MockEventServiceMockRecorder struct {
+	mock *MockEventService
+}
+
+// NewMockEventService creates a new mock instance.
+func NewMockEventService(ctrl *gomock.Controller) *MockEventService {
+	mock := &MockEventService{ctrl: ctrl}
+	mock.recorder = &MockEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventService) EXPECT() *MockEventServiceMockRecorder {
+	return m.recorder
+}
+
+// AbortEvent mocks base method.
+func (m *MockEventService) AbortEvent(ctx context.Context, dto event.AbortEventDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortEvent", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortEvent indicates an expected call of AbortEvent.
+func (mr *MockEventServiceMockRecorder) AbortEvent(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortEvent", reflect.TypeOf((*MockEventService)(nil).AbortEvent), ctx, dto)
+}
+
+// RequeueEvent mocks base method.
+func (m *MockEventService) RequeueEvent(ctx context.Context, dto event.RequeueEventDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEvent", ctx, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueEvent indicates an expected call of RequeueEvent.
+func (mr *MockEventServiceMockRecorder) RequeueEvent(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEvent", reflect.TypeOf((*MockEventService)(nil).RequeueEvent), ctx, dto)
+}
+
+// RequeueEvents mocks base method.
+func (m *MockEventService) RequeueEvents(ctx context.Context, dto event.RequeueEventsDTO) (event.RequeueEventsResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEvents", ctx, dto)
+	ret0, _ := ret[0].(event.RequeueEventsResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueEvents indicates an expected call of RequeueEvents.
+func (mr *MockEventServiceMockRecorder) RequeueEvents(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEvents", reflect.TypeOf((*MockEventService)(nil).RequeueEvents), ctx, dto)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./transaction_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mock/transaction_handler_mock.go -package=mock -source=./transaction_interface.go
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	transaction "github.com/stefanowiczd/ddd-case-01/internal/application/transaction"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransferQueryService is a mock of TransferQueryService interface.
+type MockTransferQueryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferQueryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransferQueryServiceMockRecorder is the mock recorder for MockTransferQueryService.
+type MockTransferQueryServiceMockRecorder struct {
+	mock *MockTransferQueryService
+}
+
+// NewMockTransferQueryService creates a new mock instance.
+func NewMockTransferQueryService(ctrl *gomock.Controller) *MockTransferQueryService {
+	mock := &MockTransferQueryService{ctrl: ctrl}
+	mock.recorder = &MockTransferQueryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferQueryService) EXPECT() *MockTransferQueryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTransfer mocks base method.
+func (m *MockTransferQueryService) GetTransfer(ctx context.Context, dto transaction.GetTransferDTO) (transaction.TransactionResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, dto)
+	ret0, _ := ret[0].(transaction.TransactionResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockTransferQueryServiceMockRecorder) GetTransfer(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockTransferQueryService)(nil).GetTransfer), ctx, dto)
+}
+
+// MockTransferService is a mock of TransferService interface.
+type MockTransferService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransferServiceMockRecorder is the mock recorder for MockTransferService.
+type MockTransferServiceMockRecorder struct {
+	mock *MockTransferService
+}
+
+// NewMockTransferService creates a new mock instance.
+func NewMockTransferService(ctrl *gomock.Controller) *MockTransferService {
+	mock := &MockTransferService{ctrl: ctrl}
+	mock.recorder = &MockTransferServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferService) EXPECT() *MockTransferServiceMockRecorder {
+	return m.recorder
+}
+
+// Transfer mocks base method.
+func (m *MockTransferService) Transfer(ctx context.Context, dto transaction.TransferDTO) (transaction.TransferResponseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, dto)
+	ret0, _ := ret[0].(transaction.TransferResponseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockTransferServiceMockRecorder) Transfer(ctx, dto any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockTransferService)(nil).Transfer), ctx, dto)
+}
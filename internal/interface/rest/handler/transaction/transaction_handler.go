@@ -1 +1,74 @@
 package transaction
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	applicationtransaction "github.com/stefanowiczd/ddd-case-01/internal/application/transaction"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// TransferHandler handles HTTP requests for transfer operations
+type TransferHandler struct {
+	transferService TransferService
+}
+
+// NewTransferHandler creates a new transfer handler
+func NewTransferHandler(transferService TransferService) *TransferHandler {
+	return &TransferHandler{
+		transferService: transferService,
+	}
+}
+
+// TransferRequest represents the request body for transferring money between two accounts.
+// The amount is a decimal string in the accounts currency, i.e. "10.50".
+type TransferRequest struct {
+	SourceAccountID string `json:"sourceAccountId"`
+	TargetAccountID string `json:"targetAccountId"`
+	Amount          string `json:"amount"`
+}
+
+func (r TransferRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.SourceAccountID); err != nil {
+		validationErr.Add("sourceAccountId", problem.CodeInvalidUUID, "source account id must be a uuid")
+	}
+
+	if _, err := uuid.Parse(r.TargetAccountID); err != nil {
+		validationErr.Add("targetAccountId", problem.CodeInvalidUUID, "target account id must be a uuid")
+	}
+
+	return validationErr.Err()
+}
+
+// CreateTransfer handles initiating a money transfer.
+// The transfer is processed asynchronously, the response points to the transfer status resource.
+func (h *TransferHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var req TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, r, problem.ErrInvalidBody)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	transfer, err := h.transferService.Transfer(r.Context(), applicationtransaction.TransferDTO{
+		SourceAccountID: uuid.MustParse(req.SourceAccountID),
+		TargetAccountID: uuid.MustParse(req.TargetAccountID),
+		Amount:          req.Amount,
+	})
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/transfers/"+transfer.ID)
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(transfer) // TODO decide about handling of this error.
+}
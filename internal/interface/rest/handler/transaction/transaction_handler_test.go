@@ -1,3 +1,189 @@
 //go:build unit
 
 package transaction
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/application/transaction"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/transaction/mock"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+func TestTransferHandler_CreateTransfer(t *testing.T) {
+	validRequest := TransferRequest{
+		SourceAccountID: "00000000-0000-0000-0000-000000000001",
+		TargetAccountID: "00000000-0000-0000-0000-000000000002",
+		Amount:          "100.00",
+	}
+
+	type testCaseParams struct {
+		req                 TransferRequest
+		reqBody             func(r TransferRequest) io.Reader
+		mockTransferService func(*gomock.Controller) *mock.MockTransferService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+		location   string
+		code       string
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	jsonBody := func(r TransferRequest) io.Reader {
+		body, _ := json.Marshal(r)
+		return bytes.NewBuffer(body)
+	}
+
+	tests := []testCase{
+		{
+			name: "invalid request body",
+			params: testCaseParams{
+				req: validRequest,
+				reqBody: func(_ TransferRequest) io.Reader {
+					return bytes.NewBuffer([]byte(`{ ... invalid json ... `))
+				},
+				mockTransferService: func(m *gomock.Controller) *mock.MockTransferService {
+					return mock.NewMockTransferService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				code:       "invalid_body",
+			},
+		},
+		{
+			name: "invalid source account id in request body",
+			params: testCaseParams{
+				req: TransferRequest{
+					SourceAccountID: "account123",
+					TargetAccountID: validRequest.TargetAccountID,
+					Amount:          "100.00",
+				},
+				reqBody: jsonBody,
+				mockTransferService: func(m *gomock.Controller) *mock.MockTransferService {
+					return mock.NewMockTransferService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				code:       "validation_failed",
+			},
+		},
+		{
+			name: "invalid transfer amount",
+			params: testCaseParams{
+				req:     validRequest,
+				reqBody: jsonBody,
+				mockTransferService: func(m *gomock.Controller) *mock.MockTransferService {
+					mock := mock.NewMockTransferService(m)
+					mock.EXPECT().
+						Transfer(gomock.Any(), gomock.Any()).
+						Return(transaction.TransferResponseDTO{}, transaction.ErrInvalidTransferAmount)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				code:       "invalid_amount",
+			},
+		},
+		{
+			name: "target account not found",
+			params: testCaseParams{
+				req:     validRequest,
+				reqBody: jsonBody,
+				mockTransferService: func(m *gomock.Controller) *mock.MockTransferService {
+					mock := mock.NewMockTransferService(m)
+					mock.EXPECT().
+						Transfer(gomock.Any(), gomock.Any()).
+						Return(transaction.TransferResponseDTO{}, transaction.ErrTargetAccountNotFound)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNotFound,
+				code:       "target_account_not_found",
+			},
+		},
+		{
+			name: "unsuccessful transfer",
+			params: testCaseParams{
+				req:     validRequest,
+				reqBody: jsonBody,
+				mockTransferService: func(m *gomock.Controller) *mock.MockTransferService {
+					mock := mock.NewMockTransferService(m)
+					mock.EXPECT().
+						Transfer(gomock.Any(), gomock.Any()).
+						Return(transaction.TransferResponseDTO{}, errors.New("error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+				code:       "internal_error",
+			},
+		},
+		{
+			name: "successful transfer",
+			params: testCaseParams{
+				req:     validRequest,
+				reqBody: jsonBody,
+				mockTransferService: func(m *gomock.Controller) *mock.MockTransferService {
+					mock := mock.NewMockTransferService(m)
+					mock.EXPECT().
+						Transfer(gomock.Any(), gomock.Any()).
+						Return(transaction.TransferResponseDTO{
+							TransactionResponseDTO: transaction.TransactionResponseDTO{
+								ID:     "00000000-0000-0000-0000-000000000003",
+								Status: "initiated",
+							},
+						}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusAccepted,
+				location:   "/transfers/00000000-0000-0000-0000-000000000003",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewTransferHandler(tt.params.mockTransferService(ctrl))
+
+			req := httptest.NewRequest(http.MethodPost, "/transfers", tt.params.reqBody(tt.params.req))
+			w := httptest.NewRecorder()
+
+			handler.CreateTransfer(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+			require.Equal(t, tt.expected.location, w.Header().Get("Location"))
+			if tt.expected.code != "" {
+				require.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+				var p problem.Problem
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+				require.Equal(t, tt.expected.code, p.Code)
+			}
+		})
+	}
+}
@@ -1 +1,21 @@
 package transaction
+
+import (
+	"context"
+
+	applicationtransaction "github.com/stefanowiczd/ddd-case-01/internal/application/transaction"
+)
+
+//go:generate mockgen -destination=./mock/transaction_handler_mock.go -package=mock -source=./transaction_interface.go
+
+// TransferQueryService defines the contract for transfer query service that handles queries/read operations
+type TransferQueryService interface {
+	// GetTransfer retrieves a transaction by its ID
+	GetTransfer(ctx context.Context, dto applicationtransaction.GetTransferDTO) (applicationtransaction.TransactionResponseDTO, error)
+}
+
+// TransferService defines the contract for transfer service that handles commands/mutable operations
+type TransferService interface {
+	// Transfer initiates a money transfer between two accounts
+	Transfer(ctx context.Context, dto applicationtransaction.TransferDTO) (applicationtransaction.TransferResponseDTO, error)
+}
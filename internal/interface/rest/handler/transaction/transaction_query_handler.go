@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	applicationtransaction "github.com/stefanowiczd/ddd-case-01/internal/application/transaction"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// TransferQueryHandler handles HTTP requests for transfer query operations
+type TransferQueryHandler struct {
+	transferQueryService TransferQueryService
+}
+
+// NewTransferQueryHandler creates a new transfer query handler
+func NewTransferQueryHandler(transferQueryService TransferQueryService) *TransferQueryHandler {
+	return &TransferQueryHandler{
+		transferQueryService: transferQueryService,
+	}
+}
+
+type GetTransferRequest struct {
+	TransactionID string
+}
+
+func (r GetTransferRequest) Validate() error {
+	var validationErr problem.ValidationError
+
+	if _, err := uuid.Parse(r.TransactionID); err != nil {
+		validationErr.Add("id", problem.CodeInvalidUUID, "transaction id must be a uuid")
+	}
+
+	return validationErr.Err()
+}
+
+// GetTransfer handles retrieving a transfer and its saga status by ID
+func (h *TransferQueryHandler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	req := &GetTransferRequest{
+		TransactionID: r.PathValue("id"),
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	transfer, err := h.transferQueryService.GetTransfer(r.Context(), applicationtransaction.GetTransferDTO{
+		TransactionID: uuid.MustParse(req.TransactionID),
+	})
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(transfer) // TODO decide about handling of this error.
+}
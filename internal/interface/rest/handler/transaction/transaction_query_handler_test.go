@@ -0,0 +1,122 @@
+//go:build unit
+
+package transaction
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/application/transaction"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/transaction/mock"
+)
+
+func TestTransferQueryHandler_GetTransfer(t *testing.T) {
+	type testCaseParams struct {
+		req                      GetTransferRequest
+		mockTransferQueryService func(*gomock.Controller) *mock.MockTransferQueryService
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	tests := []testCase{
+		{
+			name: "invalid transaction id format in request path",
+			params: testCaseParams{
+				req: GetTransferRequest{
+					TransactionID: "0000",
+				},
+				mockTransferQueryService: func(m *gomock.Controller) *mock.MockTransferQueryService {
+					return mock.NewMockTransferQueryService(m)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "transaction not found",
+			params: testCaseParams{
+				req: GetTransferRequest{
+					TransactionID: "00000000-0000-0000-0000-000000000000",
+				},
+				mockTransferQueryService: func(m *gomock.Controller) *mock.MockTransferQueryService {
+					mock := mock.NewMockTransferQueryService(m)
+					mock.EXPECT().
+						GetTransfer(gomock.Any(), gomock.Any()).
+						Return(transaction.TransactionResponseDTO{}, transaction.ErrTransactionNotFound)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "unsuccessful transaction retrieval",
+			params: testCaseParams{
+				req: GetTransferRequest{
+					TransactionID: "00000000-0000-0000-0000-000000000000",
+				},
+				mockTransferQueryService: func(m *gomock.Controller) *mock.MockTransferQueryService {
+					mock := mock.NewMockTransferQueryService(m)
+					mock.EXPECT().
+						GetTransfer(gomock.Any(), gomock.Any()).
+						Return(transaction.TransactionResponseDTO{}, errors.New("error"))
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "success transaction retrieval",
+			params: testCaseParams{
+				req: GetTransferRequest{
+					TransactionID: "00000000-0000-0000-0000-000000000000",
+				},
+				mockTransferQueryService: func(m *gomock.Controller) *mock.MockTransferQueryService {
+					mock := mock.NewMockTransferQueryService(m)
+					mock.EXPECT().
+						GetTransfer(gomock.Any(), gomock.Any()).
+						Return(transaction.TransactionResponseDTO{}, nil)
+					return mock
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := NewTransferQueryHandler(tt.params.mockTransferQueryService(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/transfers/{id}", nil)
+			req.SetPathValue("id", tt.params.req.TransactionID)
+
+			w := httptest.NewRecorder()
+
+			handler.GetTransfer(w, req)
+
+			require.Equal(t, tt.expected.statusCode, w.Code)
+		})
+	}
+}
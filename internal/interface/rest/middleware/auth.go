@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// Authenticate middleware authenticates the request with the first authenticator its credentials are meant for,
+// i.e. the bearer token or the API key, and puts the principal into the request context. The principal is the actor
+// of the events recorded by the request. The request with the invalid credentials is rejected with 401 Unauthorized,
+// the request without any credentials is passed through anonymously, the routes requiring the principal reject it.
+func Authenticate(authenticators ...Authenticator) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, authenticator := range authenticators {
+				principal, err := authenticator.Authenticate(r)
+				if errors.Is(err, identitydomain.ErrUnauthenticated) {
+					continue
+				}
+				if err != nil {
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					problem.Write(w, r, err)
+					return
+				}
+
+				md := eventdomain.MetadataFromContext(r.Context())
+				md.ActorID = principal.ID
+				md.ActorType = eventdomain.ActorTypeUser
+				if principal.Type == identitydomain.PrincipalTypeService {
+					md.ActorType = eventdomain.ActorTypeService
+				}
+
+				ctx := identitydomain.ContextWithPrincipal(r.Context(), principal)
+				ctx = eventdomain.ContextWithMetadata(ctx, md)
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Authorize middleware allows the route to the principals acting in any of the roles. The anonymous request
+// is rejected with 401 Unauthorized, the principal acting in none of the roles with 403 Forbidden.
+func Authorize(roles ...identitydomain.Role) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := identitydomain.PrincipalFromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				problem.Write(w, r, identitydomain.ErrUnauthenticated)
+				return
+			}
+
+			if !principal.HasAnyRole(roles...) {
+				problem.Write(w, r, identitydomain.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
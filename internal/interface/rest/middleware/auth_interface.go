@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+//go:generate mockgen -destination=./mock/auth_mock.go -package=mock -source=./auth_interface.go
+
+// Authenticator defines the interface for authenticating the request with the credentials it carries, i.e. the bearer token
+type Authenticator interface {
+	// Authenticate returns the principal the credentials of the request were issued to. It returns identitydomain.ErrUnauthenticated
+	// when the request doesn't carry the credentials of the authenticator, identitydomain.ErrInvalidCredentials when they aren't valid
+	Authenticate(r *http.Request) (identitydomain.Principal, error)
+}
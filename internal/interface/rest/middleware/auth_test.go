@@ -0,0 +1,225 @@
+//go:build unit
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware/mock"
+)
+
+func TestAuthenticate(t *testing.T) {
+	correlationID := uuid.New()
+
+	teller := identitydomain.Principal{ID: "teller-1", Type: identitydomain.PrincipalTypeUser, Roles: []identitydomain.Role{identitydomain.RoleTeller}}
+	service := identitydomain.Principal{ID: "payments", Type: identitydomain.PrincipalTypeService, Roles: []identitydomain.Role{identitydomain.RoleTeller}}
+
+	type testCaseParams struct {
+		mockAuthenticators func(*gomock.Controller) []Authenticator
+	}
+
+	type testCaseExpected struct {
+		statusCode    int
+		principal     *identitydomain.Principal
+		actorID       string
+		actorType     string
+		authenticated bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should authenticate request with first authenticator its credentials are meant for",
+			params: testCaseParams{
+				mockAuthenticators: func(ctrl *gomock.Controller) []Authenticator {
+					jwt := mock.NewMockAuthenticator(ctrl)
+					jwt.EXPECT().Authenticate(gomock.Any()).Return(identitydomain.Principal{}, identitydomain.ErrUnauthenticated)
+
+					apiKeys := mock.NewMockAuthenticator(ctrl)
+					apiKeys.EXPECT().Authenticate(gomock.Any()).Return(service, nil)
+
+					return []Authenticator{jwt, apiKeys}
+				},
+			},
+			expected: testCaseExpected{
+				statusCode:    http.StatusOK,
+				principal:     &service,
+				actorID:       "payments",
+				actorType:     eventdomain.ActorTypeService,
+				authenticated: true,
+			},
+		},
+		{
+			name: "should authenticate user",
+			params: testCaseParams{
+				mockAuthenticators: func(ctrl *gomock.Controller) []Authenticator {
+					jwt := mock.NewMockAuthenticator(ctrl)
+					jwt.EXPECT().Authenticate(gomock.Any()).Return(teller, nil)
+
+					// The request is authenticated by the first authenticator, the next one isn't called
+					apiKeys := mock.NewMockAuthenticator(ctrl)
+
+					return []Authenticator{jwt, apiKeys}
+				},
+			},
+			expected: testCaseExpected{
+				statusCode:    http.StatusOK,
+				principal:     &teller,
+				actorID:       "teller-1",
+				actorType:     eventdomain.ActorTypeUser,
+				authenticated: true,
+			},
+		},
+		{
+			name: "should pass through request without credentials anonymously",
+			params: testCaseParams{
+				mockAuthenticators: func(ctrl *gomock.Controller) []Authenticator {
+					jwt := mock.NewMockAuthenticator(ctrl)
+					jwt.EXPECT().Authenticate(gomock.Any()).Return(identitydomain.Principal{}, identitydomain.ErrUnauthenticated)
+
+					return []Authenticator{jwt}
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+				actorType:  eventdomain.ActorTypeAnonymous,
+			},
+		},
+		{
+			name: "should reject request with invalid credentials",
+			params: testCaseParams{
+				mockAuthenticators: func(ctrl *gomock.Controller) []Authenticator {
+					jwt := mock.NewMockAuthenticator(ctrl)
+					jwt.EXPECT().Authenticate(gomock.Any()).Return(identitydomain.Principal{}, fmt.Errorf("token expired: %w", identitydomain.ErrInvalidCredentials))
+
+					return []Authenticator{jwt}
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			called := false
+			handler := Authenticate(tt.params.mockAuthenticators(ctrl)...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+
+				principal, ok := identitydomain.PrincipalFromContext(r.Context())
+				require.Equal(t, tt.expected.authenticated, ok)
+				if tt.expected.principal != nil {
+					require.Equal(t, *tt.expected.principal, principal)
+				}
+
+				md := eventdomain.MetadataFromContext(r.Context())
+				require.Equal(t, correlationID, md.CorrelationID)
+				require.Equal(t, tt.expected.actorID, md.ActorID)
+				require.Equal(t, tt.expected.actorType, md.ActorType)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+			req = req.WithContext(eventdomain.ContextWithMetadata(req.Context(), eventdomain.Metadata{
+				CorrelationID: correlationID,
+				ActorType:     eventdomain.ActorTypeAnonymous,
+			}))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expected.statusCode, rr.Code)
+			require.Equal(t, tt.expected.statusCode == http.StatusOK, called)
+			if tt.expected.statusCode == http.StatusUnauthorized {
+				require.Equal(t, `Bearer error="invalid_token"`, rr.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	type testCaseParams struct {
+		principal *identitydomain.Principal
+	}
+
+	type testCaseExpected struct {
+		statusCode      int
+		wwwAuthenticate string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should allow principal acting in allowed role",
+			params: testCaseParams{
+				principal: &identitydomain.Principal{ID: "auditor-1", Roles: []identitydomain.Role{identitydomain.RoleCustomer, identitydomain.RoleAuditor}},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "should forbid principal acting in none of allowed roles",
+			params: testCaseParams{
+				principal: &identitydomain.Principal{ID: "customer-1", Roles: []identitydomain.Role{identitydomain.RoleCustomer}},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name: "should forbid principal without roles",
+			params: testCaseParams{
+				principal: &identitydomain.Principal{ID: "service-1"},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name:   "should reject anonymous request",
+			params: testCaseParams{},
+			expected: testCaseExpected{
+				statusCode:      http.StatusUnauthorized,
+				wwwAuthenticate: "Bearer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Authorize(identitydomain.RoleBackOffice, identitydomain.RoleAuditor)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/events", nil)
+			if tt.params.principal != nil {
+				req = req.WithContext(identitydomain.ContextWithPrincipal(req.Context(), *tt.params.principal))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expected.statusCode, rr.Code)
+			require.Equal(t, tt.expected.wwwAuthenticate, rr.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
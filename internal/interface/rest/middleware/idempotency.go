@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	idempotencydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/idempotency"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the idempotency key chosen by the client, i.e. a UUID
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is the response header set on the stored response replayed to the retried request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength is the maximum length of the idempotency key, as stored in the idempotency_keys table
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyConfig holds the configuration of the idempotency middleware
+type IdempotencyConfig struct {
+	TTL         time.Duration // How long the response is replayed, the key can be reused for another request after that time
+	LockTimeout time.Duration // The request in progress longer than that is considered abandoned, its key can be claimed again
+	MaxBodySize int64         // Maximum size of the request body, the body is read to fingerprint the request
+}
+
+// DefaultIdempotencyConfig returns the default idempotency middleware configuration
+func DefaultIdempotencyConfig() IdempotencyConfig {
+	return IdempotencyConfig{
+		TTL:         24 * time.Hour,
+		LockTimeout: time.Minute,
+		MaxBodySize: 1 << 20,
+	}
+}
+
+// Idempotency middleware makes the mutating requests sent with the Idempotency-Key header safe to retry. The first request
+// with the key is executed and its response is stored, the request retried with the same key gets the stored response
+// with the Idempotent-Replayed header instead of being executed again. The key reused for another request, i.e. with another
+// body, is rejected with 422 Unprocessable Entity, the key of the request still in progress with 409 Conflict.
+// The retryable response isn't stored, i.e. with a 5xx status code or 409 Conflict of the concurrently changed account,
+// so the failed request can be retried with the same key and is executed again.
+// The key is put into the metadata of the events recorded by the request. The requests without the key are passed through.
+func Idempotency(config IdempotencyConfig, store IdempotencyStore) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				problem.Write(w, r, problem.ErrInvalidIdempotencyKey)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, config.MaxBodySize))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					problem.Write(w, r, problem.ErrBodyTooLarge)
+					return
+				}
+
+				problem.Write(w, r, problem.ErrInvalidBody)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			requestFingerprint := fingerprint(r, body)
+			now := time.Now().UTC()
+			record, err := store.Claim(r.Context(), idempotencydomain.Record{
+				Key:         key,
+				Fingerprint: requestFingerprint,
+				CreatedAt:   now,
+				LockedUntil: now.Add(config.LockTimeout),
+				ExpiresAt:   now.Add(config.TTL),
+			})
+			if err != nil {
+				if !errors.Is(err, idempotencydomain.ErrKeyAlreadyClaimed) {
+					problem.Write(w, r, fmt.Errorf("claiming idempotency key: %w", err))
+					return
+				}
+
+				switch {
+				case record.Fingerprint != requestFingerprint:
+					problem.Write(w, r, problem.ErrIdempotencyKeyReused)
+				case record.InProgress():
+					problem.Write(w, r, problem.ErrIdempotencyKeyInProgress)
+				default:
+					replay(w, *record.Response)
+				}
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			md := eventdomain.MetadataFromContext(r.Context())
+			md.IdempotencyKey = key
+			ctx := eventdomain.ContextWithMetadata(r.Context(), md)
+
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+
+			// The outcome is stored even when the client went away, it's the client which is going to retry
+			storeCtx := context.WithoutCancel(r.Context())
+			if isRetryable(recorder.statusCode) {
+				if err := store.Release(storeCtx, key); err != nil {
+					log.Printf("releasing idempotency key: %v", err)
+				}
+				return
+			}
+
+			response := idempotencydomain.Response{
+				StatusCode: recorder.statusCode,
+				Header:     recorder.header,
+				Body:       recorder.body.Bytes(),
+			}
+			if err := store.SaveResponse(storeCtx, key, response); err != nil {
+				log.Printf("saving idempotency key response: %v", err)
+			}
+		})
+	}
+}
+
+// isRetryable reports whether the request which got the response with the status code may succeed when retried,
+// i.e. the server failure, the conflict with a concurrent request, the timeout or the exceeded rate limit
+func isRetryable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return true
+	default:
+		return statusCode >= http.StatusInternalServerError
+	}
+}
+
+// fingerprint returns the hex encoded SHA-256 of the request method, URI and body, the request retried with the same key has the same one.
+// The principal of the authenticated request is part of it, so the key reused by another principal doesn't replay the response.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	if principal, ok := identitydomain.PrincipalFromContext(r.Context()); ok {
+		_, _ = io.WriteString(h, principal.Type+" "+principal.ID+"\n")
+	}
+	_, _ = h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay writes the stored response to the retried request
+func replay(w http.ResponseWriter, response idempotencydomain.Response) {
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(response.StatusCode)
+	_, _ = w.Write(response.Body)
+}
+
+// responseRecorder writes the response through and keeps its status code, headers and body to be stored
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	header      http.Header
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+// WriteHeader writes the status code through and keeps it together with the headers set so far
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.wroteHeader {
+		return
+	}
+
+	r.wroteHeader = true
+	r.statusCode = statusCode
+	r.header = r.ResponseWriter.Header().Clone()
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write writes the body through and keeps it
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying response writer, it's used by http.ResponseController
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
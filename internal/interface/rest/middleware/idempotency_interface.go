@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+
+	idempotencydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/idempotency"
+)
+
+//go:generate mockgen -destination=./mock/idempotency_mock.go -package=mock -source=./idempotency_interface.go
+
+// IdempotencyStore defines the interface for the store of the idempotency keys and the responses to their requests
+type IdempotencyStore interface {
+	// Claim claims the idempotency key for the request in progress, the key held by another request is returned
+	// together with idempotencydomain.ErrKeyAlreadyClaimed
+	Claim(ctx context.Context, record idempotencydomain.Record) (idempotencydomain.Record, error)
+	// SaveResponse saves the response to the request which claimed the idempotency key
+	SaveResponse(ctx context.Context, key string, response idempotencydomain.Response) error
+	// Release releases the idempotency key claimed by the request which didn't complete, so it can be retried
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,337 @@
+//go:build unit
+
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	idempotencydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/idempotency"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware/mock"
+)
+
+func TestIdempotency(t *testing.T) {
+	const body = `{"amount":"10.00"}`
+
+	depositRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/accounts/1/deposit", strings.NewReader(body))
+	}
+	depositFingerprint := fingerprint(depositRequest(), []byte(body))
+
+	type testCaseParams struct {
+		key        string
+		handler    func(t *testing.T) http.Handler
+		mockStore  func(*gomock.Controller) *mock.MockIdempotencyStore
+		maxBodyLen int64
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+		body       string
+		replayed   bool
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	created := func(t *testing.T) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, body, string(received))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		})
+	}
+
+	notCalled := func(t *testing.T) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not be called")
+		})
+	}
+
+	tests := []testCase{
+		{
+			name: "should pass through request without idempotency key",
+			params: testCaseParams{
+				handler: created,
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					return mock.NewMockIdempotencyStore(ctrl)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusCreated,
+				body:       `{"id":"1"}`,
+			},
+		},
+		{
+			name: "should execute request and store response",
+			params: testCaseParams{
+				key: "key-1",
+				handler: func(t *testing.T) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, eventdomain.Metadata{IdempotencyKey: "key-1"}, eventdomain.MetadataFromContext(r.Context()))
+						created(t).ServeHTTP(w, r)
+					})
+				},
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					m := mock.NewMockIdempotencyStore(ctrl)
+					m.EXPECT().
+						Claim(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ any, record idempotencydomain.Record) (idempotencydomain.Record, error) {
+							require.Equal(t, "key-1", record.Key)
+							require.Equal(t, depositFingerprint, record.Fingerprint)
+							require.Equal(t, record.CreatedAt.Add(time.Minute), record.LockedUntil)
+							require.Equal(t, record.CreatedAt.Add(24*time.Hour), record.ExpiresAt)
+							return record, nil
+						})
+					m.EXPECT().
+						SaveResponse(gomock.Any(), "key-1", idempotencydomain.Response{
+							StatusCode: http.StatusCreated,
+							Header:     http.Header{"Content-Type": {"application/json"}},
+							Body:       []byte(`{"id":"1"}`),
+						}).
+						Return(nil)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusCreated,
+				body:       `{"id":"1"}`,
+			},
+		},
+		{
+			name: "should replay stored response",
+			params: testCaseParams{
+				key:     "key-1",
+				handler: notCalled,
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					m := mock.NewMockIdempotencyStore(ctrl)
+					m.EXPECT().
+						Claim(gomock.Any(), gomock.Any()).
+						Return(idempotencydomain.Record{
+							Key:         "key-1",
+							Fingerprint: depositFingerprint,
+							Response: &idempotencydomain.Response{
+								StatusCode: http.StatusCreated,
+								Header:     http.Header{"Content-Type": {"application/json"}},
+								Body:       []byte(`{"id":"1"}`),
+							},
+						}, idempotencydomain.ErrKeyAlreadyClaimed)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusCreated,
+				body:       `{"id":"1"}`,
+				replayed:   true,
+			},
+		},
+		{
+			name: "should reject key reused for another request",
+			params: testCaseParams{
+				key:     "key-1",
+				handler: notCalled,
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					m := mock.NewMockIdempotencyStore(ctrl)
+					m.EXPECT().
+						Claim(gomock.Any(), gomock.Any()).
+						Return(idempotencydomain.Record{
+							Key:         "key-1",
+							Fingerprint: "another",
+							Response:    &idempotencydomain.Response{StatusCode: http.StatusCreated},
+						}, idempotencydomain.ErrKeyAlreadyClaimed)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "should reject key of request in progress",
+			params: testCaseParams{
+				key:     "key-1",
+				handler: notCalled,
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					m := mock.NewMockIdempotencyStore(ctrl)
+					m.EXPECT().
+						Claim(gomock.Any(), gomock.Any()).
+						Return(idempotencydomain.Record{
+							Key:         "key-1",
+							Fingerprint: depositFingerprint,
+						}, idempotencydomain.ErrKeyAlreadyClaimed)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusConflict,
+			},
+		},
+		{
+			name: "should release key of failed request",
+			params: testCaseParams{
+				key: "key-1",
+				handler: func(t *testing.T) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						http.Error(w, "Internal server error", http.StatusInternalServerError)
+					})
+				},
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					m := mock.NewMockIdempotencyStore(ctrl)
+					m.EXPECT().
+						Claim(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ any, record idempotencydomain.Record) (idempotencydomain.Record, error) {
+							return record, nil
+						})
+					m.EXPECT().
+						Release(gomock.Any(), "key-1").
+						Return(nil)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "should release key of request conflicting with concurrent request",
+			params: testCaseParams{
+				key: "key-1",
+				handler: func(t *testing.T) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						http.Error(w, "Conflict", http.StatusConflict)
+					})
+				},
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					m := mock.NewMockIdempotencyStore(ctrl)
+					m.EXPECT().
+						Claim(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ any, record idempotencydomain.Record) (idempotencydomain.Record, error) {
+							return record, nil
+						})
+					m.EXPECT().
+						Release(gomock.Any(), "key-1").
+						Return(nil)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusConflict,
+			},
+		},
+		{
+			name: "should fail when key can't be claimed",
+			params: testCaseParams{
+				key:     "key-1",
+				handler: notCalled,
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					m := mock.NewMockIdempotencyStore(ctrl)
+					m.EXPECT().
+						Claim(gomock.Any(), gomock.Any()).
+						Return(idempotencydomain.Record{}, errors.New("connection refused"))
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "should reject too long key",
+			params: testCaseParams{
+				key:     strings.Repeat("k", maxIdempotencyKeyLength+1),
+				handler: notCalled,
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					return mock.NewMockIdempotencyStore(ctrl)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should reject too large body",
+			params: testCaseParams{
+				key:        "key-1",
+				handler:    notCalled,
+				maxBodyLen: 4,
+				mockStore: func(ctrl *gomock.Controller) *mock.MockIdempotencyStore {
+					return mock.NewMockIdempotencyStore(ctrl)
+				},
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusRequestEntityTooLarge,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := DefaultIdempotencyConfig()
+			if tt.params.maxBodyLen > 0 {
+				config.MaxBodySize = tt.params.maxBodyLen
+			}
+
+			handler := Idempotency(config, tt.params.mockStore(ctrl))(tt.params.handler(t))
+
+			req := depositRequest()
+			if tt.params.key != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.params.key)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expected.statusCode, rr.Code)
+			if tt.expected.body != "" {
+				require.Equal(t, tt.expected.body, rr.Body.String())
+			}
+
+			if tt.expected.replayed {
+				require.Equal(t, "true", rr.Header().Get(IdempotentReplayedHeader))
+			} else {
+				require.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	const body = `{"amount":"10.00"}`
+
+	request := func(principal *identitydomain.Principal) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/accounts/1/deposit", strings.NewReader(body))
+		if principal != nil {
+			req = req.WithContext(identitydomain.ContextWithPrincipal(req.Context(), *principal))
+		}
+
+		return req
+	}
+
+	teller := identitydomain.Principal{ID: "teller-1", Type: identitydomain.PrincipalTypeUser}
+	anotherTeller := identitydomain.Principal{ID: "teller-2", Type: identitydomain.PrincipalTypeUser}
+
+	// The request retried by the same principal has the same fingerprint, the one sent by another principal doesn't
+	require.Equal(t, fingerprint(request(&teller), []byte(body)), fingerprint(request(&teller), []byte(body)))
+	require.NotEqual(t, fingerprint(request(&teller), []byte(body)), fingerprint(request(&anotherTeller), []byte(body)))
+	require.NotEqual(t, fingerprint(request(&teller), []byte(body)), fingerprint(request(nil), []byte(body)))
+}
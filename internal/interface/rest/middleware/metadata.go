@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+const (
+	// CorrelationIDHeader is the header carrying the correlation ID of the request, the client may send the one it traces with
+	CorrelationIDHeader = "X-Correlation-ID"
+	// RequestIDHeader is the response header carrying the ID of the request, the causation ID of the events it recorded
+	RequestIDHeader = "X-Request-ID"
+)
+
+// RequestMetadata middleware puts the metadata of the request into its context, the events recorded by the request are stored with it.
+// The correlation ID sent by the client in the X-Correlation-ID header is kept, a new one is generated when it's missing or isn't a UUID.
+// Every request gets its own ID, which is the causation ID of the recorded events. Both are returned in the response headers.
+// The actor is anonymous until the request is authenticated.
+func RequestMetadata(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID, err := uuid.Parse(r.Header.Get(CorrelationIDHeader))
+		if err != nil || correlationID == uuid.Nil {
+			correlationID = uuid.New()
+		}
+
+		requestID := uuid.New()
+
+		w.Header().Set(CorrelationIDHeader, correlationID.String())
+		w.Header().Set(RequestIDHeader, requestID.String())
+
+		ctx := eventdomain.ContextWithMetadata(r.Context(), eventdomain.Metadata{
+			CorrelationID: correlationID,
+			CausationID:   requestID,
+			ActorType:     eventdomain.ActorTypeAnonymous,
+			SourceIP:      sourceIP(r),
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// sourceIP returns the IP address the request came from, the forwarding headers aren't trusted
+func sourceIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
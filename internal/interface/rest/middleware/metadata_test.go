@@ -0,0 +1,84 @@
+//go:build unit
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+func TestRequestMetadata(t *testing.T) {
+	correlationID := uuid.New()
+
+	type testCaseParams struct {
+		correlationID string
+		remoteAddr    string
+	}
+
+	type testCaseExpected struct {
+		keepsCorrelationID bool
+		sourceIP           string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name:     "should keep correlation id sent by client",
+			params:   testCaseParams{correlationID: correlationID.String(), remoteAddr: "192.0.2.1:51234"},
+			expected: testCaseExpected{keepsCorrelationID: true, sourceIP: "192.0.2.1"},
+		},
+		{
+			name:     "should generate correlation id when missing",
+			params:   testCaseParams{remoteAddr: "[2001:db8::1]:51234"},
+			expected: testCaseExpected{sourceIP: "2001:db8::1"},
+		},
+		{
+			name:     "should generate correlation id when invalid",
+			params:   testCaseParams{correlationID: "trace-1", remoteAddr: "192.0.2.1"},
+			expected: testCaseExpected{sourceIP: "192.0.2.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var md eventdomain.Metadata
+			handler := RequestMetadata(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				md = eventdomain.MetadataFromContext(r.Context())
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/accounts/1/deposit", nil)
+			req.RemoteAddr = tt.params.remoteAddr
+			if tt.params.correlationID != "" {
+				req.Header.Set(CorrelationIDHeader, tt.params.correlationID)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusNoContent, rr.Code)
+			require.NotEqual(t, uuid.Nil, md.CorrelationID)
+			require.NotEqual(t, uuid.Nil, md.CausationID)
+			require.Equal(t, md.CorrelationID.String(), rr.Header().Get(CorrelationIDHeader))
+			require.Equal(t, md.CausationID.String(), rr.Header().Get(RequestIDHeader))
+			require.Equal(t, eventdomain.ActorTypeAnonymous, md.ActorType)
+			require.Empty(t, md.ActorID)
+			require.Equal(t, tt.expected.sourceIP, md.SourceIP)
+
+			if tt.expected.keepsCorrelationID {
+				require.Equal(t, correlationID, md.CorrelationID)
+			} else {
+				require.NotEqual(t, correlationID, md.CorrelationID)
+			}
+		})
+	}
+}
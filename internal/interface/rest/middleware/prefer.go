@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// PreferHeader is the header of the client preferences (RFC 7240), i.e. Prefer: wait=10
+	PreferHeader = "Prefer"
+	// PreferenceAppliedHeader is the response header of the client preferences the server applied
+	PreferenceAppliedHeader = "Preference-Applied"
+)
+
+// PreferredWait returns how long the client prefers to wait for the request to be processed, the wait preference
+// of the Prefer header in seconds, i.e. Prefer: wait=10. It reports false when the client didn't send a valid wait preference.
+func PreferredWait(r *http.Request) (time.Duration, bool) {
+	for _, header := range r.Header.Values(PreferHeader) {
+		for _, preference := range strings.Split(header, ",") {
+			// The preference parameters, i.e. wait=10; foo, aren't defined for the wait preference
+			preference, _, _ = strings.Cut(preference, ";")
+
+			name, value, _ := strings.Cut(preference, "=")
+			if !strings.EqualFold(strings.TrimSpace(name), "wait") {
+				continue
+			}
+
+			seconds, err := strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"`))
+			if err != nil || seconds < 0 {
+				return 0, false
+			}
+
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferredWait(t *testing.T) {
+	type testCaseExpected struct {
+		wait      time.Duration
+		preferred bool
+	}
+
+	tests := []struct {
+		name     string
+		prefer   []string
+		expected testCaseExpected
+	}{
+		{
+			name:     "should find wait preference",
+			prefer:   []string{"wait=10"},
+			expected: testCaseExpected{wait: 10 * time.Second, preferred: true},
+		},
+		{
+			name:     "should find wait among other preferences",
+			prefer:   []string{"respond-async", "return=minimal, Wait = \"5\"; foo"},
+			expected: testCaseExpected{wait: 5 * time.Second, preferred: true},
+		},
+		{
+			name:     "should find zero wait",
+			prefer:   []string{"wait=0"},
+			expected: testCaseExpected{preferred: true},
+		},
+		{
+			name:     "shouldn't find wait when missing",
+			prefer:   []string{"return=minimal"},
+			expected: testCaseExpected{},
+		},
+		{
+			name:     "shouldn't find negative wait",
+			prefer:   []string{"wait=-1"},
+			expected: testCaseExpected{},
+		},
+		{
+			name:     "shouldn't find wait which isn't number of seconds",
+			prefer:   []string{"wait=10s"},
+			expected: testCaseExpected{},
+		},
+		{
+			name:     "shouldn't find wait without prefer header",
+			expected: testCaseExpected{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/accounts/1/deposit", nil)
+			for _, prefer := range tt.prefer {
+				req.Header.Add(PreferHeader, prefer)
+			}
+
+			wait, preferred := PreferredWait(req)
+
+			require.Equal(t, tt.expected.wait, wait)
+			require.Equal(t, tt.expected.preferred, preferred)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/openapi"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// ValidationConfig holds the configuration of the validation middleware
+type ValidationConfig struct {
+	MaxBodySize       int64 // Maximum size of the request body, the body is read to be validated
+	ValidateResponses bool  // The responses not matching the OpenAPI document are logged, the response is kept as it is
+}
+
+// DefaultValidationConfig returns the default validation middleware configuration
+func DefaultValidationConfig() ValidationConfig {
+	return ValidationConfig{
+		MaxBodySize:       1 << 20,
+		ValidateResponses: true,
+	}
+}
+
+// Validation middleware validates the requests against the OpenAPI document: the path parameters, the query parameters
+// and the JSON body of the request are checked with the schemas of its operation. The invalid request is rejected
+// with 400 Bad Request and the invalid fields before it reaches the handler. The requests of the undocumented operations
+// are passed through, so the router responds to them. When the responses are validated, the response not matching
+// its operation is logged, so the document and the handlers don't drift apart.
+func Validation(config ValidationConfig, doc *openapi.Document) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op, pathParams, ok := doc.Find(r.Method, r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := doc.ValidateParameters(op, pathParams, r.URL.Query()); err != nil {
+				problem.Write(w, r, err)
+				return
+			}
+
+			if op.RequestBody != nil {
+				body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, config.MaxBodySize))
+				if err != nil {
+					var maxBytesErr *http.MaxBytesError
+					if errors.As(err, &maxBytesErr) {
+						problem.Write(w, r, problem.ErrBodyTooLarge)
+						return
+					}
+
+					problem.Write(w, r, problem.ErrInvalidBody)
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+
+				if err := doc.ValidateBody(op, body); err != nil {
+					problem.Write(w, r, err)
+					return
+				}
+			}
+
+			if !config.ValidateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(recorder, r)
+
+			if err := doc.ValidateResponse(op, recorder.statusCode, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+				log.Printf("%s %s response %d doesn't match openapi document: %v", r.Method, r.URL.Path, recorder.statusCode, err)
+			}
+		})
+	}
+}
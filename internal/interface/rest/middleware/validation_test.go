@@ -0,0 +1,158 @@
+//go:build unit
+
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/openapi"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+func TestValidation(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	const accountID = "5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b"
+
+	type testCaseParams struct {
+		method      string
+		path        string
+		body        string
+		maxBodySize int64
+	}
+
+	type testCaseExpected struct {
+		statusCode int
+		code       string
+		fields     []problem.FieldError
+		called     bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should pass valid request to handler",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/accounts/" + accountID + "/deposit",
+				body:   `{"amount":"10.50"}`,
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+				called:     true,
+			},
+		},
+		{
+			name: "should pass undocumented request to handler",
+			params: testCaseParams{
+				method: http.MethodGet,
+				path:   "/accounts/" + accountID + "/history",
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusOK,
+				called:     true,
+			},
+		},
+		{
+			name: "should reject invalid path parameter",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/accounts/acc123/deposit",
+				body:   `{"amount":"10.50"}`,
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				code:       problem.CodeValidationFailed,
+				fields:     []problem.FieldError{{Field: "id", Code: problem.CodeInvalidUUID, Message: "id must be a uuid"}},
+			},
+		},
+		{
+			name: "should reject invalid body",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/accounts/" + accountID + "/deposit",
+				body:   `{"amount":"ten"}`,
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				code:       problem.CodeValidationFailed,
+				fields:     []problem.FieldError{{Field: "amount", Code: problem.CodeInvalidValue, Message: `amount must match ^[0-9]+(\.[0-9]+)?$`}},
+			},
+		},
+		{
+			name: "should reject body which isn't json",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/accounts/" + accountID + "/deposit",
+				body:   `amount=10.50`,
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusBadRequest,
+				code:       "invalid_body",
+			},
+		},
+		{
+			name: "should reject too large body",
+			params: testCaseParams{
+				method:      http.MethodPost,
+				path:        "/accounts/" + accountID + "/deposit",
+				body:        `{"amount":"10.50"}`,
+				maxBodySize: 8,
+			},
+			expected: testCaseExpected{
+				statusCode: http.StatusRequestEntityTooLarge,
+				code:       "body_too_large",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultValidationConfig()
+			if tt.params.maxBodySize > 0 {
+				config.MaxBodySize = tt.params.maxBodySize
+			}
+
+			called := false
+			handler := Validation(config, doc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+
+				// The validated body is restored for the handler
+				received, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, tt.params.body, string(received))
+
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.params.method, tt.params.path, strings.NewReader(tt.params.body))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expected.statusCode, rr.Code)
+			require.Equal(t, tt.expected.called, called)
+
+			if tt.expected.code == "" {
+				return
+			}
+
+			require.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+
+			var p problem.Problem
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&p))
+			require.Equal(t, tt.expected.code, p.Code)
+			require.Equal(t, tt.expected.fields, p.Errors)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// spec is the OpenAPI 3.1 document of the REST API, it's the contract the clients generate their code from
+//
+//go:embed openapi.json
+var spec []byte
+
+// Document is the OpenAPI document of the REST API. Only the parts the requests and responses are validated with are decoded,
+// the document is served as it's written.
+type Document struct {
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+	raw        []byte
+}
+
+// PathItem holds the operations of a path, i.e. /accounts/{id}
+type PathItem struct {
+	Get    *Operation `json:"get"`
+	Put    *Operation `json:"put"`
+	Post   *Operation `json:"post"`
+	Delete *Operation `json:"delete"`
+	Patch  *Operation `json:"patch"`
+}
+
+// Operation describes the parameters, the request body and the responses of a single API operation
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Parameters  []*Parameter        `json:"parameters"`
+	RequestBody *RequestBody        `json:"requestBody"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes the path or query parameter of an operation
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes the request body of an operation by its media type
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes the response of an operation by its media type, the response without content has no body
+type Response struct {
+	Ref     string               `json:"$ref"`
+	Content map[string]MediaType `json:"content"`
+}
+
+// MediaType holds the schema of the body of a media type, i.e. application/json
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the definitions referenced with $ref across the document
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+	Responses  map[string]Response   `json:"responses"`
+}
+
+// Load loads the OpenAPI document of the REST API, the references of the parameters and responses are resolved
+// and the references of the schemas are checked, so a broken document fails the start of the server.
+func Load() (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("decoding openapi document: %w", err)
+	}
+	doc.raw = spec
+
+	for path, item := range doc.Paths {
+		for method, op := range item.operations() {
+			if err := doc.resolveOperation(op); err != nil {
+				return nil, fmt.Errorf("resolving operation %s %s: %w", method, path, err)
+			}
+		}
+	}
+
+	for name, schema := range doc.Components.Schemas {
+		if err := doc.compile(schema); err != nil {
+			return nil, fmt.Errorf("compiling schema %s: %w", name, err)
+		}
+	}
+
+	return &doc, nil
+}
+
+// ServeHTTP serves the document, i.e. GET /openapi.json
+func (d *Document) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(d.raw)
+}
+
+// Routes returns the routes of the documented operations as the http.ServeMux patterns, i.e. GET /accounts/{id}
+func (d *Document) Routes() []string {
+	var routes []string
+	for path, item := range d.Paths {
+		for method := range item.operations() {
+			routes = append(routes, method+" "+path)
+		}
+	}
+	sort.Strings(routes)
+
+	return routes
+}
+
+// Find returns the operation of the request method and path together with the values of its path parameters.
+// The path matching the literal segments of the path template, i.e. /admin/events/requeue, is preferred
+// over the one matching the path parameters, i.e. /admin/events/{id}. It reports false for the undocumented request.
+func (d *Document) Find(method, path string) (*Operation, map[string]string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var (
+		found       *Operation
+		foundParams map[string]string
+		foundScore  = -1
+	)
+	for template, item := range d.Paths {
+		op, ok := item.operations()[method]
+		if !ok {
+			continue
+		}
+
+		params, score, ok := match(strings.Split(strings.Trim(template, "/"), "/"), segments)
+		if ok && score > foundScore {
+			found, foundParams, foundScore = op, params, score
+		}
+	}
+
+	return found, foundParams, found != nil
+}
+
+// match matches the path segments with the template segments, the score is the number of the matched literal segments
+func match(template, segments []string) (map[string]string, int, bool) {
+	if len(template) != len(segments) {
+		return nil, 0, false
+	}
+
+	params := map[string]string{}
+	score := 0
+	for i, t := range template {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			if segments[i] == "" {
+				return nil, 0, false
+			}
+			params[strings.Trim(t, "{}")] = segments[i]
+			continue
+		}
+
+		if t != segments[i] {
+			return nil, 0, false
+		}
+		score++
+	}
+
+	return params, score, true
+}
+
+// operations returns the operations of the path by the HTTP method
+func (p PathItem) operations() map[string]*Operation {
+	operations := map[string]*Operation{}
+	for method, op := range map[string]*Operation{
+		http.MethodGet:    p.Get,
+		http.MethodPut:    p.Put,
+		http.MethodPost:   p.Post,
+		http.MethodDelete: p.Delete,
+		http.MethodPatch:  p.Patch,
+	} {
+		if op != nil {
+			operations[method] = op
+		}
+	}
+
+	return operations
+}
+
+// resolveOperation resolves the references of the parameters and responses of the operation and compiles its schemas
+func (d *Document) resolveOperation(op *Operation) error {
+	for i, param := range op.Parameters {
+		if param.Ref == "" {
+			continue
+		}
+
+		resolved, ok := d.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+		if !ok {
+			return fmt.Errorf("unknown parameter %s", param.Ref)
+		}
+		op.Parameters[i] = resolved
+	}
+
+	for status, response := range op.Responses {
+		if response.Ref == "" {
+			continue
+		}
+
+		resolved, ok := d.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+		if !ok {
+			return fmt.Errorf("unknown response %s", response.Ref)
+		}
+		op.Responses[status] = resolved
+	}
+
+	var schemas []*Schema
+	for _, param := range op.Parameters {
+		schemas = append(schemas, param.Schema)
+	}
+	if op.RequestBody != nil {
+		for _, media := range op.RequestBody.Content {
+			schemas = append(schemas, media.Schema)
+		}
+	}
+	for _, response := range op.Responses {
+		for _, media := range response.Content {
+			schemas = append(schemas, media.Schema)
+		}
+	}
+
+	for _, schema := range schemas {
+		if err := d.compile(schema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compile checks the references of the schema and compiles its patterns
+func (d *Document) compile(s *Schema) error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Ref != "" {
+		if _, ok := d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]; !ok {
+			return fmt.Errorf("unknown schema %s", s.Ref)
+		}
+		return nil
+	}
+
+	if s.Pattern != "" && s.pattern == nil {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("compiling pattern %s: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+
+	for _, property := range s.Properties {
+		if err := d.compile(property); err != nil {
+			return err
+		}
+	}
+
+	return d.compile(s.Items)
+}
@@ -0,0 +1,348 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+// Schema is the JSON Schema (draft 2020-12, as used by OpenAPI 3.1) of a value.
+// Only the keywords the document uses are supported, the enum values are strings.
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       Types              `json:"type"`
+	Format     string             `json:"format"`
+	Pattern    string             `json:"pattern"`
+	Enum       []string           `json:"enum"`
+	MinLength  *int               `json:"minLength"`
+	MaxLength  *int               `json:"maxLength"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *Schema            `json:"items"`
+	pattern    *regexp.Regexp
+}
+
+// Types holds the types the value of the schema can have, i.e. ["string", "null"], the value of any type is valid when it's empty
+type Types []string
+
+// UnmarshalJSON decodes the type given as a single type or as a list of types
+func (t *Types) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		var single string
+		if err := json.Unmarshal(b, &single); err != nil {
+			return err
+		}
+
+		*t = Types{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+
+	*t = multiple
+	return nil
+}
+
+// allows reports whether the value of the type is valid
+func (t Types) allows(typ string) bool {
+	return len(t) == 0 || slices.Contains(t, typ) || (typ == "integer" && slices.Contains(t, "number"))
+}
+
+// String returns the types for the validation message, i.e. a string or null
+func (t Types) String() string {
+	articles := map[string]string{
+		"string":  "a string",
+		"integer": "an integer",
+		"number":  "a number",
+		"boolean": "a boolean",
+		"object":  "an object",
+		"array":   "an array",
+		"null":    "null",
+	}
+
+	types := make([]string, 0, len(t))
+	for _, typ := range t {
+		types = append(types, articles[typ])
+	}
+
+	return strings.Join(types, " or ")
+}
+
+// ValidateParameters validates the path parameters and the query parameters of the request against the operation parameters
+func (d *Document) ValidateParameters(op *Operation, pathParams map[string]string, query url.Values) error {
+	var validationErr problem.ValidationError
+
+	for _, param := range op.Parameters {
+		var (
+			value   string
+			present bool
+		)
+		switch param.In {
+		case "path":
+			value, present = pathParams[param.Name]
+		case "query":
+			present = query.Has(param.Name)
+			value = query.Get(param.Name)
+		default:
+			continue
+		}
+
+		if !present || value == "" {
+			if param.Required {
+				validationErr.Add(param.Name, problem.CodeRequired, param.Name+" is required")
+			}
+			continue
+		}
+
+		d.validateValue(param.Schema, parameterValue(d.resolve(param.Schema), value), param.Name, &validationErr)
+	}
+
+	return validationErr.Err()
+}
+
+// parameterValue converts the parameter to the value of the schema type, the value which can't be converted stays a string
+func parameterValue(s *Schema, value string) any {
+	if s == nil {
+		return value
+	}
+
+	switch {
+	case s.Type.allows("string"):
+		return value
+	case s.Type.allows("integer"), s.Type.allows("number"):
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case s.Type.allows("boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
+
+// ValidateBody validates the JSON request body against the operation request body, the empty body is valid when it's optional.
+// It returns problem.ErrInvalidBody when the body isn't JSON.
+func (d *Document) ValidateBody(op *Operation, body []byte) error {
+	if op.RequestBody == nil {
+		return nil
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return fmt.Errorf("request body is required: %w", problem.ErrInvalidBody)
+		}
+		return nil
+	}
+
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return nil
+	}
+
+	value, err := decode(body)
+	if err != nil {
+		return fmt.Errorf("decoding request body: %w: %w", problem.ErrInvalidBody, err)
+	}
+
+	var validationErr problem.ValidationError
+	d.validateValue(media.Schema, value, "", &validationErr)
+
+	return validationErr.Err()
+}
+
+// ValidateResponse validates the response against the operation response of the status code,
+// the response of the undocumented status code and the undocumented body are invalid
+func (d *Document) ValidateResponse(op *Operation, statusCode int, contentType string, body []byte) error {
+	response, ok := op.Responses[strconv.Itoa(statusCode)]
+	if !ok {
+		response, ok = op.Responses[strconv.Itoa(statusCode/100)+"XX"]
+	}
+	if !ok {
+		response, ok = op.Responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("status code %d is not documented", statusCode)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("parsing content type %q: %w", contentType, err)
+	}
+
+	media, ok := response.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("content type %s of status code %d is not documented", mediaType, statusCode)
+	}
+	if media.Schema == nil {
+		return nil
+	}
+
+	value, err := decode(body)
+	if err != nil {
+		return fmt.Errorf("decoding response body: %w", err)
+	}
+
+	var validationErr problem.ValidationError
+	d.validateValue(media.Schema, value, "", &validationErr)
+
+	return validationErr.Err()
+}
+
+// decode decodes the JSON value keeping the numbers as they're written
+func decode(body []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the value")
+	}
+
+	return value, nil
+}
+
+// resolve returns the schema referenced by the schema, or the schema itself when it isn't a reference
+func (d *Document) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+
+	return s
+}
+
+// validateValue validates the decoded JSON value against the schema, the invalid fields are added to the validation error
+func (d *Document) validateValue(s *Schema, value any, field string, validationErr *problem.ValidationError) {
+	s = d.resolve(s)
+	if s == nil {
+		return
+	}
+
+	name := field
+	if name == "" {
+		name = "body"
+	}
+
+	switch v := value.(type) {
+	case nil:
+		if !s.Type.allows("null") {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be %s", name, s.Type))
+		}
+	case string:
+		if !s.Type.allows("string") {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be %s", name, s.Type))
+			return
+		}
+		d.validateString(s, v, name, validationErr)
+	case json.Number:
+		typ := "number"
+		if _, err := v.Int64(); err == nil {
+			typ = "integer"
+		}
+		if !s.Type.allows(typ) {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be %s", name, s.Type))
+			return
+		}
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be at least %v", name, *s.Minimum))
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be at most %v", name, *s.Maximum))
+		}
+	case bool:
+		if !s.Type.allows("boolean") {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be %s", name, s.Type))
+		}
+	case map[string]any:
+		if !s.Type.allows("object") {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be %s", name, s.Type))
+			return
+		}
+		for _, required := range s.Required {
+			if _, ok := v[required]; !ok {
+				property := join(field, required)
+				validationErr.Add(property, problem.CodeRequired, property+" is required")
+			}
+		}
+		// The properties are validated by their names, so the invalid fields are always reported in the same order
+		for _, property := range slices.Sorted(maps.Keys(s.Properties)) {
+			if propertyValue, ok := v[property]; ok {
+				d.validateValue(s.Properties[property], propertyValue, join(field, property), validationErr)
+			}
+		}
+	case []any:
+		if !s.Type.allows("array") {
+			validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be %s", name, s.Type))
+			return
+		}
+		for i, item := range v {
+			d.validateValue(s.Items, item, fmt.Sprintf("%s[%d]", field, i), validationErr)
+		}
+	}
+}
+
+// validateString validates the string against the format, pattern, length and values of the schema
+func (d *Document) validateString(s *Schema, value, name string, validationErr *problem.ValidationError) {
+	switch s.Format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			validationErr.Add(name, problem.CodeInvalidUUID, name+" must be a uuid")
+			return
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			validationErr.Add(name, problem.CodeInvalidValue, name+" must be an RFC 3339 date-time")
+			return
+		}
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must match %s", name, s.Pattern))
+	}
+	if s.MinLength != nil && len([]rune(value)) < *s.MinLength {
+		validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be at least %d characters long", name, *s.MinLength))
+	}
+	if s.MaxLength != nil && len([]rune(value)) > *s.MaxLength {
+		validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be at most %d characters long", name, *s.MaxLength))
+	}
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, value) {
+		validationErr.Add(name, problem.CodeInvalidValue, fmt.Sprintf("%s must be one of: %s", name, strings.Join(s.Enum, ", ")))
+	}
+}
+
+// join joins the name of the property to the field it belongs to, i.e. address.city
+func join(field, property string) string {
+	if field == "" {
+		return property
+	}
+
+	return field + "." + property
+}
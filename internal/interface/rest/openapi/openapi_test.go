@@ -0,0 +1,316 @@
+//go:build unit
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/problem"
+)
+
+func TestLoad(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(doc.raw, &raw))
+	require.Equal(t, "3.1.0", raw["openapi"])
+	require.Contains(t, doc.Routes(), "GET /openapi.json")
+}
+
+func TestDocument_Find(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	type testCaseExpected struct {
+		operationID string
+		pathParams  map[string]string
+		found       bool
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected testCaseExpected
+	}{
+		{
+			name:   "should find operation with path parameter",
+			method: http.MethodPost,
+			path:   "/accounts/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b/deposit",
+			expected: testCaseExpected{
+				operationID: "deposit",
+				pathParams:  map[string]string{"id": "5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b"},
+				found:       true,
+			},
+		},
+		{
+			name:   "should prefer operation with literal segment over path parameter",
+			method: http.MethodPost,
+			path:   "/admin/events/requeue",
+			expected: testCaseExpected{
+				operationID: "requeueEvents",
+				pathParams:  map[string]string{},
+				found:       true,
+			},
+		},
+		{
+			name:   "should find operation by method",
+			method: http.MethodDelete,
+			path:   "/customers/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b",
+			expected: testCaseExpected{
+				operationID: "deleteCustomer",
+				pathParams:  map[string]string{"customerId": "5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b"},
+				found:       true,
+			},
+		},
+		{
+			name:     "shouldn't find undocumented method",
+			method:   http.MethodPatch,
+			path:     "/customers/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b",
+			expected: testCaseExpected{},
+		},
+		{
+			name:     "shouldn't find undocumented path",
+			method:   http.MethodGet,
+			path:     "/accounts/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b/history",
+			expected: testCaseExpected{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, pathParams, found := doc.Find(tt.method, tt.path)
+
+			require.Equal(t, tt.expected.found, found)
+			if !tt.expected.found {
+				return
+			}
+
+			require.Equal(t, tt.expected.operationID, op.OperationID)
+			require.Equal(t, tt.expected.pathParams, pathParams)
+		})
+	}
+}
+
+func TestDocument_ValidateRequest(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	type testCaseParams struct {
+		method string
+		path   string
+		query  string
+		body   string
+	}
+
+	type testCaseExpected struct {
+		fields      []problem.FieldError
+		invalidBody bool
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should accept valid request",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/transfers",
+				body:   `{"sourceAccountId":"5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b","targetAccountId":"6a1f2c9b-5e4d-4b9f-8c2b-4d3e2f1a0b9c","amount":"10.50"}`,
+			},
+		},
+		{
+			name: "should accept missing optional body",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/accounts/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b/block",
+			},
+		},
+		{
+			name: "should accept valid query parameters",
+			params: testCaseParams{
+				method: http.MethodGet,
+				path:   "/admin/events",
+				query:  "state=failed&limit=50",
+			},
+		},
+		{
+			name: "should reject invalid path parameter",
+			params: testCaseParams{
+				method: http.MethodGet,
+				path:   "/accounts/acc123",
+			},
+			expected: testCaseExpected{
+				fields: []problem.FieldError{{Field: "id", Code: problem.CodeInvalidUUID, Message: "id must be a uuid"}},
+			},
+		},
+		{
+			name: "should reject invalid query parameters",
+			params: testCaseParams{
+				method: http.MethodGet,
+				path:   "/admin/events",
+				query:  "state=lost&limit=many",
+			},
+			expected: testCaseExpected{
+				fields: []problem.FieldError{
+					{Field: "state", Code: problem.CodeInvalidValue, Message: "state must be one of: ready, processing, completed, failed, aborted, unprocessable"},
+					{Field: "limit", Code: problem.CodeInvalidValue, Message: "limit must be an integer"},
+				},
+			},
+		},
+		{
+			name: "should reject body with invalid and missing fields",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/transfers",
+				body:   `{"sourceAccountId":"acc123","targetAccountId":7}`,
+			},
+			expected: testCaseExpected{
+				fields: []problem.FieldError{
+					{Field: "amount", Code: problem.CodeRequired, Message: "amount is required"},
+					{Field: "sourceAccountId", Code: problem.CodeInvalidUUID, Message: "sourceAccountId must be a uuid"},
+					{Field: "targetAccountId", Code: problem.CodeInvalidValue, Message: "targetAccountId must be a string"},
+				},
+			},
+		},
+		{
+			name: "should reject body with invalid nested field",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/customers",
+				body:   `{"firstName":"John","lastName":"Doe","email":"john.doe@example.com","address":{"city":["Warsaw"]}}`,
+			},
+			expected: testCaseExpected{
+				fields: []problem.FieldError{{Field: "address.city", Code: problem.CodeInvalidValue, Message: "address.city must be a string"}},
+			},
+		},
+		{
+			name: "should reject missing required body",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/accounts/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b/deposit",
+			},
+			expected: testCaseExpected{
+				invalidBody: true,
+			},
+		},
+		{
+			name: "should reject body which isn't json",
+			params: testCaseParams{
+				method: http.MethodPost,
+				path:   "/accounts/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b/deposit",
+				body:   `{"amount":"10.50"`,
+			},
+			expected: testCaseExpected{
+				invalidBody: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, pathParams, found := doc.Find(tt.params.method, tt.params.path)
+			require.True(t, found)
+
+			query, err := url.ParseQuery(tt.params.query)
+			require.NoError(t, err)
+
+			err = doc.ValidateParameters(op, pathParams, query)
+			if err == nil {
+				err = doc.ValidateBody(op, []byte(tt.params.body))
+			}
+
+			switch {
+			case tt.expected.invalidBody:
+				require.ErrorIs(t, err, problem.ErrInvalidBody)
+			case tt.expected.fields != nil:
+				var validationErr *problem.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				require.Equal(t, tt.expected.fields, validationErr.Fields)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDocument_ValidateResponse(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	type testCaseParams struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected bool
+	}{
+		{
+			name: "should accept documented response",
+			params: testCaseParams{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				body:        `{"id":"5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b","type":"account.funds.deposited","state":"completed","finished":true,"retry":0,"maxRetry":3,"createdAt":"2025-01-02T10:00:00Z"}`,
+			},
+			expected: true,
+		},
+		{
+			name: "should accept documented problem",
+			params: testCaseParams{
+				statusCode:  http.StatusNotFound,
+				contentType: problem.ContentType,
+				body:        `{"type":"urn:ddd-bank:problem:command_not_found","title":"Command not found","status":404,"code":"command_not_found"}`,
+			},
+			expected: true,
+		},
+		{
+			name: "should reject response not matching schema",
+			params: testCaseParams{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				body:        `{"id":"5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b","state":"done"}`,
+			},
+		},
+		{
+			name: "should reject undocumented status code",
+			params: testCaseParams{
+				statusCode: http.StatusTeapot,
+			},
+		},
+		{
+			name: "should reject undocumented content type",
+			params: testCaseParams{
+				statusCode:  http.StatusNotFound,
+				contentType: "text/plain; charset=utf-8",
+				body:        "command not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, _, found := doc.Find(http.MethodGet, "/commands/5f0e1b8a-4d3c-4a8e-9b1a-3c2d1e0f9a8b")
+			require.True(t, found)
+
+			err := doc.ValidateResponse(op, tt.params.statusCode, tt.params.contentType, []byte(tt.params.body))
+			if tt.expected {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	applicationaccount "github.com/stefanowiczd/ddd-case-01/internal/application/account"
+	applicationcommand "github.com/stefanowiczd/ddd-case-01/internal/application/command"
+	applicationcredentials "github.com/stefanowiczd/ddd-case-01/internal/application/credentials"
+	applicationcustomer "github.com/stefanowiczd/ddd-case-01/internal/application/customer"
+	applicationevent "github.com/stefanowiczd/ddd-case-01/internal/application/event"
+	applicationtransaction "github.com/stefanowiczd/ddd-case-01/internal/application/transaction"
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+const (
+	// ContentType is the media type of the problem details
+	ContentType = "application/problem+json"
+	// typePrefix is the prefix of the problem type URI, the type is identified by the problem code
+	typePrefix = "urn:ddd-bank:problem:"
+	// CodeInternalError is the code of the problem of the error the server didn't expect
+	CodeInternalError = "internal_error"
+	// CodeValidationFailed is the code of the problem of the request with the invalid fields
+	CodeValidationFailed = "validation_failed"
+)
+
+// Problem represents the problem details of the failed request (RFC 7807). Besides the standard members it holds
+// the stable error code the clients can rely on, the trace ID the request is traced with (its correlation ID)
+// and the invalid fields of the request.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	TraceID  string       `json:"traceId,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// mapping translates the error to the problem, the field is set for the errors of a single request field
+type mapping struct {
+	err    error
+	status int
+	code   string
+	title  string
+	field  string
+}
+
+// mappings translates the errors returned by the handlers to the problems, the first mapping the error matches is used,
+// so the application errors go before the domain errors they may wrap.
+var mappings = []mapping{
+	// Request errors
+	{err: ErrInvalidBody, status: http.StatusBadRequest, code: "invalid_body", title: "Invalid request body"},
+	{err: ErrBodyTooLarge, status: http.StatusRequestEntityTooLarge, code: "body_too_large", title: "Request body too large"},
+	{err: ErrInvalidIdempotencyKey, status: http.StatusBadRequest, code: "invalid_idempotency_key", title: "Invalid idempotency key"},
+	{err: ErrIdempotencyKeyReused, status: http.StatusUnprocessableEntity, code: "idempotency_key_reused", title: "Idempotency key reused"},
+	{err: ErrIdempotencyKeyInProgress, status: http.StatusConflict, code: "idempotency_key_in_progress", title: "Request in progress"},
+
+	// Identity errors
+	{err: identitydomain.ErrUnauthenticated, status: http.StatusUnauthorized, code: "unauthenticated", title: "Authentication required"},
+	{err: identitydomain.ErrInvalidCredentials, status: http.StatusUnauthorized, code: "invalid_credentials", title: "Invalid credentials"},
+	{err: identitydomain.ErrForbidden, status: http.StatusForbidden, code: "forbidden", title: "Forbidden"},
+
+	// Account errors
+	{err: applicationaccount.ErrAccountNotFound, status: http.StatusNotFound, code: "account_not_found", title: "Account not found"},
+	{err: applicationaccount.ErrCustomerNotFound, status: http.StatusNotFound, code: "customer_not_found", title: "Customer not found"},
+	{err: applicationaccount.ErrInvalidWithdrawAmount, status: http.StatusBadRequest, code: "invalid_amount", title: "Invalid amount", field: "amount"},
+	{err: applicationaccount.ErrInvalidDepositAmount, status: http.StatusBadRequest, code: "invalid_amount", title: "Invalid amount", field: "amount"},
+	{err: applicationaccount.ErrInvalidInitialBalanceAmount, status: http.StatusBadRequest, code: "invalid_amount", title: "Invalid amount", field: "initialBalance"},
+	{err: applicationaccount.ErrInvalidCurrency, status: http.StatusBadRequest, code: "invalid_currency", title: "Invalid currency", field: "currency"},
+	{err: applicationaccount.ErrInvalidBlockedUntil, status: http.StatusBadRequest, code: "invalid_blocked_until", title: "Invalid blocked until time", field: "blockedUntil"},
+	{err: applicationaccount.ErrAccountStatusConflict, status: http.StatusConflict, code: "account_status_conflict", title: "Account status conflict"},
+	{err: applicationaccount.ErrInsufficientFunds, status: http.StatusUnprocessableEntity, code: "insufficient_funds", title: "Insufficient funds"},
+	{err: applicationaccount.ErrConcurrencyConflict, status: http.StatusConflict, code: "concurrency_conflict", title: "Concurrency conflict"},
+
+	// Customer errors
+	{err: applicationcustomer.ErrCustomerNotFound, status: http.StatusNotFound, code: "customer_not_found", title: "Customer not found"},
+	{err: applicationcustomer.ErrCustomerAlreadyExists, status: http.StatusConflict, code: "customer_already_exists", title: "Customer already exists"},
+	{err: applicationcustomer.ErrConcurrencyConflict, status: http.StatusConflict, code: "concurrency_conflict", title: "Concurrency conflict"},
+
+	// Credentials errors
+	{err: applicationcredentials.ErrCustomerNotFound, status: http.StatusNotFound, code: "customer_not_found", title: "Customer not found"},
+	{err: applicationcredentials.ErrCredentialsNotFound, status: http.StatusNotFound, code: "credentials_not_found", title: "Credentials not found"},
+	{err: applicationcredentials.ErrConcurrencyConflict, status: http.StatusConflict, code: "concurrency_conflict", title: "Concurrency conflict"},
+
+	// Transaction errors
+	{err: applicationtransaction.ErrTransactionNotFound, status: http.StatusNotFound, code: "transaction_not_found", title: "Transaction not found"},
+	{err: applicationtransaction.ErrSourceAccountNotFound, status: http.StatusNotFound, code: "source_account_not_found", title: "Source account not found"},
+	{err: applicationtransaction.ErrTargetAccountNotFound, status: http.StatusNotFound, code: "target_account_not_found", title: "Target account not found"},
+	{err: applicationtransaction.ErrInvalidTransferAmount, status: http.StatusBadRequest, code: "invalid_amount", title: "Invalid amount", field: "amount"},
+	{err: applicationtransaction.ErrSameAccountTransfer, status: http.StatusBadRequest, code: "same_account_transfer", title: "Same account transfer", field: "targetAccountId"},
+	{err: applicationtransaction.ErrCurrencyMismatch, status: http.StatusUnprocessableEntity, code: "currency_mismatch", title: "Currency mismatch"},
+
+	// Event errors
+	{err: applicationevent.ErrEventNotFound, status: http.StatusNotFound, code: "event_not_found", title: "Event not found"},
+	{err: applicationevent.ErrEventStateConflict, status: http.StatusConflict, code: "event_state_conflict", title: "Event state conflict"},
+	{err: applicationevent.ErrInvalidEventFilter, status: http.StatusBadRequest, code: "invalid_event_filter", title: "Invalid event filter"},
+	{err: applicationevent.ErrMissingActor, status: http.StatusBadRequest, code: CodeRequired, title: "Missing actor", field: "actor"},
+	{err: applicationevent.ErrMissingReason, status: http.StatusBadRequest, code: CodeRequired, title: "Missing reason", field: "reason"},
+
+	// Command errors
+	{err: applicationcommand.ErrCommandNotFound, status: http.StatusNotFound, code: "command_not_found", title: "Command not found"},
+
+	// Domain errors
+	{err: accountdomain.ErrAccountAlreadyExists, status: http.StatusConflict, code: "account_already_exists", title: "Account already exists"},
+	{err: customerdomain.ErrCustomerAlreadyExists, status: http.StatusConflict, code: "customer_already_exists", title: "Customer already exists"},
+	{err: credentialsdomain.ErrWeakPassword, status: http.StatusBadRequest, code: "weak_password", title: "Weak password", field: "password"},
+	{err: credentialsdomain.ErrWrongTOTPCode, status: http.StatusBadRequest, code: "wrong_totp_code", title: "Wrong TOTP code", field: "code"},
+	{err: credentialsdomain.ErrTOTPNotEnrolled, status: http.StatusConflict, code: "totp_not_enrolled", title: "TOTP not enrolled"},
+	{err: credentialsdomain.ErrTOTPAlreadyEnabled, status: http.StatusConflict, code: "totp_already_enabled", title: "TOTP already enabled"},
+	{err: credentialsdomain.ErrCredentialsLocked, status: http.StatusLocked, code: "credentials_locked", title: "Credentials locked"},
+	{err: eventdomain.ErrConcurrencyConflict, status: http.StatusConflict, code: "concurrency_conflict", title: "Concurrency conflict"},
+}
+
+// New returns the problem of the error. The problem detail is the message of the matched error rather than of the whole
+// wrapped error, which holds the internals of the failed operation. The error which matches none of the mappings
+// is an internal error without any detail.
+func New(err error) Problem {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return Problem{
+			Type:   typePrefix + CodeValidationFailed,
+			Title:  "Validation failed",
+			Status: http.StatusBadRequest,
+			Detail: "The request has invalid fields",
+			Code:   CodeValidationFailed,
+			Errors: validationErr.Fields,
+		}
+	}
+
+	for _, m := range mappings {
+		if !errors.Is(err, m.err) {
+			continue
+		}
+
+		p := Problem{
+			Type:   typePrefix + m.code,
+			Title:  m.title,
+			Status: m.status,
+			Detail: m.err.Error(),
+			Code:   m.code,
+		}
+		if m.field != "" {
+			p.Errors = []FieldError{{Field: m.field, Code: m.code, Message: m.err.Error()}}
+		}
+
+		return p
+	}
+
+	return Problem{
+		Type:   typePrefix + CodeInternalError,
+		Title:  "Internal server error",
+		Status: http.StatusInternalServerError,
+		Detail: "The request couldn't be processed",
+		Code:   CodeInternalError,
+	}
+}
+
+// Write writes the problem of the error as the response. The problem instance is the request path,
+// its trace ID is the correlation ID of the request. The internal errors are logged, as their details aren't returned.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	p := New(err)
+	p.Instance = r.URL.Path
+	if md := eventdomain.MetadataFromContext(r.Context()); md.CorrelationID != uuid.Nil {
+		p.TraceID = md.CorrelationID.String()
+	}
+
+	if p.Status == http.StatusInternalServerError {
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p) // TODO decide about handling of this error.
+}
@@ -0,0 +1,74 @@
+package problem
+
+import (
+	"errors"
+	"strings"
+)
+
+// Request errors, the errors of the request itself rather than of the use case it executes
+var (
+	// ErrInvalidBody is returned when the request body can't be read or decoded.
+	ErrInvalidBody = errors.New("invalid request body")
+	// ErrBodyTooLarge is returned when the request body exceeds the allowed size.
+	ErrBodyTooLarge = errors.New("request body too large")
+	// ErrInvalidIdempotencyKey is returned when the idempotency key of the request is invalid, i.e. too long.
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	// ErrIdempotencyKeyReused is returned when the idempotency key was already used for another request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused for another request")
+	// ErrIdempotencyKeyInProgress is returned when the request with the idempotency key is still in progress.
+	ErrIdempotencyKeyInProgress = errors.New("request with the idempotency key is in progress")
+)
+
+// Field error codes
+const (
+	// CodeInvalidUUID is the code of the field which isn't a UUID
+	CodeInvalidUUID = "invalid_uuid"
+	// CodeInvalidValue is the code of the field with the value the use case doesn't accept
+	CodeInvalidValue = "invalid_value"
+	// CodeRequired is the code of the missing field
+	CodeRequired = "required"
+)
+
+// FieldError describes the invalid field of the request, the field is named as in the request, i.e. customerId
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when the fields of the request are invalid, it holds all the invalid fields
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Add adds the invalid field
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns the validation error when any field is invalid, nil otherwise
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Error returns the invalid fields with their messages
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		fields = append(fields, f.Field+": "+f.Message)
+	}
+
+	return "validate: " + strings.Join(fields, ", ")
+}
+
+// InvalidField returns the validation error of the single invalid field
+func InvalidField(field, code, message string) error {
+	var validationErr ValidationError
+	validationErr.Add(field, code, message)
+
+	return validationErr.Err()
+}
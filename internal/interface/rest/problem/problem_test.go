@@ -0,0 +1,199 @@
+//go:build unit
+
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	applicationaccount "github.com/stefanowiczd/ddd-case-01/internal/application/account"
+	applicationcustomer "github.com/stefanowiczd/ddd-case-01/internal/application/customer"
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	credentialsdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/credentials"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+)
+
+func TestWrite(t *testing.T) {
+	correlationID := uuid.New()
+
+	type testCaseParams struct {
+		err error
+		ctx context.Context
+	}
+
+	type testCaseExpected struct {
+		problem Problem
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should write problem of wrapped application error without wrapped error details",
+			params: testCaseParams{
+				err: fmt.Errorf("finding account: %w", applicationaccount.ErrAccountNotFound),
+				ctx: context.Background(),
+			},
+			expected: testCaseExpected{
+				problem: Problem{
+					Type:     "urn:ddd-bank:problem:account_not_found",
+					Title:    "Account not found",
+					Status:   http.StatusNotFound,
+					Detail:   "account not found",
+					Instance: "/accounts/1",
+					Code:     "account_not_found",
+				},
+			},
+		},
+		{
+			name: "should write problem of application error before domain error it wraps",
+			params: testCaseParams{
+				err: fmt.Errorf("parsing deposit amount: %w: %w", applicationaccount.ErrInvalidDepositAmount, money.ErrInvalidAmount),
+				ctx: context.Background(),
+			},
+			expected: testCaseExpected{
+				problem: Problem{
+					Type:     "urn:ddd-bank:problem:invalid_amount",
+					Title:    "Invalid amount",
+					Status:   http.StatusBadRequest,
+					Detail:   "invalid deposit money amount",
+					Instance: "/accounts/1",
+					Code:     "invalid_amount",
+					Errors:   []FieldError{{Field: "amount", Code: "invalid_amount", Message: "invalid deposit money amount"}},
+				},
+			},
+		},
+		{
+			name: "should write problem of domain error",
+			params: testCaseParams{
+				err: fmt.Errorf("saving account: %w", accountdomain.ErrAccountAlreadyExists),
+				ctx: context.Background(),
+			},
+			expected: testCaseExpected{
+				problem: Problem{
+					Type:     "urn:ddd-bank:problem:account_already_exists",
+					Title:    "Account already exists",
+					Status:   http.StatusConflict,
+					Detail:   "account already exists",
+					Instance: "/accounts/1",
+					Code:     "account_already_exists",
+				},
+			},
+		},
+		{
+			name: "should write problem of locked credentials",
+			params: testCaseParams{
+				err: fmt.Errorf("verifying password: %w", credentialsdomain.ErrCredentialsLocked),
+				ctx: context.Background(),
+			},
+			expected: testCaseExpected{
+				problem: Problem{
+					Type:     "urn:ddd-bank:problem:credentials_locked",
+					Title:    "Credentials locked",
+					Status:   http.StatusLocked,
+					Detail:   credentialsdomain.ErrCredentialsLocked.Error(),
+					Instance: "/accounts/1",
+					Code:     "credentials_locked",
+				},
+			},
+		},
+		{
+			name: "should write problem with trace id of request",
+			params: testCaseParams{
+				err: applicationcustomer.ErrConcurrencyConflict,
+				ctx: eventdomain.ContextWithMetadata(context.Background(), eventdomain.Metadata{CorrelationID: correlationID}),
+			},
+			expected: testCaseExpected{
+				problem: Problem{
+					Type:     "urn:ddd-bank:problem:concurrency_conflict",
+					Title:    "Concurrency conflict",
+					Status:   http.StatusConflict,
+					Detail:   "customer was changed concurrently",
+					Instance: "/accounts/1",
+					Code:     "concurrency_conflict",
+					TraceID:  correlationID.String(),
+				},
+			},
+		},
+		{
+			name: "should write problem of validation error with invalid fields",
+			params: testCaseParams{
+				err: func() error {
+					var validationErr ValidationError
+					validationErr.Add("sourceAccountId", CodeInvalidUUID, "source account id must be a uuid")
+					validationErr.Add("targetAccountId", CodeInvalidUUID, "target account id must be a uuid")
+					return validationErr.Err()
+				}(),
+				ctx: context.Background(),
+			},
+			expected: testCaseExpected{
+				problem: Problem{
+					Type:     "urn:ddd-bank:problem:validation_failed",
+					Title:    "Validation failed",
+					Status:   http.StatusBadRequest,
+					Detail:   "The request has invalid fields",
+					Instance: "/accounts/1",
+					Code:     "validation_failed",
+					Errors: []FieldError{
+						{Field: "sourceAccountId", Code: CodeInvalidUUID, Message: "source account id must be a uuid"},
+						{Field: "targetAccountId", Code: CodeInvalidUUID, Message: "target account id must be a uuid"},
+					},
+				},
+			},
+		},
+		{
+			name: "should write internal error problem without error details",
+			params: testCaseParams{
+				err: fmt.Errorf("finding account: %w", errors.New("connection refused to 10.0.0.1:5432")),
+				ctx: context.Background(),
+			},
+			expected: testCaseExpected{
+				problem: Problem{
+					Type:     "urn:ddd-bank:problem:internal_error",
+					Title:    "Internal server error",
+					Status:   http.StatusInternalServerError,
+					Detail:   "The request couldn't be processed",
+					Instance: "/accounts/1",
+					Code:     "internal_error",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(tt.params.ctx, http.MethodGet, "/accounts/1?limit=1", nil)
+			w := httptest.NewRecorder()
+
+			Write(w, req, tt.params.err)
+
+			require.Equal(t, tt.expected.problem.Status, w.Code)
+			require.Equal(t, ContentType, w.Header().Get("Content-Type"))
+
+			var p Problem
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+			require.Equal(t, tt.expected.problem, p)
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	var validationErr ValidationError
+	require.NoError(t, validationErr.Err())
+
+	validationErr.Add("id", CodeInvalidUUID, "account id must be a uuid")
+	require.EqualError(t, validationErr.Err(), "validate: id: account id must be a uuid")
+
+	require.ErrorAs(t, fmt.Errorf("validating request: %w", InvalidField("reason", CodeRequired, "reason is required")), new(*ValidationError))
+}
@@ -4,26 +4,37 @@ import (
 	"net/http"
 
 	accounthandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
 )
 
-// registerAccountRoutes registers all account-related routes
-func RegisterAccountRoutes(r *http.ServeMux, aqh *accounthandler.AccountQueryHandler, ah *accounthandler.AccountHandler) {
+// registerAccountRoutes registers all account-related routes allowed to the roles of the policy, the mutate middleware wraps
+// the mutate operations, i.e. idempotency
+func RegisterAccountRoutes(
+	r Mux,
+	aqh *accounthandler.AccountQueryHandler,
+	ah *accounthandler.AccountHandler,
+	mutate middleware.Middleware,
+) {
 
 	// Query operations:
 	// Get account / accounts
-	r.HandleFunc("GET /accounts/{id}", aqh.GetAccount)
-	r.HandleFunc("GET /customers/{customerId}/accounts", aqh.GetCustomerAccounts)
+	r.Handle("GET /accounts/{id}", middleware.Authorize(ownerReadRoles...)(http.HandlerFunc(aqh.GetAccount)))
+	r.Handle("GET /customers/{customerId}/accounts", middleware.Authorize(ownerReadRoles...)(http.HandlerFunc(aqh.GetCustomerAccounts)))
 
 	// Mutate operations:
 	// Create
-	r.HandleFunc("POST /account", ah.CreateAccount)
+	r.Handle("POST /account", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.CreateAccount))))
 
-	// Block / unblock
-	r.HandleFunc("POST /accounts/{id}/block", ah.BlockAccount)
-	r.HandleFunc("POST /accounts/{id}/unblock", ah.UnblockAccount)
+	// Lifecycle: activate / block / unblock / freeze / unfreeze / close
+	r.Handle("POST /accounts/{id}/activate", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.ActivateAccount))))
+	r.Handle("POST /accounts/{id}/block", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.BlockAccount))))
+	r.Handle("POST /accounts/{id}/unblock", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.UnblockAccount))))
+	r.Handle("POST /accounts/{id}/freeze", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.FreezeAccount))))
+	r.Handle("POST /accounts/{id}/unfreeze", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.UnfreezeAccount))))
+	r.Handle("POST /accounts/{id}/close", middleware.Authorize(backOfficeRoles...)(mutate(http.HandlerFunc(ah.CloseAccount))))
 
 	// Deposit / withdrawn
-	r.HandleFunc("POST /accounts/{id}/deposit", ah.Deposit)
-	r.HandleFunc("POST /accounts/{id}/withdraw", ah.Withdraw)
+	r.Handle("POST /accounts/{id}/deposit", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.Deposit))))
+	r.Handle("POST /accounts/{id}/withdraw", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ah.Withdraw))))
 
 }
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"net/http"
+
+	commandhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/command"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+)
+
+// RegisterCommandRoutes registers the routes of clients tracking the commands accepted for processing, allowed to the roles of the policy
+func RegisterCommandRoutes(
+	r Mux,
+	cqh *commandhandler.CommandQueryHandler,
+) {
+
+	// Query operations:
+	// Get command status, the command is identified by the event it recorded
+	r.Handle("GET /commands/{id}", middleware.Authorize(readRoles...)(http.HandlerFunc(cqh.GetCommand)))
+
+}
@@ -0,0 +1,36 @@
+package router
+
+import (
+	"net/http"
+
+	credentialshandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/credentials"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+)
+
+// RegisterCredentialsRoutes registers the routes of the online banking credentials of the customers allowed to the roles of the policy,
+// the login routes are public, the customer logs in with them. The responses carrying the tokens and the TOTP secret aren't kept
+// by the idempotency middleware, so the routes aren't wrapped by it.
+func RegisterCredentialsRoutes(
+	r Mux,
+	ch *credentialshandler.CredentialsHandler,
+	sh *credentialshandler.SessionHandler,
+) {
+
+	// Mutate operations:
+	// Set password
+	r.Handle("PUT /customers/{customerId}/credentials", middleware.Authorize(credentialsRoles...)(http.HandlerFunc(ch.SetPassword)))
+
+	// Enroll / confirm TOTP
+	r.Handle("POST /customers/{customerId}/credentials/totp", middleware.Authorize(customerRoles...)(http.HandlerFunc(ch.EnrollTOTP)))
+	r.Handle("POST /customers/{customerId}/credentials/totp/confirm", middleware.Authorize(customerRoles...)(http.HandlerFunc(ch.ConfirmTOTP)))
+
+	// Public operations:
+	// Login / verify TOTP code
+	r.Handle("POST /auth/login", http.HandlerFunc(sh.Login))
+	r.Handle("POST /auth/login/mfa", http.HandlerFunc(sh.VerifyMFA))
+
+	// Refresh / logout
+	r.Handle("POST /auth/refresh", http.HandlerFunc(sh.RefreshSession))
+	r.Handle("POST /auth/logout", http.HandlerFunc(sh.Logout))
+
+}
@@ -4,31 +4,34 @@ import (
 	"net/http"
 
 	customerhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/customer"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
 )
 
-// registerCustomerRoutes registers all customer-related routes
+// registerCustomerRoutes registers all customer-related routes allowed to the roles of the policy, the mutate middleware wraps
+// the mutate operations, i.e. idempotency
 func RegisterCustomerRoutes(
-	r *http.ServeMux,
+	r Mux,
 	cqh *customerhandler.CustomerQueryHandler,
 	ch *customerhandler.CustomerHandler,
+	mutate middleware.Middleware,
 ) {
 
 	// Query operations:
 	// Get account / accounts
-	r.HandleFunc("GET /customers/{customerId}", cqh.GetCustomer)
+	r.Handle("GET /customers/{customerId}", middleware.Authorize(ownerReadRoles...)(http.HandlerFunc(cqh.GetCustomer)))
 
 	// Mutate operations:
 	// Create
-	r.HandleFunc("POST /customers", ch.CreateCustomer)
+	r.Handle("POST /customers", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ch.CreateCustomer))))
 
 	// Block / unblock
-	r.HandleFunc("POST /customers/{customerId}/block", ch.BlockCustomer)
-	r.HandleFunc("POST /customers/{customerId}/unblock", ch.UnblockCustomer)
+	r.Handle("POST /customers/{customerId}/block", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ch.BlockCustomer))))
+	r.Handle("POST /customers/{customerId}/unblock", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ch.UnblockCustomer))))
 
 	// Update
-	r.HandleFunc("PUT /customers/{customerId}", ch.UpdateCustomer)
+	r.Handle("PUT /customers/{customerId}", middleware.Authorize(operateRoles...)(mutate(http.HandlerFunc(ch.UpdateCustomer))))
 
 	// Delete
-	r.HandleFunc("DELETE /customers/{customerId}", ch.DeleteCustomer)
+	r.Handle("DELETE /customers/{customerId}", middleware.Authorize(backOfficeRoles...)(mutate(http.HandlerFunc(ch.DeleteCustomer))))
 
 }
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"net/http"
+
+	eventhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+)
+
+// RegisterEventRoutes registers all the admin routes of operators inspecting and repairing the events, allowed to the roles of the policy
+func RegisterEventRoutes(
+	r Mux,
+	eqh *eventhandler.EventQueryHandler,
+	eh *eventhandler.EventHandler,
+) {
+
+	// Query operations:
+	// List events by state and origin / inspect event
+	r.Handle("GET /admin/events", middleware.Authorize(eventReadRoles...)(http.HandlerFunc(eqh.ListEvents)))
+	r.Handle("GET /admin/events/{id}", middleware.Authorize(eventReadRoles...)(http.HandlerFunc(eqh.GetEvent)))
+
+	// Mutate operations:
+	// Requeue / abort event
+	r.Handle("POST /admin/events/{id}/requeue", middleware.Authorize(backOfficeRoles...)(http.HandlerFunc(eh.RequeueEvent)))
+	r.Handle("POST /admin/events/{id}/abort", middleware.Authorize(backOfficeRoles...)(http.HandlerFunc(eh.AbortEvent)))
+
+	// Requeue events by state and origin
+	r.Handle("POST /admin/events/requeue", middleware.Authorize(backOfficeRoles...)(http.HandlerFunc(eh.RequeueEvents)))
+
+}
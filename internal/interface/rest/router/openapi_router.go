@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/openapi"
+)
+
+// RegisterOpenAPIRoutes registers the route serving the OpenAPI document of the REST API
+func RegisterOpenAPIRoutes(
+	r Mux,
+	doc *openapi.Document,
+) {
+
+	// Query operations:
+	// Get OpenAPI document
+	r.Handle("GET /openapi.json", doc)
+
+}
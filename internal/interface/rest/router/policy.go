@@ -0,0 +1,23 @@
+package router
+
+import (
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+)
+
+// The policy of the routes, the roles allowed to call them
+var (
+	// readRoles read the accounts, customers, transfers and commands
+	readRoles = []identitydomain.Role{identitydomain.RoleTeller, identitydomain.RoleBackOffice, identitydomain.RoleAuditor}
+	// ownerReadRoles read the accounts and customers, the customer reads the ones they own
+	ownerReadRoles = []identitydomain.Role{identitydomain.RoleTeller, identitydomain.RoleBackOffice, identitydomain.RoleAuditor, identitydomain.RoleCustomer}
+	// operateRoles serve the customers, i.e. open the accounts, deposit and transfer the money
+	operateRoles = []identitydomain.Role{identitydomain.RoleTeller, identitydomain.RoleBackOffice}
+	// backOfficeRoles close the accounts, delete the customers and repair the events
+	backOfficeRoles = []identitydomain.Role{identitydomain.RoleBackOffice}
+	// credentialsRoles set the password of the customer, the customer sets their own and the staff resets the forgotten one
+	credentialsRoles = []identitydomain.Role{identitydomain.RoleTeller, identitydomain.RoleBackOffice, identitydomain.RoleCustomer}
+	// customerRoles enroll the second factor of the customer, only the customer holds the authenticator app
+	customerRoles = []identitydomain.Role{identitydomain.RoleCustomer}
+	// eventReadRoles inspect the events
+	eventReadRoles = []identitydomain.Role{identitydomain.RoleBackOffice, identitydomain.RoleAuditor}
+)
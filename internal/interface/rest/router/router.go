@@ -0,0 +1,11 @@
+package router
+
+import (
+	"net/http"
+)
+
+// Mux registers the handlers of the routes, it's implemented by http.ServeMux
+type Mux interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
@@ -0,0 +1,154 @@
+//go:build unit
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	identitydomain "github.com/stefanowiczd/ddd-case-01/internal/domain/identity"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/openapi"
+)
+
+// recordingMux records the patterns and the handlers of the registered routes
+type recordingMux struct {
+	patterns []string
+	handlers map[string]http.Handler
+}
+
+func (m *recordingMux) Handle(pattern string, handler http.Handler) {
+	m.patterns = append(m.patterns, pattern)
+	if m.handlers == nil {
+		m.handlers = map[string]http.Handler{}
+	}
+	m.handlers[pattern] = handler
+}
+
+func (m *recordingMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// registerRoutes registers all the routes with the handlers which aren't called, the routes are only registered
+func registerRoutes(t *testing.T) *recordingMux {
+	t.Helper()
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	mux := &recordingMux{}
+	mutate := func(next http.Handler) http.Handler { return next }
+
+	RegisterAccountRoutes(mux, nil, nil, mutate)
+	RegisterCustomerRoutes(mux, nil, nil, mutate)
+	RegisterTransactionRoutes(mux, nil, nil)
+	RegisterEventRoutes(mux, nil, nil)
+	RegisterCommandRoutes(mux, nil)
+	RegisterCredentialsRoutes(mux, nil, nil)
+	RegisterOpenAPIRoutes(mux, doc)
+
+	return mux
+}
+
+func TestRoutes_OpenAPI(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	mux := registerRoutes(t)
+
+	routes := doc.Routes()
+	for _, pattern := range mux.patterns {
+		require.Contains(t, routes, pattern, "route %s is missing in the openapi document", pattern)
+	}
+
+	sort.Strings(mux.patterns)
+	require.Equal(t, routes, mux.patterns, "routes and openapi document operations differ")
+}
+
+func TestRoutes_Authorization(t *testing.T) {
+	mux := registerRoutes(t)
+
+	// The anonymous request is rejected by every route but the public ones, before it reaches the handler
+	public := []string{"GET /openapi.json", "POST /auth/login", "POST /auth/login/mfa", "POST /auth/refresh", "POST /auth/logout"}
+	for _, pattern := range mux.patterns {
+		if slices.Contains(public, pattern) {
+			continue
+		}
+
+		method, path, _ := strings.Cut(pattern, " ")
+		rr := httptest.NewRecorder()
+		mux.handlers[pattern].ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+
+		require.Equal(t, http.StatusUnauthorized, rr.Code, "route %s isn't protected", pattern)
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		role    identitydomain.Role
+	}{
+		{
+			name:    "should forbid customer to deposit",
+			pattern: "POST /accounts/{id}/deposit",
+			role:    identitydomain.RoleCustomer,
+		},
+		{
+			name:    "should forbid teller to close account",
+			pattern: "POST /accounts/{id}/close",
+			role:    identitydomain.RoleTeller,
+		},
+		{
+			name:    "should forbid teller to delete customer",
+			pattern: "DELETE /customers/{customerId}",
+			role:    identitydomain.RoleTeller,
+		},
+		{
+			name:    "should forbid auditor to transfer money",
+			pattern: "POST /transfers",
+			role:    identitydomain.RoleAuditor,
+		},
+		{
+			name:    "should forbid auditor to requeue events",
+			pattern: "POST /admin/events/requeue",
+			role:    identitydomain.RoleAuditor,
+		},
+		{
+			name:    "should forbid teller to list events",
+			pattern: "GET /admin/events",
+			role:    identitydomain.RoleTeller,
+		},
+		{
+			name:    "should forbid auditor to set password",
+			pattern: "PUT /customers/{customerId}/credentials",
+			role:    identitydomain.RoleAuditor,
+		},
+		{
+			name:    "should forbid teller to enroll totp",
+			pattern: "POST /customers/{customerId}/credentials/totp",
+			role:    identitydomain.RoleTeller,
+		},
+		{
+			name:    "should forbid customer to get transfer",
+			pattern: "GET /transfers/{id}",
+			role:    identitydomain.RoleCustomer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, path, _ := strings.Cut(tt.pattern, " ")
+			req := httptest.NewRequest(method, path, nil)
+			req = req.WithContext(identitydomain.ContextWithPrincipal(req.Context(), identitydomain.Principal{ID: "principal-1", Roles: []identitydomain.Role{tt.role}}))
+
+			rr := httptest.NewRecorder()
+			mux.handlers[tt.pattern].ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusForbidden, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"net/http"
+
+	transactionhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/transaction"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+)
+
+// RegisterTransactionRoutes registers all transfer-related routes allowed to the roles of the policy
+func RegisterTransactionRoutes(
+	r Mux,
+	tqh *transactionhandler.TransferQueryHandler,
+	th *transactionhandler.TransferHandler,
+) {
+
+	// Query operations:
+	// Get transfer with its status
+	r.Handle("GET /transfers/{id}", middleware.Authorize(readRoles...)(http.HandlerFunc(tqh.GetTransfer)))
+
+	// Mutate operations:
+	// Transfer money between two accounts
+	r.Handle("POST /transfers", middleware.Authorize(operateRoles...)(http.HandlerFunc(th.CreateTransfer)))
+
+}
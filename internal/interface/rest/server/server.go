@@ -7,10 +7,15 @@ import (
 	"time"
 
 	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/middleware"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/openapi"
 	"github.com/stefanowiczd/ddd-case-01/internal/interface/rest/router"
 
 	accounthandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/account"
+	commandhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/command"
+	credentialshandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/credentials"
 	customerhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/customer"
+	eventhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/event"
+	transactionhandler "github.com/stefanowiczd/ddd-case-01/internal/interface/rest/handler/transaction"
 )
 
 // Server represents the HTTP server
@@ -25,6 +30,8 @@ type Config struct {
 	ReadTimeout     time.Duration
 	WriteTimeout    time.Duration
 	ShutdownTimeout time.Duration
+	Idempotency     middleware.IdempotencyConfig
+	Validation      middleware.ValidationConfig
 }
 
 // NewServer creates a new HTTP server
@@ -32,21 +39,43 @@ func NewServer(
 	config Config,
 	accountQueryHandler *accounthandler.AccountQueryHandler,
 	customerQueryHandler *customerhandler.CustomerQueryHandler,
+	transferQueryHandler *transactionhandler.TransferQueryHandler,
 	accountHandler *accounthandler.AccountHandler,
 	customerHandler *customerhandler.CustomerHandler,
+	transferHandler *transactionhandler.TransferHandler,
+	eventQueryHandler *eventhandler.EventQueryHandler,
+	eventHandler *eventhandler.EventHandler,
+	commandQueryHandler *commandhandler.CommandQueryHandler,
+	credentialsHandler *credentialshandler.CredentialsHandler,
+	sessionHandler *credentialshandler.SessionHandler,
+	idempotencyStore middleware.IdempotencyStore,
+	authenticators []middleware.Authenticator,
+	apiDoc *openapi.Document,
 ) *Server {
 	// Create router
 	r := http.NewServeMux()
 
-	// Apply middleware
+	// Apply middleware, the request is authenticated before it's validated, the routes authorize the principal by its roles
 	handler := middleware.Chain(
 		r,
 		middleware.Logging,
+		middleware.RequestMetadata,
+		middleware.Authenticate(authenticators...),
+		middleware.Validation(config.Validation, apiDoc),
 	)
 
+	// The retried mutate operations sent with the idempotency key aren't executed twice
+	idempotency := middleware.Idempotency(config.Idempotency, idempotencyStore)
+
 	// Register routes
-	router.RegisterAccountRoutes(r, accountQueryHandler, accountHandler)
-	router.RegisterCustomerRoutes(r, customerQueryHandler, customerHandler)
+	router.RegisterAccountRoutes(r, accountQueryHandler, accountHandler, idempotency)
+	router.RegisterCustomerRoutes(r, customerQueryHandler, customerHandler, idempotency)
+	router.RegisterTransactionRoutes(r, transferQueryHandler, transferHandler)
+	router.RegisterEventRoutes(r, eventQueryHandler, eventHandler)
+	router.RegisterCommandRoutes(r, commandQueryHandler)
+	router.RegisterCredentialsRoutes(r, credentialsHandler, sessionHandler)
+	router.RegisterOpenAPIRoutes(r, apiDoc)
+
 	// Create server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", config.Port),
@@ -78,5 +107,7 @@ func DefaultConfig() Config {
 		ReadTimeout:     15 * time.Second,
 		WriteTimeout:    15 * time.Second,
 		ShutdownTimeout: 5 * time.Second,
+		Idempotency:     middleware.DefaultIdempotencyConfig(),
+		Validation:      middleware.DefaultValidationConfig(),
 	}
 }
@@ -0,0 +1,285 @@
+package cloudevent
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification the events conform to
+	SpecVersion = "1.0"
+	// SourcePrefix prefixes the origin of the event in the source attribute, i.e. /ddd-bank/account
+	SourcePrefix = "/ddd-bank/"
+	// DataSchemaPrefix prefixes the type and the type version of the event in the dataschema attribute,
+	// i.e. urn:ddd-bank:schema:account.created:0.0.0
+	DataSchemaPrefix = "urn:ddd-bank:schema:"
+	// AggregateVersionExtension is the extension attribute carrying the version of the aggregate the event leads to
+	AggregateVersionExtension = "aggregateversion"
+	// IdempotencyKeyExtension is the extension attribute carrying the idempotency key of the request which recorded the event
+	IdempotencyKeyExtension = "idempotencykey"
+	// CorrelationIDExtension is the extension attribute carrying the correlation ID shared by the events following from one request
+	CorrelationIDExtension = "correlationid"
+	// CausationIDExtension is the extension attribute carrying the ID of the request or the event which caused the event
+	CausationIDExtension = "causationid"
+	// ActorIDExtension is the extension attribute carrying who triggered the event, i.e. the user ID
+	ActorIDExtension = "actorid"
+	// ActorTypeExtension is the extension attribute carrying the kind of the actor, i.e. user, system, anonymous
+	ActorTypeExtension = "actortype"
+	// JSONContentType is the content type of the event data, the domain event payload is JSON encoded
+	JSONContentType = "application/json"
+)
+
+// attributeName matches the names of the extension attributes allowed by the specification
+var attributeName = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+// contextAttributes are the attributes defined by the specification, they can't be used as the extension attributes
+var contextAttributes = map[string]bool{
+	"id":              true,
+	"source":          true,
+	"specversion":     true,
+	"type":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"subject":         true,
+	"time":            true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+// Event is the CloudEvents 1.0 event. The extension attributes hold the string, int32 or bool values,
+// the event decoded from the binary HTTP mode keeps them as strings, since the headers don't carry the attribute types.
+type Event struct {
+	ID              string         // Identifies the event, unique within the source
+	Source          string         // Identifies the context in which the event happened, URI-reference
+	SpecVersion     string         // Version of the CloudEvents specification
+	Type            string         // Type of the event
+	DataContentType string         // Content type of the data, optional
+	DataSchema      string         // Schema the data adheres to, absolute URI, optional
+	Subject         string         // Subject of the event in the context of the source, optional
+	Time            time.Time      // When the event happened, optional
+	Extensions      map[string]any // Extension attributes
+	Data            []byte         // The event payload, optional
+}
+
+// FromBaseEvent maps the event onto the cloud event: the ID onto id, the origin onto source, the type onto type,
+// the type version onto dataschema and the creation time onto time. The aggregate (context) ID is the subject and
+// the aggregate version is carried by the aggregateversion extension attribute, the JSON encoded payload is the data.
+// The idempotency key of the request which recorded the event, if any, is carried by the idempotencykey extension attribute,
+// the correlation, causation and actor of the event by the correlationid, causationid, actorid and actortype ones.
+// The source IP of the request doesn't leave the service.
+func FromBaseEvent(event *eventdomain.BaseEvent) Event {
+	e := Event{
+		ID:          event.ID.String(),
+		Source:      SourcePrefix + event.Origin,
+		SpecVersion: SpecVersion,
+		Type:        event.Type,
+		DataSchema:  DataSchemaPrefix + event.Type + ":" + event.TypeVersion,
+		Subject:     event.ContextID.String(),
+		Time:        event.CreatedAt,
+		Extensions: map[string]any{
+			AggregateVersionExtension: int32(event.AggregateVersion),
+		},
+	}
+
+	for name, value := range map[string]string{
+		IdempotencyKeyExtension: event.Metadata.IdempotencyKey,
+		ActorIDExtension:        event.Metadata.ActorID,
+		ActorTypeExtension:      event.Metadata.ActorType,
+	} {
+		if value != "" {
+			e.Extensions[name] = value
+		}
+	}
+
+	for name, id := range map[string]uuid.UUID{
+		CorrelationIDExtension: event.Metadata.CorrelationID,
+		CausationIDExtension:   event.Metadata.CausationID,
+	} {
+		if id != uuid.Nil {
+			e.Extensions[name] = id.String()
+		}
+	}
+
+	if len(event.Data) > 0 {
+		e.DataContentType = JSONContentType
+		e.Data = event.Data
+	}
+
+	return e
+}
+
+// BaseEvent maps the cloud event back onto the event, it's the reverse of FromBaseEvent
+func (e Event) BaseEvent() (*eventdomain.BaseEvent, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("mapping cloud event %s: id: %w", e.ID, ErrNotBankEvent)
+	}
+
+	origin, ok := strings.CutPrefix(e.Source, SourcePrefix)
+	if !ok || origin == "" {
+		return nil, fmt.Errorf("mapping cloud event %s: source %q: %w", e.ID, e.Source, ErrNotBankEvent)
+	}
+
+	typeVersion, ok := strings.CutPrefix(e.DataSchema, DataSchemaPrefix+e.Type+":")
+	if !ok || typeVersion == "" {
+		return nil, fmt.Errorf("mapping cloud event %s: dataschema %q: %w", e.ID, e.DataSchema, ErrNotBankEvent)
+	}
+
+	contextID, err := uuid.Parse(e.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("mapping cloud event %s: subject %q: %w", e.ID, e.Subject, ErrNotBankEvent)
+	}
+
+	aggregateVersion, err := e.aggregateVersion()
+	if err != nil {
+		return nil, fmt.Errorf("mapping cloud event %s: %w", e.ID, err)
+	}
+
+	metadata, err := e.metadata()
+	if err != nil {
+		return nil, fmt.Errorf("mapping cloud event %s: %w", e.ID, err)
+	}
+
+	return &eventdomain.BaseEvent{
+		ID:               id,
+		ContextID:        contextID,
+		Origin:           origin,
+		Type:             e.Type,
+		TypeVersion:      typeVersion,
+		CreatedAt:        e.Time,
+		AggregateVersion: aggregateVersion,
+		Metadata:         metadata,
+		Data:             e.Data,
+	}, nil
+}
+
+// aggregateVersion returns the value of the aggregateversion extension attribute, an integer or its string form
+func (e Event) aggregateVersion() (int, error) {
+	switch v := e.Extensions[AggregateVersionExtension].(type) {
+	case int32:
+		return int(v), nil
+	case string:
+		version, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("%s %q: %w", AggregateVersionExtension, v, ErrNotBankEvent)
+		}
+
+		return int(version), nil
+	default:
+		return 0, fmt.Errorf("%s %v: %w", AggregateVersionExtension, v, ErrNotBankEvent)
+	}
+}
+
+// metadata returns the event metadata carried by the extension attributes, the missing ones are left empty
+func (e Event) metadata() (eventdomain.Metadata, error) {
+	var metadata eventdomain.Metadata
+	for name, value := range map[string]*string{
+		IdempotencyKeyExtension: &metadata.IdempotencyKey,
+		ActorIDExtension:        &metadata.ActorID,
+		ActorTypeExtension:      &metadata.ActorType,
+	} {
+		v, ok := e.Extensions[name]
+		if !ok {
+			continue
+		}
+
+		if *value, ok = v.(string); !ok {
+			return eventdomain.Metadata{}, fmt.Errorf("%s %v: %w", name, v, ErrNotBankEvent)
+		}
+	}
+
+	for name, id := range map[string]*uuid.UUID{
+		CorrelationIDExtension: &metadata.CorrelationID,
+		CausationIDExtension:   &metadata.CausationID,
+	} {
+		v, ok := e.Extensions[name]
+		if !ok {
+			continue
+		}
+
+		s, _ := v.(string)
+		parsed, err := uuid.Parse(s)
+		if err != nil {
+			return eventdomain.Metadata{}, fmt.Errorf("%s %v: %w", name, v, ErrNotBankEvent)
+		}
+
+		*id = parsed
+	}
+
+	return metadata, nil
+}
+
+// Validate checks if the event conforms to the CloudEvents 1.0 specification
+func (e Event) Validate() error {
+	if e.SpecVersion != SpecVersion {
+		return fmt.Errorf("validating cloud event %s: specversion %q: %w", e.ID, e.SpecVersion, ErrUnsupportedSpecVersion)
+	}
+
+	if e.ID == "" || e.Source == "" || e.Type == "" {
+		return fmt.Errorf("validating cloud event: id, source and type are required: %w", ErrInvalidEvent)
+	}
+
+	if e.DataContentType != "" {
+		if _, _, err := mime.ParseMediaType(e.DataContentType); err != nil {
+			return fmt.Errorf("validating cloud event %s: datacontenttype %q: %w", e.ID, e.DataContentType, ErrInvalidEvent)
+		}
+	}
+
+	if e.DataSchema != "" && !strings.Contains(e.DataSchema, ":") {
+		return fmt.Errorf("validating cloud event %s: dataschema %q isn't absolute URI: %w", e.ID, e.DataSchema, ErrInvalidEvent)
+	}
+
+	for name, value := range e.Extensions {
+		if !attributeName.MatchString(name) || contextAttributes[name] {
+			return fmt.Errorf("validating cloud event %s: extension attribute name %q: %w", e.ID, name, ErrInvalidEvent)
+		}
+
+		switch value.(type) {
+		case string, int32, bool:
+		default:
+			return fmt.Errorf("validating cloud event %s: extension attribute %s of %T type: %w", e.ID, name, value, ErrInvalidEvent)
+		}
+	}
+
+	return nil
+}
+
+// isJSON checks if the data of the content type is JSON, the event without the content type carries JSON data
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == JSONContentType || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isText checks if the data of the content type is text
+func isText(contentType string) bool {
+	if isJSON(contentType) {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml")
+}
@@ -0,0 +1,17 @@
+package cloudevent
+
+import (
+	"errors"
+)
+
+// CloudEvent errors
+var (
+	// ErrInvalidEvent is returned when the event doesn't conform to the CloudEvents specification, i.e. its id is missing
+	ErrInvalidEvent = errors.New("invalid cloud event")
+	// ErrUnsupportedSpecVersion is returned when the event conforms to the CloudEvents specification version other than 1.0
+	ErrUnsupportedSpecVersion = errors.New("unsupported cloud event spec version")
+	// ErrUnsupportedFormat is returned when the structured event is encoded in the event format other than JSON or protobuf
+	ErrUnsupportedFormat = errors.New("unsupported cloud event format")
+	// ErrNotBankEvent is returned when the cloud event can't be mapped onto the bank event, i.e. it comes from another source
+	ErrNotBankEvent = errors.New("cloud event isn't bank event")
+)
@@ -0,0 +1,186 @@
+//go:build unit
+
+package cloudevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+func testBaseEvent() *eventdomain.BaseEvent {
+	return &eventdomain.BaseEvent{
+		ID:               uuid.New(),
+		ContextID:        uuid.New(),
+		Origin:           "account",
+		Type:             "account.funds.deposited",
+		TypeVersion:      "0.0.1",
+		CreatedAt:        time.Date(2025, 6, 1, 12, 30, 15, 123456000, time.UTC),
+		AggregateVersion: 7,
+		Data:             []byte(`{"amount":"10.50","currency":"EUR"}`),
+	}
+}
+
+func TestFromBaseEvent(t *testing.T) {
+	ev := testBaseEvent()
+
+	e := FromBaseEvent(ev)
+	require.NoError(t, e.Validate())
+	require.Equal(t, ev.ID.String(), e.ID)
+	require.Equal(t, "/ddd-bank/account", e.Source)
+	require.Equal(t, SpecVersion, e.SpecVersion)
+	require.Equal(t, "account.funds.deposited", e.Type)
+	require.Equal(t, "urn:ddd-bank:schema:account.funds.deposited:0.0.1", e.DataSchema)
+	require.Equal(t, ev.ContextID.String(), e.Subject)
+	require.Equal(t, ev.CreatedAt, e.Time)
+	require.Equal(t, JSONContentType, e.DataContentType)
+	require.Equal(t, int32(7), e.Extensions[AggregateVersionExtension])
+	require.Equal(t, ev.Data, e.Data)
+}
+
+func TestEvent_BaseEvent_RoundTrip(t *testing.T) {
+	withoutData := testBaseEvent()
+	withoutData.Data = nil
+
+	withMetadata := testBaseEvent()
+	withMetadata.Metadata = eventdomain.Metadata{
+		CorrelationID:  uuid.New(),
+		CausationID:    uuid.New(),
+		ActorID:        "user-1",
+		ActorType:      eventdomain.ActorTypeUser,
+		IdempotencyKey: "5f0c6f3e-deposit-1",
+	}
+
+	type testCaseParams struct {
+		event  *eventdomain.BaseEvent
+		mode   Mode
+		format string
+	}
+
+	tests := []struct {
+		name   string
+		params testCaseParams
+	}{
+		{
+			name:   "should carry event in structured mode - JSON format",
+			params: testCaseParams{event: testBaseEvent(), mode: StructuredMode, format: JSONFormat},
+		},
+		{
+			name:   "should carry event in structured mode - protobuf format",
+			params: testCaseParams{event: testBaseEvent(), mode: StructuredMode, format: ProtobufFormat},
+		},
+		{
+			name:   "should carry event in binary mode",
+			params: testCaseParams{event: testBaseEvent(), mode: BinaryMode},
+		},
+		{
+			name:   "should carry metadata in structured mode",
+			params: testCaseParams{event: withMetadata, mode: StructuredMode, format: JSONFormat},
+		},
+		{
+			name:   "should carry metadata in binary mode",
+			params: testCaseParams{event: withMetadata, mode: BinaryMode},
+		},
+		{
+			name:   "should carry event without data in structured mode",
+			params: testCaseParams{event: withoutData, mode: StructuredMode, format: JSONFormat},
+		},
+		{
+			name:   "should carry event without data in binary mode",
+			params: testCaseParams{event: withoutData, mode: BinaryMode},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, body, err := EncodeHTTP(FromBaseEvent(tt.params.event), tt.params.mode, tt.params.format)
+			require.NoError(t, err)
+
+			e, err := DecodeHTTP(header, body)
+			require.NoError(t, err)
+
+			ev, err := e.BaseEvent()
+			require.NoError(t, err)
+			require.Equal(t, tt.params.event, ev)
+		})
+	}
+}
+
+func TestEncodeHTTP_Headers(t *testing.T) {
+	ev := testBaseEvent()
+
+	header, body, err := EncodeHTTP(FromBaseEvent(ev), StructuredMode, JSONFormat)
+	require.NoError(t, err)
+	require.Equal(t, JSONFormat, header.Get("Content-Type"))
+	require.Contains(t, string(body), `"specversion":"1.0"`)
+
+	header, body, err = EncodeHTTP(FromBaseEvent(ev), BinaryMode, "")
+	require.NoError(t, err)
+	require.Equal(t, JSONContentType, header.Get("Content-Type"))
+	require.Equal(t, ev.ID.String(), header.Get("ce-id"))
+	require.Equal(t, "/ddd-bank/account", header.Get("ce-source"))
+	require.Equal(t, "1.0", header.Get("ce-specversion"))
+	require.Equal(t, "account.funds.deposited", header.Get("ce-type"))
+	require.Equal(t, "7", header.Get("ce-aggregateversion"))
+	require.Equal(t, "2025-06-01T12:30:15.123456Z", header.Get("ce-time"))
+	require.Equal(t, ev.Data, body)
+
+	_, _, err = EncodeHTTP(FromBaseEvent(ev), StructuredMode, "application/cloudevents+avro")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestEvent_BaseEvent_NotBankEvent(t *testing.T) {
+	type testCaseParams struct {
+		change func(e *Event)
+	}
+
+	type testCaseExpected struct {
+		err error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name:     "shouldn't map event - id isn't UUID",
+			params:   testCaseParams{change: func(e *Event) { e.ID = "A234-1234-1234" }},
+			expected: testCaseExpected{err: ErrNotBankEvent},
+		},
+		{
+			name:     "shouldn't map event - source of another service",
+			params:   testCaseParams{change: func(e *Event) { e.Source = "/mycontext" }},
+			expected: testCaseExpected{err: ErrNotBankEvent},
+		},
+		{
+			name:     "shouldn't map event - dataschema of another type",
+			params:   testCaseParams{change: func(e *Event) { e.DataSchema = DataSchemaPrefix + "account.created:0.0.1" }},
+			expected: testCaseExpected{err: ErrNotBankEvent},
+		},
+		{
+			name:     "shouldn't map event - aggregate version missing",
+			params:   testCaseParams{change: func(e *Event) { delete(e.Extensions, AggregateVersionExtension) }},
+			expected: testCaseExpected{err: ErrNotBankEvent},
+		},
+		{
+			name:     "shouldn't map event - spec version 0.3",
+			params:   testCaseParams{change: func(e *Event) { e.SpecVersion = "0.3" }},
+			expected: testCaseExpected{err: ErrUnsupportedSpecVersion},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := FromBaseEvent(testBaseEvent())
+			tt.params.change(&e)
+
+			_, err := e.BaseEvent()
+			require.ErrorIs(t, err, tt.expected.err)
+		})
+	}
+}
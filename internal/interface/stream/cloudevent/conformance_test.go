@@ -0,0 +1,279 @@
+//go:build unit
+
+package cloudevent
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Test_Conformance_JSONFormat decodes the events of the JSON event format, the valid ones are carried through every format
+// and mode unchanged, the invalid ones are rejected
+func Test_Conformance_JSONFormat(t *testing.T) {
+	valid, err := filepath.Glob(filepath.Join("testdata", "conformance", "valid", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, valid)
+
+	for _, path := range valid {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			e, err := DecodeJSON(data)
+			require.NoError(t, err)
+
+			encoded, err := EncodeJSON(e)
+			require.NoError(t, err)
+
+			decoded, err := DecodeJSON(encoded)
+			require.NoError(t, err)
+			require.Equal(t, e, decoded)
+
+			encoded, err = EncodeProtobuf(e)
+			require.NoError(t, err)
+
+			decoded, err = DecodeProtobuf(encoded)
+			require.NoError(t, err)
+			require.Equal(t, e, decoded)
+		})
+	}
+
+	invalid, err := filepath.Glob(filepath.Join("testdata", "conformance", "invalid", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, invalid)
+
+	for _, path := range invalid {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			_, err = DecodeJSON(data)
+			require.Error(t, err)
+		})
+	}
+}
+
+func Test_Conformance_JSONFormat_SpecExample(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "conformance", "valid", "spec_json_data.json"))
+	require.NoError(t, err)
+
+	e, err := DecodeJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, "A234-1234-1234", e.ID)
+	require.Equal(t, "/mycontext", e.Source)
+	require.Equal(t, "com.example.someevent", e.Type)
+	require.Equal(t, time.Date(2018, 4, 5, 17, 31, 0, 0, time.UTC), e.Time)
+	require.Equal(t, map[string]any{"comexampleextension1": "value", "comexampleothervalue": int32(5)}, e.Extensions)
+	require.JSONEq(t, `{"appinfoA": "abc", "appinfoB": 123, "appinfoC": true}`, string(e.Data))
+
+	data, err = os.ReadFile(filepath.Join("testdata", "conformance", "valid", "spec_data_base64.json"))
+	require.NoError(t, err)
+
+	e, err = DecodeJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, e.Data)
+	require.NotContains(t, e.Extensions, "unsetextension")
+
+	// The data of other content types than JSON is carried base64 encoded
+	encoded, err := EncodeJSON(e)
+	require.NoError(t, err)
+
+	var members map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(encoded, &members))
+	require.JSONEq(t, `"AQIDBAUGBwg="`, string(members["data_base64"]))
+	require.NotContains(t, members, "data")
+}
+
+func Test_Conformance_HTTPBinaryMode(t *testing.T) {
+	header := http.Header{}
+	header.Set("ce-specversion", "1.0")
+	header.Set("ce-type", "com.example.someevent")
+	header.Set("ce-time", "2018-04-05T03:56:24Z")
+	header.Set("ce-id", "1234-1234-1234")
+	header.Set("ce-source", "/mycontext/subcontext")
+	header.Set("ce-comexampleextension1", "Euro %E2%82%AC %F0%9F%98%80")
+	header.Set("Content-Type", "application/json; charset=utf-8")
+
+	e, err := DecodeHTTP(header, []byte(`{"appinfoA": "abc"}`))
+	require.NoError(t, err)
+	require.Equal(t, "1234-1234-1234", e.ID)
+	require.Equal(t, "/mycontext/subcontext", e.Source)
+	require.Equal(t, "application/json; charset=utf-8", e.DataContentType)
+	require.Equal(t, time.Date(2018, 4, 5, 3, 56, 24, 0, time.UTC), e.Time)
+	require.Equal(t, "Euro € 😀", e.Extensions["comexampleextension1"])
+
+	// The header values are percent-encoded back
+	encodedHeader, body, err := EncodeHTTP(e, BinaryMode, "")
+	require.NoError(t, err)
+	require.Equal(t, "Euro%20%E2%82%AC%20%F0%9F%98%80", encodedHeader.Get("ce-comexampleextension1"))
+	require.Equal(t, "application/json; charset=utf-8", encodedHeader.Get("Content-Type"))
+	require.JSONEq(t, `{"appinfoA": "abc"}`, string(body))
+
+	// The message without the attributes isn't an event
+	_, err = DecodeHTTP(http.Header{"Content-Type": []string{"application/json"}}, []byte(`{}`))
+	require.ErrorIs(t, err, ErrUnsupportedSpecVersion)
+
+	_, err = DecodeHTTP(http.Header{"Content-Type": []string{"application/cloudevents-batch+json"}}, []byte(`[]`))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+// cloudEventDescriptor describes the CloudEvent message of the protobuf event format (cloudevents.proto)
+func cloudEventDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, oneof *int32) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:       proto.String(name),
+			JsonName:   proto.String(name),
+			Number:     proto.Int32(number),
+			Label:      descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:       typ.Enum(),
+			OneofIndex: oneof,
+		}
+	}
+	message := func(f *descriptorpb.FieldDescriptorProto, typeName string) *descriptorpb.FieldDescriptorProto {
+		f.TypeName = proto.String(typeName)
+		return f
+	}
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("cloudevents.proto"),
+		Package:    proto.String("io.cloudevents.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("CloudEvent"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+					field("source", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+					field("spec_version", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+					field("type", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+					{
+						Name:     proto.String("attributes"),
+						JsonName: proto.String("attributes"),
+						Number:   proto.Int32(5),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".io.cloudevents.v1.CloudEvent.AttributesEntry"),
+					},
+					field("binary_data", 6, descriptorpb.FieldDescriptorProto_TYPE_BYTES, proto.Int32(0)),
+					field("text_data", 7, descriptorpb.FieldDescriptorProto_TYPE_STRING, proto.Int32(0)),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("AttributesEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+							message(field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, nil), ".io.cloudevents.v1.CloudEvent.CloudEventAttributeValue"),
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+					{
+						Name: proto.String("CloudEventAttributeValue"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("ce_boolean", 1, descriptorpb.FieldDescriptorProto_TYPE_BOOL, proto.Int32(0)),
+							field("ce_integer", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, proto.Int32(0)),
+							field("ce_string", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, proto.Int32(0)),
+							field("ce_bytes", 4, descriptorpb.FieldDescriptorProto_TYPE_BYTES, proto.Int32(0)),
+							field("ce_uri", 5, descriptorpb.FieldDescriptorProto_TYPE_STRING, proto.Int32(0)),
+							field("ce_uri_ref", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, proto.Int32(0)),
+							message(field("ce_timestamp", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, proto.Int32(0)), ".google.protobuf.Timestamp"),
+						},
+						OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("attr")}},
+					},
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("data")}},
+			},
+		},
+	}
+
+	// The timestamp message is resolved from the global registry
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	return fd.Messages().ByName("CloudEvent")
+}
+
+func Test_Conformance_ProtobufFormat(t *testing.T) {
+	descriptor := cloudEventDescriptor(t)
+
+	e := Event{
+		ID:              "A234-1234-1234",
+		Source:          "/mycontext",
+		SpecVersion:     SpecVersion,
+		Type:            "com.example.someevent",
+		DataContentType: "application/json",
+		DataSchema:      "urn:example:schema:someevent",
+		Subject:         "larger-context",
+		Time:            time.Date(2018, 4, 5, 17, 31, 0, 500, time.UTC),
+		Extensions:      map[string]any{"comexampleextension1": "value", "comexampleothervalue": int32(-5), "comexampleflag": true},
+		Data:            []byte(`{"appinfoA":"abc"}`),
+	}
+
+	// The encoded event is read by the protobuf runtime as the CloudEvent message
+	encoded, err := EncodeProtobuf(e)
+	require.NoError(t, err)
+
+	msg := dynamicpb.NewMessage(descriptor)
+	require.NoError(t, proto.Unmarshal(encoded, msg))
+
+	fields := descriptor.Fields()
+	require.Equal(t, e.ID, msg.Get(fields.ByName("id")).String())
+	require.Equal(t, e.Source, msg.Get(fields.ByName("source")).String())
+	require.Equal(t, e.SpecVersion, msg.Get(fields.ByName("spec_version")).String())
+	require.Equal(t, e.Type, msg.Get(fields.ByName("type")).String())
+	require.Equal(t, string(e.Data), msg.Get(fields.ByName("text_data")).String())
+
+	attributes := msg.Get(fields.ByName("attributes")).Map()
+	require.Equal(t, 7, attributes.Len())
+
+	attribute := func(name, oneof string) protoreflect.Value {
+		value := attributes.Get(protoreflect.ValueOfString(name).MapKey()).Message()
+		return value.Get(value.Descriptor().Fields().ByName(protoreflect.Name(oneof)))
+	}
+
+	require.Equal(t, e.DataContentType, attribute("datacontenttype", "ce_string").String())
+	require.Equal(t, e.DataSchema, attribute("dataschema", "ce_uri").String())
+	require.Equal(t, e.Subject, attribute("subject", "ce_string").String())
+	require.Equal(t, "value", attribute("comexampleextension1", "ce_string").String())
+	require.Equal(t, int64(-5), attribute("comexampleothervalue", "ce_integer").Int())
+	require.True(t, attribute("comexampleflag", "ce_boolean").Bool())
+
+	timestamp := attribute("time", "ce_timestamp").Message()
+	require.Equal(t, e.Time.Unix(), timestamp.Get(timestamp.Descriptor().Fields().ByName("seconds")).Int())
+	require.Equal(t, int64(500), timestamp.Get(timestamp.Descriptor().Fields().ByName("nanos")).Int())
+
+	// The CloudEvent message encoded by the protobuf runtime is decoded into the same event
+	marshaled, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	require.NoError(t, err)
+
+	decoded, err := DecodeProtobuf(marshaled)
+	require.NoError(t, err)
+	require.Equal(t, e, decoded)
+
+	// The binary data is carried by binary_data
+	e.DataContentType = "application/octet-stream"
+	e.Data = []byte{0, 1, 2}
+
+	encoded, err = EncodeProtobuf(e)
+	require.NoError(t, err)
+
+	msg = dynamicpb.NewMessage(descriptor)
+	require.NoError(t, proto.Unmarshal(encoded, msg))
+	require.Equal(t, e.Data, msg.Get(fields.ByName("binary_data")).Bytes())
+
+	_, err = DecodeProtobuf([]byte{0x0a, 0xff})
+	require.ErrorIs(t, err, ErrInvalidEvent)
+}
@@ -0,0 +1,189 @@
+package cloudevent
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mode is the content mode of the HTTP message carrying the event
+type Mode int
+
+const (
+	// StructuredMode carries the whole event in the body encoded in the event format, i.e. JSON
+	StructuredMode Mode = iota
+	// BinaryMode carries the event attributes in the ce- prefixed headers and the data in the body
+	BinaryMode
+)
+
+// Event formats of the structured mode, they are the content types of the HTTP message
+const (
+	JSONFormat     = "application/cloudevents+json"
+	ProtobufFormat = "application/cloudevents+protobuf"
+)
+
+// headerPrefix prefixes the names of the headers carrying the event attributes in the binary mode
+const headerPrefix = "ce-"
+
+// EncodeHTTP encodes the event into the headers and the body of the HTTP message. The structured mode encodes the event
+// in the format, JSONFormat or ProtobufFormat, the binary mode ignores the format and carries the data as it is.
+func EncodeHTTP(e Event, mode Mode, format string) (http.Header, []byte, error) {
+	header := http.Header{}
+
+	switch mode {
+	case StructuredMode:
+		var body []byte
+		var err error
+
+		switch format {
+		case JSONFormat:
+			body, err = EncodeJSON(e)
+		case ProtobufFormat:
+			body, err = EncodeProtobuf(e)
+		default:
+			return nil, nil, fmt.Errorf("encoding cloud event %s in %q format: %w", e.ID, format, ErrUnsupportedFormat)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		header.Set("Content-Type", format)
+
+		return header, body, nil
+	case BinaryMode:
+		if err := e.Validate(); err != nil {
+			return nil, nil, err
+		}
+
+		setHeader(header, "id", e.ID)
+		setHeader(header, "source", e.Source)
+		setHeader(header, "specversion", e.SpecVersion)
+		setHeader(header, "type", e.Type)
+		setHeader(header, "dataschema", e.DataSchema)
+		setHeader(header, "subject", e.Subject)
+
+		if !e.Time.IsZero() {
+			setHeader(header, "time", e.Time.Format(time.RFC3339Nano))
+		}
+
+		for name, value := range e.Extensions {
+			switch v := value.(type) {
+			case string:
+				setHeader(header, name, v)
+			case int32:
+				setHeader(header, name, strconv.Itoa(int(v)))
+			case bool:
+				setHeader(header, name, strconv.FormatBool(v))
+			}
+		}
+
+		if e.DataContentType != "" {
+			header.Set("Content-Type", e.DataContentType)
+		}
+
+		return header, e.Data, nil
+	default:
+		return nil, nil, fmt.Errorf("encoding cloud event %s in %d mode: %w", e.ID, mode, ErrUnsupportedFormat)
+	}
+}
+
+// DecodeHTTP decodes the event from the headers and the body of the HTTP message, the mode is recognized by the content type:
+// the cloud event formats are structured, any other content type is binary
+func DecodeHTTP(header http.Header, body []byte) (Event, error) {
+	contentType := header.Get("Content-Type")
+
+	mediaType := ""
+	if contentType != "" {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return Event{}, fmt.Errorf("decoding cloud event: content type %q: %w", contentType, ErrInvalidEvent)
+		}
+
+		mediaType = parsed
+	}
+
+	switch {
+	case mediaType == JSONFormat:
+		return DecodeJSON(body)
+	case mediaType == ProtobufFormat:
+		return DecodeProtobuf(body)
+	case strings.HasPrefix(mediaType, "application/cloudevents"):
+		return Event{}, fmt.Errorf("decoding cloud event in %q format: %w", mediaType, ErrUnsupportedFormat)
+	}
+
+	e := Event{DataContentType: contentType}
+
+	if len(body) > 0 {
+		e.Data = body
+	}
+
+	for key, values := range header {
+		name, ok := strings.CutPrefix(strings.ToLower(key), headerPrefix)
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		value, err := url.PathUnescape(values[0])
+		if err != nil {
+			return Event{}, fmt.Errorf("decoding cloud event: %s header: %w: %w", key, ErrInvalidEvent, err)
+		}
+
+		switch name {
+		case "id":
+			e.ID = value
+		case "source":
+			e.Source = value
+		case "specversion":
+			e.SpecVersion = value
+		case "type":
+			e.Type = value
+		case "dataschema":
+			e.DataSchema = value
+		case "subject":
+			e.Subject = value
+		case "time":
+			e.Time, err = time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return Event{}, fmt.Errorf("decoding cloud event: %s header: %w: %w", key, ErrInvalidEvent, err)
+			}
+		default:
+			if e.Extensions == nil {
+				e.Extensions = make(map[string]any)
+			}
+
+			e.Extensions[name] = value
+		}
+	}
+
+	if err := e.Validate(); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+// setHeader sets the header carrying the attribute in the binary mode, the value is percent-encoded as the HTTP binding requires:
+// the space, the double quote, the percent sign and the characters outside of printable ASCII are encoded
+func setHeader(header http.Header, name, value string) {
+	if value == "" {
+		return
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c <= ' ' || c >= 0x7f || c == '"' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	header.Set(headerPrefix+name, b.String())
+}
@@ -0,0 +1,185 @@
+package cloudevent
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"time"
+)
+
+// EncodeJSON encodes the event in the JSON event format. The JSON data is embedded in the data member as it is,
+// the data of other content types is carried base64 encoded by the data_base64 member.
+func EncodeJSON(e Event) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]any, len(e.Extensions)+10)
+	maps.Copy(members, e.Extensions)
+
+	members["id"] = e.ID
+	members["source"] = e.Source
+	members["specversion"] = e.SpecVersion
+	members["type"] = e.Type
+
+	if e.DataContentType != "" {
+		members["datacontenttype"] = e.DataContentType
+	}
+
+	if e.DataSchema != "" {
+		members["dataschema"] = e.DataSchema
+	}
+
+	if e.Subject != "" {
+		members["subject"] = e.Subject
+	}
+
+	if !e.Time.IsZero() {
+		members["time"] = e.Time.Format(time.RFC3339Nano)
+	}
+
+	if e.Data != nil {
+		if isJSON(e.DataContentType) {
+			if !json.Valid(e.Data) {
+				return nil, fmt.Errorf("encoding cloud event %s: data isn't valid JSON: %w", e.ID, ErrInvalidEvent)
+			}
+
+			members["data"] = json.RawMessage(e.Data)
+		} else {
+			members["data_base64"] = base64.StdEncoding.EncodeToString(e.Data)
+		}
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return nil, fmt.Errorf("encoding cloud event %s: %w", e.ID, err)
+	}
+
+	return data, nil
+}
+
+// DecodeJSON decodes the event from the JSON event format
+func DecodeJSON(data []byte) (Event, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return Event{}, fmt.Errorf("decoding cloud event: %w: %w", ErrInvalidEvent, err)
+	}
+
+	e := Event{}
+
+	for name, value := range members {
+		if bytes.Equal(value, []byte("null")) {
+			continue
+		}
+
+		var err error
+
+		switch name {
+		case "id":
+			err = json.Unmarshal(value, &e.ID)
+		case "source":
+			err = json.Unmarshal(value, &e.Source)
+		case "specversion":
+			err = json.Unmarshal(value, &e.SpecVersion)
+		case "type":
+			err = json.Unmarshal(value, &e.Type)
+		case "datacontenttype":
+			err = json.Unmarshal(value, &e.DataContentType)
+		case "dataschema":
+			err = json.Unmarshal(value, &e.DataSchema)
+		case "subject":
+			err = json.Unmarshal(value, &e.Subject)
+		case "time":
+			e.Time, err = decodeJSONTime(value)
+		case "data", "data_base64":
+			// The data is decoded once its content type is known
+		default:
+			if e.Extensions == nil {
+				e.Extensions = make(map[string]any)
+			}
+
+			e.Extensions[name], err = decodeJSONExtension(value)
+		}
+
+		if err != nil {
+			return Event{}, fmt.Errorf("decoding cloud event: %s member: %w: %w", name, ErrInvalidEvent, err)
+		}
+	}
+
+	payload, hasData := members["data"]
+	encoded, hasBase64 := members["data_base64"]
+
+	switch {
+	case hasData && hasBase64:
+		return Event{}, fmt.Errorf("decoding cloud event %s: both data and data_base64 members: %w", e.ID, ErrInvalidEvent)
+	case hasData && !bytes.Equal(payload, []byte("null")):
+		if isJSON(e.DataContentType) {
+			// The embedded JSON data is compacted, so the same data is decoded the same way regardless of its formatting
+			var compacted bytes.Buffer
+			if err := json.Compact(&compacted, payload); err != nil {
+				return Event{}, fmt.Errorf("decoding cloud event %s: data member: %w: %w", e.ID, ErrInvalidEvent, err)
+			}
+
+			e.Data = compacted.Bytes()
+		} else {
+			var text string
+			if err := json.Unmarshal(payload, &text); err != nil {
+				return Event{}, fmt.Errorf("decoding cloud event %s: data of %s content type isn't string: %w", e.ID, e.DataContentType, ErrInvalidEvent)
+			}
+
+			e.Data = []byte(text)
+		}
+	case hasBase64 && !bytes.Equal(encoded, []byte("null")):
+		var text string
+		if err := json.Unmarshal(encoded, &text); err != nil {
+			return Event{}, fmt.Errorf("decoding cloud event %s: data_base64 member: %w", e.ID, ErrInvalidEvent)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return Event{}, fmt.Errorf("decoding cloud event %s: data_base64 member: %w: %w", e.ID, ErrInvalidEvent, err)
+		}
+
+		e.Data = decoded
+	}
+
+	if err := e.Validate(); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+// decodeJSONTime decodes the RFC 3339 timestamp
+func decodeJSONTime(value json.RawMessage) (time.Time, error) {
+	var text string
+	if err := json.Unmarshal(value, &text); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse(time.RFC3339Nano, text)
+}
+
+// decodeJSONExtension decodes the value of the extension attribute, the JSON number is an integer of the int32 range
+func decodeJSONExtension(value json.RawMessage) (any, error) {
+	var v any
+	if err := json.Unmarshal(value, &v); err != nil {
+		return nil, err
+	}
+
+	switch v := v.(type) {
+	case string, bool:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt32 || v > math.MaxInt32 {
+			return nil, fmt.Errorf("%v isn't integer", v)
+		}
+
+		return int32(v), nil
+	default:
+		return nil, fmt.Errorf("%T value isn't allowed", v)
+	}
+}
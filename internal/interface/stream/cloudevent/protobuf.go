@@ -0,0 +1,269 @@
+package cloudevent
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the CloudEvent message of the protobuf event format (cloudevents.proto)
+const (
+	protoID          protowire.Number = 1
+	protoSource      protowire.Number = 2
+	protoSpecVersion protowire.Number = 3
+	protoType        protowire.Number = 4
+	protoAttributes  protowire.Number = 5
+	protoBinaryData  protowire.Number = 6
+	protoTextData    protowire.Number = 7
+	protoProtoData   protowire.Number = 8
+)
+
+// Field numbers of the CloudEventAttributeValue message, the attribute value is one of them
+const (
+	protoBoolean   protowire.Number = 1
+	protoInteger   protowire.Number = 2
+	protoString    protowire.Number = 3
+	protoBytes     protowire.Number = 4
+	protoURI       protowire.Number = 5
+	protoURIRef    protowire.Number = 6
+	protoTimestamp protowire.Number = 7
+)
+
+// EncodeProtobuf encodes the event in the protobuf event format. The text data, i.e. JSON, is carried by text_data,
+// the data of other content types by binary_data.
+func EncodeProtobuf(e Event) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	b = appendString(b, protoID, e.ID)
+	b = appendString(b, protoSource, e.Source)
+	b = appendString(b, protoSpecVersion, e.SpecVersion)
+	b = appendString(b, protoType, e.Type)
+
+	attributes := make(map[string][]byte, len(e.Extensions)+4)
+
+	if e.DataContentType != "" {
+		attributes["datacontenttype"] = appendString(nil, protoString, e.DataContentType)
+	}
+
+	if e.DataSchema != "" {
+		attributes["dataschema"] = appendString(nil, protoURI, e.DataSchema)
+	}
+
+	if e.Subject != "" {
+		attributes["subject"] = appendString(nil, protoString, e.Subject)
+	}
+
+	if !e.Time.IsZero() {
+		var timestamp []byte
+		timestamp = protowire.AppendTag(timestamp, 1, protowire.VarintType)
+		timestamp = protowire.AppendVarint(timestamp, uint64(e.Time.Unix()))
+		timestamp = protowire.AppendTag(timestamp, 2, protowire.VarintType)
+		timestamp = protowire.AppendVarint(timestamp, uint64(e.Time.Nanosecond()))
+
+		attributes["time"] = appendBytes(nil, protoTimestamp, timestamp)
+	}
+
+	for name, value := range e.Extensions {
+		switch v := value.(type) {
+		case string:
+			attributes[name] = appendString(nil, protoString, v)
+		case int32:
+			attributes[name] = protowire.AppendVarint(protowire.AppendTag(nil, protoInteger, protowire.VarintType), uint64(int64(v)))
+		case bool:
+			attributes[name] = protowire.AppendVarint(protowire.AppendTag(nil, protoBoolean, protowire.VarintType), protowire.EncodeBool(v))
+		}
+	}
+
+	// The attributes are written in the order of their names, so the same event is always encoded the same way
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		var entry []byte
+		entry = appendString(entry, 1, name)
+		entry = appendBytes(entry, 2, attributes[name])
+
+		b = appendBytes(b, protoAttributes, entry)
+	}
+
+	if e.Data != nil {
+		if isText(e.DataContentType) {
+			b = appendBytes(b, protoTextData, e.Data)
+		} else {
+			b = appendBytes(b, protoBinaryData, e.Data)
+		}
+	}
+
+	return b, nil
+}
+
+// DecodeProtobuf decodes the event from the protobuf event format, the data carried by proto_data isn't supported
+func DecodeProtobuf(data []byte) (Event, error) {
+	e := Event{}
+
+	err := consumeFields(data, func(num protowire.Number, _ protowire.Type, value []byte) error {
+		switch num {
+		case protoID:
+			e.ID = string(value)
+		case protoSource:
+			e.Source = string(value)
+		case protoSpecVersion:
+			e.SpecVersion = string(value)
+		case protoType:
+			e.Type = string(value)
+		case protoAttributes:
+			return e.decodeProtobufAttribute(value)
+		case protoBinaryData, protoTextData:
+			e.Data = bytes.Clone(value)
+		case protoProtoData:
+			return fmt.Errorf("proto_data isn't supported: %w", ErrUnsupportedFormat)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Event{}, fmt.Errorf("decoding cloud event: %w", err)
+	}
+
+	if err := e.Validate(); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+// decodeProtobufAttribute decodes the entry of the attributes map into the optional or the extension attribute
+func (e *Event) decodeProtobufAttribute(entry []byte) error {
+	var name string
+	var value any
+
+	err := consumeFields(entry, func(num protowire.Number, _ protowire.Type, field []byte) error {
+		switch num {
+		case 1:
+			name = string(field)
+		case 2:
+			return consumeFields(field, func(num protowire.Number, _ protowire.Type, field []byte) error {
+				switch num {
+				case protoBoolean:
+					value = protowire.DecodeBool(decodeVarint(field))
+				case protoInteger:
+					value = int32(decodeVarint(field))
+				case protoString, protoURI, protoURIRef:
+					value = string(field)
+				case protoBytes:
+					value = base64.StdEncoding.EncodeToString(field)
+				case protoTimestamp:
+					var seconds, nanos uint64
+					err := consumeFields(field, func(num protowire.Number, _ protowire.Type, field []byte) error {
+						switch num {
+						case 1:
+							seconds = decodeVarint(field)
+						case 2:
+							nanos = decodeVarint(field)
+						}
+
+						return nil
+					})
+					if err != nil {
+						return err
+					}
+
+					value = time.Unix(int64(seconds), int64(nanos)).UTC()
+				}
+
+				return nil
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "datacontenttype":
+		e.DataContentType, _ = value.(string)
+	case "dataschema":
+		e.DataSchema, _ = value.(string)
+	case "subject":
+		e.Subject, _ = value.(string)
+	case "time":
+		t, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("time attribute isn't timestamp: %w", ErrInvalidEvent)
+		}
+
+		e.Time = t
+	default:
+		// The extension attribute of the timestamp type is kept in its string form
+		if t, ok := value.(time.Time); ok {
+			value = t.Format(time.RFC3339Nano)
+		}
+
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]any)
+		}
+
+		e.Extensions[name] = value
+	}
+
+	return nil
+}
+
+// consumeFields calls fn with each field of the message, the varint fields are passed in their encoded form
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("%w: %w", ErrInvalidEvent, protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		var value []byte
+
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return fmt.Errorf("%w: %w", ErrInvalidEvent, protowire.ParseError(n))
+			}
+
+			value, b = v, b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return fmt.Errorf("%w: %w", ErrInvalidEvent, protowire.ParseError(n))
+			}
+
+			value, b = b[:n], b[n:]
+		}
+
+		if err := fn(num, typ, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeVarint decodes the varint field passed by consumeFields
+func decodeVarint(b []byte) uint64 {
+	v, _ := protowire.ConsumeVarint(b)
+	return v
+}
+
+// appendString appends the string field to the message
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	return protowire.AppendString(protowire.AppendTag(b, num, protowire.BytesType), v)
+}
+
+// appendBytes appends the bytes or the embedded message field to the message
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	return protowire.AppendBytes(protowire.AppendTag(b, num, protowire.BytesType), v)
+}
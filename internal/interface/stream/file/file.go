@@ -0,0 +1,49 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/stream/cloudevent"
+)
+
+// Publisher publishes the events as JSON lines written to a file or the standard output,
+// each line is the cloud event in the JSON event format
+type Publisher struct {
+	name string
+	mu   sync.Mutex
+	w    io.Writer
+}
+
+// NewPublisher creates a new publisher writing the events to w, the published events are checkpointed under the name
+func NewPublisher(name string, w io.Writer) *Publisher {
+	return &Publisher{
+		name: name,
+		w:    w,
+	}
+}
+
+// Name returns the name of the publisher
+func (p *Publisher) Name() string {
+	return p.name
+}
+
+// Publish writes the cloud event followed by a new line
+func (p *Publisher) Publish(_ context.Context, event *eventdomain.BaseEvent) error {
+	message, err := cloudevent.EncodeJSON(cloudevent.FromBaseEvent(event))
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.w.Write(append(message, '\n')); err != nil {
+		return fmt.Errorf("writing %s event %s: %w", event.Type, event.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/stream/cloudevent"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	var out bytes.Buffer
+
+	publisher := NewPublisher("stdout", &out)
+	require.Equal(t, "stdout", publisher.Name())
+
+	events := []*eventdomain.BaseEvent{
+		{
+			ID:               uuid.New(),
+			ContextID:        uuid.New(),
+			Origin:           "account",
+			Type:             "account.created",
+			TypeVersion:      "0.0.0",
+			CreatedAt:        time.Now().UTC(),
+			AggregateVersion: 1,
+			Data:             []byte(`{"initialBalance":100}`),
+		},
+		{
+			ID:               uuid.New(),
+			ContextID:        uuid.New(),
+			Origin:           "customer",
+			Type:             "customer.blocked",
+			TypeVersion:      "0.0.0",
+			CreatedAt:        time.Now().UTC(),
+			AggregateVersion: 3,
+		},
+	}
+
+	for _, ev := range events {
+		require.NoError(t, publisher.Publish(context.Background(), ev))
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, len(events))
+
+	// Each line is the cloud event in the JSON event format
+	for i, line := range lines {
+		e, err := cloudevent.DecodeJSON([]byte(line))
+		require.NoError(t, err)
+
+		ev, err := e.BaseEvent()
+		require.NoError(t, err)
+		require.Equal(t, events[i].ID, ev.ID)
+		require.Equal(t, events[i].Type, ev.Type)
+		require.Equal(t, events[i].AggregateVersion, ev.AggregateVersion)
+	}
+
+	var withData, withoutData map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &withData))
+	require.JSONEq(t, `{"initialBalance":100}`, string(withData["data"]))
+	require.JSONEq(t, `"/ddd-bank/account"`, string(withData["source"]))
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &withoutData))
+	require.NotContains(t, withoutData, "data")
+}
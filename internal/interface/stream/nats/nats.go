@@ -0,0 +1,138 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/stream/cloudevent"
+)
+
+// PublisherName is the name the events published to NATS are checkpointed under
+const PublisherName = "nats"
+
+// Config holds the configuration of the embedded NATS server
+type Config struct {
+	Host         string        // Host the clients connect to
+	Port         int           // Port the clients connect to, -1 picks a random port
+	StoreDir     string        // Directory where JetStream keeps the published events
+	Stream       string        // Name of the JetStream stream keeping the events
+	Subjects     []string      // Subjects of the events kept by the stream, the subject of an event is its type
+	Duplicates   time.Duration // Window in which the event published again, i.e. after the relay restarted, is dropped
+	StartTimeout time.Duration // How long to wait for the server to accept connections
+}
+
+// DefaultConfig returns the default configuration of the embedded NATS server
+func DefaultConfig(storeDir string) Config {
+	return Config{
+		Host:         "127.0.0.1",
+		Port:         4222,
+		StoreDir:     storeDir,
+		Stream:       "EVENTS",
+		Subjects:     []string{"account.>", "customer.>", "transaction.>"},
+		Duplicates:   2 * time.Minute,
+		StartTimeout: 10 * time.Second,
+	}
+}
+
+// Publisher publishes the events to the JetStream stream of the embedded NATS server.
+// The downstream consumers connect to the server and subscribe to the subjects, i.e. account.> or customer.>.
+type Publisher struct {
+	server *natsserver.Server
+	conn   *natsgo.Conn
+	js     jetstream.JetStream
+}
+
+// NewPublisher starts the embedded NATS server with JetStream enabled and creates the stream of the events
+func NewPublisher(ctx context.Context, config Config) (*Publisher, error) {
+	server, err := natsserver.NewServer(&natsserver.Options{
+		Host:      config.Host,
+		Port:      config.Port,
+		JetStream: true,
+		StoreDir:  config.StoreDir,
+		NoSigs:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating nats server: %w", err)
+	}
+
+	go server.Start()
+
+	if !server.ReadyForConnections(config.StartTimeout) {
+		server.Shutdown()
+		return nil, fmt.Errorf("starting nats server: not ready for connections after %s", config.StartTimeout)
+	}
+
+	conn, err := natsgo.Connect(server.ClientURL())
+	if err != nil {
+		server.Shutdown()
+		return nil, fmt.Errorf("connecting to nats server: %w", err)
+	}
+
+	p := &Publisher{
+		server: server,
+		conn:   conn,
+	}
+
+	p.js, err = jetstream.New(conn)
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("creating jetstream context: %w", err)
+	}
+
+	_, err = p.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       config.Stream,
+		Subjects:   config.Subjects,
+		Storage:    jetstream.FileStorage,
+		Duplicates: config.Duplicates,
+	})
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("creating %s stream: %w", config.Stream, err)
+	}
+
+	return p, nil
+}
+
+// ClientURL returns the URL the consumers connect to
+func (p *Publisher) ClientURL() string {
+	return p.server.ClientURL()
+}
+
+// Name returns the name of the publisher
+func (p *Publisher) Name() string {
+	return PublisherName
+}
+
+// Publish publishes the cloud event in the JSON event format with the event type as the subject. The event ID is the message ID,
+// so the event published again within the duplicates window is stored once.
+func (p *Publisher) Publish(ctx context.Context, event *eventdomain.BaseEvent) error {
+	message, err := cloudevent.EncodeJSON(cloudevent.FromBaseEvent(event))
+	if err != nil {
+		return err
+	}
+
+	msg := &natsgo.Msg{
+		Subject: event.Type,
+		Header:  natsgo.Header{"Content-Type": []string{cloudevent.JSONFormat}},
+		Data:    message,
+	}
+
+	if _, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.ID.String())); err != nil {
+		return fmt.Errorf("publishing %s event %s: %w", event.Type, event.ID, err)
+	}
+
+	return nil
+}
+
+// Close closes the connection and shuts the embedded NATS server down
+func (p *Publisher) Close() {
+	p.conn.Close()
+	p.server.Shutdown()
+	p.server.WaitForShutdown()
+}
@@ -0,0 +1,83 @@
+//go:build unit
+
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/stream/cloudevent"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	config := DefaultConfig(t.TempDir())
+	config.Port = -1
+
+	publisher, err := NewPublisher(ctx, config)
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	accountEvent := &eventdomain.BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   uuid.New(),
+		Origin:      "account",
+		Type:        "account.funds.deposited",
+		TypeVersion: "0.0.0",
+		CreatedAt:   time.Now().UTC(),
+		Data:        []byte(`{"amount":"10.50"}`),
+	}
+	customerEvent := &eventdomain.BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   uuid.New(),
+		Origin:      "customer",
+		Type:        "customer.created",
+		TypeVersion: "0.0.0",
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	require.NoError(t, publisher.Publish(ctx, accountEvent))
+	require.NoError(t, publisher.Publish(ctx, customerEvent))
+	// The event published again, i.e. after the relay restarted, is stored once
+	require.NoError(t, publisher.Publish(ctx, accountEvent))
+
+	conn, err := natsgo.Connect(publisher.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+
+	s, err := js.Stream(ctx, config.Stream)
+	require.NoError(t, err)
+
+	info, err := s.Info(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), info.State.Msgs)
+
+	consumer, err := s.OrderedConsumer(ctx, jetstream.OrderedConsumerConfig{FilterSubjects: []string{"account.>"}})
+	require.NoError(t, err)
+
+	msg, err := consumer.Next(jetstream.FetchMaxWait(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, accountEvent.Type, msg.Subject())
+
+	require.Equal(t, cloudevent.JSONFormat, msg.Headers().Get("Content-Type"))
+
+	e, err := cloudevent.DecodeJSON(msg.Data())
+	require.NoError(t, err)
+
+	ev, err := e.BaseEvent()
+	require.NoError(t, err)
+	require.Equal(t, accountEvent.ID, ev.ID)
+	require.JSONEq(t, `{"amount":"10.50"}`, string(ev.Data))
+}
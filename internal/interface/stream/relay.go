@@ -0,0 +1,125 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+// RelayConfig holds the configuration of the outbox relay
+type RelayConfig struct {
+	PollInterval time.Duration // How often the outbox is polled for the committed events
+	BatchSize    int           // Maximum number of events published between two checkpoints
+}
+
+// DefaultRelayConfig returns the default configuration of the outbox relay
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval: 1 * time.Second,
+		BatchSize:    100,
+	}
+}
+
+// validate checks if the configuration allows to run the relay
+func (c RelayConfig) validate() error {
+	if c.PollInterval <= 0 || c.BatchSize <= 0 {
+		return fmt.Errorf("validating relay config %+v: %w", c, ErrInvalidConfig)
+	}
+
+	return nil
+}
+
+// Relay publishes the committed events from the outbox through the publisher, in the order they were committed.
+// The checkpoint of the last published event is saved after each batch, so the events are delivered at least once:
+// the events published after the last saved checkpoint are published again when the relay is restarted.
+type Relay struct {
+	config     RelayConfig
+	outboxRepo OutboxRepository
+	publisher  Publisher
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(config RelayConfig, outboxRepo OutboxRepository, publisher Publisher) *Relay {
+	return &Relay{
+		config:     config,
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+	}
+}
+
+// Run polls the outbox and publishes the committed events until the context is cancelled.
+// It continues from the checkpoint of the publisher, an event which can't be published is retried with the next poll.
+func (r *Relay) Run(ctx context.Context) error {
+	if err := r.config.validate(); err != nil {
+		return err
+	}
+
+	checkpoint, err := r.outboxRepo.FindCheckpoint(ctx, r.publisher.Name())
+	if err != nil {
+		return fmt.Errorf("running %s relay: %w", r.publisher.Name(), err)
+	}
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		checkpoint = r.poll(ctx, checkpoint)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll publishes the batches of the committed events until the outbox is drained or an event can't be published,
+// it returns the checkpoint of the last published event
+func (r *Relay) poll(ctx context.Context, checkpoint eventdomain.OutboxCheckpoint) eventdomain.OutboxCheckpoint {
+	for {
+		next, published, err := r.publish(ctx, checkpoint)
+		if next != checkpoint {
+			if err := r.outboxRepo.SaveCheckpoint(ctx, r.publisher.Name(), next); err != nil && ctx.Err() == nil {
+				log.Printf("saving %s relay checkpoint: %v", r.publisher.Name(), err)
+			}
+
+			checkpoint = next
+		}
+
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("publishing outbox events: %v", err)
+			}
+
+			return checkpoint
+		}
+
+		if published < r.config.BatchSize {
+			return checkpoint
+		}
+	}
+}
+
+// publish publishes a batch of the committed events following the checkpoint, up to the first event which can't be published.
+// It returns the checkpoint of the last published event and the number of the published events.
+func (r *Relay) publish(ctx context.Context, checkpoint eventdomain.OutboxCheckpoint) (eventdomain.OutboxCheckpoint, int, error) {
+	messages, err := r.outboxRepo.FindMessages(ctx, checkpoint, r.config.BatchSize)
+	if err != nil {
+		return checkpoint, 0, err
+	}
+
+	published := 0
+	for _, message := range messages {
+		if err := r.publisher.Publish(ctx, message.Event); err != nil {
+			return checkpoint, published, fmt.Errorf("publishing %s event %s through %s: %w", message.Event.Type, message.Event.ID, r.publisher.Name(), err)
+		}
+
+		checkpoint = message.Checkpoint
+		published++
+	}
+
+	return checkpoint, published, nil
+}
@@ -0,0 +1,223 @@
+//go:build unit
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/interface/stream/mock"
+)
+
+func testRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval: 10 * time.Millisecond,
+		BatchSize:    2,
+	}
+}
+
+func testMessage(transactionID, sequenceNumber int64) eventdomain.OutboxMessage {
+	return eventdomain.OutboxMessage{
+		Checkpoint: eventdomain.OutboxCheckpoint{TransactionID: transactionID, SequenceNumber: sequenceNumber},
+		Event: &eventdomain.BaseEvent{
+			ID:        uuid.New(),
+			ContextID: uuid.New(),
+			Origin:    "account",
+			Type:      "account.created",
+			CreatedAt: time.Now().UTC(),
+			Data:      []byte(`{"initialBalance": 100}`),
+		},
+	}
+}
+
+func TestRelay_Poll(t *testing.T) {
+	first := testMessage(10, 1)
+	second := testMessage(10, 2)
+	third := testMessage(12, 3)
+
+	type testCaseParams struct {
+		checkpoint           eventdomain.OutboxCheckpoint
+		mockOutboxRepository func(ctrl *gomock.Controller) *mock.MockOutboxRepository
+		mockPublisher        func(ctrl *gomock.Controller) *mock.MockPublisher
+	}
+
+	type testCaseExpected struct {
+		checkpoint eventdomain.OutboxCheckpoint
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should publish events in batches until outbox is drained",
+			params: testCaseParams{
+				mockOutboxRepository: func(ctrl *gomock.Controller) *mock.MockOutboxRepository {
+					m := mock.NewMockOutboxRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindMessages(gomock.Any(), eventdomain.OutboxCheckpoint{}, 2).
+							Return([]eventdomain.OutboxMessage{first, second}, nil),
+						m.EXPECT().SaveCheckpoint(gomock.Any(), "file", second.Checkpoint).Return(nil),
+						m.EXPECT().FindMessages(gomock.Any(), second.Checkpoint, 2).
+							Return([]eventdomain.OutboxMessage{third}, nil),
+						m.EXPECT().SaveCheckpoint(gomock.Any(), "file", third.Checkpoint).Return(nil),
+					)
+					return m
+				},
+				mockPublisher: func(ctrl *gomock.Controller) *mock.MockPublisher {
+					m := mock.NewMockPublisher(ctrl)
+					m.EXPECT().Name().Return("file").AnyTimes()
+					gomock.InOrder(
+						m.EXPECT().Publish(gomock.Any(), first.Event).Return(nil),
+						m.EXPECT().Publish(gomock.Any(), second.Event).Return(nil),
+						m.EXPECT().Publish(gomock.Any(), third.Event).Return(nil),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				checkpoint: third.Checkpoint,
+			},
+		},
+		{
+			name: "should continue from checkpoint when outbox is empty",
+			params: testCaseParams{
+				checkpoint: second.Checkpoint,
+				mockOutboxRepository: func(ctrl *gomock.Controller) *mock.MockOutboxRepository {
+					m := mock.NewMockOutboxRepository(ctrl)
+					m.EXPECT().FindMessages(gomock.Any(), second.Checkpoint, 2).Return(nil, nil)
+					return m
+				},
+				mockPublisher: func(ctrl *gomock.Controller) *mock.MockPublisher {
+					m := mock.NewMockPublisher(ctrl)
+					m.EXPECT().Name().Return("file").AnyTimes()
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				checkpoint: second.Checkpoint,
+			},
+		},
+		{
+			name: "should save checkpoint of last published event - publishing failed",
+			params: testCaseParams{
+				mockOutboxRepository: func(ctrl *gomock.Controller) *mock.MockOutboxRepository {
+					m := mock.NewMockOutboxRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindMessages(gomock.Any(), eventdomain.OutboxCheckpoint{}, 2).
+							Return([]eventdomain.OutboxMessage{first, second}, nil),
+						m.EXPECT().SaveCheckpoint(gomock.Any(), "file", first.Checkpoint).Return(nil),
+					)
+					return m
+				},
+				mockPublisher: func(ctrl *gomock.Controller) *mock.MockPublisher {
+					m := mock.NewMockPublisher(ctrl)
+					m.EXPECT().Name().Return("file").AnyTimes()
+					gomock.InOrder(
+						m.EXPECT().Publish(gomock.Any(), first.Event).Return(nil),
+						m.EXPECT().Publish(gomock.Any(), second.Event).Return(errors.New("broker unavailable")),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				checkpoint: first.Checkpoint,
+			},
+		},
+		{
+			name: "shouldn't save checkpoint - first event not published",
+			params: testCaseParams{
+				mockOutboxRepository: func(ctrl *gomock.Controller) *mock.MockOutboxRepository {
+					m := mock.NewMockOutboxRepository(ctrl)
+					m.EXPECT().FindMessages(gomock.Any(), eventdomain.OutboxCheckpoint{}, 2).
+						Return([]eventdomain.OutboxMessage{first, second}, nil)
+					return m
+				},
+				mockPublisher: func(ctrl *gomock.Controller) *mock.MockPublisher {
+					m := mock.NewMockPublisher(ctrl)
+					m.EXPECT().Name().Return("file").AnyTimes()
+					m.EXPECT().Publish(gomock.Any(), first.Event).Return(errors.New("broker unavailable"))
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't publish events - finding outbox messages failed",
+			params: testCaseParams{
+				checkpoint: first.Checkpoint,
+				mockOutboxRepository: func(ctrl *gomock.Controller) *mock.MockOutboxRepository {
+					m := mock.NewMockOutboxRepository(ctrl)
+					m.EXPECT().FindMessages(gomock.Any(), first.Checkpoint, 2).Return(nil, errors.New("db error"))
+					return m
+				},
+				mockPublisher: func(ctrl *gomock.Controller) *mock.MockPublisher {
+					m := mock.NewMockPublisher(ctrl)
+					m.EXPECT().Name().Return("file").AnyTimes()
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				checkpoint: first.Checkpoint,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			relay := NewRelay(testRelayConfig(), tt.params.mockOutboxRepository(ctrl), tt.params.mockPublisher(ctrl))
+
+			checkpoint := relay.poll(context.Background(), tt.params.checkpoint)
+			require.Equal(t, tt.expected.checkpoint, checkpoint)
+		})
+	}
+}
+
+func TestRelay_Run_ContinuesFromCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	saved := eventdomain.OutboxCheckpoint{TransactionID: 8, SequenceNumber: 4}
+	message := testMessage(10, 5)
+
+	outboxRepo := mock.NewMockOutboxRepository(ctrl)
+	outboxRepo.EXPECT().FindCheckpoint(gomock.Any(), "file").Return(saved, nil)
+	outboxRepo.EXPECT().FindMessages(gomock.Any(), saved, 2).Return([]eventdomain.OutboxMessage{message}, nil)
+	outboxRepo.EXPECT().SaveCheckpoint(gomock.Any(), "file", message.Checkpoint).
+		DoAndReturn(func(_ context.Context, _ string, _ eventdomain.OutboxCheckpoint) error {
+			cancel()
+			return nil
+		})
+	outboxRepo.EXPECT().FindMessages(gomock.Any(), message.Checkpoint, 2).Return(nil, nil).AnyTimes()
+
+	publisher := mock.NewMockPublisher(ctrl)
+	publisher.EXPECT().Name().Return("file").AnyTimes()
+	publisher.EXPECT().Publish(gomock.Any(), message.Event).Return(nil)
+
+	require.NoError(t, NewRelay(testRelayConfig(), outboxRepo, publisher).Run(ctx))
+}
+
+func TestRelay_Run_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := testRelayConfig()
+	config.BatchSize = 0
+
+	relay := NewRelay(config, mock.NewMockOutboxRepository(ctrl), mock.NewMockPublisher(ctrl))
+
+	require.ErrorIs(t, relay.Run(context.Background()), ErrInvalidConfig)
+}
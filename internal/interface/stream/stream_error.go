@@ -0,0 +1,11 @@
+package stream
+
+import (
+	"errors"
+)
+
+// Stream errors
+var (
+	// ErrInvalidConfig is returned when the relay can't run with the given configuration
+	ErrInvalidConfig = errors.New("invalid relay config")
+)
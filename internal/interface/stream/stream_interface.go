@@ -0,0 +1,27 @@
+package stream
+
+import (
+	"context"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+)
+
+//go:generate mockgen -destination=./mock/stream_mock.go -package=mock -source=./stream_interface.go
+
+// Publisher defines the interface for publishing the recorded events outside of the service, i.e. to a message broker
+type Publisher interface {
+	// Name returns the name of the publisher, the published events are checkpointed under it
+	Name() string
+	// Publish publishes the event, it returns once the event is accepted by the receiving side
+	Publish(ctx context.Context, event *eventdomain.BaseEvent) error
+}
+
+// OutboxRepository defines the interface for the outbox of the recorded events
+type OutboxRepository interface {
+	// FindMessages finds up to limit committed events added to the outbox after the checkpoint, in the order they are published
+	FindMessages(ctx context.Context, after eventdomain.OutboxCheckpoint, limit int) ([]eventdomain.OutboxMessage, error)
+	// FindCheckpoint finds the checkpoint of the last event published by the publisher
+	FindCheckpoint(ctx context.Context, publisher string) (eventdomain.OutboxCheckpoint, error)
+	// SaveCheckpoint saves the checkpoint of the last event published by the publisher
+	SaveCheckpoint(ctx context.Context, publisher string, checkpoint eventdomain.OutboxCheckpoint) error
+}
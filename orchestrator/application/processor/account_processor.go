@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
 )
 
 type (
@@ -15,6 +16,10 @@ type (
 	AccountFundsDepositedEvent = accountdomain.AccountFundsDepositedEvent
 	AccountBlockedEvent        = accountdomain.AccountBlockedEvent
 	AccountUnblockedEvent      = accountdomain.AccountUnblockedEvent
+	AccountActivatedEvent      = accountdomain.AccountActivatedEvent
+	AccountFrozenEvent         = accountdomain.AccountFrozenEvent
+	AccountUnfrozenEvent       = accountdomain.AccountUnfrozenEvent
+	AccountClosedEvent         = accountdomain.AccountClosedEvent
 )
 
 type accountEventType interface {
@@ -22,7 +27,11 @@ type accountEventType interface {
 		AccountFundsWithdrawnEvent |
 		AccountFundsDepositedEvent |
 		AccountBlockedEvent |
-		AccountUnblockedEvent
+		AccountUnblockedEvent |
+		AccountActivatedEvent |
+		AccountFrozenEvent |
+		AccountUnfrozenEvent |
+		AccountClosedEvent
 }
 
 // AccountProcessor handles the processing of account-related events
@@ -31,13 +40,19 @@ type AccountProcessor struct {
 	orcRepo OrchestratorRepository
 	// account repository
 	accountRepo AccountRepository
+	// account events registry
+	registry *eventdomain.Registry
 }
 
 // NewAccountProcessor creates a new account event processor
 func NewAccountProcessor(orcRepo OrchestratorRepository, accountRepo AccountRepository) *AccountProcessor {
+	registry := eventdomain.NewRegistry()
+	accountdomain.RegisterEvents(registry)
+
 	return &AccountProcessor{
 		orcRepo:     orcRepo,
 		accountRepo: accountRepo,
+		registry:    registry,
 	}
 }
 
@@ -45,7 +60,7 @@ func NewAccountProcessor(orcRepo OrchestratorRepository, accountRepo AccountRepo
 func (p *AccountProcessor) Process(ctx context.Context, event BaseEvent) error {
 	switch event.GetType() {
 	case accountdomain.AccountCreatedEventType.String():
-		accountEvent, err := UnmarshalEvent[AccountCreatedEvent](event.GetEventData())
+		accountEvent, err := UnmarshalEvent[AccountCreatedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal account created event: %w", err)
 		}
@@ -53,7 +68,7 @@ func (p *AccountProcessor) Process(ctx context.Context, event BaseEvent) error {
 		return p.handleAccountCreatedEvent(ctx, accountEvent.Data)
 
 	case accountdomain.AccountFundsWithdrawnEventType.String():
-		event, err := UnmarshalEvent[AccountFundsWithdrawnEvent](event.GetEventData())
+		event, err := UnmarshalEvent[AccountFundsWithdrawnEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal account funds withdrawn event: %w", err)
 		}
@@ -61,7 +76,7 @@ func (p *AccountProcessor) Process(ctx context.Context, event BaseEvent) error {
 		return p.handleAccountFundsWithdrawnEvent(ctx, event.Data)
 
 	case accountdomain.AccountFundsDepositedEventType.String():
-		event, err := UnmarshalEvent[AccountFundsDepositedEvent](event.GetEventData())
+		event, err := UnmarshalEvent[AccountFundsDepositedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal account funds deposited event: %w", err)
 		}
@@ -69,7 +84,7 @@ func (p *AccountProcessor) Process(ctx context.Context, event BaseEvent) error {
 		return p.handleAccountFundsDepositedEvent(ctx, event.Data)
 
 	case accountdomain.AccountBlockedEventType.String():
-		event, err := UnmarshalEvent[AccountBlockedEvent](event.GetEventData())
+		event, err := UnmarshalEvent[AccountBlockedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal account blocked event: %w", err)
 		}
@@ -77,13 +92,45 @@ func (p *AccountProcessor) Process(ctx context.Context, event BaseEvent) error {
 		return p.handleAccountBlockedEvent(ctx, event.Data)
 
 	case accountdomain.AccountUnblockedEventType.String():
-		event, err := UnmarshalEvent[AccountUnblockedEvent](event.GetEventData())
+		event, err := UnmarshalEvent[AccountUnblockedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal account unblocked event: %w", err)
 		}
 
 		return p.handleAccountUnblockedEvent(ctx, event.Data)
 
+	case accountdomain.AccountActivatedEventType.String():
+		event, err := UnmarshalEvent[AccountActivatedEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal account activated event: %w", err)
+		}
+
+		return p.handleAccountStatusEvent(ctx, event.Data.BaseEvent, accountdomain.AccountStatusActive)
+
+	case accountdomain.AccountFrozenEventType.String():
+		event, err := UnmarshalEvent[AccountFrozenEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal account frozen event: %w", err)
+		}
+
+		return p.handleAccountStatusEvent(ctx, event.Data.BaseEvent, accountdomain.AccountStatusFrozen)
+
+	case accountdomain.AccountUnfrozenEventType.String():
+		event, err := UnmarshalEvent[AccountUnfrozenEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal account unfrozen event: %w", err)
+		}
+
+		return p.handleAccountStatusEvent(ctx, event.Data.BaseEvent, accountdomain.AccountStatusActive)
+
+	case accountdomain.AccountClosedEventType.String():
+		event, err := UnmarshalEvent[AccountClosedEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal account closed event: %w", err)
+		}
+
+		return p.handleAccountStatusEvent(ctx, event.Data.BaseEvent, accountdomain.AccountStatusClosed)
+
 	default:
 		if err := p.handleUnknownEvent(ctx, event.GetID()); err != nil {
 			return fmt.Errorf("handling unknown account event: %w", err)
@@ -106,7 +153,7 @@ func (p *AccountProcessor) handleAccountCreatedEvent(ctx context.Context, accoun
 	}
 
 	if errCreate != nil {
-		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, 1); errUpdateRetry != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errCreate); errUpdateRetry != nil {
 			return fmt.Errorf("updating event retry after updating account created event failure: %w", errUpdateRetry)
 		}
 
@@ -123,23 +170,7 @@ func (p *AccountProcessor) handleAccountCreatedEvent(ctx context.Context, accoun
 func (p *AccountProcessor) handleAccountFundsWithdrawnEvent(ctx context.Context, accountEvent AccountFundsWithdrawnEvent) error {
 	errWithdraw := p.accountRepo.WithdrawFunds(ctx, accountEvent)
 	if errWithdraw != nil {
-		if errors.Is(errWithdraw, accountdomain.ErrAccountInsufficientFunds) {
-			if errUpdateRetry := p.orcRepo.UpdateEventState(ctx, accountEvent.ID, "failed"); errUpdateRetry != nil {
-				return fmt.Errorf("updating event state after insufficient funds condition: %w", errUpdateRetry)
-			}
-
-			return nil
-		}
-
-		if errors.Is(errWithdraw, accountdomain.ErrAccountNotFound) {
-			if errUpdateRetry := p.orcRepo.UpdateEventState(ctx, accountEvent.ID, "failed"); errUpdateRetry != nil {
-				return fmt.Errorf("updating event state after account not found condition: %w", errUpdateRetry)
-			}
-
-			return nil
-		}
-
-		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, 1); errUpdateRetry != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errWithdraw); errUpdateRetry != nil {
 			return fmt.Errorf("updating event retry after updating account funds withdrawn event failure: %w", errUpdateRetry)
 		}
 
@@ -153,8 +184,21 @@ func (p *AccountProcessor) handleAccountFundsWithdrawnEvent(ctx context.Context,
 	return nil
 }
 
-func (p *AccountProcessor) handleAccountFundsDepositedEvent(_ context.Context, _ AccountFundsDepositedEvent) error {
-	// Implement account funds deposited logic
+// handleAccountFundsDepositedEvent processes account funds deposited events
+func (p *AccountProcessor) handleAccountFundsDepositedEvent(ctx context.Context, accountEvent AccountFundsDepositedEvent) error {
+	errDeposit := p.accountRepo.DepositFunds(ctx, accountEvent)
+	if errDeposit != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errDeposit); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating account funds deposited event failure: %w", errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, accountEvent.ID); errUpdateCompletion != nil {
+		return fmt.Errorf("updating event completion: %w", errUpdateCompletion)
+	}
+
 	return nil
 }
 
@@ -162,16 +206,54 @@ func (p *AccountProcessor) handleAccountFundsDepositedEvent(_ context.Context, _
 func (p *AccountProcessor) handleAccountBlockedEvent(ctx context.Context, accountEvent AccountBlockedEvent) error {
 	errBlock := p.accountRepo.BlockAccount(ctx, accountEvent)
 	if errBlock != nil {
-		if errors.Is(errBlock, accountdomain.ErrAccountNotFound) {
-			if errUpdateRetry := p.orcRepo.UpdateEventState(ctx, accountEvent.ID, "failed"); errUpdateRetry != nil {
-				return fmt.Errorf("updating event state after account not found condition: %w", errUpdateRetry)
-			}
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errBlock); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating account blocked event failure: %w", errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, accountEvent.ID); errUpdateCompletion != nil {
+		return fmt.Errorf("updating event completion: %w", errUpdateCompletion)
+	}
 
-			return nil
+	return nil
+}
+
+// handleAccountUnblockedEvent processes account unblocked events.
+// An unblock scheduled at the end of a temporary block is cancelled by any change of the account status recorded before it's due,
+// i.e. the account was unblocked and blocked again, frozen or closed in the meantime, then the event is completed leaving the account as it is.
+func (p *AccountProcessor) handleAccountUnblockedEvent(ctx context.Context, accountEvent AccountUnblockedEvent) error {
+	account, errFind := p.accountRepo.FindByID(ctx, accountEvent.ContextID)
+	if errFind != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errFind); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after finding unblocked account failure: %w", errUpdateRetry)
 		}
 
-		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, 1); errUpdateRetry != nil {
-			return fmt.Errorf("updating event retry after updating account blocked event failure: %w", errUpdateRetry)
+		return nil
+	}
+
+	cancelled, errCancelled := p.isUnblockCancelled(ctx, accountEvent)
+	if errCancelled != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errCancelled); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after finding account status changes failure: %w", errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	if _, errTransition := account.Status.Transition(accountdomain.AccountOperationUnblock); errTransition != nil || cancelled {
+		if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, accountEvent.ID); errUpdateCompletion != nil {
+			return fmt.Errorf("updating event completion of account unblock not applicable anymore: %w", errUpdateCompletion)
+		}
+
+		return nil
+	}
+
+	errUnblock := p.accountRepo.UnblockAccount(ctx, accountEvent)
+	if errUnblock != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errUnblock); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating account unblocked event failure: %w", errUpdateRetry)
 		}
 
 		return nil
@@ -184,8 +266,31 @@ func (p *AccountProcessor) handleAccountBlockedEvent(ctx context.Context, accoun
 	return nil
 }
 
-func (p *AccountProcessor) handleAccountUnblockedEvent(_ context.Context, _ AccountUnblockedEvent) error {
-	// Implement account unblocked logic
+// isUnblockCancelled reports whether the unblock scheduled for later was cancelled by a change of the account status
+// recorded before it was due, the unblock taking effect at once can't be cancelled
+func (p *AccountProcessor) isUnblockCancelled(ctx context.Context, accountEvent AccountUnblockedEvent) (bool, error) {
+	if !accountEvent.ScheduledAt.After(accountEvent.CreatedAt) {
+		return false, nil
+	}
+
+	return p.accountRepo.HasStatusChangedBefore(ctx, accountEvent.ContextID, accountEvent.AggregateVersion, accountEvent.ScheduledAt)
+}
+
+// handleAccountStatusEvent records the account status the event leads to, i.e. frozen for the account frozen event
+func (p *AccountProcessor) handleAccountStatusEvent(ctx context.Context, accountEvent eventdomain.BaseEvent, status accountdomain.AccountStatus) error {
+	errUpdate := p.accountRepo.UpdateAccountStatus(ctx, accountEvent.ContextID, status, accountEvent.CreatedAt)
+	if errUpdate != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, accountEvent.ID, errUpdate); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating account %s status failure: %w", status, errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, accountEvent.ID); errUpdateCompletion != nil {
+		return fmt.Errorf("updating event completion: %w", errUpdateCompletion)
+	}
+
 	return nil
 }
 
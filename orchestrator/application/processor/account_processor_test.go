@@ -79,7 +79,7 @@ func TestAccountProcessor_Process_AccountCreatedEvent(t *testing.T) {
 							ContextID:   uuid.New(),
 							Origin:      "account",
 							Type:        "account.created",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -90,7 +90,7 @@ func TestAccountProcessor_Process_AccountCreatedEvent(t *testing.T) {
 							Data:        nil,
 						},
 						CustomerID:     uuid.New(),
-						InitialBalance: 1000,
+						InitialBalance: testMoney(100000),
 					}
 
 					data, err := json.Marshal(acc)
@@ -201,7 +201,7 @@ func TestAccountProcessor_Process_AccountFundsWithdrawnEvent(t *testing.T) {
 							ContextID:   uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -211,7 +211,7 @@ func TestAccountProcessor_Process_AccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 
 					data, err := json.Marshal(acc)
@@ -263,6 +263,9 @@ func TestAccountProcessor_Process_AccountFundsWithdrawnEvent(t *testing.T) {
 func TestAccountProcessor_Process_AccountFundsDepositedEvent(t *testing.T) {
 	type testCaseParams struct {
 		accountFundsDepositedEvent func() *AccountFundsDepositedEvent
+
+		mockOrchestratorRepository func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository
+		mockAccountRepository      func(ctrl *gomock.Controller) *mock.MockAccountRepository
 	}
 
 	type testCaseExpected struct {
@@ -296,6 +299,12 @@ func TestAccountProcessor_Process_AccountFundsDepositedEvent(t *testing.T) {
 
 					return acc
 				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					return mock.NewMockOrchestratorRepository(ctrl)
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					return mock.NewMockAccountRepository(ctrl)
+				},
 			},
 			expected: testCaseExpected{
 				wantError: true,
@@ -311,7 +320,7 @@ func TestAccountProcessor_Process_AccountFundsDepositedEvent(t *testing.T) {
 							ContextID:   uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.deposited",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -321,7 +330,7 @@ func TestAccountProcessor_Process_AccountFundsDepositedEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 
 					data, err := json.Marshal(acc)
@@ -333,6 +342,16 @@ func TestAccountProcessor_Process_AccountFundsDepositedEvent(t *testing.T) {
 
 					return acc
 				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().DepositFunds(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
 			},
 			expected: testCaseExpected{
 				wantError: false,
@@ -346,8 +365,8 @@ func TestAccountProcessor_Process_AccountFundsDepositedEvent(t *testing.T) {
 			defer ctrl.Finish()
 
 			processor := NewAccountProcessor(
-				mock.NewMockOrchestratorRepository(ctrl),
-				mock.NewMockAccountRepository(ctrl),
+				testCase.params.mockOrchestratorRepository(ctrl),
+				testCase.params.mockAccountRepository(ctrl),
 			)
 
 			err := processor.Process(context.Background(), testCase.params.accountFundsDepositedEvent())
@@ -420,7 +439,7 @@ func TestAccountProcessor_Process_AccountBlockedEvent(t *testing.T) {
 							ContextID:   uuid.New(),
 							Origin:      "account",
 							Type:        "account.blocked",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -479,8 +498,15 @@ func TestAccountProcessor_Process_AccountBlockedEvent(t *testing.T) {
 }
 
 func TestAccountProcessor_Process_AccountUnblockedEvent(t *testing.T) {
+	scheduledEventID, scheduledAccountID := uuid.New(), uuid.New()
+	scheduledAt := time.Now().UTC().Add(-time.Hour)
+	dueAt := scheduledAt.Add(30 * time.Minute)
+
 	type testCaseParams struct {
 		accountUnblockedEvent func() *AccountUnblockedEvent
+
+		mockOrchestratorRepository func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository
+		mockAccountRepository      func(ctrl *gomock.Controller) *mock.MockAccountRepository
 	}
 
 	type testCaseExpected struct {
@@ -514,6 +540,12 @@ func TestAccountProcessor_Process_AccountUnblockedEvent(t *testing.T) {
 
 					return acc
 				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					return mock.NewMockOrchestratorRepository(ctrl)
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					return mock.NewMockAccountRepository(ctrl)
+				},
 			},
 			expected: testCaseExpected{
 				wantError: true,
@@ -529,7 +561,7 @@ func TestAccountProcessor_Process_AccountUnblockedEvent(t *testing.T) {
 							ContextID:   uuid.New(),
 							Origin:      "account",
 							Type:        "account.unblocked",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -550,11 +582,172 @@ func TestAccountProcessor_Process_AccountUnblockedEvent(t *testing.T) {
 
 					return acc
 				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(accountdomain.Account{Status: accountdomain.AccountStatusBlocked}, nil)
+					m.EXPECT().UnblockAccount(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
 			},
 			expected: testCaseExpected{
 				wantError: false,
 			},
 		},
+		{
+			name: "should complete account unblocked event - account closed in the meantime",
+			params: testCaseParams{
+				accountUnblockedEvent: func() *AccountUnblockedEvent {
+					return &AccountUnblockedEvent{
+						BaseEvent: eventdomain.BaseEvent{
+							ID:          uuid.New(),
+							ContextID:   uuid.New(),
+							Origin:      "account",
+							Type:        "account.unblocked",
+							TypeVersion: "0.0.0",
+							CreatedAt:   time.Now().UTC(),
+							MaxRetry:    3,
+						},
+					}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(accountdomain.Account{Status: accountdomain.AccountStatusClosed}, nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should process scheduled account unblocked event",
+			params: testCaseParams{
+				accountUnblockedEvent: func() *AccountUnblockedEvent {
+					return &AccountUnblockedEvent{
+						BaseEvent: eventdomain.BaseEvent{
+							ID:               scheduledEventID,
+							ContextID:        scheduledAccountID,
+							Origin:           "account",
+							Type:             "account.unblocked",
+							TypeVersion:      "0.0.0",
+							CreatedAt:        scheduledAt,
+							ScheduledAt:      dueAt,
+							MaxRetry:         3,
+							AggregateVersion: 4,
+						},
+					}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), scheduledEventID).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), scheduledAccountID).Return(accountdomain.Account{Status: accountdomain.AccountStatusBlocked}, nil)
+					m.EXPECT().HasStatusChangedBefore(gomock.Any(), scheduledAccountID, 4, dueAt).Return(false, nil)
+					m.EXPECT().UnblockAccount(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should complete scheduled account unblocked event - account unblocked and blocked again before it was due",
+			params: testCaseParams{
+				accountUnblockedEvent: func() *AccountUnblockedEvent {
+					return &AccountUnblockedEvent{
+						BaseEvent: eventdomain.BaseEvent{
+							ID:               scheduledEventID,
+							ContextID:        scheduledAccountID,
+							Origin:           "account",
+							Type:             "account.unblocked",
+							TypeVersion:      "0.0.0",
+							CreatedAt:        scheduledAt,
+							ScheduledAt:      dueAt,
+							MaxRetry:         3,
+							AggregateVersion: 4,
+						},
+					}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), scheduledEventID).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), scheduledAccountID).Return(accountdomain.Account{Status: accountdomain.AccountStatusBlocked}, nil)
+					m.EXPECT().HasStatusChangedBefore(gomock.Any(), scheduledAccountID, 4, dueAt).Return(true, nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should report failure of finding account status changes to the retry policy",
+			params: testCaseParams{
+				accountUnblockedEvent: func() *AccountUnblockedEvent {
+					return &AccountUnblockedEvent{
+						BaseEvent: eventdomain.BaseEvent{
+							ID:               scheduledEventID,
+							ContextID:        scheduledAccountID,
+							Origin:           "account",
+							Type:             "account.unblocked",
+							TypeVersion:      "0.0.0",
+							CreatedAt:        scheduledAt,
+							ScheduledAt:      dueAt,
+							MaxRetry:         3,
+							AggregateVersion: 4,
+						},
+					}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), scheduledEventID, gomock.Any()).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), scheduledAccountID).Return(accountdomain.Account{Status: accountdomain.AccountStatusBlocked}, nil)
+					m.EXPECT().HasStatusChangedBefore(gomock.Any(), scheduledAccountID, 4, dueAt).Return(false, errors.New("db error"))
+					return m
+				},
+			},
+		},
+		{
+			name: "should report account not found to the retry policy",
+			params: testCaseParams{
+				accountUnblockedEvent: func() *AccountUnblockedEvent {
+					return &AccountUnblockedEvent{
+						BaseEvent: eventdomain.BaseEvent{
+							ID:          uuid.New(),
+							ContextID:   uuid.New(),
+							Origin:      "account",
+							Type:        "account.unblocked",
+							TypeVersion: "0.0.0",
+							CreatedAt:   time.Now().UTC(),
+							MaxRetry:    3,
+						},
+					}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), accountdomain.ErrAccountNotFound).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(accountdomain.Account{}, accountdomain.ErrAccountNotFound)
+					return m
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -563,8 +756,8 @@ func TestAccountProcessor_Process_AccountUnblockedEvent(t *testing.T) {
 			defer ctrl.Finish()
 
 			processor := NewAccountProcessor(
-				mock.NewMockOrchestratorRepository(ctrl),
-				mock.NewMockAccountRepository(ctrl),
+				testCase.params.mockOrchestratorRepository(ctrl),
+				testCase.params.mockAccountRepository(ctrl),
 			)
 
 			err := processor.Process(context.Background(), testCase.params.accountUnblockedEvent())
@@ -577,6 +770,131 @@ func TestAccountProcessor_Process_AccountUnblockedEvent(t *testing.T) {
 	}
 }
 
+func TestAccountProcessor_Process_AccountStatusEvents(t *testing.T) {
+	type testCaseParams struct {
+		event                      func() BaseEvent
+		mockOrchestratorRepository func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository
+		mockAccountRepository      func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockAccountRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+	}
+
+	baseEvent := func(eventType accountdomain.AccountEventType) eventdomain.BaseEvent {
+		return eventdomain.BaseEvent{
+			ID:          uuid.New(),
+			ContextID:   uuid.New(),
+			Origin:      "account",
+			Type:        eventType.String(),
+			TypeVersion: "0.0.0",
+			State:       "ready",
+			CreatedAt:   time.Now().UTC(),
+			MaxRetry:    3,
+		}
+	}
+
+	completed := func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+		m := mock.NewMockOrchestratorRepository(ctrl)
+		m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+		return m
+	}
+
+	statusUpdated := func(status accountdomain.AccountStatus) func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockAccountRepository {
+		return func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockAccountRepository {
+			m := mock.NewMockAccountRepository(ctrl)
+			m.EXPECT().UpdateAccountStatus(gomock.Any(), id, status, gomock.Any()).Return(nil)
+			return m
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should activate account",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &AccountActivatedEvent{BaseEvent: baseEvent(accountdomain.AccountActivatedEventType)}
+				},
+				mockOrchestratorRepository: completed,
+				mockAccountRepository:      statusUpdated(accountdomain.AccountStatusActive),
+			},
+		},
+		{
+			name: "should freeze account",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &AccountFrozenEvent{BaseEvent: baseEvent(accountdomain.AccountFrozenEventType)}
+				},
+				mockOrchestratorRepository: completed,
+				mockAccountRepository:      statusUpdated(accountdomain.AccountStatusFrozen),
+			},
+		},
+		{
+			name: "should unfreeze account",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &AccountUnfrozenEvent{BaseEvent: baseEvent(accountdomain.AccountUnfrozenEventType)}
+				},
+				mockOrchestratorRepository: completed,
+				mockAccountRepository:      statusUpdated(accountdomain.AccountStatusActive),
+			},
+		},
+		{
+			name: "should close account",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &AccountClosedEvent{BaseEvent: baseEvent(accountdomain.AccountClosedEventType)}
+				},
+				mockOrchestratorRepository: completed,
+				mockAccountRepository:      statusUpdated(accountdomain.AccountStatusClosed),
+			},
+		},
+		{
+			name: "should retry account status event - status update failed",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &AccountFrozenEvent{BaseEvent: baseEvent(accountdomain.AccountFrozenEventType)}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockAccountRepository: func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockAccountRepository {
+					m := mock.NewMockAccountRepository(ctrl)
+					m.EXPECT().UpdateAccountStatus(gomock.Any(), id, accountdomain.AccountStatusFrozen, gomock.Any()).Return(errors.New("db error"))
+					return m
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ev := testCase.params.event()
+
+			processor := NewAccountProcessor(
+				testCase.params.mockOrchestratorRepository(ctrl),
+				testCase.params.mockAccountRepository(ctrl, ev.GetContextID()),
+			)
+
+			err := processor.Process(context.Background(), ev)
+			if testCase.expected.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestAccountProcessor_Process_UnknownEvent(t *testing.T) {
 	type testCaseParams struct {
 		unknownEvent func() *eventdomain.BaseEvent
@@ -680,7 +998,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.created",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -691,7 +1009,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							Data:        nil,
 						},
 						CustomerID:     uuid.New(),
-						InitialBalance: 1000,
+						InitialBalance: testMoney(100000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -720,7 +1038,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.created",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -731,7 +1049,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							Data:        nil,
 						},
 						CustomerID:     uuid.New(),
-						InitialBalance: 1000,
+						InitialBalance: testMoney(100000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -760,7 +1078,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.created",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -771,7 +1089,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							Data:        nil,
 						},
 						CustomerID:     uuid.New(),
-						InitialBalance: 1000,
+						InitialBalance: testMoney(100000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -799,7 +1117,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.created",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -810,7 +1128,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							Data:        nil,
 						},
 						CustomerID:     uuid.New(),
-						InitialBalance: 1000,
+						InitialBalance: testMoney(100000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -838,7 +1156,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.created",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -849,7 +1167,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							Data:        nil,
 						},
 						CustomerID:     uuid.New(),
-						InitialBalance: 1000,
+						InitialBalance: testMoney(100000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -877,7 +1195,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.created",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -888,7 +1206,7 @@ func TestAccountProcessor_handleAccountCreatedEvent(t *testing.T) {
 							Data:        nil,
 						},
 						CustomerID:     uuid.New(),
-						InitialBalance: 1000,
+						InitialBalance: testMoney(100000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -949,7 +1267,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 
 	testCases := []testCase{
 		{
-			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountInsufficientFunds error, UpdateEventState returns internal error",
+			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountInsufficientFunds error, UpdateEventRetry returns internal error",
 			params: testCaseParams{
 				accountFundsWithdrawnEvent: func() AccountFundsWithdrawnEvent {
 					return AccountFundsWithdrawnEvent{
@@ -957,7 +1275,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -967,12 +1285,12 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
 					m := mock.NewMockOrchestratorRepository(ctrl)
-					m.EXPECT().UpdateEventState(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), accountdomain.ErrAccountInsufficientFunds).Return(errors.New("internal error"))
 					return m
 				},
 				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
@@ -986,7 +1304,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 			},
 		},
 		{
-			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountInsufficientFunds error, UpdateEventState returns nil",
+			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountInsufficientFunds error, UpdateEventRetry returns nil",
 			params: testCaseParams{
 				accountFundsWithdrawnEvent: func() AccountFundsWithdrawnEvent {
 					return AccountFundsWithdrawnEvent{
@@ -994,7 +1312,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1004,12 +1322,12 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
 					m := mock.NewMockOrchestratorRepository(ctrl)
-					m.EXPECT().UpdateEventState(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), accountdomain.ErrAccountInsufficientFunds).Return(nil)
 					return m
 				},
 				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
@@ -1023,7 +1341,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 			},
 		},
 		{
-			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountNotFound error, UpdateEventState returns internal error",
+			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountNotFound error, UpdateEventRetry returns internal error",
 			params: testCaseParams{
 				accountFundsWithdrawnEvent: func() AccountFundsWithdrawnEvent {
 					return AccountFundsWithdrawnEvent{
@@ -1031,7 +1349,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1041,12 +1359,12 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
 					m := mock.NewMockOrchestratorRepository(ctrl)
-					m.EXPECT().UpdateEventState(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), accountdomain.ErrAccountNotFound).Return(errors.New("internal error"))
 					return m
 				},
 				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
@@ -1060,7 +1378,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 			},
 		},
 		{
-			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountNotFound error, UpdateEventState returns nil",
+			name: "shouldn't process account funds withdrawn event - WithdrawFunds returns ErrAccountNotFound error, UpdateEventRetry returns nil",
 			params: testCaseParams{
 				accountFundsWithdrawnEvent: func() AccountFundsWithdrawnEvent {
 					return AccountFundsWithdrawnEvent{
@@ -1068,7 +1386,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1078,12 +1396,12 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
 					m := mock.NewMockOrchestratorRepository(ctrl)
-					m.EXPECT().UpdateEventState(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), accountdomain.ErrAccountNotFound).Return(nil)
 					return m
 				},
 				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
@@ -1105,7 +1423,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1115,7 +1433,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -1142,7 +1460,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1152,7 +1470,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -1179,7 +1497,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1189,7 +1507,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -1216,7 +1534,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.funds.withdrawn",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1226,7 +1544,7 @@ func TestAccountProcessor_handleAccountFundsWithdrawnEvent(t *testing.T) {
 							MaxRetry:    3,
 							Data:        nil,
 						},
-						Amount: 100,
+						Amount: testMoney(10000),
 					}
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
@@ -1290,7 +1608,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 
 	testCases := []testCase{
 		{
-			name: "shouldn't process account blocked event - BlockAccount returns ErrAccountNotFound error, UpdateEventState returns internal error",
+			name: "shouldn't process account blocked event - BlockAccount returns ErrAccountNotFound error, UpdateEventRetry returns internal error",
 			params: testCaseParams{
 				accountBlockedEvent: func() AccountBlockedEvent {
 					return AccountBlockedEvent{
@@ -1298,7 +1616,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.blocked",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1312,7 +1630,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
 					m := mock.NewMockOrchestratorRepository(ctrl)
-					m.EXPECT().UpdateEventState(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), accountdomain.ErrAccountNotFound).Return(errors.New("internal error"))
 					return m
 				},
 				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
@@ -1326,7 +1644,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 			},
 		},
 		{
-			name: "shouldn't process account blocked event - BlockAccount returns ErrAccountNotFound error, UpdateEventState returns nil",
+			name: "shouldn't process account blocked event - BlockAccount returns ErrAccountNotFound error, UpdateEventRetry returns nil",
 			params: testCaseParams{
 				accountBlockedEvent: func() AccountBlockedEvent {
 					return AccountBlockedEvent{
@@ -1334,7 +1652,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.blocked",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1348,7 +1666,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 				},
 				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
 					m := mock.NewMockOrchestratorRepository(ctrl)
-					m.EXPECT().UpdateEventState(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), accountdomain.ErrAccountNotFound).Return(nil)
 					return m
 				},
 				mockAccountRepository: func(ctrl *gomock.Controller) *mock.MockAccountRepository {
@@ -1370,7 +1688,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.blocked",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1406,7 +1724,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.blocked",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -1442,7 +1760,7 @@ func TestAccountProcessor_handleAccountBlockedEvent(t *testing.T) {
 							ID:          uuid.New(),
 							Origin:      "account",
 							Type:        "account.blocked",
-							TypeVersion: "1.0.0",
+							TypeVersion: "0.0.0",
 							State:       "created",
 							CreatedAt:   time.Now().UTC(),
 							ScheduledAt: time.Time{},
@@ -2,10 +2,12 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
 )
 
 type (
@@ -27,19 +29,24 @@ type customerEventType interface {
 type CustomerProcessor struct {
 	orcRepo      OrchestratorRepository
 	customerRepo CustomerRepository
+	registry     *eventdomain.Registry
 }
 
 func NewCustomerProcessor(orcRepo OrchestratorRepository, customerRepo CustomerRepository) *CustomerProcessor {
+	registry := eventdomain.NewRegistry()
+	customerdomain.RegisterEvents(registry)
+
 	return &CustomerProcessor{
 		orcRepo:      orcRepo,
 		customerRepo: customerRepo,
+		registry:     registry,
 	}
 }
 
 func (p *CustomerProcessor) Process(ctx context.Context, event BaseEvent) error {
 	switch event.GetType() {
 	case customerdomain.CustomerCreatedEventType.String():
-		customerEvent, err := UnmarshalEvent[CustomerCreatedEvent](event.GetEventData())
+		customerEvent, err := UnmarshalEvent[CustomerCreatedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal customer created event: %w", err)
 		}
@@ -47,7 +54,7 @@ func (p *CustomerProcessor) Process(ctx context.Context, event BaseEvent) error
 		return p.handleCustomerCreatedEvent(ctx, customerEvent.Data)
 
 	case customerdomain.CustomerActivatedEventType.String():
-		customerEvent, err := UnmarshalEvent[CustomerActivatedEvent](event.GetEventData())
+		customerEvent, err := UnmarshalEvent[CustomerActivatedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal customer activated event: %w", err)
 		}
@@ -55,7 +62,7 @@ func (p *CustomerProcessor) Process(ctx context.Context, event BaseEvent) error
 		return p.handleCustomerActivatedEvent(ctx, customerEvent.Data)
 
 	case customerdomain.CustomerDeactivatedEventType.String():
-		customerEvent, err := UnmarshalEvent[CustomerDeactivatedEvent](event.GetEventData())
+		customerEvent, err := UnmarshalEvent[CustomerDeactivatedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal customer deactivated event: %w", err)
 		}
@@ -63,7 +70,7 @@ func (p *CustomerProcessor) Process(ctx context.Context, event BaseEvent) error
 		return p.handleCustomerDeactivatedEvent(ctx, customerEvent.Data)
 
 	case customerdomain.CustomerBlockedEventType.String():
-		customerEvent, err := UnmarshalEvent[CustomerBlockedEvent](event.GetEventData())
+		customerEvent, err := UnmarshalEvent[CustomerBlockedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal customer blocked event: %w", err)
 		}
@@ -71,7 +78,7 @@ func (p *CustomerProcessor) Process(ctx context.Context, event BaseEvent) error
 		return p.handleCustomerBlockedEvent(ctx, customerEvent.Data)
 
 	case customerdomain.CustomerUnblockedEventType.String():
-		customerEvent, err := UnmarshalEvent[CustomerUnblockedEvent](event.GetEventData())
+		customerEvent, err := UnmarshalEvent[CustomerUnblockedEvent](p.registry, event)
 		if err != nil {
 			return fmt.Errorf("unmarshal customer unblocked event: %w", err)
 		}
@@ -86,25 +93,69 @@ func (p *CustomerProcessor) Process(ctx context.Context, event BaseEvent) error
 	return nil
 }
 
-func (p *CustomerProcessor) handleCustomerCreatedEvent(ctx context.Context, event CustomerCreatedEvent) error {
+// handleCustomerCreatedEvent processes customer creation events
+func (p *CustomerProcessor) handleCustomerCreatedEvent(ctx context.Context, customerEvent CustomerCreatedEvent) error {
+	errCreate := p.customerRepo.CreateCustomer(ctx, customerEvent)
+	if errCreate != nil && errors.Is(errCreate, customerdomain.ErrCustomerAlreadyExists) {
+		// Customer was created successfully, update event completion didn't complete
+		if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, customerEvent.ID); errUpdateCompletion != nil {
+			return fmt.Errorf("updating event completion when customer event creation succeeded: %w", errUpdateCompletion)
+		}
+
+		return nil
+	}
+
+	if errCreate != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, customerEvent.ID, errCreate); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating customer created event failure: %w", errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, customerEvent.ID); errUpdateCompletion != nil {
+		return fmt.Errorf("updating event completion: %w", errUpdateCompletion)
+	}
+
 	return nil
 }
 
-func (p *CustomerProcessor) handleCustomerActivatedEvent(ctx context.Context, event CustomerActivatedEvent) error {
-	return nil
+// handleCustomerActivatedEvent processes customer activation events
+func (p *CustomerProcessor) handleCustomerActivatedEvent(ctx context.Context, customerEvent CustomerActivatedEvent) error {
+	return p.handleCustomerStatusEvent(ctx, customerEvent.BaseEvent, customerdomain.CustomerStatusActive)
 }
 
-func (p *CustomerProcessor) handleCustomerDeactivatedEvent(ctx context.Context, event CustomerDeactivatedEvent) error {
-	return nil
+// handleCustomerDeactivatedEvent processes customer deactivation events
+func (p *CustomerProcessor) handleCustomerDeactivatedEvent(ctx context.Context, customerEvent CustomerDeactivatedEvent) error {
+	return p.handleCustomerStatusEvent(ctx, customerEvent.BaseEvent, customerdomain.CustomerStatusInactive)
 }
 
-func (p *CustomerProcessor) handleCustomerBlockedEvent(ctx context.Context, event CustomerBlockedEvent) error {
-	return nil
+// handleCustomerBlockedEvent processes customer blocked events
+func (p *CustomerProcessor) handleCustomerBlockedEvent(ctx context.Context, customerEvent CustomerBlockedEvent) error {
+	return p.handleCustomerStatusEvent(ctx, customerEvent.BaseEvent, customerdomain.CustomerStatusBlocked)
 }
 
-func (p *CustomerProcessor) handleCustomerUnblockedEvent(ctx context.Context, event CustomerUnblockedEvent) error {
-	return nil
+// handleCustomerUnblockedEvent processes customer unblocked events
+func (p *CustomerProcessor) handleCustomerUnblockedEvent(ctx context.Context, customerEvent CustomerUnblockedEvent) error {
+	return p.handleCustomerStatusEvent(ctx, customerEvent.BaseEvent, customerdomain.CustomerStatusActive)
+}
+
+// handleCustomerStatusEvent records the customer status the event leads to
+func (p *CustomerProcessor) handleCustomerStatusEvent(ctx context.Context, customerEvent eventdomain.BaseEvent, status customerdomain.CustomerStatus) error {
+	errUpdate := p.customerRepo.UpdateCustomerStatus(ctx, customerEvent.ContextID, status, customerEvent.CreatedAt)
+	if errUpdate != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, customerEvent.ID, errUpdate); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating customer %s status failure: %w", status, errUpdateRetry)
+		}
 
+		return nil
+	}
+
+	if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, customerEvent.ID); errUpdateCompletion != nil {
+		return fmt.Errorf("updating event completion: %w", errUpdateCompletion)
+	}
+
+	return nil
 }
 
 func (p *CustomerProcessor) handleUnknownEvent(ctx context.Context, id uuid.UUID) error {
@@ -1,3 +1,272 @@
 //go:build unit
 
 package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor/mock"
+)
+
+func testCustomerBaseEvent(eventType customerdomain.CustomerEventType) eventdomain.BaseEvent {
+	return eventdomain.BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   uuid.New(),
+		Origin:      "customer",
+		Type:        eventType.String(),
+		TypeVersion: "0.0.0",
+		State:       "ready",
+		CreatedAt:   time.Now().UTC(),
+		MaxRetry:    3,
+	}
+}
+
+func TestCustomerProcessor_Process_CustomerCreatedEvent(t *testing.T) {
+	type testCaseParams struct {
+		mockOrchestratorRepository func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository
+		mockCustomerRepository     func(ctrl *gomock.Controller) *mock.MockCustomerRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+	}
+
+	testCases := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should process customer created event",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockCustomerRepository: func(ctrl *gomock.Controller) *mock.MockCustomerRepository {
+					m := mock.NewMockCustomerRepository(ctrl)
+					m.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+			},
+		},
+		{
+			name: "should complete customer created event - customer already exists",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockCustomerRepository: func(ctrl *gomock.Controller) *mock.MockCustomerRepository {
+					m := mock.NewMockCustomerRepository(ctrl)
+					m.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).Return(customerdomain.ErrCustomerAlreadyExists)
+					return m
+				},
+			},
+		},
+		{
+			name: "should retry customer created event - customer creation failed",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockCustomerRepository: func(ctrl *gomock.Controller) *mock.MockCustomerRepository {
+					m := mock.NewMockCustomerRepository(ctrl)
+					m.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't process customer created event - event retry update failed",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+					return m
+				},
+				mockCustomerRepository: func(ctrl *gomock.Controller) *mock.MockCustomerRepository {
+					m := mock.NewMockCustomerRepository(ctrl)
+					m.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			processor := NewCustomerProcessor(
+				testCase.params.mockOrchestratorRepository(ctrl),
+				testCase.params.mockCustomerRepository(ctrl),
+			)
+
+			ev := &CustomerCreatedEvent{
+				BaseEvent: testCustomerBaseEvent(customerdomain.CustomerCreatedEventType),
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     "john.doe@example.com",
+			}
+
+			data, err := eventdomain.Encode(ev)
+			require.NoError(t, err)
+			ev.Data = data
+
+			err = processor.Process(context.Background(), ev)
+			if testCase.expected.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCustomerProcessor_Process_CustomerStatusEvents(t *testing.T) {
+	type testCaseParams struct {
+		event                      func() BaseEvent
+		mockOrchestratorRepository func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository
+		mockCustomerRepository     func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockCustomerRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+	}
+
+	completed := func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+		m := mock.NewMockOrchestratorRepository(ctrl)
+		m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+		return m
+	}
+
+	statusUpdated := func(status customerdomain.CustomerStatus) func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockCustomerRepository {
+		return func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockCustomerRepository {
+			m := mock.NewMockCustomerRepository(ctrl)
+			m.EXPECT().UpdateCustomerStatus(gomock.Any(), id, status, gomock.Any()).Return(nil)
+			return m
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should activate customer",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &CustomerActivatedEvent{BaseEvent: testCustomerBaseEvent(customerdomain.CustomerActivatedEventType)}
+				},
+				mockOrchestratorRepository: completed,
+				mockCustomerRepository:     statusUpdated(customerdomain.CustomerStatusActive),
+			},
+		},
+		{
+			name: "should deactivate customer",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &CustomerDeactivatedEvent{BaseEvent: testCustomerBaseEvent(customerdomain.CustomerDeactivatedEventType)}
+				},
+				mockOrchestratorRepository: completed,
+				mockCustomerRepository:     statusUpdated(customerdomain.CustomerStatusInactive),
+			},
+		},
+		{
+			name: "should block customer",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &CustomerBlockedEvent{BaseEvent: testCustomerBaseEvent(customerdomain.CustomerBlockedEventType), Reason: "fraud"}
+				},
+				mockOrchestratorRepository: completed,
+				mockCustomerRepository:     statusUpdated(customerdomain.CustomerStatusBlocked),
+			},
+		},
+		{
+			name: "should unblock customer",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &CustomerUnblockedEvent{BaseEvent: testCustomerBaseEvent(customerdomain.CustomerUnblockedEventType)}
+				},
+				mockOrchestratorRepository: completed,
+				mockCustomerRepository:     statusUpdated(customerdomain.CustomerStatusActive),
+			},
+		},
+		{
+			name: "should report customer not found to the retry policy",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &CustomerBlockedEvent{BaseEvent: testCustomerBaseEvent(customerdomain.CustomerBlockedEventType), Reason: "fraud"}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), customerdomain.ErrCustomerNotFound).Return(nil)
+					return m
+				},
+				mockCustomerRepository: func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockCustomerRepository {
+					m := mock.NewMockCustomerRepository(ctrl)
+					m.EXPECT().UpdateCustomerStatus(gomock.Any(), id, customerdomain.CustomerStatusBlocked, gomock.Any()).Return(customerdomain.ErrCustomerNotFound)
+					return m
+				},
+			},
+		},
+		{
+			name: "should retry customer status event - status update failed",
+			params: testCaseParams{
+				event: func() BaseEvent {
+					return &CustomerActivatedEvent{BaseEvent: testCustomerBaseEvent(customerdomain.CustomerActivatedEventType)}
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					return m
+				},
+				mockCustomerRepository: func(ctrl *gomock.Controller, id uuid.UUID) *mock.MockCustomerRepository {
+					m := mock.NewMockCustomerRepository(ctrl)
+					m.EXPECT().UpdateCustomerStatus(gomock.Any(), id, customerdomain.CustomerStatusActive, gomock.Any()).Return(errors.New("db error"))
+					return m
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ev := testCase.params.event()
+
+			processor := NewCustomerProcessor(
+				testCase.params.mockOrchestratorRepository(ctrl),
+				testCase.params.mockCustomerRepository(ctrl, ev.GetContextID()),
+			)
+
+			err := processor.Process(context.Background(), ev)
+			if testCase.expected.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package processor
 
 import (
-	"encoding/json"
+	"fmt"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
 )
 
 // Event represents the base structure for all events
@@ -10,19 +12,20 @@ type Event[T any] struct {
 	Ok   bool
 }
 
-// UnmarshalEvent unmarshals the event data into the generic type
-func UnmarshalEvent[T any](eventData []byte) (Event[T], error) {
-	if len(eventData) == 0 {
-		return Event[T]{}, nil
+// UnmarshalEvent decodes the event into the generic type, using the domain event registered for the event type and type version
+func UnmarshalEvent[T any](registry *eventdomain.Registry, event BaseEvent) (Event[T], error) {
+	decoded, err := registry.Decode(event)
+	if err != nil {
+		return Event[T]{}, err
 	}
 
-	var data T
-	if err := json.Unmarshal(eventData, &data); err != nil {
-		return Event[T]{}, err
+	data, ok := any(decoded).(*T)
+	if !ok {
+		return Event[T]{}, fmt.Errorf("decoding %s event into %T: %w", event.GetType(), data, eventdomain.ErrEventNotRegistered)
 	}
 
 	return Event[T]{
-		Data: data,
+		Data: *data,
 		Ok:   true,
 	}, nil
 }
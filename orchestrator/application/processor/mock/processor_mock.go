@@ -12,11 +12,13 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	account "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
 	customer "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
 	event "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	transaction "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -44,6 +46,62 @@ func (m *MockBaseEvent) EXPECT() *MockBaseEventMockRecorder {
 	return m.recorder
 }
 
+// GetAggregateVersion mocks base method.
+func (m *MockBaseEvent) GetAggregateVersion() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateVersion")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetAggregateVersion indicates an expected call of GetAggregateVersion.
+func (mr *MockBaseEventMockRecorder) GetAggregateVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateVersion", reflect.TypeOf((*MockBaseEvent)(nil).GetAggregateVersion))
+}
+
+// GetCompletedAt mocks base method.
+func (m *MockBaseEvent) GetCompletedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetCompletedAt indicates an expected call of GetCompletedAt.
+func (mr *MockBaseEventMockRecorder) GetCompletedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedAt", reflect.TypeOf((*MockBaseEvent)(nil).GetCompletedAt))
+}
+
+// GetContextID mocks base method.
+func (m *MockBaseEvent) GetContextID() uuid.UUID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContextID")
+	ret0, _ := ret[0].(uuid.UUID)
+	return ret0
+}
+
+// GetContextID indicates an expected call of GetContextID.
+func (mr *MockBaseEventMockRecorder) GetContextID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContextID", reflect.TypeOf((*MockBaseEvent)(nil).GetContextID))
+}
+
+// GetCreatedAt mocks base method.
+func (m *MockBaseEvent) GetCreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetCreatedAt indicates an expected call of GetCreatedAt.
+func (mr *MockBaseEventMockRecorder) GetCreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatedAt", reflect.TypeOf((*MockBaseEvent)(nil).GetCreatedAt))
+}
+
 // GetEventData mocks base method.
 func (m *MockBaseEvent) GetEventData() []byte {
 	m.ctrl.T.Helper()
@@ -72,6 +130,20 @@ func (mr *MockBaseEventMockRecorder) GetID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetID", reflect.TypeOf((*MockBaseEvent)(nil).GetID))
 }
 
+// GetMaxRetry mocks base method.
+func (m *MockBaseEvent) GetMaxRetry() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxRetry")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxRetry indicates an expected call of GetMaxRetry.
+func (mr *MockBaseEventMockRecorder) GetMaxRetry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxRetry", reflect.TypeOf((*MockBaseEvent)(nil).GetMaxRetry))
+}
+
 // GetOrigin mocks base method.
 func (m *MockBaseEvent) GetOrigin() string {
 	m.ctrl.T.Helper()
@@ -86,6 +158,62 @@ func (mr *MockBaseEventMockRecorder) GetOrigin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrigin", reflect.TypeOf((*MockBaseEvent)(nil).GetOrigin))
 }
 
+// GetRetry mocks base method.
+func (m *MockBaseEvent) GetRetry() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetry")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRetry indicates an expected call of GetRetry.
+func (mr *MockBaseEventMockRecorder) GetRetry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetry", reflect.TypeOf((*MockBaseEvent)(nil).GetRetry))
+}
+
+// GetScheduledAt mocks base method.
+func (m *MockBaseEvent) GetScheduledAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetScheduledAt indicates an expected call of GetScheduledAt.
+func (mr *MockBaseEventMockRecorder) GetScheduledAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledAt", reflect.TypeOf((*MockBaseEvent)(nil).GetScheduledAt))
+}
+
+// GetStartedAt mocks base method.
+func (m *MockBaseEvent) GetStartedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStartedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetStartedAt indicates an expected call of GetStartedAt.
+func (mr *MockBaseEventMockRecorder) GetStartedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartedAt", reflect.TypeOf((*MockBaseEvent)(nil).GetStartedAt))
+}
+
+// GetState mocks base method.
+func (m *MockBaseEvent) GetState() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockBaseEventMockRecorder) GetState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockBaseEvent)(nil).GetState))
+}
+
 // GetType mocks base method.
 func (m *MockBaseEvent) GetType() string {
 	m.ctrl.T.Helper()
@@ -100,6 +228,20 @@ func (mr *MockBaseEventMockRecorder) GetType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetType", reflect.TypeOf((*MockBaseEvent)(nil).GetType))
 }
 
+// GetTypeVersion mocks base method.
+func (m *MockBaseEvent) GetTypeVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTypeVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTypeVersion indicates an expected call of GetTypeVersion.
+func (mr *MockBaseEventMockRecorder) GetTypeVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTypeVersion", reflect.TypeOf((*MockBaseEvent)(nil).GetTypeVersion))
+}
+
 // MockOrchestratorRepository is a mock of OrchestratorRepository interface.
 type MockOrchestratorRepository struct {
 	ctrl     *gomock.Controller
@@ -124,6 +266,20 @@ func (m *MockOrchestratorRepository) EXPECT() *MockOrchestratorRepositoryMockRec
 	return m.recorder
 }
 
+// AbortEvent mocks base method.
+func (m *MockOrchestratorRepository) AbortEvent(ctx context.Context, id uuid.UUID, actor, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortEvent", ctx, id, actor, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortEvent indicates an expected call of AbortEvent.
+func (mr *MockOrchestratorRepositoryMockRecorder) AbortEvent(ctx, id, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortEvent", reflect.TypeOf((*MockOrchestratorRepository)(nil).AbortEvent), ctx, id, actor, reason)
+}
+
 // FindAllEvents mocks base method.
 func (m *MockOrchestratorRepository) FindAllEvents(ctx context.Context) ([]*event.BaseEvent, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +325,36 @@ func (mr *MockOrchestratorRepositoryMockRecorder) FindByOriginAndStatus(ctx, ori
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOriginAndStatus", reflect.TypeOf((*MockOrchestratorRepository)(nil).FindByOriginAndStatus), ctx, origin, state, limit)
 }
 
+// FindEventAudits mocks base method.
+func (m *MockOrchestratorRepository) FindEventAudits(ctx context.Context, eventID uuid.UUID) ([]event.Audit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventAudits", ctx, eventID)
+	ret0, _ := ret[0].([]event.Audit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventAudits indicates an expected call of FindEventAudits.
+func (mr *MockOrchestratorRepositoryMockRecorder) FindEventAudits(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventAudits", reflect.TypeOf((*MockOrchestratorRepository)(nil).FindEventAudits), ctx, eventID)
+}
+
+// FindEvents mocks base method.
+func (m *MockOrchestratorRepository) FindEvents(ctx context.Context, state, origin string, limit, offset int) ([]*event.BaseEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEvents", ctx, state, origin, limit, offset)
+	ret0, _ := ret[0].([]*event.BaseEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEvents indicates an expected call of FindEvents.
+func (mr *MockOrchestratorRepositoryMockRecorder) FindEvents(ctx, state, origin, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEvents", reflect.TypeOf((*MockOrchestratorRepository)(nil).FindEvents), ctx, state, origin, limit, offset)
+}
+
 // FindProcessableEvents mocks base method.
 func (m *MockOrchestratorRepository) FindProcessableEvents(ctx context.Context, limit int) ([]*event.BaseEvent, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +370,35 @@ func (mr *MockOrchestratorRepositoryMockRecorder) FindProcessableEvents(ctx, lim
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProcessableEvents", reflect.TypeOf((*MockOrchestratorRepository)(nil).FindProcessableEvents), ctx, limit)
 }
 
+// RequeueEvent mocks base method.
+func (m *MockOrchestratorRepository) RequeueEvent(ctx context.Context, id uuid.UUID, actor, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEvent", ctx, id, actor, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueEvent indicates an expected call of RequeueEvent.
+func (mr *MockOrchestratorRepositoryMockRecorder) RequeueEvent(ctx, id, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEvent", reflect.TypeOf((*MockOrchestratorRepository)(nil).RequeueEvent), ctx, id, actor, reason)
+}
+
+// RequeueEvents mocks base method.
+func (m *MockOrchestratorRepository) RequeueEvents(ctx context.Context, state, origin, actor, reason string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueEvents", ctx, state, origin, actor, reason)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueEvents indicates an expected call of RequeueEvents.
+func (mr *MockOrchestratorRepositoryMockRecorder) RequeueEvents(ctx, state, origin, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueEvents", reflect.TypeOf((*MockOrchestratorRepository)(nil).RequeueEvents), ctx, state, origin, actor, reason)
+}
+
 // UpdateEventCompletion mocks base method.
 func (m *MockOrchestratorRepository) UpdateEventCompletion(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -199,17 +414,17 @@ func (mr *MockOrchestratorRepositoryMockRecorder) UpdateEventCompletion(ctx, id
 }
 
 // UpdateEventRetry mocks base method.
-func (m *MockOrchestratorRepository) UpdateEventRetry(ctx context.Context, id uuid.UUID, retryInterval int) error {
+func (m *MockOrchestratorRepository) UpdateEventRetry(ctx context.Context, id uuid.UUID, cause error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEventRetry", ctx, id, retryInterval)
+	ret := m.ctrl.Call(m, "UpdateEventRetry", ctx, id, cause)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateEventRetry indicates an expected call of UpdateEventRetry.
-func (mr *MockOrchestratorRepositoryMockRecorder) UpdateEventRetry(ctx, id, retryInterval any) *gomock.Call {
+func (mr *MockOrchestratorRepositoryMockRecorder) UpdateEventRetry(ctx, id, cause any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventRetry", reflect.TypeOf((*MockOrchestratorRepository)(nil).UpdateEventRetry), ctx, id, retryInterval)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventRetry", reflect.TypeOf((*MockOrchestratorRepository)(nil).UpdateEventRetry), ctx, id, cause)
 }
 
 // UpdateEventStart mocks base method.
@@ -321,6 +536,49 @@ func (mr *MockAccountRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAccountRepository)(nil).FindByID), ctx, id)
 }
 
+// HasStatusChangedBefore mocks base method.
+func (m *MockAccountRepository) HasStatusChangedBefore(ctx context.Context, id uuid.UUID, version int, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasStatusChangedBefore", ctx, id, version, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasStatusChangedBefore indicates an expected call of HasStatusChangedBefore.
+func (mr *MockAccountRepositoryMockRecorder) HasStatusChangedBefore(ctx, id, version, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasStatusChangedBefore", reflect.TypeOf((*MockAccountRepository)(nil).HasStatusChangedBefore), ctx, id, version, at)
+}
+
+// UnblockAccount mocks base method.
+func (m *MockAccountRepository) UnblockAccount(ctx context.Context, accountEvent account.AccountUnblockedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockAccount", ctx, accountEvent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockAccount indicates an expected call of UnblockAccount.
+func (mr *MockAccountRepositoryMockRecorder) UnblockAccount(ctx, accountEvent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockAccount", reflect.TypeOf((*MockAccountRepository)(nil).UnblockAccount), ctx, accountEvent)
+}
+
+// UpdateAccountStatus mocks base method.
+func (m *MockAccountRepository) UpdateAccountStatus(ctx context.Context, id uuid.UUID, status account.AccountStatus, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatus", ctx, id, status, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockAccountRepositoryMockRecorder) UpdateAccountStatus(ctx, id, status, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccountStatus), ctx, id, status, updatedAt)
+}
+
 // WithdrawFunds mocks base method.
 func (m *MockAccountRepository) WithdrawFunds(ctx context.Context, accountEvent account.AccountFundsWithdrawnEvent) error {
 	m.ctrl.T.Helper()
@@ -335,6 +593,99 @@ func (mr *MockAccountRepositoryMockRecorder) WithdrawFunds(ctx, accountEvent any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawFunds", reflect.TypeOf((*MockAccountRepository)(nil).WithdrawFunds), ctx, accountEvent)
 }
 
+// MockAccountEventRepository is a mock of AccountEventRepository interface.
+type MockAccountEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountEventRepositoryMockRecorder is the mock recorder for MockAccountEventRepository.
+type MockAccountEventRepositoryMockRecorder struct {
+	mock *MockAccountEventRepository
+}
+
+// NewMockAccountEventRepository creates a new mock instance.
+func NewMockAccountEventRepository(ctrl *gomock.Controller) *MockAccountEventRepository {
+	mock := &MockAccountEventRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountEventRepository) EXPECT() *MockAccountEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindEventsAfterVersion mocks base method.
+func (m *MockAccountEventRepository) FindEventsAfterVersion(ctx context.Context, id uuid.UUID, version int) ([]account.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventsAfterVersion", ctx, id, version)
+	ret0, _ := ret[0].([]account.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventsAfterVersion indicates an expected call of FindEventsAfterVersion.
+func (mr *MockAccountEventRepositoryMockRecorder) FindEventsAfterVersion(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsAfterVersion", reflect.TypeOf((*MockAccountEventRepository)(nil).FindEventsAfterVersion), ctx, id, version)
+}
+
+// FindEventsByContextID mocks base method.
+func (m *MockAccountEventRepository) FindEventsByContextID(ctx context.Context, id uuid.UUID) ([]account.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventsByContextID", ctx, id)
+	ret0, _ := ret[0].([]account.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventsByContextID indicates an expected call of FindEventsByContextID.
+func (mr *MockAccountEventRepositoryMockRecorder) FindEventsByContextID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsByContextID", reflect.TypeOf((*MockAccountEventRepository)(nil).FindEventsByContextID), ctx, id)
+}
+
+// MockAccountSnapshotRepository is a mock of AccountSnapshotRepository interface.
+type MockAccountSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountSnapshotRepositoryMockRecorder is the mock recorder for MockAccountSnapshotRepository.
+type MockAccountSnapshotRepositoryMockRecorder struct {
+	mock *MockAccountSnapshotRepository
+}
+
+// NewMockAccountSnapshotRepository creates a new mock instance.
+func NewMockAccountSnapshotRepository(ctrl *gomock.Controller) *MockAccountSnapshotRepository {
+	mock := &MockAccountSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountSnapshotRepository) EXPECT() *MockAccountSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLatestSnapshot mocks base method.
+func (m *MockAccountSnapshotRepository) FindLatestSnapshot(ctx context.Context, id uuid.UUID) (account.AccountSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestSnapshot", ctx, id)
+	ret0, _ := ret[0].(account.AccountSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestSnapshot indicates an expected call of FindLatestSnapshot.
+func (mr *MockAccountSnapshotRepositoryMockRecorder) FindLatestSnapshot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestSnapshot", reflect.TypeOf((*MockAccountSnapshotRepository)(nil).FindLatestSnapshot), ctx, id)
+}
+
 // MockCustomerRepository is a mock of CustomerRepository interface.
 type MockCustomerRepository struct {
 	ctrl     *gomock.Controller
@@ -372,3 +723,122 @@ func (mr *MockCustomerRepositoryMockRecorder) CreateCustomer(ctx, arg1 any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).CreateCustomer), ctx, arg1)
 }
+
+// UpdateCustomerStatus mocks base method.
+func (m *MockCustomerRepository) UpdateCustomerStatus(ctx context.Context, id uuid.UUID, status customer.CustomerStatus, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomerStatus", ctx, id, status, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomerStatus indicates an expected call of UpdateCustomerStatus.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateCustomerStatus(ctx, id, status, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomerStatus", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateCustomerStatus), ctx, id, status, updatedAt)
+}
+
+// MockTransactionRepository is a mock of TransactionRepository interface.
+type MockTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionRepositoryMockRecorder is the mock recorder for MockTransactionRepository.
+type MockTransactionRepositoryMockRecorder struct {
+	mock *MockTransactionRepository
+}
+
+// NewMockTransactionRepository creates a new mock instance.
+func NewMockTransactionRepository(ctrl *gomock.Controller) *MockTransactionRepository {
+	mock := &MockTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTransaction mocks base method.
+func (m *MockTransactionRepository) CreateTransaction(ctx context.Context, transactionEvent transaction.TransactionInitiatedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransaction", ctx, transactionEvent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransaction indicates an expected call of CreateTransaction.
+func (mr *MockTransactionRepositoryMockRecorder) CreateTransaction(ctx, transactionEvent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).CreateTransaction), ctx, transactionEvent)
+}
+
+// UpdateTransactionStatus mocks base method.
+func (m *MockTransactionRepository) UpdateTransactionStatus(ctx context.Context, id uuid.UUID, status transaction.TransactionStatus, failureReason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionStatus", ctx, id, status, failureReason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTransactionStatus indicates an expected call of UpdateTransactionStatus.
+func (mr *MockTransactionRepositoryMockRecorder) UpdateTransactionStatus(ctx, id, status, failureReason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionStatus", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateTransactionStatus), ctx, id, status, failureReason)
+}
+
+// MockEventRepository is a mock of EventRepository interface.
+type MockEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventRepositoryMockRecorder is the mock recorder for MockEventRepository.
+type MockEventRepositoryMockRecorder struct {
+	mock *MockEventRepository
+}
+
+// NewMockEventRepository creates a new mock instance.
+func NewMockEventRepository(ctrl *gomock.Controller) *MockEventRepository {
+	mock := &MockEventRepository{ctrl: ctrl}
+	mock.recorder = &MockEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEvents mocks base method.
+func (m *MockEventRepository) CreateEvents(ctx context.Context, expectedVersions map[uuid.UUID]int, events []event.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvents", ctx, expectedVersions, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvents indicates an expected call of CreateEvents.
+func (mr *MockEventRepositoryMockRecorder) CreateEvents(ctx, expectedVersions, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockEventRepository)(nil).CreateEvents), ctx, expectedVersions, events)
+}
+
+// FindAggregateVersion mocks base method.
+func (m *MockEventRepository) FindAggregateVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAggregateVersion", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAggregateVersion indicates an expected call of FindAggregateVersion.
+func (mr *MockEventRepositoryMockRecorder) FindAggregateVersion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAggregateVersion", reflect.TypeOf((*MockEventRepository)(nil).FindAggregateVersion), ctx, id)
+}
@@ -1 +1,19 @@
 package processor
+
+import (
+	"errors"
+)
+
+// Transfer saga errors, recorded as the reason of a failed or compensated transaction
+var (
+	// ErrSourceAccountNotFound is returned when the account the money is transferred from is not found
+	ErrSourceAccountNotFound = errors.New("source account not found")
+	// ErrTargetAccountNotFound is returned when the account the money is transferred to is not found
+	ErrTargetAccountNotFound = errors.New("target account not found")
+	// ErrAccountNotActive is returned when an account taking part in the transfer is not active
+	ErrAccountNotActive = errors.New("account is not active")
+	// ErrCurrencyMismatch is returned when the account currency differs from the transfer currency
+	ErrCurrencyMismatch = errors.New("account currency differs from transfer currency")
+	// ErrInsufficientFunds is returned when the source account balance doesn't cover the transfer
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
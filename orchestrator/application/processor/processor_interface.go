@@ -2,20 +2,60 @@ package processor
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
 	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
 )
 
 //go:generate mockgen -destination=./mock/processor_mock.go -package=mock -source=./processor_interface.go
 
+// BaseEvent represents an event read from the events table
 type BaseEvent interface {
+	// GetID returns the unique identifier of the event
 	GetID() uuid.UUID
+
+	// GetContextID returns the unique identifier of the context that the event belongs to, i.e. account ID, customer ID, etc.
+	GetContextID() uuid.UUID
+
+	// GetOrigin returns the origin of the event, i.e. account, customer, etc.
 	GetOrigin() string
+
+	// GetType returns the type of the event, i.e. account.funds.withdrawn, customer.created, etc.
 	GetType() string
+
+	// GetTypeVersion returns the version of the event's type, i.e. 0.0.1
+	GetTypeVersion() string
+
+	// GetState returns the state of the event, i.e. created, completed, failed, aborted
+	GetState() string
+
+	// GetCreatedAt returns the date and time the event was created
+	GetCreatedAt() time.Time
+
+	// GetScheduledAt returns the date and time the event was scheduled to be processed (if applicable)
+	GetScheduledAt() time.Time
+
+	// GetStartedAt returns the date and time the event was started
+	GetStartedAt() time.Time
+
+	// GetCompletedAt returns the date and time the event was completed
+	GetCompletedAt() time.Time
+
+	// GetRetry returns the number of times the event has been processed
+	GetRetry() int
+
+	// GetMaxRetry returns the maximum number of times the event can be retried
+	GetMaxRetry() int
+
+	// GetAggregateVersion returns the version of the aggregate (context) the event leads to
+	GetAggregateVersion() int
+
+	// GetEventData returns the event data, the JSON encoded payload of the event
 	GetEventData() []byte
 }
 
@@ -25,22 +65,33 @@ type OrchestratorRepository interface {
 
 	// FindAllEvents returns all events
 	FindAllEvents(ctx context.Context) ([]*eventdomain.BaseEvent, error)
-	// FindProcessableEvents returns all events that are ready to be processed
+	// FindProcessableEvents returns the events which are ready and due to be processed, the oldest scheduled first
 	FindProcessableEvents(ctx context.Context, limit int) ([]*eventdomain.BaseEvent, error)
 	// FindByOriginAndStatus returns all events that match the origin and status
 	FindByOriginAndStatus(ctx context.Context, origin, state string, limit int) ([]*eventdomain.BaseEvent, error)
 	// FindByID returns an event by its ID
 	FindByID(ctx context.Context, id uuid.UUID) (*eventdomain.BaseEvent, error)
+	// FindEvents returns the events in the state and of the origin paginated with the limit and offset, an empty state or origin matches any
+	FindEvents(ctx context.Context, state, origin string, limit, offset int) ([]*eventdomain.BaseEvent, error)
+	// FindEventAudits returns the actions taken by operators on the event
+	FindEventAudits(ctx context.Context, eventID uuid.UUID) ([]eventdomain.Audit, error)
 
 	// Command Operations
 	// UpdateEventStart updates the event at start up
 	UpdateEventStart(ctx context.Context, id uuid.UUID) error
 	// UpdateEventCompletion updates the event at completion
 	UpdateEventCompletion(ctx context.Context, id uuid.UUID) error
-	// UpdateEventRetry updates the event at retry
-	UpdateEventRetry(ctx context.Context, id uuid.UUID, retryInterval int) error
+	// UpdateEventRetry schedules the event which failed with the cause to be processed again,
+	// the retry policy of the event fails it when the cause is terminal or the attempts are exhausted
+	UpdateEventRetry(ctx context.Context, id uuid.UUID, cause error) error
 	// UpdateEventState updates the event state
 	UpdateEventState(ctx context.Context, id uuid.UUID, state string) error
+	// RequeueEvent requeues the dead-lettered event with its retry counter reset and records it in the event audit
+	RequeueEvent(ctx context.Context, id uuid.UUID, actor, reason string) error
+	// RequeueEvents requeues the dead-lettered events in the state and of the origin and records them in the event audit
+	RequeueEvents(ctx context.Context, state, origin, actor, reason string) (int, error)
+	// AbortEvent aborts the event and records it in the event audit
+	AbortEvent(ctx context.Context, id uuid.UUID, actor, reason string) error
 }
 
 // AccountRepository defines the interface for account operations
@@ -49,16 +100,56 @@ type AccountRepository interface {
 	CreateAccount(ctx context.Context, accountEvent accountdomain.AccountCreatedEvent) error
 	// FindByID returns an account by its ID
 	FindByID(ctx context.Context, id uuid.UUID) (accountdomain.Account, error)
+	// HasStatusChangedBefore reports whether the account status was changed by an event recorded after the version and before the time
+	HasStatusChangedBefore(ctx context.Context, id uuid.UUID, version int, at time.Time) (bool, error)
 	// WithdrawFunds withdraws funds from an account
 	WithdrawFunds(ctx context.Context, accountEvent accountdomain.AccountFundsWithdrawnEvent) error
 	// DepositFunds deposits funds into an account
 	DepositFunds(ctx context.Context, accountEvent accountdomain.AccountFundsDepositedEvent) error
 	// BlockAccount blocks an account
 	BlockAccount(ctx context.Context, accountEvent accountdomain.AccountBlockedEvent) error
+	// UnblockAccount unblocks an account
+	UnblockAccount(ctx context.Context, accountEvent accountdomain.AccountUnblockedEvent) error
+	// UpdateAccountStatus records the account status the account lifecycle event leads to, i.e. closed
+	UpdateAccountStatus(ctx context.Context, id uuid.UUID, status accountdomain.AccountStatus, updatedAt time.Time) error
+}
+
+// AccountEventRepository defines the interface for reading the account events the saga rebuilds the accounts from
+type AccountEventRepository interface {
+	// FindEventsByContextID retrieves the events of an account in the order they were recorded
+	FindEventsByContextID(ctx context.Context, id uuid.UUID) ([]accountdomain.Event, error)
+	// FindEventsAfterVersion retrieves the events of an account recorded after the version in the order they were recorded
+	FindEventsAfterVersion(ctx context.Context, id uuid.UUID, version int) ([]accountdomain.Event, error)
+}
+
+// AccountSnapshotRepository defines the interface for reading the account snapshots the saga restores the accounts from
+type AccountSnapshotRepository interface {
+	// FindLatestSnapshot retrieves the latest snapshot of an account,
+	// it returns accountdomain.ErrAccountSnapshotNotFound when the account has no snapshot
+	FindLatestSnapshot(ctx context.Context, id uuid.UUID) (accountdomain.AccountSnapshot, error)
 }
 
 // CustomerRepository defines the interface for customer operations
 type CustomerRepository interface {
 	// CreateCustomer creates a new customer
 	CreateCustomer(ctx context.Context, customer customerdomain.CustomerCreatedEvent) error
+	// UpdateCustomerStatus records the customer status the customer event leads to, i.e. blocked
+	UpdateCustomerStatus(ctx context.Context, id uuid.UUID, status customerdomain.CustomerStatus, updatedAt time.Time) error
+}
+
+// TransactionRepository defines the interface for transaction operations
+type TransactionRepository interface {
+	// CreateTransaction creates a new transaction
+	CreateTransaction(ctx context.Context, transactionEvent transactiondomain.TransactionInitiatedEvent) error
+	// UpdateTransactionStatus updates the status and the failure reason of a transaction
+	UpdateTransactionStatus(ctx context.Context, id uuid.UUID, status transactiondomain.TransactionStatus, failureReason string) error
+}
+
+// EventRepository defines the interface for recording events emitted while processing other events
+type EventRepository interface {
+	// FindAggregateVersion returns the version of the last event recorded for the aggregate (context), 0 when it has no events
+	FindAggregateVersion(ctx context.Context, id uuid.UUID) (int, error)
+	// CreateEvents persists the events atomically, all of them or none. The events of each aggregate are recorded after its expected
+	// version, it returns eventdomain.ErrConcurrencyConflict when another command recorded the next version of any of them in the meantime
+	CreateEvents(ctx context.Context, expectedVersions map[uuid.UUID]int, events []eventdomain.Event) error
 }
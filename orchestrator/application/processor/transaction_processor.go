@@ -0,0 +1,408 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+type (
+	TransactionInitiatedEvent     = transactiondomain.TransactionInitiatedEvent
+	TransactionFundsReservedEvent = transactiondomain.TransactionFundsReservedEvent
+	TransactionCompletedEvent     = transactiondomain.TransactionCompletedEvent
+	TransactionFailedEvent        = transactiondomain.TransactionFailedEvent
+	TransactionCompensatedEvent   = transactiondomain.TransactionCompensatedEvent
+)
+
+// maxStepAttempts is the number of times a saga step is run when the accounts it decided on are changed concurrently
+const maxStepAttempts = 3
+
+// TransactionProcessor handles the processing of transaction-related events.
+// It drives the transfer saga: every processed step records the account events
+// together with the transaction event which triggers the next step.
+type TransactionProcessor struct {
+	// orchestrator repository
+	orcRepo OrchestratorRepository
+	// event repository
+	eventRepo EventRepository
+	// account event repository
+	accountEventRepo AccountEventRepository
+	// account snapshot repository
+	accountSnapshotRepo AccountSnapshotRepository
+	// transaction repository
+	transactionRepo TransactionRepository
+	// transaction events registry
+	registry *eventdomain.Registry
+}
+
+// NewTransactionProcessor creates a new transaction event processor
+func NewTransactionProcessor(
+	orcRepo OrchestratorRepository,
+	eventRepo EventRepository,
+	accountEventRepo AccountEventRepository,
+	accountSnapshotRepo AccountSnapshotRepository,
+	transactionRepo TransactionRepository,
+) *TransactionProcessor {
+	registry := eventdomain.NewRegistry()
+	transactiondomain.RegisterEvents(registry)
+
+	return &TransactionProcessor{
+		orcRepo:             orcRepo,
+		eventRepo:           eventRepo,
+		accountEventRepo:    accountEventRepo,
+		accountSnapshotRepo: accountSnapshotRepo,
+		transactionRepo:     transactionRepo,
+		registry:            registry,
+	}
+}
+
+// Process handles the transaction event processing
+func (p *TransactionProcessor) Process(ctx context.Context, event BaseEvent) error {
+	switch event.GetType() {
+	case transactiondomain.TransactionInitiatedEventType.String():
+		transactionEvent, err := UnmarshalEvent[TransactionInitiatedEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal transaction initiated event: %w", err)
+		}
+
+		return p.handleTransactionInitiatedEvent(ctx, transactionEvent.Data)
+
+	case transactiondomain.TransactionFundsReservedEventType.String():
+		transactionEvent, err := UnmarshalEvent[TransactionFundsReservedEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal transaction funds reserved event: %w", err)
+		}
+
+		return p.handleTransactionFundsReservedEvent(ctx, transactionEvent.Data)
+
+	case transactiondomain.TransactionCompletedEventType.String():
+		transactionEvent, err := UnmarshalEvent[TransactionCompletedEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal transaction completed event: %w", err)
+		}
+
+		return p.handleTransactionFinishedEvent(ctx, transactionEvent.Data.BaseEvent, transactiondomain.TransactionStatusCompleted, "")
+
+	case transactiondomain.TransactionFailedEventType.String():
+		transactionEvent, err := UnmarshalEvent[TransactionFailedEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal transaction failed event: %w", err)
+		}
+
+		return p.handleTransactionFinishedEvent(ctx, transactionEvent.Data.BaseEvent, transactiondomain.TransactionStatusFailed, transactionEvent.Data.Reason)
+
+	case transactiondomain.TransactionCompensatedEventType.String():
+		transactionEvent, err := UnmarshalEvent[TransactionCompensatedEvent](p.registry, event)
+		if err != nil {
+			return fmt.Errorf("unmarshal transaction compensated event: %w", err)
+		}
+
+		return p.handleTransactionFinishedEvent(ctx, transactionEvent.Data.BaseEvent, transactiondomain.TransactionStatusCompensated, transactionEvent.Data.Reason)
+
+	default:
+		if err := p.handleUnknownEvent(ctx, event.GetID()); err != nil {
+			return fmt.Errorf("handling unknown transaction event: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// handleTransactionInitiatedEvent records the transaction and reserves the funds on the source account
+func (p *TransactionProcessor) handleTransactionInitiatedEvent(ctx context.Context, transactionEvent TransactionInitiatedEvent) error {
+	errCreate := p.transactionRepo.CreateTransaction(ctx, transactionEvent)
+	if errCreate != nil && !errors.Is(errCreate, transactiondomain.ErrTransactionAlreadyExists) {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, transactionEvent.ID, errCreate); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after creating transaction failure: %w", errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	return p.runStep(ctx, transactionEvent.BaseEvent, func(ctx context.Context) (sagaStep, error) {
+		transaction := &transactiondomain.Transaction{
+			ID:       transactionEvent.ContextID,
+			Transfer: transactionEvent.Transfer,
+			Status:   transactiondomain.TransactionStatusInitiated,
+		}
+
+		return p.reserveFunds(ctx, transaction)
+	})
+}
+
+// handleTransactionFundsReservedEvent credits the target account, or returns the funds to the source account
+// when the target account can't be credited
+func (p *TransactionProcessor) handleTransactionFundsReservedEvent(ctx context.Context, transactionEvent TransactionFundsReservedEvent) error {
+	errUpdate := p.transactionRepo.UpdateTransactionStatus(ctx, transactionEvent.ContextID, transactiondomain.TransactionStatusReserved, "")
+	if errUpdate != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, transactionEvent.ID, errUpdate); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating transaction status failure: %w", errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	return p.runStep(ctx, transactionEvent.BaseEvent, func(ctx context.Context) (sagaStep, error) {
+		transaction := &transactiondomain.Transaction{
+			ID:       transactionEvent.ContextID,
+			Transfer: transactionEvent.Transfer,
+			Status:   transactiondomain.TransactionStatusReserved,
+		}
+
+		return p.creditFunds(ctx, transaction)
+	})
+}
+
+// handleTransactionFinishedEvent updates the transaction with the final status of the saga
+func (p *TransactionProcessor) handleTransactionFinishedEvent(
+	ctx context.Context,
+	transactionEvent eventdomain.BaseEvent,
+	status transactiondomain.TransactionStatus,
+	reason string,
+) error {
+	errUpdate := p.transactionRepo.UpdateTransactionStatus(ctx, transactionEvent.ContextID, status, reason)
+	if errUpdate != nil {
+		if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, transactionEvent.ID, errUpdate); errUpdateRetry != nil {
+			return fmt.Errorf("updating event retry after updating transaction status failure: %w", errUpdateRetry)
+		}
+
+		return nil
+	}
+
+	if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, transactionEvent.ID); errUpdateCompletion != nil {
+		return fmt.Errorf("updating event completion: %w", errUpdateCompletion)
+	}
+
+	return nil
+}
+
+// reserveFunds withdraws the amount from the source account.
+// The transaction fails when the source account can't cover the transfer.
+func (p *TransactionProcessor) reserveFunds(ctx context.Context, transaction *transactiondomain.Transaction) (sagaStep, error) {
+	source, err := p.findAccount(ctx, transaction.SourceAccountID)
+	if err != nil {
+		if !errors.Is(err, accountdomain.ErrAccountNotFound) {
+			return sagaStep{}, fmt.Errorf("finding source account: %w", err)
+		}
+
+		if err := transaction.Fail(ErrSourceAccountNotFound.Error()); err != nil {
+			return sagaStep{}, err
+		}
+
+		return newSagaStep(transaction), nil
+	}
+
+	errReject := checkAccount(source, transaction.Transfer)
+	if errReject == nil {
+		if cmp, _ := source.Balance.Cmp(transaction.Amount); cmp < 0 {
+			errReject = ErrInsufficientFunds
+		}
+	}
+
+	if errReject != nil {
+		if err := transaction.Fail(errReject.Error()); err != nil {
+			return sagaStep{}, err
+		}
+
+		return newSagaStep(transaction), nil
+	}
+
+	if err := source.Withdraw(transaction.Amount); err != nil {
+		return sagaStep{}, fmt.Errorf("withdrawing funds from source account: %w", err)
+	}
+
+	if err := transaction.ReserveFunds(); err != nil {
+		return sagaStep{}, err
+	}
+
+	return newSagaStep(transaction, source), nil
+}
+
+// creditFunds deposits the amount into the target account.
+// The reserved funds are returned to the source account when the target account can't be credited.
+func (p *TransactionProcessor) creditFunds(ctx context.Context, transaction *transactiondomain.Transaction) (sagaStep, error) {
+	target, err := p.findAccount(ctx, transaction.TargetAccountID)
+	if err != nil {
+		if !errors.Is(err, accountdomain.ErrAccountNotFound) {
+			return sagaStep{}, fmt.Errorf("finding target account: %w", err)
+		}
+
+		return p.compensate(ctx, transaction, ErrTargetAccountNotFound)
+	}
+
+	if errReject := checkAccount(target, transaction.Transfer); errReject != nil {
+		return p.compensate(ctx, transaction, errReject)
+	}
+
+	if err := target.Deposit(transaction.Amount); err != nil {
+		return sagaStep{}, fmt.Errorf("depositing funds into target account: %w", err)
+	}
+
+	if err := transaction.Complete(); err != nil {
+		return sagaStep{}, err
+	}
+
+	return newSagaStep(transaction, target), nil
+}
+
+// compensate returns the reserved funds to the source account, whatever the source account status is by now
+func (p *TransactionProcessor) compensate(ctx context.Context, transaction *transactiondomain.Transaction, reason error) (sagaStep, error) {
+	source, err := p.findAccount(ctx, transaction.SourceAccountID)
+	if err != nil {
+		return sagaStep{}, fmt.Errorf("finding source account: %w", err)
+	}
+
+	if err := source.Refund(transaction.Amount); err != nil {
+		return sagaStep{}, fmt.Errorf("returning funds to source account: %w", err)
+	}
+
+	if err := transaction.Compensate(reason.Error()); err != nil {
+		return sagaStep{}, err
+	}
+
+	return newSagaStep(transaction, source), nil
+}
+
+// findAccount rebuilds the account from its events, so the saga step decides on the current state of the account
+// even when the accounts read model lags behind. The account is restored from its latest snapshot and the events
+// recorded after it, or from all its events when it has no snapshot. A missing account is reported with accountdomain.ErrAccountNotFound.
+func (p *TransactionProcessor) findAccount(ctx context.Context, id uuid.UUID) (*accountdomain.Account, error) {
+	snapshot, err := p.accountSnapshotRepo.FindLatestSnapshot(ctx, id)
+	if err != nil {
+		if !errors.Is(err, accountdomain.ErrAccountSnapshotNotFound) {
+			return nil, fmt.Errorf("finding account snapshot: %w", err)
+		}
+
+		events, err := p.accountEventRepo.FindEventsByContextID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("finding account events: %w", err)
+		}
+
+		account, err := accountdomain.NewAccountFromEvents(events)
+		if err != nil {
+			return nil, fmt.Errorf("rebuilding account: %w", err)
+		}
+
+		return account, nil
+	}
+
+	events, err := p.accountEventRepo.FindEventsAfterVersion(ctx, id, snapshot.Version)
+	if err != nil {
+		return nil, fmt.Errorf("finding account events after snapshot: %w", err)
+	}
+
+	account, err := accountdomain.NewAccountFromSnapshot(snapshot, events)
+	if err != nil {
+		return nil, fmt.Errorf("restoring account from snapshot: %w", err)
+	}
+
+	return account, nil
+}
+
+// runStep runs the saga step and records the events it emitted expecting the accounts in the versions the step decided on
+// and the transaction in the version of the processed event, then completes the processed event. When any account was changed
+// concurrently, the step is run again on the accounts rebuilt once more, up to maxStepAttempts times. The step already recorded
+// by a previous attempt moved the transaction past the processed event, so it isn't run again.
+func (p *TransactionProcessor) runStep(ctx context.Context, transactionEvent eventdomain.BaseEvent, step func(ctx context.Context) (sagaStep, error)) error {
+	for attempt := 1; ; attempt++ {
+		version, err := p.eventRepo.FindAggregateVersion(ctx, transactionEvent.ContextID)
+		if err != nil {
+			return p.handleStepFailure(ctx, transactionEvent.ID, fmt.Errorf("finding transaction version: %w", err))
+		}
+
+		if version > transactionEvent.AggregateVersion {
+			return p.completeStep(ctx, transactionEvent.ID)
+		}
+
+		outcome, err := step(ctx)
+		if err != nil {
+			return p.handleStepFailure(ctx, transactionEvent.ID, err)
+		}
+
+		outcome.versions[transactionEvent.ContextID] = transactionEvent.AggregateVersion
+
+		errCreate := p.eventRepo.CreateEvents(ctx, outcome.versions, outcome.events)
+		if errCreate == nil || errors.Is(errCreate, eventdomain.ErrEventAlreadyExists) {
+			return p.completeStep(ctx, transactionEvent.ID)
+		}
+
+		if !errors.Is(errCreate, eventdomain.ErrConcurrencyConflict) || attempt == maxStepAttempts {
+			if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, transactionEvent.ID, errCreate); errUpdateRetry != nil {
+				return fmt.Errorf("updating event retry after recording saga step failure: %w", errUpdateRetry)
+			}
+
+			return nil
+		}
+	}
+}
+
+// completeStep completes the processed event once the saga step is recorded
+func (p *TransactionProcessor) completeStep(ctx context.Context, id uuid.UUID) error {
+	if errUpdateCompletion := p.orcRepo.UpdateEventCompletion(ctx, id); errUpdateCompletion != nil {
+		return fmt.Errorf("updating event completion: %w", errUpdateCompletion)
+	}
+
+	return nil
+}
+
+// handleStepFailure retries the saga step, the retry policy fails the event when the step can't be applied to the transaction
+func (p *TransactionProcessor) handleStepFailure(ctx context.Context, id uuid.UUID, errStep error) error {
+	if errUpdateRetry := p.orcRepo.UpdateEventRetry(ctx, id, errStep); errUpdateRetry != nil {
+		return fmt.Errorf("updating event retry after saga step failure: %w", errUpdateRetry)
+	}
+
+	return nil
+}
+
+// handleUnknownEvent processes unknown event types
+func (p *TransactionProcessor) handleUnknownEvent(ctx context.Context, id uuid.UUID) error {
+	return p.orcRepo.UpdateEventState(ctx, id, "unprocessable")
+}
+
+// checkAccount checks if the account can take part in the transfer
+func checkAccount(account *accountdomain.Account, transfer transactiondomain.Transfer) error {
+	if account.StatusAt(time.Now().UTC()) != accountdomain.AccountStatusActive {
+		return ErrAccountNotActive
+	}
+
+	if account.Balance.Currency() != transfer.Amount.Currency() {
+		return ErrCurrencyMismatch
+	}
+
+	return nil
+}
+
+// sagaStep is the outcome of a saga step, the events it emitted and the versions of the accounts it decided on
+type sagaStep struct {
+	events   []eventdomain.Event
+	versions map[uuid.UUID]int
+}
+
+// newSagaStep creates the outcome of the saga step, the account events are recorded before the transaction events
+func newSagaStep(transaction *transactiondomain.Transaction, accounts ...*accountdomain.Account) sagaStep {
+	step := sagaStep{versions: make(map[uuid.UUID]int, len(accounts)+1)}
+	for _, account := range accounts {
+		step.events = append(step.events, toDomainEvents(account.GetEvents())...)
+		step.versions[account.ID] = account.Version
+	}
+
+	step.events = append(step.events, toDomainEvents(transaction.GetEvents())...)
+
+	return step
+}
+
+// toDomainEvents converts the aggregate events into events which can be recorded together
+func toDomainEvents[T eventdomain.Event](events []T) []eventdomain.Event {
+	domainEvents := make([]eventdomain.Event, len(events))
+	for i, ev := range events {
+		domainEvents[i] = ev
+	}
+
+	return domainEvents
+}
@@ -0,0 +1,861 @@
+//go:build unit
+
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor/mock"
+)
+
+var (
+	testSourceAccountID = uuid.New()
+	testTargetAccountID = uuid.New()
+	testTransactionID   = uuid.New()
+)
+
+// testAccountVersion is the version of the accounts restored from their snapshots
+const testAccountVersion = 5
+
+func testTransactionBaseEvent(eventType transactiondomain.TransactionEventType) eventdomain.BaseEvent {
+	return eventdomain.BaseEvent{
+		ID:               uuid.New(),
+		ContextID:        testTransactionID,
+		Origin:           "transaction",
+		Type:             eventType.String(),
+		TypeVersion:      "0.0.0",
+		State:            "ready",
+		CreatedAt:        time.Now().UTC(),
+		MaxRetry:         3,
+		AggregateVersion: 1,
+	}
+}
+
+// testMoney creates USD money from the amount in cents
+func testMoney(amount int64) money.Money {
+	m, err := money.New(amount, "USD")
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func testTransfer() transactiondomain.Transfer {
+	return transactiondomain.Transfer{
+		SourceAccountID: testSourceAccountID,
+		TargetAccountID: testTargetAccountID,
+		Amount:          testMoney(10000),
+	}
+}
+
+func testAccountSnapshot(id uuid.UUID, balance int64, status accountdomain.AccountStatus) accountdomain.AccountSnapshot {
+	return accountdomain.AccountSnapshot{
+		ID:      id,
+		Balance: testMoney(balance),
+		Status:  status,
+		Version: testAccountVersion,
+	}
+}
+
+// accountSnapshots returns the latest snapshots of the accounts
+func accountSnapshots(snapshots ...accountdomain.AccountSnapshot) func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+	return func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+		m := mock.NewMockAccountSnapshotRepository(ctrl)
+		for _, snapshot := range snapshots {
+			m.EXPECT().FindLatestSnapshot(gomock.Any(), snapshot.ID).Return(snapshot, nil).AnyTimes()
+		}
+
+		return m
+	}
+}
+
+// noEventsAfterSnapshots returns no events recorded after the snapshots of the accounts
+func noEventsAfterSnapshots(snapshots ...accountdomain.AccountSnapshot) func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+	return func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+		m := mock.NewMockAccountEventRepository(ctrl)
+		for _, snapshot := range snapshots {
+			m.EXPECT().FindEventsAfterVersion(gomock.Any(), snapshot.ID, snapshot.Version).Return(nil, nil).AnyTimes()
+		}
+
+		return m
+	}
+}
+
+// eventTypes matches the recorded events by their types
+func eventTypes(types ...string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		events, ok := x.([]eventdomain.Event)
+		if !ok || len(events) != len(types) {
+			return false
+		}
+
+		for i, ev := range events {
+			if ev.GetType() != types[i] {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func TestTransactionProcessor_Process_TransactionInitiatedEvent(t *testing.T) {
+	type testCaseParams struct {
+		transactionInitiatedEvent func() *TransactionInitiatedEvent
+
+		mockOrchestratorRepository    func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository
+		mockEventRepository           func(ctrl *gomock.Controller) *mock.MockEventRepository
+		mockAccountEventRepository    func(ctrl *gomock.Controller) *mock.MockAccountEventRepository
+		mockAccountSnapshotRepository func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository
+		mockTransactionRepository     func(ctrl *gomock.Controller) *mock.MockTransactionRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	initiatedEvent := func() *TransactionInitiatedEvent {
+		ev := &TransactionInitiatedEvent{
+			BaseEvent: testTransactionBaseEvent(transactiondomain.TransactionInitiatedEventType),
+			Transfer:  testTransfer(),
+		}
+
+		data, err := json.Marshal(ev)
+		if err != nil {
+			t.Fatalf("failed to marshal transaction initiated event: %v", err)
+		}
+
+		ev.Data = data
+
+		return ev
+	}
+
+	createdTransaction := func(ctrl *gomock.Controller) *mock.MockTransactionRepository {
+		m := mock.NewMockTransactionRepository(ctrl)
+		m.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(nil)
+
+		return m
+	}
+
+	source := testAccountSnapshot(testSourceAccountID, 100000, accountdomain.AccountStatusActive)
+
+	testCases := []testCase{
+		{
+			name: "shouldn't process transaction initiated event - invalid data resulting in unmarshal error",
+			params: testCaseParams{
+				transactionInitiatedEvent: func() *TransactionInitiatedEvent {
+					ev := &TransactionInitiatedEvent{
+						BaseEvent: eventdomain.BaseEvent{
+							Origin: "transaction",
+							Type:   "transaction.initiated",
+						},
+					}
+
+					data, err := json.Marshal([]byte(`{ ... invalid data ... }	`))
+					if err != nil {
+						t.Fatalf("failed to marshal transaction initiated event: %v", err)
+					}
+
+					ev.Data = data
+
+					return ev
+				},
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					return mock.NewMockOrchestratorRepository(ctrl)
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(),
+				mockAccountSnapshotRepository: accountSnapshots(),
+				mockTransactionRepository: func(ctrl *gomock.Controller) *mock.MockTransactionRepository {
+					return mock.NewMockTransactionRepository(ctrl)
+				},
+			},
+			expected: testCaseExpected{
+				wantError: true,
+			},
+		},
+		{
+			name: "should retry transaction initiated event - transaction couldn't be created",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(),
+				mockAccountSnapshotRepository: accountSnapshots(),
+				mockTransactionRepository: func(ctrl *gomock.Controller) *mock.MockTransactionRepository {
+					m := mock.NewMockTransactionRepository(ctrl)
+					m.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should reserve funds on the source account",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(),
+						map[uuid.UUID]int{testSourceAccountID: testAccountVersion, testTransactionID: 1},
+						eventTypes(
+							accountdomain.AccountFundsWithdrawnEventType.String(),
+							transactiondomain.TransactionFundsReservedEventType.String(),
+						),
+					).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(source),
+				mockAccountSnapshotRepository: accountSnapshots(source),
+				mockTransactionRepository:     createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should reserve funds on the source account rebuilt from its events",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), eventTypes(
+						accountdomain.AccountFundsWithdrawnEventType.String(),
+						transactiondomain.TransactionFundsReservedEventType.String(),
+					)).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					account := accountdomain.NewAccount(testSourceAccountID, uuid.New(), "0123456789", testMoney(100000))
+					require.NoError(t, account.Activate())
+
+					m := mock.NewMockAccountEventRepository(ctrl)
+					m.EXPECT().FindEventsByContextID(gomock.Any(), testSourceAccountID).Return(account.GetEvents(), nil)
+
+					return m
+				},
+				mockAccountSnapshotRepository: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := mock.NewMockAccountSnapshotRepository(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), testSourceAccountID).Return(accountdomain.AccountSnapshot{}, accountdomain.ErrAccountSnapshotNotFound)
+
+					return m
+				},
+				mockTransactionRepository: createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should reserve funds on the source account - scheduled unblock of the source account is due",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), eventTypes(
+						accountdomain.AccountFundsWithdrawnEventType.String(),
+						transactiondomain.TransactionFundsReservedEventType.String(),
+					)).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository: noEventsAfterSnapshots(source),
+				mockAccountSnapshotRepository: func() func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					blocked := testAccountSnapshot(testSourceAccountID, 100000, accountdomain.AccountStatusBlocked)
+					blocked.UnblockAt = time.Now().UTC().Add(-time.Hour)
+
+					return accountSnapshots(blocked)
+				}(),
+				mockTransactionRepository: createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should reserve funds again - source account changed concurrently",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil).Times(2)
+					gomock.InOrder(
+						m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrConcurrencyConflict),
+						m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(source),
+				mockAccountSnapshotRepository: accountSnapshots(source),
+				mockTransactionRepository:     createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should retry transaction initiated event - source account changed concurrently on every attempt",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), eventdomain.ErrConcurrencyConflict).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil).Times(maxStepAttempts)
+					m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrConcurrencyConflict).Times(maxStepAttempts)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(source),
+				mockAccountSnapshotRepository: accountSnapshots(source),
+				mockTransactionRepository:     createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should complete event - saga step was already recorded by previous attempt",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(2, nil)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(),
+				mockAccountSnapshotRepository: accountSnapshots(),
+				mockTransactionRepository: func(ctrl *gomock.Controller) *mock.MockTransactionRepository {
+					m := mock.NewMockTransactionRepository(ctrl)
+					m.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(transactiondomain.ErrTransactionAlreadyExists)
+
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should complete event - saga step events were recorded by concurrent attempt",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrEventAlreadyExists)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(source),
+				mockAccountSnapshotRepository: accountSnapshots(source),
+				mockTransactionRepository:     createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should fail transaction - insufficient funds on the source account",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(),
+						map[uuid.UUID]int{testTransactionID: 1},
+						eventTypes(
+							transactiondomain.TransactionFailedEventType.String(),
+						),
+					).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(testAccountSnapshot(testSourceAccountID, 1000, accountdomain.AccountStatusActive)),
+				mockAccountSnapshotRepository: accountSnapshots(testAccountSnapshot(testSourceAccountID, 1000, accountdomain.AccountStatusActive)),
+				mockTransactionRepository:     createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should fail transaction - source account is blocked",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), eventTypes(
+						transactiondomain.TransactionFailedEventType.String(),
+					)).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(testAccountSnapshot(testSourceAccountID, 100000, accountdomain.AccountStatusBlocked)),
+				mockAccountSnapshotRepository: accountSnapshots(testAccountSnapshot(testSourceAccountID, 100000, accountdomain.AccountStatusBlocked)),
+				mockTransactionRepository:     createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should fail transaction - source account not found",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), eventTypes(
+						transactiondomain.TransactionFailedEventType.String(),
+					)).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := mock.NewMockAccountEventRepository(ctrl)
+					m.EXPECT().FindEventsByContextID(gomock.Any(), testSourceAccountID).Return(nil, nil)
+
+					return m
+				},
+				mockAccountSnapshotRepository: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := mock.NewMockAccountSnapshotRepository(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), testSourceAccountID).Return(accountdomain.AccountSnapshot{}, accountdomain.ErrAccountSnapshotNotFound)
+
+					return m
+				},
+				mockTransactionRepository: createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should retry transaction initiated event - source account couldn't be found",
+			params: testCaseParams{
+				transactionInitiatedEvent: initiatedEvent,
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+
+					return m
+				},
+				mockAccountEventRepository: noEventsAfterSnapshots(),
+				mockAccountSnapshotRepository: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := mock.NewMockAccountSnapshotRepository(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), testSourceAccountID).Return(accountdomain.AccountSnapshot{}, errors.New("internal error"))
+
+					return m
+				},
+				mockTransactionRepository: createdTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			processor := NewTransactionProcessor(
+				testCase.params.mockOrchestratorRepository(ctrl),
+				testCase.params.mockEventRepository(ctrl),
+				testCase.params.mockAccountEventRepository(ctrl),
+				testCase.params.mockAccountSnapshotRepository(ctrl),
+				testCase.params.mockTransactionRepository(ctrl),
+			)
+
+			err := processor.Process(context.Background(), testCase.params.transactionInitiatedEvent())
+			if testCase.expected.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransactionProcessor_Process_TransactionFundsReservedEvent(t *testing.T) {
+	type testCaseParams struct {
+		mockOrchestratorRepository    func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository
+		mockEventRepository           func(ctrl *gomock.Controller) *mock.MockEventRepository
+		mockAccountEventRepository    func(ctrl *gomock.Controller) *mock.MockAccountEventRepository
+		mockAccountSnapshotRepository func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository
+		mockTransactionRepository     func(ctrl *gomock.Controller) *mock.MockTransactionRepository
+	}
+
+	type testCaseExpected struct {
+		wantError bool
+	}
+
+	type testCase struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}
+
+	reservedTransaction := func(ctrl *gomock.Controller) *mock.MockTransactionRepository {
+		m := mock.NewMockTransactionRepository(ctrl)
+		m.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), transactiondomain.TransactionStatusReserved, "").Return(nil)
+
+		return m
+	}
+
+	source := testAccountSnapshot(testSourceAccountID, 90000, accountdomain.AccountStatusActive)
+	target := testAccountSnapshot(testTargetAccountID, 0, accountdomain.AccountStatusActive)
+
+	testCases := []testCase{
+		{
+			name: "should credit the target account and complete transaction",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(),
+						map[uuid.UUID]int{testTargetAccountID: testAccountVersion, testTransactionID: 1},
+						eventTypes(
+							accountdomain.AccountFundsDepositedEventType.String(),
+							transactiondomain.TransactionCompletedEventType.String(),
+						),
+					).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(target),
+				mockAccountSnapshotRepository: accountSnapshots(target),
+				mockTransactionRepository:     reservedTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should return funds to the source account - target account not found",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil)
+					m.EXPECT().CreateEvents(gomock.Any(),
+						map[uuid.UUID]int{testSourceAccountID: testAccountVersion, testTransactionID: 1},
+						eventTypes(
+							accountdomain.AccountFundsDepositedEventType.String(),
+							transactiondomain.TransactionCompensatedEventType.String(),
+						),
+					).Return(nil)
+
+					return m
+				},
+				mockAccountEventRepository: func(ctrl *gomock.Controller) *mock.MockAccountEventRepository {
+					m := noEventsAfterSnapshots(source)(ctrl)
+					m.EXPECT().FindEventsByContextID(gomock.Any(), testTargetAccountID).Return(nil, nil)
+
+					return m
+				},
+				mockAccountSnapshotRepository: func(ctrl *gomock.Controller) *mock.MockAccountSnapshotRepository {
+					m := accountSnapshots(source)(ctrl)
+					m.EXPECT().FindLatestSnapshot(gomock.Any(), testTargetAccountID).Return(accountdomain.AccountSnapshot{}, accountdomain.ErrAccountSnapshotNotFound)
+
+					return m
+				},
+				mockTransactionRepository: reservedTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should credit the target account again - target account changed concurrently",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventCompletion(gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					m := mock.NewMockEventRepository(ctrl)
+					m.EXPECT().FindAggregateVersion(gomock.Any(), testTransactionID).Return(1, nil).Times(2)
+					gomock.InOrder(
+						m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(eventdomain.ErrConcurrencyConflict),
+						m.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					)
+
+					return m
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(target),
+				mockAccountSnapshotRepository: accountSnapshots(target),
+				mockTransactionRepository:     reservedTransaction,
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+		{
+			name: "should retry transaction funds reserved event - transaction status couldn't be updated",
+			params: testCaseParams{
+				mockOrchestratorRepository: func(ctrl *gomock.Controller) *mock.MockOrchestratorRepository {
+					m := mock.NewMockOrchestratorRepository(ctrl)
+					m.EXPECT().UpdateEventRetry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+					return m
+				},
+				mockEventRepository: func(ctrl *gomock.Controller) *mock.MockEventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+				mockAccountEventRepository:    noEventsAfterSnapshots(),
+				mockAccountSnapshotRepository: accountSnapshots(),
+				mockTransactionRepository: func(ctrl *gomock.Controller) *mock.MockTransactionRepository {
+					m := mock.NewMockTransactionRepository(ctrl)
+					m.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				wantError: false,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			processor := NewTransactionProcessor(
+				testCase.params.mockOrchestratorRepository(ctrl),
+				testCase.params.mockEventRepository(ctrl),
+				testCase.params.mockAccountEventRepository(ctrl),
+				testCase.params.mockAccountSnapshotRepository(ctrl),
+				testCase.params.mockTransactionRepository(ctrl),
+			)
+
+			ev := &TransactionFundsReservedEvent{
+				BaseEvent: testTransactionBaseEvent(transactiondomain.TransactionFundsReservedEventType),
+				Transfer:  testTransfer(),
+			}
+
+			data, err := json.Marshal(ev)
+			require.NoError(t, err)
+			ev.Data = data
+
+			err = processor.Process(context.Background(), ev)
+			if testCase.expected.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTransactionProcessor_Process_TransactionFinishedEvents(t *testing.T) {
+	completed := &TransactionCompletedEvent{
+		BaseEvent: testTransactionBaseEvent(transactiondomain.TransactionCompletedEventType),
+		Transfer:  testTransfer(),
+	}
+	failed := &TransactionFailedEvent{
+		BaseEvent: testTransactionBaseEvent(transactiondomain.TransactionFailedEventType),
+		Transfer:  testTransfer(),
+		Reason:    ErrInsufficientFunds.Error(),
+	}
+	compensated := &TransactionCompensatedEvent{
+		BaseEvent: testTransactionBaseEvent(transactiondomain.TransactionCompensatedEventType),
+		Transfer:  testTransfer(),
+		Reason:    ErrTargetAccountNotFound.Error(),
+	}
+
+	var err error
+	completed.Data, err = json.Marshal(completed)
+	require.NoError(t, err)
+	failed.Data, err = json.Marshal(failed)
+	require.NoError(t, err)
+	compensated.Data, err = json.Marshal(compensated)
+	require.NoError(t, err)
+
+	type testCaseParams struct {
+		event         BaseEvent
+		contextID     uuid.UUID
+		status        transactiondomain.TransactionStatus
+		failureReason string
+	}
+
+	testCases := []struct {
+		name   string
+		params testCaseParams
+	}{
+		{
+			name:   "should update transaction - completed",
+			params: testCaseParams{event: completed, contextID: completed.ContextID, status: transactiondomain.TransactionStatusCompleted},
+		},
+		{
+			name:   "should update transaction - failed",
+			params: testCaseParams{event: failed, contextID: failed.ContextID, status: transactiondomain.TransactionStatusFailed, failureReason: failed.Reason},
+		},
+		{
+			name:   "should update transaction - compensated",
+			params: testCaseParams{event: compensated, contextID: compensated.ContextID, status: transactiondomain.TransactionStatusCompensated, failureReason: compensated.Reason},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orcRepo := mock.NewMockOrchestratorRepository(ctrl)
+			orcRepo.EXPECT().UpdateEventCompletion(gomock.Any(), testCase.params.event.GetID()).Return(nil)
+
+			transactionRepo := mock.NewMockTransactionRepository(ctrl)
+			transactionRepo.EXPECT().UpdateTransactionStatus(gomock.Any(), testCase.params.contextID, testCase.params.status, testCase.params.failureReason).Return(nil)
+
+			processor := NewTransactionProcessor(
+				orcRepo,
+				mock.NewMockEventRepository(ctrl),
+				mock.NewMockAccountEventRepository(ctrl),
+				mock.NewMockAccountSnapshotRepository(ctrl),
+				transactionRepo,
+			)
+
+			require.NoError(t, processor.Process(context.Background(), testCase.params.event))
+		})
+	}
+}
+
+func TestTransactionProcessor_Process_UnknownEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ev := &eventdomain.BaseEvent{ID: uuid.New(), Origin: "transaction", Type: "transaction.unknown"}
+
+	orcRepo := mock.NewMockOrchestratorRepository(ctrl)
+	orcRepo.EXPECT().UpdateEventState(gomock.Any(), ev.ID, "unprocessable").Return(nil)
+
+	processor := NewTransactionProcessor(
+		orcRepo,
+		mock.NewMockEventRepository(ctrl),
+		mock.NewMockAccountEventRepository(ctrl),
+		mock.NewMockAccountSnapshotRepository(ctrl),
+		mock.NewMockTransactionRepository(ctrl),
+	)
+
+	require.NoError(t, processor.Process(context.Background(), ev))
+}
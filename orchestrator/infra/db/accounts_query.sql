@@ -0,0 +1,27 @@
+-- name: CreateAccount :exec
+INSERT INTO accounts (id, customer_id, account_number, balance, currency, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ExistsAccountStatusEventBefore :one
+SELECT EXISTS (
+    SELECT 1 FROM events
+    WHERE context_id = $1 AND aggregate_version > $2 AND created_at < $3
+        AND event_state <> 'aborted'
+        AND event_type = ANY(sqlc.arg(event_types)::VARCHAR[])
+) AS exists;
+
+-- name: FindAccountByID :one
+SELECT * FROM accounts
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdateAccountBalance :execrows
+UPDATE accounts
+SET balance = $2,
+    updated_at = $3
+WHERE id = $1;
+
+-- name: UpdateAccountStatus :execrows
+UPDATE accounts
+SET status = $2,
+    updated_at = $3
+WHERE id = $1;
@@ -0,0 +1,9 @@
+-- name: CreateCustomer :exec
+INSERT INTO customers (id, first_name, last_name, email, phone, date_of_birth, address_street, address_city, address_state, address_zip_code, address_country, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
+
+-- name: UpdateCustomerStatus :execrows
+UPDATE customers
+SET status = $2,
+    updated_at = $3
+WHERE id = $1;
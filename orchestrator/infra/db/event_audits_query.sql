@@ -0,0 +1,8 @@
+-- name: CreateEventAudit :exec
+INSERT INTO event_audits (event_id, action, actor, reason, previous_state)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: FindEventAuditsByEventID :many
+SELECT * FROM event_audits
+WHERE event_id = $1
+ORDER BY created_at ASC;
@@ -1,23 +1,101 @@
+-- name: CreateEvents :copyfrom
+INSERT INTO events (id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, retry, max_retry, event_data, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18);
+
+-- name: CreateOutboxMessages :exec
+INSERT INTO outbox (event_id)
+SELECT id FROM events
+WHERE id = ANY(sqlc.arg(event_ids)::UUID[])
+ORDER BY sequence_number;
+
+-- name: ExistsEventAfterVersion :one
+SELECT EXISTS (
+    SELECT 1 FROM events
+    WHERE context_id = $1 AND aggregate_version > $2
+) AS exists;
+
+-- name: FindAggregateVersion :one
+SELECT COALESCE(MAX(aggregate_version), 0)::BIGINT AS aggregate_version FROM events
+WHERE context_id = $1;
+
 -- name: FindEvents :many
 SELECT * FROM events
 ORDER BY scheduled_at DESC;
 
 -- name: FindProcessableEvents :many
 SELECT * FROM events
-WHERE event_state = 'ready'
-ORDER BY scheduled_at DESC
+WHERE event_state = 'ready' AND scheduled_at <= CURRENT_TIMESTAMP
+ORDER BY scheduled_at ASC
 LIMIT (sqlc.arg('limit'));
 
+-- name: ClaimProcessableEvents :many
+UPDATE events
+SET event_state = 'processing',
+    started_at = CURRENT_TIMESTAMP,
+    lease_expires_at = CURRENT_TIMESTAMP + (sqlc.arg('lease_seconds')::INT * INTERVAL '1 second')
+WHERE id IN (
+    SELECT e.id FROM events AS e
+    WHERE ((e.event_state = 'ready' AND e.scheduled_at <= CURRENT_TIMESTAMP)
+        OR (e.event_state = 'processing' AND e.lease_expires_at < CURRENT_TIMESTAMP))
+        AND NOT EXISTS (
+            SELECT 1 FROM events AS preceding
+            WHERE preceding.context_id = e.context_id
+                AND preceding.sequence_number < e.sequence_number
+                AND preceding.event_state IN ('ready', 'processing')
+                AND (preceding.scheduled_at <= CURRENT_TIMESTAMP OR preceding.retry > 0)
+        )
+    ORDER BY e.scheduled_at ASC, e.sequence_number ASC
+    LIMIT (sqlc.arg('limit'))
+    FOR UPDATE OF e SKIP LOCKED
+)
+RETURNING *;
+
 -- name: FindEventByID :one
 SELECT * FROM events
 WHERE id = $1;
 
+-- name: FindEventByIDForUpdate :one
+SELECT * FROM events
+WHERE id = $1
+FOR UPDATE;
+
+-- name: FindEventsByFilter :many
+SELECT * FROM events
+WHERE (sqlc.arg('event_state')::VARCHAR = '' OR event_state = sqlc.arg('event_state'))
+    AND (sqlc.arg('event_origin')::VARCHAR = '' OR event_origin = sqlc.arg('event_origin'))
+ORDER BY sequence_number ASC
+LIMIT (sqlc.arg('limit')) OFFSET (sqlc.arg('offset'));
+
 -- name: FindEventsByOriginAndStatus :many
 SELECT * FROM events
 WHERE event_origin = $1 AND event_state = $2
 ORDER BY scheduled_at DESC
 LIMIT (sqlc.arg('limit'));
 
+-- name: RequeueEvent :exec
+UPDATE events
+SET event_state = 'ready',
+    retry = 0,
+    scheduled_at = CURRENT_TIMESTAMP,
+    started_at = NULL,
+    completed_at = NULL,
+    lease_expires_at = NULL,
+    failure_reason = ''
+WHERE id = $1;
+
+-- name: RequeueEventsByFilter :many
+UPDATE events
+SET event_state = 'ready',
+    retry = 0,
+    scheduled_at = CURRENT_TIMESTAMP,
+    started_at = NULL,
+    completed_at = NULL,
+    lease_expires_at = NULL,
+    failure_reason = ''
+WHERE event_state = sqlc.arg('event_state')
+    AND (sqlc.arg('event_origin')::VARCHAR = '' OR event_origin = sqlc.arg('event_origin'))
+RETURNING id;
+
 -- name: UpdateEventStartedAt :exec
 UPDATE events
 SET started_at = CURRENT_TIMESTAMP,
@@ -27,18 +105,21 @@ WHERE id = $1;
 -- name: UpdateEventRetry :exec
 UPDATE events
 SET retry = retry + 1,
-    event_state = CASE
-        WHEN retry + 1 >= max_retry THEN 'failed'
-        ELSE 'ready'
-    END,
-    scheduled_at = CASE
-        WHEN retry + 1 < max_retry THEN CURRENT_TIMESTAMP + (sqlc.arg('retry_interval') * INTERVAL '1 minute')
-        ELSE scheduled_at
-    END,
-    completed_at = CASE
-        WHEN retry + 1 >= max_retry THEN CURRENT_TIMESTAMP
-    END
-WHERE id = $1;
+    max_retry = sqlc.arg('max_retry'),
+    event_state = 'ready',
+    scheduled_at = CURRENT_TIMESTAMP + (sqlc.arg('delay_ms')::BIGINT * INTERVAL '1 millisecond'),
+    completed_at = NULL,
+    failure_reason = sqlc.arg('failure_reason')
+WHERE id = sqlc.arg('id');
+
+-- name: UpdateEventFailure :exec
+UPDATE events
+SET retry = retry + 1,
+    max_retry = sqlc.arg('max_retry'),
+    event_state = 'failed',
+    completed_at = CURRENT_TIMESTAMP,
+    failure_reason = sqlc.arg('failure_reason')
+WHERE id = sqlc.arg('id');
 
 -- name: UpdateEventStart :exec
 UPDATE events
@@ -52,3 +133,9 @@ UPDATE events
 SET completed_at = CURRENT_TIMESTAMP,
     event_state = 'completed'
 WHERE id = $1;
+
+-- name: UpdateEventState :exec
+UPDATE events
+SET event_state = $2,
+    completed_at = CURRENT_TIMESTAMP
+WHERE id = $1;
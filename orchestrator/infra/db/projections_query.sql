@@ -0,0 +1,27 @@
+-- name: CountProcessingEvents :one
+SELECT COUNT(*) FROM events
+WHERE event_origin = $1 AND event_state = 'processing';
+
+-- name: DeleteProjectionCheckpoint :exec
+DELETE FROM projection_checkpoints
+WHERE projection = $1;
+
+-- name: FindCompletedEventsAfter :many
+SELECT * FROM events
+WHERE event_origin = $1
+    AND event_state = 'completed'
+    AND (completed_at > $2 OR (completed_at = $2 AND sequence_number > $3))
+ORDER BY completed_at ASC, sequence_number ASC
+LIMIT (sqlc.arg('limit'));
+
+-- name: FindProjectionCheckpoint :one
+SELECT * FROM projection_checkpoints
+WHERE projection = $1 LIMIT 1;
+
+-- name: SaveProjectionCheckpoint :exec
+INSERT INTO projection_checkpoints (projection, completed_at, sequence_number, updated_at)
+VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+ON CONFLICT (projection) DO UPDATE
+SET completed_at = EXCLUDED.completed_at,
+    sequence_number = EXCLUDED.sequence_number,
+    updated_at = EXCLUDED.updated_at;
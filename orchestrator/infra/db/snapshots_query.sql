@@ -0,0 +1,3 @@
+-- name: DeleteSnapshotsFromVersion :exec
+DELETE FROM snapshots
+WHERE context_id = $1 AND aggregate_version >= $2;
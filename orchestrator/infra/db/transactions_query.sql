@@ -0,0 +1,10 @@
+-- name: CreateTransaction :exec
+INSERT INTO transactions (id, source_account_id, target_account_id, amount, currency, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: UpdateTransactionStatus :exec
+UPDATE transactions
+SET status = $2,
+    failure_reason = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
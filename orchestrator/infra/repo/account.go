@@ -0,0 +1,191 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/infra/repo/query"
+)
+
+// AccountRepository is the repository maintaining the accounts read model built from the account events
+type AccountRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewAccountRepository creates a new account repository
+func NewAccountRepository(conn *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{
+		Conn: conn,
+		Q:    query.New(conn),
+	}
+}
+
+// CreateAccount creates a new account from the event creating the account
+func (r *AccountRepository) CreateAccount(ctx context.Context, accountEvent accountdomain.AccountCreatedEvent) error {
+	err := r.Q.CreateAccount(ctx, query.CreateAccountParams{
+		ID:            pgtype.UUID{Bytes: accountEvent.ContextID, Valid: true},
+		CustomerID:    pgtype.UUID{Bytes: accountEvent.CustomerID, Valid: true},
+		AccountNumber: accountEvent.AccountNumber,
+		Balance:       accountEvent.InitialBalance.Amount(),
+		Currency:      accountEvent.InitialBalance.Currency().String(),
+		Status:        accountdomain.AccountStatusPending.String(),
+		CreatedAt:     pgtype.Timestamp{Time: accountEvent.CreatedAt, Valid: true},
+		UpdatedAt:     pgtype.Timestamp{Time: accountEvent.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+			return fmt.Errorf("creating account: %w", accountdomain.ErrAccountAlreadyExists)
+		}
+
+		return fmt.Errorf("creating account: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID returns an account by its ID
+func (r *AccountRepository) FindByID(ctx context.Context, id uuid.UUID) (accountdomain.Account, error) {
+	account, err := r.Q.FindAccountByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return accountdomain.Account{}, fmt.Errorf("finding account by id: %w", accountdomain.ErrAccountNotFound)
+		}
+
+		return accountdomain.Account{}, fmt.Errorf("finding account by id: %w", err)
+	}
+
+	balance, err := money.New(account.Balance, money.Currency(account.Currency))
+	if err != nil {
+		return accountdomain.Account{}, fmt.Errorf("mapping account balance: finding account by id: %w", err)
+	}
+
+	return accountdomain.Account{
+		ID:            account.ID.Bytes,
+		CustomerID:    account.CustomerID.Bytes,
+		AccountNumber: account.AccountNumber,
+		Balance:       balance,
+		Status:        accountdomain.AccountStatus(account.Status),
+		CreatedAt:     account.CreatedAt.Time,
+		UpdatedAt:     account.UpdatedAt.Time,
+	}, nil
+}
+
+// HasStatusChangedBefore reports whether the account status was changed by an event recorded after the version and before the time,
+// such a change cancels the unblocking scheduled for the time. The aborted events don't change the account.
+func (r *AccountRepository) HasStatusChangedBefore(ctx context.Context, id uuid.UUID, version int, at time.Time) (bool, error) {
+	eventTypes := make([]string, len(accountdomain.AccountStatusEventTypes))
+	for i, eventType := range accountdomain.AccountStatusEventTypes {
+		eventTypes[i] = eventType.String()
+	}
+
+	changed, err := r.Q.ExistsAccountStatusEventBefore(ctx, query.ExistsAccountStatusEventBeforeParams{
+		ContextID:        pgtype.UUID{Bytes: id, Valid: true},
+		AggregateVersion: int64(version),
+		CreatedAt:        pgtype.Timestamp{Time: at, Valid: true},
+		EventTypes:       eventTypes,
+	})
+	if err != nil {
+		return false, fmt.Errorf("finding account status changes: %w", err)
+	}
+
+	return changed, nil
+}
+
+// WithdrawFunds records the balance of the account after the withdrawal
+func (r *AccountRepository) WithdrawFunds(ctx context.Context, accountEvent accountdomain.AccountFundsWithdrawnEvent) error {
+	if err := r.updateBalance(ctx, accountEvent.ContextID, accountEvent.Balance, accountEvent.CreatedAt); err != nil {
+		return fmt.Errorf("withdrawing funds: %w", err)
+	}
+
+	return nil
+}
+
+// DepositFunds records the balance of the account after the deposit
+func (r *AccountRepository) DepositFunds(ctx context.Context, accountEvent accountdomain.AccountFundsDepositedEvent) error {
+	if err := r.updateBalance(ctx, accountEvent.ContextID, accountEvent.Balance, accountEvent.CreatedAt); err != nil {
+		return fmt.Errorf("depositing funds: %w", err)
+	}
+
+	return nil
+}
+
+// BlockAccount blocks an account
+func (r *AccountRepository) BlockAccount(ctx context.Context, accountEvent accountdomain.AccountBlockedEvent) error {
+	if err := r.updateStatus(ctx, accountEvent.ContextID, accountdomain.AccountStatusBlocked, accountEvent.CreatedAt); err != nil {
+		return fmt.Errorf("blocking account: %w", err)
+	}
+
+	return nil
+}
+
+// UnblockAccount unblocks an account, the unblocking scheduled for later takes effect at the time it was due
+func (r *AccountRepository) UnblockAccount(ctx context.Context, accountEvent accountdomain.AccountUnblockedEvent) error {
+	unblockedAt := accountEvent.CreatedAt
+	if accountEvent.ScheduledAt.After(unblockedAt) {
+		unblockedAt = accountEvent.ScheduledAt
+	}
+
+	if err := r.updateStatus(ctx, accountEvent.ContextID, accountdomain.AccountStatusActive, unblockedAt); err != nil {
+		return fmt.Errorf("unblocking account: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAccountStatus records the account status the account lifecycle event leads to, i.e. closed
+func (r *AccountRepository) UpdateAccountStatus(ctx context.Context, id uuid.UUID, status accountdomain.AccountStatus, updatedAt time.Time) error {
+	if err := r.updateStatus(ctx, id, status, updatedAt); err != nil {
+		return fmt.Errorf("changing account status: %w", err)
+	}
+
+	return nil
+}
+
+// updateBalance sets the balance of the account, the balance carried by the event is the one after the operation,
+// so recording the same event again leaves the account unchanged
+func (r *AccountRepository) updateBalance(ctx context.Context, id uuid.UUID, balance money.Money, updatedAt time.Time) error {
+	rows, err := r.Q.UpdateAccountBalance(ctx, query.UpdateAccountBalanceParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		Balance:   balance.Amount(),
+		UpdatedAt: pgtype.Timestamp{Time: updatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("updating account balance: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("updating account balance: %w", accountdomain.ErrAccountNotFound)
+	}
+
+	return nil
+}
+
+// updateStatus sets the status of the account
+func (r *AccountRepository) updateStatus(ctx context.Context, id uuid.UUID, status accountdomain.AccountStatus, updatedAt time.Time) error {
+	rows, err := r.Q.UpdateAccountStatus(ctx, query.UpdateAccountStatusParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		Status:    status.String(),
+		UpdatedAt: pgtype.Timestamp{Time: updatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("updating account status: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("updating account status: %w", accountdomain.ErrAccountNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/infra/repo/query"
+)
+
+// CustomerRepository is the repository maintaining the customers read model built from the customer events
+type CustomerRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewCustomerRepository creates a new customer repository
+func NewCustomerRepository(conn *pgxpool.Pool) *CustomerRepository {
+	return &CustomerRepository{
+		Conn: conn,
+		Q:    query.New(conn),
+	}
+}
+
+// CreateCustomer creates a new customer from the event creating the customer
+func (r *CustomerRepository) CreateCustomer(ctx context.Context, customerEvent customerdomain.CustomerCreatedEvent) error {
+	err := r.Q.CreateCustomer(ctx, query.CreateCustomerParams{
+		ID:             pgtype.UUID{Bytes: customerEvent.ContextID, Valid: true},
+		FirstName:      customerEvent.FirstName,
+		LastName:       customerEvent.LastName,
+		Email:          customerEvent.Email,
+		Phone:          pgtype.Text{String: customerEvent.Phone, Valid: true},
+		DateOfBirth:    customerEvent.DateOfBirth,
+		AddressStreet:  pgtype.Text{String: customerEvent.Address.Street, Valid: true},
+		AddressCity:    pgtype.Text{String: customerEvent.Address.City, Valid: true},
+		AddressState:   pgtype.Text{String: customerEvent.Address.State, Valid: true},
+		AddressZipCode: pgtype.Text{String: customerEvent.Address.PostalCode, Valid: true},
+		AddressCountry: pgtype.Text{String: customerEvent.Address.Country, Valid: true},
+		Status:         customerdomain.CustomerStatusActive.String(),
+		CreatedAt:      pgtype.Timestamp{Time: customerEvent.CreatedAt, Valid: true},
+		UpdatedAt:      pgtype.Timestamp{Time: customerEvent.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+			return fmt.Errorf("creating customer: %w", customerdomain.ErrCustomerAlreadyExists)
+		}
+
+		return fmt.Errorf("creating customer: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCustomerStatus records the customer status the customer event leads to, i.e. blocked.
+// The customer is updated at the time of the event, so replaying the events rebuilds the same customer.
+func (r *CustomerRepository) UpdateCustomerStatus(ctx context.Context, id uuid.UUID, status customerdomain.CustomerStatus, updatedAt time.Time) error {
+	rows, err := r.Q.UpdateCustomerStatus(ctx, query.UpdateCustomerStatusParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		Status:    status.String(),
+		UpdatedAt: pgtype.Timestamp{Time: updatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("updating customer status: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("updating customer status: %w", customerdomain.ErrCustomerNotFound)
+	}
+
+	return nil
+}
@@ -46,9 +46,44 @@ func setupTestDB(t *testing.T, keepContainer bool) (*pgxpool.Pool, string) {
 				ContainerFilePath: "/docker-entrypoint-initdb.d/0000_events.sql",
 				FileMode:          0644,
 			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0001_customers_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0001_customers.sql",
+				FileMode:          0644,
+			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0002_accounts_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0002_accounts.sql",
+				FileMode:          0644,
+			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0003_transactions_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0003_transactions.sql",
+				FileMode:          0644,
+			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0004_event_audits_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0004_event_audits.sql",
+				FileMode:          0644,
+			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0005_snapshots_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0005_snapshots.sql",
+				FileMode:          0644,
+			},
 			{
 				HostFilePath:      filepath.Join(dataDir, "0000_data.sql"),
-				ContainerFilePath: "/docker-entrypoint-initdb.d/0003_event_data.sql",
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0005_event_data.sql",
+				FileMode:          0644,
+			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0006_projection_checkpoints_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0006_projection_checkpoints.sql",
+				FileMode:          0644,
+			},
+			{
+				HostFilePath:      filepath.Join(schemaDir, "0007_outbox_table.sql"),
+				ContainerFilePath: "/docker-entrypoint-initdb.d/0007_outbox.sql",
 				FileMode:          0644,
 			},
 		},
@@ -3,6 +3,7 @@ package repo
 import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
 	"github.com/stefanowiczd/ddd-case-01/orchestrator/infra/repo/query"
 )
 
@@ -10,12 +11,15 @@ import (
 type OrchestratorRepository struct {
 	Conn *pgxpool.Pool
 	Q    *query.Queries
+	// RetryPolicies decide when the failed events are processed again
+	RetryPolicies *eventdomain.RetryPolicies
 }
 
-// NewOrchestratorRepository creates a new orchestrator repository
-func NewOrchestratorRepository(conn *pgxpool.Pool) *OrchestratorRepository {
+// NewOrchestratorRepository creates a new orchestrator repository rescheduling the failed events by the retry policies
+func NewOrchestratorRepository(conn *pgxpool.Pool, retryPolicies *eventdomain.RetryPolicies) *OrchestratorRepository {
 	return &OrchestratorRepository{
-		Conn: conn,
-		Q:    query.New(conn),
+		Conn:          conn,
+		Q:             query.New(conn),
+		RetryPolicies: retryPolicies,
 	}
 }
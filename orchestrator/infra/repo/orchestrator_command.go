@@ -2,11 +2,15 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
 	"github.com/stefanowiczd/ddd-case-01/orchestrator/infra/repo/query"
 )
 
@@ -28,14 +32,311 @@ func (r *OrchestratorRepository) UpdateEventCompletion(ctx context.Context, id u
 	return nil
 }
 
-// UpdateEventRetry updates the event at retry
-func (r *OrchestratorRepository) UpdateEventRetry(ctx context.Context, id uuid.UUID, retry int) error {
-	if err := r.Q.UpdateEventRetry(ctx, query.UpdateEventRetryParams{
-		ID:            pgtype.UUID{Bytes: id, Valid: true},
-		RetryInterval: retry,
-	}); err != nil {
+// UpdateEventRetry records the failed attempt to process the event together with its cause and schedules the next attempt by the retry policy
+// of the event type or origin. The event fails at once when the cause is terminal or the attempts of the policy are exhausted.
+func (r *OrchestratorRepository) UpdateEventRetry(ctx context.Context, id uuid.UUID, cause error) error {
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: updating event retry: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	ev, err := qtx.FindEventByIDForUpdate(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("finding event by id: %w", eventdomain.ErrEventNotFound)
+		}
+
+		return fmt.Errorf("finding event by id: %w", err)
+	}
+
+	policy := r.RetryPolicies.Policy(ev.EventOrigin, ev.EventType)
+
+	delay, retryable := policy.Next(int(ev.Retry)+1, cause)
+	if retryable {
+		err = qtx.UpdateEventRetry(ctx, query.UpdateEventRetryParams{
+			MaxRetry:      int32(policy.MaxAttempts),
+			DelayMs:       delay.Milliseconds(),
+			FailureReason: cause.Error(),
+			ID:            ev.ID,
+		})
+	} else {
+		err = qtx.UpdateEventFailure(ctx, query.UpdateEventFailureParams{
+			MaxRetry:      int32(policy.MaxAttempts),
+			FailureReason: cause.Error(),
+			ID:            ev.ID,
+		})
+	}
+	if err != nil {
 		return fmt.Errorf("updating event retry: %w", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: updating event retry: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEventState updates the event state, i.e. when the event failed or couldn't be processed
+func (r *OrchestratorRepository) UpdateEventState(ctx context.Context, id uuid.UUID, state string) error {
+	if err := r.Q.UpdateEventState(ctx, query.UpdateEventStateParams{
+		ID:         pgtype.UUID{Bytes: id, Valid: true},
+		EventState: state,
+	}); err != nil {
+		return fmt.Errorf("updating event state: %w", err)
+	}
+
+	return nil
+}
+
+// CreateEvents creates the events in a single database transaction, all of them or none.
+// The events are written with a single COPY. The events of each aggregate (context) are recorded after the version expected
+// by the step which decided on them, an aggregate missing from the expected versions is expected to have no events yet.
+// When another command recorded the next version of any of the aggregates in the meantime, none of the events is persisted
+// and ErrConcurrencyConflict is returned. The events are added to the outbox in the same transaction, so they are published once committed.
+func (r *OrchestratorRepository) CreateEvents(ctx context.Context, expectedVersions map[uuid.UUID]int, events []eventdomain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	md := eventdomain.MetadataFromContext(ctx)
+	metadata, err := eventdomain.EncodeMetadata(md)
+	if err != nil {
+		return fmt.Errorf("creating events: %w", err)
+	}
+
+	versions := make(map[uuid.UUID]int, len(expectedVersions))
+	params := make([]query.CreateEventsParams, len(events))
+	ids := make([]pgtype.UUID, len(events))
+	for i, ev := range events {
+		data, err := eventdomain.Encode(ev)
+		if err != nil {
+			return fmt.Errorf("creating events: %w", err)
+		}
+
+		version, ok := versions[ev.GetContextID()]
+		if !ok {
+			version = expectedVersions[ev.GetContextID()]
+		}
+
+		version++
+		versions[ev.GetContextID()] = version
+
+		ids[i] = pgtype.UUID{Bytes: ev.GetID(), Valid: true}
+		params[i] = query.CreateEventsParams{
+			ID:               pgtype.UUID{Bytes: ev.GetID(), Valid: true},
+			ContextID:        pgtype.UUID{Bytes: ev.GetContextID(), Valid: true},
+			EventOrigin:      ev.GetOrigin(),
+			EventType:        ev.GetType(),
+			EventTypeVersion: ev.GetTypeVersion(),
+			EventState:       ev.GetState(),
+			CreatedAt:        pgtype.Timestamp{Time: ev.GetCreatedAt(), Valid: true},
+			ScheduledAt:      pgtype.Timestamp{Time: ev.GetScheduledAt(), Valid: true},
+			Retry:            int32(ev.GetRetry()),
+			MaxRetry:         int32(ev.GetMaxRetry()),
+			EventData:        data,
+			AggregateVersion: int64(version),
+			EventMetadata:    metadata,
+			CorrelationID:    pgtype.UUID{Bytes: md.CorrelationID, Valid: md.CorrelationID != uuid.Nil},
+			CausationID:      pgtype.UUID{Bytes: md.CausationID, Valid: md.CausationID != uuid.Nil},
+			ActorID:          md.ActorID,
+			ActorType:        md.ActorType,
+			SourceIp:         md.SourceIP,
+		}
+	}
+
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: creating events: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	created, err := qtx.CreateEvents(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+			if pgErr.ConstraintName == query.EVENTS_AGGREGATE_VERSION_CONSTRAINT {
+				return fmt.Errorf("creating events: %w", eventdomain.ErrConcurrencyConflict)
+			}
+
+			return fmt.Errorf("creating events: %w", eventdomain.ErrEventAlreadyExists)
+		}
+
+		return fmt.Errorf("creating events: %w", err)
+	}
+
+	if created != int64(len(events)) {
+		return fmt.Errorf("creating events: created %d of %d events", created, len(events))
+	}
+
+	if err := qtx.CreateOutboxMessages(ctx, ids); err != nil {
+		return fmt.Errorf("creating events: adding events to outbox: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: creating events: %w", err)
+	}
+
+	return nil
+}
+
+// RequeueEvent moves the dead-lettered event back to the ready state with its retry counter reset,
+// so it is processed again. The requeue is recorded in the event audit together with the actor and the reason.
+func (r *OrchestratorRepository) RequeueEvent(ctx context.Context, id uuid.UUID, actor, reason string) error {
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: requeuing event: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	ev, err := qtx.FindEventByIDForUpdate(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("finding event by id: %w", eventdomain.ErrEventNotFound)
+		}
+
+		return fmt.Errorf("finding event by id: %w", err)
+	}
+
+	if !eventdomain.EventState(ev.EventState).CanRequeue() {
+		return fmt.Errorf("requeuing event in %s state: %w", ev.EventState, eventdomain.ErrEventStateInvalid)
+	}
+
+	if err := qtx.RequeueEvent(ctx, ev.ID); err != nil {
+		return fmt.Errorf("requeuing event: %w", err)
+	}
+
+	if err := qtx.CreateEventAudit(ctx, query.CreateEventAuditParams{
+		EventID:       ev.ID,
+		Action:        eventdomain.AuditActionRequeued.String(),
+		Actor:         actor,
+		Reason:        reason,
+		PreviousState: ev.EventState,
+	}); err != nil {
+		return fmt.Errorf("creating event audit: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: requeuing event: %w", err)
+	}
+
+	return nil
+}
+
+// RequeueEvents requeues all the dead-lettered events in the state and of the origin, an empty origin matches any.
+// Each requeued event is recorded in the event audit, it returns the number of the requeued events.
+func (r *OrchestratorRepository) RequeueEvents(ctx context.Context, state, origin, actor, reason string) (int, error) {
+	if !eventdomain.EventState(state).CanRequeue() {
+		return 0, fmt.Errorf("requeuing events in %s state: %w", state, eventdomain.ErrEventStateInvalid)
+	}
+
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: requeuing events: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	ids, err := qtx.RequeueEventsByFilter(ctx, query.RequeueEventsByFilterParams{
+		EventState:  state,
+		EventOrigin: origin,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("requeuing events by filter: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := qtx.CreateEventAudit(ctx, query.CreateEventAuditParams{
+			EventID:       id,
+			Action:        eventdomain.AuditActionRequeued.String(),
+			Actor:         actor,
+			Reason:        reason,
+			PreviousState: state,
+		}); err != nil {
+			return 0, fmt.Errorf("creating event audit: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("committing transaction: requeuing events: %w", err)
+	}
+
+	return len(ids), nil
+}
+
+// AbortEvent moves the event to the aborted state, so it is never processed and doesn't change its aggregate.
+// Only the last event of the aggregate can be aborted, the later events were decided on the state it led to, so it returns
+// ErrEventHasSuccessors for them. The snapshots taken at or after the version of the event are dropped, so the aggregate is
+// restored without it. The abort is recorded in the event audit together with the actor and the reason.
+func (r *OrchestratorRepository) AbortEvent(ctx context.Context, id uuid.UUID, actor, reason string) error {
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: aborting event: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := r.Q.WithTx(tx)
+
+	ev, err := qtx.FindEventByIDForUpdate(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("finding event by id: %w", eventdomain.ErrEventNotFound)
+		}
+
+		return fmt.Errorf("finding event by id: %w", err)
+	}
+
+	if !eventdomain.EventState(ev.EventState).CanAbort() {
+		return fmt.Errorf("aborting event in %s state: %w", ev.EventState, eventdomain.ErrEventStateInvalid)
+	}
+
+	successors, err := qtx.ExistsEventAfterVersion(ctx, query.ExistsEventAfterVersionParams{
+		ContextID:        ev.ContextID,
+		AggregateVersion: ev.AggregateVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("finding successors of event: %w", err)
+	}
+
+	if successors {
+		return fmt.Errorf("aborting event in version %d: %w", ev.AggregateVersion, eventdomain.ErrEventHasSuccessors)
+	}
+
+	if err := qtx.DeleteSnapshotsFromVersion(ctx, query.DeleteSnapshotsFromVersionParams{
+		ContextID:        ev.ContextID,
+		AggregateVersion: ev.AggregateVersion,
+	}); err != nil {
+		return fmt.Errorf("deleting snapshots from version %d: %w", ev.AggregateVersion, err)
+	}
+
+	if err := qtx.UpdateEventState(ctx, query.UpdateEventStateParams{
+		ID:         ev.ID,
+		EventState: eventdomain.EventStateAborted.String(),
+	}); err != nil {
+		return fmt.Errorf("aborting event: %w", err)
+	}
+
+	if err := qtx.CreateEventAudit(ctx, query.CreateEventAuditParams{
+		EventID:       ev.ID,
+		Action:        eventdomain.AuditActionAborted.String(),
+		Actor:         actor,
+		Reason:        reason,
+		PreviousState: ev.EventState,
+	}); err != nil {
+		return fmt.Errorf("creating event audit: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: aborting event: %w", err)
+	}
+
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -22,27 +23,35 @@ func (r *OrchestratorRepository) FindAllEvents(ctx context.Context) ([]*eventdom
 
 	orchestratorEvents := make([]*eventdomain.BaseEvent, len(events))
 	for i, ev := range events {
+		metadata, err := eventMetadata(ev)
+		if err != nil {
+			return []*eventdomain.BaseEvent{}, fmt.Errorf("finding all events: %w", err)
+		}
+
 		orchestratorEvents[i] = &eventdomain.BaseEvent{
-			ID:          ev.ID.Bytes,
-			ContextID:   ev.ContextID.Bytes,
-			Origin:      ev.EventOrigin,
-			Type:        ev.EventType,
-			TypeVersion: ev.EventTypeVersion,
-			State:       ev.EventState,
-			CreatedAt:   ev.CreatedAt.Time,
-			ScheduledAt: ev.ScheduledAt.Time,
-			StartedAt:   ev.StartedAt.Time,
-			CompletedAt: ev.CompletedAt.Time,
-			Retry:       int(ev.Retry),
-			MaxRetry:    int(ev.MaxRetry),
-			Data:        ev.EventData,
+			ID:               ev.ID.Bytes,
+			ContextID:        ev.ContextID.Bytes,
+			Origin:           ev.EventOrigin,
+			Type:             ev.EventType,
+			TypeVersion:      ev.EventTypeVersion,
+			State:            ev.EventState,
+			CreatedAt:        ev.CreatedAt.Time,
+			ScheduledAt:      ev.ScheduledAt.Time,
+			StartedAt:        ev.StartedAt.Time,
+			CompletedAt:      ev.CompletedAt.Time,
+			Retry:            int(ev.Retry),
+			MaxRetry:         int(ev.MaxRetry),
+			AggregateVersion: int(ev.AggregateVersion),
+			Metadata:         metadata,
+			FailureReason:    ev.FailureReason,
+			Data:             ev.EventData,
 		}
 	}
 
 	return orchestratorEvents, nil
 }
 
-// FindProcessableEvents finds all events that are ready to be processed
+// FindProcessableEvents finds the events which are ready and due to be processed, the oldest scheduled first
 func (r *OrchestratorRepository) FindProcessableEvents(ctx context.Context, limit int) ([]*eventdomain.BaseEvent, error) {
 	ev, err := r.Q.FindProcessableEvents(ctx, int32(limit))
 	if err != nil {
@@ -51,28 +60,81 @@ func (r *OrchestratorRepository) FindProcessableEvents(ctx context.Context, limi
 
 	orchestratorEvents := make([]*eventdomain.BaseEvent, len(ev))
 	for i, ev := range ev {
+		metadata, err := eventMetadata(ev)
+		if err != nil {
+			return []*eventdomain.BaseEvent{}, fmt.Errorf("finding processable events: %w", err)
+		}
+
 		orchestratorEvents[i] = &eventdomain.BaseEvent{
-			ID:          ev.ID.Bytes,
-			ContextID:   ev.ContextID.Bytes,
-			Origin:      ev.EventOrigin,
-			Type:        ev.EventType,
-			TypeVersion: ev.EventTypeVersion,
-			State:       ev.EventState,
-			CreatedAt:   ev.CreatedAt.Time,
-			ScheduledAt: ev.ScheduledAt.Time,
-			StartedAt:   ev.StartedAt.Time,
-			CompletedAt: ev.CompletedAt.Time,
-			Retry:       int(ev.Retry),
-			MaxRetry:    int(ev.MaxRetry),
-			Data:        ev.EventData,
+			ID:               ev.ID.Bytes,
+			ContextID:        ev.ContextID.Bytes,
+			Origin:           ev.EventOrigin,
+			Type:             ev.EventType,
+			TypeVersion:      ev.EventTypeVersion,
+			State:            ev.EventState,
+			CreatedAt:        ev.CreatedAt.Time,
+			ScheduledAt:      ev.ScheduledAt.Time,
+			StartedAt:        ev.StartedAt.Time,
+			CompletedAt:      ev.CompletedAt.Time,
+			Retry:            int(ev.Retry),
+			MaxRetry:         int(ev.MaxRetry),
+			AggregateVersion: int(ev.AggregateVersion),
+			Metadata:         metadata,
+			FailureReason:    ev.FailureReason,
+			Data:             ev.EventData,
 		}
 	}
 
 	return orchestratorEvents, nil
 }
 
-// FindEventsByOriginAndStatus finds all events by origin and status
-func (r *OrchestratorRepository) findByOriginAndStatus(ctx context.Context, origin, state string, limit int) ([]*eventdomain.BaseEvent, error) {
+// ClaimProcessableEvents claims the events which are ready to be processed, the oldest scheduled first.
+// Claimed events are moved to the processing state and leased for the lease duration. Events claimed concurrently
+// by another orchestrator are skipped, and events whose lease expired before they were finished can be claimed again.
+// Only the first unfinished event of a context, in the order the events were recorded, can be claimed, so the events
+// of the same aggregate are processed one by one while the events of different aggregates are processed in parallel.
+// An event waiting for a retry holds the following events of its context, a deferred event joins the line once it's due.
+func (r *OrchestratorRepository) ClaimProcessableEvents(ctx context.Context, limit int, lease time.Duration) ([]*eventdomain.BaseEvent, error) {
+	ev, err := r.Q.ClaimProcessableEvents(ctx, query.ClaimProcessableEventsParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return []*eventdomain.BaseEvent{}, fmt.Errorf("claiming processable events: %w", err)
+	}
+
+	orchestratorEvents := make([]*eventdomain.BaseEvent, len(ev))
+	for i, ev := range ev {
+		metadata, err := eventMetadata(ev)
+		if err != nil {
+			return []*eventdomain.BaseEvent{}, fmt.Errorf("claiming processable events: %w", err)
+		}
+
+		orchestratorEvents[i] = &eventdomain.BaseEvent{
+			ID:               ev.ID.Bytes,
+			ContextID:        ev.ContextID.Bytes,
+			Origin:           ev.EventOrigin,
+			Type:             ev.EventType,
+			TypeVersion:      ev.EventTypeVersion,
+			State:            ev.EventState,
+			CreatedAt:        ev.CreatedAt.Time,
+			ScheduledAt:      ev.ScheduledAt.Time,
+			StartedAt:        ev.StartedAt.Time,
+			CompletedAt:      ev.CompletedAt.Time,
+			Retry:            int(ev.Retry),
+			MaxRetry:         int(ev.MaxRetry),
+			AggregateVersion: int(ev.AggregateVersion),
+			Metadata:         metadata,
+			FailureReason:    ev.FailureReason,
+			Data:             ev.EventData,
+		}
+	}
+
+	return orchestratorEvents, nil
+}
+
+// FindByOriginAndStatus finds all events by origin and status
+func (r *OrchestratorRepository) FindByOriginAndStatus(ctx context.Context, origin, state string, limit int) ([]*eventdomain.BaseEvent, error) {
 	ev, err := r.Q.FindEventsByOriginAndStatus(ctx, query.FindEventsByOriginAndStatusParams{
 		EventOrigin: origin,
 		EventState:  state,
@@ -88,28 +150,36 @@ func (r *OrchestratorRepository) findByOriginAndStatus(ctx context.Context, orig
 
 	orchestratorEvents := make([]*eventdomain.BaseEvent, len(ev))
 	for i, ev := range ev {
+		metadata, err := eventMetadata(ev)
+		if err != nil {
+			return []*eventdomain.BaseEvent{}, fmt.Errorf("finding events by origin and status: %w", err)
+		}
+
 		orchestratorEvents[i] = &eventdomain.BaseEvent{
-			ID:          ev.ID.Bytes,
-			ContextID:   ev.ContextID.Bytes,
-			Origin:      ev.EventOrigin,
-			Type:        ev.EventType,
-			TypeVersion: ev.EventTypeVersion,
-			State:       ev.EventState,
-			CreatedAt:   ev.CreatedAt.Time,
-			ScheduledAt: ev.ScheduledAt.Time,
-			StartedAt:   ev.StartedAt.Time,
-			CompletedAt: ev.CompletedAt.Time,
-			Retry:       int(ev.Retry),
-			MaxRetry:    int(ev.MaxRetry),
-			Data:        ev.EventData,
+			ID:               ev.ID.Bytes,
+			ContextID:        ev.ContextID.Bytes,
+			Origin:           ev.EventOrigin,
+			Type:             ev.EventType,
+			TypeVersion:      ev.EventTypeVersion,
+			State:            ev.EventState,
+			CreatedAt:        ev.CreatedAt.Time,
+			ScheduledAt:      ev.ScheduledAt.Time,
+			StartedAt:        ev.StartedAt.Time,
+			CompletedAt:      ev.CompletedAt.Time,
+			Retry:            int(ev.Retry),
+			MaxRetry:         int(ev.MaxRetry),
+			AggregateVersion: int(ev.AggregateVersion),
+			Metadata:         metadata,
+			FailureReason:    ev.FailureReason,
+			Data:             ev.EventData,
 		}
 	}
 
 	return orchestratorEvents, nil
 }
 
-// FindEventByID finds an event by id
-func (r *OrchestratorRepository) findByID(ctx context.Context, id uuid.UUID) (*eventdomain.BaseEvent, error) {
+// FindByID finds an event by id
+func (r *OrchestratorRepository) FindByID(ctx context.Context, id uuid.UUID) (*eventdomain.BaseEvent, error) {
 	ev, err := r.Q.FindEventByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -119,19 +189,119 @@ func (r *OrchestratorRepository) findByID(ctx context.Context, id uuid.UUID) (*e
 		return nil, fmt.Errorf("finding event by id: %w", err)
 	}
 
+	metadata, err := eventMetadata(ev)
+	if err != nil {
+		return nil, fmt.Errorf("finding event by id: %w", err)
+	}
+
 	return &eventdomain.BaseEvent{
-		ID:          ev.ID.Bytes,
-		ContextID:   ev.ContextID.Bytes,
-		Origin:      ev.EventOrigin,
-		Type:        ev.EventType,
-		TypeVersion: ev.EventTypeVersion,
-		State:       ev.EventState,
-		CreatedAt:   ev.CreatedAt.Time,
-		ScheduledAt: ev.ScheduledAt.Time,
-		StartedAt:   ev.StartedAt.Time,
-		CompletedAt: ev.CompletedAt.Time,
-		Retry:       int(ev.Retry),
-		MaxRetry:    int(ev.MaxRetry),
-		Data:        ev.EventData,
+		ID:               ev.ID.Bytes,
+		ContextID:        ev.ContextID.Bytes,
+		Origin:           ev.EventOrigin,
+		Type:             ev.EventType,
+		TypeVersion:      ev.EventTypeVersion,
+		State:            ev.EventState,
+		CreatedAt:        ev.CreatedAt.Time,
+		ScheduledAt:      ev.ScheduledAt.Time,
+		StartedAt:        ev.StartedAt.Time,
+		CompletedAt:      ev.CompletedAt.Time,
+		Retry:            int(ev.Retry),
+		MaxRetry:         int(ev.MaxRetry),
+		AggregateVersion: int(ev.AggregateVersion),
+		Metadata:         metadata,
+		FailureReason:    ev.FailureReason,
+		Data:             ev.EventData,
 	}, nil
 }
+
+// FindEvents finds the events in the state and of the origin, an empty state or origin matches any.
+// The events are ordered as they were recorded and paginated with the limit and offset.
+func (r *OrchestratorRepository) FindEvents(ctx context.Context, state, origin string, limit, offset int) ([]*eventdomain.BaseEvent, error) {
+	ev, err := r.Q.FindEventsByFilter(ctx, query.FindEventsByFilterParams{
+		EventState:  state,
+		EventOrigin: origin,
+		Limit:       int32(limit),
+		Offset:      int32(offset),
+	})
+	if err != nil {
+		return []*eventdomain.BaseEvent{}, fmt.Errorf("finding events by filter: %w", err)
+	}
+
+	orchestratorEvents := make([]*eventdomain.BaseEvent, len(ev))
+	for i, ev := range ev {
+		metadata, err := eventMetadata(ev)
+		if err != nil {
+			return []*eventdomain.BaseEvent{}, fmt.Errorf("finding events by filter: %w", err)
+		}
+
+		orchestratorEvents[i] = &eventdomain.BaseEvent{
+			ID:               ev.ID.Bytes,
+			ContextID:        ev.ContextID.Bytes,
+			Origin:           ev.EventOrigin,
+			Type:             ev.EventType,
+			TypeVersion:      ev.EventTypeVersion,
+			State:            ev.EventState,
+			CreatedAt:        ev.CreatedAt.Time,
+			ScheduledAt:      ev.ScheduledAt.Time,
+			StartedAt:        ev.StartedAt.Time,
+			CompletedAt:      ev.CompletedAt.Time,
+			Retry:            int(ev.Retry),
+			MaxRetry:         int(ev.MaxRetry),
+			AggregateVersion: int(ev.AggregateVersion),
+			Metadata:         metadata,
+			FailureReason:    ev.FailureReason,
+			Data:             ev.EventData,
+		}
+	}
+
+	return orchestratorEvents, nil
+}
+
+// FindAggregateVersion returns the version of the last event recorded for the aggregate (context), 0 when it has no events
+func (r *OrchestratorRepository) FindAggregateVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	version, err := r.Q.FindAggregateVersion(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("finding aggregate version: %w", err)
+	}
+
+	return int(version), nil
+}
+
+// FindEventAudits finds the actions taken by operators on the event, the oldest first
+func (r *OrchestratorRepository) FindEventAudits(ctx context.Context, eventID uuid.UUID) ([]eventdomain.Audit, error) {
+	audits, err := r.Q.FindEventAuditsByEventID(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+	if err != nil {
+		return []eventdomain.Audit{}, fmt.Errorf("finding event audits: %w", err)
+	}
+
+	eventAudits := make([]eventdomain.Audit, len(audits))
+	for i, audit := range audits {
+		eventAudits[i] = eventdomain.Audit{
+			ID:            audit.ID.Bytes,
+			EventID:       audit.EventID.Bytes,
+			Action:        eventdomain.AuditAction(audit.Action),
+			Actor:         audit.Actor,
+			Reason:        audit.Reason,
+			PreviousState: audit.PreviousState,
+			CreatedAt:     audit.CreatedAt.Time,
+		}
+	}
+
+	return eventAudits, nil
+}
+
+// eventMetadata returns the metadata of the event, the idempotency key is decoded from the event_metadata column
+func eventMetadata(ev query.Event) (eventdomain.Metadata, error) {
+	md, err := eventdomain.DecodeMetadata(ev.EventMetadata)
+	if err != nil {
+		return eventdomain.Metadata{}, err
+	}
+
+	md.CorrelationID = ev.CorrelationID.Bytes
+	md.CausationID = ev.CausationID.Bytes
+	md.ActorID = ev.ActorID
+	md.ActorType = ev.ActorType
+	md.SourceIP = ev.SourceIp
+
+	return md, nil
+}
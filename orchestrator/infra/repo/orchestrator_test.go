@@ -5,13 +5,19 @@ package repo
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
 	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
 )
 
 func TestOrchestrator_Start(t *testing.T) {
@@ -21,7 +27,7 @@ func TestOrchestrator_Start(t *testing.T) {
 
 	log.Printf("container address: %s", address)
 
-	eventRepo := NewOrchestratorRepository(pool)
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
 
 	events, err := eventRepo.FindAllEvents(ctx)
 	require.NoError(t, err)
@@ -33,7 +39,7 @@ func TestOrchestrator_Start(t *testing.T) {
 	restoredEvent := &customerdomain.CustomerCreatedEvent{}
 	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
 
-	events, err = eventRepo.findByOriginAndStatus(ctx, "customer", "ready", 5)
+	events, err = eventRepo.FindByOriginAndStatus(ctx, "customer", "ready", 5)
 	require.NoError(t, err)
 	require.NotNil(t, events)
 	require.Len(t, events, 2)
@@ -42,7 +48,7 @@ func TestOrchestrator_Start(t *testing.T) {
 	restoredEvent = &customerdomain.CustomerCreatedEvent{}
 	require.NoError(t, json.Unmarshal(ev.GetEventData(), &restoredEvent))
 
-	events, err = eventRepo.findByOriginAndStatus(ctx, "customer", "ready", 5)
+	events, err = eventRepo.FindByOriginAndStatus(ctx, "customer", "ready", 5)
 	require.NoError(t, err)
 	require.NotNil(t, events)
 	require.Len(t, events, 2)
@@ -56,9 +62,14 @@ func TestOrchestrator_SetStateAndScheduledAt(t *testing.T) {
 
 	log.Printf("container address: %s", address)
 
-	eventRepo := NewOrchestratorRepository(pool)
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     eventdomain.BackoffExponential,
+		BaseDelay:   time.Minute,
+		MaxDelay:    time.Hour,
+	}))
 
-	events, err := eventRepo.findByOriginAndStatus(ctx, "customer", "ready", 5)
+	events, err := eventRepo.FindByOriginAndStatus(ctx, "customer", "ready", 5)
 	require.NoError(t, err)
 	require.NotNil(t, events)
 	require.Len(t, events, 2)
@@ -70,45 +81,89 @@ func TestOrchestrator_SetStateAndScheduledAt(t *testing.T) {
 
 	id := ev.ID
 
-	eventBeforeUpdate, err := eventRepo.findByID(ctx, id)
+	eventBeforeUpdate, err := eventRepo.FindByID(ctx, id)
 	require.NoError(t, err)
 	require.NotNil(t, eventBeforeUpdate)
 	require.Equal(t, 0, eventBeforeUpdate.Retry)
 
-	err = eventRepo.UpdateEventRetry(ctx, eventBeforeUpdate.ID, 1)
+	err = eventRepo.UpdateEventRetry(ctx, eventBeforeUpdate.ID, errors.New("db error"))
 	require.NoError(t, err)
 
-	eventAfterUpdate, err := eventRepo.findByID(ctx, id)
+	eventAfterUpdate, err := eventRepo.FindByID(ctx, id)
 	require.NoError(t, err)
 	require.NotNil(t, eventAfterUpdate)
 
 	require.Equal(t, 1, eventAfterUpdate.Retry)
 	require.Greater(t, eventAfterUpdate.ScheduledAt, eventBeforeUpdate.ScheduledAt)
+	require.WithinDuration(t, time.Now().UTC().Add(time.Minute), eventAfterUpdate.ScheduledAt.UTC(), 10*time.Second)
 	require.Equal(t, "ready", eventAfterUpdate.State)
+	require.Equal(t, "db error", eventAfterUpdate.FailureReason)
 
-	err = eventRepo.UpdateEventRetry(ctx, eventBeforeUpdate.ID, 1)
+	err = eventRepo.UpdateEventRetry(ctx, eventBeforeUpdate.ID, errors.New("db error"))
 	require.NoError(t, err)
 
-	eventAfterSecondUpdate, err := eventRepo.findByID(ctx, id)
+	eventAfterSecondUpdate, err := eventRepo.FindByID(ctx, id)
 	require.NoError(t, err)
 	require.NotNil(t, eventAfterSecondUpdate)
 
 	require.Equal(t, 2, eventAfterSecondUpdate.Retry)
 	require.Greater(t, eventAfterSecondUpdate.ScheduledAt, eventAfterUpdate.ScheduledAt)
+	require.WithinDuration(t, time.Now().UTC().Add(2*time.Minute), eventAfterSecondUpdate.ScheduledAt.UTC(), 10*time.Second)
 	require.Equal(t, "ready", eventAfterSecondUpdate.State)
 
-	err = eventRepo.UpdateEventRetry(ctx, eventBeforeUpdate.ID, 1)
+	err = eventRepo.UpdateEventRetry(ctx, eventBeforeUpdate.ID, errors.New("db error"))
 	require.NoError(t, err)
 
-	eventAfterThirdUpdate, err := eventRepo.findByID(ctx, id)
+	eventAfterThirdUpdate, err := eventRepo.FindByID(ctx, id)
 	require.NoError(t, err)
 	require.NotNil(t, eventAfterThirdUpdate)
 
 	require.Equal(t, 3, eventAfterThirdUpdate.Retry)
 	require.Equal(t, eventAfterThirdUpdate.ScheduledAt, eventAfterSecondUpdate.ScheduledAt)
 	require.Equal(t, "failed", eventAfterThirdUpdate.State)
+	require.Equal(t, "db error", eventAfterThirdUpdate.FailureReason)
 	require.NotNil(t, eventAfterThirdUpdate.CompletedAt)
 	require.Greater(t, time.Now().UTC(), eventAfterThirdUpdate.CompletedAt.UTC())
+
+	// The requeued event is processed again from scratch
+	require.NoError(t, eventRepo.RequeueEvent(ctx, id, "jane.doe", "database recovered"))
+
+	requeued, err := eventRepo.FindByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "ready", requeued.State)
+	require.Empty(t, requeued.FailureReason)
+}
+
+func TestOrchestrator_UpdateEventRetry_TerminalCause(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	policies := eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy())
+	customerdomain.RegisterRetryPolicies(policies)
+
+	eventRepo := NewOrchestratorRepository(pool, policies)
+
+	events, err := eventRepo.FindByOriginAndStatus(ctx, "customer", "ready", 5)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+
+	id := events[0].ID
+
+	err = eventRepo.UpdateEventRetry(ctx, id, fmt.Errorf("updating customer status: %w", customerdomain.ErrCustomerNotFound))
+	require.NoError(t, err)
+
+	ev, err := eventRepo.FindByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 1, ev.Retry)
+	require.Equal(t, "failed", ev.State)
+	require.False(t, ev.CompletedAt.IsZero())
+	require.Contains(t, ev.FailureReason, customerdomain.ErrCustomerNotFound.Error())
+
+	err = eventRepo.UpdateEventRetry(ctx, uuid.New(), errors.New("db error"))
+	require.ErrorIs(t, err, eventdomain.ErrEventNotFound)
 }
 
 func TestOrchestrator_UpdateEventCompletion(t *testing.T) {
@@ -118,9 +173,9 @@ func TestOrchestrator_UpdateEventCompletion(t *testing.T) {
 
 	log.Printf("container address: %s", address)
 
-	eventRepo := NewOrchestratorRepository(pool)
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
 
-	events, err := eventRepo.findByOriginAndStatus(ctx, "customer", "ready", 5)
+	events, err := eventRepo.FindByOriginAndStatus(ctx, "customer", "ready", 5)
 	require.NoError(t, err)
 	require.NotNil(t, events)
 	require.Len(t, events, 2)
@@ -130,7 +185,7 @@ func TestOrchestrator_UpdateEventCompletion(t *testing.T) {
 	err = eventRepo.UpdateEventCompletion(ctx, ev.ID)
 	require.NoError(t, err)
 
-	eventAfterUpdate, err := eventRepo.findByID(ctx, ev.ID)
+	eventAfterUpdate, err := eventRepo.FindByID(ctx, ev.ID)
 	require.NoError(t, err)
 	require.NotNil(t, eventAfterUpdate)
 	require.Equal(t, "completed", eventAfterUpdate.State)
@@ -144,9 +199,9 @@ func TestOrchestrator_UpdateEventStart(t *testing.T) {
 
 	log.Printf("container address: %s", address)
 
-	eventRepo := NewOrchestratorRepository(pool)
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
 
-	events, err := eventRepo.findByOriginAndStatus(ctx, "customer", "ready", 5)
+	events, err := eventRepo.FindByOriginAndStatus(ctx, "customer", "ready", 5)
 	require.NoError(t, err)
 	require.NotNil(t, events)
 	require.Len(t, events, 2)
@@ -156,7 +211,7 @@ func TestOrchestrator_UpdateEventStart(t *testing.T) {
 	err = eventRepo.UpdateEventStart(ctx, ev.ID)
 	require.NoError(t, err)
 
-	eventAfterStart, err := eventRepo.findByID(ctx, ev.ID)
+	eventAfterStart, err := eventRepo.FindByID(ctx, ev.ID)
 	require.NoError(t, err)
 	require.NotNil(t, eventAfterStart)
 	require.Equal(t, "processing", eventAfterStart.State)
@@ -166,10 +221,267 @@ func TestOrchestrator_UpdateEventStart(t *testing.T) {
 	err = eventRepo.UpdateEventCompletion(ctx, ev.ID)
 	require.NoError(t, err)
 
-	eventAfterCompletion, err := eventRepo.findByID(ctx, ev.ID)
+	eventAfterCompletion, err := eventRepo.FindByID(ctx, ev.ID)
 	require.NoError(t, err)
 	require.NotNil(t, eventAfterCompletion)
 	require.Equal(t, "completed", eventAfterCompletion.State)
 	require.NotNil(t, eventAfterCompletion.CompletedAt)
 	require.Greater(t, time.Now().UTC(), eventAfterCompletion.CompletedAt.UTC())
 }
+
+func TestOrchestrator_FindProcessableEvents(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+
+	account := accountdomain.NewAccount(uuid.New(), uuid.New(), "1234567890", testAmount(t))
+	require.NoError(t, account.Activate())
+	require.NoError(t, account.Block())
+	require.NoError(t, account.ScheduleUnblock(time.Now().UTC().Add(time.Hour)))
+	accountEvents := make([]eventdomain.Event, 0, len(account.GetEvents()))
+	for _, ev := range account.GetEvents() {
+		accountEvents = append(accountEvents, ev)
+	}
+	require.NoError(t, eventRepo.CreateEvents(ctx, nil, accountEvents))
+
+	events, err := eventRepo.FindProcessableEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+
+	// The deferred unblocking isn't due yet
+	for i, ev := range events {
+		require.NotEqual(t, account.GetEvents()[3].GetID(), ev.ID)
+		require.False(t, ev.ScheduledAt.After(time.Now().UTC()))
+
+		if i > 0 {
+			require.False(t, ev.ScheduledAt.Before(events[i-1].ScheduledAt))
+		}
+	}
+
+	// The account events are claimed one by one
+	claimed, err := eventRepo.ClaimProcessableEvents(ctx, 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 3)
+}
+
+func TestOrchestrator_ClaimProcessableEvents(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+
+	claimed, err := eventRepo.ClaimProcessableEvents(ctx, 5, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+
+	for _, ev := range claimed {
+		require.Equal(t, "processing", ev.State)
+		require.False(t, ev.StartedAt.IsZero())
+	}
+
+	// Claimed events are leased, they can't be claimed again until the lease expires
+	claimed, err = eventRepo.ClaimProcessableEvents(ctx, 5, 30*time.Second)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	transaction, err := transactiondomain.NewTransfer(uuid.New(), uuid.New(), uuid.New(), testAmount(t))
+	require.NoError(t, err)
+	require.NoError(t, eventRepo.CreateEvents(ctx, nil, []eventdomain.Event{transaction.GetEvents()[0]}))
+
+	claimed, err = eventRepo.ClaimProcessableEvents(ctx, 5, time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, transaction.GetEvents()[0].GetID(), claimed[0].ID)
+
+	time.Sleep(2 * time.Second)
+
+	// The event wasn't finished before its lease expired
+	claimed, err = eventRepo.ClaimProcessableEvents(ctx, 5, time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, transaction.GetEvents()[0].GetID(), claimed[0].ID)
+
+	require.NoError(t, eventRepo.UpdateEventState(ctx, claimed[0].ID, "unprocessable"))
+
+	time.Sleep(2 * time.Second)
+
+	claimed, err = eventRepo.ClaimProcessableEvents(ctx, 5, time.Second)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+}
+
+func TestOrchestrator_ClaimProcessableEvents_InContextOrder(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+
+	// Leave the seeded events out of the way
+	seeded, err := eventRepo.ClaimProcessableEvents(ctx, 10, 30*time.Second)
+	require.NoError(t, err)
+	for _, ev := range seeded {
+		require.NoError(t, eventRepo.UpdateEventCompletion(ctx, ev.ID))
+	}
+
+	first := accountdomain.NewAccount(uuid.New(), uuid.New(), "1234567890", testAmount(t))
+	require.NoError(t, first.Activate())
+	require.NoError(t, first.Deposit(testAmount(t)))
+	require.NoError(t, first.Withdraw(testAmount(t)))
+
+	second := accountdomain.NewAccount(uuid.New(), uuid.New(), "0987654321", testAmount(t))
+
+	events := make([]eventdomain.Event, 0)
+	for _, ev := range append(first.GetEvents(), second.GetEvents()...) {
+		events = append(events, ev)
+	}
+	require.NoError(t, eventRepo.CreateEvents(ctx, nil, events))
+
+	// The events of different accounts are claimed together, the following events of an account wait for the first one
+	claimed, err := eventRepo.ClaimProcessableEvents(ctx, 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	require.ElementsMatch(t,
+		[]uuid.UUID{first.GetEvents()[0].GetID(), second.GetEvents()[0].GetID()},
+		[]uuid.UUID{claimed[0].ID, claimed[1].ID},
+	)
+
+	claimed, err = eventRepo.ClaimProcessableEvents(ctx, 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	require.NoError(t, eventRepo.UpdateEventCompletion(ctx, first.GetEvents()[0].GetID()))
+
+	claimed, err = eventRepo.ClaimProcessableEvents(ctx, 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, first.GetEvents()[1].GetID(), claimed[0].ID)
+
+	// The event waiting for a retry holds the following events of the account
+	require.NoError(t, eventRepo.UpdateEventRetry(ctx, first.GetEvents()[1].GetID(), errors.New("db error")))
+
+	claimed, err = eventRepo.ClaimProcessableEvents(ctx, 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+}
+
+func TestOrchestrator_RequeueAndAbortEvents(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+
+	first := accountdomain.NewAccount(uuid.New(), uuid.New(), "1234567890", testAmount(t))
+	second := accountdomain.NewAccount(uuid.New(), uuid.New(), "0987654321", testAmount(t))
+	firstID, secondID := first.GetEvents()[0].GetID(), second.GetEvents()[0].GetID()
+
+	require.NoError(t, eventRepo.CreateEvents(ctx, nil, []eventdomain.Event{first.GetEvents()[0], second.GetEvents()[0]}))
+	require.NoError(t, eventRepo.UpdateEventState(ctx, firstID, eventdomain.EventStateFailed.String()))
+	require.NoError(t, eventRepo.UpdateEventState(ctx, secondID, eventdomain.EventStateFailed.String()))
+
+	failed, err := eventRepo.FindEvents(ctx, "failed", "account", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, failed, 2)
+	require.Equal(t, firstID, failed[0].ID)
+
+	failed, err = eventRepo.FindEvents(ctx, "failed", "", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.Equal(t, secondID, failed[0].ID)
+
+	// Only the dead-lettered events can be requeued
+	require.ErrorIs(t, eventRepo.RequeueEvent(ctx, uuid.New(), "jane.doe", ""), eventdomain.ErrEventNotFound)
+
+	require.NoError(t, eventRepo.RequeueEvent(ctx, firstID, "jane.doe", "account projection fixed"))
+	require.ErrorIs(t, eventRepo.RequeueEvent(ctx, firstID, "jane.doe", ""), eventdomain.ErrEventStateInvalid)
+
+	requeued, err := eventRepo.FindByID(ctx, firstID)
+	require.NoError(t, err)
+	require.Equal(t, "ready", requeued.State)
+	require.Equal(t, 0, requeued.Retry)
+	require.True(t, requeued.CompletedAt.IsZero())
+
+	require.NoError(t, eventRepo.AbortEvent(ctx, firstID, "jane.doe", "duplicated account"))
+	require.ErrorIs(t, eventRepo.AbortEvent(ctx, firstID, "jane.doe", "duplicated account"), eventdomain.ErrEventStateInvalid)
+
+	count, err := eventRepo.RequeueEvents(ctx, "failed", "account", "jane.doe", "database outage")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	_, err = eventRepo.RequeueEvents(ctx, "completed", "", "jane.doe", "")
+	require.ErrorIs(t, err, eventdomain.ErrEventStateInvalid)
+
+	audits, err := eventRepo.FindEventAudits(ctx, firstID)
+	require.NoError(t, err)
+	require.Len(t, audits, 2)
+	require.Equal(t, eventdomain.AuditActionRequeued, audits[0].Action)
+	require.Equal(t, "failed", audits[0].PreviousState)
+	require.Equal(t, eventdomain.AuditActionAborted, audits[1].Action)
+	require.Equal(t, "ready", audits[1].PreviousState)
+	require.Equal(t, "duplicated account", audits[1].Reason)
+
+	audits, err = eventRepo.FindEventAudits(ctx, secondID)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	require.Equal(t, "jane.doe", audits[0].Actor)
+}
+
+func TestOrchestrator_AbortEventWithSuccessors(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+
+	account := accountdomain.NewAccount(uuid.New(), uuid.New(), "1234567890", testAmount(t))
+	require.NoError(t, account.Activate())
+	require.NoError(t, account.Deposit(testAmount(t)))
+	events := make([]eventdomain.Event, 0, len(account.GetEvents()))
+	for _, ev := range account.GetEvents() {
+		events = append(events, ev)
+	}
+	require.NoError(t, eventRepo.CreateEvents(ctx, nil, events))
+
+	activatedID, depositedID := account.GetEvents()[1].GetID(), account.GetEvents()[2].GetID()
+
+	for _, version := range []int{2, 3} {
+		_, err := pool.Exec(ctx, `INSERT INTO snapshots (context_id, aggregate_type, aggregate_version, snapshot_version, snapshot_data)
+			VALUES ($1, 'account', $2, '0.0.1', '{}')`, account.ID, version)
+		require.NoError(t, err)
+	}
+
+	// The deposit was decided on the activated account, so the activation can't be aborted
+	require.ErrorIs(t, eventRepo.AbortEvent(ctx, activatedID, "jane.doe", "activated by mistake"), eventdomain.ErrEventHasSuccessors)
+
+	activated, err := eventRepo.FindByID(ctx, activatedID)
+	require.NoError(t, err)
+	require.Equal(t, "ready", activated.State)
+
+	// The last event is aborted together with the snapshots which include it
+	require.NoError(t, eventRepo.AbortEvent(ctx, depositedID, "jane.doe", "duplicated deposit"))
+
+	var versions []int64
+	rows, err := pool.Query(ctx, "SELECT aggregate_version FROM snapshots WHERE context_id = $1 ORDER BY aggregate_version", account.ID)
+	require.NoError(t, err)
+	for rows.Next() {
+		var version int64
+		require.NoError(t, rows.Scan(&version))
+		versions = append(versions, version)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []int64{2}, versions)
+}
@@ -0,0 +1,323 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/infra/db"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/infra/repo/query"
+)
+
+// Read models (projections) maintained by the orchestrator which can be rebuilt from the events
+const (
+	AccountsProjection  = "accounts"
+	CustomersProjection = "customers"
+)
+
+// projection is a read model table built from the events of one origin
+type projection struct {
+	origin     string // Origin of the events the read model is built from, i.e. account
+	schemaFile string // Script of the database schema creating the table of the read model with its indexes
+	dependents string // Statements restoring the constraints of the other tables referencing the table, they're dropped by the swap
+}
+
+// projections holds the read models by their table names
+var projections = map[string]projection{
+	AccountsProjection: {
+		origin:     "account",
+		schemaFile: "schema/0002_accounts_table.sql",
+	},
+	CustomersProjection: {
+		origin:     "customer",
+		schemaFile: "schema/0001_customers_table.sql",
+		dependents: `ALTER TABLE accounts ADD CONSTRAINT accounts_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE`,
+	},
+}
+
+// ProjectionRepository is the repository rebuilding the read models in their shadow tables.
+// The shadow table of a read model has the name of the live one and is kept in the rebuild_<read model> schema, i.e. rebuild_accounts.accounts.
+type ProjectionRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewProjectionRepository creates a new projection repository
+func NewProjectionRepository(conn *pgxpool.Pool) *ProjectionRepository {
+	return &ProjectionRepository{
+		Conn: conn,
+		Q:    query.New(conn),
+	}
+}
+
+// NewShadowPool creates a connection pool resolving the table of the read model to its shadow table,
+// so the repositories maintaining the read model, i.e. AccountRepository, rebuild the shadow table instead of the live one.
+// The other tables, i.e. the events the repositories look up, are resolved to the live ones.
+func NewShadowPool(ctx context.Context, conn *pgxpool.Pool, name string) (*pgxpool.Pool, error) {
+	if _, err := findProjection(name); err != nil {
+		return nil, fmt.Errorf("creating shadow connection pool: %w", err)
+	}
+
+	config := conn.Config()
+	config.ConnConfig.RuntimeParams["search_path"] = pgx.Identifier{shadowSchema(name)}.Sanitize() + ", public"
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("creating shadow connection pool of %s projection: %w", name, err)
+	}
+
+	return pool, nil
+}
+
+// PrepareShadow creates the empty shadow table of the read model, replacing the one left by the previous rebuild,
+// and removes the checkpoint of the previous rebuild
+func (r *ProjectionRepository) PrepareShadow(ctx context.Context, name string) error {
+	p, err := findProjection(name)
+	if err != nil {
+		return fmt.Errorf("preparing shadow table: %w", err)
+	}
+
+	schema, err := db.Schema.ReadFile(p.schemaFile)
+	if err != nil {
+		return fmt.Errorf("reading schema of %s projection: %w", name, err)
+	}
+
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: preparing shadow table: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	statements := []string{
+		"CREATE SCHEMA IF NOT EXISTS " + pgx.Identifier{shadowSchema(name)}.Sanitize(),
+		"DROP TABLE IF EXISTS " + shadowTable(name),
+		// The schema script creates the table in the shadow schema, while the tables it references are resolved to the live ones
+		"SET LOCAL search_path TO " + pgx.Identifier{shadowSchema(name)}.Sanitize() + ", public",
+		string(schema),
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("preparing shadow table of %s projection: %w", name, err)
+		}
+	}
+
+	if err := r.Q.WithTx(tx).DeleteProjectionCheckpoint(ctx, name); err != nil {
+		return fmt.Errorf("deleting checkpoint: preparing shadow table of %s projection: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: preparing shadow table: %w", err)
+	}
+
+	return nil
+}
+
+// FindCheckpoint returns the checkpoint of the rebuild of the read model
+func (r *ProjectionRepository) FindCheckpoint(ctx context.Context, name string) (eventdomain.Checkpoint, error) {
+	checkpoint, err := r.Q.FindProjectionCheckpoint(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return eventdomain.Checkpoint{}, fmt.Errorf("finding checkpoint of %s projection: %w", name, eventdomain.ErrCheckpointNotFound)
+		}
+
+		return eventdomain.Checkpoint{}, fmt.Errorf("finding checkpoint of %s projection: %w", name, err)
+	}
+
+	return eventdomain.Checkpoint{
+		CompletedAt:    checkpoint.CompletedAt.Time,
+		SequenceNumber: checkpoint.SequenceNumber,
+	}, nil
+}
+
+// SaveCheckpoint records the last event replayed into the shadow table of the read model
+func (r *ProjectionRepository) SaveCheckpoint(ctx context.Context, name string, checkpoint eventdomain.Checkpoint) error {
+	err := r.Q.SaveProjectionCheckpoint(ctx, query.SaveProjectionCheckpointParams{
+		Projection:     name,
+		CompletedAt:    pgtype.Timestamp{Time: checkpoint.CompletedAt, Valid: true},
+		SequenceNumber: checkpoint.SequenceNumber,
+	})
+	if err != nil {
+		return fmt.Errorf("saving checkpoint of %s projection: %w", name, err)
+	}
+
+	return nil
+}
+
+// FindCompletedEvents returns up to limit events of the read model completed after the checkpoint, in the order they were completed,
+// together with the checkpoint of the last one
+func (r *ProjectionRepository) FindCompletedEvents(ctx context.Context, name string, after eventdomain.Checkpoint, limit int) ([]*eventdomain.BaseEvent, eventdomain.Checkpoint, error) {
+	p, err := findProjection(name)
+	if err != nil {
+		return nil, after, fmt.Errorf("finding completed events: %w", err)
+	}
+
+	events, err := r.Q.FindCompletedEventsAfter(ctx, query.FindCompletedEventsAfterParams{
+		EventOrigin:    p.origin,
+		CompletedAt:    pgtype.Timestamp{Time: after.CompletedAt, Valid: true},
+		SequenceNumber: after.SequenceNumber,
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, after, fmt.Errorf("finding completed events of %s projection: %w", name, err)
+	}
+
+	checkpoint := after
+	completedEvents := make([]*eventdomain.BaseEvent, len(events))
+	for i, ev := range events {
+		completedEvents[i] = &eventdomain.BaseEvent{
+			ID:               ev.ID.Bytes,
+			ContextID:        ev.ContextID.Bytes,
+			Origin:           ev.EventOrigin,
+			Type:             ev.EventType,
+			TypeVersion:      ev.EventTypeVersion,
+			State:            ev.EventState,
+			CreatedAt:        ev.CreatedAt.Time,
+			ScheduledAt:      ev.ScheduledAt.Time,
+			StartedAt:        ev.StartedAt.Time,
+			CompletedAt:      ev.CompletedAt.Time,
+			Retry:            int(ev.Retry),
+			MaxRetry:         int(ev.MaxRetry),
+			AggregateVersion: int(ev.AggregateVersion),
+			Data:             ev.EventData,
+		}
+
+		checkpoint = eventdomain.Checkpoint{
+			CompletedAt:    ev.CompletedAt.Time,
+			SequenceNumber: ev.SequenceNumber,
+		}
+	}
+
+	return completedEvents, checkpoint, nil
+}
+
+// DiffShadow returns the rows which differ between the live read model and its shadow table, ordered by their identifiers
+func (r *ProjectionRepository) DiffShadow(ctx context.Context, name string) ([]eventdomain.ProjectionDiff, error) {
+	if _, err := findProjection(name); err != nil {
+		return nil, fmt.Errorf("diffing shadow table: %w", err)
+	}
+
+	rows, err := r.Conn.Query(ctx, `
+		SELECT COALESCE(live.id, rebuilt.id)::TEXT,
+			CASE WHEN live.id IS NULL THEN '' ELSE to_jsonb(live)::TEXT END,
+			CASE WHEN rebuilt.id IS NULL THEN '' ELSE to_jsonb(rebuilt)::TEXT END
+		FROM `+pgx.Identifier{name}.Sanitize()+` AS live
+		FULL OUTER JOIN `+shadowTable(name)+` AS rebuilt ON rebuilt.id = live.id
+		WHERE live.id IS NULL OR rebuilt.id IS NULL OR to_jsonb(live) <> to_jsonb(rebuilt)
+		ORDER BY 1`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("diffing shadow table of %s projection: %w", name, err)
+	}
+	defer rows.Close()
+
+	var diffs []eventdomain.ProjectionDiff
+	for rows.Next() {
+		var diff eventdomain.ProjectionDiff
+		if err := rows.Scan(&diff.ID, &diff.Live, &diff.Rebuilt); err != nil {
+			return nil, fmt.Errorf("scanning diff: diffing shadow table of %s projection: %w", name, err)
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("diffing shadow table of %s projection: %w", name, err)
+	}
+
+	return diffs, nil
+}
+
+// SwapShadow replaces the live read model with its shadow table in one transaction, and removes the checkpoint of the rebuild.
+// The live table is locked against writes first, so the catch-up replays the events completed since the last checkpoint
+// and no event is applied to the live table only. The swap fails with ErrProjectionBusy when the events of the read model
+// are being processed, they may have been applied to the live table but not completed yet.
+func (r *ProjectionRepository) SwapShadow(ctx context.Context, name string, catchUp func(ctx context.Context) error) error {
+	p, err := findProjection(name)
+	if err != nil {
+		return fmt.Errorf("swapping shadow table: %w", err)
+	}
+
+	tx, err := r.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: swapping shadow table: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	live := pgx.Identifier{name}.Sanitize()
+
+	if _, err := tx.Exec(ctx, "LOCK TABLE "+live+" IN EXCLUSIVE MODE"); err != nil {
+		return fmt.Errorf("locking %s projection: %w", name, err)
+	}
+
+	processing, err := r.Q.WithTx(tx).CountProcessingEvents(ctx, p.origin)
+	if err != nil {
+		return fmt.Errorf("counting processing events: swapping shadow table of %s projection: %w", name, err)
+	}
+
+	if processing > 0 {
+		return fmt.Errorf("swapping shadow table of %s projection with %d events processing: %w", name, processing, eventdomain.ErrProjectionBusy)
+	}
+
+	if err := catchUp(ctx); err != nil {
+		return fmt.Errorf("catching up: swapping shadow table of %s projection: %w", name, err)
+	}
+
+	drop := "DROP TABLE " + live
+	if p.dependents != "" {
+		drop += " CASCADE"
+	}
+
+	statements := []string{
+		drop,
+		"ALTER TABLE " + shadowTable(name) + " SET SCHEMA public",
+	}
+	if p.dependents != "" {
+		statements = append(statements, p.dependents)
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("swapping shadow table of %s projection: %w", name, err)
+		}
+	}
+
+	if err := r.Q.WithTx(tx).DeleteProjectionCheckpoint(ctx, name); err != nil {
+		return fmt.Errorf("deleting checkpoint: swapping shadow table of %s projection: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: swapping shadow table: %w", err)
+	}
+
+	return nil
+}
+
+// findProjection returns the read model by its table name
+func findProjection(name string) (projection, error) {
+	p, ok := projections[name]
+	if !ok {
+		return projection{}, fmt.Errorf("finding %q projection: %w", name, eventdomain.ErrProjectionNotFound)
+	}
+
+	return p, nil
+}
+
+// shadowSchema returns the schema keeping the shadow table of the read model
+func shadowSchema(name string) string {
+	return "rebuild_" + name
+}
+
+// shadowTable returns the quoted name of the shadow table of the read model
+func shadowTable(name string) string {
+	return pgx.Identifier{shadowSchema(name), name}.Sanitize()
+}
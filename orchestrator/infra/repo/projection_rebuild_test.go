@@ -0,0 +1,201 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor"
+)
+
+func TestProjectionRepository_RebuildAccounts(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	orcRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+	projectionRepo := NewProjectionRepository(pool)
+
+	customer := customerdomain.NewCustomer(uuid.New(), "John", "Doe", "john.rebuild@example.com", "1234567890", "1990-01-01", customerdomain.Address{})
+	customerCreated := customer.GetEvents()[0].(*customerdomain.CustomerCreatedEvent)
+	require.NoError(t, NewCustomerRepository(pool).CreateCustomer(ctx, *customerCreated))
+
+	initialBalance, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	account := accountdomain.NewAccount(uuid.New(), customer.ID, "9876543210", initialBalance)
+	require.NoError(t, account.Activate())
+
+	deposit, err := money.New(2550, "USD")
+	require.NoError(t, err)
+	require.NoError(t, account.Deposit(deposit))
+
+	events := make([]eventdomain.Event, 0)
+	for _, ev := range account.GetEvents() {
+		events = append(events, ev)
+	}
+
+	require.NoError(t, orcRepo.CreateEvents(ctx, nil, events))
+	for _, ev := range events {
+		require.NoError(t, orcRepo.UpdateEventCompletion(ctx, ev.GetID()))
+	}
+
+	// The live read model missed the deposit
+	accountCreated := account.GetEvents()[0].(*accountdomain.AccountCreatedEvent)
+	require.NoError(t, NewAccountRepository(pool).CreateAccount(ctx, *accountCreated))
+
+	shadowPool, err := NewShadowPool(ctx, pool, AccountsProjection)
+	require.NoError(t, err)
+	t.Cleanup(shadowPool.Close)
+
+	_, err = NewShadowPool(ctx, pool, "products")
+	require.ErrorIs(t, err, eventdomain.ErrProjectionNotFound)
+
+	require.NoError(t, projectionRepo.PrepareShadow(ctx, AccountsProjection))
+
+	_, err = projectionRepo.FindCheckpoint(ctx, AccountsProjection)
+	require.ErrorIs(t, err, eventdomain.ErrCheckpointNotFound)
+
+	// The completed events are found in batches in the order they were completed
+	completed, checkpoint, err := projectionRepo.FindCompletedEvents(ctx, AccountsProjection, eventdomain.Checkpoint{}, 2)
+	require.NoError(t, err)
+	require.Len(t, completed, 2)
+	require.Equal(t, events[0].GetID(), completed[0].ID)
+	require.Equal(t, events[1].GetID(), completed[1].ID)
+
+	remaining, last, err := projectionRepo.FindCompletedEvents(ctx, AccountsProjection, checkpoint, 2)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	require.Equal(t, events[2].GetID(), remaining[0].ID)
+
+	require.NoError(t, projectionRepo.SaveCheckpoint(ctx, AccountsProjection, last))
+
+	found, err := projectionRepo.FindCheckpoint(ctx, AccountsProjection)
+	require.NoError(t, err)
+	require.Equal(t, last.SequenceNumber, found.SequenceNumber)
+
+	// The shadow table is rebuilt through the shadow pool, the live one is left as it is
+	shadowRepo := NewAccountRepository(shadowPool)
+	require.NoError(t, shadowRepo.CreateAccount(ctx, *accountCreated))
+	require.NoError(t, shadowRepo.DepositFunds(ctx, *account.GetEvents()[2].(*accountdomain.AccountFundsDepositedEvent)))
+
+	live, err := NewAccountRepository(pool).FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10000), live.Balance.Amount())
+
+	diff, err := projectionRepo.DiffShadow(ctx, AccountsProjection)
+	require.NoError(t, err)
+	require.Len(t, diff, 1)
+	require.Equal(t, account.ID.String(), diff[0].ID)
+	require.Contains(t, diff[0].Live, `"balance": 10000`)
+	require.Contains(t, diff[0].Rebuilt, `"balance": 12550`)
+
+	caughtUp := false
+	require.NoError(t, projectionRepo.SwapShadow(ctx, AccountsProjection, func(_ context.Context) error {
+		caughtUp = true
+		return nil
+	}))
+	require.True(t, caughtUp)
+
+	live, err = NewAccountRepository(pool).FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(12550), live.Balance.Amount())
+
+	// The swapped in table keeps the constraints of the live one
+	unknownCustomer := accountdomain.NewAccount(uuid.New(), uuid.New(), "1111111111", initialBalance)
+	require.Error(t, NewAccountRepository(pool).CreateAccount(ctx, *unknownCustomer.GetEvents()[0].(*accountdomain.AccountCreatedEvent)))
+
+	_, err = projectionRepo.FindCheckpoint(ctx, AccountsProjection)
+	require.ErrorIs(t, err, eventdomain.ErrCheckpointNotFound)
+}
+
+func TestProjectionRepository_RebuildAccounts_ScheduledUnblock(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	orcRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+
+	customer := customerdomain.NewCustomer(uuid.New(), "John", "Doe", "john.unblock@example.com", "1234567890", "1990-01-01", customerdomain.Address{})
+	customerCreated := customer.GetEvents()[0].(*customerdomain.CustomerCreatedEvent)
+	require.NoError(t, NewCustomerRepository(pool).CreateCustomer(ctx, *customerCreated))
+
+	initialBalance, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	account := accountdomain.NewAccount(uuid.New(), customer.ID, "9876543210", initialBalance)
+	require.NoError(t, account.Activate())
+	require.NoError(t, account.Block())
+	require.NoError(t, account.ScheduleUnblock(time.Now().UTC().Add(time.Hour)))
+
+	events := make([]eventdomain.Event, 0)
+	for _, ev := range account.GetEvents() {
+		events = append(events, ev)
+	}
+
+	require.NoError(t, orcRepo.CreateEvents(ctx, nil, events))
+	for _, ev := range events {
+		require.NoError(t, orcRepo.UpdateEventCompletion(ctx, ev.GetID()))
+	}
+
+	// The live read model missed all the events after the account creation
+	accountCreated := account.GetEvents()[0].(*accountdomain.AccountCreatedEvent)
+	require.NoError(t, NewAccountRepository(pool).CreateAccount(ctx, *accountCreated))
+
+	shadowPool, err := NewShadowPool(ctx, pool, AccountsProjection)
+	require.NoError(t, err)
+	t.Cleanup(shadowPool.Close)
+
+	// The scheduled unblock looks the later status changes of the account up in the events through the shadow pool
+	rebuilder := orchestrator.NewProjectionRebuilder(
+		orchestrator.DefaultRebuildConfig(),
+		AccountsProjection,
+		NewProjectionRepository(pool),
+		func(orcRepo processor.OrchestratorRepository) orchestrator.Processor {
+			return processor.NewAccountProcessor(orcRepo, NewAccountRepository(shadowPool))
+		},
+	)
+
+	result, err := rebuilder.Rebuild(ctx, orchestrator.RebuildOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, len(events), result.Replayed)
+	require.Len(t, result.Diff, 1)
+	require.Equal(t, account.ID.String(), result.Diff[0].ID)
+	require.Contains(t, result.Diff[0].Live, `"status": "pending"`)
+	require.Contains(t, result.Diff[0].Rebuilt, `"status": "active"`)
+}
+
+func TestProjectionRepository_RebuildCustomers_Busy(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	projectionRepo := NewProjectionRepository(pool)
+
+	require.NoError(t, projectionRepo.PrepareShadow(ctx, CustomersProjection))
+
+	_, err := pool.Exec(ctx, "UPDATE events SET event_state = 'processing' WHERE event_origin = 'customer'")
+	require.NoError(t, err)
+
+	err = projectionRepo.SwapShadow(ctx, CustomersProjection, func(_ context.Context) error {
+		return nil
+	})
+	require.ErrorIs(t, err, eventdomain.ErrProjectionBusy)
+}
@@ -0,0 +1,145 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+)
+
+func TestAccountAndCustomerRepository_Projection(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	customerRepo := NewCustomerRepository(pool)
+	accountRepo := NewAccountRepository(pool)
+
+	customer := customerdomain.NewCustomer(uuid.New(), "John", "Doe", "john.doe@example.com", "1234567890", "1990-01-01", customerdomain.Address{
+		Street:     "Street 1",
+		City:       "Warsaw",
+		State:      "Masovian",
+		PostalCode: "00-000",
+		Country:    "Poland",
+	})
+	customerCreated := customer.GetEvents()[0].(*customerdomain.CustomerCreatedEvent)
+
+	require.NoError(t, customerRepo.CreateCustomer(ctx, *customerCreated))
+	require.ErrorIs(t, customerRepo.CreateCustomer(ctx, *customerCreated), customerdomain.ErrCustomerAlreadyExists)
+	require.NoError(t, customerRepo.UpdateCustomerStatus(ctx, customer.ID, customerdomain.CustomerStatusBlocked, customerCreated.CreatedAt))
+	require.ErrorIs(t, customerRepo.UpdateCustomerStatus(ctx, uuid.New(), customerdomain.CustomerStatusBlocked, customerCreated.CreatedAt), customerdomain.ErrCustomerNotFound)
+
+	initialBalance, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	account := accountdomain.NewAccount(uuid.New(), customer.ID, "1234567890", initialBalance)
+	accountCreated := account.GetEvents()[0].(*accountdomain.AccountCreatedEvent)
+
+	require.NoError(t, accountRepo.CreateAccount(ctx, *accountCreated))
+	require.ErrorIs(t, accountRepo.CreateAccount(ctx, *accountCreated), accountdomain.ErrAccountAlreadyExists)
+
+	found, err := accountRepo.FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, accountdomain.AccountStatusPending, found.Status)
+
+	require.NoError(t, account.Activate())
+	activated := account.GetEvents()[1].(*accountdomain.AccountActivatedEvent)
+	require.NoError(t, accountRepo.UpdateAccountStatus(ctx, account.ID, accountdomain.AccountStatusActive, activated.CreatedAt))
+
+	deposit, err := money.New(2550, "USD")
+	require.NoError(t, err)
+	require.NoError(t, account.Deposit(deposit))
+
+	deposited := account.GetEvents()[2].(*accountdomain.AccountFundsDepositedEvent)
+	require.NoError(t, accountRepo.DepositFunds(ctx, *deposited))
+	// Recording the same event again leaves the balance unchanged
+	require.NoError(t, accountRepo.DepositFunds(ctx, *deposited))
+
+	found, err = accountRepo.FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(12550), found.Balance.Amount())
+	require.Equal(t, "1234567890", found.AccountNumber)
+	require.Equal(t, customer.ID, found.CustomerID)
+
+	require.NoError(t, accountRepo.BlockAccount(ctx, accountdomain.AccountBlockedEvent{BaseEvent: deposited.BaseEvent}))
+
+	found, err = accountRepo.FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, accountdomain.AccountStatusBlocked, found.Status)
+
+	require.NoError(t, accountRepo.UnblockAccount(ctx, accountdomain.AccountUnblockedEvent{BaseEvent: deposited.BaseEvent}))
+
+	found, err = accountRepo.FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, accountdomain.AccountStatusActive, found.Status)
+	require.WithinDuration(t, deposited.CreatedAt, found.UpdatedAt, time.Millisecond)
+
+	_, err = accountRepo.FindByID(ctx, uuid.New())
+	require.ErrorIs(t, err, accountdomain.ErrAccountNotFound)
+
+	missing := *deposited
+	missing.ContextID = uuid.New()
+	require.ErrorIs(t, accountRepo.DepositFunds(ctx, missing), accountdomain.ErrAccountNotFound)
+}
+
+func TestAccountRepository_HasStatusChangedBefore(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+	accountRepo := NewAccountRepository(pool)
+
+	account := accountdomain.NewAccount(uuid.New(), uuid.New(), "1234567890", testAmount(t))
+	require.NoError(t, account.Activate())
+	require.NoError(t, account.Block())
+
+	dueAt := time.Now().UTC().Add(time.Hour)
+	require.NoError(t, account.ScheduleUnblock(dueAt))
+	require.NoError(t, account.Refund(testAmount(t)))
+
+	events := make([]eventdomain.Event, 0, len(account.GetEvents()))
+	for _, ev := range account.GetEvents() {
+		events = append(events, ev)
+	}
+	require.NoError(t, eventRepo.CreateEvents(ctx, nil, events))
+
+	// The deposit doesn't change the account status, so the scheduled unblock isn't cancelled
+	changed, err := accountRepo.HasStatusChangedBefore(ctx, account.ID, 4, dueAt)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	account.ClearEvents()
+	require.NoError(t, account.Unblock())
+	require.NoError(t, account.Block())
+
+	events = events[:0]
+	for _, ev := range account.GetEvents() {
+		events = append(events, ev)
+	}
+	require.NoError(t, eventRepo.CreateEvents(ctx, map[uuid.UUID]int{account.ID: 5}, events))
+
+	// Unblocking and blocking the account again before the scheduled unblock is due cancels it
+	changed, err = accountRepo.HasStatusChangedBefore(ctx, account.ID, 4, dueAt)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	// The status changes recorded before the scheduled unblock don't cancel it
+	changed, err = accountRepo.HasStatusChangedBefore(ctx, account.ID, 7, dueAt)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
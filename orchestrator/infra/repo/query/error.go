@@ -0,0 +1,11 @@
+package query
+
+// File defines the error codes which are returned by PostgreSQL.
+// These codes are used to determine the type of error that occurred.
+
+const (
+	// POSTGRESQL_DUPLICATE_KEY_CODE is the code for a duplicate key value violation
+	POSTGRESQL_DUPLICATE_KEY_CODE = "23505"
+	// EVENTS_AGGREGATE_VERSION_CONSTRAINT is the name of the constraint keeping the aggregate versions of a context unique
+	EVENTS_AGGREGATE_VERSION_CONSTRAINT = "events_context_id_aggregate_version_key"
+)
@@ -11,8 +11,144 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimProcessableEvents = `-- name: ClaimProcessableEvents :many
+UPDATE events
+SET event_state = 'processing',
+    started_at = CURRENT_TIMESTAMP,
+    lease_expires_at = CURRENT_TIMESTAMP + ($1::INT * INTERVAL '1 second')
+WHERE id IN (
+    SELECT e.id FROM events AS e
+    WHERE ((e.event_state = 'ready' AND e.scheduled_at <= CURRENT_TIMESTAMP)
+        OR (e.event_state = 'processing' AND e.lease_expires_at < CURRENT_TIMESTAMP))
+        AND NOT EXISTS (
+            SELECT 1 FROM events AS preceding
+            WHERE preceding.context_id = e.context_id
+                AND preceding.sequence_number < e.sequence_number
+                AND preceding.event_state IN ('ready', 'processing')
+                AND (preceding.scheduled_at <= CURRENT_TIMESTAMP OR preceding.retry > 0)
+        )
+    ORDER BY e.scheduled_at ASC, e.sequence_number ASC
+    LIMIT ($2)
+    FOR UPDATE OF e SKIP LOCKED
+)
+RETURNING id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason
+`
+
+type ClaimProcessableEventsParams struct {
+	LeaseSeconds int32
+	Limit        int32
+}
+
+func (q *Queries) ClaimProcessableEvents(ctx context.Context, arg ClaimProcessableEventsParams) ([]Event, error) {
+	rows, err := q.db.Query(ctx, claimProcessableEvents, arg.LeaseSeconds, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.ContextID,
+			&i.EventOrigin,
+			&i.EventType,
+			&i.EventTypeVersion,
+			&i.EventState,
+			&i.CreatedAt,
+			&i.ScheduledAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.Retry,
+			&i.MaxRetry,
+			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+type CreateEventsParams struct {
+	ID               pgtype.UUID
+	ContextID        pgtype.UUID
+	EventOrigin      string
+	EventType        string
+	EventTypeVersion string
+	EventState       string
+	CreatedAt        pgtype.Timestamp
+	ScheduledAt      pgtype.Timestamp
+	Retry            int32
+	MaxRetry         int32
+	EventData        []byte
+	AggregateVersion int64
+	EventMetadata    []byte
+	CorrelationID    pgtype.UUID
+	CausationID      pgtype.UUID
+	ActorID          string
+	ActorType        string
+	SourceIp         string
+}
+
+const createOutboxMessages = `-- name: CreateOutboxMessages :exec
+INSERT INTO outbox (event_id)
+SELECT id FROM events
+WHERE id = ANY($1::UUID[])
+ORDER BY sequence_number
+`
+
+func (q *Queries) CreateOutboxMessages(ctx context.Context, eventIds []pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, createOutboxMessages, eventIds)
+	return err
+}
+
+const existsEventAfterVersion = `-- name: ExistsEventAfterVersion :one
+SELECT EXISTS (
+    SELECT 1 FROM events
+    WHERE context_id = $1 AND aggregate_version > $2
+) AS exists
+`
+
+type ExistsEventAfterVersionParams struct {
+	ContextID        pgtype.UUID
+	AggregateVersion int64
+}
+
+func (q *Queries) ExistsEventAfterVersion(ctx context.Context, arg ExistsEventAfterVersionParams) (bool, error) {
+	row := q.db.QueryRow(ctx, existsEventAfterVersion, arg.ContextID, arg.AggregateVersion)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const findAggregateVersion = `-- name: FindAggregateVersion :one
+SELECT COALESCE(MAX(aggregate_version), 0)::BIGINT AS aggregate_version FROM events
+WHERE context_id = $1
+`
+
+func (q *Queries) FindAggregateVersion(ctx context.Context, contextID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, findAggregateVersion, contextID)
+	var aggregate_version int64
+	err := row.Scan(&aggregate_version)
+	return aggregate_version, err
+}
+
 const findEventByID = `-- name: FindEventByID :one
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 WHERE id = $1
 `
 
@@ -33,12 +169,59 @@ func (q *Queries) FindEventByID(ctx context.Context, id pgtype.UUID) (Event, err
 		&i.Retry,
 		&i.MaxRetry,
 		&i.EventData,
+		&i.LeaseExpiresAt,
+		&i.SequenceNumber,
+		&i.AggregateVersion,
+		&i.EventMetadata,
+		&i.CorrelationID,
+		&i.CausationID,
+		&i.ActorID,
+		&i.ActorType,
+		&i.SourceIp,
+		&i.FailureReason,
+	)
+	return i, err
+}
+
+const findEventByIDForUpdate = `-- name: FindEventByIDForUpdate :one
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) FindEventByIDForUpdate(ctx context.Context, id pgtype.UUID) (Event, error) {
+	row := q.db.QueryRow(ctx, findEventByIDForUpdate, id)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.ContextID,
+		&i.EventOrigin,
+		&i.EventType,
+		&i.EventTypeVersion,
+		&i.EventState,
+		&i.CreatedAt,
+		&i.ScheduledAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.Retry,
+		&i.MaxRetry,
+		&i.EventData,
+		&i.LeaseExpiresAt,
+		&i.SequenceNumber,
+		&i.AggregateVersion,
+		&i.EventMetadata,
+		&i.CorrelationID,
+		&i.CausationID,
+		&i.ActorID,
+		&i.ActorType,
+		&i.SourceIp,
+		&i.FailureReason,
 	)
 	return i, err
 }
 
 const findEvents = `-- name: FindEvents :many
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 ORDER BY scheduled_at DESC
 `
 
@@ -65,6 +248,80 @@ func (q *Queries) FindEvents(ctx context.Context) ([]Event, error) {
 			&i.Retry,
 			&i.MaxRetry,
 			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findEventsByFilter = `-- name: FindEventsByFilter :many
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
+WHERE ($1::VARCHAR = '' OR event_state = $1)
+    AND ($2::VARCHAR = '' OR event_origin = $2)
+ORDER BY sequence_number ASC
+LIMIT ($3) OFFSET ($4)
+`
+
+type FindEventsByFilterParams struct {
+	EventState  string
+	EventOrigin string
+	Limit       int32
+	Offset      int32
+}
+
+func (q *Queries) FindEventsByFilter(ctx context.Context, arg FindEventsByFilterParams) ([]Event, error) {
+	rows, err := q.db.Query(ctx, findEventsByFilter,
+		arg.EventState,
+		arg.EventOrigin,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.ContextID,
+			&i.EventOrigin,
+			&i.EventType,
+			&i.EventTypeVersion,
+			&i.EventState,
+			&i.CreatedAt,
+			&i.ScheduledAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.Retry,
+			&i.MaxRetry,
+			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
 		); err != nil {
 			return nil, err
 		}
@@ -77,7 +334,7 @@ func (q *Queries) FindEvents(ctx context.Context) ([]Event, error) {
 }
 
 const findEventsByOriginAndStatus = `-- name: FindEventsByOriginAndStatus :many
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
 WHERE event_origin = $1 AND event_state = $2
 ORDER BY scheduled_at DESC
 LIMIT ($3)
@@ -112,6 +369,16 @@ func (q *Queries) FindEventsByOriginAndStatus(ctx context.Context, arg FindEvent
 			&i.Retry,
 			&i.MaxRetry,
 			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
 		); err != nil {
 			return nil, err
 		}
@@ -124,9 +391,9 @@ func (q *Queries) FindEventsByOriginAndStatus(ctx context.Context, arg FindEvent
 }
 
 const findProcessableEvents = `-- name: FindProcessableEvents :many
-SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data FROM events
-WHERE event_state = 'ready'
-ORDER BY scheduled_at DESC
+SELECT id, context_id, event_origin, event_type, event_type_version, event_state, created_at, scheduled_at, started_at, completed_at, retry, max_retry, event_data, lease_expires_at, sequence_number, aggregate_version, event_metadata, correlation_id, causation_id, actor_id, actor_type, source_ip, failure_reason FROM events
+WHERE event_state = 'ready' AND scheduled_at <= CURRENT_TIMESTAMP
+ORDER BY scheduled_at ASC
 LIMIT ($1)
 `
 
@@ -153,6 +420,16 @@ func (q *Queries) FindProcessableEvents(ctx context.Context, limit int32) ([]Eve
 			&i.Retry,
 			&i.MaxRetry,
 			&i.EventData,
+			&i.LeaseExpiresAt,
+			&i.SequenceNumber,
+			&i.AggregateVersion,
+			&i.EventMetadata,
+			&i.CorrelationID,
+			&i.CausationID,
+			&i.ActorID,
+			&i.ActorType,
+			&i.SourceIp,
+			&i.FailureReason,
 		); err != nil {
 			return nil, err
 		}
@@ -164,6 +441,62 @@ func (q *Queries) FindProcessableEvents(ctx context.Context, limit int32) ([]Eve
 	return items, nil
 }
 
+const requeueEvent = `-- name: RequeueEvent :exec
+UPDATE events
+SET event_state = 'ready',
+    retry = 0,
+    scheduled_at = CURRENT_TIMESTAMP,
+    started_at = NULL,
+    completed_at = NULL,
+    lease_expires_at = NULL,
+    failure_reason = ''
+WHERE id = $1
+`
+
+func (q *Queries) RequeueEvent(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, requeueEvent, id)
+	return err
+}
+
+const requeueEventsByFilter = `-- name: RequeueEventsByFilter :many
+UPDATE events
+SET event_state = 'ready',
+    retry = 0,
+    scheduled_at = CURRENT_TIMESTAMP,
+    started_at = NULL,
+    completed_at = NULL,
+    lease_expires_at = NULL,
+    failure_reason = ''
+WHERE event_state = $1
+    AND ($2::VARCHAR = '' OR event_origin = $2)
+RETURNING id
+`
+
+type RequeueEventsByFilterParams struct {
+	EventState  string
+	EventOrigin string
+}
+
+func (q *Queries) RequeueEventsByFilter(ctx context.Context, arg RequeueEventsByFilterParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, requeueEventsByFilter, arg.EventState, arg.EventOrigin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateEventCompletion = `-- name: UpdateEventCompletion :exec
 UPDATE events
 SET completed_at = CURRENT_TIMESTAMP,
@@ -176,30 +509,52 @@ func (q *Queries) UpdateEventCompletion(ctx context.Context, id pgtype.UUID) err
 	return err
 }
 
+const updateEventFailure = `-- name: UpdateEventFailure :exec
+UPDATE events
+SET retry = retry + 1,
+    max_retry = $1,
+    event_state = 'failed',
+    completed_at = CURRENT_TIMESTAMP,
+    failure_reason = $2
+WHERE id = $3
+`
+
+type UpdateEventFailureParams struct {
+	MaxRetry      int32
+	FailureReason string
+	ID            pgtype.UUID
+}
+
+func (q *Queries) UpdateEventFailure(ctx context.Context, arg UpdateEventFailureParams) error {
+	_, err := q.db.Exec(ctx, updateEventFailure, arg.MaxRetry, arg.FailureReason, arg.ID)
+	return err
+}
+
 const updateEventRetry = `-- name: UpdateEventRetry :exec
 UPDATE events
 SET retry = retry + 1,
-    event_state = CASE
-        WHEN retry + 1 >= max_retry THEN 'failed'
-        ELSE 'ready'
-    END,
-    scheduled_at = CASE
-        WHEN retry + 1 < max_retry THEN CURRENT_TIMESTAMP + ($2 * INTERVAL '1 minute')
-        ELSE scheduled_at
-    END,
-    completed_at = CASE
-        WHEN retry + 1 >= max_retry THEN CURRENT_TIMESTAMP
-    END
-WHERE id = $1
+    max_retry = $1,
+    event_state = 'ready',
+    scheduled_at = CURRENT_TIMESTAMP + ($2::BIGINT * INTERVAL '1 millisecond'),
+    completed_at = NULL,
+    failure_reason = $3
+WHERE id = $4
 `
 
 type UpdateEventRetryParams struct {
+	MaxRetry      int32
+	DelayMs       int64
+	FailureReason string
 	ID            pgtype.UUID
-	RetryInterval interface{}
 }
 
 func (q *Queries) UpdateEventRetry(ctx context.Context, arg UpdateEventRetryParams) error {
-	_, err := q.db.Exec(ctx, updateEventRetry, arg.ID, arg.RetryInterval)
+	_, err := q.db.Exec(ctx, updateEventRetry,
+		arg.MaxRetry,
+		arg.DelayMs,
+		arg.FailureReason,
+		arg.ID,
+	)
 	return err
 }
 
@@ -226,3 +581,20 @@ func (q *Queries) UpdateEventStartedAt(ctx context.Context, id pgtype.UUID) erro
 	_, err := q.db.Exec(ctx, updateEventStartedAt, id)
 	return err
 }
+
+const updateEventState = `-- name: UpdateEventState :exec
+UPDATE events
+SET event_state = $2,
+    completed_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+type UpdateEventStateParams struct {
+	ID         pgtype.UUID
+	EventState string
+}
+
+func (q *Queries) UpdateEventState(ctx context.Context, arg UpdateEventStateParams) error {
+	_, err := q.db.Exec(ctx, updateEventState, arg.ID, arg.EventState)
+	return err
+}
@@ -8,6 +8,35 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Account struct {
+	ID            pgtype.UUID
+	AccountNumber string
+	CustomerID    pgtype.UUID
+	Balance       int64
+	Currency      string
+	Status        string
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+type Customer struct {
+	ID             pgtype.UUID
+	FirstName      string
+	LastName       string
+	Email          string
+	Phone          pgtype.Text
+	DateOfBirth    string
+	AddressStreet  pgtype.Text
+	AddressCity    pgtype.Text
+	AddressState   pgtype.Text
+	AddressZipCode pgtype.Text
+	AddressCountry pgtype.Text
+	Status         string
+	DeletedAt      pgtype.Timestamp
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+}
+
 type Event struct {
 	ID               pgtype.UUID
 	ContextID        pgtype.UUID
@@ -22,4 +51,66 @@ type Event struct {
 	Retry            int32
 	MaxRetry         int32
 	EventData        []byte
+	LeaseExpiresAt   pgtype.Timestamp
+	SequenceNumber   int64
+	AggregateVersion int64
+	EventMetadata    []byte
+	CorrelationID    pgtype.UUID
+	CausationID      pgtype.UUID
+	ActorID          string
+	ActorType        string
+	SourceIp         string
+	FailureReason    string
+}
+
+type EventAudit struct {
+	ID            pgtype.UUID
+	EventID       pgtype.UUID
+	Action        string
+	Actor         string
+	Reason        string
+	PreviousState string
+	CreatedAt     pgtype.Timestamp
+}
+
+type Outbox struct {
+	SequenceNumber int64
+	EventID        pgtype.UUID
+	TransactionID  int64
+	CreatedAt      pgtype.Timestamp
+}
+
+type ProjectionCheckpoint struct {
+	Projection     string
+	CompletedAt    pgtype.Timestamp
+	SequenceNumber int64
+	UpdatedAt      pgtype.Timestamp
+}
+
+type PublishCheckpoint struct {
+	Publisher      string
+	TransactionID  int64
+	SequenceNumber int64
+	UpdatedAt      pgtype.Timestamp
+}
+
+type Snapshot struct {
+	ContextID        pgtype.UUID
+	AggregateType    string
+	AggregateVersion int64
+	SnapshotVersion  string
+	SnapshotData     []byte
+	CreatedAt        pgtype.Timestamp
+}
+
+type Transaction struct {
+	ID              pgtype.UUID
+	SourceAccountID pgtype.UUID
+	TargetAccountID pgtype.UUID
+	Amount          int64
+	Currency        string
+	Status          string
+	FailureReason   pgtype.Text
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
 }
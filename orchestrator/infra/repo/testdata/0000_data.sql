@@ -9,7 +9,8 @@ INSERT INTO events (
     scheduled_at,
     retry,
     max_retry,
-    event_data
+    event_data,
+    aggregate_version
 ) VALUES 
 (
   '00000000-0000-0000-0000-000000000000',
@@ -23,19 +24,6 @@ INSERT INTO events (
   0,
   3,
   '{
-    "id": "00000000-0000-0000-0000-000000000000",
-    "context_id": "11111111-0000-0000-0000-000000000000",
-    "origin": "customer",
-    "type": "customer.created",
-    "type_version": "1.0.0",
-    "state": "ready",
-    "created_at": "2025-05-06T08:09:14.345074Z",
-    "scheduled_at": "2025-05-06T08:09:14.345074Z",
-    "started_at": "0001-01-01T00:00:00Z",
-    "completed_at": "0001-01-01T00:00:00Z",
-    "retry": 0,
-    "max_retry": 3,
-    "data": null,
     "firstName": "John",
     "lastName": "Doe",
     "phone": "1234567890",
@@ -48,7 +36,8 @@ INSERT INTO events (
       "postalCode": "00-000",
       "country": "Poland"
     }
-  }'
+  }',
+  1
 ),
 (
   '00000000-0000-0000-0000-111111111111',
@@ -62,19 +51,6 @@ INSERT INTO events (
   2,
   3,
   '{
-    "id": "00000000-0000-0000-0000-111111111111",
-    "context_id": "22222222-0000-0000-0000-000000000000",
-    "origin": "customer",
-    "type": "customer.blocked",
-    "type_version": "1.0.0",
-    "state": "failed",
-    "created_at": "2025-05-06T08:09:14.345074Z",
-    "scheduled_at": "2025-05-06T08:09:14.345074Z",
-    "started_at": "0001-01-01T00:00:00Z",
-    "completed_at": "0001-01-01T00:00:00Z",
-    "retry":2,
-    "max_retry": 3,
-    "data": null,
     "firstName": "John",
     "lastName": "Doe",
     "phone": "1234567890",
@@ -87,7 +63,8 @@ INSERT INTO events (
       "postalCode": "00-000",
       "country": "Poland"
     }
-  }'
+  }',
+  1
 ),
 (
   '00000000-0000-0000-0000-222222222222',
@@ -126,5 +103,6 @@ INSERT INTO events (
       "postalCode": "00-000",
       "country": "Poland"
     }
-  }'
+  }',
+  1
 );
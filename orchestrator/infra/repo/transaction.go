@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/infra/repo/query"
+)
+
+// TransactionRepository is the repository maintaining the transactions read model of the transfer saga
+type TransactionRepository struct {
+	Conn *pgxpool.Pool
+	Q    *query.Queries
+}
+
+// NewTransactionRepository creates a new transaction repository
+func NewTransactionRepository(conn *pgxpool.Pool) *TransactionRepository {
+	return &TransactionRepository{
+		Conn: conn,
+		Q:    query.New(conn),
+	}
+}
+
+// CreateTransaction creates a new transaction from the event initiating the transfer
+func (r *TransactionRepository) CreateTransaction(ctx context.Context, transactionEvent transactiondomain.TransactionInitiatedEvent) error {
+	err := r.Q.CreateTransaction(ctx, query.CreateTransactionParams{
+		ID:              pgtype.UUID{Bytes: transactionEvent.ContextID, Valid: true},
+		SourceAccountID: pgtype.UUID{Bytes: transactionEvent.SourceAccountID, Valid: true},
+		TargetAccountID: pgtype.UUID{Bytes: transactionEvent.TargetAccountID, Valid: true},
+		Amount:          transactionEvent.Amount.Amount(),
+		Currency:        transactionEvent.Amount.Currency().String(),
+		Status:          transactiondomain.TransactionStatusInitiated.String(),
+		CreatedAt:       pgtype.Timestamp{Time: transactionEvent.CreatedAt, Valid: true},
+		UpdatedAt:       pgtype.Timestamp{Time: transactionEvent.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == query.POSTGRESQL_DUPLICATE_KEY_CODE {
+			return fmt.Errorf("creating transaction: %w", transactiondomain.ErrTransactionAlreadyExists)
+		}
+
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTransactionStatus updates the status and the failure reason of a transaction
+func (r *TransactionRepository) UpdateTransactionStatus(ctx context.Context, id uuid.UUID, status transactiondomain.TransactionStatus, failureReason string) error {
+	if err := r.Q.UpdateTransactionStatus(ctx, query.UpdateTransactionStatusParams{
+		ID:            pgtype.UUID{Bytes: id, Valid: true},
+		Status:        status.String(),
+		FailureReason: pgtype.Text{String: failureReason, Valid: failureReason != ""},
+	}); err != nil {
+		return fmt.Errorf("updating transaction status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+//go:build integration
+
+package repo
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/internal/domain/money"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+func testAmount(t *testing.T) money.Money {
+	amount, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	return amount
+}
+
+func TestOrchestrator_CreateEvents(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	eventRepo := NewOrchestratorRepository(pool, eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy()))
+
+	transaction, err := transactiondomain.NewTransfer(uuid.New(), uuid.New(), uuid.New(), testAmount(t))
+	require.NoError(t, err)
+	require.NoError(t, transaction.ReserveFunds())
+
+	events := make([]eventdomain.Event, 0)
+	for _, ev := range transaction.GetEvents() {
+		events = append(events, ev)
+	}
+
+	require.NoError(t, eventRepo.CreateEvents(ctx, nil, events))
+
+	// The events follow each other in the versions of the transaction
+	for i, ev := range events {
+		created, err := eventRepo.FindByID(ctx, ev.GetID())
+		require.NoError(t, err)
+		require.Equal(t, ev.GetType(), created.Type)
+		require.Equal(t, i+1, created.AggregateVersion)
+	}
+
+	version, err := eventRepo.FindAggregateVersion(ctx, transaction.ID)
+	require.NoError(t, err)
+	require.Equal(t, len(events), version)
+
+	// The step decided on the transaction in the previous version conflicts with the recorded one
+	stale := *transaction.GetEvents()[1].(*transactiondomain.TransactionFundsReservedEvent)
+	stale.ID = uuid.New()
+	err = eventRepo.CreateEvents(ctx, map[uuid.UUID]int{transaction.ID: 1}, []eventdomain.Event{&stale})
+	require.ErrorIs(t, err, eventdomain.ErrConcurrencyConflict)
+
+	// Recording the same saga step again is rejected as a whole
+	err = eventRepo.CreateEvents(ctx, map[uuid.UUID]int{transaction.ID: version}, events[1:])
+	require.ErrorIs(t, err, eventdomain.ErrEventAlreadyExists)
+
+	version, err = eventRepo.FindAggregateVersion(ctx, transaction.ID)
+	require.NoError(t, err)
+	require.Equal(t, len(events), version)
+}
+
+func TestTransactionRepository_CreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	keepContainer := false
+	pool, address := setupTestDB(t, keepContainer)
+
+	log.Printf("container address: %s", address)
+
+	repo := NewTransactionRepository(pool)
+
+	transaction, err := transactiondomain.NewTransfer(uuid.New(), uuid.New(), uuid.New(), testAmount(t))
+	require.NoError(t, err)
+
+	initiatedEvent, ok := transaction.GetEvents()[0].(*transactiondomain.TransactionInitiatedEvent)
+	require.True(t, ok)
+
+	require.NoError(t, repo.CreateTransaction(ctx, *initiatedEvent))
+
+	err = repo.CreateTransaction(ctx, *initiatedEvent)
+	require.ErrorIs(t, err, transactiondomain.ErrTransactionAlreadyExists)
+
+	require.NoError(t, repo.UpdateTransactionStatus(ctx, transaction.ID, transactiondomain.TransactionStatusFailed, "insufficient funds"))
+
+	var status, failureReason string
+	err = pool.QueryRow(ctx, "SELECT status, failure_reason FROM transactions WHERE id = $1", transaction.ID).Scan(&status, &failureReason)
+	require.NoError(t, err)
+	require.Equal(t, transactiondomain.TransactionStatusFailed.String(), status)
+	require.Equal(t, "insufficient funds", failureReason)
+}
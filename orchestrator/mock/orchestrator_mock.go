@@ -10,10 +10,13 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
 	uuid "github.com/google/uuid"
+	event "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	processor "github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +44,20 @@ func (m *MockBaseEvent) EXPECT() *MockBaseEventMockRecorder {
 	return m.recorder
 }
 
+// GetAggregateVersion mocks base method.
+func (m *MockBaseEvent) GetAggregateVersion() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateVersion")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetAggregateVersion indicates an expected call of GetAggregateVersion.
+func (mr *MockBaseEventMockRecorder) GetAggregateVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateVersion", reflect.TypeOf((*MockBaseEvent)(nil).GetAggregateVersion))
+}
+
 // GetCompletedAt mocks base method.
 func (m *MockBaseEvent) GetCompletedAt() time.Time {
 	m.ctrl.T.Helper()
@@ -247,50 +264,195 @@ func (m *MockOrchestratorRepository) EXPECT() *MockOrchestratorRepositoryMockRec
 	return m.recorder
 }
 
-// MockAccountRepository is a mock of AccountRepository interface.
-type MockAccountRepository struct {
+// ClaimProcessableEvents mocks base method.
+func (m *MockOrchestratorRepository) ClaimProcessableEvents(ctx context.Context, limit int, lease time.Duration) ([]*event.BaseEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimProcessableEvents", ctx, limit, lease)
+	ret0, _ := ret[0].([]*event.BaseEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimProcessableEvents indicates an expected call of ClaimProcessableEvents.
+func (mr *MockOrchestratorRepositoryMockRecorder) ClaimProcessableEvents(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimProcessableEvents", reflect.TypeOf((*MockOrchestratorRepository)(nil).ClaimProcessableEvents), ctx, limit, lease)
+}
+
+// UpdateEventRetry mocks base method.
+func (m *MockOrchestratorRepository) UpdateEventRetry(ctx context.Context, id uuid.UUID, cause error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventRetry", ctx, id, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventRetry indicates an expected call of UpdateEventRetry.
+func (mr *MockOrchestratorRepositoryMockRecorder) UpdateEventRetry(ctx, id, cause any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventRetry", reflect.TypeOf((*MockOrchestratorRepository)(nil).UpdateEventRetry), ctx, id, cause)
+}
+
+// UpdateEventState mocks base method.
+func (m *MockOrchestratorRepository) UpdateEventState(ctx context.Context, id uuid.UUID, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventState", ctx, id, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventState indicates an expected call of UpdateEventState.
+func (mr *MockOrchestratorRepositoryMockRecorder) UpdateEventState(ctx, id, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventState", reflect.TypeOf((*MockOrchestratorRepository)(nil).UpdateEventState), ctx, id, state)
+}
+
+// MockProcessor is a mock of Processor interface.
+type MockProcessor struct {
 	ctrl     *gomock.Controller
-	recorder *MockAccountRepositoryMockRecorder
+	recorder *MockProcessorMockRecorder
 	isgomock struct{}
 }
 
-// MockAccountRepositoryMockRecorder is the mock recorder for MockAccountRepository.
-type MockAccountRepositoryMockRecorder struct {
-	mock *MockAccountRepository
+// MockProcessorMockRecorder is the mock recorder for MockProcessor.
+type MockProcessorMockRecorder struct {
+	mock *MockProcessor
 }
 
-// NewMockAccountRepository creates a new mock instance.
-func NewMockAccountRepository(ctrl *gomock.Controller) *MockAccountRepository {
-	mock := &MockAccountRepository{ctrl: ctrl}
-	mock.recorder = &MockAccountRepositoryMockRecorder{mock}
+// NewMockProcessor creates a new mock instance.
+func NewMockProcessor(ctrl *gomock.Controller) *MockProcessor {
+	mock := &MockProcessor{ctrl: ctrl}
+	mock.recorder = &MockProcessorMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
+func (m *MockProcessor) EXPECT() *MockProcessorMockRecorder {
 	return m.recorder
 }
 
-// MockCustomerRepository is a mock of CustomerRepository interface.
-type MockCustomerRepository struct {
+// Process mocks base method.
+func (m *MockProcessor) Process(ctx context.Context, arg1 processor.BaseEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockProcessorMockRecorder) Process(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockProcessor)(nil).Process), ctx, arg1)
+}
+
+// MockProjectionRepository is a mock of ProjectionRepository interface.
+type MockProjectionRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockCustomerRepositoryMockRecorder
+	recorder *MockProjectionRepositoryMockRecorder
 	isgomock struct{}
 }
 
-// MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
-type MockCustomerRepositoryMockRecorder struct {
-	mock *MockCustomerRepository
+// MockProjectionRepositoryMockRecorder is the mock recorder for MockProjectionRepository.
+type MockProjectionRepositoryMockRecorder struct {
+	mock *MockProjectionRepository
 }
 
-// NewMockCustomerRepository creates a new mock instance.
-func NewMockCustomerRepository(ctrl *gomock.Controller) *MockCustomerRepository {
-	mock := &MockCustomerRepository{ctrl: ctrl}
-	mock.recorder = &MockCustomerRepositoryMockRecorder{mock}
+// NewMockProjectionRepository creates a new mock instance.
+func NewMockProjectionRepository(ctrl *gomock.Controller) *MockProjectionRepository {
+	mock := &MockProjectionRepository{ctrl: ctrl}
+	mock.recorder = &MockProjectionRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
+func (m *MockProjectionRepository) EXPECT() *MockProjectionRepositoryMockRecorder {
 	return m.recorder
 }
+
+// DiffShadow mocks base method.
+func (m *MockProjectionRepository) DiffShadow(ctx context.Context, projection string) ([]event.ProjectionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffShadow", ctx, projection)
+	ret0, _ := ret[0].([]event.ProjectionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffShadow indicates an expected call of DiffShadow.
+func (mr *MockProjectionRepositoryMockRecorder) DiffShadow(ctx, projection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffShadow", reflect.TypeOf((*MockProjectionRepository)(nil).DiffShadow), ctx, projection)
+}
+
+// FindCheckpoint mocks base method.
+func (m *MockProjectionRepository) FindCheckpoint(ctx context.Context, projection string) (event.Checkpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCheckpoint", ctx, projection)
+	ret0, _ := ret[0].(event.Checkpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCheckpoint indicates an expected call of FindCheckpoint.
+func (mr *MockProjectionRepositoryMockRecorder) FindCheckpoint(ctx, projection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCheckpoint", reflect.TypeOf((*MockProjectionRepository)(nil).FindCheckpoint), ctx, projection)
+}
+
+// FindCompletedEvents mocks base method.
+func (m *MockProjectionRepository) FindCompletedEvents(ctx context.Context, projection string, after event.Checkpoint, limit int) ([]*event.BaseEvent, event.Checkpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCompletedEvents", ctx, projection, after, limit)
+	ret0, _ := ret[0].([]*event.BaseEvent)
+	ret1, _ := ret[1].(event.Checkpoint)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindCompletedEvents indicates an expected call of FindCompletedEvents.
+func (mr *MockProjectionRepositoryMockRecorder) FindCompletedEvents(ctx, projection, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCompletedEvents", reflect.TypeOf((*MockProjectionRepository)(nil).FindCompletedEvents), ctx, projection, after, limit)
+}
+
+// PrepareShadow mocks base method.
+func (m *MockProjectionRepository) PrepareShadow(ctx context.Context, projection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareShadow", ctx, projection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrepareShadow indicates an expected call of PrepareShadow.
+func (mr *MockProjectionRepositoryMockRecorder) PrepareShadow(ctx, projection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareShadow", reflect.TypeOf((*MockProjectionRepository)(nil).PrepareShadow), ctx, projection)
+}
+
+// SaveCheckpoint mocks base method.
+func (m *MockProjectionRepository) SaveCheckpoint(ctx context.Context, projection string, checkpoint event.Checkpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCheckpoint", ctx, projection, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCheckpoint indicates an expected call of SaveCheckpoint.
+func (mr *MockProjectionRepositoryMockRecorder) SaveCheckpoint(ctx, projection, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCheckpoint", reflect.TypeOf((*MockProjectionRepository)(nil).SaveCheckpoint), ctx, projection, checkpoint)
+}
+
+// SwapShadow mocks base method.
+func (m *MockProjectionRepository) SwapShadow(ctx context.Context, projection string, catchUp func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapShadow", ctx, projection, catchUp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwapShadow indicates an expected call of SwapShadow.
+func (mr *MockProjectionRepositoryMockRecorder) SwapShadow(ctx, projection, catchUp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapShadow", reflect.TypeOf((*MockProjectionRepository)(nil).SwapShadow), ctx, projection, catchUp)
+}
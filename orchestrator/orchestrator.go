@@ -1,17 +1,168 @@
 package orchestrator
 
-// Orchestrator is the main struct for the orchestrator
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+)
+
+// Config holds the orchestrator configuration
+type Config struct {
+	PollInterval  time.Duration // How often the events table is polled for the events ready to be processed
+	BatchSize     int           // Maximum number of events claimed by a single poll
+	Workers       int           // Number of workers processing the claimed events concurrently
+	LeaseDuration time.Duration // How long a claimed event stays reserved for a worker, after that it can be claimed again
+}
+
+// DefaultConfig returns the default orchestrator configuration
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:  1 * time.Second,
+		BatchSize:     50,
+		Workers:       4,
+		LeaseDuration: 30 * time.Second,
+	}
+}
+
+// DefaultRetryPolicies returns the retry policies of the account, customer and transaction events,
+// the events of other origins are retried by the default retry policy
+func DefaultRetryPolicies() *eventdomain.RetryPolicies {
+	policies := eventdomain.NewRetryPolicies(eventdomain.DefaultRetryPolicy())
+	accountdomain.RegisterRetryPolicies(policies)
+	customerdomain.RegisterRetryPolicies(policies)
+	transactiondomain.RegisterRetryPolicies(policies)
+
+	return policies
+}
+
+// validate checks if the configuration allows to run the orchestrator
+func (c Config) validate() error {
+	if c.PollInterval <= 0 || c.BatchSize <= 0 || c.Workers <= 0 || c.LeaseDuration < time.Second {
+		return fmt.Errorf("validating orchestrator config %+v: %w", c, ErrInvalidConfig)
+	}
+
+	return nil
+}
+
+// Orchestrator is the main struct for the orchestrator.
+// It claims the events ready to be processed and dispatches them by their origin to the processors.
 type Orchestrator struct {
-	orcRepo      OrchestratorRepository
-	accountRepo  AccountRepository
-	customerRepo CustomerRepository
+	config     Config
+	orcRepo    OrchestratorRepository
+	processors map[string]Processor
 }
 
 // NewOrchestrator creates a new Orchestrator
 func NewOrchestrator(
+	config Config,
 	orcRepo OrchestratorRepository,
-	accountRepo AccountRepository,
-	customerRepo CustomerRepository,
+	accountProcessor Processor,
+	customerProcessor Processor,
+	transactionProcessor Processor,
 ) *Orchestrator {
-	return &Orchestrator{orcRepo, accountRepo, customerRepo}
+	return &Orchestrator{
+		config:  config,
+		orcRepo: orcRepo,
+		processors: map[string]Processor{
+			"account":     accountProcessor,
+			"customer":    customerProcessor,
+			"transaction": transactionProcessor,
+		},
+	}
+}
+
+// Run polls the events table and processes the claimed events with the pool of workers until the context is cancelled.
+// The events being processed when the context is cancelled are finished before Run returns,
+// the events claimed but not started yet are claimed again once their lease expires.
+func (o *Orchestrator) Run(ctx context.Context) error {
+	if err := o.config.validate(); err != nil {
+		return err
+	}
+
+	events := make(chan *eventdomain.BaseEvent)
+
+	var wg sync.WaitGroup
+	for range o.config.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ev := range events {
+				o.process(ctx, ev)
+			}
+		}()
+	}
+
+	defer func() {
+		close(events)
+		wg.Wait()
+	}()
+
+	ticker := time.NewTicker(o.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		o.poll(ctx, events)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll claims the events ready to be processed and hands them over to the workers
+func (o *Orchestrator) poll(ctx context.Context, events chan<- *eventdomain.BaseEvent) {
+	claimed, err := o.orcRepo.ClaimProcessableEvents(ctx, o.config.BatchSize, o.config.LeaseDuration)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("claiming processable events: %v", err)
+		}
+
+		return
+	}
+
+	for _, ev := range claimed {
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// process dispatches the event to the processor of its origin.
+// The processing isn't interrupted by the shutdown, but it can't last longer than the lease of the event.
+// The events recorded by the processor are caused by the event, they share its correlation ID and actor.
+func (o *Orchestrator) process(ctx context.Context, ev *eventdomain.BaseEvent) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), o.config.LeaseDuration)
+	defer cancel()
+
+	ctx = eventdomain.ContextWithMetadata(ctx, ev.Metadata.CausedBy(ev.GetID()))
+
+	processor, ok := o.processors[ev.GetOrigin()]
+	if !ok {
+		if err := o.orcRepo.UpdateEventState(ctx, ev.GetID(), "unprocessable"); err != nil {
+			log.Printf("updating state of event %s with unknown origin %q: %v", ev.GetID(), ev.GetOrigin(), err)
+		}
+
+		return
+	}
+
+	if err := processor.Process(ctx, ev); err != nil {
+		log.Printf("processing %s event %s: %v", ev.GetType(), ev.GetID(), err)
+
+		// When the retry can't be recorded either, the event is claimed again once its lease expires
+		if errRetry := o.orcRepo.UpdateEventRetry(ctx, ev.GetID(), err); errRetry != nil {
+			log.Printf("updating retry of event %s: %v", ev.GetID(), errRetry)
+		}
+	}
 }
@@ -0,0 +1,13 @@
+package orchestrator
+
+import (
+	"errors"
+)
+
+// Orchestrator errors
+var (
+	// ErrInvalidConfig is returned when the orchestrator can't run with the given configuration
+	ErrInvalidConfig = errors.New("invalid orchestrator config")
+	// ErrReplayFailed is returned when a completed event can't be replayed into the rebuilt read model
+	ErrReplayFailed = errors.New("event replay failed")
+)
@@ -1,9 +1,13 @@
 package orchestrator
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor"
 )
 
 //go:generate mockgen -destination=./mock/orchestrator_mock.go -package=mock -source=./orchestrator_interface.go
@@ -46,12 +50,47 @@ type BaseEvent interface {
 	// GetMaxRetry returns the maximum number of times the event can be retried
 	GetMaxRetry() int
 
+	// GetAggregateVersion returns the version of the aggregate (context) the event leads to
+	GetAggregateVersion() int
+
 	// GetEventData returns the event data
 	GetEventData() []byte
 }
 
-type OrchestratorRepository interface{}
+// OrchestratorRepository defines the interface for claiming the events and recording the outcome of their dispatching
+type OrchestratorRepository interface {
+	// ClaimProcessableEvents claims up to limit events ready to be processed and leases them for the lease duration.
+	// At most one event of a context is claimed until it is finished, so the events of an aggregate are processed in order.
+	ClaimProcessableEvents(ctx context.Context, limit int, lease time.Duration) ([]*eventdomain.BaseEvent, error)
+	// UpdateEventRetry schedules the event which failed with the cause to be processed again,
+	// the retry policy of the event fails it when the cause is terminal or the attempts are exhausted
+	UpdateEventRetry(ctx context.Context, id uuid.UUID, cause error) error
+	// UpdateEventState updates the event state
+	UpdateEventState(ctx context.Context, id uuid.UUID, state string) error
+}
 
-type AccountRepository interface{}
+// Processor processes the events of a single origin, i.e. account events
+type Processor interface {
+	// Process processes the event and records the outcome of the processing
+	Process(ctx context.Context, event processor.BaseEvent) error
+}
 
-type CustomerRepository interface{}
+// ProjectionRepository defines the interface for rebuilding a read model (projection) in its shadow table
+type ProjectionRepository interface {
+	// PrepareShadow creates the empty shadow table of the read model, replacing the one left by the previous rebuild,
+	// and removes the checkpoint of the previous rebuild
+	PrepareShadow(ctx context.Context, projection string) error
+	// FindCheckpoint returns the checkpoint of the rebuild of the read model
+	FindCheckpoint(ctx context.Context, projection string) (eventdomain.Checkpoint, error)
+	// SaveCheckpoint records the last event replayed into the shadow table of the read model
+	SaveCheckpoint(ctx context.Context, projection string, checkpoint eventdomain.Checkpoint) error
+	// FindCompletedEvents returns up to limit events of the read model completed after the checkpoint, in the order they were completed,
+	// together with the checkpoint of the last one
+	FindCompletedEvents(ctx context.Context, projection string, after eventdomain.Checkpoint, limit int) ([]*eventdomain.BaseEvent, eventdomain.Checkpoint, error)
+	// DiffShadow returns the rows which differ between the live read model and its shadow table
+	DiffShadow(ctx context.Context, projection string) ([]eventdomain.ProjectionDiff, error)
+	// SwapShadow replaces the live read model with its shadow table atomically, the catch-up replays the events completed
+	// since the last checkpoint while the live read model is locked. It fails with ErrProjectionBusy when the events
+	// of the read model are being processed.
+	SwapShadow(ctx context.Context, projection string, catchUp func(ctx context.Context) error) error
+}
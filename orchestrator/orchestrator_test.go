@@ -0,0 +1,274 @@
+//go:build unit
+
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	accountdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/account"
+	customerdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/customer"
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	transactiondomain "github.com/stefanowiczd/ddd-case-01/internal/domain/transaction"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/mock"
+)
+
+func testConfig() Config {
+	return Config{
+		PollInterval:  10 * time.Millisecond,
+		BatchSize:     10,
+		Workers:       2,
+		LeaseDuration: time.Second,
+	}
+}
+
+func testEvent(origin string) *eventdomain.BaseEvent {
+	return &eventdomain.BaseEvent{
+		ID:          uuid.New(),
+		ContextID:   uuid.New(),
+		Origin:      origin,
+		Type:        origin + ".created",
+		TypeVersion: "0.0.0",
+		State:       eventdomain.EventStateReady.String(),
+		CreatedAt:   time.Now().UTC(),
+		ScheduledAt: time.Now().UTC(),
+		MaxRetry:    3,
+	}
+}
+
+// runOrchestrator runs the orchestrator until all the expected calls are done, then shuts it down
+func runOrchestrator(t *testing.T, o *Orchestrator, done *sync.WaitGroup) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- o.Run(ctx)
+	}()
+
+	finished := make(chan struct{})
+	go func() {
+		done.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("events weren't processed in time")
+	}
+
+	cancel()
+
+	select {
+	case err := <-result:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("orchestrator didn't shut down in time")
+	}
+}
+
+func TestOrchestrator_Run_DispatchesEventsByOrigin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountEvent := testEvent("account")
+	customerEvent := testEvent("customer")
+	transactionEvent := testEvent("transaction")
+	unknownEvent := testEvent("product")
+
+	var done sync.WaitGroup
+	done.Add(4)
+
+	processed := func(_ context.Context, _ processor.BaseEvent) error {
+		done.Done()
+		return nil
+	}
+
+	orcRepo := mock.NewMockOrchestratorRepository(ctrl)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).
+		Return([]*eventdomain.BaseEvent{accountEvent, customerEvent, transactionEvent, unknownEvent}, nil)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return(nil, nil).AnyTimes()
+	orcRepo.EXPECT().UpdateEventState(gomock.Any(), unknownEvent.ID, "unprocessable").
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string) error {
+			done.Done()
+			return nil
+		})
+
+	accountProcessor := mock.NewMockProcessor(ctrl)
+	accountProcessor.EXPECT().Process(gomock.Any(), accountEvent).DoAndReturn(processed)
+
+	customerProcessor := mock.NewMockProcessor(ctrl)
+	customerProcessor.EXPECT().Process(gomock.Any(), customerEvent).DoAndReturn(processed)
+
+	transactionProcessor := mock.NewMockProcessor(ctrl)
+	transactionProcessor.EXPECT().Process(gomock.Any(), transactionEvent).DoAndReturn(processed)
+
+	o := NewOrchestrator(testConfig(), orcRepo, accountProcessor, customerProcessor, transactionProcessor)
+
+	runOrchestrator(t, o, &done)
+}
+
+func TestOrchestrator_Run_RetriesEventWhenProcessingFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountEvent := testEvent("account")
+	errProcess := errors.New("decoding event")
+
+	var done sync.WaitGroup
+	done.Add(1)
+
+	orcRepo := mock.NewMockOrchestratorRepository(ctrl)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return([]*eventdomain.BaseEvent{accountEvent}, nil)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return(nil, nil).AnyTimes()
+	orcRepo.EXPECT().UpdateEventRetry(gomock.Any(), accountEvent.ID, errProcess).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ error) error {
+			done.Done()
+			return nil
+		})
+
+	accountProcessor := mock.NewMockProcessor(ctrl)
+	accountProcessor.EXPECT().Process(gomock.Any(), accountEvent).Return(errProcess)
+
+	o := NewOrchestrator(testConfig(), orcRepo, accountProcessor, mock.NewMockProcessor(ctrl), mock.NewMockProcessor(ctrl))
+
+	runOrchestrator(t, o, &done)
+}
+
+func TestOrchestrator_Run_StampsCausedEventsMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transactionEvent := testEvent("transaction")
+	transactionEvent.Metadata = eventdomain.Metadata{
+		CorrelationID:  uuid.New(),
+		CausationID:    uuid.New(),
+		ActorID:        "user-1",
+		ActorType:      eventdomain.ActorTypeUser,
+		SourceIP:       "192.0.2.1",
+		IdempotencyKey: "transfer-1",
+	}
+
+	var done sync.WaitGroup
+	done.Add(1)
+
+	orcRepo := mock.NewMockOrchestratorRepository(ctrl)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return([]*eventdomain.BaseEvent{transactionEvent}, nil)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return(nil, nil).AnyTimes()
+
+	// The events recorded by the processor are caused by the processed event and share its correlation ID and actor
+	transactionProcessor := mock.NewMockProcessor(ctrl)
+	transactionProcessor.EXPECT().Process(gomock.Any(), transactionEvent).
+		DoAndReturn(func(ctx context.Context, _ processor.BaseEvent) error {
+			defer done.Done()
+
+			require.Equal(t, eventdomain.Metadata{
+				CorrelationID: transactionEvent.Metadata.CorrelationID,
+				CausationID:   transactionEvent.ID,
+				ActorID:       "user-1",
+				ActorType:     eventdomain.ActorTypeUser,
+			}, eventdomain.MetadataFromContext(ctx))
+			return nil
+		})
+
+	o := NewOrchestrator(testConfig(), orcRepo, mock.NewMockProcessor(ctrl), mock.NewMockProcessor(ctrl), transactionProcessor)
+
+	runOrchestrator(t, o, &done)
+}
+
+func TestOrchestrator_Run_KeepsPollingWhenClaimingFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	customerEvent := testEvent("customer")
+
+	var done sync.WaitGroup
+	done.Add(1)
+
+	orcRepo := mock.NewMockOrchestratorRepository(ctrl)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return(nil, errors.New("db error"))
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return([]*eventdomain.BaseEvent{customerEvent}, nil)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return(nil, nil).AnyTimes()
+
+	customerProcessor := mock.NewMockProcessor(ctrl)
+	customerProcessor.EXPECT().Process(gomock.Any(), customerEvent).
+		DoAndReturn(func(_ context.Context, _ processor.BaseEvent) error {
+			done.Done()
+			return nil
+		})
+
+	o := NewOrchestrator(testConfig(), orcRepo, mock.NewMockProcessor(ctrl), customerProcessor, mock.NewMockProcessor(ctrl))
+
+	runOrchestrator(t, o, &done)
+}
+
+func TestOrchestrator_Run_FinishesEventsBeingProcessedOnShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountEvent := testEvent("account")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orcRepo := mock.NewMockOrchestratorRepository(ctrl)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return([]*eventdomain.BaseEvent{accountEvent}, nil)
+	orcRepo.EXPECT().ClaimProcessableEvents(gomock.Any(), 10, time.Second).Return(nil, nil).AnyTimes()
+
+	finished := false
+
+	accountProcessor := mock.NewMockProcessor(ctrl)
+	accountProcessor.EXPECT().Process(gomock.Any(), accountEvent).
+		DoAndReturn(func(processCtx context.Context, _ processor.BaseEvent) error {
+			// The shutdown is requested while the event is being processed
+			cancel()
+			time.Sleep(50 * time.Millisecond)
+
+			require.NoError(t, processCtx.Err())
+			finished = true
+
+			return nil
+		})
+
+	o := NewOrchestrator(testConfig(), orcRepo, accountProcessor, mock.NewMockProcessor(ctrl), mock.NewMockProcessor(ctrl))
+
+	require.NoError(t, o.Run(ctx))
+	require.True(t, finished)
+}
+
+func TestOrchestrator_Run_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := testConfig()
+	config.Workers = 0
+
+	o := NewOrchestrator(
+		config,
+		mock.NewMockOrchestratorRepository(ctrl),
+		mock.NewMockProcessor(ctrl),
+		mock.NewMockProcessor(ctrl),
+		mock.NewMockProcessor(ctrl),
+	)
+
+	require.ErrorIs(t, o.Run(context.Background()), ErrInvalidConfig)
+}
+
+func TestDefaultRetryPolicies(t *testing.T) {
+	policies := DefaultRetryPolicies()
+
+	require.False(t, policies.Policy("account", "account.funds.withdrawn").IsRetryable(accountdomain.ErrAccountInsufficientFunds))
+	require.False(t, policies.Policy("customer", "customer.blocked").IsRetryable(customerdomain.ErrCustomerNotFound))
+	require.False(t, policies.Policy("transaction", "transaction.initiated").IsRetryable(transactiondomain.ErrTransactionInvalidState))
+	require.False(t, policies.Policy("product", "product.created").IsRetryable(eventdomain.ErrEventDataInvalid))
+	require.True(t, policies.Policy("account", "account.created").IsRetryable(errors.New("db error")))
+}
@@ -0,0 +1,189 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor"
+)
+
+// RebuildConfig holds the configuration of the read model (projection) rebuild
+type RebuildConfig struct {
+	BatchSize    int           // Maximum number of events replayed between two checkpoints
+	SwapAttempts int           // Number of attempts to swap the rebuilt read model in while its events are being processed
+	SwapDelay    time.Duration // Delay between the swap attempts
+}
+
+// DefaultRebuildConfig returns the default configuration of the read model rebuild
+func DefaultRebuildConfig() RebuildConfig {
+	return RebuildConfig{
+		BatchSize:    500,
+		SwapAttempts: 10,
+		SwapDelay:    1 * time.Second,
+	}
+}
+
+// RebuildOptions holds the options of a single read model rebuild
+type RebuildOptions struct {
+	DryRun bool // Diff the rebuilt read model against the live one instead of swapping it in
+	Resume bool // Continue the interrupted rebuild from its checkpoint instead of replaying all the events again
+}
+
+// RebuildResult describes the outcome of the read model rebuild
+type RebuildResult struct {
+	Replayed int                          // Number of the events replayed into the rebuilt read model
+	Diff     []eventdomain.ProjectionDiff // Rows which differ between the live and the rebuilt read model, set by the dry run only
+}
+
+// ProjectionRebuilder rebuilds a read model (projection) maintained by the orchestrator, i.e. accounts.
+// It replays the completed events of the read model into its shadow table through the same processor which maintains
+// the live read model, then it swaps the shadow table in.
+type ProjectionRebuilder struct {
+	config         RebuildConfig
+	projection     string
+	projectionRepo ProjectionRepository
+	processor      Processor
+}
+
+// NewProjectionRebuilder creates a new rebuilder of the read model. The processor is created by newProcessor with the repository
+// recording the outcome of the replayed events, it has to maintain the shadow table of the read model.
+func NewProjectionRebuilder(
+	config RebuildConfig,
+	projection string,
+	projectionRepo ProjectionRepository,
+	newProcessor func(orcRepo processor.OrchestratorRepository) Processor,
+) *ProjectionRebuilder {
+	return &ProjectionRebuilder{
+		config:         config,
+		projection:     projection,
+		projectionRepo: projectionRepo,
+		processor:      newProcessor(replayRecorder{}),
+	}
+}
+
+// Rebuild replays the events into the shadow table of the read model, then it swaps the shadow table in or, for the dry run,
+// diffs it against the live read model. The checkpoint of the last replayed events is saved after every batch,
+// so an interrupted rebuild can be resumed.
+func (r *ProjectionRebuilder) Rebuild(ctx context.Context, options RebuildOptions) (RebuildResult, error) {
+	if r.config.BatchSize <= 0 || r.config.SwapAttempts <= 0 {
+		return RebuildResult{}, fmt.Errorf("validating rebuild config %+v: %w", r.config, ErrInvalidConfig)
+	}
+
+	var checkpoint eventdomain.Checkpoint
+
+	if options.Resume {
+		found, err := r.projectionRepo.FindCheckpoint(ctx, r.projection)
+		if err != nil {
+			return RebuildResult{}, fmt.Errorf("resuming rebuild of %s projection: %w", r.projection, err)
+		}
+
+		checkpoint = found
+	} else if err := r.projectionRepo.PrepareShadow(ctx, r.projection); err != nil {
+		return RebuildResult{}, fmt.Errorf("rebuilding %s projection: %w", r.projection, err)
+	}
+
+	result := RebuildResult{}
+
+	checkpoint, replayed, err := r.replay(ctx, checkpoint)
+	result.Replayed += replayed
+	if err != nil {
+		return result, fmt.Errorf("rebuilding %s projection: %w", r.projection, err)
+	}
+
+	if options.DryRun {
+		diff, err := r.projectionRepo.DiffShadow(ctx, r.projection)
+		if err != nil {
+			return result, fmt.Errorf("rebuilding %s projection: %w", r.projection, err)
+		}
+
+		result.Diff = diff
+
+		return result, nil
+	}
+
+	// The events completed since the last checkpoint are replayed while the live read model is locked against writes
+	catchUp := func(ctx context.Context) error {
+		var replayed int
+		var err error
+
+		checkpoint, replayed, err = r.replay(ctx, checkpoint)
+		result.Replayed += replayed
+
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.projectionRepo.SwapShadow(ctx, r.projection, catchUp)
+		if err == nil {
+			return result, nil
+		}
+
+		if !errors.Is(err, eventdomain.ErrProjectionBusy) || attempt >= r.config.SwapAttempts {
+			return result, fmt.Errorf("rebuilding %s projection: %w", r.projection, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, fmt.Errorf("rebuilding %s projection: %w", r.projection, ctx.Err())
+		case <-time.After(r.config.SwapDelay):
+		}
+	}
+}
+
+// replay replays the events completed after the checkpoint in batches and saves the checkpoint after each batch,
+// it returns the checkpoint of the last replayed event and the number of the replayed events
+func (r *ProjectionRebuilder) replay(ctx context.Context, checkpoint eventdomain.Checkpoint) (eventdomain.Checkpoint, int, error) {
+	replayed := 0
+
+	for {
+		events, next, err := r.projectionRepo.FindCompletedEvents(ctx, r.projection, checkpoint, r.config.BatchSize)
+		if err != nil {
+			return checkpoint, replayed, fmt.Errorf("replaying events: %w", err)
+		}
+
+		if len(events) == 0 {
+			return checkpoint, replayed, nil
+		}
+
+		for _, ev := range events {
+			if err := r.processor.Process(ctx, ev); err != nil {
+				return checkpoint, replayed, fmt.Errorf("replaying %s event %s: %w", ev.GetType(), ev.GetID(), err)
+			}
+
+			replayed++
+		}
+
+		if err := r.projectionRepo.SaveCheckpoint(ctx, r.projection, next); err != nil {
+			return checkpoint, replayed, fmt.Errorf("replaying events: %w", err)
+		}
+
+		checkpoint = next
+	}
+}
+
+// replayRecorder records the outcome of the replayed events for the processor instead of the events table.
+// The replayed events were completed already, so a processor can only complete them again, any other outcome fails the replay.
+// The processors maintaining the read models don't use the other operations of the repository.
+type replayRecorder struct {
+	processor.OrchestratorRepository
+}
+
+// UpdateEventCompletion leaves the replayed event completed
+func (replayRecorder) UpdateEventCompletion(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+// UpdateEventRetry fails the replay with the cause of the processing failure
+func (replayRecorder) UpdateEventRetry(_ context.Context, id uuid.UUID, cause error) error {
+	return fmt.Errorf("replaying event %s: %w: %w", id, ErrReplayFailed, cause)
+}
+
+// UpdateEventState fails the replay, the event couldn't be processed
+func (replayRecorder) UpdateEventState(_ context.Context, id uuid.UUID, state string) error {
+	return fmt.Errorf("replaying event %s into %s state: %w", id, state, ErrReplayFailed)
+}
@@ -0,0 +1,260 @@
+//go:build unit
+
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	eventdomain "github.com/stefanowiczd/ddd-case-01/internal/domain/event"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/application/processor"
+	"github.com/stefanowiczd/ddd-case-01/orchestrator/mock"
+)
+
+// testReplayProcessor completes the replayed events, or reports the processing failure to the retry
+type testReplayProcessor struct {
+	orcRepo   processor.OrchestratorRepository
+	err       error
+	processed []uuid.UUID
+}
+
+func (p *testReplayProcessor) Process(ctx context.Context, event processor.BaseEvent) error {
+	p.processed = append(p.processed, event.GetID())
+
+	if p.err != nil {
+		return p.orcRepo.UpdateEventRetry(ctx, event.GetID(), p.err)
+	}
+
+	return p.orcRepo.UpdateEventCompletion(ctx, event.GetID())
+}
+
+func testRebuildConfig() RebuildConfig {
+	return RebuildConfig{
+		BatchSize:    2,
+		SwapAttempts: 2,
+		SwapDelay:    time.Millisecond,
+	}
+}
+
+func TestProjectionRebuilder_Rebuild(t *testing.T) {
+	first := testEvent("account")
+	second := testEvent("account")
+	third := testEvent("account")
+
+	firstBatch := eventdomain.Checkpoint{CompletedAt: time.Now().UTC(), SequenceNumber: 2}
+	secondBatch := eventdomain.Checkpoint{CompletedAt: firstBatch.CompletedAt.Add(time.Second), SequenceNumber: 3}
+	diff := []eventdomain.ProjectionDiff{{ID: first.ContextID.String(), Live: `{"balance": 100}`, Rebuilt: `{"balance": 200}`}}
+
+	// catchUp runs the catch-up of the swap, which finds no more events
+	catchUp := func(m *mock.MockProjectionRepository, after eventdomain.Checkpoint) func(ctx context.Context, _ string, catchUp func(ctx context.Context) error) error {
+		return func(ctx context.Context, _ string, catchUp func(ctx context.Context) error) error {
+			m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", after, 2).Return(nil, after, nil)
+			return catchUp(ctx)
+		}
+	}
+
+	type testCaseParams struct {
+		options                  RebuildOptions
+		processErr               error
+		mockProjectionRepository func(ctrl *gomock.Controller) *mock.MockProjectionRepository
+	}
+
+	type testCaseExpected struct {
+		processed []uuid.UUID
+		replayed  int
+		diff      []eventdomain.ProjectionDiff
+		err       error
+	}
+
+	tests := []struct {
+		name     string
+		params   testCaseParams
+		expected testCaseExpected
+	}{
+		{
+			name: "should replay events in batches and swap shadow table in",
+			params: testCaseParams{
+				mockProjectionRepository: func(ctrl *gomock.Controller) *mock.MockProjectionRepository {
+					m := mock.NewMockProjectionRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().PrepareShadow(gomock.Any(), "accounts").Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", eventdomain.Checkpoint{}, 2).
+							Return([]*eventdomain.BaseEvent{first, second}, firstBatch, nil),
+						m.EXPECT().SaveCheckpoint(gomock.Any(), "accounts", firstBatch).Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", firstBatch, 2).
+							Return([]*eventdomain.BaseEvent{third}, secondBatch, nil),
+						m.EXPECT().SaveCheckpoint(gomock.Any(), "accounts", secondBatch).Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", secondBatch, 2).Return(nil, secondBatch, nil),
+						m.EXPECT().SwapShadow(gomock.Any(), "accounts", gomock.Any()).DoAndReturn(catchUp(m, secondBatch)),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				processed: []uuid.UUID{first.ID, second.ID, third.ID},
+				replayed:  3,
+			},
+		},
+		{
+			name: "should resume rebuild from checkpoint",
+			params: testCaseParams{
+				options: RebuildOptions{Resume: true},
+				mockProjectionRepository: func(ctrl *gomock.Controller) *mock.MockProjectionRepository {
+					m := mock.NewMockProjectionRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().FindCheckpoint(gomock.Any(), "accounts").Return(firstBatch, nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", firstBatch, 2).
+							Return([]*eventdomain.BaseEvent{third}, secondBatch, nil),
+						m.EXPECT().SaveCheckpoint(gomock.Any(), "accounts", secondBatch).Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", secondBatch, 2).Return(nil, secondBatch, nil),
+						m.EXPECT().SwapShadow(gomock.Any(), "accounts", gomock.Any()).DoAndReturn(catchUp(m, secondBatch)),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				processed: []uuid.UUID{third.ID},
+				replayed:  1,
+			},
+		},
+		{
+			name: "should diff shadow table against live one - dry run",
+			params: testCaseParams{
+				options: RebuildOptions{DryRun: true},
+				mockProjectionRepository: func(ctrl *gomock.Controller) *mock.MockProjectionRepository {
+					m := mock.NewMockProjectionRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().PrepareShadow(gomock.Any(), "accounts").Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", eventdomain.Checkpoint{}, 2).
+							Return([]*eventdomain.BaseEvent{first}, firstBatch, nil),
+						m.EXPECT().SaveCheckpoint(gomock.Any(), "accounts", firstBatch).Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", firstBatch, 2).Return(nil, firstBatch, nil),
+						m.EXPECT().DiffShadow(gomock.Any(), "accounts").Return(diff, nil),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				processed: []uuid.UUID{first.ID},
+				replayed:  1,
+				diff:      diff,
+			},
+		},
+		{
+			name: "should swap shadow table in once events aren't processed",
+			params: testCaseParams{
+				mockProjectionRepository: func(ctrl *gomock.Controller) *mock.MockProjectionRepository {
+					m := mock.NewMockProjectionRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().PrepareShadow(gomock.Any(), "accounts").Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", eventdomain.Checkpoint{}, 2).Return(nil, eventdomain.Checkpoint{}, nil),
+						m.EXPECT().SwapShadow(gomock.Any(), "accounts", gomock.Any()).Return(eventdomain.ErrProjectionBusy),
+						m.EXPECT().SwapShadow(gomock.Any(), "accounts", gomock.Any()).DoAndReturn(catchUp(m, eventdomain.Checkpoint{})),
+					)
+					return m
+				},
+			},
+		},
+		{
+			name: "shouldn't swap shadow table in - events still processed",
+			params: testCaseParams{
+				mockProjectionRepository: func(ctrl *gomock.Controller) *mock.MockProjectionRepository {
+					m := mock.NewMockProjectionRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().PrepareShadow(gomock.Any(), "accounts").Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", eventdomain.Checkpoint{}, 2).Return(nil, eventdomain.Checkpoint{}, nil),
+						m.EXPECT().SwapShadow(gomock.Any(), "accounts", gomock.Any()).Return(eventdomain.ErrProjectionBusy).Times(2),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				err: eventdomain.ErrProjectionBusy,
+			},
+		},
+		{
+			name: "shouldn't rebuild projection - event replay failed",
+			params: testCaseParams{
+				processErr: errors.New("account not found"),
+				mockProjectionRepository: func(ctrl *gomock.Controller) *mock.MockProjectionRepository {
+					m := mock.NewMockProjectionRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().PrepareShadow(gomock.Any(), "accounts").Return(nil),
+						m.EXPECT().FindCompletedEvents(gomock.Any(), "accounts", eventdomain.Checkpoint{}, 2).
+							Return([]*eventdomain.BaseEvent{first, second}, firstBatch, nil),
+					)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				processed: []uuid.UUID{first.ID},
+				err:       ErrReplayFailed,
+			},
+		},
+		{
+			name: "shouldn't resume rebuild - checkpoint not found",
+			params: testCaseParams{
+				options: RebuildOptions{Resume: true},
+				mockProjectionRepository: func(ctrl *gomock.Controller) *mock.MockProjectionRepository {
+					m := mock.NewMockProjectionRepository(ctrl)
+					m.EXPECT().FindCheckpoint(gomock.Any(), "accounts").Return(eventdomain.Checkpoint{}, eventdomain.ErrCheckpointNotFound)
+					return m
+				},
+			},
+			expected: testCaseExpected{
+				err: eventdomain.ErrCheckpointNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			replayProcessor := &testReplayProcessor{err: tt.params.processErr}
+
+			rebuilder := NewProjectionRebuilder(
+				testRebuildConfig(),
+				"accounts",
+				tt.params.mockProjectionRepository(ctrl),
+				func(orcRepo processor.OrchestratorRepository) Processor {
+					replayProcessor.orcRepo = orcRepo
+					return replayProcessor
+				},
+			)
+
+			result, err := rebuilder.Rebuild(context.Background(), tt.params.options)
+			if tt.expected.err != nil {
+				require.ErrorIs(t, err, tt.expected.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected.replayed, result.Replayed)
+				require.Equal(t, tt.expected.diff, result.Diff)
+			}
+
+			require.Equal(t, tt.expected.processed, replayProcessor.processed)
+		})
+	}
+}
+
+func TestProjectionRebuilder_Rebuild_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := testRebuildConfig()
+	config.BatchSize = 0
+
+	rebuilder := NewProjectionRebuilder(config, "accounts", mock.NewMockProjectionRepository(ctrl), func(_ processor.OrchestratorRepository) Processor {
+		return mock.NewMockProcessor(ctrl)
+	})
+
+	_, err := rebuilder.Rebuild(context.Background(), RebuildOptions{})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}